@@ -16,16 +16,72 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// TenantServiceAddPlatformAdminBody defines model for TenantServiceAddPlatformAdminBody.
+type TenantServiceAddPlatformAdminBody struct {
+	UserId *string `json:"userId,omitempty"`
+}
+
+// TenantServiceAssignRoleBody defines model for TenantServiceAssignRoleBody.
+type TenantServiceAssignRoleBody struct {
+	UserId *string `json:"userId,omitempty"`
+}
+
+// TenantServiceCreateAPIKeyBody defines model for TenantServiceCreateAPIKeyBody.
+type TenantServiceCreateAPIKeyBody struct {
+	Name *string `json:"name,omitempty"`
+	Role *string `json:"role,omitempty"`
+}
+
+// TenantServiceCreateRoleBody defines model for TenantServiceCreateRoleBody.
+type TenantServiceCreateRoleBody struct {
+	Name        *string   `json:"name,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+// TenantServiceCreateWebhookSubscriptionBody defines model for TenantServiceCreateWebhookSubscriptionBody.
+type TenantServiceCreateWebhookSubscriptionBody struct {
+	EventTypes *[]string `json:"eventTypes,omitempty"`
+
+	// Secret The key of the HMAC-SHA256 signature of the deliveries.
+	Secret *string `json:"secret,omitempty"`
+
+	// Url An absolute http or https URL.
+	Url *string `json:"url,omitempty"`
+}
+
 // TenantServiceInviteMemberBody defines model for TenantServiceInviteMemberBody.
 type TenantServiceInviteMemberBody struct {
 	Email *string `json:"email,omitempty"`
-	Role  *string `json:"role,omitempty"`
+
+	// IdempotencyKey Optional key making retries of the same request safe, see also the Idempotency-Key header.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+	Role           *string `json:"role,omitempty"`
+}
+
+// TenantServiceLinkTenantToSupportGroupBody defines model for TenantServiceLinkTenantToSupportGroupBody.
+type TenantServiceLinkTenantToSupportGroupBody struct {
+	GroupId *string `json:"groupId,omitempty"`
 }
 
 // TenantServiceProvisionUserBody defines model for TenantServiceProvisionUserBody.
 type TenantServiceProvisionUserBody struct {
 	Email *string `json:"email,omitempty"`
-	Role  *string `json:"role,omitempty"`
+
+	// IdempotencyKey Optional key making retries of the same request safe, see also the Idempotency-Key header.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+	Role           *string `json:"role,omitempty"`
+}
+
+// TenantServiceReplayWebhookDeliveryBody defines model for TenantServiceReplayWebhookDeliveryBody.
+type TenantServiceReplayWebhookDeliveryBody = map[string]interface{}
+
+// TenantServiceResendInviteBody defines model for TenantServiceResendInviteBody.
+type TenantServiceResendInviteBody = map[string]interface{}
+
+// TenantServiceUpdateRoleBody defines model for TenantServiceUpdateRoleBody.
+type TenantServiceUpdateRoleBody struct {
+	// Permissions Replaces the permissions held by the role.
+	Permissions *[]string `json:"permissions,omitempty"`
 }
 
 // TenantServiceUpdateTenantBody defines model for TenantServiceUpdateTenantBody.
@@ -34,6 +90,10 @@ type TenantServiceUpdateTenantBody struct {
 		CreatedAt *string `json:"createdAt,omitempty"`
 		Enabled   *bool   `json:"enabled,omitempty"`
 		Name      *string `json:"name,omitempty"`
+
+		// Region The region the tenant is homed in, writes are only accepted there.
+		// Empty for tenants served by every region.
+		Region *string `json:"region,omitempty"`
 	} `json:"tenant,omitempty"`
 	UpdateMask *string `json:"updateMask,omitempty"`
 }
@@ -58,24 +118,117 @@ type RpcStatus struct {
 
 // TenantCreateTenantRequest defines model for tenantCreateTenantRequest.
 type TenantCreateTenantRequest struct {
-	Name *string `json:"name,omitempty"`
+	// IdempotencyKey Optional key making retries of the same request safe, see also the Idempotency-Key header.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+	Name           *string `json:"name,omitempty"`
+
+	// Region Optional region to home the tenant in.
+	Region *string `json:"region,omitempty"`
+}
+
+// TenantRunDiagnosticsRequest defines model for tenantRunDiagnosticsRequest.
+type TenantRunDiagnosticsRequest struct {
+	// Fix Anomaly categories to repair while scanning. Empty means report only.
+	Fix *[]string `json:"fix,omitempty"`
+}
+
+// TenantServiceListAuthzAuditParams defines parameters for TenantServiceListAuthzAudit.
+type TenantServiceListAuthzAuditParams struct {
+	// Actor actor, user and object narrow the entries down, empty matches any.
+	Actor  *string `form:"actor,omitempty" json:"actor,omitempty"`
+	User   *string `form:"user,omitempty" json:"user,omitempty"`
+	Object *string `form:"object,omitempty" json:"object,omitempty"`
+
+	// Since since is an RFC 3339 timestamp, older entries are left out.
+	Since     *string `form:"since,omitempty" json:"since,omitempty"`
+	PageSize  *int32  `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+}
+
+// TenantServiceListTenantsParams defines parameters for TenantServiceListTenants.
+type TenantServiceListTenantsParams struct {
+	// PageSize Maximum number of tenants to return, every tenant when 0.
+	PageSize *int32 `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// PageToken next_page_token of the previous page.
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+
+	// Enabled Only return the enabled, or disabled, tenants.
+	Enabled *bool `form:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// NameContains Only return the tenants whose name contains this text, ignoring case.
+	NameContains *string `form:"nameContains,omitempty" json:"nameContains,omitempty"`
+}
+
+// TenantServiceDeleteTenantParams defines parameters for TenantServiceDeleteTenant.
+type TenantServiceDeleteTenantParams struct {
+	// Strict Fail with NotFound instead of succeeding when the tenant does not exist.
+	Strict *bool `form:"strict,omitempty" json:"strict,omitempty"`
+}
+
+// TenantServiceListTenantUsersParams defines parameters for TenantServiceListTenantUsers.
+type TenantServiceListTenantUsersParams struct {
+	// SkipIdentityLookup Return the user IDs and roles only, without looking up the identities.
+	SkipIdentityLookup *bool `form:"skipIdentityLookup,omitempty" json:"skipIdentityLookup,omitempty"`
+
+	// PageSize Maximum number of users to return, every user when 0.
+	PageSize *int32 `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// PageToken next_page_token of the previous page.
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
 }
 
+// TenantServiceRemoveTenantUserParams defines parameters for TenantServiceRemoveTenantUser.
+type TenantServiceRemoveTenantUserParams struct {
+	// Strict Fail with NotFound instead of succeeding when the user is not a member.
+	Strict *bool `form:"strict,omitempty" json:"strict,omitempty"`
+}
+
+// TenantServiceAddPlatformAdminJSONRequestBody defines body for TenantServiceAddPlatformAdmin for application/json ContentType.
+type TenantServiceAddPlatformAdminJSONRequestBody = TenantServiceAddPlatformAdminBody
+
+// TenantServiceRunDiagnosticsJSONRequestBody defines body for TenantServiceRunDiagnostics for application/json ContentType.
+type TenantServiceRunDiagnosticsJSONRequestBody = TenantRunDiagnosticsRequest
+
 // TenantServiceCreateTenantJSONRequestBody defines body for TenantServiceCreateTenant for application/json ContentType.
 type TenantServiceCreateTenantJSONRequestBody = TenantCreateTenantRequest
 
 // TenantServiceUpdateTenantJSONRequestBody defines body for TenantServiceUpdateTenant for application/json ContentType.
 type TenantServiceUpdateTenantJSONRequestBody = TenantServiceUpdateTenantBody
 
+// TenantServiceCreateAPIKeyJSONRequestBody defines body for TenantServiceCreateAPIKey for application/json ContentType.
+type TenantServiceCreateAPIKeyJSONRequestBody = TenantServiceCreateAPIKeyBody
+
 // TenantServiceInviteMemberJSONRequestBody defines body for TenantServiceInviteMember for application/json ContentType.
 type TenantServiceInviteMemberJSONRequestBody = TenantServiceInviteMemberBody
 
+// TenantServiceResendInviteJSONRequestBody defines body for TenantServiceResendInvite for application/json ContentType.
+type TenantServiceResendInviteJSONRequestBody = TenantServiceResendInviteBody
+
+// TenantServiceCreateRoleJSONRequestBody defines body for TenantServiceCreateRole for application/json ContentType.
+type TenantServiceCreateRoleJSONRequestBody = TenantServiceCreateRoleBody
+
+// TenantServiceUpdateRoleJSONRequestBody defines body for TenantServiceUpdateRole for application/json ContentType.
+type TenantServiceUpdateRoleJSONRequestBody = TenantServiceUpdateRoleBody
+
+// TenantServiceAssignRoleJSONRequestBody defines body for TenantServiceAssignRole for application/json ContentType.
+type TenantServiceAssignRoleJSONRequestBody = TenantServiceAssignRoleBody
+
+// TenantServiceLinkTenantToSupportGroupJSONRequestBody defines body for TenantServiceLinkTenantToSupportGroup for application/json ContentType.
+type TenantServiceLinkTenantToSupportGroupJSONRequestBody = TenantServiceLinkTenantToSupportGroupBody
+
 // TenantServiceProvisionUserJSONRequestBody defines body for TenantServiceProvisionUser for application/json ContentType.
 type TenantServiceProvisionUserJSONRequestBody = TenantServiceProvisionUserBody
 
 // TenantServiceUpdateTenantUserJSONRequestBody defines body for TenantServiceUpdateTenantUser for application/json ContentType.
 type TenantServiceUpdateTenantUserJSONRequestBody = TenantServiceUpdateTenantUserBody
 
+// TenantServiceCreateWebhookSubscriptionJSONRequestBody defines body for TenantServiceCreateWebhookSubscription for application/json ContentType.
+type TenantServiceCreateWebhookSubscriptionJSONRequestBody = TenantServiceCreateWebhookSubscriptionBody
+
+// TenantServiceReplayWebhookDeliveryJSONRequestBody defines body for TenantServiceReplayWebhookDelivery for application/json ContentType.
+type TenantServiceReplayWebhookDeliveryJSONRequestBody = TenantServiceReplayWebhookDeliveryBody
+
 // Getter for additional properties for ProtobufAny. Returns the specified
 // element and whether it was found
 func (a ProtobufAny) Get(fieldName string) (value interface{}, found bool) {
@@ -217,11 +370,36 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// TenantServiceListAuthzAudit request
+	TenantServiceListAuthzAudit(ctx context.Context, params *TenantServiceListAuthzAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceListPlatformAdmins request
+	TenantServiceListPlatformAdmins(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceAddPlatformAdminWithBody request with any body
+	TenantServiceAddPlatformAdminWithBody(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceAddPlatformAdmin(ctx context.Context, groupId string, body TenantServiceAddPlatformAdminJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceRemovePlatformAdmin request
+	TenantServiceRemovePlatformAdmin(ctx context.Context, groupId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceRunDiagnosticsWithBody request with any body
+	TenantServiceRunDiagnosticsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceRunDiagnostics(ctx context.Context, body TenantServiceRunDiagnosticsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceWhoAmI request
+	TenantServiceWhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// TenantServiceListMyTenants request
 	TenantServiceListMyTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TenantServiceGetMyPermissions request
+	TenantServiceGetMyPermissions(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// TenantServiceListTenants request
-	TenantServiceListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	TenantServiceListTenants(ctx context.Context, params *TenantServiceListTenantsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// TenantServiceCreateTenantWithBody request with any body
 	TenantServiceCreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -234,32 +412,111 @@ type ClientInterface interface {
 	TenantServiceUpdateTenant(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// TenantServiceDeleteTenant request
-	TenantServiceDeleteTenant(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	TenantServiceDeleteTenant(ctx context.Context, tenantId string, params *TenantServiceDeleteTenantParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceListAPIKeys request
+	TenantServiceListAPIKeys(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceCreateAPIKeyWithBody request with any body
+	TenantServiceCreateAPIKeyWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceCreateAPIKey(ctx context.Context, tenantId string, body TenantServiceCreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceRevokeAPIKey request
+	TenantServiceRevokeAPIKey(ctx context.Context, tenantId string, apiKeyId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceListInvites request
+	TenantServiceListInvites(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// TenantServiceInviteMemberWithBody request with any body
 	TenantServiceInviteMemberWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	TenantServiceInviteMember(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TenantServiceRevokeInvite request
+	TenantServiceRevokeInvite(ctx context.Context, tenantId string, inviteId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceResendInviteWithBody request with any body
+	TenantServiceResendInviteWithBody(ctx context.Context, tenantId string, inviteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceResendInvite(ctx context.Context, tenantId string, inviteId string, body TenantServiceResendInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceListRoles request
+	TenantServiceListRoles(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceCreateRoleWithBody request with any body
+	TenantServiceCreateRoleWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceCreateRole(ctx context.Context, tenantId string, body TenantServiceCreateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceDeleteRole request
+	TenantServiceDeleteRole(ctx context.Context, tenantId string, roleId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceUpdateRoleWithBody request with any body
+	TenantServiceUpdateRoleWithBody(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceUpdateRole(ctx context.Context, tenantId string, roleId string, body TenantServiceUpdateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceAssignRoleWithBody request with any body
+	TenantServiceAssignRoleWithBody(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceAssignRole(ctx context.Context, tenantId string, roleId string, body TenantServiceAssignRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceUnassignRole request
+	TenantServiceUnassignRole(ctx context.Context, tenantId string, roleId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceGetTenantStats request
+	TenantServiceGetTenantStats(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceLinkTenantToSupportGroupWithBody request with any body
+	TenantServiceLinkTenantToSupportGroupWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceLinkTenantToSupportGroup(ctx context.Context, tenantId string, body TenantServiceLinkTenantToSupportGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// TenantServiceListTenantUsers request
-	TenantServiceListTenantUsers(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	TenantServiceListTenantUsers(ctx context.Context, tenantId string, params *TenantServiceListTenantUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// TenantServiceProvisionUserWithBody request with any body
 	TenantServiceProvisionUserWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	TenantServiceProvisionUser(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TenantServiceRemoveTenantUser request
+	TenantServiceRemoveTenantUser(ctx context.Context, tenantId string, userId string, params *TenantServiceRemoveTenantUserParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceGetTenantUser request
+	TenantServiceGetTenantUser(ctx context.Context, tenantId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// TenantServiceUpdateTenantUserWithBody request with any body
 	TenantServiceUpdateTenantUserWithBody(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	TenantServiceUpdateTenantUser(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TenantServiceListWebhookSubscriptions request
+	TenantServiceListWebhookSubscriptions(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceCreateWebhookSubscriptionWithBody request with any body
+	TenantServiceCreateWebhookSubscriptionWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceCreateWebhookSubscription(ctx context.Context, tenantId string, body TenantServiceCreateWebhookSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceDeleteWebhookSubscription request
+	TenantServiceDeleteWebhookSubscription(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceListWebhookDeliveries request
+	TenantServiceListWebhookDeliveries(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TenantServiceReplayWebhookDeliveryWithBody request with any body
+	TenantServiceReplayWebhookDeliveryWithBody(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TenantServiceReplayWebhookDelivery(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, body TenantServiceReplayWebhookDeliveryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// TenantServiceListUserTenants request
 	TenantServiceListUserTenants(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) TenantServiceListMyTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceListMyTenantsRequest(c.Server)
+func (c *Client) TenantServiceListAuthzAudit(ctx context.Context, params *TenantServiceListAuthzAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListAuthzAuditRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -270,8 +527,8 @@ func (c *Client) TenantServiceListMyTenants(ctx context.Context, reqEditors ...R
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceListTenantsRequest(c.Server)
+func (c *Client) TenantServiceListPlatformAdmins(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListPlatformAdminsRequest(c.Server, groupId)
 	if err != nil {
 		return nil, err
 	}
@@ -282,8 +539,8 @@ func (c *Client) TenantServiceListTenants(ctx context.Context, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceCreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceCreateTenantRequestWithBody(c.Server, contentType, body)
+func (c *Client) TenantServiceAddPlatformAdminWithBody(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceAddPlatformAdminRequestWithBody(c.Server, groupId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -294,8 +551,8 @@ func (c *Client) TenantServiceCreateTenantWithBody(ctx context.Context, contentT
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceCreateTenant(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceCreateTenantRequest(c.Server, body)
+func (c *Client) TenantServiceAddPlatformAdmin(ctx context.Context, groupId string, body TenantServiceAddPlatformAdminJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceAddPlatformAdminRequest(c.Server, groupId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -306,8 +563,8 @@ func (c *Client) TenantServiceCreateTenant(ctx context.Context, body TenantServi
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceUpdateTenantWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceUpdateTenantRequestWithBody(c.Server, tenantId, contentType, body)
+func (c *Client) TenantServiceRemovePlatformAdmin(ctx context.Context, groupId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRemovePlatformAdminRequest(c.Server, groupId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -318,8 +575,8 @@ func (c *Client) TenantServiceUpdateTenantWithBody(ctx context.Context, tenantId
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceUpdateTenant(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceUpdateTenantRequest(c.Server, tenantId, body)
+func (c *Client) TenantServiceRunDiagnosticsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRunDiagnosticsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -330,8 +587,8 @@ func (c *Client) TenantServiceUpdateTenant(ctx context.Context, tenantId string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceDeleteTenant(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceDeleteTenantRequest(c.Server, tenantId)
+func (c *Client) TenantServiceRunDiagnostics(ctx context.Context, body TenantServiceRunDiagnosticsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRunDiagnosticsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -342,8 +599,8 @@ func (c *Client) TenantServiceDeleteTenant(ctx context.Context, tenantId string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceInviteMemberWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceInviteMemberRequestWithBody(c.Server, tenantId, contentType, body)
+func (c *Client) TenantServiceWhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceWhoAmIRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -354,8 +611,8 @@ func (c *Client) TenantServiceInviteMemberWithBody(ctx context.Context, tenantId
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceInviteMember(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceInviteMemberRequest(c.Server, tenantId, body)
+func (c *Client) TenantServiceListMyTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListMyTenantsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -366,8 +623,8 @@ func (c *Client) TenantServiceInviteMember(ctx context.Context, tenantId string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceListTenantUsers(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceListTenantUsersRequest(c.Server, tenantId)
+func (c *Client) TenantServiceGetMyPermissions(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceGetMyPermissionsRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
@@ -378,8 +635,8 @@ func (c *Client) TenantServiceListTenantUsers(ctx context.Context, tenantId stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceProvisionUserWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceProvisionUserRequestWithBody(c.Server, tenantId, contentType, body)
+func (c *Client) TenantServiceListTenants(ctx context.Context, params *TenantServiceListTenantsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListTenantsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -390,8 +647,8 @@ func (c *Client) TenantServiceProvisionUserWithBody(ctx context.Context, tenantI
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceProvisionUser(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceProvisionUserRequest(c.Server, tenantId, body)
+func (c *Client) TenantServiceCreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateTenantRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -402,8 +659,8 @@ func (c *Client) TenantServiceProvisionUser(ctx context.Context, tenantId string
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceUpdateTenantUserWithBody(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceUpdateTenantUserRequestWithBody(c.Server, tenantId, userId, contentType, body)
+func (c *Client) TenantServiceCreateTenant(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateTenantRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -414,8 +671,8 @@ func (c *Client) TenantServiceUpdateTenantUserWithBody(ctx context.Context, tena
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceUpdateTenantUser(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceUpdateTenantUserRequest(c.Server, tenantId, userId, body)
+func (c *Client) TenantServiceUpdateTenantWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateTenantRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -426,8 +683,8 @@ func (c *Client) TenantServiceUpdateTenantUser(ctx context.Context, tenantId str
 	return c.Client.Do(req)
 }
 
-func (c *Client) TenantServiceListUserTenants(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTenantServiceListUserTenantsRequest(c.Server, userId)
+func (c *Client) TenantServiceUpdateTenant(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateTenantRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -438,840 +695,4512 @@ func (c *Client) TenantServiceListUserTenants(ctx context.Context, userId string
 	return c.Client.Do(req)
 }
 
-// NewTenantServiceListMyTenantsRequest generates requests for TenantServiceListMyTenants
-func NewTenantServiceListMyTenantsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceDeleteTenant(ctx context.Context, tenantId string, params *TenantServiceDeleteTenantParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceDeleteTenantRequest(c.Server, tenantId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/me/tenants")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceListAPIKeys(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListAPIKeysRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceListTenantsRequest generates requests for TenantServiceListTenants
-func NewTenantServiceListTenantsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceCreateAPIKeyWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateAPIKeyRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceCreateAPIKey(ctx context.Context, tenantId string, body TenantServiceCreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateAPIKeyRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceCreateTenantRequest calls the generic TenantServiceCreateTenant builder with application/json body
-func NewTenantServiceCreateTenantRequest(server string, body TenantServiceCreateTenantJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) TenantServiceRevokeAPIKey(ctx context.Context, tenantId string, apiKeyId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRevokeAPIKeyRequest(c.Server, tenantId, apiKeyId)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewTenantServiceCreateTenantRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceCreateTenantRequestWithBody generates requests for TenantServiceCreateTenant with any type of body
-func NewTenantServiceCreateTenantRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceListInvites(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListInvitesRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceInviteMemberWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceInviteMemberRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceUpdateTenantRequest calls the generic TenantServiceUpdateTenant builder with application/json body
-func NewTenantServiceUpdateTenantRequest(server string, tenantId string, body TenantServiceUpdateTenantJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) TenantServiceInviteMember(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceInviteMemberRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewTenantServiceUpdateTenantRequestWithBody(server, tenantId, "application/json", bodyReader)
-}
-
-// NewTenantServiceUpdateTenantRequestWithBody generates requests for TenantServiceUpdateTenant with any type of body
-func NewTenantServiceUpdateTenantRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenant.id", runtime.ParamLocationPath, tenantId)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceRevokeInvite(ctx context.Context, tenantId string, inviteId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRevokeInviteRequest(c.Server, tenantId, inviteId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceResendInviteWithBody(ctx context.Context, tenantId string, inviteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceResendInviteRequestWithBody(c.Server, tenantId, inviteId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceDeleteTenantRequest generates requests for TenantServiceDeleteTenant
-func NewTenantServiceDeleteTenantRequest(server string, tenantId string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+func (c *Client) TenantServiceResendInvite(ctx context.Context, tenantId string, inviteId string, body TenantServiceResendInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceResendInviteRequest(c.Server, tenantId, inviteId, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceListRoles(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListRolesRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceCreateRoleWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateRoleRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceInviteMemberRequest calls the generic TenantServiceInviteMember builder with application/json body
-func NewTenantServiceInviteMemberRequest(server string, tenantId string, body TenantServiceInviteMemberJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) TenantServiceCreateRole(ctx context.Context, tenantId string, body TenantServiceCreateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateRoleRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewTenantServiceInviteMemberRequestWithBody(server, tenantId, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceInviteMemberRequestWithBody generates requests for TenantServiceInviteMember with any type of body
-func NewTenantServiceInviteMemberRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+func (c *Client) TenantServiceDeleteRole(ctx context.Context, tenantId string, roleId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceDeleteRoleRequest(c.Server, tenantId, roleId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceUpdateRoleWithBody(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateRoleRequestWithBody(c.Server, tenantId, roleId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s/invites", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceUpdateRole(ctx context.Context, tenantId string, roleId string, body TenantServiceUpdateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateRoleRequest(c.Server, tenantId, roleId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceListTenantUsersRequest generates requests for TenantServiceListTenantUsers
-func NewTenantServiceListTenantUsersRequest(server string, tenantId string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+func (c *Client) TenantServiceAssignRoleWithBody(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceAssignRoleRequestWithBody(c.Server, tenantId, roleId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceAssignRole(ctx context.Context, tenantId string, roleId string, body TenantServiceAssignRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceAssignRoleRequest(c.Server, tenantId, roleId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceUnassignRole(ctx context.Context, tenantId string, roleId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUnassignRoleRequest(c.Server, tenantId, roleId, userId)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceProvisionUserRequest calls the generic TenantServiceProvisionUser builder with application/json body
-func NewTenantServiceProvisionUserRequest(server string, tenantId string, body TenantServiceProvisionUserJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) TenantServiceGetTenantStats(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceGetTenantStatsRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewTenantServiceProvisionUserRequestWithBody(server, tenantId, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceProvisionUserRequestWithBody generates requests for TenantServiceProvisionUser with any type of body
-func NewTenantServiceProvisionUserRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+func (c *Client) TenantServiceLinkTenantToSupportGroupWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceLinkTenantToSupportGroupRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceLinkTenantToSupportGroup(ctx context.Context, tenantId string, body TenantServiceLinkTenantToSupportGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceLinkTenantToSupportGroupRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceListTenantUsers(ctx context.Context, tenantId string, params *TenantServiceListTenantUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListTenantUsersRequest(c.Server, tenantId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceUpdateTenantUserRequest calls the generic TenantServiceUpdateTenantUser builder with application/json body
-func NewTenantServiceUpdateTenantUserRequest(server string, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) TenantServiceProvisionUserWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceProvisionUserRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewTenantServiceUpdateTenantUserRequestWithBody(server, tenantId, userId, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceUpdateTenantUserRequestWithBody generates requests for TenantServiceUpdateTenantUser with any type of body
-func NewTenantServiceUpdateTenantUserRequestWithBody(server string, tenantId string, userId string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+func (c *Client) TenantServiceProvisionUser(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceProvisionUserRequest(c.Server, tenantId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceRemoveTenantUser(ctx context.Context, tenantId string, userId string, params *TenantServiceRemoveTenantUserParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceRemoveTenantUserRequest(c.Server, tenantId, userId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceGetTenantUser(ctx context.Context, tenantId string, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceGetTenantUserRequest(c.Server, tenantId, userId)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewTenantServiceListUserTenantsRequest generates requests for TenantServiceListUserTenants
-func NewTenantServiceListUserTenantsRequest(server string, userId string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+func (c *Client) TenantServiceUpdateTenantUserWithBody(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateTenantUserRequestWithBody(c.Server, tenantId, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) TenantServiceUpdateTenantUser(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceUpdateTenantUserRequest(c.Server, tenantId, userId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v0/users/%s/tenants", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) TenantServiceListWebhookSubscriptions(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListWebhookSubscriptionsRequest(c.Server, tenantId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) TenantServiceCreateWebhookSubscriptionWithBody(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateWebhookSubscriptionRequestWithBody(c.Server, tenantId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) TenantServiceCreateWebhookSubscription(ctx context.Context, tenantId string, body TenantServiceCreateWebhookSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceCreateWebhookSubscriptionRequest(c.Server, tenantId, body)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
+	return c.Client.Do(req)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+func (c *Client) TenantServiceDeleteWebhookSubscription(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceDeleteWebhookSubscriptionRequest(c.Server, tenantId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) TenantServiceListWebhookDeliveries(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListWebhookDeliveriesRequest(c.Server, tenantId, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) TenantServiceReplayWebhookDeliveryWithBody(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceReplayWebhookDeliveryRequestWithBody(c.Server, tenantId, subscriptionId, deliveryId, contentType, body)
+	if err != nil {
+		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// TenantServiceListMyTenantsWithResponse request
-	TenantServiceListMyTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListMyTenantsResponse, error)
+func (c *Client) TenantServiceReplayWebhookDelivery(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, body TenantServiceReplayWebhookDeliveryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceReplayWebhookDeliveryRequest(c.Server, tenantId, subscriptionId, deliveryId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	// TenantServiceListTenantsWithResponse request
-	TenantServiceListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListTenantsResponse, error)
+func (c *Client) TenantServiceListUserTenants(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTenantServiceListUserTenantsRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	// TenantServiceCreateTenantWithBodyWithResponse request with any body
-	TenantServiceCreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error)
+// NewTenantServiceListAuthzAuditRequest generates requests for TenantServiceListAuthzAudit
+func NewTenantServiceListAuthzAuditRequest(server string, params *TenantServiceListAuthzAuditParams) (*http.Request, error) {
+	var err error
 
-	TenantServiceCreateTenantWithResponse(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// TenantServiceUpdateTenantWithBodyWithResponse request with any body
-	TenantServiceUpdateTenantWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error)
+	operationPath := fmt.Sprintf("/api/v0/admin/audit/authz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	TenantServiceUpdateTenantWithResponse(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// TenantServiceDeleteTenantWithResponse request
-	TenantServiceDeleteTenantWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteTenantResponse, error)
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	// TenantServiceInviteMemberWithBodyWithResponse request with any body
-	TenantServiceInviteMemberWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error)
+		if params.Actor != nil {
 
-	TenantServiceInviteMemberWithResponse(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// TenantServiceListTenantUsersWithResponse request
-	TenantServiceListTenantUsersWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListTenantUsersResponse, error)
+		}
 
-	// TenantServiceProvisionUserWithBodyWithResponse request with any body
-	TenantServiceProvisionUserWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error)
+		if params.User != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, *params.User); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	TenantServiceProvisionUserWithResponse(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error)
+		}
 
-	// TenantServiceUpdateTenantUserWithBodyWithResponse request with any body
-	TenantServiceUpdateTenantUserWithBodyWithResponse(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error)
+		if params.Object != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "object", runtime.ParamLocationQuery, *params.Object); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	TenantServiceUpdateTenantUserWithResponse(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error)
+		}
 
-	// TenantServiceListUserTenantsWithResponse request
-	TenantServiceListUserTenantsWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*TenantServiceListUserTenantsResponse, error)
-}
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-type TenantServiceListMyTenantsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
-}
+		}
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceListMyTenantsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageToken", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceListMyTenantsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type TenantServiceListTenantsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceListTenantsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewTenantServiceListPlatformAdminsRequest generates requests for TenantServiceListPlatformAdmins
+func NewTenantServiceListPlatformAdminsRequest(server string, groupId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupId", runtime.ParamLocationPath, groupId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceListTenantsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type TenantServiceCreateTenantResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
-}
+	operationPath := fmt.Sprintf("/api/v0/admin/support-groups/%s/admins", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceCreateTenantResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceCreateTenantResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type TenantServiceUpdateTenantResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceUpdateTenantResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewTenantServiceAddPlatformAdminRequest calls the generic TenantServiceAddPlatformAdmin builder with application/json body
+func NewTenantServiceAddPlatformAdminRequest(server string, groupId string, body TenantServiceAddPlatformAdminJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceAddPlatformAdminRequestWithBody(server, groupId, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceUpdateTenantResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+// NewTenantServiceAddPlatformAdminRequestWithBody generates requests for TenantServiceAddPlatformAdmin with any type of body
+func NewTenantServiceAddPlatformAdminRequestWithBody(server string, groupId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-type TenantServiceDeleteTenantResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
-}
+	var pathParam0 string
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceDeleteTenantResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupId", runtime.ParamLocationPath, groupId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceDeleteTenantResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type TenantServiceInviteMemberResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
-}
+	operationPath := fmt.Sprintf("/api/v0/admin/support-groups/%s/admins", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceInviteMemberResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceInviteMemberResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type TenantServiceListTenantUsersResponse struct {
-	Body         []byte
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceRemovePlatformAdminRequest generates requests for TenantServiceRemovePlatformAdmin
+func NewTenantServiceRemovePlatformAdminRequest(server string, groupId string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupId", runtime.ParamLocationPath, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/admin/support-groups/%s/admins/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceRunDiagnosticsRequest calls the generic TenantServiceRunDiagnostics builder with application/json body
+func NewTenantServiceRunDiagnosticsRequest(server string, body TenantServiceRunDiagnosticsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceRunDiagnosticsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTenantServiceRunDiagnosticsRequestWithBody generates requests for TenantServiceRunDiagnostics with any type of body
+func NewTenantServiceRunDiagnosticsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/diagnostics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceWhoAmIRequest generates requests for TenantServiceWhoAmI
+func NewTenantServiceWhoAmIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceListMyTenantsRequest generates requests for TenantServiceListMyTenants
+func NewTenantServiceListMyTenantsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/me/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceGetMyPermissionsRequest generates requests for TenantServiceGetMyPermissions
+func NewTenantServiceGetMyPermissionsRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/me/tenants/%s/permissions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceListTenantsRequest generates requests for TenantServiceListTenants
+func NewTenantServiceListTenantsRequest(server string, params *TenantServiceListTenantsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageToken", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Enabled != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "enabled", runtime.ParamLocationQuery, *params.Enabled); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.NameContains != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "nameContains", runtime.ParamLocationQuery, *params.NameContains); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceCreateTenantRequest calls the generic TenantServiceCreateTenant builder with application/json body
+func NewTenantServiceCreateTenantRequest(server string, body TenantServiceCreateTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceCreateTenantRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTenantServiceCreateTenantRequestWithBody generates requests for TenantServiceCreateTenant with any type of body
+func NewTenantServiceCreateTenantRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceUpdateTenantRequest calls the generic TenantServiceUpdateTenant builder with application/json body
+func NewTenantServiceUpdateTenantRequest(server string, tenantId string, body TenantServiceUpdateTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceUpdateTenantRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceUpdateTenantRequestWithBody generates requests for TenantServiceUpdateTenant with any type of body
+func NewTenantServiceUpdateTenantRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenant.id", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceDeleteTenantRequest generates requests for TenantServiceDeleteTenant
+func NewTenantServiceDeleteTenantRequest(server string, tenantId string, params *TenantServiceDeleteTenantParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Strict != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "strict", runtime.ParamLocationQuery, *params.Strict); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceListAPIKeysRequest generates requests for TenantServiceListAPIKeys
+func NewTenantServiceListAPIKeysRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/api-keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceCreateAPIKeyRequest calls the generic TenantServiceCreateAPIKey builder with application/json body
+func NewTenantServiceCreateAPIKeyRequest(server string, tenantId string, body TenantServiceCreateAPIKeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceCreateAPIKeyRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceCreateAPIKeyRequestWithBody generates requests for TenantServiceCreateAPIKey with any type of body
+func NewTenantServiceCreateAPIKeyRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/api-keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceRevokeAPIKeyRequest generates requests for TenantServiceRevokeAPIKey
+func NewTenantServiceRevokeAPIKeyRequest(server string, tenantId string, apiKeyId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "apiKeyId", runtime.ParamLocationPath, apiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/api-keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceListInvitesRequest generates requests for TenantServiceListInvites
+func NewTenantServiceListInvitesRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceInviteMemberRequest calls the generic TenantServiceInviteMember builder with application/json body
+func NewTenantServiceInviteMemberRequest(server string, tenantId string, body TenantServiceInviteMemberJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceInviteMemberRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceInviteMemberRequestWithBody generates requests for TenantServiceInviteMember with any type of body
+func NewTenantServiceInviteMemberRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceRevokeInviteRequest generates requests for TenantServiceRevokeInvite
+func NewTenantServiceRevokeInviteRequest(server string, tenantId string, inviteId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "inviteId", runtime.ParamLocationPath, inviteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/invites/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceResendInviteRequest calls the generic TenantServiceResendInvite builder with application/json body
+func NewTenantServiceResendInviteRequest(server string, tenantId string, inviteId string, body TenantServiceResendInviteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceResendInviteRequestWithBody(server, tenantId, inviteId, "application/json", bodyReader)
+}
+
+// NewTenantServiceResendInviteRequestWithBody generates requests for TenantServiceResendInvite with any type of body
+func NewTenantServiceResendInviteRequestWithBody(server string, tenantId string, inviteId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "inviteId", runtime.ParamLocationPath, inviteId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/invites/%s/resend", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceListRolesRequest generates requests for TenantServiceListRoles
+func NewTenantServiceListRolesRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceCreateRoleRequest calls the generic TenantServiceCreateRole builder with application/json body
+func NewTenantServiceCreateRoleRequest(server string, tenantId string, body TenantServiceCreateRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceCreateRoleRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceCreateRoleRequestWithBody generates requests for TenantServiceCreateRole with any type of body
+func NewTenantServiceCreateRoleRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceDeleteRoleRequest generates requests for TenantServiceDeleteRole
+func NewTenantServiceDeleteRoleRequest(server string, tenantId string, roleId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "roleId", runtime.ParamLocationPath, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceUpdateRoleRequest calls the generic TenantServiceUpdateRole builder with application/json body
+func NewTenantServiceUpdateRoleRequest(server string, tenantId string, roleId string, body TenantServiceUpdateRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceUpdateRoleRequestWithBody(server, tenantId, roleId, "application/json", bodyReader)
+}
+
+// NewTenantServiceUpdateRoleRequestWithBody generates requests for TenantServiceUpdateRole with any type of body
+func NewTenantServiceUpdateRoleRequestWithBody(server string, tenantId string, roleId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "roleId", runtime.ParamLocationPath, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceAssignRoleRequest calls the generic TenantServiceAssignRole builder with application/json body
+func NewTenantServiceAssignRoleRequest(server string, tenantId string, roleId string, body TenantServiceAssignRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceAssignRoleRequestWithBody(server, tenantId, roleId, "application/json", bodyReader)
+}
+
+// NewTenantServiceAssignRoleRequestWithBody generates requests for TenantServiceAssignRole with any type of body
+func NewTenantServiceAssignRoleRequestWithBody(server string, tenantId string, roleId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "roleId", runtime.ParamLocationPath, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles/%s/assignees", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceUnassignRoleRequest generates requests for TenantServiceUnassignRole
+func NewTenantServiceUnassignRoleRequest(server string, tenantId string, roleId string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "roleId", runtime.ParamLocationPath, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/roles/%s/assignees/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceGetTenantStatsRequest generates requests for TenantServiceGetTenantStats
+func NewTenantServiceGetTenantStatsRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceLinkTenantToSupportGroupRequest calls the generic TenantServiceLinkTenantToSupportGroup builder with application/json body
+func NewTenantServiceLinkTenantToSupportGroupRequest(server string, tenantId string, body TenantServiceLinkTenantToSupportGroupJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceLinkTenantToSupportGroupRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceLinkTenantToSupportGroupRequestWithBody generates requests for TenantServiceLinkTenantToSupportGroup with any type of body
+func NewTenantServiceLinkTenantToSupportGroupRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/support-groups", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceListTenantUsersRequest generates requests for TenantServiceListTenantUsers
+func NewTenantServiceListTenantUsersRequest(server string, tenantId string, params *TenantServiceListTenantUsersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SkipIdentityLookup != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "skipIdentityLookup", runtime.ParamLocationQuery, *params.SkipIdentityLookup); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageToken", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceProvisionUserRequest calls the generic TenantServiceProvisionUser builder with application/json body
+func NewTenantServiceProvisionUserRequest(server string, tenantId string, body TenantServiceProvisionUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceProvisionUserRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceProvisionUserRequestWithBody generates requests for TenantServiceProvisionUser with any type of body
+func NewTenantServiceProvisionUserRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceRemoveTenantUserRequest generates requests for TenantServiceRemoveTenantUser
+func NewTenantServiceRemoveTenantUserRequest(server string, tenantId string, userId string, params *TenantServiceRemoveTenantUserParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Strict != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "strict", runtime.ParamLocationQuery, *params.Strict); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceGetTenantUserRequest generates requests for TenantServiceGetTenantUser
+func NewTenantServiceGetTenantUserRequest(server string, tenantId string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceUpdateTenantUserRequest calls the generic TenantServiceUpdateTenantUser builder with application/json body
+func NewTenantServiceUpdateTenantUserRequest(server string, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceUpdateTenantUserRequestWithBody(server, tenantId, userId, "application/json", bodyReader)
+}
+
+// NewTenantServiceUpdateTenantUserRequestWithBody generates requests for TenantServiceUpdateTenantUser with any type of body
+func NewTenantServiceUpdateTenantUserRequestWithBody(server string, tenantId string, userId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/users/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceListWebhookSubscriptionsRequest generates requests for TenantServiceListWebhookSubscriptions
+func NewTenantServiceListWebhookSubscriptionsRequest(server string, tenantId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/webhook-subscriptions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceCreateWebhookSubscriptionRequest calls the generic TenantServiceCreateWebhookSubscription builder with application/json body
+func NewTenantServiceCreateWebhookSubscriptionRequest(server string, tenantId string, body TenantServiceCreateWebhookSubscriptionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceCreateWebhookSubscriptionRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewTenantServiceCreateWebhookSubscriptionRequestWithBody generates requests for TenantServiceCreateWebhookSubscription with any type of body
+func NewTenantServiceCreateWebhookSubscriptionRequestWithBody(server string, tenantId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/webhook-subscriptions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceDeleteWebhookSubscriptionRequest generates requests for TenantServiceDeleteWebhookSubscription
+func NewTenantServiceDeleteWebhookSubscriptionRequest(server string, tenantId string, subscriptionId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/webhook-subscriptions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceListWebhookDeliveriesRequest generates requests for TenantServiceListWebhookDeliveries
+func NewTenantServiceListWebhookDeliveriesRequest(server string, tenantId string, subscriptionId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/webhook-subscriptions/%s/deliveries", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTenantServiceReplayWebhookDeliveryRequest calls the generic TenantServiceReplayWebhookDelivery builder with application/json body
+func NewTenantServiceReplayWebhookDeliveryRequest(server string, tenantId string, subscriptionId string, deliveryId string, body TenantServiceReplayWebhookDeliveryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTenantServiceReplayWebhookDeliveryRequestWithBody(server, tenantId, subscriptionId, deliveryId, "application/json", bodyReader)
+}
+
+// NewTenantServiceReplayWebhookDeliveryRequestWithBody generates requests for TenantServiceReplayWebhookDelivery with any type of body
+func NewTenantServiceReplayWebhookDeliveryRequestWithBody(server string, tenantId string, subscriptionId string, deliveryId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tenantId", runtime.ParamLocationPath, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subscriptionId", runtime.ParamLocationPath, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "deliveryId", runtime.ParamLocationPath, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/tenants/%s/webhook-subscriptions/%s/deliveries/%s/replay", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTenantServiceListUserTenantsRequest generates requests for TenantServiceListUserTenants
+func NewTenantServiceListUserTenantsRequest(server string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v0/users/%s/tenants", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// TenantServiceListAuthzAuditWithResponse request
+	TenantServiceListAuthzAuditWithResponse(ctx context.Context, params *TenantServiceListAuthzAuditParams, reqEditors ...RequestEditorFn) (*TenantServiceListAuthzAuditResponse, error)
+
+	// TenantServiceListPlatformAdminsWithResponse request
+	TenantServiceListPlatformAdminsWithResponse(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*TenantServiceListPlatformAdminsResponse, error)
+
+	// TenantServiceAddPlatformAdminWithBodyWithResponse request with any body
+	TenantServiceAddPlatformAdminWithBodyWithResponse(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceAddPlatformAdminResponse, error)
+
+	TenantServiceAddPlatformAdminWithResponse(ctx context.Context, groupId string, body TenantServiceAddPlatformAdminJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceAddPlatformAdminResponse, error)
+
+	// TenantServiceRemovePlatformAdminWithResponse request
+	TenantServiceRemovePlatformAdminWithResponse(ctx context.Context, groupId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceRemovePlatformAdminResponse, error)
+
+	// TenantServiceRunDiagnosticsWithBodyWithResponse request with any body
+	TenantServiceRunDiagnosticsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceRunDiagnosticsResponse, error)
+
+	TenantServiceRunDiagnosticsWithResponse(ctx context.Context, body TenantServiceRunDiagnosticsJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceRunDiagnosticsResponse, error)
+
+	// TenantServiceWhoAmIWithResponse request
+	TenantServiceWhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceWhoAmIResponse, error)
+
+	// TenantServiceListMyTenantsWithResponse request
+	TenantServiceListMyTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListMyTenantsResponse, error)
+
+	// TenantServiceGetMyPermissionsWithResponse request
+	TenantServiceGetMyPermissionsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceGetMyPermissionsResponse, error)
+
+	// TenantServiceListTenantsWithResponse request
+	TenantServiceListTenantsWithResponse(ctx context.Context, params *TenantServiceListTenantsParams, reqEditors ...RequestEditorFn) (*TenantServiceListTenantsResponse, error)
+
+	// TenantServiceCreateTenantWithBodyWithResponse request with any body
+	TenantServiceCreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error)
+
+	TenantServiceCreateTenantWithResponse(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error)
+
+	// TenantServiceUpdateTenantWithBodyWithResponse request with any body
+	TenantServiceUpdateTenantWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error)
+
+	TenantServiceUpdateTenantWithResponse(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error)
+
+	// TenantServiceDeleteTenantWithResponse request
+	TenantServiceDeleteTenantWithResponse(ctx context.Context, tenantId string, params *TenantServiceDeleteTenantParams, reqEditors ...RequestEditorFn) (*TenantServiceDeleteTenantResponse, error)
+
+	// TenantServiceListAPIKeysWithResponse request
+	TenantServiceListAPIKeysWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListAPIKeysResponse, error)
+
+	// TenantServiceCreateAPIKeyWithBodyWithResponse request with any body
+	TenantServiceCreateAPIKeyWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateAPIKeyResponse, error)
+
+	TenantServiceCreateAPIKeyWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateAPIKeyResponse, error)
+
+	// TenantServiceRevokeAPIKeyWithResponse request
+	TenantServiceRevokeAPIKeyWithResponse(ctx context.Context, tenantId string, apiKeyId string, reqEditors ...RequestEditorFn) (*TenantServiceRevokeAPIKeyResponse, error)
+
+	// TenantServiceListInvitesWithResponse request
+	TenantServiceListInvitesWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListInvitesResponse, error)
+
+	// TenantServiceInviteMemberWithBodyWithResponse request with any body
+	TenantServiceInviteMemberWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error)
+
+	TenantServiceInviteMemberWithResponse(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error)
+
+	// TenantServiceRevokeInviteWithResponse request
+	TenantServiceRevokeInviteWithResponse(ctx context.Context, tenantId string, inviteId string, reqEditors ...RequestEditorFn) (*TenantServiceRevokeInviteResponse, error)
+
+	// TenantServiceResendInviteWithBodyWithResponse request with any body
+	TenantServiceResendInviteWithBodyWithResponse(ctx context.Context, tenantId string, inviteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceResendInviteResponse, error)
+
+	TenantServiceResendInviteWithResponse(ctx context.Context, tenantId string, inviteId string, body TenantServiceResendInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceResendInviteResponse, error)
+
+	// TenantServiceListRolesWithResponse request
+	TenantServiceListRolesWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListRolesResponse, error)
+
+	// TenantServiceCreateRoleWithBodyWithResponse request with any body
+	TenantServiceCreateRoleWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateRoleResponse, error)
+
+	TenantServiceCreateRoleWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateRoleResponse, error)
+
+	// TenantServiceDeleteRoleWithResponse request
+	TenantServiceDeleteRoleWithResponse(ctx context.Context, tenantId string, roleId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteRoleResponse, error)
+
+	// TenantServiceUpdateRoleWithBodyWithResponse request with any body
+	TenantServiceUpdateRoleWithBodyWithResponse(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateRoleResponse, error)
+
+	TenantServiceUpdateRoleWithResponse(ctx context.Context, tenantId string, roleId string, body TenantServiceUpdateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateRoleResponse, error)
+
+	// TenantServiceAssignRoleWithBodyWithResponse request with any body
+	TenantServiceAssignRoleWithBodyWithResponse(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceAssignRoleResponse, error)
+
+	TenantServiceAssignRoleWithResponse(ctx context.Context, tenantId string, roleId string, body TenantServiceAssignRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceAssignRoleResponse, error)
+
+	// TenantServiceUnassignRoleWithResponse request
+	TenantServiceUnassignRoleWithResponse(ctx context.Context, tenantId string, roleId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceUnassignRoleResponse, error)
+
+	// TenantServiceGetTenantStatsWithResponse request
+	TenantServiceGetTenantStatsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceGetTenantStatsResponse, error)
+
+	// TenantServiceLinkTenantToSupportGroupWithBodyWithResponse request with any body
+	TenantServiceLinkTenantToSupportGroupWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceLinkTenantToSupportGroupResponse, error)
+
+	TenantServiceLinkTenantToSupportGroupWithResponse(ctx context.Context, tenantId string, body TenantServiceLinkTenantToSupportGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceLinkTenantToSupportGroupResponse, error)
+
+	// TenantServiceListTenantUsersWithResponse request
+	TenantServiceListTenantUsersWithResponse(ctx context.Context, tenantId string, params *TenantServiceListTenantUsersParams, reqEditors ...RequestEditorFn) (*TenantServiceListTenantUsersResponse, error)
+
+	// TenantServiceProvisionUserWithBodyWithResponse request with any body
+	TenantServiceProvisionUserWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error)
+
+	TenantServiceProvisionUserWithResponse(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error)
+
+	// TenantServiceRemoveTenantUserWithResponse request
+	TenantServiceRemoveTenantUserWithResponse(ctx context.Context, tenantId string, userId string, params *TenantServiceRemoveTenantUserParams, reqEditors ...RequestEditorFn) (*TenantServiceRemoveTenantUserResponse, error)
+
+	// TenantServiceGetTenantUserWithResponse request
+	TenantServiceGetTenantUserWithResponse(ctx context.Context, tenantId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceGetTenantUserResponse, error)
+
+	// TenantServiceUpdateTenantUserWithBodyWithResponse request with any body
+	TenantServiceUpdateTenantUserWithBodyWithResponse(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error)
+
+	TenantServiceUpdateTenantUserWithResponse(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error)
+
+	// TenantServiceListWebhookSubscriptionsWithResponse request
+	TenantServiceListWebhookSubscriptionsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListWebhookSubscriptionsResponse, error)
+
+	// TenantServiceCreateWebhookSubscriptionWithBodyWithResponse request with any body
+	TenantServiceCreateWebhookSubscriptionWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateWebhookSubscriptionResponse, error)
+
+	TenantServiceCreateWebhookSubscriptionWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateWebhookSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateWebhookSubscriptionResponse, error)
+
+	// TenantServiceDeleteWebhookSubscriptionWithResponse request
+	TenantServiceDeleteWebhookSubscriptionWithResponse(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteWebhookSubscriptionResponse, error)
+
+	// TenantServiceListWebhookDeliveriesWithResponse request
+	TenantServiceListWebhookDeliveriesWithResponse(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*TenantServiceListWebhookDeliveriesResponse, error)
+
+	// TenantServiceReplayWebhookDeliveryWithBodyWithResponse request with any body
+	TenantServiceReplayWebhookDeliveryWithBodyWithResponse(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceReplayWebhookDeliveryResponse, error)
+
+	TenantServiceReplayWebhookDeliveryWithResponse(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, body TenantServiceReplayWebhookDeliveryJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceReplayWebhookDeliveryResponse, error)
+
+	// TenantServiceListUserTenantsWithResponse request
+	TenantServiceListUserTenantsWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*TenantServiceListUserTenantsResponse, error)
+}
+
+type TenantServiceListAuthzAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListAuthzAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListAuthzAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListPlatformAdminsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListPlatformAdminsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListPlatformAdminsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceAddPlatformAdminResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceAddPlatformAdminResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceAddPlatformAdminResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceRemovePlatformAdminResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceRemovePlatformAdminResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceRemovePlatformAdminResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceRunDiagnosticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceRunDiagnosticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceRunDiagnosticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceWhoAmIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceWhoAmIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceWhoAmIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListMyTenantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListMyTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListMyTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceGetMyPermissionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceGetMyPermissionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceGetMyPermissionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListTenantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceCreateTenantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceCreateTenantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceCreateTenantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceUpdateTenantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceUpdateTenantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceUpdateTenantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceDeleteTenantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceDeleteTenantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceDeleteTenantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListAPIKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListAPIKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListAPIKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceCreateAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceCreateAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceCreateAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceRevokeAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceRevokeAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceRevokeAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListInvitesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListInvitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListInvitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceInviteMemberResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceInviteMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceInviteMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceRevokeInviteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceRevokeInviteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceRevokeInviteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceResendInviteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceResendInviteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceResendInviteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListRolesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListRolesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListRolesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceCreateRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceCreateRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceCreateRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceDeleteRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceDeleteRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceDeleteRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceUpdateRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceUpdateRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceUpdateRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceAssignRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceAssignRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceAssignRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceUnassignRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceUnassignRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceUnassignRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceGetTenantStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceGetTenantStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceGetTenantStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceLinkTenantToSupportGroupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceLinkTenantToSupportGroupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceLinkTenantToSupportGroupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListTenantUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListTenantUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListTenantUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceProvisionUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceProvisionUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceProvisionUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceRemoveTenantUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceRemoveTenantUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceRemoveTenantUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceGetTenantUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceGetTenantUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceGetTenantUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceUpdateTenantUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceUpdateTenantUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceUpdateTenantUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListWebhookSubscriptionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListWebhookSubscriptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListWebhookSubscriptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceCreateWebhookSubscriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceCreateWebhookSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceCreateWebhookSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceDeleteWebhookSubscriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceDeleteWebhookSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceDeleteWebhookSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListWebhookDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceListWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceReplayWebhookDeliveryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *RpcStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r TenantServiceReplayWebhookDeliveryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceReplayWebhookDeliveryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TenantServiceListUserTenantsResponse struct {
+	Body         []byte
 	HTTPResponse *http.Response
 	JSONDefault  *RpcStatus
 }
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceListTenantUsersResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// Status returns HTTPResponse.Status
+func (r TenantServiceListUserTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TenantServiceListUserTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// TenantServiceListAuthzAuditWithResponse request returning *TenantServiceListAuthzAuditResponse
+func (c *ClientWithResponses) TenantServiceListAuthzAuditWithResponse(ctx context.Context, params *TenantServiceListAuthzAuditParams, reqEditors ...RequestEditorFn) (*TenantServiceListAuthzAuditResponse, error) {
+	rsp, err := c.TenantServiceListAuthzAudit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListAuthzAuditResponse(rsp)
+}
+
+// TenantServiceListPlatformAdminsWithResponse request returning *TenantServiceListPlatformAdminsResponse
+func (c *ClientWithResponses) TenantServiceListPlatformAdminsWithResponse(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*TenantServiceListPlatformAdminsResponse, error) {
+	rsp, err := c.TenantServiceListPlatformAdmins(ctx, groupId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListPlatformAdminsResponse(rsp)
+}
+
+// TenantServiceAddPlatformAdminWithBodyWithResponse request with arbitrary body returning *TenantServiceAddPlatformAdminResponse
+func (c *ClientWithResponses) TenantServiceAddPlatformAdminWithBodyWithResponse(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceAddPlatformAdminResponse, error) {
+	rsp, err := c.TenantServiceAddPlatformAdminWithBody(ctx, groupId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceAddPlatformAdminResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceAddPlatformAdminWithResponse(ctx context.Context, groupId string, body TenantServiceAddPlatformAdminJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceAddPlatformAdminResponse, error) {
+	rsp, err := c.TenantServiceAddPlatformAdmin(ctx, groupId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceAddPlatformAdminResponse(rsp)
+}
+
+// TenantServiceRemovePlatformAdminWithResponse request returning *TenantServiceRemovePlatformAdminResponse
+func (c *ClientWithResponses) TenantServiceRemovePlatformAdminWithResponse(ctx context.Context, groupId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceRemovePlatformAdminResponse, error) {
+	rsp, err := c.TenantServiceRemovePlatformAdmin(ctx, groupId, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRemovePlatformAdminResponse(rsp)
+}
+
+// TenantServiceRunDiagnosticsWithBodyWithResponse request with arbitrary body returning *TenantServiceRunDiagnosticsResponse
+func (c *ClientWithResponses) TenantServiceRunDiagnosticsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceRunDiagnosticsResponse, error) {
+	rsp, err := c.TenantServiceRunDiagnosticsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRunDiagnosticsResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceRunDiagnosticsWithResponse(ctx context.Context, body TenantServiceRunDiagnosticsJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceRunDiagnosticsResponse, error) {
+	rsp, err := c.TenantServiceRunDiagnostics(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRunDiagnosticsResponse(rsp)
+}
+
+// TenantServiceWhoAmIWithResponse request returning *TenantServiceWhoAmIResponse
+func (c *ClientWithResponses) TenantServiceWhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceWhoAmIResponse, error) {
+	rsp, err := c.TenantServiceWhoAmI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceWhoAmIResponse(rsp)
+}
+
+// TenantServiceListMyTenantsWithResponse request returning *TenantServiceListMyTenantsResponse
+func (c *ClientWithResponses) TenantServiceListMyTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListMyTenantsResponse, error) {
+	rsp, err := c.TenantServiceListMyTenants(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListMyTenantsResponse(rsp)
+}
+
+// TenantServiceGetMyPermissionsWithResponse request returning *TenantServiceGetMyPermissionsResponse
+func (c *ClientWithResponses) TenantServiceGetMyPermissionsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceGetMyPermissionsResponse, error) {
+	rsp, err := c.TenantServiceGetMyPermissions(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceGetMyPermissionsResponse(rsp)
+}
+
+// TenantServiceListTenantsWithResponse request returning *TenantServiceListTenantsResponse
+func (c *ClientWithResponses) TenantServiceListTenantsWithResponse(ctx context.Context, params *TenantServiceListTenantsParams, reqEditors ...RequestEditorFn) (*TenantServiceListTenantsResponse, error) {
+	rsp, err := c.TenantServiceListTenants(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListTenantsResponse(rsp)
+}
+
+// TenantServiceCreateTenantWithBodyWithResponse request with arbitrary body returning *TenantServiceCreateTenantResponse
+func (c *ClientWithResponses) TenantServiceCreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error) {
+	rsp, err := c.TenantServiceCreateTenantWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateTenantResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceCreateTenantWithResponse(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error) {
+	rsp, err := c.TenantServiceCreateTenant(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateTenantResponse(rsp)
+}
+
+// TenantServiceUpdateTenantWithBodyWithResponse request with arbitrary body returning *TenantServiceUpdateTenantResponse
+func (c *ClientWithResponses) TenantServiceUpdateTenantWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error) {
+	rsp, err := c.TenantServiceUpdateTenantWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateTenantResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceUpdateTenantWithResponse(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error) {
+	rsp, err := c.TenantServiceUpdateTenant(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateTenantResponse(rsp)
+}
+
+// TenantServiceDeleteTenantWithResponse request returning *TenantServiceDeleteTenantResponse
+func (c *ClientWithResponses) TenantServiceDeleteTenantWithResponse(ctx context.Context, tenantId string, params *TenantServiceDeleteTenantParams, reqEditors ...RequestEditorFn) (*TenantServiceDeleteTenantResponse, error) {
+	rsp, err := c.TenantServiceDeleteTenant(ctx, tenantId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceDeleteTenantResponse(rsp)
+}
+
+// TenantServiceListAPIKeysWithResponse request returning *TenantServiceListAPIKeysResponse
+func (c *ClientWithResponses) TenantServiceListAPIKeysWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListAPIKeysResponse, error) {
+	rsp, err := c.TenantServiceListAPIKeys(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListAPIKeysResponse(rsp)
+}
+
+// TenantServiceCreateAPIKeyWithBodyWithResponse request with arbitrary body returning *TenantServiceCreateAPIKeyResponse
+func (c *ClientWithResponses) TenantServiceCreateAPIKeyWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateAPIKeyResponse, error) {
+	rsp, err := c.TenantServiceCreateAPIKeyWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateAPIKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceCreateAPIKeyWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateAPIKeyResponse, error) {
+	rsp, err := c.TenantServiceCreateAPIKey(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateAPIKeyResponse(rsp)
+}
+
+// TenantServiceRevokeAPIKeyWithResponse request returning *TenantServiceRevokeAPIKeyResponse
+func (c *ClientWithResponses) TenantServiceRevokeAPIKeyWithResponse(ctx context.Context, tenantId string, apiKeyId string, reqEditors ...RequestEditorFn) (*TenantServiceRevokeAPIKeyResponse, error) {
+	rsp, err := c.TenantServiceRevokeAPIKey(ctx, tenantId, apiKeyId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRevokeAPIKeyResponse(rsp)
+}
+
+// TenantServiceListInvitesWithResponse request returning *TenantServiceListInvitesResponse
+func (c *ClientWithResponses) TenantServiceListInvitesWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListInvitesResponse, error) {
+	rsp, err := c.TenantServiceListInvites(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListInvitesResponse(rsp)
+}
+
+// TenantServiceInviteMemberWithBodyWithResponse request with arbitrary body returning *TenantServiceInviteMemberResponse
+func (c *ClientWithResponses) TenantServiceInviteMemberWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error) {
+	rsp, err := c.TenantServiceInviteMemberWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceInviteMemberResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceInviteMemberWithResponse(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error) {
+	rsp, err := c.TenantServiceInviteMember(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceInviteMemberResponse(rsp)
+}
+
+// TenantServiceRevokeInviteWithResponse request returning *TenantServiceRevokeInviteResponse
+func (c *ClientWithResponses) TenantServiceRevokeInviteWithResponse(ctx context.Context, tenantId string, inviteId string, reqEditors ...RequestEditorFn) (*TenantServiceRevokeInviteResponse, error) {
+	rsp, err := c.TenantServiceRevokeInvite(ctx, tenantId, inviteId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRevokeInviteResponse(rsp)
+}
+
+// TenantServiceResendInviteWithBodyWithResponse request with arbitrary body returning *TenantServiceResendInviteResponse
+func (c *ClientWithResponses) TenantServiceResendInviteWithBodyWithResponse(ctx context.Context, tenantId string, inviteId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceResendInviteResponse, error) {
+	rsp, err := c.TenantServiceResendInviteWithBody(ctx, tenantId, inviteId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceResendInviteResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceResendInviteWithResponse(ctx context.Context, tenantId string, inviteId string, body TenantServiceResendInviteJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceResendInviteResponse, error) {
+	rsp, err := c.TenantServiceResendInvite(ctx, tenantId, inviteId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceResendInviteResponse(rsp)
+}
+
+// TenantServiceListRolesWithResponse request returning *TenantServiceListRolesResponse
+func (c *ClientWithResponses) TenantServiceListRolesWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListRolesResponse, error) {
+	rsp, err := c.TenantServiceListRoles(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListRolesResponse(rsp)
+}
+
+// TenantServiceCreateRoleWithBodyWithResponse request with arbitrary body returning *TenantServiceCreateRoleResponse
+func (c *ClientWithResponses) TenantServiceCreateRoleWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateRoleResponse, error) {
+	rsp, err := c.TenantServiceCreateRoleWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceCreateRoleWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateRoleResponse, error) {
+	rsp, err := c.TenantServiceCreateRole(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateRoleResponse(rsp)
+}
+
+// TenantServiceDeleteRoleWithResponse request returning *TenantServiceDeleteRoleResponse
+func (c *ClientWithResponses) TenantServiceDeleteRoleWithResponse(ctx context.Context, tenantId string, roleId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteRoleResponse, error) {
+	rsp, err := c.TenantServiceDeleteRole(ctx, tenantId, roleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceDeleteRoleResponse(rsp)
+}
+
+// TenantServiceUpdateRoleWithBodyWithResponse request with arbitrary body returning *TenantServiceUpdateRoleResponse
+func (c *ClientWithResponses) TenantServiceUpdateRoleWithBodyWithResponse(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateRoleResponse, error) {
+	rsp, err := c.TenantServiceUpdateRoleWithBody(ctx, tenantId, roleId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceUpdateRoleWithResponse(ctx context.Context, tenantId string, roleId string, body TenantServiceUpdateRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateRoleResponse, error) {
+	rsp, err := c.TenantServiceUpdateRole(ctx, tenantId, roleId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateRoleResponse(rsp)
+}
+
+// TenantServiceAssignRoleWithBodyWithResponse request with arbitrary body returning *TenantServiceAssignRoleResponse
+func (c *ClientWithResponses) TenantServiceAssignRoleWithBodyWithResponse(ctx context.Context, tenantId string, roleId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceAssignRoleResponse, error) {
+	rsp, err := c.TenantServiceAssignRoleWithBody(ctx, tenantId, roleId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceAssignRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceAssignRoleWithResponse(ctx context.Context, tenantId string, roleId string, body TenantServiceAssignRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceAssignRoleResponse, error) {
+	rsp, err := c.TenantServiceAssignRole(ctx, tenantId, roleId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceAssignRoleResponse(rsp)
+}
+
+// TenantServiceUnassignRoleWithResponse request returning *TenantServiceUnassignRoleResponse
+func (c *ClientWithResponses) TenantServiceUnassignRoleWithResponse(ctx context.Context, tenantId string, roleId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceUnassignRoleResponse, error) {
+	rsp, err := c.TenantServiceUnassignRole(ctx, tenantId, roleId, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUnassignRoleResponse(rsp)
+}
+
+// TenantServiceGetTenantStatsWithResponse request returning *TenantServiceGetTenantStatsResponse
+func (c *ClientWithResponses) TenantServiceGetTenantStatsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceGetTenantStatsResponse, error) {
+	rsp, err := c.TenantServiceGetTenantStats(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceGetTenantStatsResponse(rsp)
+}
+
+// TenantServiceLinkTenantToSupportGroupWithBodyWithResponse request with arbitrary body returning *TenantServiceLinkTenantToSupportGroupResponse
+func (c *ClientWithResponses) TenantServiceLinkTenantToSupportGroupWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceLinkTenantToSupportGroupResponse, error) {
+	rsp, err := c.TenantServiceLinkTenantToSupportGroupWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceLinkTenantToSupportGroupResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceLinkTenantToSupportGroupWithResponse(ctx context.Context, tenantId string, body TenantServiceLinkTenantToSupportGroupJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceLinkTenantToSupportGroupResponse, error) {
+	rsp, err := c.TenantServiceLinkTenantToSupportGroup(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceLinkTenantToSupportGroupResponse(rsp)
+}
+
+// TenantServiceListTenantUsersWithResponse request returning *TenantServiceListTenantUsersResponse
+func (c *ClientWithResponses) TenantServiceListTenantUsersWithResponse(ctx context.Context, tenantId string, params *TenantServiceListTenantUsersParams, reqEditors ...RequestEditorFn) (*TenantServiceListTenantUsersResponse, error) {
+	rsp, err := c.TenantServiceListTenantUsers(ctx, tenantId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListTenantUsersResponse(rsp)
+}
+
+// TenantServiceProvisionUserWithBodyWithResponse request with arbitrary body returning *TenantServiceProvisionUserResponse
+func (c *ClientWithResponses) TenantServiceProvisionUserWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error) {
+	rsp, err := c.TenantServiceProvisionUserWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceProvisionUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceProvisionUserWithResponse(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error) {
+	rsp, err := c.TenantServiceProvisionUser(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceProvisionUserResponse(rsp)
+}
+
+// TenantServiceRemoveTenantUserWithResponse request returning *TenantServiceRemoveTenantUserResponse
+func (c *ClientWithResponses) TenantServiceRemoveTenantUserWithResponse(ctx context.Context, tenantId string, userId string, params *TenantServiceRemoveTenantUserParams, reqEditors ...RequestEditorFn) (*TenantServiceRemoveTenantUserResponse, error) {
+	rsp, err := c.TenantServiceRemoveTenantUser(ctx, tenantId, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceRemoveTenantUserResponse(rsp)
+}
+
+// TenantServiceGetTenantUserWithResponse request returning *TenantServiceGetTenantUserResponse
+func (c *ClientWithResponses) TenantServiceGetTenantUserWithResponse(ctx context.Context, tenantId string, userId string, reqEditors ...RequestEditorFn) (*TenantServiceGetTenantUserResponse, error) {
+	rsp, err := c.TenantServiceGetTenantUser(ctx, tenantId, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceGetTenantUserResponse(rsp)
+}
+
+// TenantServiceUpdateTenantUserWithBodyWithResponse request with arbitrary body returning *TenantServiceUpdateTenantUserResponse
+func (c *ClientWithResponses) TenantServiceUpdateTenantUserWithBodyWithResponse(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error) {
+	rsp, err := c.TenantServiceUpdateTenantUserWithBody(ctx, tenantId, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateTenantUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceUpdateTenantUserWithResponse(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error) {
+	rsp, err := c.TenantServiceUpdateTenantUser(ctx, tenantId, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceUpdateTenantUserResponse(rsp)
+}
+
+// TenantServiceListWebhookSubscriptionsWithResponse request returning *TenantServiceListWebhookSubscriptionsResponse
+func (c *ClientWithResponses) TenantServiceListWebhookSubscriptionsWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListWebhookSubscriptionsResponse, error) {
+	rsp, err := c.TenantServiceListWebhookSubscriptions(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListWebhookSubscriptionsResponse(rsp)
+}
+
+// TenantServiceCreateWebhookSubscriptionWithBodyWithResponse request with arbitrary body returning *TenantServiceCreateWebhookSubscriptionResponse
+func (c *ClientWithResponses) TenantServiceCreateWebhookSubscriptionWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateWebhookSubscriptionResponse, error) {
+	rsp, err := c.TenantServiceCreateWebhookSubscriptionWithBody(ctx, tenantId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateWebhookSubscriptionResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceCreateWebhookSubscriptionWithResponse(ctx context.Context, tenantId string, body TenantServiceCreateWebhookSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateWebhookSubscriptionResponse, error) {
+	rsp, err := c.TenantServiceCreateWebhookSubscription(ctx, tenantId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceCreateWebhookSubscriptionResponse(rsp)
+}
+
+// TenantServiceDeleteWebhookSubscriptionWithResponse request returning *TenantServiceDeleteWebhookSubscriptionResponse
+func (c *ClientWithResponses) TenantServiceDeleteWebhookSubscriptionWithResponse(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteWebhookSubscriptionResponse, error) {
+	rsp, err := c.TenantServiceDeleteWebhookSubscription(ctx, tenantId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceDeleteWebhookSubscriptionResponse(rsp)
+}
+
+// TenantServiceListWebhookDeliveriesWithResponse request returning *TenantServiceListWebhookDeliveriesResponse
+func (c *ClientWithResponses) TenantServiceListWebhookDeliveriesWithResponse(ctx context.Context, tenantId string, subscriptionId string, reqEditors ...RequestEditorFn) (*TenantServiceListWebhookDeliveriesResponse, error) {
+	rsp, err := c.TenantServiceListWebhookDeliveries(ctx, tenantId, subscriptionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListWebhookDeliveriesResponse(rsp)
+}
+
+// TenantServiceReplayWebhookDeliveryWithBodyWithResponse request with arbitrary body returning *TenantServiceReplayWebhookDeliveryResponse
+func (c *ClientWithResponses) TenantServiceReplayWebhookDeliveryWithBodyWithResponse(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceReplayWebhookDeliveryResponse, error) {
+	rsp, err := c.TenantServiceReplayWebhookDeliveryWithBody(ctx, tenantId, subscriptionId, deliveryId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceReplayWebhookDeliveryResponse(rsp)
+}
+
+func (c *ClientWithResponses) TenantServiceReplayWebhookDeliveryWithResponse(ctx context.Context, tenantId string, subscriptionId string, deliveryId string, body TenantServiceReplayWebhookDeliveryJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceReplayWebhookDeliveryResponse, error) {
+	rsp, err := c.TenantServiceReplayWebhookDelivery(ctx, tenantId, subscriptionId, deliveryId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceReplayWebhookDeliveryResponse(rsp)
+}
+
+// TenantServiceListUserTenantsWithResponse request returning *TenantServiceListUserTenantsResponse
+func (c *ClientWithResponses) TenantServiceListUserTenantsWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*TenantServiceListUserTenantsResponse, error) {
+	rsp, err := c.TenantServiceListUserTenants(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTenantServiceListUserTenantsResponse(rsp)
+}
+
+// ParseTenantServiceListAuthzAuditResponse parses an HTTP response from a TenantServiceListAuthzAuditWithResponse call
+func ParseTenantServiceListAuthzAuditResponse(rsp *http.Response) (*TenantServiceListAuthzAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListAuthzAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceListPlatformAdminsResponse parses an HTTP response from a TenantServiceListPlatformAdminsWithResponse call
+func ParseTenantServiceListPlatformAdminsResponse(rsp *http.Response) (*TenantServiceListPlatformAdminsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListPlatformAdminsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceAddPlatformAdminResponse parses an HTTP response from a TenantServiceAddPlatformAdminWithResponse call
+func ParseTenantServiceAddPlatformAdminResponse(rsp *http.Response) (*TenantServiceAddPlatformAdminResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceAddPlatformAdminResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceRemovePlatformAdminResponse parses an HTTP response from a TenantServiceRemovePlatformAdminWithResponse call
+func ParseTenantServiceRemovePlatformAdminResponse(rsp *http.Response) (*TenantServiceRemovePlatformAdminResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceRemovePlatformAdminResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceRunDiagnosticsResponse parses an HTTP response from a TenantServiceRunDiagnosticsWithResponse call
+func ParseTenantServiceRunDiagnosticsResponse(rsp *http.Response) (*TenantServiceRunDiagnosticsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceRunDiagnosticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceWhoAmIResponse parses an HTTP response from a TenantServiceWhoAmIWithResponse call
+func ParseTenantServiceWhoAmIResponse(rsp *http.Response) (*TenantServiceWhoAmIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceWhoAmIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceListMyTenantsResponse parses an HTTP response from a TenantServiceListMyTenantsWithResponse call
+func ParseTenantServiceListMyTenantsResponse(rsp *http.Response) (*TenantServiceListMyTenantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListMyTenantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceGetMyPermissionsResponse parses an HTTP response from a TenantServiceGetMyPermissionsWithResponse call
+func ParseTenantServiceGetMyPermissionsResponse(rsp *http.Response) (*TenantServiceGetMyPermissionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceGetMyPermissionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceListTenantsResponse parses an HTTP response from a TenantServiceListTenantsWithResponse call
+func ParseTenantServiceListTenantsResponse(rsp *http.Response) (*TenantServiceListTenantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListTenantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceCreateTenantResponse parses an HTTP response from a TenantServiceCreateTenantWithResponse call
+func ParseTenantServiceCreateTenantResponse(rsp *http.Response) (*TenantServiceCreateTenantResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceCreateTenantResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceUpdateTenantResponse parses an HTTP response from a TenantServiceUpdateTenantWithResponse call
+func ParseTenantServiceUpdateTenantResponse(rsp *http.Response) (*TenantServiceUpdateTenantResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceUpdateTenantResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceDeleteTenantResponse parses an HTTP response from a TenantServiceDeleteTenantWithResponse call
+func ParseTenantServiceDeleteTenantResponse(rsp *http.Response) (*TenantServiceDeleteTenantResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &TenantServiceDeleteTenantResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceListTenantUsersResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseTenantServiceListAPIKeysResponse parses an HTTP response from a TenantServiceListAPIKeysWithResponse call
+func ParseTenantServiceListAPIKeysResponse(rsp *http.Response) (*TenantServiceListAPIKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &TenantServiceListAPIKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-type TenantServiceProvisionUserResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
+// ParseTenantServiceCreateAPIKeyResponse parses an HTTP response from a TenantServiceCreateAPIKeyWithResponse call
+func ParseTenantServiceCreateAPIKeyResponse(rsp *http.Response) (*TenantServiceCreateAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceCreateAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceProvisionUserResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseTenantServiceRevokeAPIKeyResponse parses an HTTP response from a TenantServiceRevokeAPIKeyWithResponse call
+func ParseTenantServiceRevokeAPIKeyResponse(rsp *http.Response) (*TenantServiceRevokeAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &TenantServiceRevokeAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceListInvitesResponse parses an HTTP response from a TenantServiceListInvitesWithResponse call
+func ParseTenantServiceListInvitesResponse(rsp *http.Response) (*TenantServiceListInvitesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListInvitesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceInviteMemberResponse parses an HTTP response from a TenantServiceInviteMemberWithResponse call
+func ParseTenantServiceInviteMemberResponse(rsp *http.Response) (*TenantServiceInviteMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceInviteMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceRevokeInviteResponse parses an HTTP response from a TenantServiceRevokeInviteWithResponse call
+func ParseTenantServiceRevokeInviteResponse(rsp *http.Response) (*TenantServiceRevokeInviteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceRevokeInviteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceProvisionUserResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseTenantServiceResendInviteResponse parses an HTTP response from a TenantServiceResendInviteWithResponse call
+func ParseTenantServiceResendInviteResponse(rsp *http.Response) (*TenantServiceResendInviteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceResendInviteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceListRolesResponse parses an HTTP response from a TenantServiceListRolesWithResponse call
+func ParseTenantServiceListRolesResponse(rsp *http.Response) (*TenantServiceListRolesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceListRolesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTenantServiceCreateRoleResponse parses an HTTP response from a TenantServiceCreateRoleWithResponse call
+func ParseTenantServiceCreateRoleResponse(rsp *http.Response) (*TenantServiceCreateRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TenantServiceCreateRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return 0
-}
 
-type TenantServiceUpdateTenantUserResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceUpdateTenantUserResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseTenantServiceDeleteRoleResponse parses an HTTP response from a TenantServiceDeleteRoleWithResponse call
+func ParseTenantServiceDeleteRoleResponse(rsp *http.Response) (*TenantServiceDeleteRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceUpdateTenantUserResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &TenantServiceDeleteRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type TenantServiceListUserTenantsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *RpcStatus
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// Status returns HTTPResponse.Status
-func (r TenantServiceListUserTenantsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TenantServiceListUserTenantsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// TenantServiceListMyTenantsWithResponse request returning *TenantServiceListMyTenantsResponse
-func (c *ClientWithResponses) TenantServiceListMyTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListMyTenantsResponse, error) {
-	rsp, err := c.TenantServiceListMyTenants(ctx, reqEditors...)
+// ParseTenantServiceUpdateRoleResponse parses an HTTP response from a TenantServiceUpdateRoleWithResponse call
+func ParseTenantServiceUpdateRoleResponse(rsp *http.Response) (*TenantServiceUpdateRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceListMyTenantsResponse(rsp)
-}
 
-// TenantServiceListTenantsWithResponse request returning *TenantServiceListTenantsResponse
-func (c *ClientWithResponses) TenantServiceListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TenantServiceListTenantsResponse, error) {
-	rsp, err := c.TenantServiceListTenants(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &TenantServiceUpdateRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseTenantServiceListTenantsResponse(rsp)
-}
 
-// TenantServiceCreateTenantWithBodyWithResponse request with arbitrary body returning *TenantServiceCreateTenantResponse
-func (c *ClientWithResponses) TenantServiceCreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error) {
-	rsp, err := c.TenantServiceCreateTenantWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseTenantServiceCreateTenantResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) TenantServiceCreateTenantWithResponse(ctx context.Context, body TenantServiceCreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceCreateTenantResponse, error) {
-	rsp, err := c.TenantServiceCreateTenant(ctx, body, reqEditors...)
+// ParseTenantServiceAssignRoleResponse parses an HTTP response from a TenantServiceAssignRoleWithResponse call
+func ParseTenantServiceAssignRoleResponse(rsp *http.Response) (*TenantServiceAssignRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceCreateTenantResponse(rsp)
-}
 
-// TenantServiceUpdateTenantWithBodyWithResponse request with arbitrary body returning *TenantServiceUpdateTenantResponse
-func (c *ClientWithResponses) TenantServiceUpdateTenantWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error) {
-	rsp, err := c.TenantServiceUpdateTenantWithBody(ctx, tenantId, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &TenantServiceAssignRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseTenantServiceUpdateTenantResponse(rsp)
-}
 
-func (c *ClientWithResponses) TenantServiceUpdateTenantWithResponse(ctx context.Context, tenantId string, body TenantServiceUpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantResponse, error) {
-	rsp, err := c.TenantServiceUpdateTenant(ctx, tenantId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseTenantServiceUpdateTenantResponse(rsp)
+
+	return response, nil
 }
 
-// TenantServiceDeleteTenantWithResponse request returning *TenantServiceDeleteTenantResponse
-func (c *ClientWithResponses) TenantServiceDeleteTenantWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceDeleteTenantResponse, error) {
-	rsp, err := c.TenantServiceDeleteTenant(ctx, tenantId, reqEditors...)
+// ParseTenantServiceUnassignRoleResponse parses an HTTP response from a TenantServiceUnassignRoleWithResponse call
+func ParseTenantServiceUnassignRoleResponse(rsp *http.Response) (*TenantServiceUnassignRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceDeleteTenantResponse(rsp)
-}
 
-// TenantServiceInviteMemberWithBodyWithResponse request with arbitrary body returning *TenantServiceInviteMemberResponse
-func (c *ClientWithResponses) TenantServiceInviteMemberWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error) {
-	rsp, err := c.TenantServiceInviteMemberWithBody(ctx, tenantId, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &TenantServiceUnassignRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseTenantServiceInviteMemberResponse(rsp)
-}
 
-func (c *ClientWithResponses) TenantServiceInviteMemberWithResponse(ctx context.Context, tenantId string, body TenantServiceInviteMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceInviteMemberResponse, error) {
-	rsp, err := c.TenantServiceInviteMember(ctx, tenantId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseTenantServiceInviteMemberResponse(rsp)
+
+	return response, nil
 }
 
-// TenantServiceListTenantUsersWithResponse request returning *TenantServiceListTenantUsersResponse
-func (c *ClientWithResponses) TenantServiceListTenantUsersWithResponse(ctx context.Context, tenantId string, reqEditors ...RequestEditorFn) (*TenantServiceListTenantUsersResponse, error) {
-	rsp, err := c.TenantServiceListTenantUsers(ctx, tenantId, reqEditors...)
+// ParseTenantServiceGetTenantStatsResponse parses an HTTP response from a TenantServiceGetTenantStatsWithResponse call
+func ParseTenantServiceGetTenantStatsResponse(rsp *http.Response) (*TenantServiceGetTenantStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceListTenantUsersResponse(rsp)
-}
 
-// TenantServiceProvisionUserWithBodyWithResponse request with arbitrary body returning *TenantServiceProvisionUserResponse
-func (c *ClientWithResponses) TenantServiceProvisionUserWithBodyWithResponse(ctx context.Context, tenantId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error) {
-	rsp, err := c.TenantServiceProvisionUserWithBody(ctx, tenantId, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &TenantServiceGetTenantStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseTenantServiceProvisionUserResponse(rsp)
-}
 
-func (c *ClientWithResponses) TenantServiceProvisionUserWithResponse(ctx context.Context, tenantId string, body TenantServiceProvisionUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceProvisionUserResponse, error) {
-	rsp, err := c.TenantServiceProvisionUser(ctx, tenantId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseTenantServiceProvisionUserResponse(rsp)
+
+	return response, nil
 }
 
-// TenantServiceUpdateTenantUserWithBodyWithResponse request with arbitrary body returning *TenantServiceUpdateTenantUserResponse
-func (c *ClientWithResponses) TenantServiceUpdateTenantUserWithBodyWithResponse(ctx context.Context, tenantId string, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error) {
-	rsp, err := c.TenantServiceUpdateTenantUserWithBody(ctx, tenantId, userId, contentType, body, reqEditors...)
+// ParseTenantServiceLinkTenantToSupportGroupResponse parses an HTTP response from a TenantServiceLinkTenantToSupportGroupWithResponse call
+func ParseTenantServiceLinkTenantToSupportGroupResponse(rsp *http.Response) (*TenantServiceLinkTenantToSupportGroupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceUpdateTenantUserResponse(rsp)
-}
 
-func (c *ClientWithResponses) TenantServiceUpdateTenantUserWithResponse(ctx context.Context, tenantId string, userId string, body TenantServiceUpdateTenantUserJSONRequestBody, reqEditors ...RequestEditorFn) (*TenantServiceUpdateTenantUserResponse, error) {
-	rsp, err := c.TenantServiceUpdateTenantUser(ctx, tenantId, userId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &TenantServiceLinkTenantToSupportGroupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseTenantServiceUpdateTenantUserResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// TenantServiceListUserTenantsWithResponse request returning *TenantServiceListUserTenantsResponse
-func (c *ClientWithResponses) TenantServiceListUserTenantsWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*TenantServiceListUserTenantsResponse, error) {
-	rsp, err := c.TenantServiceListUserTenants(ctx, userId, reqEditors...)
+// ParseTenantServiceListTenantUsersResponse parses an HTTP response from a TenantServiceListTenantUsersWithResponse call
+func ParseTenantServiceListTenantUsersResponse(rsp *http.Response) (*TenantServiceListTenantUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseTenantServiceListUserTenantsResponse(rsp)
+
+	response := &TenantServiceListTenantUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest RpcStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseTenantServiceListMyTenantsResponse parses an HTTP response from a TenantServiceListMyTenantsWithResponse call
-func ParseTenantServiceListMyTenantsResponse(rsp *http.Response) (*TenantServiceListMyTenantsResponse, error) {
+// ParseTenantServiceProvisionUserResponse parses an HTTP response from a TenantServiceProvisionUserWithResponse call
+func ParseTenantServiceProvisionUserResponse(rsp *http.Response) (*TenantServiceProvisionUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceListMyTenantsResponse{
+	response := &TenantServiceProvisionUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1289,15 +5218,15 @@ func ParseTenantServiceListMyTenantsResponse(rsp *http.Response) (*TenantService
 	return response, nil
 }
 
-// ParseTenantServiceListTenantsResponse parses an HTTP response from a TenantServiceListTenantsWithResponse call
-func ParseTenantServiceListTenantsResponse(rsp *http.Response) (*TenantServiceListTenantsResponse, error) {
+// ParseTenantServiceRemoveTenantUserResponse parses an HTTP response from a TenantServiceRemoveTenantUserWithResponse call
+func ParseTenantServiceRemoveTenantUserResponse(rsp *http.Response) (*TenantServiceRemoveTenantUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceListTenantsResponse{
+	response := &TenantServiceRemoveTenantUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1315,15 +5244,15 @@ func ParseTenantServiceListTenantsResponse(rsp *http.Response) (*TenantServiceLi
 	return response, nil
 }
 
-// ParseTenantServiceCreateTenantResponse parses an HTTP response from a TenantServiceCreateTenantWithResponse call
-func ParseTenantServiceCreateTenantResponse(rsp *http.Response) (*TenantServiceCreateTenantResponse, error) {
+// ParseTenantServiceGetTenantUserResponse parses an HTTP response from a TenantServiceGetTenantUserWithResponse call
+func ParseTenantServiceGetTenantUserResponse(rsp *http.Response) (*TenantServiceGetTenantUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceCreateTenantResponse{
+	response := &TenantServiceGetTenantUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1341,15 +5270,15 @@ func ParseTenantServiceCreateTenantResponse(rsp *http.Response) (*TenantServiceC
 	return response, nil
 }
 
-// ParseTenantServiceUpdateTenantResponse parses an HTTP response from a TenantServiceUpdateTenantWithResponse call
-func ParseTenantServiceUpdateTenantResponse(rsp *http.Response) (*TenantServiceUpdateTenantResponse, error) {
+// ParseTenantServiceUpdateTenantUserResponse parses an HTTP response from a TenantServiceUpdateTenantUserWithResponse call
+func ParseTenantServiceUpdateTenantUserResponse(rsp *http.Response) (*TenantServiceUpdateTenantUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceUpdateTenantResponse{
+	response := &TenantServiceUpdateTenantUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1367,15 +5296,15 @@ func ParseTenantServiceUpdateTenantResponse(rsp *http.Response) (*TenantServiceU
 	return response, nil
 }
 
-// ParseTenantServiceDeleteTenantResponse parses an HTTP response from a TenantServiceDeleteTenantWithResponse call
-func ParseTenantServiceDeleteTenantResponse(rsp *http.Response) (*TenantServiceDeleteTenantResponse, error) {
+// ParseTenantServiceListWebhookSubscriptionsResponse parses an HTTP response from a TenantServiceListWebhookSubscriptionsWithResponse call
+func ParseTenantServiceListWebhookSubscriptionsResponse(rsp *http.Response) (*TenantServiceListWebhookSubscriptionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceDeleteTenantResponse{
+	response := &TenantServiceListWebhookSubscriptionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1393,15 +5322,15 @@ func ParseTenantServiceDeleteTenantResponse(rsp *http.Response) (*TenantServiceD
 	return response, nil
 }
 
-// ParseTenantServiceInviteMemberResponse parses an HTTP response from a TenantServiceInviteMemberWithResponse call
-func ParseTenantServiceInviteMemberResponse(rsp *http.Response) (*TenantServiceInviteMemberResponse, error) {
+// ParseTenantServiceCreateWebhookSubscriptionResponse parses an HTTP response from a TenantServiceCreateWebhookSubscriptionWithResponse call
+func ParseTenantServiceCreateWebhookSubscriptionResponse(rsp *http.Response) (*TenantServiceCreateWebhookSubscriptionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceInviteMemberResponse{
+	response := &TenantServiceCreateWebhookSubscriptionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1419,15 +5348,15 @@ func ParseTenantServiceInviteMemberResponse(rsp *http.Response) (*TenantServiceI
 	return response, nil
 }
 
-// ParseTenantServiceListTenantUsersResponse parses an HTTP response from a TenantServiceListTenantUsersWithResponse call
-func ParseTenantServiceListTenantUsersResponse(rsp *http.Response) (*TenantServiceListTenantUsersResponse, error) {
+// ParseTenantServiceDeleteWebhookSubscriptionResponse parses an HTTP response from a TenantServiceDeleteWebhookSubscriptionWithResponse call
+func ParseTenantServiceDeleteWebhookSubscriptionResponse(rsp *http.Response) (*TenantServiceDeleteWebhookSubscriptionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceListTenantUsersResponse{
+	response := &TenantServiceDeleteWebhookSubscriptionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1445,15 +5374,15 @@ func ParseTenantServiceListTenantUsersResponse(rsp *http.Response) (*TenantServi
 	return response, nil
 }
 
-// ParseTenantServiceProvisionUserResponse parses an HTTP response from a TenantServiceProvisionUserWithResponse call
-func ParseTenantServiceProvisionUserResponse(rsp *http.Response) (*TenantServiceProvisionUserResponse, error) {
+// ParseTenantServiceListWebhookDeliveriesResponse parses an HTTP response from a TenantServiceListWebhookDeliveriesWithResponse call
+func ParseTenantServiceListWebhookDeliveriesResponse(rsp *http.Response) (*TenantServiceListWebhookDeliveriesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceProvisionUserResponse{
+	response := &TenantServiceListWebhookDeliveriesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1471,15 +5400,15 @@ func ParseTenantServiceProvisionUserResponse(rsp *http.Response) (*TenantService
 	return response, nil
 }
 
-// ParseTenantServiceUpdateTenantUserResponse parses an HTTP response from a TenantServiceUpdateTenantUserWithResponse call
-func ParseTenantServiceUpdateTenantUserResponse(rsp *http.Response) (*TenantServiceUpdateTenantUserResponse, error) {
+// ParseTenantServiceReplayWebhookDeliveryResponse parses an HTTP response from a TenantServiceReplayWebhookDeliveryWithResponse call
+func ParseTenantServiceReplayWebhookDeliveryResponse(rsp *http.Response) (*TenantServiceReplayWebhookDeliveryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TenantServiceUpdateTenantUserResponse{
+	response := &TenantServiceReplayWebhookDeliveryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
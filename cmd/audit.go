@@ -0,0 +1,90 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit trails",
+}
+
+var auditAuthzCmd = &cobra.Command{
+	Use:   "authz",
+	Short: "List the authorization tuple changes, newest first",
+	Long: `List the tuples written to or deleted from OpenFGA by the service, newest first.
+
+--since takes an RFC 3339 timestamp or a duration such as 24h, counted back
+from now. When more entries are available, the token to pass to --page-token
+to read them is printed after the table.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		actor, _ := cmd.Flags().GetString("actor")
+		user, _ := cmd.Flags().GetString("user")
+		object, _ := cmd.Flags().GetString("object")
+		since, _ := cmd.Flags().GetString("since")
+		limit, _ := cmd.Flags().GetInt32("limit")
+		pageToken, _ := cmd.Flags().GetString("page-token")
+
+		if d, err := time.ParseDuration(since); err == nil {
+			since = time.Now().Add(-d).UTC().Format(time.RFC3339)
+		}
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListAuthzAudit(ctx, &v0.ListAuthzAuditRequest{
+			Actor:     actor,
+			User:      user,
+			Object:    object,
+			Since:     since,
+			PageSize:  limit,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list authz audit entries: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "TIME\tACTOR\tOPERATION\tUSER\tRELATION\tOBJECT\tREQUEST_ID\tSOURCE_IP")
+		for _, e := range resp.Entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.CreatedAt, e.Actor, e.Operation, e.User, e.Relation, e.Object, e.RequestId, e.SourceIp)
+		}
+		w.Flush()
+
+		if resp.NextPageToken != "" {
+			fmt.Printf("\nNext page token: %s\n", resp.NextPageToken)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditAuthzCmd)
+
+	auditAuthzCmd.Flags().String("actor", "", "Only list the changes made by this principal")
+	auditAuthzCmd.Flags().String("user", "", "Only list the tuples of this user, e.g. user:<id>")
+	auditAuthzCmd.Flags().String("object", "", "Only list the tuples on this object, e.g. tenant:<id>")
+	auditAuthzCmd.Flags().String("since", "", "Only list the changes made since this time or duration")
+	auditAuthzCmd.Flags().Int32("limit", 0, "Maximum number of entries to list, the server default if 0")
+	auditAuthzCmd.Flags().String("page-token", "", "Token of the page to list, printed by the previous call")
+}
@@ -0,0 +1,474 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/tenant"
+)
+
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Set up the database and openfga of a new deployment",
+	Long: `Set up the database and openfga of a new deployment in one go:
+
+  1. the database migrations are applied,
+  2. the openfga store is created, or the given one is checked, and the
+     embedded authorization model is written unless the latest model of the
+     store already matches it, then recorded for the status API,
+  3. with --platform-admin, the user is made an admin of the support group
+     --platform-admin-group,
+  4. with --tenant-name, the tenant is created with --tenant-owner as its
+     owner, unless a tenant of that name exists, and linked to the support
+     group.
+
+Every step is skipped when already done, so that the command can be run on
+every deployment. --dry-run reports what would be done without changing
+anything.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts bootstrapOptions
+		opts.dsn, _ = cmd.Flags().GetString("dsn")
+		opts.fgaApiUrl, _ = cmd.Flags().GetString("fga-api-url")
+		opts.fgaApiToken, _ = cmd.Flags().GetString("fga-api-token")
+		opts.fgaStoreId, _ = cmd.Flags().GetString("fga-store-id")
+		opts.modelVersion, _ = cmd.Flags().GetString("model-version")
+		opts.adminGroup, _ = cmd.Flags().GetString("platform-admin-group")
+		opts.admin, _ = cmd.Flags().GetString("platform-admin")
+		opts.tenantName, _ = cmd.Flags().GetString("tenant-name")
+		opts.tenantOwner, _ = cmd.Flags().GetString("tenant-owner")
+		opts.tenantRegion, _ = cmd.Flags().GetString("tenant-region")
+		opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+		opts.verbose, _ = cmd.Flags().GetBool("verbose")
+		format, _ := cmd.Flags().GetString("format")
+
+		if err := opts.validate(); err != nil {
+			return err
+		}
+
+		result, err := bootstrap(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		if !opts.dryRun {
+			if err := writeModelTargets(cmd.Context(), cmd, result.StoreId, result.ModelId); err != nil {
+				return err
+			}
+		}
+
+		switch format {
+		case "export":
+			return printExports(cmd.OutOrStdout(), result.StoreId, result.ModelId)
+		case "json":
+			if err := json.NewEncoder(cmd.OutOrStdout()).Encode(result); err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+			return nil
+		}
+
+		result.print(cmd)
+		return nil
+	},
+}
+
+func init() {
+	bootstrapCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	bootstrapCmd.Flags().String("fga-api-url", "", "The openfga API URL")
+	bootstrapCmd.Flags().String("fga-api-token", "", "The openfga API token")
+	bootstrapCmd.Flags().String("fga-store-id", "", "The openfga store to check, if empty one will be created")
+	bootstrapCmd.Flags().String("model-version", authorization.LatestModelVersion, "The authorization model version to write")
+	bootstrapCmd.Flags().String("platform-admin-group", tenant.DefaultPlatformAdminGroup, "The support group of the platform admin and of the initial tenant")
+	bootstrapCmd.Flags().String("platform-admin", "", "The user ID made an admin of the support group")
+	bootstrapCmd.Flags().String("tenant-name", "", "The name of the initial tenant, none is created when empty")
+	bootstrapCmd.Flags().String("tenant-owner", "", "The user ID of the owner of the initial tenant")
+	bootstrapCmd.Flags().String("tenant-region", "", "The region of the initial tenant")
+	bootstrapCmd.Flags().Bool("dry-run", false, "Report what would be done without changing anything")
+	bootstrapCmd.Flags().String("format", "text", "Output format (text, json or export)")
+	bootstrapCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
+	addModelTargetFlags(bootstrapCmd)
+	_ = bootstrapCmd.MarkFlagRequired("dsn")
+	_ = bootstrapCmd.MarkFlagRequired("fga-api-url")
+	_ = bootstrapCmd.MarkFlagRequired("fga-api-token")
+
+	rootCmd.AddCommand(bootstrapCmd)
+}
+
+type bootstrapOptions struct {
+	dsn          string
+	fgaApiUrl    string
+	fgaApiToken  string
+	fgaStoreId   string
+	modelVersion string
+	adminGroup   string
+	admin        string
+	tenantName   string
+	tenantOwner  string
+	tenantRegion string
+	dryRun       bool
+	verbose      bool
+}
+
+func (o bootstrapOptions) validate() error {
+	if !slices.Contains(authorization.ModelVersions(), o.modelVersion) {
+		return fmt.Errorf("unknown model version %s, expected one of %v", o.modelVersion, authorization.ModelVersions())
+	}
+	if o.admin != "" && o.adminGroup == "" {
+		return fmt.Errorf("--platform-admin requires --platform-admin-group")
+	}
+	if o.tenantName != "" && o.tenantOwner == "" {
+		return fmt.Errorf("--tenant-name requires --tenant-owner")
+	}
+	if o.tenantName == "" && (o.tenantOwner != "" || o.tenantRegion != "") {
+		return fmt.Errorf("--tenant-owner and --tenant-region require --tenant-name")
+	}
+	return nil
+}
+
+// bootstrapResult reports the outcome of every step, what would be done on
+// a dry run.
+type bootstrapResult struct {
+	DryRun        bool                   `json:"dry_run"`
+	Migrations    []int64                `json:"migrations"`
+	StoreId       string                 `json:"store_id"`
+	StoreCreated  bool                   `json:"store_created"`
+	ModelId       string                 `json:"model_id"`
+	ModelWritten  bool                   `json:"model_written"`
+	PlatformAdmin *bootstrapAdminResult  `json:"platform_admin,omitempty"`
+	Tenant        *bootstrapTenantResult `json:"tenant,omitempty"`
+}
+
+type bootstrapAdminResult struct {
+	GroupId string `json:"group_id"`
+	UserId  string `json:"user_id"`
+	Added   bool   `json:"added"`
+}
+
+type bootstrapTenantResult struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	OwnerId string `json:"owner_id"`
+	Created bool   `json:"created"`
+}
+
+func (r *bootstrapResult) print(cmd *cobra.Command) {
+	would := func(done string) string {
+		if r.DryRun {
+			return "would be " + done
+		}
+		return done
+	}
+
+	if len(r.Migrations) == 0 {
+		cmd.Println("Database is up to date")
+	} else {
+		cmd.Printf("Migrations %v %s\n", r.Migrations, would("applied"))
+	}
+
+	switch {
+	case r.StoreCreated && r.DryRun:
+		cmd.Println("Store would be created")
+	case r.StoreCreated:
+		cmd.Printf("Store %s created\n", r.StoreId)
+	default:
+		cmd.Printf("Store %s checked\n", r.StoreId)
+	}
+	switch {
+	case r.ModelWritten && r.DryRun:
+		cmd.Println("Model would be written")
+	case r.ModelWritten:
+		cmd.Printf("Model %s written\n", r.ModelId)
+	default:
+		cmd.Printf("Model %s matches the embedded model\n", r.ModelId)
+	}
+
+	if a := r.PlatformAdmin; a != nil {
+		if a.Added {
+			cmd.Printf("User %s %s an admin of %s\n", a.UserId, would("made"), a.GroupId)
+		} else {
+			cmd.Printf("User %s is already an admin of %s\n", a.UserId, a.GroupId)
+		}
+	}
+
+	if t := r.Tenant; t != nil {
+		switch {
+		case t.Created && r.DryRun:
+			cmd.Printf("Tenant %q would be created with owner %s\n", t.Name, t.OwnerId)
+		case t.Created:
+			cmd.Printf("Tenant %q created with owner %s: %s\n", t.Name, t.OwnerId, t.Id)
+		default:
+			cmd.Printf("Tenant %q exists: %s\n", t.Name, t.Id)
+		}
+	}
+
+	if !r.DryRun {
+		cmd.Printf("Set OPENFGA_STORE_ID=%s and OPENFGA_AUTHORIZATION_MODEL_ID=%s\n", r.StoreId, r.ModelId)
+	}
+}
+
+func bootstrap(ctx context.Context, opts bootstrapOptions) (*bootstrapResult, error) {
+	result := &bootstrapResult{DryRun: opts.dryRun}
+
+	migrated, err := bootstrapMigrations(ctx, opts.dsn, opts.dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	result.Migrations = migrated
+
+	logger := logging.NewNoopLogger()
+	tracer := tracing.NewNoopTracer()
+	monitor := monitoring.NewNoopMonitor("", logger)
+
+	scheme, host, err := parseURL(opts.fgaApiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	fgaConfig := openfga.Config{
+		ApiScheme: scheme,
+		ApiHost:   host,
+		StoreID:   opts.fgaStoreId,
+		ApiToken:  opts.fgaApiToken,
+		Debug:     opts.verbose,
+		Tracer:    tracer,
+		Monitor:   monitor,
+		Logger:    logger,
+	}
+
+	if err := bootstrapModel(ctx, &fgaConfig, opts, result); err != nil {
+		return nil, err
+	}
+
+	dbClient, err := db.NewDBClient(
+		db.Config{
+			DSN:             opts.dsn,
+			MaxConns:        1,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: time.Minute,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database client: %w", err)
+	}
+	defer dbClient.Close()
+
+	s := storage.NewStorage(dbClient, tracer, monitor, logger)
+
+	if !opts.dryRun {
+		if err := s.CreateAuthorizationModel(ctx, &types.AuthorizationModel{
+			ModelID:       result.ModelId,
+			StoreID:       result.StoreId,
+			SchemaVersion: authorization.NewAuthorizationModelProvider(opts.modelVersion).GetModel().SchemaVersion,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record model: %w", err)
+		}
+	}
+
+	// without a store there is nothing to read on a dry run
+	var authorizer *authorization.Authorizer
+	if fgaConfig.StoreID != "" {
+		authorizer = authorization.NewAuthorizer(openfga.NewClient(&fgaConfig), tracer, monitor, logger)
+	}
+
+	if opts.admin != "" {
+		added, err := bootstrapAdmin(ctx, authorizer, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.PlatformAdmin = &bootstrapAdminResult{GroupId: opts.adminGroup, UserId: opts.admin, Added: added}
+	}
+
+	if opts.tenantName != "" {
+		// the tables do not exist yet on a dry run of a new database
+		tenant, err := bootstrapTenant(ctx, s, dbClient, authorizer, opts, len(migrated) > 0 && opts.dryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Tenant = tenant
+	}
+
+	return result, nil
+}
+
+// bootstrapMigrations applies the pending migrations and returns their
+// versions, only lists them on a dry run.
+func bootstrapMigrations(ctx context.Context, dsn string, dryRun bool) ([]int64, error) {
+	lock, err := migrationLock(defaultMigrationWait)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, provider, err := migrationProvider(ctx, dsn, true, lock)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlDB.Close()
+
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]int64, 0)
+	for _, s := range statuses {
+		if s.State == goose.StatePending {
+			pending = append(pending, s.Source.Version)
+		}
+	}
+	if dryRun {
+		return pending, nil
+	}
+
+	if _, err := provider.Up(ctx); err != nil {
+		return nil, err
+	}
+	if err := recordChecksums(ctx, sqlDB, provider); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// bootstrapModel creates the store when config has none and writes the
+// embedded model unless it is the latest model of the store, config is
+// updated with the store and the model.
+func bootstrapModel(ctx context.Context, config *openfga.Config, opts bootstrapOptions, result *bootstrapResult) error {
+	fgaClient := openfga.NewClient(config)
+	expected := authorization.NewAuthorizationModelProvider(opts.modelVersion).GetModel()
+
+	if config.StoreID == "" {
+		result.StoreCreated = true
+		result.ModelWritten = true
+		if opts.dryRun {
+			return nil
+		}
+
+		storeId, err := fgaClient.CreateStore(ctx, StoreName)
+		if err != nil {
+			return fmt.Errorf("failed to create store: %w", err)
+		}
+		fgaClient.SetStoreID(ctx, storeId)
+		config.StoreID = storeId
+	} else {
+		deployed, err := fgaClient.ReadLatestModel(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read deployed model: %w", err)
+		}
+		if deployed != nil && len(authorization.DiffModels(expected, deployed)) == 0 {
+			config.AuthModelID = deployed.Id
+			result.StoreId, result.ModelId = config.StoreID, deployed.Id
+			return nil
+		}
+		result.ModelWritten = true
+		result.StoreId = config.StoreID
+		if opts.dryRun {
+			return nil
+		}
+	}
+
+	modelId, err := fgaClient.WriteModel(ctx, &client.ClientWriteAuthorizationModelRequest{
+		TypeDefinitions: expected.TypeDefinitions,
+		SchemaVersion:   expected.SchemaVersion,
+		Conditions:      expected.Conditions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+
+	config.AuthModelID = modelId
+	result.StoreId, result.ModelId = config.StoreID, modelId
+	return nil
+}
+
+// bootstrapAdmin makes the platform admin an admin of the support group, it
+// reports whether the user was not one.
+func bootstrapAdmin(ctx context.Context, authorizer *authorization.Authorizer, opts bootstrapOptions) (bool, error) {
+	if authorizer == nil {
+		return true, nil
+	}
+
+	admins, err := authorizer.ListPrivilegedAdmins(ctx, opts.adminGroup)
+	if err != nil {
+		return false, fmt.Errorf("failed to list platform admins: %w", err)
+	}
+	if slices.Contains(admins, opts.admin) {
+		return false, nil
+	}
+	if opts.dryRun {
+		return true, nil
+	}
+
+	if err := authorizer.AssignPrivilegedAdmin(ctx, opts.adminGroup, opts.admin); err != nil {
+		return false, fmt.Errorf("failed to add platform admin: %w", err)
+	}
+	return true, nil
+}
+
+// bootstrapTenant creates the initial tenant with its owner and links it to
+// the support group, unless a tenant of that name exists. fresh skips the
+// lookup, the database has no table yet.
+func bootstrapTenant(ctx context.Context, s *storage.Storage, dbClient *db.DBClient, authorizer *authorization.Authorizer, opts bootstrapOptions, fresh bool) (*bootstrapTenantResult, error) {
+	result := &bootstrapTenantResult{Name: opts.tenantName, OwnerId: opts.tenantOwner}
+
+	if !fresh {
+		tenants, err := s.ListTenants(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tenants: %w", err)
+		}
+		if i := slices.IndexFunc(tenants, func(t *types.Tenant) bool { return t.Name == opts.tenantName }); i >= 0 {
+			result.Id = tenants[i].ID
+			return result, nil
+		}
+	}
+
+	result.Created = true
+	if opts.dryRun {
+		return result, nil
+	}
+
+	err := dbClient.WithTx(ctx, func(ctx context.Context) error {
+		tenant, err := s.CreateTenant(ctx, &types.Tenant{Name: opts.tenantName, Enabled: true, Region: opts.tenantRegion})
+		if err != nil {
+			return err
+		}
+		if _, err := s.AddMember(ctx, tenant.ID, opts.tenantOwner, types.RoleOwner); err != nil {
+			return err
+		}
+		// the tuples are written last, the rows are rolled back if they fail
+		if err := authorizer.AssignTenantOwner(ctx, tenant.ID, opts.tenantOwner); err != nil {
+			return err
+		}
+		if opts.adminGroup != "" {
+			if err := authorizer.LinkTenantToPrivileged(ctx, tenant.ID, opts.adminGroup); err != nil {
+				return err
+			}
+		}
+		result.Id = tenant.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant %s: %w", opts.tenantName, err)
+	}
+
+	return result, nil
+}
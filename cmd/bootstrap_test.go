@@ -0,0 +1,56 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+)
+
+func TestBootstrapOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      bootstrapOptions
+		wantError bool
+	}{
+		{
+			name: "Migrations and model only",
+			opts: bootstrapOptions{modelVersion: authorization.LatestModelVersion},
+		},
+		{
+			name: "Platform admin and tenant",
+			opts: bootstrapOptions{modelVersion: authorization.LatestModelVersion, adminGroup: "platform", admin: "user-1", tenantName: "Acme", tenantOwner: "user-1"},
+		},
+		{
+			name:      "Unknown model version",
+			opts:      bootstrapOptions{modelVersion: "v9"},
+			wantError: true,
+		},
+		{
+			name:      "Platform admin without group",
+			opts:      bootstrapOptions{modelVersion: authorization.LatestModelVersion, admin: "user-1"},
+			wantError: true,
+		},
+		{
+			name:      "Tenant without owner",
+			opts:      bootstrapOptions{modelVersion: authorization.LatestModelVersion, tenantName: "Acme"},
+			wantError: true,
+		},
+		{
+			name:      "Owner without tenant",
+			opts:      bootstrapOptions{modelVersion: authorization.LatestModelVersion, tenantOwner: "user-1"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if (err != nil) != tt.wantError {
+				t.Errorf("validate() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -5,30 +5,96 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 
 	v0 "github.com/canonical/tenant-service/v0"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 )
 
 // getClient returns a client interface and a closure function to close resources if needed.
 // It decides whether to return a gRPC or HTTP client based on flags.
+// Without --token, the token cached by login is used, refreshed if needed.
 func getClient() (func() error, v0.TenantServiceClient, error) {
+	if authToken == "" {
+		token, err := cachedAccessToken(context.Background())
+		if err != nil {
+			return nil, nil, err
+		}
+		authToken = token
+	}
+
+	tlsConfig, err := clientTLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// If HTTP endpoint is set, prefer HTTP
 	if httpEndpoint != "" {
-		return func() error { return nil }, newHTTPTenantClient(httpEndpoint), nil
+		return func() error { return nil }, newHTTPTenantClient(httpEndpoint, tlsConfig), nil
+	}
+
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
 	}
 
 	// Use gRPC endpoint
-	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial gRPC server: %w", err)
 	}
 	return conn.Close, v0.NewTenantServiceClient(conn), nil
 }
 
+// clientTLSConfig returns the TLS configuration of the connection to the
+// service, nil when it is in plaintext. --ca-cert is trusted on top of the
+// system roots and --client-cert is presented for mutual TLS.
+func clientTLSConfig() (*tls.Config, error) {
+	if !tlsEnabled && caCertFile == "" && clientCertFile == "" && clientKeyFile == "" && tlsServerName == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: tlsServerName}
+
+	if caCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", caCertFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		return nil, fmt.Errorf("--client-cert and --client-key must be provided together")
+	}
+
+	if clientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 func getAuthenticatedContext(ctx context.Context) context.Context {
 	if authToken != "" {
 		token := authToken
@@ -6,6 +6,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,14 +27,23 @@ type httpTenantClient struct {
 // Ensure interface compliance
 var _ v0.TenantServiceClient = (*httpTenantClient)(nil)
 
-func newHTTPTenantClient(endpoint string) v0.TenantServiceClient {
+func newHTTPTenantClient(endpoint string, tlsConfig *tls.Config) v0.TenantServiceClient {
 	if !strings.HasPrefix(endpoint, "http") {
-		endpoint = "http://" + endpoint
+		if tlsConfig != nil {
+			endpoint = "https://" + endpoint
+		} else {
+			endpoint = "http://" + endpoint
+		}
 	}
 	// remove trailing slash
 	endpoint = strings.TrimSuffix(endpoint, "/")
 
 	opts := []httpclient.ClientOption{}
+	if tlsConfig != nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = tlsConfig
+		opts = append(opts, httpclient.WithHTTPClient(&http.Client{Transport: t}))
+	}
 	if authToken != "" {
 		opts = append(opts, httpclient.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 			token := authToken
@@ -64,7 +74,7 @@ func (c *httpTenantClient) handleRequest(resp *http.Response, err error, out pro
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api error (status %d): %s", resp.StatusCode, string(body))
+		return httpError(resp.StatusCode, body)
 	}
 
 	if out != nil {
@@ -81,6 +91,15 @@ func (c *httpTenantClient) handleRequest(resp *http.Response, err error, out pro
 
 // Implement v0.TenantServiceClient interface
 
+func (c *httpTenantClient) WhoAmI(ctx context.Context, in *v0.WhoAmIRequest, opts ...grpc.CallOption) (*v0.WhoAmIResponse, error) {
+	out := new(v0.WhoAmIResponse)
+	resp, err := c.client.TenantServiceWhoAmI(ctx)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *httpTenantClient) ListMyTenants(ctx context.Context, in *v0.ListMyTenantsRequest, opts ...grpc.CallOption) (*v0.ListMyTenantsResponse, error) {
 	out := new(v0.ListMyTenantsResponse)
 	resp, err := c.client.TenantServiceListMyTenants(ctx)
@@ -90,9 +109,28 @@ func (c *httpTenantClient) ListMyTenants(ctx context.Context, in *v0.ListMyTenan
 	return out, nil
 }
 
+func (c *httpTenantClient) GetMyPermissions(ctx context.Context, in *v0.GetMyPermissionsRequest, opts ...grpc.CallOption) (*v0.GetMyPermissionsResponse, error) {
+	out := new(v0.GetMyPermissionsResponse)
+	resp, err := c.client.TenantServiceGetMyPermissions(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *httpTenantClient) ListTenants(ctx context.Context, in *v0.ListTenantsRequest, opts ...grpc.CallOption) (*v0.ListTenantsResponse, error) {
 	out := new(v0.ListTenantsResponse)
-	resp, err := c.client.TenantServiceListTenants(ctx)
+	params := &httpclient.TenantServiceListTenantsParams{Enabled: in.Enabled}
+	if in.PageSize != 0 {
+		params.PageSize = &in.PageSize
+	}
+	if in.PageToken != "" {
+		params.PageToken = &in.PageToken
+	}
+	if in.NameContains != "" {
+		params.NameContains = &in.NameContains
+	}
+	resp, err := c.client.TenantServiceListTenants(ctx, params)
 	if err := c.handleRequest(resp, err, out); err != nil {
 		return nil, err
 	}
@@ -112,6 +150,46 @@ func (c *httpTenantClient) InviteMember(ctx context.Context, in *v0.InviteMember
 	return out, nil
 }
 
+func (c *httpTenantClient) ListInvites(ctx context.Context, in *v0.ListInvitesRequest, opts ...grpc.CallOption) (*v0.ListInvitesResponse, error) {
+	out := new(v0.ListInvitesResponse)
+	resp, err := c.client.TenantServiceListInvites(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) RevokeInvite(ctx context.Context, in *v0.RevokeInviteRequest, opts ...grpc.CallOption) (*v0.Invite, error) {
+	out := new(v0.Invite)
+	resp, err := c.client.TenantServiceRevokeInvite(ctx, in.TenantId, in.InviteId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ResendInvite(ctx context.Context, in *v0.ResendInviteRequest, opts ...grpc.CallOption) (*v0.ResendInviteResponse, error) {
+	out := new(v0.ResendInviteResponse)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceResendInviteWithBody(ctx, in.TenantId, in.InviteId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) GetTenantStats(ctx context.Context, in *v0.GetTenantStatsRequest, opts ...grpc.CallOption) (*v0.GetTenantStatsResponse, error) {
+	out := new(v0.GetTenantStatsResponse)
+	resp, err := c.client.TenantServiceGetTenantStats(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *httpTenantClient) ListUserTenants(ctx context.Context, in *v0.ListUserTenantsRequest, opts ...grpc.CallOption) (*v0.ListUserTenantsResponse, error) {
 	out := new(v0.ListUserTenantsResponse)
 	resp, err := c.client.TenantServiceListUserTenants(ctx, in.UserId)
@@ -152,9 +230,13 @@ func (c *httpTenantClient) UpdateTenant(ctx context.Context, in *v0.UpdateTenant
 	return out, nil
 }
 
-func (c *httpTenantClient) DeleteTenant(ctx context.Context, in *v0.DeleteTenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	resp, err := c.client.TenantServiceDeleteTenant(ctx, in.TenantId)
+func (c *httpTenantClient) DeleteTenant(ctx context.Context, in *v0.DeleteTenantRequest, opts ...grpc.CallOption) (*v0.DeleteTenantResponse, error) {
+	out := new(v0.DeleteTenantResponse)
+	params := &httpclient.TenantServiceDeleteTenantParams{}
+	if in.Strict {
+		params.Strict = &in.Strict
+	}
+	resp, err := c.client.TenantServiceDeleteTenant(ctx, in.TenantId, params)
 	if err := c.handleRequest(resp, err, out); err != nil {
 		return nil, err
 	}
@@ -176,7 +258,39 @@ func (c *httpTenantClient) ProvisionUser(ctx context.Context, in *v0.ProvisionUs
 
 func (c *httpTenantClient) ListTenantUsers(ctx context.Context, in *v0.ListTenantUsersRequest, opts ...grpc.CallOption) (*v0.ListTenantUsersResponse, error) {
 	out := new(v0.ListTenantUsersResponse)
-	resp, err := c.client.TenantServiceListTenantUsers(ctx, in.TenantId)
+	params := &httpclient.TenantServiceListTenantUsersParams{}
+	if in.SkipIdentityLookup {
+		params.SkipIdentityLookup = &in.SkipIdentityLookup
+	}
+	if in.PageSize != 0 {
+		params.PageSize = &in.PageSize
+	}
+	if in.PageToken != "" {
+		params.PageToken = &in.PageToken
+	}
+	resp, err := c.client.TenantServiceListTenantUsers(ctx, in.TenantId, params)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) GetTenantUser(ctx context.Context, in *v0.GetTenantUserRequest, opts ...grpc.CallOption) (*v0.GetTenantUserResponse, error) {
+	out := new(v0.GetTenantUserResponse)
+	resp, err := c.client.TenantServiceGetTenantUser(ctx, in.TenantId, in.UserId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) RemoveTenantUser(ctx context.Context, in *v0.RemoveTenantUserRequest, opts ...grpc.CallOption) (*v0.RemoveTenantUserResponse, error) {
+	out := new(v0.RemoveTenantUserResponse)
+	params := &httpclient.TenantServiceRemoveTenantUserParams{}
+	if in.Strict {
+		params.Strict = &in.Strict
+	}
+	resp, err := c.client.TenantServiceRemoveTenantUser(ctx, in.TenantId, in.UserId, params)
 	if err := c.handleRequest(resp, err, out); err != nil {
 		return nil, err
 	}
@@ -186,3 +300,238 @@ func (c *httpTenantClient) ListTenantUsers(ctx context.Context, in *v0.ListTenan
 func (c *httpTenantClient) UpdateTenantUser(ctx context.Context, in *v0.UpdateTenantUserRequest, opts ...grpc.CallOption) (*v0.UpdateTenantUserResponse, error) {
 	return nil, fmt.Errorf("method UpdateTenantUser not implemented in HTTP client")
 }
+
+func (c *httpTenantClient) RunDiagnostics(ctx context.Context, in *v0.RunDiagnosticsRequest, opts ...grpc.CallOption) (*v0.RunDiagnosticsResponse, error) {
+	out := new(v0.RunDiagnosticsResponse)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceRunDiagnosticsWithBody(ctx, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) CreateRole(ctx context.Context, in *v0.CreateRoleRequest, opts ...grpc.CallOption) (*v0.CreateRoleResponse, error) {
+	out := new(v0.CreateRoleResponse)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceCreateRoleWithBody(ctx, in.TenantId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListRoles(ctx context.Context, in *v0.ListRolesRequest, opts ...grpc.CallOption) (*v0.ListRolesResponse, error) {
+	out := new(v0.ListRolesResponse)
+	resp, err := c.client.TenantServiceListRoles(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) UpdateRole(ctx context.Context, in *v0.UpdateRoleRequest, opts ...grpc.CallOption) (*v0.UpdateRoleResponse, error) {
+	out := new(v0.UpdateRoleResponse)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceUpdateRoleWithBody(ctx, in.TenantId, in.RoleId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) DeleteRole(ctx context.Context, in *v0.DeleteRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	resp, err := c.client.TenantServiceDeleteRole(ctx, in.TenantId, in.RoleId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) AssignRole(ctx context.Context, in *v0.AssignRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceAssignRoleWithBody(ctx, in.TenantId, in.RoleId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) UnassignRole(ctx context.Context, in *v0.UnassignRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	resp, err := c.client.TenantServiceUnassignRole(ctx, in.TenantId, in.RoleId, in.UserId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) CreateAPIKey(ctx context.Context, in *v0.CreateAPIKeyRequest, opts ...grpc.CallOption) (*v0.CreateAPIKeyResponse, error) {
+	out := new(v0.CreateAPIKeyResponse)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceCreateAPIKeyWithBody(ctx, in.TenantId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListAPIKeys(ctx context.Context, in *v0.ListAPIKeysRequest, opts ...grpc.CallOption) (*v0.ListAPIKeysResponse, error) {
+	out := new(v0.ListAPIKeysResponse)
+	resp, err := c.client.TenantServiceListAPIKeys(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) RevokeAPIKey(ctx context.Context, in *v0.RevokeAPIKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	resp, err := c.client.TenantServiceRevokeAPIKey(ctx, in.TenantId, in.ApiKeyId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) CreateWebhookSubscription(ctx context.Context, in *v0.CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*v0.WebhookSubscription, error) {
+	out := new(v0.WebhookSubscription)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceCreateWebhookSubscriptionWithBody(ctx, in.TenantId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListWebhookSubscriptions(ctx context.Context, in *v0.ListWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*v0.ListWebhookSubscriptionsResponse, error) {
+	out := new(v0.ListWebhookSubscriptionsResponse)
+	resp, err := c.client.TenantServiceListWebhookSubscriptions(ctx, in.TenantId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) DeleteWebhookSubscription(ctx context.Context, in *v0.DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	resp, err := c.client.TenantServiceDeleteWebhookSubscription(ctx, in.TenantId, in.SubscriptionId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListWebhookDeliveries(ctx context.Context, in *v0.ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*v0.ListWebhookDeliveriesResponse, error) {
+	out := new(v0.ListWebhookDeliveriesResponse)
+	resp, err := c.client.TenantServiceListWebhookDeliveries(ctx, in.TenantId, in.SubscriptionId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ReplayWebhookDelivery(ctx context.Context, in *v0.ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*v0.WebhookDelivery, error) {
+	out := new(v0.WebhookDelivery)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceReplayWebhookDeliveryWithBody(ctx, in.TenantId, in.SubscriptionId, in.DeliveryId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) AddPlatformAdmin(ctx context.Context, in *v0.AddPlatformAdminRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceAddPlatformAdminWithBody(ctx, in.GroupId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) RemovePlatformAdmin(ctx context.Context, in *v0.RemovePlatformAdminRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	resp, err := c.client.TenantServiceRemovePlatformAdmin(ctx, in.GroupId, in.UserId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListPlatformAdmins(ctx context.Context, in *v0.ListPlatformAdminsRequest, opts ...grpc.CallOption) (*v0.ListPlatformAdminsResponse, error) {
+	out := new(v0.ListPlatformAdminsResponse)
+	resp, err := c.client.TenantServiceListPlatformAdmins(ctx, in.GroupId)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) LinkTenantToSupportGroup(ctx context.Context, in *v0.LinkTenantToSupportGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	bodyBytes, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	resp, err := c.client.TenantServiceLinkTenantToSupportGroupWithBody(ctx, in.TenantId, "application/json", bytes.NewReader(bodyBytes))
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *httpTenantClient) ListAuthzAudit(ctx context.Context, in *v0.ListAuthzAuditRequest, opts ...grpc.CallOption) (*v0.ListAuthzAuditResponse, error) {
+	out := new(v0.ListAuthzAuditResponse)
+	params := &httpclient.TenantServiceListAuthzAuditParams{}
+	if in.Actor != "" {
+		params.Actor = &in.Actor
+	}
+	if in.User != "" {
+		params.User = &in.User
+	}
+	if in.Object != "" {
+		params.Object = &in.Object
+	}
+	if in.Since != "" {
+		params.Since = &in.Since
+	}
+	if in.PageSize != 0 {
+		params.PageSize = &in.PageSize
+	}
+	if in.PageToken != "" {
+		params.PageToken = &in.PageToken
+	}
+	resp, err := c.client.TenantServiceListAuthzAudit(ctx, params)
+	if err := c.handleRequest(resp, err, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
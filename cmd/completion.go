@@ -0,0 +1,42 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script of the CLI for the given shell.
+
+To load the completions in the current shell:
+
+  bash: source <(app completion bash)
+  zsh:  source <(app completion zsh)
+  fish: app completion fish | source
+
+To load them in every session, write the script to the completion directory
+of the shell, such as /etc/bash_completion.d/app, a directory of $fpath as
+_app, or ~/.config/fish/completions/app.fish.`,
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return cmd.Root().GenBashCompletionV2(out, true)
+		case "zsh":
+			return cmd.Root().GenZshCompletion(out)
+		default:
+			return cmd.Root().GenFishCompletion(out, true)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	cascadeMembers = "members"
+	cascadeKeep    = "keep"
+)
+
+// errNotConfirmed is returned when the typed name does not match.
+var errNotConfirmed = errors.New("confirmation does not match the tenant name, aborted")
+
+// errAborted is returned when a yes/no confirmation is declined.
+var errAborted = errors.New("aborted")
+
+// confirmTenant asks the user to type the name of the tenant before a
+// destructive command, unless --force is set. A tenant that cannot be found
+// needs no confirmation, the command reports it.
+func confirmTenant(ctx context.Context, cmd *cobra.Command, client v0.TenantServiceClient, tenantID, warning string) error {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return nil
+	}
+
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		return errors.New("refusing to continue without a confirmation, use --force in scripts")
+	}
+
+	resp, err := client.ListTenants(ctx, &v0.ListTenantsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to look up tenant: %w", err)
+	}
+
+	for _, t := range resp.Tenants {
+		if t.Id == tenantID {
+			return confirmName(in, cmd.ErrOrStderr(), t.Name, warning)
+		}
+	}
+	return nil
+}
+
+// confirmAction asks the user to confirm a change with yes, unless --force
+// is set.
+func confirmAction(cmd *cobra.Command, warning string) error {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return nil
+	}
+
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		return errors.New("refusing to continue without a confirmation, use --force in scripts")
+	}
+	return confirmYes(in, cmd.ErrOrStderr(), warning)
+}
+
+// confirmYes prints warning and reads a line from in, which must be y or yes.
+func confirmYes(in io.Reader, out io.Writer, warning string) error {
+	fmt.Fprintln(out, warning)
+	fmt.Fprint(out, "Continue? [y/N]: ")
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return errAborted
+}
+
+// confirmName prints warning and reads a line from in, which must be name.
+func confirmName(in io.Reader, out io.Writer, name, warning string) error {
+	fmt.Fprintln(out, warning)
+	fmt.Fprintf(out, "Type the tenant name (%s) to confirm: ", name)
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if strings.TrimSpace(line) != name {
+		return errNotConfirmed
+	}
+	return nil
+}
+
+// validateCascade checks the --cascade flag against the values the command
+// supports.
+func validateCascade(cmd *cobra.Command, allowed ...string) (string, error) {
+	cascade, _ := cmd.Flags().GetString("cascade")
+	for _, a := range allowed {
+		if cascade == a {
+			return cascade, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --cascade %q, must be one of: %s", cascade, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestConfirmName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{name: "Matching name", input: "Acme\n"},
+		{name: "Matching name without newline", input: "  Acme  "},
+		{name: "Other name", input: "acme\n", expected: errNotConfirmed},
+		{name: "No input", input: "", expected: errNotConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirmName(strings.NewReader(tt.input), &out, "Acme", "Tenant will be deleted.")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("confirmName() error = %v, want %v", err, tt.expected)
+			}
+			if !strings.Contains(out.String(), "Tenant will be deleted.") {
+				t.Errorf("expected the warning to be printed, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestConfirmTenantWithoutTerminal(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("force", false, "")
+	cmd.SetIn(strings.NewReader("Acme\n"))
+
+	if err := confirmTenant(context.Background(), cmd, nil, "tenant-1", ""); err == nil {
+		t.Error("expected a confirmation without a terminal to be refused")
+	}
+
+	_ = cmd.Flags().Set("force", "true")
+	if err := confirmTenant(context.Background(), cmd, nil, "tenant-1", ""); err != nil {
+		t.Errorf("expected --force to skip the confirmation, got %v", err)
+	}
+}
+
+func TestValidateCascade(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantError bool
+	}{
+		{value: cascadeKeep},
+		{value: cascadeMembers},
+		{value: "all", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("cascade", tt.value, "")
+
+			cascade, err := validateCascade(cmd, cascadeKeep, cascadeMembers)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("validateCascade() error = %v, wantError %v", err, tt.wantError)
+			}
+			if !tt.wantError && cascade != tt.value {
+				t.Errorf("validateCascade() = %q, want %q", cascade, tt.value)
+			}
+		})
+	}
+}
+
+func TestConfirmYes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{name: "Yes", input: "yes\n"},
+		{name: "Short yes in capitals", input: " Y "},
+		{name: "No", input: "n\n", expected: errAborted},
+		{name: "No input", input: "", expected: errAborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirmYes(strings.NewReader(tt.input), &out, "The tuple will be deleted.")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("confirmYes() error = %v, want %v", err, tt.expected)
+			}
+			if !strings.Contains(out.String(), "The tuple will be deleted.") {
+				t.Errorf("expected the warning to be printed, got %q", out.String())
+			}
+		})
+	}
+}
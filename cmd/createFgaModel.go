@@ -9,24 +9,20 @@ import (
 	"fmt"
 	"net/url"
 	"os"
-
-	"strings"
+	"slices"
+	"time"
 
 	"github.com/openfga/go-sdk/client"
 	"github.com/spf13/cobra"
-	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/canonical/tenant-service/internal/authorization"
-
+	"github.com/canonical/tenant-service/internal/db"
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
 )
 
 const StoreName = "tenant-service"
@@ -42,24 +38,39 @@ var createFgaModelCmd = &cobra.Command{
 		storeId, _ := cmd.Flags().GetString("fga-store-id")
 		format, _ := cmd.Flags().GetString("format")
 		verbose, _ := cmd.Flags().GetBool("verbose")
-		configMapResource, _ := cmd.Flags().GetString("store-k8s-configmap-resource")
-		kubeconfigPath, _ := cmd.Flags().GetString("kubeconfig")
+		dsn, _ := cmd.Flags().GetString("dsn")
+		modelVersion, _ := cmd.Flags().GetString("model-version")
+
+		if !slices.Contains(authorization.ModelVersions(), modelVersion) {
+			cmd.PrintErrln(fmt.Errorf("unknown model version %s, expected one of %v", modelVersion, authorization.ModelVersions()))
+			os.Exit(1)
+		}
 
-		modelId, finalStoreId, err := createModel(apiUrl, apiToken, storeId, verbose)
+		modelId, finalStoreId, err := createModel(apiUrl, apiToken, storeId, modelVersion, verbose)
 		if err != nil {
 			cmd.PrintErrln(err)
 			os.Exit(1)
 		}
 
-		if configMapResource != "" {
-			if err := updateConfigMap(cmd.Context(), kubeconfigPath, configMapResource, finalStoreId, modelId); err != nil {
-				cmd.PrintErrln(fmt.Errorf("failed to update configmap: %w", err))
+		if dsn != "" {
+			if err := recordModel(cmd.Context(), dsn, finalStoreId, modelId, modelVersion); err != nil {
+				cmd.PrintErrln(fmt.Errorf("failed to record model: %w", err))
 				os.Exit(1)
 			}
-			cmd.Printf("ConfigMap %s updated successfully\n", configMapResource)
 		}
 
-		if format == "json" {
+		if err := writeModelTargets(cmd.Context(), cmd, finalStoreId, modelId); err != nil {
+			cmd.PrintErrln(err)
+			os.Exit(1)
+		}
+
+		switch format {
+		case "export":
+			if err := printExports(cmd.OutOrStdout(), finalStoreId, modelId); err != nil {
+				cmd.PrintErrln(fmt.Errorf("failed to print output: %v", err))
+				os.Exit(1)
+			}
+		case "json":
 			output := struct {
 				StoreId string `json:"store_id"`
 				ModelId string `json:"model_id"`
@@ -71,7 +82,7 @@ var createFgaModelCmd = &cobra.Command{
 				cmd.PrintErrln(fmt.Errorf("failed to encode output: %v", err))
 				os.Exit(1)
 			}
-		} else {
+		default:
 			cmd.Printf("Created model: %s\n", modelId)
 			if storeId == "" {
 				cmd.Printf("Created store: %s\n", finalStoreId)
@@ -86,15 +97,16 @@ func init() {
 	createFgaModelCmd.Flags().String("fga-api-url", "", "The openfga API URL")
 	createFgaModelCmd.Flags().String("fga-api-token", "", "The openfga API token")
 	createFgaModelCmd.Flags().String("fga-store-id", "", "The openfga store to create the model in, if empty one will be created")
-	createFgaModelCmd.Flags().String("format", "text", "Output format (text or json)")
+	createFgaModelCmd.Flags().String("format", "text", "Output format (text, json or export)")
 	createFgaModelCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
-	createFgaModelCmd.Flags().String("store-k8s-configmap-resource", "", "The configmap resource to store the FGA Store ID and Model ID, format: namespace/name")
-	createFgaModelCmd.Flags().String("kubeconfig", "", "Path to the kubeconfig file (optional, defaults to in-cluster config)")
+	createFgaModelCmd.Flags().String("model-version", authorization.LatestModelVersion, "The authorization model version to write, an existing store is upgraded to it")
+	createFgaModelCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string, when set the model is recorded for the status API")
+	addModelTargetFlags(createFgaModelCmd)
 	createFgaModelCmd.MarkFlagRequired("fga-api-url")
 	createFgaModelCmd.MarkFlagRequired("fga-api-token")
 }
 
-func createModel(apiUrl, apiToken, storeId string, verbose bool) (string, string, error) {
+func createModel(apiUrl, apiToken, storeId, modelVersion string, verbose bool) (string, string, error) {
 	ctx := context.Background()
 
 	logger := logging.NewNoopLogger()
@@ -131,7 +143,7 @@ func createModel(apiUrl, apiToken, storeId string, verbose bool) (string, string
 		fgaClient.SetStoreID(ctx, storeId)
 	}
 
-	authzModel := authorization.NewAuthorizationModelProvider("v0").
+	authzModel := authorization.NewAuthorizationModelProvider(modelVersion).
 		GetModel()
 
 	modelId, err := fgaClient.WriteModel(
@@ -150,79 +162,40 @@ func createModel(apiUrl, apiToken, storeId string, verbose bool) (string, string
 	return modelId, storeId, nil
 }
 
-func parseURL(s string) (string, string, error) {
-	u, err := url.Parse(s)
-	if err != nil {
-		return "", "", err
-	}
-	return u.Scheme, u.Host, nil
-}
-
-func updateConfigMap(ctx context.Context, kubeconfigPath, configMapResource, storeId, modelId string) error {
-	parts := strings.Split(configMapResource, "/")
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid configmap resource format: %s, expected namespace/name", configMapResource)
-	}
-	namespace, name := parts[0], parts[1]
-
-	var config *rest.Config
-	var err error
-
-	if kubeconfigPath != "" {
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-	} else {
-		config, err = rest.InClusterConfig()
-		if err != nil {
-			// Fallback to kubeconfig if in-cluster fails (e.g. running locally without flag)
-			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-			configOverrides := &clientcmd.ConfigOverrides{}
-			kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-			config, err = kubeConfig.ClientConfig()
-		}
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load kubeconfig: %w", err)
-	}
-
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
-	}
+// recordModel stores the model metadata so that running instances can report
+// whether they enforce the latest model of their store.
+func recordModel(ctx context.Context, dsn, storeId, modelId, modelVersion string) error {
+	logger := logging.NewNoopLogger()
+	tracer := tracing.NewNoopTracer()
+	monitor := monitoring.NewNoopMonitor("", logger)
 
-	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	dbClient, err := db.NewDBClient(
+		db.Config{
+			DSN:             dsn,
+			MaxConns:        1,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: time.Minute,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
 	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			// Try to Create it
-			cm = &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      name,
-					Namespace: namespace,
-				},
-				Data: map[string]string{
-					"OPENFGA_STORE_ID":               storeId,
-					"OPENFGA_AUTHORIZATION_MODEL_ID": modelId,
-				},
-			}
-			_, err = clientset.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to create configmap %s: %w", configMapResource, err)
-			}
-			return nil
-		}
-		return fmt.Errorf("failed to get configmap %s: %w", configMapResource, err)
+		return fmt.Errorf("failed to create database client: %w", err)
 	}
+	defer dbClient.Close()
 
-	if cm.Data == nil {
-		cm.Data = make(map[string]string)
-	}
-
-	cm.Data["OPENFGA_STORE_ID"] = storeId
-	cm.Data["OPENFGA_AUTHORIZATION_MODEL_ID"] = modelId
+	return storage.NewStorage(dbClient, tracer, monitor, logger).CreateAuthorizationModel(ctx, &types.AuthorizationModel{
+		ModelID:       modelId,
+		StoreID:       storeId,
+		SchemaVersion: authorization.NewAuthorizationModelProvider(modelVersion).GetModel().SchemaVersion,
+	})
+}
 
-	_, err = clientset.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+func parseURL(s string) (string, string, error) {
+	u, err := url.Parse(s)
 	if err != nil {
-		return fmt.Errorf("failed to update configmap %s: %w", configMapResource, err)
+		return "", "", err
 	}
-
-	return nil
+	return u.Scheme, u.Host, nil
 }
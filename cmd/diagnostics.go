@@ -0,0 +1,73 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var diagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "Scan tenants for inconsistent memberships",
+	Long: `Scan tenants for memberships pointing at deleted identities, tenants without owners,
+missing OpenFGA relations and users holding conflicting relations.
+
+Use --fix with one or more categories (orphaned_membership, ownerless_tenant,
+missing_tuple, duplicate_membership) to repair the matching anomalies.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fix, _ := cmd.Flags().GetStringSlice("fix")
+		format, _ := cmd.Flags().GetString("format")
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.RunDiagnostics(ctx, &v0.RunDiagnosticsRequest{
+			Fix: fix,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run diagnostics: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		if format == "json" {
+			out, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(resp)
+			if err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CATEGORY\tTENANT_ID\tUSER_ID\tFIXED\tDETAIL")
+		for _, a := range resp.Anomalies {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", a.Category, a.TenantId, a.UserId, a.Fixed, a.Detail)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	diagnosticsCmd.Flags().StringSlice("fix", nil, "Anomaly categories to repair")
+	diagnosticsCmd.Flags().String("format", "text", "Output format (text or json)")
+	diagnosticsCmd.Flags().MarkDeprecated("format", "use --output json or --output yaml")
+
+	rootCmd.AddCommand(diagnosticsCmd)
+}
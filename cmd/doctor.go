@@ -0,0 +1,354 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/kelseyhightower/envconfig"
+	openfgasdk "github.com/openfga/go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/config"
+	"github.com/canonical/tenant-service/internal/http/outbound"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	doctorPass = "pass"
+	doctorFail = "fail"
+	doctorSkip = "skip"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration and the dependencies of the service",
+	Long: `Check the configuration and the dependencies of the service, read from
+the same environment variables as serve:
+
+  - config: the environment and the files it points at,
+  - postgres: the database is reachable and its migrations are up to date,
+  - openfga: the store is reachable and its model matches the embedded one,
+    skipped without AUTHORIZATION_ENABLED,
+  - kratos: the admin API is ready and lists identities,
+  - oidc: the discovery and the keys of every trusted issuer, skipped
+    without AUTHENTICATION_ENABLED.
+
+Every check is given --timeout. The command fails when a check fails, use
+--output json for a report CI can parse.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		report := runDoctor(cmd.Context(), doctorChecks(), timeout)
+
+		if ok, err := printValue(cmd.OutOrStdout(), report); ok {
+			if err != nil {
+				return err
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "CHECK\tSTATUS\tDURATION\tDETAIL")
+			for _, r := range report.Checks {
+				// driver errors span several lines
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Status, r.Duration, strings.Join(strings.Fields(r.Detail), " "))
+			}
+			w.Flush()
+		}
+
+		if report.Status == doctorFail {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d checks failed", report.Failed)
+		}
+		return nil
+	},
+}
+
+// doctorResult is the outcome of a check, Detail explains the status.
+type doctorResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail"`
+	Duration string `json:"duration"`
+}
+
+type doctorReport struct {
+	// Status is fail when any check failed, pass otherwise
+	Status string         `json:"status"`
+	Failed int            `json:"failed"`
+	Checks []doctorResult `json:"checks"`
+}
+
+type doctorCheck struct {
+	name string
+	run  func(context.Context) (string, error)
+}
+
+// doctorSkipped is returned by the checks of a disabled feature.
+type doctorSkipped string
+
+func (s doctorSkipped) Error() string {
+	return string(s)
+}
+
+// runDoctor runs the checks one after the other, each given timeout.
+func runDoctor(ctx context.Context, checks []doctorCheck, timeout time.Duration) *doctorReport {
+	report := &doctorReport{Status: doctorPass, Checks: make([]doctorResult, 0, len(checks))}
+
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		detail, err := c.run(checkCtx)
+		cancel()
+
+		r := doctorResult{
+			Name:     c.name,
+			Status:   doctorPass,
+			Detail:   detail,
+			Duration: time.Since(start).Round(time.Millisecond).String(),
+		}
+
+		var skipped doctorSkipped
+		switch {
+		case errors.As(err, &skipped):
+			r.Status, r.Detail = doctorSkip, skipped.Error()
+		case err != nil:
+			r.Status, r.Detail = doctorFail, err.Error()
+			report.Status = doctorFail
+			report.Failed++
+		}
+		report.Checks = append(report.Checks, r)
+	}
+
+	return report
+}
+
+// doctorChecks returns the checks of the configuration in the environment,
+// a single failed config check when it cannot be read.
+func doctorChecks() []doctorCheck {
+	specs := new(config.EnvSpec)
+	err := envconfig.Process("", specs)
+
+	var client *http.Client
+	if err == nil {
+		client, err = outbound.NewClient(
+			outbound.NewConfig(
+				specs.OutboundTLSCAFile,
+				specs.OutboundTLSCertFile,
+				specs.OutboundTLSKeyFile,
+				specs.OutboundProxyURL,
+			),
+		)
+	}
+
+	var issuers []authentication.IssuerConfig
+	if err == nil && specs.AuthenticationEnabled {
+		if specs.AuthenticationIssuer != "" {
+			issuers = append(issuers, authentication.IssuerConfig{Issuer: specs.AuthenticationIssuer, JWKSURL: specs.AuthenticationJwksURL})
+		}
+		if specs.AuthenticationIssuersFile != "" {
+			var more []authentication.IssuerConfig
+			more, err = authentication.LoadIssuers(specs.AuthenticationIssuersFile)
+			issuers = append(issuers, more...)
+		}
+	}
+
+	checks := []doctorCheck{{
+		name: "config",
+		run: func(context.Context) (string, error) {
+			if err != nil {
+				return "", err
+			}
+			return "environment loaded", nil
+		},
+	}}
+	if err != nil {
+		return checks
+	}
+
+	checks = append(checks,
+		doctorCheck{name: "postgres", run: func(ctx context.Context) (string, error) { return checkPostgres(ctx, specs.DSN) }},
+		doctorCheck{name: "openfga", run: func(ctx context.Context) (string, error) { return checkOpenFGA(ctx, specs, client) }},
+		doctorCheck{name: "kratos", run: func(ctx context.Context) (string, error) { return checkKratos(ctx, client, specs.KratosAdminURL) }},
+	)
+
+	switch {
+	case !specs.AuthenticationEnabled:
+		checks = append(checks, doctorCheck{name: "oidc", run: func(context.Context) (string, error) {
+			return "", doctorSkipped("authentication is disabled")
+		}})
+	case len(issuers) == 0:
+		checks = append(checks, doctorCheck{name: "oidc", run: func(context.Context) (string, error) {
+			return "", errors.New("no trusted issuer, set AUTHENTICATION_ISSUER or AUTHENTICATION_ISSUERS_FILE")
+		}})
+	}
+	for _, issuer := range issuers {
+		checks = append(checks, doctorCheck{name: "oidc", run: func(ctx context.Context) (string, error) {
+			return checkIssuer(ctx, client, issuer)
+		}})
+	}
+	return checks
+}
+
+// checkPostgres connects to the database and compares its version with the
+// embedded migrations.
+func checkPostgres(ctx context.Context, dsn string) (string, error) {
+	db, provider, err := migrationProvider(ctx, dsn, true)
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	current, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the database version: %w", err)
+	}
+
+	sources := provider.ListSources()
+	latest := sources[len(sources)-1].Version
+	if current < latest {
+		return "", fmt.Errorf("migrations are pending: version %d, latest %d, run migrate up", current, latest)
+	}
+	return fmt.Sprintf("version %d, up to date", current), nil
+}
+
+// checkOpenFGA reads the model served to the service, the latest one of the
+// store without OPENFGA_AUTHORIZATION_MODEL_ID, and diffs it with the
+// embedded model.
+func checkOpenFGA(ctx context.Context, specs *config.EnvSpec, httpClient *http.Client) (detail string, err error) {
+	if !specs.AuthorizationEnabled {
+		return "", doctorSkipped("authorization is disabled")
+	}
+
+	logger := logging.NewNoopLogger()
+	fgaConfig := openfga.NewConfig(
+		specs.OpenfgaApiScheme,
+		specs.OpenfgaApiHost,
+		specs.OpenfgaStoreId,
+		specs.OpenfgaApiToken,
+		specs.OpenfgaModelId,
+		false,
+		tracing.NewNoopTracer(),
+		monitoring.NewNoopMonitor("", logger),
+		logger,
+	)
+	if fgaConfig == nil {
+		return "", errors.New("invalid configuration, check OPENFGA_API_SCHEME, OPENFGA_API_HOST and OPENFGA_STORE_ID")
+	}
+	fgaConfig.HTTPClient = httpClient
+
+	// the SDK rejects malformed IDs by panicking
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid configuration: %v", r)
+		}
+	}()
+	fgaClient := openfga.NewClient(fgaConfig)
+
+	var deployed *openfgasdk.AuthorizationModel
+	if specs.OpenfgaModelId != "" {
+		deployed, err = fgaClient.ReadModel(ctx)
+	} else {
+		deployed, err = fgaClient.ReadLatestModel(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the model of store %s: %w", specs.OpenfgaStoreId, err)
+	}
+	if deployed == nil {
+		return "", fmt.Errorf("store %s has no model, run bootstrap", specs.OpenfgaStoreId)
+	}
+
+	expected := authorization.NewAuthorizationModelProvider(authorization.LatestModelVersion).GetModel()
+	if diff := authorization.DiffModels(expected, deployed); len(diff) > 0 {
+		return "", fmt.Errorf("model %s differs from the embedded model %s in %d places, run bootstrap", deployed.Id, authorization.LatestModelVersion, len(diff))
+	}
+	return fmt.Sprintf("store %s, model %s matches %s", specs.OpenfgaStoreId, deployed.Id, authorization.LatestModelVersion), nil
+}
+
+// checkKratos checks the readiness of Kratos and that the URL serves the
+// admin API, which the public one does not.
+func checkKratos(ctx context.Context, client *http.Client, adminURL string) (string, error) {
+	base := strings.TrimSuffix(adminURL, "/")
+
+	if err := doctorGet(ctx, client, base+"/health/ready", nil); err != nil {
+		return "", fmt.Errorf("not ready: %w", err)
+	}
+	if err := doctorGet(ctx, client, base+"/admin/identities?page_size=1", nil); err != nil {
+		return "", fmt.Errorf("failed to list identities, is %s the admin URL? %w", adminURL, err)
+	}
+	return "admin API ready at " + adminURL, nil
+}
+
+// checkIssuer runs the discovery of issuer, unless it sets its JWKS URL, and
+// fetches its keys.
+func checkIssuer(ctx context.Context, client *http.Client, issuer authentication.IssuerConfig) (string, error) {
+	jwksURL := issuer.JWKSURL
+	if jwksURL == "" {
+		provider, err := oidc.NewProvider(oidc.ClientContext(ctx, client), issuer.Issuer)
+		if err != nil {
+			return "", fmt.Errorf("discovery of %s failed: %w", issuer.Issuer, err)
+		}
+
+		var claims struct {
+			JWKSURL string `json:"jwks_uri"`
+		}
+		if err := provider.Claims(&claims); err != nil {
+			return "", fmt.Errorf("invalid discovery document of %s: %w", issuer.Issuer, err)
+		}
+		jwksURL = claims.JWKSURL
+	}
+
+	var keys struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := doctorGet(ctx, client, jwksURL, &keys); err != nil {
+		return "", fmt.Errorf("failed to fetch the keys of %s: %w", issuer.Issuer, err)
+	}
+	if len(keys.Keys) == 0 {
+		return "", fmt.Errorf("no keys published by %s", issuer.Issuer)
+	}
+	return fmt.Sprintf("%s publishes %d keys", issuer.Issuer, len(keys.Keys)), nil
+}
+
+// doctorGet expects a 200 from url and decodes the body into v, if any.
+func doctorGet(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func init() {
+	doctorCmd.Flags().Duration("timeout", 10*time.Second, "Time allowed to every check")
+
+	rootCmd.AddCommand(doctorCmd)
+}
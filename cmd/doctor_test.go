@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+func TestRunDoctor(t *testing.T) {
+	checks := []doctorCheck{
+		{name: "passing", run: func(context.Context) (string, error) { return "fine", nil }},
+		{name: "skipped", run: func(context.Context) (string, error) { return "", doctorSkipped("disabled") }},
+		{name: "failing", run: func(context.Context) (string, error) { return "", errors.New("unreachable") }},
+		{name: "slow", run: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+
+	report := runDoctor(context.Background(), checks, 10*time.Millisecond)
+
+	if report.Status != doctorFail || report.Failed != 2 {
+		t.Fatalf("expected 2 failed checks, got %s with %d", report.Status, report.Failed)
+	}
+
+	expected := []struct{ status, detail string }{
+		{doctorPass, "fine"},
+		{doctorSkip, "disabled"},
+		{doctorFail, "unreachable"},
+		{doctorFail, context.DeadlineExceeded.Error()},
+	}
+	for i, e := range expected {
+		r := report.Checks[i]
+		if r.Status != e.status || r.Detail != e.detail {
+			t.Errorf("check %s = %s %q, want %s %q", r.Name, r.Status, r.Detail, e.status, e.detail)
+		}
+	}
+}
+
+func TestCheckKratos(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		wantError bool
+	}{
+		{
+			name:    "Admin API",
+			handler: func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "[]") },
+		},
+		{
+			name: "Public API",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/admin/identities" {
+					http.NotFound(w, r)
+				}
+			},
+			wantError: true,
+		},
+		{
+			name:      "Not ready",
+			handler:   func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			if _, err := checkKratos(context.Background(), server.Client(), server.URL+"/"); (err != nil) != tt.wantError {
+				t.Errorf("checkKratos() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCheckIssuer(t *testing.T) {
+	keys := `{"keys": [{"kty": "EC"}]}`
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, server.URL, server.URL+"/jwks")
+		case "/jwks":
+			fmt.Fprint(w, keys)
+		case "/empty-jwks":
+			fmt.Fprint(w, `{"keys": []}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		issuer    authentication.IssuerConfig
+		wantError bool
+	}{
+		{name: "Discovery", issuer: authentication.IssuerConfig{Issuer: server.URL}},
+		{name: "JWKS URL", issuer: authentication.IssuerConfig{Issuer: "https://other.example.com", JWKSURL: server.URL + "/jwks"}},
+		{name: "No keys", issuer: authentication.IssuerConfig{Issuer: server.URL, JWKSURL: server.URL + "/empty-jwks"}, wantError: true},
+		{name: "Failed discovery", issuer: authentication.IssuerConfig{Issuer: server.URL + "/missing"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := checkIssuer(context.Background(), server.Client(), tt.issuer); (err != nil) != tt.wantError {
+				t.Errorf("checkIssuer() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusHints are printed under the errors of the service with these codes,
+// the most likely causes on the caller side.
+var statusHints = map[codes.Code]string{
+	codes.Unauthenticated:   "is the token missing, expired or from an untrusted issuer? Run login, pass --token, or check it with token verify",
+	codes.PermissionDenied:  "are you listed in AUTHENTICATION_ALLOWED_SUBJECTS, or holding AUTHENTICATION_REQUIRED_SCOPE, and a member of the tenant with the needed role?",
+	codes.NotFound:          "check the IDs, tenant list and tenant users list print them",
+	codes.AlreadyExists:     "the resource exists already, a retry with the same --idempotency-key is safe",
+	codes.InvalidArgument:   "check the arguments and flags, --help describes them",
+	codes.ResourceExhausted: "the service is rate limiting or shedding load, retry later, or the tenant reached a quota, tenant stats shows them",
+	codes.Unavailable:       "is the service running at --grpc-endpoint or --http-endpoint, with the matching --tls flags?",
+	codes.DeadlineExceeded:  "the service did not answer in time, retry later",
+	codes.Unimplemented:     "the service is older than the CLI, upgrade it or use a matching CLI",
+}
+
+// renderError describes err for the user: the status of the service is
+// named by its code rather than printed raw, followed by a hint of its cause
+// when there is one.
+func renderError(err error) string {
+	message := err.Error()
+
+	if st, ok := wrappedStatus(err); ok && st.Code() != codes.Unknown {
+		friendly := fmt.Sprintf("%s: %s", st.Code(), st.Message())
+		message = strings.Replace(message, st.Err().Error(), friendly, 1)
+		if hint, ok := statusHints[st.Code()]; ok {
+			return fmt.Sprintf("Error: %s\nHint: %s", message, hint)
+		}
+		return "Error: " + message
+	}
+
+	var netErr *net.OpError
+	if errors.As(err, &netErr) {
+		return fmt.Sprintf("Error: %s\nHint: %s", message, statusHints[codes.Unavailable])
+	}
+	return "Error: " + message
+}
+
+// wrappedStatus returns the status of the service err wraps, status.FromError
+// would merge the messages of the wrapping errors in it.
+func wrappedStatus(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) || se.GRPCStatus() == nil {
+		return nil, false
+	}
+	return se.GRPCStatus(), true
+}
+
+// httpError returns the status error of an HTTP error response, read from
+// its problem+json or former grpc-gateway body, or from the HTTP status when
+// the body is neither. The code of a problem is the name of the gRPC code,
+// or the reason of the error when the service gives one.
+func httpError(statusCode int, body []byte) error {
+	var gatewayStatus struct {
+		Code    json.RawMessage `json:"code"`
+		Message string          `json:"message"`
+		Detail  string          `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &gatewayStatus); err == nil && len(gatewayStatus.Code) > 0 {
+		message := gatewayStatus.Detail
+		if message == "" {
+			message = gatewayStatus.Message
+		}
+
+		var code codes.Code
+		if err := code.UnmarshalJSON(gatewayStatus.Code); err != nil {
+			code = httpStatusCode(statusCode)
+		}
+		return status.Error(code, message)
+	}
+
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	return status.Error(httpStatusCode(statusCode), message)
+}
+
+// httpStatusCode maps an HTTP status back to the code grpc-gateway maps to
+// it, Unknown when there is none.
+func httpStatusCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unknown
+	}
+}
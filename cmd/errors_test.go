@@ -0,0 +1,106 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenderError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "Status with a hint",
+			err:      fmt.Errorf("failed to list tenants: %w", status.Error(codes.PermissionDenied, "forbidden")),
+			expected: "Error: failed to list tenants: PermissionDenied: forbidden\nHint: " + statusHints[codes.PermissionDenied],
+		},
+		{
+			name:     "Status without a hint",
+			err:      fmt.Errorf("failed to delete tenant: %w", status.Error(codes.FailedPrecondition, "tenant is enabled")),
+			expected: "Error: failed to delete tenant: FailedPrecondition: tenant is enabled",
+		},
+		{
+			name:     "Network error",
+			err:      fmt.Errorf("request failed: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}),
+			expected: "Error: request failed: dial tcp: connection refused\nHint: " + statusHints[codes.Unavailable],
+		},
+		{
+			name:     "Other error",
+			err:      errors.New("--page-size must not be negative"),
+			expected: "Error: --page-size must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderError(tt.err); got != tt.expected {
+				t.Errorf("renderError() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		code       codes.Code
+		message    string
+	}{
+		{
+			name:       "Gateway status",
+			statusCode: http.StatusForbidden,
+			body:       `{"code":7,"message":"forbidden","details":[]}`,
+			code:       codes.PermissionDenied,
+			message:    "forbidden",
+		},
+		{
+			name:       "Problem",
+			statusCode: http.StatusNotFound,
+			body:       `{"type":"about:blank","title":"Not Found","status":404,"detail":"tenant not found","code":"NOT_FOUND","message":"tenant not found"}`,
+			code:       codes.NotFound,
+			message:    "tenant not found",
+		},
+		{
+			name:       "Problem with a reason",
+			statusCode: http.StatusForbidden,
+			body:       `{"type":"about:blank","title":"Forbidden","status":403,"detail":"step-up required","code":"STEP_UP_REQUIRED","message":"step-up required"}`,
+			code:       codes.PermissionDenied,
+			message:    "step-up required",
+		},
+		{
+			name:       "Plain body",
+			statusCode: http.StatusTooManyRequests,
+			body:       "slow down\n",
+			code:       codes.ResourceExhausted,
+			message:    "slow down",
+		},
+		{
+			name:       "Empty body",
+			statusCode: http.StatusBadGateway,
+			code:       codes.Unknown,
+			message:    "Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(httpError(tt.statusCode, []byte(tt.body)))
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Errorf("httpError() = %s %q, want %s %q", st.Code(), st.Message(), tt.code, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+var fgaModelCmd = &cobra.Command{
+	Use:   "fga-model",
+	Short: "Inspect the embedded openfga authorization model",
+}
+
+var fgaModelShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the embedded authorization model",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		modelVersion, _ := cmd.Flags().GetString("model-version")
+
+		if !slices.Contains(authorization.ModelVersions(), modelVersion) {
+			return fmt.Errorf("unknown model version %s, expected one of %v", modelVersion, authorization.ModelVersions())
+		}
+
+		provider := authorization.NewAuthorizationModelProvider(modelVersion)
+
+		switch format {
+		case "dsl":
+			fmt.Fprint(cmd.OutOrStdout(), provider.GetDSL())
+		case "json":
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(provider.GetModel()); err != nil {
+				return fmt.Errorf("failed to encode model: %w", err)
+			}
+		default:
+			return fmt.Errorf("unknown format %s, expected dsl or json", format)
+		}
+
+		return nil
+	},
+}
+
+var fgaModelDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the embedded authorization model with the one deployed in a store",
+	Long: `Compare the embedded authorization model with the one deployed in an openfga
+store, by default the latest model of the store. The types, relations and
+conditions the deployed model is missing, has in excess or defines differently
+are listed, and the command fails when there is any, as serve would refuse to
+start with that model.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiUrl, _ := cmd.Flags().GetString("fga-api-url")
+		apiToken, _ := cmd.Flags().GetString("fga-api-token")
+		storeId, _ := cmd.Flags().GetString("fga-store-id")
+		modelId, _ := cmd.Flags().GetString("fga-model-id")
+		modelVersion, _ := cmd.Flags().GetString("model-version")
+		format, _ := cmd.Flags().GetString("format")
+
+		if !slices.Contains(authorization.ModelVersions(), modelVersion) {
+			return fmt.Errorf("unknown model version %s, expected one of %v", modelVersion, authorization.ModelVersions())
+		}
+
+		logger := logging.NewNoopLogger()
+		tracer := tracing.NewNoopTracer()
+		monitor := monitoring.NewNoopMonitor("", logger)
+
+		scheme, host, err := parseURL(apiUrl)
+		if err != nil {
+			return fmt.Errorf("failed to parse url: %w", err)
+		}
+
+		fgaClient := openfga.NewClient(&openfga.Config{
+			ApiScheme:   scheme,
+			ApiHost:     host,
+			StoreID:     storeId,
+			ApiToken:    apiToken,
+			AuthModelID: modelId,
+			Tracer:      tracer,
+			Monitor:     monitor,
+			Logger:      logger,
+		})
+
+		read := fgaClient.ReadModel
+		if modelId == "" {
+			read = fgaClient.ReadLatestModel
+		}
+		deployed, err := read(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to read deployed model: %w", err)
+		}
+		if deployed == nil {
+			return fmt.Errorf("store %s has no authorization model", storeId)
+		}
+
+		diff := authorization.DiffModels(authorization.NewAuthorizationModelProvider(modelVersion).GetModel(), deployed)
+
+		if format == "json" {
+			output := struct {
+				StoreId      string                          `json:"store_id"`
+				ModelId      string                          `json:"model_id"`
+				ModelVersion string                          `json:"model_version"`
+				Differences  []authorization.ModelDifference `json:"differences"`
+			}{
+				StoreId:      storeId,
+				ModelId:      deployed.Id,
+				ModelVersion: modelVersion,
+				Differences:  diff,
+			}
+			if err := json.NewEncoder(cmd.OutOrStdout()).Encode(output); err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+		} else if len(diff) == 0 {
+			fmt.Printf("Model %s matches the embedded %s model\n", deployed.Id, modelVersion)
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "KIND\tNAME\tCHANGE")
+			for _, d := range diff {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", d.Kind, d.Name, d.Change)
+			}
+			w.Flush()
+		}
+
+		if len(diff) > 0 {
+			return fmt.Errorf("model %s differs from the embedded %s model in %d places", deployed.Id, modelVersion, len(diff))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fgaModelCmd)
+	fgaModelCmd.AddCommand(fgaModelShowCmd)
+	fgaModelCmd.AddCommand(fgaModelDiffCmd)
+
+	fgaModelShowCmd.Flags().String("format", "dsl", "Output format (dsl or json)")
+	fgaModelShowCmd.Flags().String("model-version", authorization.LatestModelVersion, "The authorization model version to print")
+
+	fgaModelDiffCmd.Flags().String("fga-api-url", "", "The openfga API URL")
+	fgaModelDiffCmd.Flags().String("fga-api-token", "", "The openfga API token")
+	fgaModelDiffCmd.Flags().String("fga-store-id", "", "The openfga store holding the deployed model")
+	fgaModelDiffCmd.Flags().String("fga-model-id", "", "The deployed authorization model ID, the latest model of the store when empty")
+	fgaModelDiffCmd.Flags().String("model-version", authorization.LatestModelVersion, "The authorization model version to compare with")
+	fgaModelDiffCmd.Flags().String("format", "text", "Output format (text or json)")
+	fgaModelDiffCmd.MarkFlagRequired("fga-api-url")
+	fgaModelDiffCmd.MarkFlagRequired("fga-api-token")
+	fgaModelDiffCmd.MarkFlagRequired("fga-store-id")
+}
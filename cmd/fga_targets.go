@@ -0,0 +1,273 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	storeIDKey = "OPENFGA_STORE_ID"
+	modelIDKey = "OPENFGA_AUTHORIZATION_MODEL_ID"
+)
+
+// modelVars are the environment variables configuring the store and model,
+// in the order they are written.
+func modelVars(storeId, modelId string) [][2]string {
+	return [][2]string{
+		{storeIDKey, storeId},
+		{modelIDKey, modelId},
+	}
+}
+
+// addModelTargetFlags registers the flags of the resources the store and
+// model IDs are written to, every flag can be repeated.
+func addModelTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("store-k8s-configmap-resource", nil, "The configmap resources to store the FGA Store ID and Model ID, format: namespace/name")
+	cmd.Flags().StringSlice("store-k8s-secret-resource", nil, "The secret resources to store the FGA Store ID and Model ID, format: namespace/name")
+	cmd.Flags().StringSlice("output-env-file", nil, "The dotenv files to store the FGA Store ID and Model ID, other variables are kept")
+	cmd.Flags().String("kubeconfig", "", "Path to the kubeconfig file (optional, defaults to in-cluster config)")
+}
+
+// writeModelTargets writes the store and model IDs to every resource of the
+// flags of addModelTargetFlags.
+func writeModelTargets(ctx context.Context, cmd *cobra.Command, storeId, modelId string) error {
+	configMaps, _ := cmd.Flags().GetStringSlice("store-k8s-configmap-resource")
+	secrets, _ := cmd.Flags().GetStringSlice("store-k8s-secret-resource")
+	envFiles, _ := cmd.Flags().GetStringSlice("output-env-file")
+	kubeconfigPath, _ := cmd.Flags().GetString("kubeconfig")
+
+	vars := modelVars(storeId, modelId)
+
+	for _, path := range envFiles {
+		if err := updateEnvFile(path, vars); err != nil {
+			return fmt.Errorf("failed to update env file %s: %w", path, err)
+		}
+		cmd.Printf("Env file %s updated successfully\n", path)
+	}
+
+	if len(configMaps) == 0 && len(secrets) == 0 {
+		return nil
+	}
+
+	clientset, err := kubernetesClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range configMaps {
+		if err := updateConfigMap(ctx, clientset, resource, vars); err != nil {
+			return fmt.Errorf("failed to update configmap: %w", err)
+		}
+		cmd.Printf("ConfigMap %s updated successfully\n", resource)
+	}
+	for _, resource := range secrets {
+		if err := updateSecret(ctx, clientset, resource, vars); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		cmd.Printf("Secret %s updated successfully\n", resource)
+	}
+	return nil
+}
+
+// printExports prints the store and model IDs as shell exports, to be
+// evaluated by a script.
+func printExports(w io.Writer, storeId, modelId string) error {
+	for _, v := range modelVars(storeId, modelId) {
+		if _, err := fmt.Fprintf(w, "export %s=%s\n", v[0], v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func kubernetesClient(kubeconfigPath string) (*kubernetes.Clientset, error) {
+	var config *rest.Config
+	var err error
+
+	if kubeconfigPath != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	} else {
+		config, err = rest.InClusterConfig()
+		if err != nil {
+			// Fallback to kubeconfig if in-cluster fails (e.g. running locally without flag)
+			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+			configOverrides := &clientcmd.ConfigOverrides{}
+			kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+			config, err = kubeConfig.ClientConfig()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	return clientset, nil
+}
+
+// splitResource splits a namespace/name resource.
+func splitResource(resource string) (string, string, error) {
+	namespace, name, ok := strings.Cut(resource, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid resource format: %s, expected namespace/name", resource)
+	}
+	return namespace, name, nil
+}
+
+func updateConfigMap(ctx context.Context, clientset kubernetes.Interface, resource string, vars [][2]string) error {
+	namespace, name, err := splitResource(resource)
+	if err != nil {
+		return err
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get configmap %s: %w", resource, err)
+		}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: make(map[string]string),
+		}
+		for _, v := range vars {
+			cm.Data[v[0]] = v[1]
+		}
+		if _, err := clientset.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create configmap %s: %w", resource, err)
+		}
+		return nil
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	for _, v := range vars {
+		cm.Data[v[0]] = v[1]
+	}
+
+	if _, err := clientset.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update configmap %s: %w", resource, err)
+	}
+	return nil
+}
+
+func updateSecret(ctx context.Context, clientset kubernetes.Interface, resource string, vars [][2]string) error {
+	namespace, name, err := splitResource(resource)
+	if err != nil {
+		return err
+	}
+
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %s: %w", resource, err)
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: make(map[string][]byte),
+		}
+		for _, v := range vars {
+			secret.Data[v[0]] = []byte(v[1])
+		}
+		if _, err := clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", resource, err)
+		}
+		return nil
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	for _, v := range vars {
+		secret.Data[v[0]] = []byte(v[1])
+	}
+
+	if _, err := clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", resource, err)
+	}
+	return nil
+}
+
+// updateEnvFile sets vars in the dotenv file of path, creating it if needed.
+func updateEnvFile(path string, vars [][2]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	perm := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	return os.WriteFile(path, mergeEnv(content, vars), perm)
+}
+
+// mergeEnv replaces the assignments of vars in the dotenv content, with or
+// without export, and appends the missing ones. Other lines are kept as is.
+func mergeEnv(content []byte, vars [][2]string) []byte {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		values[v[0]] = v[1]
+	}
+
+	var out bytes.Buffer
+	written := make(map[string]bool, len(vars))
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		assignment, exported := strings.CutPrefix(strings.TrimSpace(line), "export ")
+		key, _, ok := strings.Cut(assignment, "=")
+		key = strings.TrimSpace(key)
+		if value, set := values[key]; ok && set {
+			if !written[key] {
+				if exported {
+					out.WriteString("export ")
+				}
+				fmt.Fprintf(&out, "%s=%s\n", key, value)
+				written[key] = true
+			}
+			continue
+		}
+
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+
+	for _, v := range vars {
+		if !written[v[0]] {
+			fmt.Fprintf(&out, "%s=%s\n", v[0], v[1])
+		}
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMergeEnv(t *testing.T) {
+	vars := modelVars("store-2", "model-2")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "New file",
+			content:  "",
+			expected: "OPENFGA_STORE_ID=store-2\nOPENFGA_AUTHORIZATION_MODEL_ID=model-2\n",
+		},
+		{
+			name:     "Other variables kept",
+			content:  "# tenant-service\nDSN=postgres://db\nOPENFGA_STORE_ID=store-1\n",
+			expected: "# tenant-service\nDSN=postgres://db\nOPENFGA_STORE_ID=store-2\nOPENFGA_AUTHORIZATION_MODEL_ID=model-2\n",
+		},
+		{
+			name:     "Exports kept",
+			content:  "export OPENFGA_AUTHORIZATION_MODEL_ID=model-1\nexport OPENFGA_STORE_ID=store-1",
+			expected: "export OPENFGA_AUTHORIZATION_MODEL_ID=model-2\nexport OPENFGA_STORE_ID=store-2\n",
+		},
+		{
+			name:     "Duplicates dropped",
+			content:  "OPENFGA_STORE_ID=store-0\nOPENFGA_STORE_ID=store-1\nOPENFGA_AUTHORIZATION_MODEL_ID=model-1\n",
+			expected: "OPENFGA_STORE_ID=store-2\nOPENFGA_AUTHORIZATION_MODEL_ID=model-2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(mergeEnv([]byte(tt.content), vars)); got != tt.expected {
+				t.Errorf("mergeEnv() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrintExports(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printExports(&buf, "store-1", "model-1"); err != nil {
+		t.Fatalf("printExports() error = %v", err)
+	}
+
+	expected := "export OPENFGA_STORE_ID=store-1\nexport OPENFGA_AUTHORIZATION_MODEL_ID=model-1\n"
+	if buf.String() != expected {
+		t.Errorf("printExports() = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestUpdateKubernetesResources(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+			Data:       map[string]string{"OTHER": "kept", storeIDKey: "store-1"},
+		},
+	)
+	vars := modelVars("store-2", "model-2")
+
+	for _, resource := range []string{"default/existing", "default/created"} {
+		if err := updateConfigMap(ctx, clientset, resource, vars); err != nil {
+			t.Fatalf("updateConfigMap(%s) error = %v", resource, err)
+		}
+		if err := updateSecret(ctx, clientset, resource, vars); err != nil {
+			t.Fatalf("updateSecret(%s) error = %v", resource, err)
+		}
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps("default").Get(ctx, "existing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["OTHER"] != "kept" || cm.Data[storeIDKey] != "store-2" || cm.Data[modelIDKey] != "model-2" {
+		t.Errorf("unexpected configmap data %v", cm.Data)
+	}
+
+	secret, err := clientset.CoreV1().Secrets("default").Get(ctx, "created", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[storeIDKey]) != "store-2" || string(secret.Data[modelIDKey]) != "model-2" {
+		t.Errorf("unexpected secret data %v", secret.Data)
+	}
+
+	if err := updateSecret(ctx, clientset, "default", vars); err == nil {
+		t.Error("expected a resource without a name to be rejected")
+	}
+}
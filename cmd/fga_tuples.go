@@ -0,0 +1,261 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/audit"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+var fgaCmd = &cobra.Command{
+	Use:   "fga",
+	Short: "Inspect and repair the OpenFGA authorization state",
+}
+
+var fgaTuplesCmd = &cobra.Command{
+	Use:   "tuples",
+	Short: "Manage the tuples on a tenant",
+	Long: `List, write and delete the tuples whose object is a tenant.
+
+Changes go through the same authorizer as the service and are recorded in the
+authorization audit trail, attributed to --actor.`,
+}
+
+var fgaTuplesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the tuples on a tenant",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		authorizer, _, closeDB, err := newTuplesAuthorizer(cmd, false)
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		tuples, err := authorizer.ListTenantTuples(cmd.Context(), tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to list tuples: %w", err)
+		}
+
+		rows := make([]tupleRow, len(tuples))
+		for i, t := range tuples {
+			rows[i] = tupleRow{User: t.User, Relation: t.Relation, Object: t.Object}
+		}
+		if ok, err := printValue(cmd.OutOrStdout(), rows); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "USER\tRELATION\tOBJECT")
+		for _, r := range rows {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.User, r.Relation, r.Object)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var fgaTuplesWriteCmd = &cobra.Command{
+	Use:   "write <user> <relation>",
+	Short: "Write a tuple on a tenant",
+	Long: `Write the tuple <user> <relation> tenant:<id>.
+
+The user is a user ID or a typed object such as role:<id>#assignee.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeTuple(cmd, args, authorization.AuditOperationWrite)
+	},
+}
+
+var fgaTuplesDeleteCmd = &cobra.Command{
+	Use:   "delete <user> <relation>",
+	Short: "Delete a tuple from a tenant",
+	Long: `Delete the tuple <user> <relation> tenant:<id>.
+
+The user is a user ID or a typed object such as role:<id>#assignee.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeTuple(cmd, args, authorization.AuditOperationDelete)
+	},
+}
+
+func init() {
+	flags := fgaTuplesCmd.PersistentFlags()
+	flags.String("tenant", "", "ID of the tenant")
+	flags.String("dsn", "", "PostgreSQL DSN connection string, required to record changes")
+	flags.String("fga-api-url", "", "The openfga API URL")
+	flags.String("fga-api-token", "", "The openfga API token")
+	flags.String("fga-store-id", "", "The openfga store holding the tenant relations")
+	flags.String("fga-model-id", "", "The openfga authorization model ID")
+	_ = fgaTuplesCmd.MarkPersistentFlagRequired("tenant")
+	_ = fgaTuplesCmd.MarkPersistentFlagRequired("fga-api-url")
+	_ = fgaTuplesCmd.MarkPersistentFlagRequired("fga-api-token")
+	_ = fgaTuplesCmd.MarkPersistentFlagRequired("fga-store-id")
+
+	for _, c := range []*cobra.Command{fgaTuplesWriteCmd, fgaTuplesDeleteCmd} {
+		c.Flags().String("actor", "", "Principal the change is recorded for, the OS user when empty")
+		c.Flags().Bool("force", false, "Skip the confirmation prompt")
+	}
+
+	rootCmd.AddCommand(fgaCmd)
+	fgaCmd.AddCommand(fgaTuplesCmd)
+	fgaTuplesCmd.AddCommand(fgaTuplesListCmd)
+	fgaTuplesCmd.AddCommand(fgaTuplesWriteCmd)
+	fgaTuplesCmd.AddCommand(fgaTuplesDeleteCmd)
+}
+
+// tupleRow is the --output representation of a tuple.
+type tupleRow struct {
+	User     string `json:"user"`
+	Relation string `json:"relation"`
+	Object   string `json:"object"`
+}
+
+// changeTuple writes or deletes the tuple of args once confirmed, the audit
+// entry is recorded in the same transaction.
+func changeTuple(cmd *cobra.Command, args []string, operation string) error {
+	tenantID, _ := cmd.Flags().GetString("tenant")
+	actor, _ := cmd.Flags().GetString("actor")
+
+	tuple, err := tenantTuple(tenantID, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if actor == "" {
+		actor, err = osActor()
+		if err != nil {
+			return err
+		}
+	}
+
+	warning := fmt.Sprintf("The tuple %s %s %s will be %sd.", tuple.User, tuple.Relation, tuple.Object, operation)
+	if err := confirmAction(cmd, warning); err != nil {
+		return err
+	}
+
+	authorizer, dbClient, closeDB, err := newTuplesAuthorizer(cmd, true)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := authentication.WithUserID(cmd.Context(), actor)
+	err = dbClient.WithTx(ctx, func(ctx context.Context) error {
+		if operation == authorization.AuditOperationWrite {
+			return authorizer.WriteTuples(ctx, tuple)
+		}
+		return authorizer.DeleteTuples(ctx, tuple)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s tuple: %w", operation, err)
+	}
+
+	cmd.Printf("Tuple %s %s %s %sd by %s\n", tuple.User, tuple.Relation, tuple.Object, operation, actor)
+	return nil
+}
+
+// tenantTuple builds the tuple of user and relation on the tenant, a user
+// without a type is taken as a user ID.
+func tenantTuple(tenantID, user, relation string) (openfga.Tuple, error) {
+	if tenantID == "" {
+		return openfga.Tuple{}, errors.New("--tenant must not be empty")
+	}
+	if user == "" || relation == "" {
+		return openfga.Tuple{}, errors.New("user and relation must not be empty")
+	}
+	if strings.ContainsAny(relation, ":#") {
+		return openfga.Tuple{}, fmt.Errorf("invalid relation %q", relation)
+	}
+	if !strings.Contains(user, ":") {
+		user = authorization.UserTuple(user)
+	}
+	return *openfga.NewTuple(user, relation, authorization.TenantTuple(tenantID)), nil
+}
+
+// osActor names the principal of a change made from the command line.
+func osActor() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the OS user, use --actor: %w", err)
+	}
+	return "cli:" + u.Username, nil
+}
+
+// newTuplesAuthorizer builds the authorizer of the tuples commands. With
+// audited set the database is required and the authorizer records its
+// changes there, otherwise no database client is returned.
+func newTuplesAuthorizer(cmd *cobra.Command, audited bool) (*authorization.Authorizer, *db.DBClient, func(), error) {
+	dsn, _ := cmd.Flags().GetString("dsn")
+	apiUrl, _ := cmd.Flags().GetString("fga-api-url")
+	apiToken, _ := cmd.Flags().GetString("fga-api-token")
+	storeId, _ := cmd.Flags().GetString("fga-store-id")
+	modelId, _ := cmd.Flags().GetString("fga-model-id")
+
+	logger := logging.NewNoopLogger()
+	tracer := tracing.NewNoopTracer()
+	monitor := monitoring.NewNoopMonitor("", logger)
+
+	scheme, host, err := parseURL(apiUrl)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	fgaClient := openfga.NewClient(&openfga.Config{
+		ApiScheme:   scheme,
+		ApiHost:     host,
+		StoreID:     storeId,
+		ApiToken:    apiToken,
+		AuthModelID: modelId,
+		Tracer:      tracer,
+		Monitor:     monitor,
+		Logger:      logger,
+	})
+	authorizer := authorization.NewAuthorizer(fgaClient, tracer, monitor, logger)
+
+	if !audited {
+		return authorizer, nil, func() {}, nil
+	}
+	if dsn == "" {
+		return nil, nil, nil, errors.New("--dsn is required to record the change in the audit trail")
+	}
+
+	dbClient, err := db.NewDBClient(
+		db.Config{
+			DSN:             dsn,
+			MaxConns:        2,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: time.Minute,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create database client: %w", err)
+	}
+
+	authorizer.SetAuditor(audit.NewRecorder(storage.NewStorage(dbClient, tracer, monitor, logger), tracer, monitor, logger))
+	return authorizer, dbClient, dbClient.Close, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/openfga"
+)
+
+func TestTenantTuple(t *testing.T) {
+	tests := []struct {
+		name      string
+		tenantID  string
+		user      string
+		relation  string
+		expected  openfga.Tuple
+		wantError bool
+	}{
+		{
+			name:     "User ID",
+			tenantID: "tenant-1",
+			user:     "user-1",
+			relation: "member",
+			expected: openfga.Tuple{User: "user:user-1", Relation: "member", Object: "tenant:tenant-1"},
+		},
+		{
+			name:     "Typed userset",
+			tenantID: "tenant-1",
+			user:     "role:role-1#assignee",
+			relation: "viewer",
+			expected: openfga.Tuple{User: "role:role-1#assignee", Relation: "viewer", Object: "tenant:tenant-1"},
+		},
+		{name: "No tenant", user: "user-1", relation: "member", wantError: true},
+		{name: "No user", tenantID: "tenant-1", relation: "member", wantError: true},
+		{name: "Typed relation", tenantID: "tenant-1", user: "user-1", relation: "tenant:member", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tuple, err := tenantTuple(tt.tenantID, tt.user, tt.relation)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("tenantTuple() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tuple != tt.expected {
+				t.Errorf("tenantTuple() = %+v, want %+v", tuple, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with the identity provider and cache the token",
+	Long: `Log in with the identity provider and cache the token for the other commands.
+
+The authorization code flow opens the consent page in a browser and receives
+the code on --listen-address, which must be a redirect URI of the client as
+http://<listen-address>/callback. --device uses the device flow instead, to
+log in from another device.
+
+The token is cached in the user configuration directory, readable by the user
+only, and refreshed by the commands once expired. --token takes precedence
+over the cached token.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issuer, _ := cmd.Flags().GetString("issuer-url")
+		clientID, _ := cmd.Flags().GetString("client-id")
+		clientSecret, _ := cmd.Flags().GetString("client-secret")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		device, _ := cmd.Flags().GetBool("device")
+		listenAddress, _ := cmd.Flags().GetString("listen-address")
+		noBrowser, _ := cmd.Flags().GetBool("no-browser")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		provider, err := oidc.NewProvider(ctx, issuer)
+		if err != nil {
+			return fmt.Errorf("failed to discover the issuer: %w", err)
+		}
+
+		config := &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		}
+
+		var token *oauth2.Token
+		if device {
+			token, err = deviceLogin(ctx, config)
+		} else {
+			token, err = browserLogin(ctx, config, listenAddress, !noBrowser)
+		}
+		if err != nil {
+			return err
+		}
+
+		login := &cachedLogin{
+			IssuerURL:    issuer,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     config.Endpoint.TokenURL,
+			Scopes:       scopes,
+			Token:        token,
+		}
+		if err := writeTokenCache(login); err != nil {
+			return err
+		}
+
+		if token.RefreshToken == "" {
+			fmt.Fprintln(os.Stderr, "No refresh token issued, request the offline_access scope to stay logged in.")
+		}
+		fmt.Printf("Logged in to %s, the token expires at %s\n", issuer, token.Expiry.Format(time.RFC3339))
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the token cached by login",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := removeTokenCache()
+		if err != nil {
+			return err
+		}
+
+		if !removed {
+			fmt.Println("Not logged in, nothing to remove")
+			return nil
+		}
+		fmt.Println("Logged out")
+		return nil
+	},
+}
+
+// browserLogin runs the authorization code flow with PKCE, the code is
+// received by a server on listenAddress.
+func browserLogin(ctx context.Context, config *oauth2.Config, listenAddress string, openBrowser bool) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the callback: %w", err)
+	}
+	defer listener.Close()
+
+	config.RedirectURL = fmt.Sprintf("http://%s/callback", listenAddress)
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	type result struct {
+		token *oauth2.Token
+		err   error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "Invalid state", http.StatusBadRequest)
+			return
+		}
+
+		var res result
+		if e := q.Get("error"); e != "" {
+			res.err = fmt.Errorf("login refused: %s %s", e, q.Get("error_description"))
+		} else {
+			res.token, res.err = config.Exchange(ctx, q.Get("code"), oauth2.VerifierOption(verifier))
+			if res.err != nil {
+				res.err = fmt.Errorf("failed to exchange the code: %w", res.err)
+			}
+		}
+
+		if res.err != nil {
+			http.Error(w, "Login failed, see the terminal.", http.StatusUnauthorized)
+		} else {
+			fmt.Fprintln(w, "Logged in, you can close this page.")
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	url := config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	fmt.Fprintf(os.Stderr, "Open the following URL to log in:\n\n  %s\n\n", url)
+	if openBrowser {
+		browse(url)
+	}
+
+	select {
+	case res := <-results:
+		return res.token, res.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no login before the timeout: %w", ctx.Err())
+	}
+}
+
+// deviceLogin runs the device authorization flow, the user logs in from
+// any device with the printed code.
+func deviceLogin(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	if config.Endpoint.DeviceAuthURL == "" {
+		return nil, errors.New("the issuer does not support the device flow")
+	}
+
+	resp, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the device flow: %w", err)
+	}
+
+	if resp.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "Open the following URL to log in:\n\n  %s\n\n", resp.VerificationURIComplete)
+	} else {
+		fmt.Fprintf(os.Stderr, "Open %s and enter the code %s to log in\n", resp.VerificationURI, resp.UserCode)
+	}
+
+	token, err := config.DeviceAccessToken(ctx, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the token: %w", err)
+	}
+	return token, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate the login state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// browse opens url in the default browser, the URL is printed anyway when
+// it cannot.
+func browse(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	_ = cmd.Start()
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+
+	loginCmd.Flags().String("issuer-url", "", "Issuer URL (for OIDC discovery)")
+	loginCmd.Flags().String("client-id", "", "Client ID")
+	loginCmd.Flags().String("client-secret", "", "Client Secret, unset for a public client")
+	loginCmd.Flags().StringSlice("scopes", []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}, "Scopes (comma-separated)")
+	loginCmd.Flags().Bool("device", false, "Use the device flow instead of a browser on this machine")
+	loginCmd.Flags().String("listen-address", "127.0.0.1:8085", "Address receiving the authorization code")
+	loginCmd.Flags().Bool("no-browser", false, "Only print the login URL")
+	loginCmd.Flags().Duration("timeout", 5*time.Minute, "Time allowed to log in")
+
+	_ = loginCmd.MarkFlagRequired("issuer-url")
+	_ = loginCmd.MarkFlagRequired("client-id")
+}
@@ -5,22 +5,33 @@ package cmd
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"text/template"
 	"time"
+	"unicode"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
 	"github.com/spf13/cobra"
 
 	"github.com/canonical/tenant-service/migrations"
 )
 
+// defaultMigrationWait is how long the migrations wait for the advisory lock
+// held by another replica.
+const defaultMigrationWait = 5 * time.Minute
+
 // migrateCmd performs DB migrations
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
@@ -42,7 +53,7 @@ func customValidArgs() func(cmd *cobra.Command, args []string) error {
 
 		first := args[0]
 		switch first {
-		case "up", "down", "status", "check":
+		case "up", "down", "status", "check", "verify":
 			// valid first argument
 		default:
 			return fmt.Errorf("invalid first argument: %q", first)
@@ -77,47 +88,99 @@ func runMigrate() func(cmd *cobra.Command, args []string) {
 
 		dsn, _ := cmd.Flags().GetString("dsn")
 		format, _ := cmd.Flags().GetString("format")
+		wait, _ := cmd.Flags().GetDuration("wait-timeout")
 
-		if err := migrate(cmd, dsn, command, format, version); err != nil {
+		if err := migrate(cmd, dsn, command, format, version, wait); err != nil {
 			cmd.PrintErr(err)
 			os.Exit(1)
 		}
 	}
 }
 
+// migrateCreateCmd scaffolds a new migration
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name> [sql|go]",
+	Short: "Create a new migration file",
+	Long: `Create a new SQL or Go migration in the migrations directory.
+
+Migrations are numbered after the last one of the directory, --timestamp
+versions them with the current UTC time instead. Go migrations register
+themselves with goose and are built into the binary.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		timestamp, _ := cmd.Flags().GetBool("timestamp")
+
+		kind := "sql"
+		if len(args) > 1 {
+			kind = args[1]
+		}
+
+		path, err := createMigration(dir, args[0], kind, timestamp, time.Now())
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Created %s\n", path)
+		return nil
+	},
+}
+
+// migrateRedoCmd rolls back and reapplies the latest migration
+var migrateRedoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Roll back and reapply the latest migration",
+	Long: `Roll back the latest applied migration and apply it again, to iterate on
+a migration during local development.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dsn, _ := cmd.Flags().GetString("dsn")
+		format, _ := cmd.Flags().GetString("format")
+		wait, _ := cmd.Flags().GetDuration("wait-timeout")
+
+		lock, err := migrationLock(wait)
+		if err != nil {
+			return err
+		}
+
+		db, provider, err := migrationProvider(cmd.Context(), dsn, format == "json", lock)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		return runRedo(cmd.Context(), db, provider, format, cmd.OutOrStdout())
+	},
+}
+
 func init() {
 	migrateCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
 	migrateCmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	migrateCmd.Flags().Duration("wait-timeout", defaultMigrationWait, "How long to wait for another replica holding the migration lock")
 	_ = migrateCmd.MarkFlagRequired("dsn")
 
+	migrateCreateCmd.Flags().String("dir", "migrations", "Directory of the migrations")
+	migrateCreateCmd.Flags().Bool("timestamp", false, "Version the migration with the current time")
+
+	migrateRedoCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	migrateRedoCmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	migrateRedoCmd.Flags().Duration("wait-timeout", defaultMigrationWait, "How long to wait for another replica holding the migration lock")
+	_ = migrateRedoCmd.MarkFlagRequired("dsn")
+
 	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateCreateCmd)
+	migrateCmd.AddCommand(migrateRedoCmd)
 }
 
-func migrate(cmd *cobra.Command, dsn, command, format string, version int) error {
-	config, err := pgx.ParseConfig(dsn)
+func migrate(cmd *cobra.Command, dsn, command, format string, version int, wait time.Duration) error {
+	lock, err := migrationLock(wait)
 	if err != nil {
-		return fmt.Errorf("DSN validation failed, shutting down, err: %v", err)
-	}
-
-	db := stdlib.OpenDB(*config)
-
-	if err := db.PingContext(cmd.Context()); err != nil {
-		return fmt.Errorf("DB connection failed, shutting down, err: %v", err)
-	}
-	goose.SetBaseFS(migrations.EmbedMigrations)
-
-	if err := goose.SetDialect("postgres"); err != nil {
 		return err
 	}
 
-	var opts []goose.ProviderOption
-	if format == "json" {
-		opts = append(opts, goose.WithLogger(goose.NopLogger()))
-	}
-
-	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.EmbedMigrations, opts...)
+	db, provider, err := migrationProvider(cmd.Context(), dsn, format == "json", lock)
 	if err != nil {
-		return fmt.Errorf("failed to create goose provider: %w", err)
+		return err
 	}
 
 	ctx := cmd.Context()
@@ -125,23 +188,78 @@ func migrate(cmd *cobra.Command, dsn, command, format string, version int) error
 
 	switch command {
 	case "up":
-		return runUp(ctx, provider, format, out)
+		return runUp(ctx, db, provider, format, out)
 	case "down":
-		return runDown(ctx, provider, version, format, out)
+		return runDown(ctx, db, provider, version, format, out)
 	case "status":
 		return runStatus(ctx, provider, format, out)
 	case "check":
 		return runCheck(ctx, provider, format, out)
+	case "verify":
+		return runVerify(ctx, db, provider, format, out)
 	}
 
 	return nil
 }
 
-func runUp(ctx context.Context, provider *goose.Provider, format string, out io.Writer) error {
+// migrationLock serializes the migrations applied or rolled back by several
+// replicas with a Postgres advisory lock, waiting up to wait for it.
+func migrationLock(wait time.Duration) (goose.ProviderOption, error) {
+	locker, err := lock.NewPostgresSessionLocker(
+		lock.WithLockTimeout(1, migrationLockAttempts(wait)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the migration lock: %w", err)
+	}
+	return goose.WithSessionLocker(locker), nil
+}
+
+// migrationLockAttempts is the number of attempts, one a second, to take the
+// migration lock within wait. The lock is tried at least once.
+func migrationLockAttempts(wait time.Duration) uint64 {
+	return uint64(max(1, (wait+time.Second-1)/time.Second))
+}
+
+// migrationProvider connects to the database of dsn and returns the provider
+// of the embedded migrations, quiet silences the goose logs.
+func migrationProvider(ctx context.Context, dsn string, quiet bool, opts ...goose.ProviderOption) (*sql.DB, *goose.Provider, error) {
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("DSN validation failed, shutting down, err: %v", err)
+	}
+
+	db := stdlib.OpenDB(*config)
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, nil, fmt.Errorf("DB connection failed, shutting down, err: %v", err)
+	}
+	goose.SetBaseFS(migrations.EmbedMigrations)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return nil, nil, err
+	}
+
+	if quiet {
+		opts = append(opts, goose.WithLogger(goose.NopLogger()))
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.EmbedMigrations, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create goose provider: %w", err)
+	}
+
+	return db, provider, nil
+}
+
+func runUp(ctx context.Context, db *sql.DB, provider *goose.Provider, format string, out io.Writer) error {
 	results, err := provider.Up(ctx)
 	if err != nil {
 		return err
 	}
+
+	if err := recordChecksums(ctx, db, provider); err != nil {
+		return err
+	}
 	if format == "json" {
 		if results == nil {
 			results = []*goose.MigrationResult{}
@@ -153,7 +271,7 @@ func runUp(ctx context.Context, provider *goose.Provider, format string, out io.
 	return nil
 }
 
-func runDown(ctx context.Context, provider *goose.Provider, version int, format string, out io.Writer) error {
+func runDown(ctx context.Context, db *sql.DB, provider *goose.Provider, version int, format string, out io.Writer) error {
 	var results []*goose.MigrationResult
 	var err error
 
@@ -171,6 +289,14 @@ func runDown(ctx context.Context, provider *goose.Provider, version int, format
 		return err
 	}
 
+	current, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if err := migrations.DeleteChecksums(ctx, db, current); err != nil {
+		return fmt.Errorf("failed to delete the checksums of the rolled back migrations: %w", err)
+	}
+
 	if format == "json" {
 		if results == nil {
 			results = []*goose.MigrationResult{}
@@ -182,6 +308,62 @@ func runDown(ctx context.Context, provider *goose.Provider, version int, format
 	return nil
 }
 
+// applyMigrations applies the pending migrations under the migration lock,
+// for the commands running them on startup.
+func applyMigrations(ctx context.Context, dsn string, wait time.Duration) ([]*goose.MigrationResult, error) {
+	lock, err := migrationLock(wait)
+	if err != nil {
+		return nil, err
+	}
+
+	db, provider, err := migrationProvider(ctx, dsn, true, lock)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordChecksums(ctx, db, provider); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// runRedo rolls back the latest migration and applies it again, keeping the
+// recorded checksums in line.
+func runRedo(ctx context.Context, db *sql.DB, provider *goose.Provider, format string, out io.Writer) error {
+	down, err := provider.Down(ctx)
+	if err != nil {
+		return err
+	}
+
+	current, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if err := migrations.DeleteChecksums(ctx, db, current); err != nil {
+		return fmt.Errorf("failed to delete the checksums of the rolled back migrations: %w", err)
+	}
+
+	up, err := provider.UpByOne(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := recordChecksums(ctx, db, provider); err != nil {
+		return err
+	}
+	if format == "json" {
+		return json.NewEncoder(out).Encode(map[string]interface{}{
+			"applied": []*goose.MigrationResult{down, up},
+		})
+	}
+	return nil
+}
+
 func runStatus(ctx context.Context, provider *goose.Provider, format string, out io.Writer) error {
 	statuses, err := provider.Status(ctx)
 	if err != nil {
@@ -242,3 +424,207 @@ func runCheck(ctx context.Context, provider *goose.Provider, format string, out
 	}
 	return nil
 }
+
+// appliedVersions returns the versions of the applied migrations.
+func appliedVersions(ctx context.Context, provider *goose.Provider) ([]int64, error) {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(statuses))
+	for _, s := range statuses {
+		if s.State == goose.StateApplied {
+			versions = append(versions, s.Source.Version)
+		}
+	}
+	return versions, nil
+}
+
+// recordChecksums records the checksums of the applied migrations, including
+// the ones applied before checksums were recorded.
+func recordChecksums(ctx context.Context, db *sql.DB, provider *goose.Provider) error {
+	applied, err := appliedVersions(ctx, provider)
+	if err != nil {
+		return err
+	}
+
+	checksums, err := migrations.Checksums(migrations.EmbedMigrations)
+	if err != nil {
+		return err
+	}
+
+	checksums = slices.DeleteFunc(checksums, func(c migrations.Checksum) bool {
+		return !slices.Contains(applied, c.Version)
+	})
+
+	return migrations.RecordChecksums(ctx, db, checksums)
+}
+
+func runVerify(ctx context.Context, db *sql.DB, provider *goose.Provider, format string, out io.Writer) error {
+	applied, err := appliedVersions(ctx, provider)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := migrations.ReadChecksums(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read the migration checksums: %w", err)
+	}
+
+	// recorded versions no longer embedded were applied by another release
+	for version := range recorded {
+		if !slices.Contains(applied, version) {
+			applied = append(applied, version)
+		}
+	}
+	slices.Sort(applied)
+
+	embedded, err := migrations.Checksums(migrations.EmbedMigrations)
+	if err != nil {
+		return err
+	}
+
+	verifications := migrations.Verify(applied, recorded, embedded)
+
+	drifted := 0
+	for _, v := range verifications {
+		if v.Status == migrations.ChecksumModified || v.Status == migrations.ChecksumMissing {
+			drifted++
+		}
+	}
+
+	if format == "json" {
+		status := "ok"
+		if drifted > 0 {
+			status = "drift"
+		}
+		if err := json.NewEncoder(out).Encode(map[string]interface{}{
+			"status":     status,
+			"migrations": verifications,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, v := range verifications {
+			path := v.Path
+			if path == "" {
+				path = fmt.Sprintf("version %d", v.Version)
+			}
+			fmt.Fprintf(out, "%-10s %s\n", v.Status, path)
+		}
+	}
+
+	if drifted > 0 {
+		return fmt.Errorf("%d applied migrations differ from the embedded ones", drifted)
+	}
+	return nil
+}
+
+var migrationTemplates = map[string]*template.Template{
+	"sql": template.Must(template.New("sql").Parse(`--  Copyright {{.Year}} Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- +goose StatementEnd
+`)),
+	"go": template.Must(template.New("go").Parse(`// Copyright {{.Year}} Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(up{{.CamelName}}, down{{.CamelName}})
+}
+
+func up{{.CamelName}}(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func down{{.CamelName}}(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+`)),
+}
+
+// createMigration writes an empty migration of kind sql or go to dir and
+// returns its path. The version follows the last migration of dir, or is the
+// time now with timestamp set.
+func createMigration(dir, name, kind string, timestamp bool, now time.Time) (string, error) {
+	tmpl, ok := migrationTemplates[kind]
+	if !ok {
+		return "", fmt.Errorf("invalid migration type %q, must be sql or go", kind)
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", fmt.Errorf("invalid migration name %q", name)
+	}
+
+	version := now.UTC().Format("20060102150405")
+	if !timestamp {
+		last, err := lastMigrationVersion(dir)
+		if err != nil {
+			return "", err
+		}
+		version = fmt.Sprintf("%03d", last+1)
+	}
+
+	camel := make([]string, len(words))
+	for i, w := range words {
+		camel[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.%s", version, strings.Join(words, "_"), kind))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create migration file: %w", err)
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, map[string]any{
+		"Year":      now.Year(),
+		"CamelName": strings.Join(camel, "") + version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to write migration file: %w", err)
+	}
+	return path, nil
+}
+
+// lastMigrationVersion returns the highest version of the migrations of dir.
+func lastMigrationVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the migrations directory: %w", err)
+	}
+
+	var last int64
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".sql" && ext != ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		// files without a numeric prefix, such as migration.go, are not migrations
+		if version, err := goose.NumericComponent(e.Name()); err == nil {
+			last = max(last, version)
+		}
+	}
+	return last, nil
+}
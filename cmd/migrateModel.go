@@ -0,0 +1,217 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+var migrateModelCmd = &cobra.Command{
+	Use:   "migrate-model",
+	Short: "Upgrade an openfga store to a newer authorization model version",
+	Long: `Upgrade an existing openfga store from one authorization model version to a
+later one: the new model is written to the store, then the tuples holding
+relations renamed in between are rewritten, as are the tuples of the members
+whose role is mapped to another relation, read from the database of --dsn.
+
+The command is safe to rerun after a failure. Instances keep enforcing their
+configured model until OPENFGA_AUTHORIZATION_MODEL_ID is updated to the model
+ID it prints, use --dry-run to list the tuples that would be rewritten.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiUrl, _ := cmd.Flags().GetString("fga-api-url")
+		apiToken, _ := cmd.Flags().GetString("fga-api-token")
+		storeId, _ := cmd.Flags().GetString("fga-store-id")
+		from, _ := cmd.Flags().GetString("from-version")
+		to, _ := cmd.Flags().GetString("to-version")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		dsn, _ := cmd.Flags().GetString("dsn")
+		format, _ := cmd.Flags().GetString("format")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		renames, err := authorization.MigrationRenames(from, to)
+		if err != nil {
+			return err
+		}
+		remaps, err := authorization.MigrationRemaps(from, to)
+		if err != nil {
+			return err
+		}
+		if len(remaps) > 0 && dsn == "" {
+			return fmt.Errorf("migrating from %s to %s rewrites the tuples of the %s members, --dsn is required", from, to, remaps[0].Role)
+		}
+
+		modelId := ""
+		if !dryRun {
+			// the renamed relations must exist before the tuples are rewritten
+			modelId, _, err = createModel(apiUrl, apiToken, storeId, to, verbose)
+			if err != nil {
+				return err
+			}
+		}
+
+		logger := logging.NewNoopLogger()
+		tracer := tracing.NewNoopTracer()
+		monitor := monitoring.NewNoopMonitor("", logger)
+
+		scheme, host, err := parseURL(apiUrl)
+		if err != nil {
+			return fmt.Errorf("failed to parse url: %w", err)
+		}
+
+		// no model ID, tuples are validated against the model just written
+		fgaClient := openfga.NewClient(&openfga.Config{
+			ApiScheme: scheme,
+			ApiHost:   host,
+			StoreID:   storeId,
+			ApiToken:  apiToken,
+			Debug:     verbose,
+			Tracer:    tracer,
+			Monitor:   monitor,
+			Logger:    logger,
+		})
+
+		authorizer := authorization.NewAuthorizer(fgaClient, tracer, monitor, logger)
+		rewritten, err := authorizer.RenameRelations(cmd.Context(), renames, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to rewrite tuples: %w", err)
+		}
+
+		if len(remaps) > 0 {
+			members, err := listMemberships(cmd.Context(), dsn)
+			if err != nil {
+				return fmt.Errorf("failed to list memberships: %w", err)
+			}
+			remapped, err := authorizer.RemapRoles(cmd.Context(), remaps, members, dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to rewrite tuples: %w", err)
+			}
+			rewritten = append(rewritten, remapped...)
+		}
+
+		if dsn != "" && !dryRun {
+			if err := recordModel(cmd.Context(), dsn, storeId, modelId, to); err != nil {
+				return fmt.Errorf("failed to record model: %w", err)
+			}
+		}
+
+		if format == "json" {
+			type tuple struct {
+				User     string `json:"user"`
+				Relation string `json:"relation"`
+				Object   string `json:"object"`
+			}
+			tuples := make([]tuple, len(rewritten))
+			for i, t := range rewritten {
+				tuples[i] = tuple{User: t.User, Relation: t.Relation, Object: t.Object}
+			}
+
+			output := struct {
+				StoreId string  `json:"store_id"`
+				ModelId string  `json:"model_id"`
+				From    string  `json:"from_version"`
+				To      string  `json:"to_version"`
+				DryRun  bool    `json:"dry_run"`
+				Tuples  []tuple `json:"rewritten_tuples"`
+			}{
+				StoreId: storeId,
+				ModelId: modelId,
+				From:    from,
+				To:      to,
+				DryRun:  dryRun,
+				Tuples:  tuples,
+			}
+			if err := json.NewEncoder(cmd.OutOrStdout()).Encode(output); err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "USER\tRELATION\tOBJECT")
+		for _, t := range rewritten {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", t.User, t.Relation, t.Object)
+		}
+		w.Flush()
+
+		if dryRun {
+			cmd.Printf("Dry run, %d tuples would be rewritten from %s to %s\n", len(rewritten), from, to)
+		} else {
+			cmd.Printf("Migrated store %s from %s to %s with model %s, %d tuples rewritten\n", storeId, from, to, modelId, len(rewritten))
+		}
+		return nil
+	},
+}
+
+// listMemberships returns the memberships of every tenant.
+func listMemberships(ctx context.Context, dsn string) ([]*types.Membership, error) {
+	logger := logging.NewNoopLogger()
+	tracer := tracing.NewNoopTracer()
+	monitor := monitoring.NewNoopMonitor("", logger)
+
+	dbClient, err := db.NewDBClient(
+		db.Config{
+			DSN:             dsn,
+			MaxConns:        1,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: time.Minute,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database client: %w", err)
+	}
+	defer dbClient.Close()
+
+	s := storage.NewStorage(dbClient, tracer, monitor, logger)
+	tenants, err := s.ListTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*types.Membership
+	for _, t := range tenants {
+		ms, err := s.ListMembersByTenantID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, ms...)
+	}
+	return members, nil
+}
+
+func init() {
+	migrateModelCmd.Flags().String("fga-api-url", "", "The openfga API URL")
+	migrateModelCmd.Flags().String("fga-api-token", "", "The openfga API token")
+	migrateModelCmd.Flags().String("fga-store-id", "", "The openfga store to upgrade")
+	migrateModelCmd.Flags().String("from-version", "", "The authorization model version the store currently holds")
+	migrateModelCmd.Flags().String("to-version", authorization.LatestModelVersion, "The authorization model version to upgrade to")
+	migrateModelCmd.Flags().Bool("dry-run", false, "List the tuples to rewrite without changing the store")
+	migrateModelCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string, when set the new model is recorded for the status API, required to remap roles")
+	migrateModelCmd.Flags().String("format", "text", "Output format (text or json)")
+	migrateModelCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
+	_ = migrateModelCmd.MarkFlagRequired("fga-api-url")
+	_ = migrateModelCmd.MarkFlagRequired("fga-api-token")
+	_ = migrateModelCmd.MarkFlagRequired("fga-store-id")
+	_ = migrateModelCmd.MarkFlagRequired("from-version")
+
+	rootCmd.AddCommand(migrateModelCmd)
+}
@@ -4,7 +4,11 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -50,6 +54,16 @@ func TestCustomValidArgs(t *testing.T) {
 			args:      []string{"check"},
 			wantError: false,
 		},
+		{
+			name:      "Valid verify",
+			args:      []string{"verify"},
+			wantError: false,
+		},
+		{
+			name:      "Invalid version with verify",
+			args:      []string{"verify", "3"},
+			wantError: true,
+		},
 		{
 			name:      "Invalid command",
 			args:      []string{"invalid"},
@@ -97,3 +111,73 @@ func TestCustomValidArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateMigration(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		kind      string
+		timestamp bool
+		expected  string
+		contains  string
+		wantError bool
+	}{
+		{name: "Add tenant notes", kind: "sql", expected: "003_add_tenant_notes.sql", contains: "-- +goose Up"},
+		{name: "backfill-notes", kind: "go", expected: "003_backfill_notes.go", contains: "func upBackfillNotes003("},
+		{name: "notes", kind: "sql", timestamp: true, expected: "20260304050607_notes.sql", contains: "Copyright 2026"},
+		{name: "notes", kind: "yaml", wantError: true},
+		{name: "  ", kind: "sql", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.kind, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range []string{"001_initial.sql", "002_next.sql", "migration.go"} {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path, err := createMigration(dir, tt.name, tt.kind, tt.timestamp, now)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("createMigration() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+			if filepath.Base(path) != tt.expected {
+				t.Errorf("createMigration() = %s, want %s", filepath.Base(path), tt.expected)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), tt.contains) {
+				t.Errorf("expected %s to contain %q, got:\n%s", path, tt.contains, content)
+			}
+		})
+	}
+}
+
+func TestMigrationLockAttempts(t *testing.T) {
+	tests := []struct {
+		wait     time.Duration
+		expected uint64
+	}{
+		{wait: 0, expected: 1},
+		{wait: 500 * time.Millisecond, expected: 1},
+		{wait: time.Second, expected: 1},
+		{wait: 1500 * time.Millisecond, expected: 2},
+		{wait: 5 * time.Minute, expected: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wait.String(), func(t *testing.T) {
+			if got := migrationLockAttempts(tt.wait); got != tt.expected {
+				t.Errorf("migrationLockAttempts() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
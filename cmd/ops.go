@@ -0,0 +1,233 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	opsv0 "github.com/canonical/tenant-service/ops/v0"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var opsAddress string
+
+var opsCmd = &cobra.Command{
+	Use:   "ops",
+	Short: "Run operational actions through the ops API",
+	Long: `Run operational actions through the ops API of a running instance.
+
+The ops API is served on OPS_ADDRESS, a loopback address or a unix socket, so
+these commands are run next to the service, e.g. by the charm.`,
+}
+
+func getOpsClient() (func() error, opsv0.OpsServiceClient, error) {
+	if opsAddress == "" {
+		return nil, nil, fmt.Errorf("--ops-address or OPS_ADDRESS is required")
+	}
+
+	conn, err := grpc.NewClient(opsAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial ops API: %w", err)
+	}
+	return conn.Close, opsv0.NewOpsServiceClient(conn), nil
+}
+
+var opsReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconcile tenant memberships with OpenFGA",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenantIDs, _ := cmd.Flags().GetStringSlice("tenant-id")
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		resp, err := client.Reconcile(context.Background(), &opsv0.ReconcileRequest{TenantIds: tenantIDs, Fix: fix})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "KIND\tTENANT_ID\tUSER_ID\tRELATION\tFIXED")
+		for _, d := range resp.Drifts {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", d.Kind, d.TenantId, d.UserId, d.Relation, d.Fixed)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var opsFlushCacheCmd = &cobra.Command{
+	Use:   "flush-cache",
+	Short: "Drop every cached authorization decision",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		resp, err := client.FlushAuthzCache(context.Background(), &opsv0.FlushAuthzCacheRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to flush the authorization cache: %w", err)
+		}
+
+		fmt.Printf("Flushed %d authorization decisions\n", resp.Flushed)
+		return nil
+	},
+}
+
+var opsMaintenanceCmd = &cobra.Command{
+	Use:       "maintenance [on|off]",
+	Short:     "Show or toggle the maintenance mode",
+	Long:      "Show or toggle the maintenance mode, in which the tenant API only serves reads.",
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"on", "off"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := context.Background()
+		if len(args) == 0 {
+			resp, err := client.GetMaintenanceMode(ctx, &opsv0.GetMaintenanceModeRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get the maintenance mode: %w", err)
+			}
+			fmt.Printf("Maintenance mode: %s\n", onOff(resp.Enabled))
+			return nil
+		}
+
+		resp, err := client.SetMaintenanceMode(ctx, &opsv0.SetMaintenanceModeRequest{Enabled: args[0] == "on"})
+		if err != nil {
+			return fmt.Errorf("failed to set the maintenance mode: %w", err)
+		}
+
+		fmt.Printf("Maintenance mode: %s (was %s)\n", onOff(resp.Enabled), onOff(resp.Previous))
+		return nil
+	},
+}
+
+var opsLogLevelCmd = &cobra.Command{
+	Use:   "log-level [debug|info|warning|error|critical]",
+	Short: "Show or change the level of the service logs",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := context.Background()
+		if len(args) == 0 {
+			resp, err := client.GetLogLevel(ctx, &opsv0.GetLogLevelRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get the log level: %w", err)
+			}
+			fmt.Printf("Log level: %s\n", resp.Level)
+			return nil
+		}
+
+		resp, err := client.SetLogLevel(ctx, &opsv0.SetLogLevelRequest{Level: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to set the log level: %w", err)
+		}
+
+		fmt.Printf("Log level: %s (was %s)\n", resp.Level, resp.Previous)
+		return nil
+	},
+}
+
+var opsDeadLettersCmd = &cobra.Command{
+	Use:   "dead-letters",
+	Short: "List the webhook calls whose processing failed for good",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("kind")
+		limit, _ := cmd.Flags().GetInt32("limit")
+
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		resp, err := client.ListDeadLetters(context.Background(), &opsv0.ListDeadLettersRequest{Kind: kind, Limit: limit})
+		if err != nil {
+			return fmt.Errorf("failed to list dead letters: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tKIND\tPAYLOAD\tREPLAYS\tLAST_ERROR\tCREATED_AT")
+		for _, l := range resp.DeadLetters {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", l.Id, l.Kind, l.Payload, l.Replays, l.LastError, l.CreatedAt)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var opsDeadLettersReplayCmd = &cobra.Command{
+	Use:   "replay [dead-letter-id]",
+	Short: "Process a registration dead letter again, it is deleted once it succeeds",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getOpsClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		if _, err := client.ReplayDeadLetter(context.Background(), &opsv0.ReplayDeadLetterRequest{Id: args[0]}); err != nil {
+			return fmt.Errorf("failed to replay dead letter: %w", err)
+		}
+
+		fmt.Printf("Replayed dead letter %s\n", args[0])
+		return nil
+	},
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+func init() {
+	rootCmd.AddCommand(opsCmd)
+	opsCmd.AddCommand(opsReconcileCmd)
+	opsCmd.AddCommand(opsFlushCacheCmd)
+	opsCmd.AddCommand(opsMaintenanceCmd)
+	opsCmd.AddCommand(opsLogLevelCmd)
+	opsCmd.AddCommand(opsDeadLettersCmd)
+	opsDeadLettersCmd.AddCommand(opsDeadLettersReplayCmd)
+
+	opsCmd.PersistentFlags().StringVar(&opsAddress, "ops-address", os.Getenv("OPS_ADDRESS"), "Ops API address, defaults to OPS_ADDRESS")
+	opsReconcileCmd.Flags().StringSlice("tenant-id", nil, "Tenants to reconcile, all tenants when empty")
+	opsReconcileCmd.Flags().Bool("fix", false, "Write missing relations and delete orphaned ones")
+	opsDeadLettersCmd.Flags().String("kind", "", "Only list the dead letters of a kind, registration or token_hook")
+	opsDeadLettersCmd.Flags().Int32("limit", 0, "Maximum number of dead letters listed, 100 when 0")
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// validateOutput rejects an unknown --output before the command runs.
+func validateOutput(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(outputFormats, outputFormat) {
+		return fmt.Errorf("invalid --output %q, must be one of table, json or yaml", outputFormat)
+	}
+	return nil
+}
+
+// printMessage writes msg to w in the --output format, it reports false for
+// the table format, which the command renders itself.
+//
+// The fields are named after the API messages, in snake case, and unset
+// fields are written with their zero value, so the output of a command keeps
+// the same shape across calls and releases.
+func printMessage(w io.Writer, msg proto.Message) (bool, error) {
+	if outputFormat == outputTable {
+		return false, nil
+	}
+
+	out, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return true, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	if outputFormat == outputYAML {
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return true, fmt.Errorf("failed to encode output: %w", err)
+		}
+		_, err = w.Write(out)
+		return true, err
+	}
+
+	// protojson varies its whitespace between builds on purpose
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, out, "", "  "); err != nil {
+		return true, fmt.Errorf("failed to encode output: %w", err)
+	}
+	buf.WriteByte('\n')
+
+	_, err = buf.WriteTo(w)
+	return true, err
+}
+
+// printValue writes v to w in the --output format like printMessage, for the
+// outputs that are not an API message, their fields are named by their JSON
+// tags.
+func printValue(w io.Writer, v any) (bool, error) {
+	if outputFormat == outputTable {
+		return false, nil
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	if outputFormat == outputYAML {
+		out, err = yaml.Marshal(v)
+	} else {
+		out, err = json.MarshalIndent(v, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	_, err = w.Write(out)
+	return true, err
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+func TestPrintMessage(t *testing.T) {
+	resp := &v0.ListTenantsResponse{
+		Tenants: []*v0.Tenant{{Id: "tenant-1", Name: "Acme", Enabled: true}},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		printed  bool
+		expected string
+	}{
+		{
+			name:   "Table",
+			format: outputTable,
+		},
+		{
+			name:     "JSON",
+			format:   outputJSON,
+			printed:  true,
+			expected: "{\n  \"tenants\": [\n    {\n      \"id\": \"tenant-1\",\n      \"name\": \"Acme\",\n      \"created_at\": \"\",\n      \"enabled\": true,\n      \"region\": \"\"\n    }\n  ],\n  \"next_page_token\": \"\"\n}\n",
+		},
+		{
+			name:     "YAML",
+			format:   outputYAML,
+			printed:  true,
+			expected: "next_page_token: \"\"\ntenants:\n- created_at: \"\"\n  enabled: true\n  id: tenant-1\n  name: Acme\n  region: \"\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(format string) { outputFormat = format }(outputFormat)
+			outputFormat = tt.format
+
+			var buf bytes.Buffer
+			printed, err := printMessage(&buf, resp)
+			if err != nil {
+				t.Fatalf("printMessage() error = %v", err)
+			}
+			if printed != tt.printed {
+				t.Errorf("printMessage() printed = %v, want %v", printed, tt.printed)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("printMessage() wrote %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+
+	for _, format := range outputFormats {
+		outputFormat = format
+		if err := validateOutput(nil, nil); err != nil {
+			t.Errorf("validateOutput() rejected %q: %v", format, err)
+		}
+	}
+
+	outputFormat = "xml"
+	if err := validateOutput(nil, nil); err == nil {
+		t.Error("validateOutput() accepted xml")
+	}
+}
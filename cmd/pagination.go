@@ -0,0 +1,122 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// allPageSize is the page size of --all when --page-size is not set.
+const allPageSize = 100
+
+// listOptions are the pagination and watch flags of the list commands.
+type listOptions struct {
+	pageSize  int32
+	pageToken string
+	all       bool
+	watch     bool
+	interval  time.Duration
+}
+
+// addListFlags registers the flags read by readListOptions.
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("page-size", 0, "Maximum number of items per page, every item at once when 0")
+	cmd.Flags().String("page-token", "", "Token of the page to list, printed by the previous call")
+	cmd.Flags().Bool("all", false, "Follow the pages until the last one")
+	cmd.Flags().Bool("watch", false, "List again every --interval until interrupted")
+	cmd.Flags().Duration("interval", 5*time.Second, "Polling interval of --watch")
+}
+
+func readListOptions(cmd *cobra.Command) (listOptions, error) {
+	var opts listOptions
+	opts.pageSize, _ = cmd.Flags().GetInt32("page-size")
+	opts.pageToken, _ = cmd.Flags().GetString("page-token")
+	opts.all, _ = cmd.Flags().GetBool("all")
+	opts.watch, _ = cmd.Flags().GetBool("watch")
+	opts.interval, _ = cmd.Flags().GetDuration("interval")
+
+	if opts.pageSize < 0 {
+		return opts, errors.New("--page-size must not be negative")
+	}
+	if opts.all && opts.pageToken != "" {
+		return opts, errors.New("--all and --page-token are mutually exclusive")
+	}
+	if opts.watch && opts.interval <= 0 {
+		return opts, errors.New("--interval must be positive")
+	}
+	if opts.all && opts.pageSize == 0 {
+		opts.pageSize = allPageSize
+	}
+	return opts, nil
+}
+
+// collectPages calls fetch with the token of each page in turn, starting
+// from the token of opts, until the last page with --all or once otherwise.
+// It returns the token of the page following the last one fetched.
+func collectPages(opts listOptions, fetch func(token string) (string, error)) (string, error) {
+	token := opts.pageToken
+	for {
+		next, err := fetch(token)
+		if err != nil {
+			return "", err
+		}
+		if !opts.all || next == "" {
+			return next, nil
+		}
+		if next == token {
+			return "", fmt.Errorf("the server returned the page token %q again", next)
+		}
+		token = next
+	}
+}
+
+// printNextPageToken prints the token of the next page after a table.
+func printNextPageToken(token string) {
+	if token != "" && outputFormat == outputTable {
+		fmt.Printf("\nNext page token: %s\n", token)
+	}
+}
+
+// runList runs list once, or every --interval with --watch until interrupted.
+// A failed run is reported and the next one attempted while watching.
+func runList(cmd *cobra.Command, opts listOptions, list func(ctx context.Context) error) error {
+	if !opts.watch {
+		return list(cmd.Context())
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	redraw := outputFormat == outputTable && term.IsTerminal(int(os.Stdout.Fd()))
+	for {
+		if redraw {
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Every %s: %s\n\n", opts.interval, time.Now().Format(time.RFC3339))
+		}
+		if err := list(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			cmd.PrintErrln(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCollectPages(t *testing.T) {
+	pages := map[string]string{"": "a", "a": "b", "b": ""}
+
+	tests := []struct {
+		name     string
+		opts     listOptions
+		pages    map[string]string
+		fetched  []string
+		next     string
+		expected error
+	}{
+		{
+			name:    "First page",
+			opts:    listOptions{},
+			pages:   pages,
+			fetched: []string{""},
+			next:    "a",
+		},
+		{
+			name:    "Page of the token",
+			opts:    listOptions{pageToken: "a"},
+			pages:   pages,
+			fetched: []string{"a"},
+			next:    "b",
+		},
+		{
+			name:    "All pages",
+			opts:    listOptions{all: true},
+			pages:   pages,
+			fetched: []string{"", "a", "b"},
+		},
+		{
+			name:     "Repeated token",
+			opts:     listOptions{all: true},
+			pages:    map[string]string{"": "a", "a": "a"},
+			fetched:  []string{"", "a"},
+			expected: errors.New(`the server returned the page token "a" again`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			next, err := collectPages(tt.opts, func(token string) (string, error) {
+				fetched = append(fetched, token)
+				return tt.pages[token], nil
+			})
+
+			if tt.expected != nil {
+				if err == nil || err.Error() != tt.expected.Error() {
+					t.Fatalf("collectPages() error = %v, want %v", err, tt.expected)
+				}
+			} else if err != nil {
+				t.Fatalf("collectPages() error = %v", err)
+			}
+			if next != tt.next {
+				t.Errorf("collectPages() = %q, want %q", next, tt.next)
+			}
+			if !reflect.DeepEqual(fetched, tt.fetched) {
+				t.Errorf("fetched %v, want %v", fetched, tt.fetched)
+			}
+		})
+	}
+}
+
+func TestCollectPagesError(t *testing.T) {
+	failure := errors.New("unavailable")
+
+	calls := 0
+	_, err := collectPages(listOptions{all: true}, func(token string) (string, error) {
+		calls++
+		if calls == 2 {
+			return "", failure
+		}
+		return "next", nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("collectPages() error = %v, want %v", err, failure)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want 2", calls)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/reconcile"
+)
+
+var reconcileFgaCmd = &cobra.Command{
+	Use:   "reconcile-fga",
+	Short: "Reconcile tenant memberships with OpenFGA",
+	Long: `Compare the memberships stored in the database with the owner and member
+relations stored in OpenFGA, in both directions, and report the drift.
+
+Use --fix to write the missing relations and delete the orphaned ones.
+The command exits with an error while drift is left unrepaired.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dsn, _ := cmd.Flags().GetString("dsn")
+		apiUrl, _ := cmd.Flags().GetString("fga-api-url")
+		apiToken, _ := cmd.Flags().GetString("fga-api-token")
+		storeId, _ := cmd.Flags().GetString("fga-store-id")
+		modelId, _ := cmd.Flags().GetString("fga-model-id")
+		tenantIDs, _ := cmd.Flags().GetStringSlice("tenant-id")
+		fix, _ := cmd.Flags().GetBool("fix")
+		format, _ := cmd.Flags().GetString("format")
+
+		logger := logging.NewNoopLogger()
+		tracer := tracing.NewNoopTracer()
+		monitor := monitoring.NewNoopMonitor("", logger)
+
+		scheme, host, err := parseURL(apiUrl)
+		if err != nil {
+			return fmt.Errorf("failed to parse url: %w", err)
+		}
+
+		dbClient, err := db.NewDBClient(
+			db.Config{
+				DSN:             dsn,
+				MaxConns:        2,
+				MaxConnLifetime: time.Hour,
+				MaxConnIdleTime: time.Minute,
+			},
+			tracer,
+			monitor,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create database client: %w", err)
+		}
+		defer dbClient.Close()
+
+		fgaClient := openfga.NewClient(&openfga.Config{
+			ApiScheme:   scheme,
+			ApiHost:     host,
+			StoreID:     storeId,
+			ApiToken:    apiToken,
+			AuthModelID: modelId,
+			Tracer:      tracer,
+			Monitor:     monitor,
+			Logger:      logger,
+		})
+
+		reconciler := reconcile.NewService(
+			storage.NewStorage(dbClient, tracer, monitor, logger),
+			authorization.NewAuthorizer(fgaClient, tracer, monitor, logger),
+			tracer,
+			monitor,
+			logger,
+		)
+
+		drifts, err := reconciler.Reconcile(cmd.Context(), tenantIDs, fix)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile: %w", err)
+		}
+
+		if format == "json" {
+			if err := json.NewEncoder(cmd.OutOrStdout()).Encode(drifts); err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "KIND\tTENANT_ID\tUSER_ID\tRELATION\tFIXED")
+			for _, d := range drifts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", d.Kind, d.TenantID, d.UserID, d.Relation, d.Fixed)
+			}
+			w.Flush()
+		}
+
+		unfixed := 0
+		for _, d := range drifts {
+			if !d.Fixed {
+				unfixed++
+			}
+		}
+		if unfixed > 0 {
+			return fmt.Errorf("%d drifted relations left unrepaired", unfixed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reconcileFgaCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	reconcileFgaCmd.Flags().String("fga-api-url", "", "The openfga API URL")
+	reconcileFgaCmd.Flags().String("fga-api-token", "", "The openfga API token")
+	reconcileFgaCmd.Flags().String("fga-store-id", "", "The openfga store holding the tenant relations")
+	reconcileFgaCmd.Flags().String("fga-model-id", "", "The openfga authorization model ID")
+	reconcileFgaCmd.Flags().StringSlice("tenant-id", nil, "Tenants to reconcile, all tenants when empty")
+	reconcileFgaCmd.Flags().Bool("fix", false, "Write missing relations and delete orphaned ones")
+	reconcileFgaCmd.Flags().String("format", "text", "Output format (text or json)")
+	_ = reconcileFgaCmd.MarkFlagRequired("dsn")
+	_ = reconcileFgaCmd.MarkFlagRequired("fga-api-url")
+	_ = reconcileFgaCmd.MarkFlagRequired("fga-api-token")
+	_ = reconcileFgaCmd.MarkFlagRequired("fga-store-id")
+
+	rootCmd.AddCommand(reconcileFgaCmd)
+}
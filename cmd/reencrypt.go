@@ -0,0 +1,85 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/idempotency"
+)
+
+var reencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Re-encrypt stored sensitive fields with the active key",
+	Long: `Re-encrypt the sensitive fields stored in the database with the active
+encryption key, fields stored in clear are encrypted.
+
+The keys are read from ENCRYPTION_KEYS and ENCRYPTION_ACTIVE_KEY_ID, as for
+serve. After a rotation, run it once every instance uses the new active key,
+then drop the old key from ENCRYPTION_KEYS.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dsn, _ := cmd.Flags().GetString("dsn")
+
+		var keys struct {
+			Keys        string `envconfig:"encryption_keys" required:"true"`
+			ActiveKeyID string `envconfig:"encryption_active_key_id" required:"true"`
+		}
+		if err := envconfig.Process("", &keys); err != nil {
+			return fmt.Errorf("issues with environment sourcing: %w", err)
+		}
+
+		logger := logging.NewNoopLogger()
+		tracer := tracing.NewNoopTracer()
+		monitor := monitoring.NewNoopMonitor("", logger)
+
+		cipher, err := encryption.NewCipherFromConfig(keys.Keys, keys.ActiveKeyID, tracer, monitor)
+		if err != nil {
+			return fmt.Errorf("invalid encryption keys: %w", err)
+		}
+
+		dbClient, err := db.NewDBClient(
+			db.Config{
+				DSN:             dsn,
+				MaxConns:        1,
+				MaxConnLifetime: time.Hour,
+				MaxConnIdleTime: time.Minute,
+			},
+			tracer,
+			monitor,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create database client: %w", err)
+		}
+		defer dbClient.Close()
+
+		s := storage.NewStorage(dbClient, tracer, monitor, logger)
+		// the TTL only matters when serving requests
+		n, err := idempotency.NewService(s, cipher, 0, tracer, monitor, logger).Reencrypt(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt idempotency responses: %w", err)
+		}
+
+		cmd.Printf("Re-encrypted %d idempotency responses with key %s\n", n, keys.ActiveKeyID)
+		return nil
+	},
+}
+
+func init() {
+	reencryptCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	_ = reencryptCmd.MarkFlagRequired("dsn")
+
+	rootCmd.AddCommand(reencryptCmd)
+}
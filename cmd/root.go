@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,13 @@ var (
 	authToken    string
 	grpcEndpoint string
 	httpEndpoint string
+	outputFormat string
+
+	tlsEnabled     bool
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+	tlsServerName  string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -20,6 +28,10 @@ var rootCmd = &cobra.Command{
 	Use:   "app",
 	Short: "Tenant Service",
 	Long:  `Tenant Service CLI for managing tenants and users.`,
+
+	PersistentPreRunE: validateOutput,
+	// printed by Execute with renderError
+	SilenceErrors: true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -27,6 +39,7 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		fmt.Fprintln(os.Stderr, renderError(err))
 		os.Exit(1)
 	}
 }
@@ -35,4 +48,11 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&grpcEndpoint, "grpc-endpoint", "localhost:50051", "gRPC server endpoint")
 	rootCmd.PersistentFlags().StringVar(&httpEndpoint, "http-endpoint", "", "HTTP server endpoint (e.g. http://localhost:8000)")
 	rootCmd.PersistentFlags().StringVar(&authToken, "token", "", "Authorization token (e.g. Bearer <token>)")
+	rootCmd.PersistentFlags().BoolVar(&tlsEnabled, "tls", false, "Connect to the service over TLS, implied by the other TLS flags")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", "", "PEM bundle of the CAs trusted for the service certificate, on top of the system roots")
+	rootCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "Client certificate presented for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "Key of the client certificate")
+	rootCmd.PersistentFlags().StringVar(&tlsServerName, "server-name", "", "Name expected in the SANs of the service certificate, the endpoint host by default")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "Output format of the read commands (table, json or yaml)")
+	_ = rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
 }
@@ -0,0 +1,366 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/kratos"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+var (
+	seedFirstNames = []string{"alice", "bruno", "chloe", "dmitri", "elena", "farid", "grace", "hiro", "ines", "jonas", "kemi", "liam", "maya", "nils", "olga", "pablo", "quinn", "rosa", "sami", "tara"}
+	seedLastNames  = []string{"martin", "okafor", "tanaka", "novak", "silva", "haddad", "kowalski", "nguyen", "larsen", "moreau", "rossi", "schmidt", "costa", "ivanova", "murphy", "kaur"}
+	seedAdjectives = []string{"Blue", "Northern", "Rapid", "Silver", "Quiet", "Bright", "Iron", "Green", "Lunar", "Coastal"}
+	seedNouns      = []string{"Harbor", "Summit", "Orchard", "Circuit", "Meadow", "Beacon", "Forge", "Canyon", "Atlas", "Delta"}
+	seedSuffixes   = []string{"Labs", "Systems", "Works", "Analytics", "Cloud"}
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Fill a development deployment with generated tenants and users",
+	Long: `Fill a development deployment with generated tenants and users, for the
+docker-compose environment and load tests.
+
+The users get identities in Kratos, the existing ones are reused, and each
+tenant is created with --members-per-tenant of them: an owner, about one
+admin in ten and members. Users belong to several tenants when --users is
+lower than the memberships. The OpenFGA tuples are written unless
+--fga-api-url is empty.
+
+Names and emails follow the index of the tenant or user, and the memberships
+--seed, so that running the command again skips the tenants already seeded.
+Never run it against a production deployment.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts seedOptions
+		opts.dsn, _ = cmd.Flags().GetString("dsn")
+		opts.kratosAdminURL, _ = cmd.Flags().GetString("kratos-admin-url")
+		opts.fgaApiUrl, _ = cmd.Flags().GetString("fga-api-url")
+		opts.fgaApiToken, _ = cmd.Flags().GetString("fga-api-token")
+		opts.fgaStoreId, _ = cmd.Flags().GetString("fga-store-id")
+		opts.tenants, _ = cmd.Flags().GetInt("tenants")
+		opts.membersPerTenant, _ = cmd.Flags().GetInt("members-per-tenant")
+		opts.users, _ = cmd.Flags().GetInt("users")
+		opts.emailDomain, _ = cmd.Flags().GetString("email-domain")
+		opts.seed, _ = cmd.Flags().GetUint64("seed")
+		opts.concurrency, _ = cmd.Flags().GetInt("concurrency")
+
+		if opts.users == 0 {
+			opts.users = max(opts.membersPerTenant, opts.tenants*opts.membersPerTenant/2)
+		}
+		if err := opts.validate(); err != nil {
+			return err
+		}
+
+		result, err := seed(cmd.Context(), opts, seedPlan(opts))
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Seeded %d tenants (%d already there) with %d memberships, %d identities created and %d reused\n",
+			result.tenantsCreated, result.tenantsSkipped, result.memberships, result.identitiesCreated, result.identitiesReused)
+		if opts.fgaApiUrl == "" {
+			cmd.Println("No --fga-api-url, the OpenFGA tuples were not written")
+		}
+		return nil
+	},
+}
+
+func init() {
+	seedCmd.Flags().String("dsn", "", "PostgreSQL DSN connection string")
+	seedCmd.Flags().String("kratos-admin-url", "http://127.0.0.1:4434", "The Kratos admin API URL")
+	seedCmd.Flags().String("fga-api-url", "", "The openfga API URL, the tuples are not written when empty")
+	seedCmd.Flags().String("fga-api-token", "", "The openfga API token")
+	seedCmd.Flags().String("fga-store-id", "", "The openfga store")
+	seedCmd.Flags().Int("tenants", 10, "Number of tenants")
+	seedCmd.Flags().Int("members-per-tenant", 5, "Number of members of every tenant")
+	seedCmd.Flags().Int("users", 0, "Number of users the members are picked from, half the memberships when 0")
+	seedCmd.Flags().String("email-domain", "example.com", "Domain of the user emails")
+	seedCmd.Flags().Uint64("seed", 1, "Seed of the membership assignment")
+	seedCmd.Flags().Int("concurrency", 4, "Maximum Kratos requests in flight")
+	_ = seedCmd.MarkFlagRequired("dsn")
+
+	rootCmd.AddCommand(seedCmd)
+}
+
+type seedOptions struct {
+	dsn              string
+	kratosAdminURL   string
+	fgaApiUrl        string
+	fgaApiToken      string
+	fgaStoreId       string
+	tenants          int
+	membersPerTenant int
+	users            int
+	emailDomain      string
+	seed             uint64
+	concurrency      int
+}
+
+func (o seedOptions) validate() error {
+	if o.tenants < 1 || o.membersPerTenant < 1 || o.concurrency < 1 {
+		return errors.New("--tenants, --members-per-tenant and --concurrency must be positive")
+	}
+	if o.users < o.membersPerTenant {
+		return fmt.Errorf("--users must be at least --members-per-tenant (%d)", o.membersPerTenant)
+	}
+	if o.fgaApiUrl != "" && o.fgaStoreId == "" {
+		return errors.New("--fga-api-url requires --fga-store-id")
+	}
+	return nil
+}
+
+type seedTenant struct {
+	name    string
+	members []seedMember
+}
+
+type seedMember struct {
+	// user is the index of the user in the plan
+	user int
+	role types.MembershipRole
+}
+
+type seedData struct {
+	emails  []string
+	tenants []seedTenant
+}
+
+// seedPlan generates the users and the tenants of opts, the same options
+// always give the same plan.
+func seedPlan(opts seedOptions) *seedData {
+	r := rand.New(rand.NewPCG(opts.seed, opts.seed))
+	plan := &seedData{
+		emails:  make([]string, opts.users),
+		tenants: make([]seedTenant, opts.tenants),
+	}
+
+	for i := range plan.emails {
+		first := seedFirstNames[i%len(seedFirstNames)]
+		last := seedLastNames[(i/len(seedFirstNames))%len(seedLastNames)]
+		local := first + "." + last
+		if n := i / (len(seedFirstNames) * len(seedLastNames)); n > 0 {
+			local = fmt.Sprintf("%s%d", local, n+1)
+		}
+		plan.emails[i] = local + "@" + opts.emailDomain
+	}
+
+	for i := range plan.tenants {
+		name := []string{
+			seedAdjectives[i%len(seedAdjectives)],
+			seedNouns[(i/len(seedAdjectives))%len(seedNouns)],
+			seedSuffixes[(i/(len(seedAdjectives)*len(seedNouns)))%len(seedSuffixes)],
+		}
+		if n := i / (len(seedAdjectives) * len(seedNouns) * len(seedSuffixes)); n > 0 {
+			name = append(name, fmt.Sprint(n+1))
+		}
+
+		members := make([]seedMember, opts.membersPerTenant)
+		for j, user := range r.Perm(opts.users)[:opts.membersPerTenant] {
+			role := types.RoleMember
+			switch {
+			case j == 0:
+				role = types.RoleOwner
+			case r.IntN(10) == 0:
+				role = types.RoleAdmin
+			}
+			members[j] = seedMember{user: user, role: role}
+		}
+
+		plan.tenants[i] = seedTenant{name: strings.Join(name, " "), members: members}
+	}
+
+	return plan
+}
+
+type seedResult struct {
+	tenantsCreated    int
+	tenantsSkipped    int
+	memberships       int
+	identitiesCreated int
+	identitiesReused  int
+}
+
+func seed(ctx context.Context, opts seedOptions, plan *seedData) (*seedResult, error) {
+	logger := logging.NewNoopLogger()
+	tracer := tracing.NewNoopTracer()
+	monitor := monitoring.NewNoopMonitor("", logger)
+
+	dbClient, err := db.NewDBClient(
+		db.Config{
+			DSN:             opts.dsn,
+			MaxConns:        int32(opts.concurrency),
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: time.Minute,
+		},
+		tracer,
+		monitor,
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database client: %w", err)
+	}
+	defer dbClient.Close()
+
+	s := storage.NewStorage(dbClient, tracer, monitor, logger)
+
+	var authorizer *authorization.Authorizer
+	if opts.fgaApiUrl != "" {
+		scheme, host, err := parseURL(opts.fgaApiUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse url: %w", err)
+		}
+		authorizer = authorization.NewAuthorizer(
+			openfga.NewClient(&openfga.Config{
+				ApiScheme: scheme,
+				ApiHost:   host,
+				StoreID:   opts.fgaStoreId,
+				ApiToken:  opts.fgaApiToken,
+				Tracer:    tracer,
+				Monitor:   monitor,
+				Logger:    logger,
+			}),
+			tracer,
+			monitor,
+			logger,
+		)
+	}
+
+	existing, err := s.ListTenants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	seeded := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		seeded[t.Name] = true
+	}
+
+	result := new(seedResult)
+	pending := make([]seedTenant, 0, len(plan.tenants))
+	for _, t := range plan.tenants {
+		if seeded[t.name] {
+			result.tenantsSkipped++
+			continue
+		}
+		pending = append(pending, t)
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	ids, err := seedIdentities(ctx, kratos.NewClient(opts.kratosAdminURL, nil, tracer, monitor, logger), plan.emails, opts.concurrency, result)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, t := range pending {
+		err := dbClient.WithTx(ctx, func(ctx context.Context) error {
+			tenant, err := s.CreateTenant(ctx, &types.Tenant{Name: t.name, Enabled: true})
+			if err != nil {
+				return err
+			}
+			for _, m := range t.members {
+				if _, err := s.AddMember(ctx, tenant.ID, ids[m.user], m.role); err != nil {
+					return err
+				}
+			}
+			// the tuples are written last, the rows are rolled back if they fail
+			if authorizer == nil {
+				return nil
+			}
+			for _, m := range t.members {
+				if err := seedRelation(ctx, authorizer, tenant.ID, ids[m.user], m.role); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed tenant %s: %w", t.name, err)
+		}
+
+		result.tenantsCreated++
+		result.memberships += len(t.members)
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s: %d members\n", i+1, len(pending), t.name, len(t.members))
+	}
+
+	return result, nil
+}
+
+// seedIdentities returns the identity IDs of emails, creating the missing
+// identities with at most concurrency requests in flight.
+func seedIdentities(ctx context.Context, client kratos.ClientInterface, emails []string, concurrency int, result *seedResult) ([]string, error) {
+	ids := make([]string, len(emails))
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan int)
+
+	for range min(concurrency, len(emails)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				id, err := client.GetIdentityIDByEmail(ctx, emails[i])
+				created := false
+				if err == nil && id == "" {
+					id, err = client.CreateIdentity(ctx, emails[i])
+					created = true
+				}
+
+				mu.Lock()
+				switch {
+				case err != nil && firstErr == nil:
+					firstErr = fmt.Errorf("failed to seed identity %s: %w", emails[i], err)
+				case err == nil && created:
+					result.identitiesCreated++
+				case err == nil:
+					result.identitiesReused++
+				}
+				ids[i] = id
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range emails {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ids, firstErr
+}
+
+func seedRelation(ctx context.Context, authorizer *authorization.Authorizer, tenantID, userID string, role types.MembershipRole) error {
+	switch authorization.RelationForRole(role) {
+	case authorization.OWNER_RELATION:
+		return authorizer.AssignTenantOwner(ctx, tenantID, userID)
+	case authorization.ADMIN_RELATION:
+		return authorizer.AssignTenantAdmin(ctx, tenantID, userID)
+	default:
+		return authorizer.AssignTenantMember(ctx, tenantID, userID)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+func TestSeedPlan(t *testing.T) {
+	opts := seedOptions{tenants: 600, membersPerTenant: 20, users: 400, emailDomain: "example.com", seed: 7}
+
+	plan := seedPlan(opts)
+
+	if !reflect.DeepEqual(plan, seedPlan(opts)) {
+		t.Fatal("expected the same options to give the same plan")
+	}
+
+	emails := make(map[string]bool)
+	for _, e := range plan.emails {
+		if emails[e] {
+			t.Fatalf("duplicate email %s", e)
+		}
+		emails[e] = true
+	}
+	if plan.emails[0] != "alice.martin@example.com" {
+		t.Errorf("unexpected first email %s", plan.emails[0])
+	}
+
+	names := make(map[string]bool)
+	for _, tenant := range plan.tenants {
+		if names[tenant.name] {
+			t.Fatalf("duplicate tenant name %s", tenant.name)
+		}
+		names[tenant.name] = true
+
+		if len(tenant.members) != opts.membersPerTenant {
+			t.Fatalf("expected %d members in %s, got %d", opts.membersPerTenant, tenant.name, len(tenant.members))
+		}
+		users := make(map[int]bool)
+		for i, m := range tenant.members {
+			if users[m.user] {
+				t.Fatalf("user %d is twice a member of %s", m.user, tenant.name)
+			}
+			users[m.user] = true
+			if (i == 0) != (m.role == types.RoleOwner) {
+				t.Fatalf("expected the first member of %s only to be the owner, got %s at %d", tenant.name, m.role, i)
+			}
+		}
+	}
+
+	opts.seed = 8
+	if reflect.DeepEqual(plan.tenants, seedPlan(opts).tenants) {
+		t.Error("expected another seed to assign other memberships")
+	}
+}
+
+func TestSeedOptionsValidate(t *testing.T) {
+	valid := seedOptions{tenants: 1, membersPerTenant: 2, users: 2, concurrency: 1}
+
+	tests := []struct {
+		name      string
+		update    func(*seedOptions)
+		wantError bool
+	}{
+		{name: "Valid", update: func(*seedOptions) {}},
+		{name: "No tenants", update: func(o *seedOptions) { o.tenants = 0 }, wantError: true},
+		{name: "Fewer users than members", update: func(o *seedOptions) { o.users = 1 }, wantError: true},
+		{name: "OpenFGA without store", update: func(o *seedOptions) { o.fgaApiUrl = "http://openfga:8080" }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.update(&opts)
+			if err := opts.validate(); (err != nil) != tt.wantError {
+				t.Errorf("validate() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
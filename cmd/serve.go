@@ -11,27 +11,60 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/clientip"
 	"github.com/canonical/tenant-service/internal/config"
 	"github.com/canonical/tenant-service/internal/db"
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/http/outbound"
+	"github.com/canonical/tenant-service/internal/jobs"
 	"github.com/canonical/tenant-service/internal/kratos"
 	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/maintenance"
+	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/monitoring/prometheus"
 	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/recovery"
 	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tasks"
+	"github.com/canonical/tenant-service/internal/tlsconfig"
 	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/version"
+	"github.com/canonical/tenant-service/openapi"
+	opsv0 "github.com/canonical/tenant-service/ops/v0"
+	"github.com/canonical/tenant-service/pkg/apikey"
+	"github.com/canonical/tenant-service/pkg/audit"
 	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/deprecation"
+	"github.com/canonical/tenant-service/pkg/docs"
+	"github.com/canonical/tenant-service/pkg/events"
+	"github.com/canonical/tenant-service/pkg/idempotency"
+	"github.com/canonical/tenant-service/pkg/ops"
+	"github.com/canonical/tenant-service/pkg/ratelimit"
+	"github.com/canonical/tenant-service/pkg/reconcile"
+	"github.com/canonical/tenant-service/pkg/role"
+	"github.com/canonical/tenant-service/pkg/status"
+	"github.com/canonical/tenant-service/pkg/subscription"
+	"github.com/canonical/tenant-service/pkg/telemetry"
 	"github.com/canonical/tenant-service/pkg/tenant"
 	"github.com/canonical/tenant-service/pkg/web"
+	"github.com/canonical/tenant-service/pkg/webhooks"
 	v0 "github.com/canonical/tenant-service/v0"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 var serveCmd = &cobra.Command{
@@ -43,7 +76,15 @@ var serveCmd = &cobra.Command{
 	},
 }
 
+var (
+	autoMigrate        bool
+	autoMigrateTimeout time.Duration
+)
+
 func init() {
+	serveCmd.Flags().BoolVar(&autoMigrate, "auto-migrate", false, "Apply the pending database migrations before serving")
+	serveCmd.Flags().DurationVar(&autoMigrateTimeout, "wait-timeout", defaultMigrationWait, "How long --auto-migrate waits for another replica holding the migration lock")
+
 	rootCmd.AddCommand(serveCmd)
 }
 
@@ -57,9 +98,200 @@ func serve() error {
 	logger.Debugf("env vars: %v", specs)
 	defer logger.Sync()
 
+	// background work and the resources it depends on, drained on shutdown
+	registry := tasks.NewRegistry(logger)
+	// only runs the shutdown hooks on early returns, Stop is a noop once called
+	defer registry.Stop(context.Background())
+
+	if !specs.HTTPEnabled && !specs.GRPCEnabled {
+		return fmt.Errorf("at least one of HTTP_ENABLED and GRPC_ENABLED must be set")
+	}
+
+	switch specs.TenantListingSource {
+	case tenant.TenantSourceDatabase:
+	case tenant.TenantSourceOpenFGA:
+		if !specs.AuthorizationEnabled {
+			return fmt.Errorf("TENANT_LISTING_SOURCE=%s requires AUTHORIZATION_ENABLED", specs.TenantListingSource)
+		}
+	default:
+		return fmt.Errorf("invalid TENANT_LISTING_SOURCE %q, expected %s or %s", specs.TenantListingSource, tenant.TenantSourceDatabase, tenant.TenantSourceOpenFGA)
+	}
+
+	if specs.SinglePortEnabled {
+		if !specs.GRPCEnabled {
+			return fmt.Errorf("SINGLE_PORT_ENABLED requires GRPC_ENABLED")
+		}
+		if specs.GRPCTLSCertFile != "" || specs.GRPCTLSKeyFile != "" {
+			return fmt.Errorf("SINGLE_PORT_ENABLED serves cleartext HTTP/2, terminate TLS in front of it instead of setting GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE")
+		}
+	}
+
+	if specs.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive")
+	}
+
+	if specs.WebhookSignatureTolerance <= 0 {
+		return fmt.Errorf("WEBHOOK_SIGNATURE_TOLERANCE must be positive")
+	}
+
+	if specs.APIMaxBodySize < 0 || specs.WebhookMaxBodySize < 0 || specs.APITimeout < 0 || specs.WebhookTimeout < 0 || specs.HTTPReadHeaderTimeout < 0 {
+		return fmt.Errorf("API_MAX_BODY_SIZE, API_TIMEOUT, WEBHOOK_MAX_BODY_SIZE, WEBHOOK_TIMEOUT and HTTP_READ_HEADER_TIMEOUT must not be negative")
+	}
+
+	if _, err := time.ParseDuration(specs.InvitationLifetime); err != nil {
+		return fmt.Errorf("invalid INVITATION_LIFETIME: %v", err)
+	}
+
+	if specs.TenantMemberQuota < 0 || specs.TenantInviteQuota < 0 {
+		return fmt.Errorf("TENANT_MEMBER_QUOTA and TENANT_INVITE_QUOTA must not be negative")
+	}
+
+	if specs.TenantQuotaWarningPercent < 0 || specs.TenantQuotaWarningPercent > 100 {
+		return fmt.Errorf("TENANT_QUOTA_WARNING_PERCENT must be between 0 and 100")
+	}
+
+	if specs.ReconcileFGAInterval > 0 && !specs.AuthorizationEnabled {
+		return fmt.Errorf("RECONCILE_FGA_INTERVAL requires AUTHORIZATION_ENABLED")
+	}
+
+	if specs.AuthorizationEnforceTenantScope && !specs.AuthorizationEnabled {
+		return fmt.Errorf("AUTHORIZATION_ENFORCE_TENANT_SCOPE requires AUTHORIZATION_ENABLED")
+	}
+
+	if specs.AuthorizationCacheTTL > 0 && specs.AuthorizationCacheSize <= 0 {
+		return fmt.Errorf("AUTHORIZATION_CACHE_TTL requires a positive AUTHORIZATION_CACHE_SIZE")
+	}
+
+	if specs.AuthenticationSessionEnabled && (!specs.AuthenticationEnabled || specs.KratosPublicURL == "") {
+		return fmt.Errorf("AUTHENTICATION_SESSION_ENABLED requires AUTHENTICATION_ENABLED and KRATOS_PUBLIC_URL")
+	}
+
+	if specs.AuthenticationAPIKeysEnabled && !specs.AuthenticationEnabled {
+		return fmt.Errorf("AUTHENTICATION_API_KEYS_ENABLED requires AUTHENTICATION_ENABLED")
+	}
+
+	if specs.AuthenticationCacheEnabled && specs.AuthenticationCacheSize <= 0 {
+		return fmt.Errorf("AUTHENTICATION_CACHE_ENABLED requires a positive AUTHENTICATION_CACHE_SIZE")
+	}
+
+	tokenTargets, err := webhooks.ParseTokenTargets(specs.TokenHookTargets)
+	if err != nil {
+		return fmt.Errorf("invalid TOKEN_HOOK_TARGETS: %v", err)
+	}
+
+	tokenClaim, err := webhooks.ParseTokenClaim(specs.TokenHookClaim, specs.TokenHookClaimFormat, specs.TokenHookMaxTenants)
+	if err != nil {
+		return fmt.Errorf("invalid token hook claim: %v", err)
+	}
+
+	webhookVersion, err := webhooks.ParseVersion(specs.WebhookVersion)
+	if err != nil {
+		return fmt.Errorf("invalid WEBHOOK_VERSION: %v", err)
+	}
+
+	registrationTenant, err := webhooks.ParseRegistrationTenant(
+		specs.RegistrationTenantNameTemplate,
+		specs.RegistrationTenantEnabled,
+		specs.RegistrationTenantEnabledDomains,
+		specs.RegistrationTenantRole,
+		specs.RegistrationSkipTenantField,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid registration tenant: %v", err)
+	}
+
+	consentPolicy, err := webhooks.ParseConsentPolicy(specs.ConsentScopeRoles, specs.ConsentAudienceRoles)
+	if err != nil {
+		return fmt.Errorf("invalid consent policy: %v", err)
+	}
+
+	eventsEnabled := len(specs.EventSinkURLs) > 0 || specs.WebhookSubscriptionsEnabled
+	jobsEnabled := specs.WebhookQueueEnabled || eventsEnabled
+	if jobsEnabled && (specs.JobWorkers <= 0 || specs.JobPollInterval <= 0 || specs.JobTimeout <= 0 || specs.JobMaxAttempts <= 0) {
+		return fmt.Errorf("WEBHOOK_QUEUE_ENABLED, EVENT_SINK_URLS and WEBHOOK_SUBSCRIPTIONS_ENABLED require positive JOB_WORKERS, JOB_POLL_INTERVAL, JOB_TIMEOUT and JOB_MAX_ATTEMPTS")
+	}
+
+	if specs.TelemetryEnabled && (specs.TelemetryEndpoint == "" || specs.TelemetryInterval <= 0) {
+		return fmt.Errorf("TELEMETRY_ENABLED requires TELEMETRY_ENDPOINT and a positive TELEMETRY_INTERVAL")
+	}
+
 	monitor := prometheus.NewMonitor("tenant-service", logger)
 	tracer := tracing.NewTracer(tracing.NewConfig(specs.TracingEnabled, specs.OtelGRPCEndpoint, specs.OtelHTTPEndpoint, logger))
 
+	var panicReporter recovery.ReporterInterface
+	if specs.SentryDSN != "" {
+		sentryReporter, err := recovery.NewSentryReporter(specs.SentryDSN, specs.SentryEnvironment, version.Get().Version)
+		if err != nil {
+			return fmt.Errorf("invalid SENTRY_DSN: %v", err)
+		}
+		registry.OnShutdown("sentry", sentryReporter.Flush)
+		panicReporter = sentryReporter
+		logger.Info("Reporting panics to Sentry")
+	}
+	recoverer := recovery.NewRecoverer(panicReporter, monitor, logger)
+
+	// shared by the Kratos, Hydra and OpenFGA clients
+	outboundClient, err := outbound.NewClient(
+		outbound.NewConfig(
+			specs.OutboundTLSCAFile,
+			specs.OutboundTLSCertFile,
+			specs.OutboundTLSKeyFile,
+			specs.OutboundProxyURL,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create outbound HTTP client: %v", err)
+	}
+
+	var shedder *monitoring.LoadShedder
+	if specs.LoadSheddingEnabled {
+		shedder = monitoring.NewLoadShedder(
+			specs.LoadSheddingWindow,
+			map[string]time.Duration{
+				monitoring.DatabaseDependency: specs.LoadSheddingDBThreshold,
+				monitoring.OpenFGADependency:  specs.LoadSheddingOpenFGAThreshold,
+			},
+			monitor,
+			logger,
+		)
+		logger.Info("Load shedding is enabled")
+	}
+
+	var rateLimitStore ratelimit.StoreInterface = ratelimit.NewMemoryStore(specs.APIRateLimitKeys)
+	if specs.APIRateLimitRedisURL != "" {
+		redisStore, err := ratelimit.NewRedisStore(specs.APIRateLimitRedisURL)
+		if err != nil {
+			return fmt.Errorf("invalid API_RATE_LIMIT_REDIS_URL: %v", err)
+		}
+		registry.OnShutdown("rate-limit-redis", func(context.Context) error { return redisStore.Close() })
+		rateLimitStore = redisStore
+	}
+	rateLimiter := ratelimit.NewLimiter(
+		ratelimit.Config{
+			Limit:        specs.APIRateLimit,
+			Burst:        specs.APIRateBurst,
+			SubjectLimit: specs.APISubjectRateLimit,
+			SubjectBurst: specs.APISubjectRateBurst,
+			TenantLimit:  specs.APITenantRateLimit,
+			TenantBurst:  specs.APITenantRateBurst,
+		},
+		rateLimitStore,
+		monitor,
+		logger,
+	)
+	if rateLimiter != nil {
+		logger.Infof("Rate limiting the API to %v calls per second, %v per subject, %v per tenant", specs.APIRateLimit, specs.APISubjectRateLimit, specs.APITenantRateLimit)
+	}
+
+	if autoMigrate {
+		// replicas starting together take turns, the later ones find nothing pending
+		results, err := applyMigrations(context.Background(), specs.DSN, autoMigrateTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to apply the database migrations: %v", err)
+		}
+		logger.Infof("Applied %d database migrations", len(results))
+	}
+
 	dbConfig := db.Config{
 		DSN:             specs.DSN,
 		MaxConns:        specs.DBMaxConns,
@@ -68,37 +300,113 @@ func serve() error {
 		MaxConnIdleTime: specs.DBMaxConnIdleTime,
 		TracingEnabled:  specs.TracingEnabled,
 	}
+	if shedder != nil {
+		dbConfig.LatencyObserver = shedder
+	}
 	dbClient, err := db.NewDBClient(dbConfig, tracer, monitor, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create database client: %v", err)
 	}
-	defer dbClient.Close()
+	registry.OnShutdown("database", func(context.Context) error {
+		dbClient.Close()
+		return nil
+	})
 	s := storage.NewStorage(dbClient, tracer, monitor, logger)
 
+	// probed by the readiness endpoint and the gRPC health service
+	readinessDependencies := []status.Dependency{
+		{Name: monitoring.DatabaseDependency, Probe: dbClient.Ping, Timeout: specs.ReadinessDBTimeout},
+	}
+
 	var authorizer *authorization.Authorizer
+	var authzModel status.ModelConfig
+	dependencies := make(map[string]status.DependencyInterface)
+	// left nil unless enabled, the ops API checks them
+	var authzCache ops.CacheInterface
+	var reconciler ops.ReconcilerInterface
 	if specs.AuthorizationEnabled {
-		ofga := openfga.NewClient(
-			openfga.NewConfig(
-				specs.OpenfgaApiScheme,
-				specs.OpenfgaApiHost,
-				specs.OpenfgaStoreId,
-				specs.OpenfgaApiToken,
-				specs.OpenfgaModelId,
-				specs.Debug,
-				tracer,
-				monitor,
-				logger,
-			),
+		authzModel = status.ModelConfig{StoreID: specs.OpenfgaStoreId, ModelID: specs.OpenfgaModelId}
+
+		fgaConfig := openfga.NewConfig(
+			specs.OpenfgaApiScheme,
+			specs.OpenfgaApiHost,
+			specs.OpenfgaStoreId,
+			specs.OpenfgaApiToken,
+			specs.OpenfgaModelId,
+			specs.Debug,
+			tracer,
+			monitor,
+			logger,
 		)
-		authorizer = authorization.NewAuthorizer(
+		if fgaConfig != nil {
+			fgaConfig.HTTPClient = outboundClient
+		}
+		if fgaConfig != nil && shedder != nil {
+			fgaConfig.LatencyObserver = shedder
+		}
+		if fgaConfig != nil && specs.OpenfgaRetryMaxAttempts > 0 {
+			fgaConfig.Retry = &openfga.RetryPolicy{
+				MaxAttempts: specs.OpenfgaRetryMaxAttempts,
+				Backoff:     specs.OpenfgaRetryBackoff,
+				MaxBackoff:  specs.OpenfgaRetryMaxBackoff,
+			}
+		}
+		if fgaConfig != nil && specs.OpenfgaBreakerThreshold > 0 {
+			breaker := openfga.NewCircuitBreaker(specs.OpenfgaBreakerThreshold, specs.OpenfgaBreakerCooldown, monitor, logger)
+			fgaConfig.Breaker = breaker
+			dependencies[monitoring.OpenFGADependency] = breaker
+		}
+		ofga := openfga.NewClient(fgaConfig)
+		readinessDependencies = append(readinessDependencies, status.Dependency{
+			Name: monitoring.OpenFGADependency,
+			Probe: func(ctx context.Context) error {
+				if specs.OpenfgaModelId == "" {
+					_, err := ofga.ReadLatestModel(ctx)
+					return err
+				}
+				_, err := ofga.ReadModel(ctx)
+				return err
+			},
+			Timeout: specs.ReadinessOpenFGATimeout,
+		})
+		modelGuard, err := authorization.NewModelGuard(
+			specs.AuthorizationModelPolicy,
+			specs.AuthorizationModelRetryBackoff,
+			specs.AuthorizationModelRetryMaxBackoff,
 			ofga,
+			openfga.NewNoopClient(tracer, monitor, logger),
+			monitor,
+			logger,
+		)
+		if err != nil {
+			return err
+		}
+		authzModel.Validation = modelGuard
+		dependencies["authorization_model"] = modelGuard
+
+		authorizer = authorization.NewAuthorizer(
+			modelGuard,
 			tracer,
 			monitor,
 			logger,
 		)
+		if specs.AuthorizationCacheTTL > 0 {
+			cache := authorization.NewDecisionCache(specs.AuthorizationCacheSize, specs.AuthorizationCacheTTL)
+			authorizer.SetCache(cache)
+			authzCache = cache
+			logger.Infof("Authorization decisions are cached for %s", specs.AuthorizationCacheTTL)
+		}
+		// tuple changes are recorded within the transaction of the request
+		authorizer.SetAuditor(audit.NewRecorder(s, tracer, monitor, logger))
 		logger.Info("Authorization is enabled")
-		if authorizer.ValidateModel(context.Background()) != nil {
-			panic("Invalid authorization model provided")
+		if err := modelGuard.Validate(context.Background(), authorizer.ValidateModel); err != nil {
+			return fmt.Errorf("invalid authorization model: %v", err)
+		}
+		if modelGuard.State() == authorization.ModelStateValidating {
+			registry.Go("authorization-model", func(ctx context.Context) error {
+				modelGuard.Run(ctx, authorizer.ValidateModel)
+				return nil
+			})
 		}
 	} else {
 		authorizer = authorization.NewAuthorizer(
@@ -111,6 +419,7 @@ func serve() error {
 	}
 
 	var jwtVerifier authentication.TokenVerifierInterface
+	var sessionVerifier *authentication.SessionVerifier
 	if specs.AuthenticationEnabled {
 		// Parse allowed subjects from comma-separated string
 		var allowedSubjects []string
@@ -124,13 +433,44 @@ func serve() error {
 			}
 		}
 
+		serviceClients := make(map[string]authentication.PlatformRole, len(specs.AuthenticationServiceClients))
+		for clientID, role := range specs.AuthenticationServiceClients {
+			serviceClients[clientID] = authentication.PlatformRole(role)
+		}
+
+		var issuers []authentication.IssuerConfig
+		if specs.AuthenticationIssuer != "" {
+			issuers = append(issuers, authentication.IssuerConfig{
+				Issuer:                specs.AuthenticationIssuer,
+				JWKSURL:               specs.AuthenticationJwksURL,
+				Audiences:             specs.AuthenticationAllowedAudiences,
+				AudienceBypassClients: specs.AuthenticationAudienceBypassClients,
+				AllowedSubjects:       allowedSubjects,
+				RequiredScope:         specs.AuthenticationRequiredScope,
+				ServiceClients:        serviceClients,
+			})
+		}
+		if specs.AuthenticationIssuersFile != "" {
+			more, err := authentication.LoadIssuers(specs.AuthenticationIssuersFile)
+			if err != nil {
+				return err
+			}
+			issuers = append(issuers, more...)
+		}
+
+		var tokenCache *authentication.TokenCache
+		if specs.AuthenticationCacheEnabled {
+			tokenCache = authentication.NewTokenCache(specs.AuthenticationCacheSize, specs.AuthenticationCacheTTL, specs.AuthenticationKeysFailureTTL)
+			logger.Infof("Verified tokens are cached for up to %s", specs.AuthenticationCacheTTL)
+		}
+
 		var err error
 		jwtVerifier, err = authentication.NewJWTAuthenticator(
 			context.Background(),
-			specs.AuthenticationIssuer,
-			specs.AuthenticationJwksURL,
-			allowedSubjects,
-			specs.AuthenticationRequiredScope,
+			issuers,
+			authentication.TokenLifetime{Leeway: specs.AuthenticationClockSkew, MaxAge: specs.AuthenticationMaxTokenAge},
+			tokenCache,
+			outboundClient,
 			tracer,
 			monitor,
 			logger,
@@ -138,74 +478,492 @@ func serve() error {
 		if err != nil {
 			return fmt.Errorf("failed to setup JWT authenticator: %v", err)
 		}
+
+		if specs.AuthenticationIntrospectionURL != "" {
+			introspection := authentication.NewIntrospectionVerifier(
+				specs.AuthenticationIntrospectionURL,
+				specs.AuthenticationIntrospectionClientID,
+				specs.AuthenticationIntrospectionClientSecret,
+				issuers,
+				outboundClient,
+				tracer,
+				monitor,
+				logger,
+			)
+			introspection.SetCache(tokenCache)
+			jwtVerifier = authentication.NewFallbackVerifier(jwtVerifier, introspection)
+			logger.Infof("Opaque tokens are introspected with %s", specs.AuthenticationIntrospectionURL)
+		}
+
+		if specs.AuthenticationSessionEnabled {
+			sessionVerifier = authentication.NewSessionVerifier(
+				kratos.NewSessionClient(specs.KratosPublicURL, outboundClient, tracer, monitor, logger),
+				specs.AuthenticationSessionRoutes,
+				specs.AuthenticationSessionOrigins,
+				authentication.IssuerConfig{
+					AllowedSubjects: allowedSubjects,
+					RequiredScope:   specs.AuthenticationRequiredScope,
+				},
+				tracer,
+				monitor,
+				logger,
+			)
+			sessionVerifier.SetCache(tokenCache)
+			logger.Infof("Browser sessions are accepted on %v from %v", specs.AuthenticationSessionRoutes, specs.AuthenticationSessionOrigins)
+		}
 	} else {
 		logger.Info("JWT authentication is disabled")
 		jwtVerifier = authentication.NewNoopVerifier()
 	}
 
-	kratosClient := kratos.NewClient(
+	kratosAdmin := kratos.NewClient(
 		specs.KratosAdminURL,
+		outboundClient,
 		tracer,
 		monitor,
 		logger,
 	)
+	readinessDependencies = append(readinessDependencies, status.Dependency{Name: "kratos", Probe: kratosAdmin.Ready, Timeout: specs.ReadinessKratosTimeout})
+	readiness := status.NewReadinessChecker(readinessDependencies, specs.ReadinessCacheTTL)
+	var kratosClient kratos.ClientInterface = kratosAdmin
+	if specs.KratosCreateIdentityRate > 0 {
+		kratosClient = kratos.NewRateLimitedClient(
+			kratosClient,
+			specs.KratosCreateIdentityRate,
+			specs.KratosCreateIdentityBurst,
+			specs.KratosCreateIdentityConcurrency,
+			tracer,
+			monitor,
+			logger,
+		)
+	}
 
 	tenantService := tenant.NewService(
 		s,
 		authorizer,
 		kratosClient,
 		specs.InvitationLifetime,
+		specs.AuthorizationEnabled,
+		specs.TenantListingSource,
 		tracer,
 		monitor,
 		logger,
 	)
+	tenantService.SetQuotas(tenant.Quotas{
+		Members:        specs.TenantMemberQuota,
+		PendingInvites: specs.TenantInviteQuota,
+		WarningPercent: specs.TenantQuotaWarningPercent,
+	})
 
-	authMiddleware := authentication.NewMiddleware(jwtVerifier, tracer, monitor, logger)
-	tenantHandler := tenant.NewHandler(tenantService, tracer, monitor, logger)
+	var jobQueue *jobs.Queue
+	if jobsEnabled {
+		jobQueue = jobs.NewQueue(
+			jobs.Config{
+				Workers:      specs.JobWorkers,
+				PollInterval: specs.JobPollInterval,
+				Timeout:      specs.JobTimeout,
+				MaxAttempts:  specs.JobMaxAttempts,
+				Backoff:      specs.JobRetryBackoff,
+				MaxBackoff:   specs.JobRetryMaxBackoff,
+			},
+			s,
+			dbClient,
+			tracer,
+			monitor,
+			logger,
+		)
+		registry.Go("jobs", func(ctx context.Context) error {
+			jobQueue.Run(ctx)
+			return nil
+		})
+		logger.Infof("Running the jobs with %d workers", specs.JobWorkers)
+	}
 
-	// Start gRPC server
-	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%v", specs.GRPCPort))
+	cipher, err := encryption.NewCipherFromConfig(specs.EncryptionKeys, specs.EncryptionActiveKeyID, tracer, monitor)
 	if err != nil {
-		logger.Fatalf("failed to listen on grpc port: %v", err)
+		return fmt.Errorf("invalid encryption keys: %v", err)
 	}
 
-	grpcServer := grpc.NewServer(
-		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-		grpc.UnaryInterceptor(authMiddleware.GRPCInterceptor),
-	)
-	v0.RegisterTenantServiceServer(grpcServer, tenantHandler)
+	var eventPublisher *events.Publisher
+	var subscriptionService *subscription.Service
+	if eventsEnabled {
+		sinks := make([]events.SinkInterface, 0, len(specs.EventSinkURLs))
+		for _, url := range specs.EventSinkURLs {
+			sinks = append(sinks, events.NewHTTPSink(url, specs.EventSinkSecret, outboundClient))
+		}
+		eventPublisher = events.NewPublisher(specs.EventSource, sinks, jobQueue, tracer, monitor, logger)
+		tenantService.SetEvents(eventPublisher)
+		if len(sinks) > 0 {
+			if specs.EventSinkSecret == "" {
+				logger.Warn("EVENT_SINK_SECRET is not set, the events are not signed")
+			}
+			logger.Infof("Publishing the tenant events to %d sinks", len(sinks))
+		}
+		if specs.WebhookSubscriptionsEnabled {
+			// the callback URLs are chosen by the tenants, only public addresses are dialed
+			deliveryClient, err := outbound.NewPublicClient(
+				outbound.NewConfig(specs.OutboundTLSCAFile, specs.OutboundTLSCertFile, specs.OutboundTLSKeyFile, ""),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create webhook delivery HTTP client: %v", err)
+			}
+			subscriptionService = subscription.NewService(s, jobQueue, cipher, deliveryClient, tracer, monitor, logger)
+			eventPublisher.SetSubscriptions(subscriptionService)
+			logger.Info("Delivering the tenant events to the webhook subscriptions of the tenants")
+		}
+	}
 
-	go func() {
-		logger.Infof("Starting gRPC server on port %v", specs.GRPCPort)
-		if err := grpcServer.Serve(lis); err != nil {
-			logger.Fatalf("failed to serve gRPC: %v", err)
+	clientIPs, err := clientip.NewResolver(specs.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	authMiddleware := authentication.NewMiddleware(jwtVerifier, tracer, monitor, logger)
+	if sessionVerifier != nil {
+		authMiddleware.SetSessionVerifier(sessionVerifier)
+	}
+	routePolicy, err := authentication.NewRoutePolicy(specs.AuthenticationPublicPaths, specs.AuthenticationRouteModes)
+	if err != nil {
+		return fmt.Errorf("invalid AUTHENTICATION_ROUTE_MODES: %v", err)
+	}
+	authMiddleware.SetRoutePolicy(routePolicy)
+	if specs.AuthenticationFailureThreshold > 0 {
+		authMiddleware.SetFailureTracker(authentication.NewFailureTracker(specs.AuthenticationFailureThreshold, specs.AuthenticationFailureWindow, specs.AuthenticationFailureSources))
+		logger.Infof("Clients failing to authenticate %d times within %s are blocked", specs.AuthenticationFailureThreshold, specs.AuthenticationFailureWindow)
+	}
+	if specs.AuthenticationAPIKeysEnabled {
+		authMiddleware.SetAPIKeyVerifier(authentication.NewAPIKeyVerifier(s, tracer, monitor, logger))
+		logger.Info("Tenant API keys are accepted")
+	}
+	idempotencyService := idempotency.NewService(s, cipher, specs.IdempotencyKeyTTL, tracer, monitor, logger)
+	roleService := role.NewService(s, authorizer, tracer, monitor, logger)
+	apiKeyService := apikey.NewService(s, tracer, monitor, logger)
+	deprecationService := deprecation.NewService(tracer, monitor, logger)
+	tenantHandler := tenant.NewHandler(tenantService, roleService, apiKeyService, idempotencyService, deprecationService, tracer, monitor, logger)
+	if subscriptionService != nil {
+		tenantHandler.SetSubscriptions(subscriptionService)
+	}
+	accessControl := tenant.NewAccessControl(authorizer, s, specs.Region, specs.RegionEndpoints, tracer, monitor, logger)
+	accessControl.SetFreshAuthMaxAge(specs.AuthenticationFreshAuthMaxAge)
+	accessControl.SetPlatformAdminGroup(specs.PlatformAdminGroup)
+	accessControl.SetEnforceTenantScope(specs.AuthorizationEnforceTenantScope)
+	if specs.AuthenticationStepUpEnabled {
+		accessControl.SetStepUpPolicy(&tenant.StepUpPolicy{
+			Scope:      specs.AuthenticationStepUpScope,
+			MFAMethods: specs.AuthenticationStepUpMFAMethods,
+			MaxAge:     specs.AuthenticationStepUpMaxAge,
+		})
+		logger.Infof("Deleting a tenant requires the %s scope or a login with MFA within %s", specs.AuthenticationStepUpScope, specs.AuthenticationStepUpMaxAge)
+	}
+	if specs.Region != "" {
+		logger.Infof("Serving region %s, writes to tenants homed in other regions are rejected", specs.Region)
+	}
+
+	maintenanceMode := maintenance.NewMode(logger)
+
+	var payloadLogger *logging.PayloadLogger
+	if specs.DebugPayloads {
+		payloadLogger = logging.NewPayloadLogger(logger)
+		logger.Warn("Logging redacted API payloads at debug level")
+	}
+
+	if specs.AuthorizationEnabled {
+		r := reconcile.NewService(s, authorizer, tracer, monitor, logger)
+		reconciler = r
+
+		if specs.ReconcileFGAInterval > 0 {
+			registry.Go("reconcile-fga", func(ctx context.Context) error {
+				r.Run(ctx, specs.ReconcileFGAInterval, specs.ReconcileFGAFix)
+				return nil
+			})
+			logger.Infof("Reconciling tenant relations with OpenFGA every %v", specs.ReconcileFGAInterval)
 		}
-	}()
+	}
 
-	router := web.NewRouter(
-		tenantHandler,
-		authMiddleware,
-		s,
-		dbClient,
-		authorizer,
-		tracer,
-		monitor,
-		logger,
-	)
-	logger.Infof("Starting HTTP server on port %v", specs.Port)
+	// opt-in only, nothing is sent unless TELEMETRY_ENABLED is set
+	if specs.TelemetryEnabled {
+		reporter := telemetry.NewService(
+			specs.TelemetryEndpoint,
+			map[string]bool{
+				"authorization":  specs.AuthorizationEnabled,
+				"authentication": specs.AuthenticationEnabled,
+				"load_shedding":  specs.LoadSheddingEnabled,
+				"encryption":     specs.EncryptionKeys != "",
+				"reconcile_fga":  specs.ReconcileFGAInterval > 0,
+				"tracing":        specs.TracingEnabled,
+			},
+			outboundClient,
+			s,
+			tracer,
+			monitor,
+			logger,
+		)
+		registry.Go("telemetry", func(ctx context.Context) error {
+			reporter.Run(ctx, specs.TelemetryInterval)
+			return nil
+		})
+		logger.Infof("Reporting anonymized telemetry to %s every %v", specs.TelemetryEndpoint, specs.TelemetryInterval)
+	}
+
+	var grpcServer *grpc.Server
+	if specs.GRPCEnabled {
+		interceptors := []grpc.UnaryServerInterceptor{
+			recoverer.UnaryServerInterceptor,
+			clientIPs.UnaryServerInterceptor,
+			openfga.ConsistencyInterceptor,
+		}
+		if specs.APITimeout > 0 {
+			interceptors = append(interceptors, timeoutInterceptor(specs.APITimeout))
+		}
+		if payloadLogger != nil {
+			// before authentication, so that rejected calls are logged too
+			interceptors = append(interceptors, payloadLogger.UnaryServerInterceptor)
+		}
+		interceptors = append(
+			interceptors,
+			exemptMethods(isPublicMethod, authMiddleware.GRPCInterceptor),
+			exemptMethods(isEssentialMethod, maintenanceMode.UnaryServerInterceptor(isReadOnlyMethod)),
+		)
+		if shedder != nil {
+			interceptors = append(interceptors, exemptMethods(isEssentialMethod, shedder.UnaryServerInterceptor(isListMethod)))
+		}
+		if rateLimiter != nil {
+			interceptors = append(interceptors, exemptMethods(isPublicMethod, rateLimiter.UnaryServerInterceptor))
+		}
+		interceptors = append(interceptors, exemptMethods(isPublicMethod, accessControl.UnaryServerInterceptor))
+
+		// health watches and reflection
+		streamInterceptors := []grpc.StreamServerInterceptor{
+			recoverer.StreamServerInterceptor,
+			exemptStreamMethods(isPublicMethod, authMiddleware.GRPCStreamInterceptor),
+		}
+
+		grpcOptions := []grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.ChainUnaryInterceptor(interceptors...),
+			grpc.ChainStreamInterceptor(streamInterceptors...),
+		}
+		if specs.APIMaxBodySize > 0 {
+			grpcOptions = append(grpcOptions, grpc.MaxRecvMsgSize(int(specs.APIMaxBodySize)))
+		}
+		if specs.GRPCTLSCertFile != "" || specs.GRPCTLSKeyFile != "" {
+			certs, err := tlsconfig.NewServer(
+				tlsconfig.ServerConfig{
+					CertFile:     specs.GRPCTLSCertFile,
+					KeyFile:      specs.GRPCTLSKeyFile,
+					ClientCAFile: specs.GRPCTLSClientCAFile,
+					ClientSANs:   specs.GRPCTLSClientSANs,
+				},
+				logger,
+			)
+			if err != nil {
+				return fmt.Errorf("invalid gRPC TLS configuration: %v", err)
+			}
+			grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(certs.TLSConfig())))
+
+			if specs.GRPCTLSReloadInterval > 0 {
+				registry.Go("grpc-tls-reload", func(ctx context.Context) error {
+					certs.Watch(ctx, specs.GRPCTLSReloadInterval)
+					return nil
+				})
+			}
+			if specs.GRPCTLSClientCAFile != "" {
+				logger.Info("Serving gRPC over mutual TLS")
+			} else {
+				logger.Info("Serving gRPC over TLS")
+			}
+		} else if specs.GRPCTLSClientCAFile != "" || len(specs.GRPCTLSClientSANs) > 0 {
+			return fmt.Errorf("GRPC_TLS_CLIENT_CA_FILE and GRPC_TLS_CLIENT_SANS require GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE")
+		}
+
+		grpcServer = grpc.NewServer(grpcOptions...)
+		v0.RegisterTenantServiceServer(grpcServer, tenantHandler)
+
+		healthServer := health.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		if specs.GRPCHealthCheckInterval > 0 {
+			checker := status.NewHealthChecker(v0.TenantService_ServiceDesc.ServiceName, readiness, healthServer, logger)
+			registry.Go("grpc-health", func(ctx context.Context) error {
+				checker.Run(ctx, specs.GRPCHealthCheckInterval)
+				return nil
+			})
+		} else {
+			healthServer.SetServingStatus(v0.TenantService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+		}
+		if specs.GRPCReflectionEnabled {
+			reflection.Register(grpcServer)
+		}
+		registry.OnDrain("grpc-server", func(ctx context.Context) error {
+			// lets the probes see the server going away before it stops
+			healthServer.Shutdown()
+			return stopGRPCServer(grpcServer)(ctx)
+		})
+
+		if specs.SinglePortEnabled {
+			logger.Infof("Serving gRPC on port %v along with HTTP", specs.Port)
+		} else {
+			lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%v", specs.GRPCPort))
+			if err != nil {
+				logger.Fatalf("failed to listen on grpc port: %v", err)
+			}
+
+			go func() {
+				logger.Infof("Starting gRPC server on port %v", specs.GRPCPort)
+				if err := grpcServer.Serve(lis); err != nil {
+					logger.Fatalf("failed to serve gRPC: %v", err)
+				}
+			}()
+		}
+	} else {
+		logger.Info("gRPC server is disabled")
+	}
+
+	webhooksService := webhooks.NewService(tokenTargets, s, authorizer, tracer, monitor, logger)
+	webhooksService.SetTokenClaim(tokenClaim)
+	webhooksService.SetRegistrationTenant(registrationTenant)
+	webhooksService.SetConsentPolicy(consentPolicy)
+	webhooksService.SetClientTenants(specs.TokenHookClientTenants)
+	if specs.WebhookQueueEnabled {
+		webhooksService.SetQueue(jobQueue)
+		logger.Info("Provisioning the registrations from the job queue")
+	}
+	if eventPublisher != nil {
+		webhooksService.SetEvents(eventPublisher)
+	}
+
+	if specs.OpsAddress != "" {
+		lis, err := ops.Listen(specs.OpsAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on ops address: %v", err)
+		}
+
+		opsServer := grpc.NewServer(
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.UnaryInterceptor(recoverer.UnaryServerInterceptor),
+		)
+		opsHandler := ops.NewHandler(reconciler, authzCache, maintenanceMode, logger, tracer, monitor, logger)
+		opsHandler.SetDeadLetters(webhooksService, dbClient)
+		opsv0.RegisterOpsServiceServer(opsServer, opsHandler)
+		registry.OnDrain("ops-server", stopGRPCServer(opsServer))
+
+		go func() {
+			logger.Infof("Starting ops server on %v", specs.OpsAddress)
+			if err := opsServer.Serve(lis); err != nil {
+				logger.Fatalf("failed to serve ops API: %v", err)
+			}
+		}()
+	}
+
+	var router http.Handler
+	if specs.HTTPEnabled {
+		var registrationAuth, tokenAuth, loginAuth, identityAuth, consentAuth *webhooks.SecretVerifier
+		if len(specs.WebhookRegistrationSecrets) > 0 {
+			registrationAuth = webhooks.NewSecretVerifier("registration", specs.WebhookRegistrationSecrets, specs.WebhookSignatureTolerance, logger)
+		} else {
+			logger.Warn("WEBHOOK_REGISTRATION_SECRETS is not set, the registration webhook is not authenticated")
+		}
+		if len(specs.WebhookTokenSecrets) > 0 {
+			tokenAuth = webhooks.NewSecretVerifier("token", specs.WebhookTokenSecrets, specs.WebhookSignatureTolerance, logger)
+		} else {
+			logger.Warn("WEBHOOK_TOKEN_SECRETS is not set, the token webhook is not authenticated")
+		}
+		if len(specs.WebhookLoginSecrets) > 0 {
+			loginAuth = webhooks.NewSecretVerifier("login", specs.WebhookLoginSecrets, specs.WebhookSignatureTolerance, logger)
+		} else {
+			logger.Warn("WEBHOOK_LOGIN_SECRETS is not set, the login webhook is not authenticated")
+		}
+		// removes memberships, never served without authentication
+		if len(specs.WebhookIdentitySecrets) > 0 {
+			identityAuth = webhooks.NewSecretVerifier("identity_deleted", specs.WebhookIdentitySecrets, specs.WebhookSignatureTolerance, logger)
+		}
+		// tells the memberships of any user, never served without authentication
+		if len(specs.WebhookConsentSecrets) > 0 {
+			consentAuth = webhooks.NewSecretVerifier("consent", specs.WebhookConsentSecrets, specs.WebhookSignatureTolerance, logger)
+		}
+		webhookLimiter := webhooks.NewRateLimiter(
+			webhooks.RateLimitConfig{
+				Limit:       specs.WebhookRateLimit,
+				Burst:       specs.WebhookRateBurst,
+				SourceLimit: specs.WebhookSourceRateLimit,
+				SourceBurst: specs.WebhookSourceRateBurst,
+				Sources:     specs.WebhookRateLimitSources,
+			},
+			monitor,
+			logger,
+		)
+		if webhookLimiter != nil {
+			logger.Infof("Rate limiting the webhooks to %v calls per second, %v per client IP", specs.WebhookRateLimit, specs.WebhookSourceRateLimit)
+		}
+
+		docsAPI, err := docs.NewAPI(openapi.Spec, specs.APIDocsEnabled, strings.TrimSuffix(specs.APIDocsAssetsURL, "/"), logger)
+		if err != nil {
+			return err
+		}
+
+		router = web.NewRouter(
+			// the gateway calls the handler in-process, skipping the gRPC interceptors
+			accessControl.Server(tenantHandler),
+			clientIPs,
+			recoverer,
+			authMiddleware,
+			rateLimiter,
+			shedder,
+			maintenanceMode,
+			web.Limits{
+				API:      web.RouteLimits{MaxBodySize: specs.APIMaxBodySize, Timeout: specs.APITimeout},
+				Webhooks: web.RouteLimits{MaxBodySize: specs.WebhookMaxBodySize, Timeout: specs.WebhookTimeout},
+			},
+			payloadLogger,
+			s,
+			dbClient,
+			authzModel,
+			dependencies,
+			readiness,
+			webhooksService,
+			specs.StrictJSON,
+			specs.StrictWebhookJSON,
+			webhookVersion,
+			registrationAuth,
+			tokenAuth,
+			loginAuth,
+			identityAuth,
+			consentAuth,
+			webhookLimiter,
+			docsAPI,
+			tracer,
+			monitor,
+			logger,
+		)
+		logger.Infof("Starting HTTP server on port %v", specs.Port)
+	} else {
+		// keeps the status and metrics endpoints reachable without the API
+		router = web.NewAdminRouter(s, authzModel, dependencies, readiness, recoverer, tracer, monitor, logger)
+		logger.Infof("Starting HTTP admin server on port %v", specs.Port)
+	}
 
 	srv := &http.Server{
-		Addr:         fmt.Sprintf("0.0.0.0:%v", specs.Port),
-		WriteTimeout: time.Second * 60,
-		ReadTimeout:  time.Second * 15,
-		IdleTimeout:  time.Second * 60,
-		Handler:      router,
+		Addr:              fmt.Sprintf("0.0.0.0:%v", specs.Port),
+		WriteTimeout:      time.Second * 60,
+		ReadTimeout:       time.Second * 15,
+		ReadHeaderTimeout: specs.HTTPReadHeaderTimeout,
+		IdleTimeout:       time.Second * 60,
+		Handler:           router,
 	}
+	if specs.SinglePortEnabled {
+		// gRPC clients speak HTTP/2 without TLS nor upgrade, with prior knowledge
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+		srv.Handler = singlePortHandler(grpcServer, router)
+	}
+	registry.OnDrain("http-server", srv.Shutdown)
 
 	var serverError error
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
+	registry.Start(context.Background())
+
 	go func() {
 		logger.Security().SystemStartup()
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -216,18 +974,137 @@ func serve() error {
 
 	<-c
 
-	// Create a deadline to wait for.
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	// the servers stop accepting and finish the requests in flight, then the
+	// jobs and the other tasks are drained, before the database is closed
+	ctx, cancel := context.WithTimeout(context.Background(), specs.ShutdownTimeout)
 	defer cancel()
 
 	logger.Security().SystemShutdown()
-	if err := srv.Shutdown(ctx); err != nil {
-		serverError = fmt.Errorf("server shutdown error: %w", err)
+	logger.Infof("Shutting down, draining for up to %v", specs.ShutdownTimeout)
+	if err := registry.Stop(ctx); err != nil {
+		serverError = errors.Join(serverError, fmt.Errorf("shutdown error: %w", err))
 	}
 
 	return serverError
 }
 
+// isListMethod tells whether a gRPC method is a listing, the first calls to be
+// shed when a dependency is degraded.
+func isListMethod(fullMethod string) bool {
+	return strings.HasPrefix(path.Base(fullMethod), "List")
+}
+
+// isReadOnlyMethod tells whether a gRPC method only reads, the calls still
+// served in maintenance.
+func isReadOnlyMethod(fullMethod string) bool {
+	method := path.Base(fullMethod)
+	return strings.HasPrefix(method, "List") || strings.HasPrefix(method, "Get")
+}
+
+// grpcMethodPolicy tells how the interceptors treat a gRPC method, the
+// methods missing from grpcMethodPolicies are authenticated, authorized,
+// refused in maintenance unless read-only and shed if listings.
+type grpcMethodPolicy struct {
+	// public methods are served without authentication nor authorization
+	public bool
+	// essential methods are served in maintenance and never shed
+	essential bool
+}
+
+// grpcMethodPolicies lists the methods exempted from some interceptors. The
+// streaming methods, health watches and reflection, only go through recovery
+// and authentication.
+var grpcMethodPolicies = map[string]grpcMethodPolicy{
+	healthpb.Health_Check_FullMethodName: {public: true, essential: true},
+	healthpb.Health_List_FullMethodName:  {public: true, essential: true},
+	healthpb.Health_Watch_FullMethodName: {public: true, essential: true},
+}
+
+// isPublicMethod tells whether a gRPC method is served without
+// authentication nor authorization.
+func isPublicMethod(fullMethod string) bool {
+	return grpcMethodPolicies[fullMethod].public
+}
+
+// isEssentialMethod tells whether a gRPC method is served in maintenance and
+// under load.
+func isEssentialMethod(fullMethod string) bool {
+	return grpcMethodPolicies[fullMethod].essential
+}
+
+// exemptStreamMethods returns interceptor skipped by the streaming methods
+// exempt tells.
+func exemptStreamMethods(exempt func(string) bool, interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if exempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		return interceptor(srv, ss, info, handler)
+	}
+}
+
+// exemptMethods returns interceptor skipped by the methods exempt tells.
+func exemptMethods(exempt func(string) bool, interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+// singlePortHandler serves the gRPC calls, HTTP/2 requests of an
+// application/grpc content type, with grpcServer and the other requests with
+// router.
+func singlePortHandler(grpcServer, router http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		router.ServeHTTP(w, r)
+	})
+}
+
+// timeoutInterceptor gives the calls timeout at most, through the deadline of
+// their context. The internal errors of the calls past it are reported as
+// DeadlineExceeded, they are the outcome of the timeout.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			switch grpcStatus.Code(err) {
+			case codes.Internal, codes.Unknown, codes.Canceled:
+				return nil, grpcStatus.Error(codes.DeadlineExceeded, "request timed out")
+			}
+		}
+		return resp, err
+	}
+}
+
+// stopGRPCServer returns a shutdown hook stopping server gracefully, or
+// abruptly once ctx is done.
+func stopGRPCServer(server *grpc.Server) func(context.Context) error {
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
 func main() {
 	if err := serve(); err != nil {
 		fmt.Fprintf(os.Stderr, "Fatal error: %v\n", err)
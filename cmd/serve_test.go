@@ -0,0 +1,192 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+func TestExemptMethods(t *testing.T) {
+	deny := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	interceptor := exemptMethods(isPublicMethod, deny)
+
+	tests := []struct {
+		name     string
+		method   string
+		expected codes.Code
+	}{
+		{name: "health check", method: healthpb.Health_Check_FullMethodName, expected: codes.OK},
+		{name: "health list", method: healthpb.Health_List_FullMethodName, expected: codes.OK},
+		{name: "tenant method", method: v0.TenantService_ListTenants_FullMethodName, expected: codes.Unauthenticated},
+		{name: "unknown method", method: "/tenant.v0.TenantService/Unknown", expected: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestExemptStreamMethods(t *testing.T) {
+	deny := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainStreamInterceptor(exemptStreamMethods(isPublicMethod, deny)))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	reflection.Register(grpcServer)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watch, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	if _, err := watch.Recv(); err != nil {
+		t.Errorf("expected the health watch served, got %v", err)
+	}
+
+	info, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	info.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	if _, err := info.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected reflection to require authentication, got %v", err)
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor := timeoutInterceptor(10 * time.Millisecond)
+	info := &grpc.UnaryServerInfo{FullMethod: v0.TenantService_ListTenants_FullMethodName}
+
+	tests := []struct {
+		name     string
+		handler  grpc.UnaryHandler
+		expected codes.Code
+	}{
+		{
+			name:     "in time",
+			handler:  func(ctx context.Context, req any) (any, error) { return nil, nil },
+			expected: codes.OK,
+		},
+		{
+			name: "past the deadline",
+			handler: func(ctx context.Context, req any) (any, error) {
+				<-ctx.Done()
+				return nil, status.Errorf(codes.Internal, "failed to list tenants: %v", ctx.Err())
+			},
+			expected: codes.DeadlineExceeded,
+		},
+		{
+			name: "unrelated error",
+			handler: func(ctx context.Context, req any) (any, error) {
+				return nil, errors.New("boom")
+			},
+			expected: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, info, tt.handler)
+			if status.Code(err) != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSinglePortHandler(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "router")
+	})
+
+	srv := httptest.NewUnstartedServer(singlePortHandler(grpcServer, router))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	defer srv.Close()
+
+	conn, err := grpc.NewClient(strings.TrimPrefix(srv.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("expected the gRPC call served, got %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING, got %s", resp.Status)
+	}
+
+	httpResp, err := http.Get(srv.URL + "/api/v0/status")
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	defer httpResp.Body.Close()
+	body, _ := io.ReadAll(httpResp.Body)
+	if string(body) != "router" {
+		t.Errorf("expected the HTTP request served by the router, got %q", body)
+	}
+}
+
+func TestGRPCMethodPolicies(t *testing.T) {
+	for method, policy := range grpcMethodPolicies {
+		// a public method held back in maintenance would fail the probes
+		if policy.public && !policy.essential {
+			t.Errorf("expected the public method %s to be essential", method)
+		}
+	}
+	if isEssentialMethod(v0.TenantService_CreateTenant_FullMethodName) {
+		t.Error("expected the tenant methods not to be essential")
+	}
+}
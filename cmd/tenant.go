@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -30,9 +31,14 @@ var createTenantCmd = &cobra.Command{
 		}
 		defer conn()
 
+		idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+		region, _ := cmd.Flags().GetString("region")
+
 		ctx := getAuthenticatedContext(context.Background())
 		resp, err := client.CreateTenant(ctx, &v0.CreateTenantRequest{
-			Name: args[0],
+			Name:           args[0],
+			IdempotencyKey: idempotencyKey,
+			Region:         region,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create tenant: %w", err)
@@ -46,22 +52,47 @@ var createTenantCmd = &cobra.Command{
 var deleteTenantCmd = &cobra.Command{
 	Use:   "delete [id]",
 	Short: "Delete a tenant",
-	Args:  cobra.ExactArgs(1),
+	Long: `Delete a tenant, after typing its name to confirm unless --force is set.
+
+The memberships of the tenant and its relations in OpenFGA are deleted with
+it, --cascade=members is the only behavior. Deactivate the tenant instead to
+keep them.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cascade, err := validateCascade(cmd, cascadeMembers, cascadeKeep)
+		if err != nil {
+			return err
+		}
+		if cascade == cascadeKeep {
+			return errors.New("memberships are deleted with the tenant, deactivate the tenant to keep them")
+		}
+
 		conn, client, err := getClient()
 		if err != nil {
 			return err
 		}
 		defer conn()
 
+		strict, _ := cmd.Flags().GetBool("strict")
+
 		ctx := getAuthenticatedContext(context.Background())
-		_, err = client.DeleteTenant(ctx, &v0.DeleteTenantRequest{
+		warning := fmt.Sprintf("Tenant %s will be deleted with all its memberships, this cannot be undone.", args[0])
+		if err := confirmTenant(ctx, cmd, client, args[0], warning); err != nil {
+			return err
+		}
+
+		resp, err := client.DeleteTenant(ctx, &v0.DeleteTenantRequest{
 			TenantId: args[0],
+			Strict:   strict,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to delete tenant: %w", err)
 		}
 
+		if !resp.Existed {
+			fmt.Printf("Tenant not found, nothing to delete: %s\n", args[0])
+			return nil
+		}
 		fmt.Printf("Tenant deleted: %s\n", args[0])
 		return nil
 	},
@@ -71,25 +102,58 @@ var listTenantsCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List tenants for the authenticated user",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		conn, client, err := getClient()
+		opts, err := readListOptions(cmd)
 		if err != nil {
 			return err
 		}
-		defer conn()
+		nameContains, _ := cmd.Flags().GetString("name-contains")
+		var enabled *bool
+		if cmd.Flags().Changed("enabled") {
+			v, _ := cmd.Flags().GetBool("enabled")
+			enabled = &v
+		}
 
-		ctx := getAuthenticatedContext(context.Background())
-		resp, err := client.ListTenants(ctx, &v0.ListTenantsRequest{})
+		conn, client, err := getClient()
 		if err != nil {
-			return fmt.Errorf("failed to list tenants: %w", err)
+			return err
 		}
+		defer conn()
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tENABLED\tCREATED_AT")
-		for _, t := range resp.Tenants {
-			fmt.Fprintf(w, "%s\t%s\t%v\t%s\n", t.Id, t.Name, t.Enabled, t.CreatedAt)
-		}
-		w.Flush()
-		return nil
+		return runList(cmd, opts, func(ctx context.Context) error {
+			ctx = getAuthenticatedContext(ctx)
+
+			resp := &v0.ListTenantsResponse{}
+			next, err := collectPages(opts, func(token string) (string, error) {
+				page, err := client.ListTenants(ctx, &v0.ListTenantsRequest{
+					PageSize:     opts.pageSize,
+					PageToken:    token,
+					Enabled:      enabled,
+					NameContains: nameContains,
+				})
+				if err != nil {
+					return "", err
+				}
+				resp.Tenants = append(resp.Tenants, page.Tenants...)
+				return page.NextPageToken, nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list tenants: %w", err)
+			}
+			resp.NextPageToken = next
+
+			if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tENABLED\tREGION\tCREATED_AT")
+			for _, t := range resp.Tenants {
+				fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%s\n", t.Id, t.Name, t.Enabled, t.Region, t.CreatedAt)
+			}
+			w.Flush()
+			printNextPageToken(resp.NextPageToken)
+			return nil
+		})
 	},
 }
 
@@ -124,8 +188,18 @@ var activateTenantCmd = &cobra.Command{
 var deactivateTenantCmd = &cobra.Command{
 	Use:   "deactivate [id]",
 	Short: "Deactivate a tenant",
-	Args:  cobra.ExactArgs(1),
+	Long: `Deactivate a tenant, after typing its name to confirm unless --force is set.
+
+--cascade=keep (the default) keeps the memberships, activating the tenant
+again restores the access of its users. --cascade=members also removes every
+member once the tenant is deactivated.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cascade, err := validateCascade(cmd, cascadeKeep, cascadeMembers)
+		if err != nil {
+			return err
+		}
+
 		conn, client, err := getClient()
 		if err != nil {
 			return err
@@ -133,6 +207,14 @@ var deactivateTenantCmd = &cobra.Command{
 		defer conn()
 
 		ctx := getAuthenticatedContext(context.Background())
+		warning := fmt.Sprintf("Tenant %s will be deactivated, its users lose access until it is activated again.", args[0])
+		if cascade == cascadeMembers {
+			warning = fmt.Sprintf("Tenant %s will be deactivated and all its members removed.", args[0])
+		}
+		if err := confirmTenant(ctx, cmd, client, args[0], warning); err != nil {
+			return err
+		}
+
 		_, err = client.UpdateTenant(ctx, &v0.UpdateTenantRequest{
 			Tenant: &v0.Tenant{
 				Id:      args[0],
@@ -145,6 +227,28 @@ var deactivateTenantCmd = &cobra.Command{
 		}
 
 		fmt.Printf("Tenant deactivated: %s\n", args[0])
+
+		if cascade != cascadeMembers {
+			return nil
+		}
+
+		resp, err := client.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{
+			TenantId:           args[0],
+			SkipIdentityLookup: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list members: %w", err)
+		}
+
+		for _, u := range resp.Users {
+			if _, err := client.RemoveTenantUser(ctx, &v0.RemoveTenantUserRequest{
+				TenantId: args[0],
+				UserId:   u.UserId,
+			}); err != nil {
+				return fmt.Errorf("failed to remove member %s: %w", u.UserId, err)
+			}
+		}
+		fmt.Printf("Members removed: %d\n", len(resp.Users))
 		return nil
 	},
 }
@@ -177,6 +281,50 @@ var updateTenantCmd = &cobra.Command{
 	},
 }
 
+var tenantStatsCmd = &cobra.Command{
+	Use:   "stats [id]",
+	Short: "Show the usage of a tenant against its quotas",
+	Long:  "Show the members and pending invitations of a tenant against TENANT_MEMBER_QUOTA and TENANT_INVITE_QUOTA, WARNING is set past TENANT_QUOTA_WARNING_PERCENT of a quota.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.GetTenantStats(ctx, &v0.GetTenantStatsRequest{
+			TenantId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get tenant stats: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "QUOTA\tUSED\tLIMIT\tWARNING")
+		for _, q := range []struct {
+			name  string
+			usage *v0.QuotaUsage
+		}{
+			{name: "members", usage: resp.Members},
+			{name: "pending_invites", usage: resp.PendingInvites},
+		} {
+			limit := "-"
+			if q.usage.GetLimit() > 0 {
+				limit = fmt.Sprint(q.usage.GetLimit())
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%t\n", q.name, q.usage.GetUsed(), limit, q.usage.GetWarning())
+		}
+		w.Flush()
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(tenantCmd)
 	tenantCmd.AddCommand(createTenantCmd)
@@ -185,6 +333,20 @@ func init() {
 	tenantCmd.AddCommand(activateTenantCmd)
 	tenantCmd.AddCommand(deactivateTenantCmd)
 	tenantCmd.AddCommand(updateTenantCmd)
+	tenantCmd.AddCommand(tenantStatsCmd)
+
+	createTenantCmd.Flags().String("idempotency-key", "", "Key making retries of this creation safe")
+	createTenantCmd.Flags().String("region", "", "Region to home the tenant in, writes are only accepted there")
+	addListFlags(listTenantsCmd)
+	listTenantsCmd.Flags().Bool("enabled", false, "Only list the enabled tenants, or the disabled ones with --enabled=false")
+	listTenantsCmd.Flags().String("name-contains", "", "Only list the tenants whose name contains this text, ignoring case")
+	deleteTenantCmd.Flags().Bool("strict", false, "Fail when the tenant does not exist")
+	deleteTenantCmd.Flags().Bool("force", false, "Delete without asking for a confirmation")
+	deleteTenantCmd.Flags().String("cascade", cascadeMembers, "What happens to the memberships, only members (deleted with the tenant)")
+	deactivateTenantCmd.Flags().Bool("force", false, "Deactivate without asking for a confirmation")
+	deactivateTenantCmd.Flags().String("cascade", cascadeKeep, "What happens to the memberships: keep or members (removed)")
+	_ = deleteTenantCmd.RegisterFlagCompletionFunc("cascade", cobra.FixedCompletions([]string{cascadeMembers}, cobra.ShellCompDirectiveNoFileComp))
+	_ = deactivateTenantCmd.RegisterFlagCompletionFunc("cascade", cobra.FixedCompletions([]string{cascadeKeep, cascadeMembers}, cobra.ShellCompDirectiveNoFileComp))
 
 	// Removed owners flag as it's not supported in simple name/enable update
 }
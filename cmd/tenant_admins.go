@@ -0,0 +1,133 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var adminsCmd = &cobra.Command{
+	Use:   "admins",
+	Short: "Manage the platform admins of support groups",
+	Long: `Manage the platform admins of support groups.
+
+The admins of a support group have admin access to every tenant linked to it.`,
+}
+
+var addAdminCmd = &cobra.Command{
+	Use:   "add [group-id] [user-id]",
+	Short: "Make a user an admin of a support group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.AddPlatformAdmin(ctx, &v0.AddPlatformAdminRequest{
+			GroupId: args[0],
+			UserId:  args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add platform admin: %w", err)
+		}
+
+		fmt.Printf("User %s is now an admin of %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var removeAdminCmd = &cobra.Command{
+	Use:   "remove [group-id] [user-id]",
+	Short: "Remove a user from the admins of a support group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.RemovePlatformAdmin(ctx, &v0.RemovePlatformAdminRequest{
+			GroupId: args[0],
+			UserId:  args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove platform admin: %w", err)
+		}
+
+		fmt.Printf("User %s removed from the admins of %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var listAdminsCmd = &cobra.Command{
+	Use:   "list [group-id]",
+	Short: "List the admins of a support group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListPlatformAdmins(ctx, &v0.ListPlatformAdminsRequest{
+			GroupId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list platform admins: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		for _, id := range resp.UserIds {
+			fmt.Println(id)
+		}
+		return nil
+	},
+}
+
+var linkTenantCmd = &cobra.Command{
+	Use:   "link-tenant [tenant-id] [group-id]",
+	Short: "Grant the admins of a support group access to a tenant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.LinkTenantToSupportGroup(ctx, &v0.LinkTenantToSupportGroupRequest{
+			TenantId: args[0],
+			GroupId:  args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link tenant to support group: %w", err)
+		}
+
+		fmt.Printf("Tenant %s linked to %s\n", args[0], args[1])
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(adminsCmd)
+	adminsCmd.AddCommand(addAdminCmd)
+	adminsCmd.AddCommand(removeAdminCmd)
+	adminsCmd.AddCommand(listAdminsCmd)
+	adminsCmd.AddCommand(linkTenantCmd)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var apiKeysCmd = &cobra.Command{
+	Use:   "api-keys",
+	Short: "Manage tenant API keys",
+}
+
+var listAPIKeysCmd = &cobra.Command{
+	Use:   "list [tenant-id]",
+	Short: "List API keys of a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListAPIKeys(ctx, &v0.ListAPIKeysRequest{
+			TenantId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tROLE\tPREFIX\tREVOKED")
+		for _, k := range resp.ApiKeys {
+			revoked := "-"
+			if k.RevokedAt != "" {
+				revoked = k.RevokedAt
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", k.Id, k.Name, k.Role, k.Prefix, revoked)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var createAPIKeyCmd = &cobra.Command{
+	Use:   "create [tenant-id] [name] [role]",
+	Short: "Create an API key holding a role on the tenant",
+	Long:  "Create an API key holding a built-in role (owner, admin or member) on the tenant. The key is printed once and cannot be retrieved again.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.CreateAPIKey(ctx, &v0.CreateAPIKeyRequest{
+			TenantId: args[0],
+			Name:     args[1],
+			Role:     args[2],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		fmt.Printf("API key created: %s (ID: %s)\n", resp.ApiKey.Name, resp.ApiKey.Id)
+		fmt.Printf("Key: %s\n", resp.Key)
+		fmt.Println("Store the key now, it cannot be retrieved again.")
+		return nil
+	},
+}
+
+var revokeAPIKeyCmd = &cobra.Command{
+	Use:   "revoke [tenant-id] [api-key-id]",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.RevokeAPIKey(ctx, &v0.RevokeAPIKeyRequest{
+			TenantId: args[0],
+			ApiKeyId: args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke API key: %w", err)
+		}
+
+		fmt.Printf("API key revoked: %s\n", args[1])
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(apiKeysCmd)
+	apiKeysCmd.AddCommand(listAPIKeysCmd)
+	apiKeysCmd.AddCommand(createAPIKeyCmd)
+	apiKeysCmd.AddCommand(revokeAPIKeyCmd)
+}
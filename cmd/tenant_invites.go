@@ -0,0 +1,131 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var invitesCmd = &cobra.Command{
+	Use:   "invites",
+	Short: "Manage tenant invitations",
+}
+
+var listInvitesCmd = &cobra.Command{
+	Use:   "list [tenant-id]",
+	Short: "List invitations of a tenant",
+	Long:  "List the invitations of a tenant, newest first, with their state: pending, accepted (the user logged in since), expired or revoked.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListInvites(ctx, &v0.ListInvitesRequest{
+			TenantId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list invites: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tEMAIL\tROLE\tSTATE\tEXPIRES_AT\tACCEPTED_AT")
+		for _, i := range resp.Invites {
+			accepted := "-"
+			if i.AcceptedAt != "" {
+				accepted = i.AcceptedAt
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", i.Id, i.Email, i.Role, i.State, i.ExpiresAt, accepted)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var revokeInviteCmd = &cobra.Command{
+	Use:   "revoke [tenant-id] [invite-id]",
+	Short: "Revoke an invitation",
+	Long:  "Revoke an invitation not accepted yet. The user is removed from the tenant when the invitation added them to it, an accepted invitation is left to tenant users remove.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.RevokeInvite(ctx, &v0.RevokeInviteRequest{
+			TenantId: args[0],
+			InviteId: args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke invite: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		fmt.Printf("Invite revoked: %s (%s)\n", resp.Id, resp.Email)
+		return nil
+	},
+}
+
+var resendInviteCmd = &cobra.Command{
+	Use:   "resend [tenant-id] [invite-id]",
+	Short: "Send an invitation again",
+	Long:  "Create a new link for a pending or expired invitation, valid for the invitation lifetime of the service.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ResendInvite(ctx, &v0.ResendInviteRequest{
+			TenantId: args[0],
+			InviteId: args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resend invite: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		fmt.Printf("Invite sent again: %s (%s)\n", resp.Invite.Id, resp.Invite.Email)
+		fmt.Printf("Expires at: %s\n", resp.Invite.ExpiresAt)
+		if resp.Link != "" {
+			fmt.Printf("Link: %s\n", resp.Link)
+		}
+		if resp.Code != "" {
+			fmt.Printf("Code: %s\n", resp.Code)
+		}
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(invitesCmd)
+	invitesCmd.AddCommand(listInvitesCmd)
+	invitesCmd.AddCommand(revokeInviteCmd)
+	invitesCmd.AddCommand(resendInviteCmd)
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var rolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "Manage custom tenant roles",
+}
+
+var listRolesCmd = &cobra.Command{
+	Use:   "list [tenant-id]",
+	Short: "List custom roles of a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListRoles(ctx, &v0.ListRolesRequest{
+			TenantId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list roles: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPERMISSIONS")
+		for _, r := range resp.Roles {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.Id, r.Name, strings.Join(r.Permissions, ","))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var createRoleCmd = &cobra.Command{
+	Use:   "create [tenant-id] [name] [permission...]",
+	Short: "Create a custom role granting the given permissions",
+	Long:  "Create a custom role granting the given permissions (can_view, can_edit, can_create, can_delete) to its assignees.",
+	Args:  cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.CreateRole(ctx, &v0.CreateRoleRequest{
+			TenantId:    args[0],
+			Name:        args[1],
+			Permissions: args[2:],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create role: %w", err)
+		}
+
+		fmt.Printf("Role created: %s (ID: %s)\n", resp.Role.Name, resp.Role.Id)
+		return nil
+	},
+}
+
+var updateRoleCmd = &cobra.Command{
+	Use:   "update [tenant-id] [role-id] [permission...]",
+	Short: "Replace the permissions of a custom role",
+	Args:  cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.UpdateRole(ctx, &v0.UpdateRoleRequest{
+			TenantId:    args[0],
+			RoleId:      args[1],
+			Permissions: args[2:],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update role: %w", err)
+		}
+
+		fmt.Printf("Role updated: %s\n", resp.Role.Name)
+		fmt.Printf("Permissions: %s\n", strings.Join(resp.Role.Permissions, ","))
+		return nil
+	},
+}
+
+var deleteRoleCmd = &cobra.Command{
+	Use:   "delete [tenant-id] [role-id]",
+	Short: "Delete a custom role and its assignments",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.DeleteRole(ctx, &v0.DeleteRoleRequest{
+			TenantId: args[0],
+			RoleId:   args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete role: %w", err)
+		}
+
+		fmt.Printf("Role deleted: %s\n", args[1])
+		return nil
+	},
+}
+
+var assignRoleCmd = &cobra.Command{
+	Use:   "assign [tenant-id] [role-id] [user-id]",
+	Short: "Assign a custom role to a tenant member",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.AssignRole(ctx, &v0.AssignRoleRequest{
+			TenantId: args[0],
+			RoleId:   args[1],
+			UserId:   args[2],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+
+		fmt.Printf("Role %s assigned to %s\n", args[1], args[2])
+		return nil
+	},
+}
+
+var unassignRoleCmd = &cobra.Command{
+	Use:   "unassign [tenant-id] [role-id] [user-id]",
+	Short: "Remove a custom role from a tenant member",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.UnassignRole(ctx, &v0.UnassignRoleRequest{
+			TenantId: args[0],
+			RoleId:   args[1],
+			UserId:   args[2],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to unassign role: %w", err)
+		}
+
+		fmt.Printf("Role %s removed from %s\n", args[1], args[2])
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(rolesCmd)
+	rolesCmd.AddCommand(listRolesCmd)
+	rolesCmd.AddCommand(createRoleCmd)
+	rolesCmd.AddCommand(updateRoleCmd)
+	rolesCmd.AddCommand(deleteRoleCmd)
+	rolesCmd.AddCommand(assignRoleCmd)
+	rolesCmd.AddCommand(unassignRoleCmd)
+}
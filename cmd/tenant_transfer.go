@@ -0,0 +1,500 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/canonical/tenant-service/internal/types"
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+const (
+	transferJSON = "json"
+	transferCSV  = "csv"
+)
+
+var transferCSVHeader = []string{"tenant_id", "tenant_name", "enabled", "region", "user_id", "email", "role"}
+
+// exportedTenant is a tenant with its memberships, as written by export and
+// read by import.
+type exportedTenant struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Enabled   bool             `json:"enabled"`
+	Region    string           `json:"region,omitempty"`
+	CreatedAt string           `json:"created_at,omitempty"`
+	Members   []exportedMember `json:"members"`
+}
+
+type exportedMember struct {
+	UserID string `json:"user_id,omitempty"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+type transferFailure struct {
+	tenant string
+	email  string
+	err    error
+}
+
+var exportTenantsCmd = &cobra.Command{
+	Use:   "export [tenant-id...]",
+	Short: "Export tenants and their memberships to a JSON or CSV file",
+	Long: `Export tenants and their memberships to a JSON or CSV file, the given
+tenants or all of them with --all.
+
+The format follows the extension of --file unless --format is set, JSON is
+written to the standard output without --file. Tenants are written as they
+are listed, a tenant whose members cannot be listed is reported at the end
+and left out of the file.
+
+Custom roles, API keys and webhook subscriptions are not exported.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		path, _ := cmd.Flags().GetString("file")
+		if all == (len(args) > 0) {
+			return errors.New("either --all or tenant IDs must be given")
+		}
+
+		format, err := transferFormat(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(cmd.Context())
+		resp, err := client.ListTenants(ctx, &v0.ListTenantsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list tenants: %w", err)
+		}
+
+		tenants := resp.Tenants
+		if !all {
+			tenants = slices.DeleteFunc(tenants, func(t *v0.Tenant) bool { return !slices.Contains(args, t.Id) })
+			if len(tenants) != len(args) {
+				return fmt.Errorf("found %d of the %d tenants given", len(tenants), len(args))
+			}
+		}
+
+		out := io.Writer(os.Stdout)
+		if path != "" {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		w := newTenantWriter(out, format)
+		var failures []transferFailure
+		for i, t := range tenants {
+			users, err := client.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{TenantId: t.Id})
+			if err != nil {
+				failures = append(failures, transferFailure{tenant: t.Name, err: err})
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s: failed to list members\n", i+1, len(tenants), t.Name)
+				continue
+			}
+
+			exported := &exportedTenant{
+				ID:        t.Id,
+				Name:      t.Name,
+				Enabled:   t.Enabled,
+				Region:    t.Region,
+				CreatedAt: t.CreatedAt,
+				Members:   make([]exportedMember, 0, len(users.Users)),
+			}
+			for _, u := range users.Users {
+				if u.IdentityStatus != v0.IdentityStatus_IDENTITY_STATUS_RESOLVED {
+					fmt.Fprintf(os.Stderr, "[%d/%d] %s: no email for member %s, it cannot be imported\n", i+1, len(tenants), t.Name, u.UserId)
+				}
+				exported.Members = append(exported.Members, exportedMember{UserID: u.UserId, Email: u.Email, Role: u.Role})
+			}
+
+			if err := w.write(exported); err != nil {
+				return fmt.Errorf("failed to write export file: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s: %d members\n", i+1, len(tenants), t.Name, len(exported.Members))
+		}
+		if err := w.close(); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Exported %d of %d tenants\n", len(tenants)-len(failures), len(tenants))
+		return reportTransferFailures(failures, "export")
+	},
+}
+
+var importTenantsCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tenants and their memberships from a JSON or CSV file",
+	Long: `Import tenants and their memberships from a file written by export.
+
+A tenant is matched by name: missing tenants are created, with new IDs, and
+existing ones are kept as they are. Members are provisioned by email, the
+identity being created when it does not exist, members of an existing
+tenant are skipped. A tenant or member that cannot be imported is reported
+at the end, running the command again retries them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("file")
+		perSecond, _ := cmd.Flags().GetFloat64("rate")
+		if perSecond <= 0 {
+			return errors.New("--rate must be positive")
+		}
+
+		format, err := transferFormat(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer f.Close()
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(cmd.Context())
+		resp, err := client.ListTenants(ctx, &v0.ListTenantsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list tenants: %w", err)
+		}
+		existing := make(map[string]string, len(resp.Tenants))
+		for _, t := range resp.Tenants {
+			existing[t.Name] = t.Id
+		}
+
+		limiter := rate.NewLimiter(rate.Limit(perSecond), 1)
+		var (
+			failures []transferFailure
+			count    int
+		)
+		err = readTenants(f, format, func(t *exportedTenant) error {
+			count++
+			failures = append(failures, importTenant(ctx, client, limiter, existing, t, count)...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %d tenants, %d failures\n", count, len(failures))
+		return reportTransferFailures(failures, "import")
+	},
+}
+
+// importTenant creates t unless a tenant has the same name and provisions
+// the members missing from it.
+func importTenant(ctx context.Context, client v0.TenantServiceClient, limiter *rate.Limiter, existing map[string]string, t *exportedTenant, n int) []transferFailure {
+	tenantID, matched := existing[t.Name]
+	members := make(map[string]bool)
+
+	if matched {
+		users, err := client.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{TenantId: tenantID})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%d] %s: failed to list members\n", n, t.Name)
+			return []transferFailure{{tenant: t.Name, err: err}}
+		}
+		for _, u := range users.Users {
+			members[u.Email] = true
+		}
+	} else {
+		// a deterministic key makes reruns safe when a response was lost
+		key := fmt.Sprintf("import-%x", sha256.Sum256([]byte(t.ID+"\n"+t.Name)))
+		resp, err := client.CreateTenant(ctx, &v0.CreateTenantRequest{
+			Name:           t.Name,
+			Region:         t.Region,
+			IdempotencyKey: key,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%d] %s: failed to create tenant\n", n, t.Name)
+			return []transferFailure{{tenant: t.Name, err: err}}
+		}
+		tenantID = resp.Tenant.Id
+		existing[t.Name] = tenantID
+
+		if !t.Enabled {
+			_, err := client.UpdateTenant(ctx, &v0.UpdateTenantRequest{
+				Tenant:     &v0.Tenant{Id: tenantID, Enabled: false},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"enabled"}},
+			})
+			if err != nil {
+				return []transferFailure{{tenant: t.Name, err: fmt.Errorf("failed to deactivate tenant: %w", err)}}
+			}
+		}
+	}
+
+	var failures []transferFailure
+	added := 0
+	for _, m := range t.Members {
+		if m.Email == "" {
+			failures = append(failures, transferFailure{tenant: t.Name, email: m.UserID, err: errors.New("no email exported")})
+			continue
+		}
+		if members[m.Email] {
+			continue
+		}
+		if err := provisionBulkUser(ctx, client, limiter, tenantID, bulkUser{email: m.Email, role: m.Role}); err != nil {
+			failures = append(failures, transferFailure{tenant: t.Name, email: m.Email, err: err})
+			continue
+		}
+		members[m.Email] = true
+		added++
+	}
+
+	state := "created"
+	if matched {
+		state = "matched"
+	}
+	fmt.Fprintf(os.Stderr, "[%d] %s: %s as %s, %d members added, %d failed\n", n, t.Name, state, tenantID, added, len(failures))
+	return failures
+}
+
+func reportTransferFailures(failures []transferFailure, action string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "TENANT\tMEMBER\tERROR")
+	for _, f := range failures {
+		fmt.Fprintf(w, "%s\t%s\t%v\n", f.tenant, f.email, f.err)
+	}
+	w.Flush()
+
+	return fmt.Errorf("failed to %s %d tenants or members", action, len(failures))
+}
+
+// transferFormat returns the --format flag, or the format matching the
+// extension of path.
+func transferFormat(cmd *cobra.Command, path string) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = transferJSON
+		if filepath.Ext(path) == ".csv" {
+			format = transferCSV
+		}
+	}
+	if format != transferJSON && format != transferCSV {
+		return "", fmt.Errorf("invalid --format %q, must be json or csv", format)
+	}
+	return format, nil
+}
+
+// tenantWriter writes the tenants one at a time, so that an export holds
+// no more than a tenant in memory.
+type tenantWriter interface {
+	write(*exportedTenant) error
+	close() error
+}
+
+func newTenantWriter(w io.Writer, format string) tenantWriter {
+	if format == transferCSV {
+		return &csvTenantWriter{w: csv.NewWriter(w)}
+	}
+	return &jsonTenantWriter{w: w}
+}
+
+// jsonTenantWriter writes a JSON array of tenants.
+type jsonTenantWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonTenantWriter) write(t *exportedTenant) error {
+	data, err := json.MarshalIndent(t, "  ", "  ")
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n  "
+	if j.count == 0 {
+		sep = "[\n  "
+	}
+	j.count++
+
+	_, err = fmt.Fprintf(j.w, "%s%s", sep, data)
+	return err
+}
+
+func (j *jsonTenantWriter) close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// csvTenantWriter writes a line per membership, and a line without a user
+// for a tenant without members.
+type csvTenantWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvTenantWriter) write(t *exportedTenant) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write(transferCSVHeader); err != nil {
+			return err
+		}
+	}
+
+	tenant := []string{t.ID, t.Name, strconv.FormatBool(t.Enabled), t.Region}
+	if len(t.Members) == 0 {
+		if err := c.w.Write(append(tenant, "", "", "")); err != nil {
+			return err
+		}
+	}
+	for _, m := range t.Members {
+		if err := c.w.Write(append(slices.Clone(tenant), m.UserID, m.Email, m.Role)); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvTenantWriter) close() error {
+	if !c.header {
+		if err := c.w.Write(transferCSVHeader); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// readTenants calls fn with every tenant of r as it is read.
+func readTenants(r io.Reader, format string, fn func(*exportedTenant) error) error {
+	if format == transferCSV {
+		return readCSVTenants(r, fn)
+	}
+
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return errors.New("invalid import file, expected a JSON array of tenants")
+	}
+	for dec.More() {
+		t := new(exportedTenant)
+		if err := dec.Decode(t); err != nil {
+			return fmt.Errorf("failed to read import file: %w", err)
+		}
+		if err := validateExportedTenant(t); err != nil {
+			return err
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+	return nil
+}
+
+// readCSVTenants groups the consecutive lines of a tenant.
+func readCSVTenants(r io.Reader, fn func(*exportedTenant) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(transferCSVHeader)
+
+	header, err := cr.Read()
+	if err != nil || !slices.Equal(header, transferCSVHeader) {
+		return fmt.Errorf("invalid import file, expected the header %v", transferCSVHeader)
+	}
+
+	var current *exportedTenant
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		if err := validateExportedTenant(current); err != nil {
+			return err
+		}
+		return fn(current)
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read import file: %w", err)
+		}
+
+		if current == nil || record[0] != current.ID || record[1] != current.Name {
+			if err := flush(); err != nil {
+				return err
+			}
+			enabled, err := strconv.ParseBool(record[2])
+			if err != nil {
+				line, _ := cr.FieldPos(2)
+				return fmt.Errorf("invalid import file line %d: %w", line, err)
+			}
+			current = &exportedTenant{ID: record[0], Name: record[1], Enabled: enabled, Region: record[3]}
+		}
+
+		if record[4] != "" || record[5] != "" {
+			current.Members = append(current.Members, exportedMember{UserID: record[4], Email: record[5], Role: record[6]})
+		}
+	}
+	return flush()
+}
+
+func validateExportedTenant(t *exportedTenant) error {
+	if t.Name == "" {
+		return fmt.Errorf("invalid import file, tenant %q has no name", t.ID)
+	}
+	for _, m := range t.Members {
+		if _, err := types.ParseMembershipRole(m.Role); err != nil {
+			return fmt.Errorf("invalid import file, tenant %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	tenantCmd.AddCommand(exportTenantsCmd)
+	tenantCmd.AddCommand(importTenantsCmd)
+
+	exportTenantsCmd.Flags().Bool("all", false, "Export all the tenants")
+	exportTenantsCmd.Flags().StringP("file", "f", "", "File to write, the standard output when unset")
+	exportTenantsCmd.Flags().String("format", "", "json or csv, defaults to the extension of --file")
+
+	importTenantsCmd.Flags().StringP("file", "f", "", "File written by export")
+	importTenantsCmd.Flags().String("format", "", "json or csv, defaults to the extension of --file")
+	importTenantsCmd.Flags().Float64("rate", 5, "Maximum provisioning requests per second")
+	_ = importTenantsCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTenantTransferRoundTrip(t *testing.T) {
+	tenants := []*exportedTenant{
+		{
+			ID:      "tenant-1",
+			Name:    "Acme",
+			Enabled: true,
+			Region:  "eu",
+			Members: []exportedMember{
+				{UserID: "user-1", Email: "owner@acme.com", Role: "owner"},
+				{UserID: "user-2", Email: "member@acme.com", Role: "member"},
+			},
+		},
+		{ID: "tenant-2", Name: "Empty, Inc", Members: []exportedMember{}},
+	}
+
+	for _, format := range []string{transferJSON, transferCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := newTenantWriter(&buf, format)
+			for _, tenant := range tenants {
+				if err := w.write(tenant); err != nil {
+					t.Fatalf("write() error = %v", err)
+				}
+			}
+			if err := w.close(); err != nil {
+				t.Fatalf("close() error = %v", err)
+			}
+
+			var read []*exportedTenant
+			err := readTenants(&buf, format, func(tenant *exportedTenant) error {
+				if tenant.Members == nil {
+					tenant.Members = []exportedMember{}
+				}
+				read = append(read, tenant)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("readTenants() error = %v", err)
+			}
+			if !reflect.DeepEqual(read, tenants) {
+				t.Errorf("readTenants() = %+v, want %+v", read, tenants)
+			}
+		})
+	}
+}
+
+func TestTenantTransferEmpty(t *testing.T) {
+	for _, format := range []string{transferJSON, transferCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := newTenantWriter(&buf, format).close(); err != nil {
+				t.Fatalf("close() error = %v", err)
+			}
+
+			count := 0
+			if err := readTenants(&buf, format, func(*exportedTenant) error { count++; return nil }); err != nil {
+				t.Fatalf("readTenants() error = %v", err)
+			}
+			if count != 0 {
+				t.Errorf("expected no tenants, got %d", count)
+			}
+		})
+	}
+}
+
+func TestReadTenantsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{name: "JSON object", format: transferJSON, input: `{"tenants": []}`},
+		{name: "JSON invalid role", format: transferJSON, input: `[{"id": "1", "name": "Acme", "members": [{"email": "a@acme.com", "role": "root"}]}]`},
+		{name: "JSON without name", format: transferJSON, input: `[{"id": "1"}]`},
+		{name: "CSV without header", format: transferCSV, input: "1,Acme,true,,,,\n"},
+		{name: "CSV invalid enabled", format: transferCSV, input: strings.Join(transferCSVHeader, ",") + "\n1,Acme,yes please,,,,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := readTenants(strings.NewReader(tt.input), tt.format, func(*exportedTenant) error { return nil })
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
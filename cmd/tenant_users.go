@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"text/tabwriter"
 
 	v0 "github.com/canonical/tenant-service/v0"
@@ -22,6 +24,65 @@ var listUsersCmd = &cobra.Command{
 	Use:   "list [tenant-id]",
 	Short: "List users for a tenant",
 	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := readListOptions(cmd)
+		if err != nil {
+			return err
+		}
+		skipIdentityLookup, _ := cmd.Flags().GetBool("skip-identity-lookup")
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		return runList(cmd, opts, func(ctx context.Context) error {
+			ctx = getAuthenticatedContext(ctx)
+
+			resp := &v0.ListTenantUsersResponse{}
+			next, err := collectPages(opts, func(token string) (string, error) {
+				page, err := client.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{
+					TenantId:           args[0],
+					SkipIdentityLookup: skipIdentityLookup,
+					PageSize:           opts.pageSize,
+					PageToken:          token,
+				})
+				if err != nil {
+					return "", err
+				}
+				resp.Users = append(resp.Users, page.Users...)
+				return page.NextPageToken, nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list users: %w", err)
+			}
+			resp.NextPageToken = next
+
+			if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "USER_ID\tEMAIL\tROLE\tJOINED_AT")
+			for _, u := range resp.Users {
+				email := u.Email
+				if u.IdentityStatus == v0.IdentityStatus_IDENTITY_STATUS_UNKNOWN {
+					email = "<unknown>"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", u.UserId, email, u.Role, u.JoinedAt)
+			}
+			w.Flush()
+			printNextPageToken(resp.NextPageToken)
+			return nil
+		})
+	},
+}
+
+var getUserCmd = &cobra.Command{
+	Use:   "get [tenant-id] [user-id]",
+	Short: "Show the membership of a user in a tenant",
+	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conn, client, err := getClient()
 		if err != nil {
@@ -30,18 +91,38 @@ var listUsersCmd = &cobra.Command{
 		defer conn()
 
 		ctx := getAuthenticatedContext(context.Background())
-		resp, err := client.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{
+		resp, err := client.GetTenantUser(ctx, &v0.GetTenantUserRequest{
 			TenantId: args[0],
+			UserId:   args[1],
 		})
 		if err != nil {
-			return fmt.Errorf("failed to list users: %w", err)
+			return fmt.Errorf("failed to get user: %w", err)
 		}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "USER_ID\tEMAIL\tROLE")
-		for _, u := range resp.Users {
-			fmt.Fprintf(w, "%s\t%s\t%s\n", u.UserId, u.Email, u.Role)
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
 		}
+
+		email := resp.User.Email
+		if resp.User.IdentityStatus == v0.IdentityStatus_IDENTITY_STATUS_UNKNOWN {
+			email = "<unknown>"
+		}
+
+		var granted []string
+		for permission, ok := range resp.Permissions {
+			if ok {
+				granted = append(granted, permission)
+			}
+		}
+		slices.Sort(granted)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "USER_ID:\t%s\n", resp.User.UserId)
+		fmt.Fprintf(w, "EMAIL:\t%s\n", email)
+		fmt.Fprintf(w, "ROLE:\t%s\n", resp.User.Role)
+		fmt.Fprintf(w, "JOINED_AT:\t%s\n", resp.User.JoinedAt)
+		fmt.Fprintf(w, "RELATIONS:\t%s\n", strings.Join(resp.Relations, ","))
+		fmt.Fprintf(w, "PERMISSIONS:\t%s\n", strings.Join(granted, ","))
 		w.Flush()
 		return nil
 	},
@@ -58,11 +139,14 @@ var inviteUserCmd = &cobra.Command{
 		}
 		defer conn()
 
+		idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+
 		ctx := getAuthenticatedContext(context.Background())
 		resp, err := client.InviteMember(ctx, &v0.InviteMemberRequest{
-			TenantId: args[0],
-			Email:    args[1],
-			Role:     args[2],
+			TenantId:       args[0],
+			Email:          args[1],
+			Role:           args[2],
+			IdempotencyKey: idempotencyKey,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to invite user: %w", err)
@@ -91,11 +175,14 @@ var provisionUserCmd = &cobra.Command{
 		}
 		defer conn()
 
+		idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+
 		ctx := getAuthenticatedContext(context.Background())
 		_, err = client.ProvisionUser(ctx, &v0.ProvisionUserRequest{
-			TenantId: args[0],
-			Email:    args[1],
-			Role:     args[2],
+			TenantId:       args[0],
+			Email:          args[1],
+			Role:           args[2],
+			IdempotencyKey: idempotencyKey,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to provision user: %w", err)
@@ -133,10 +220,50 @@ var updateUserCmd = &cobra.Command{
 	},
 }
 
+var removeUserCmd = &cobra.Command{
+	Use:   "remove [tenant-id] [user-id]",
+	Short: "Remove a user from a tenant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.RemoveTenantUser(ctx, &v0.RemoveTenantUserRequest{
+			TenantId: args[0],
+			UserId:   args[1],
+			Strict:   strict,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove user: %w", err)
+		}
+
+		if !resp.Existed {
+			fmt.Printf("User is not a member, nothing to remove: %s\n", args[1])
+			return nil
+		}
+		fmt.Printf("User removed: %s\n", args[1])
+		return nil
+	},
+}
+
 func init() {
 	tenantCmd.AddCommand(usersCmd)
 	usersCmd.AddCommand(listUsersCmd)
+	usersCmd.AddCommand(getUserCmd)
 	usersCmd.AddCommand(inviteUserCmd)
 	usersCmd.AddCommand(provisionUserCmd)
 	usersCmd.AddCommand(updateUserCmd)
+	usersCmd.AddCommand(removeUserCmd)
+
+	addListFlags(listUsersCmd)
+	listUsersCmd.Flags().Bool("skip-identity-lookup", false, "List user IDs and roles only, without their emails")
+	inviteUserCmd.Flags().String("idempotency-key", "", "Key making retries of this invitation safe")
+	provisionUserCmd.Flags().String("idempotency-key", "", "Key making retries of this provisioning safe")
+	removeUserCmd.Flags().Bool("strict", false, "Fail when the user is not a member")
 }
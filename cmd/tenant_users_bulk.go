@@ -0,0 +1,234 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	"github.com/canonical/tenant-service/internal/types"
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+type bulkUser struct {
+	email string
+	role  string
+}
+
+type bulkFailure struct {
+	user bulkUser
+	err  error
+}
+
+var provisionBulkCmd = &cobra.Command{
+	Use:   "provision-bulk [tenant-id] [file]",
+	Short: "Provision the users listed in a CSV file to a tenant",
+	Long: `Provision the users listed in a CSV file to a tenant, one email and an
+optional role per line, lines starting with # are skipped.
+
+Users are provisioned with at most --concurrency requests in flight and
+--rate requests per second. Provisioned users are appended to the checkpoint
+file, running the command again skips them and retries the failed ones.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenantID, path := args[0], args[1]
+		defaultRole, _ := cmd.Flags().GetString("role")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		perSecond, _ := cmd.Flags().GetFloat64("rate")
+		checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+		if checkpointPath == "" {
+			checkpointPath = path + ".done"
+		}
+
+		if concurrency < 1 || perSecond <= 0 {
+			return fmt.Errorf("--concurrency and --rate must be positive")
+		}
+
+		users, err := readBulkUsers(path, defaultRole)
+		if err != nil {
+			return err
+		}
+
+		done, err := readBulkCheckpoint(checkpointPath)
+		if err != nil {
+			return err
+		}
+
+		pending := make([]bulkUser, 0, len(users))
+		for _, u := range users {
+			if !done[u.email] {
+				pending = append(pending, u)
+			}
+		}
+		if len(pending) == 0 {
+			fmt.Printf("All %d users are already provisioned\n", len(users))
+			return nil
+		}
+
+		checkpoint, err := os.OpenFile(checkpointPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open checkpoint file: %w", err)
+		}
+		defer checkpoint.Close()
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(cmd.Context())
+		limiter := rate.NewLimiter(rate.Limit(perSecond), 1)
+
+		var (
+			mu       sync.Mutex
+			failures []bulkFailure
+			wg       sync.WaitGroup
+		)
+		jobs := make(chan bulkUser)
+
+		for range min(concurrency, len(pending)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for u := range jobs {
+					err := provisionBulkUser(ctx, client, limiter, tenantID, u)
+
+					mu.Lock()
+					if err == nil {
+						_, err = fmt.Fprintf(checkpoint, "%s,%s\n", u.email, u.role)
+					}
+					if err != nil {
+						failures = append(failures, bulkFailure{user: u, err: err})
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+
+		for _, u := range pending {
+			jobs <- u
+		}
+		close(jobs)
+		wg.Wait()
+
+		fmt.Printf("Provisioned %d of %d users, %d already done\n", len(pending)-len(failures), len(pending), len(users)-len(pending))
+		if len(failures) == 0 {
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "EMAIL\tROLE\tERROR")
+		for _, f := range failures {
+			fmt.Fprintf(w, "%s\t%s\t%v\n", f.user.email, f.user.role, f.err)
+		}
+		w.Flush()
+
+		return fmt.Errorf("failed to provision %d users, run the command again to retry them", len(failures))
+	},
+}
+
+func provisionBulkUser(ctx context.Context, client v0.TenantServiceClient, limiter *rate.Limiter, tenantID string, u bulkUser) error {
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	// a deterministic key makes reruns safe when a response was lost
+	key := fmt.Sprintf("provision-bulk-%x", sha256.Sum256([]byte(tenantID+"\n"+u.email+"\n"+u.role)))
+	_, err := client.ProvisionUser(ctx, &v0.ProvisionUserRequest{
+		TenantId:       tenantID,
+		Email:          u.email,
+		Role:           u.role,
+		IdempotencyKey: key,
+	})
+	return err
+}
+
+// readBulkUsers reads the email,role lines of path, the role defaults to defaultRole.
+func readBulkUsers(path, defaultRole string) ([]bulkUser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open users file: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	users := make([]bulkUser, 0)
+	seen := make(map[string]bool)
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read users file: %w", err)
+		}
+
+		u := bulkUser{email: strings.TrimSpace(record[0]), role: defaultRole}
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			u.role = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 || u.email == "" {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("invalid users file line %d, expected email[,role]", line)
+		}
+		if _, err := types.ParseMembershipRole(u.role); err != nil {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("invalid users file line %d: %w", line, err)
+		}
+		if seen[u.email] {
+			continue
+		}
+		seen[u.email] = true
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+// readBulkCheckpoint returns the emails recorded in the checkpoint file, if any.
+func readBulkCheckpoint(path string) (map[string]bool, error) {
+	done := make(map[string]bool)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		email, _, _ := strings.Cut(line, ",")
+		if email != "" {
+			done[email] = true
+		}
+	}
+
+	return done, nil
+}
+
+func init() {
+	usersCmd.AddCommand(provisionBulkCmd)
+
+	provisionBulkCmd.Flags().String("role", "member", "Role of the users listed without one")
+	provisionBulkCmd.Flags().Int("concurrency", 4, "Maximum provisioning requests in flight")
+	provisionBulkCmd.Flags().Float64("rate", 5, "Maximum provisioning requests per second")
+	provisionBulkCmd.Flags().String("checkpoint", "", "File recording the provisioned users, defaults to the users file with a .done suffix")
+}
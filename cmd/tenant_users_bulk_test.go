@@ -0,0 +1,82 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadBulkUsers(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expected  []bulkUser
+		wantError bool
+	}{
+		{
+			name:    "Emails with and without role",
+			content: "# users\nalice@example.com\nbob@example.com, owner\n\nalice@example.com,admin\n",
+			expected: []bulkUser{
+				{email: "alice@example.com", role: "member"},
+				{email: "bob@example.com", role: "owner"},
+			},
+		},
+		{
+			name:      "Too many fields",
+			content:   "alice@example.com,member,extra\n",
+			wantError: true,
+		},
+		{
+			name:      "Unknown role",
+			content:   "alice@example.com,superadmin\n",
+			wantError: true,
+		},
+		{
+			name:      "Missing email",
+			content:   ",owner\n",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "users.csv")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write users file: %v", err)
+			}
+
+			users, err := readBulkUsers(path, "member")
+			if (err != nil) != tt.wantError {
+				t.Fatalf("readBulkUsers() error = %v, wantError %v", err, tt.wantError)
+			}
+			if !tt.wantError && !reflect.DeepEqual(users, tt.expected) {
+				t.Errorf("readBulkUsers() = %v, want %v", users, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadBulkCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv.done")
+
+	done, err := readBulkCheckpoint(path)
+	if err != nil || len(done) != 0 {
+		t.Fatalf("expected an empty checkpoint for a missing file, got %v, %v", done, err)
+	}
+
+	if err := os.WriteFile(path, []byte("alice@example.com,member\nbob@example.com,owner\n"), 0o600); err != nil {
+		t.Fatalf("failed to write checkpoint file: %v", err)
+	}
+
+	done, err = readBulkCheckpoint(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(done, map[string]bool{"alice@example.com": true, "bob@example.com": true}) {
+		t.Errorf("unexpected checkpoint %v", done)
+	}
+}
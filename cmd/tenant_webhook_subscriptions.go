@@ -0,0 +1,187 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var webhookSubscriptionsCmd = &cobra.Command{
+	Use:   "webhook-subscriptions",
+	Short: "Manage the webhook subscriptions of a tenant",
+}
+
+var listWebhookSubscriptionsCmd = &cobra.Command{
+	Use:   "list [tenant-id]",
+	Short: "List webhook subscriptions of a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListWebhookSubscriptions(ctx, &v0.ListWebhookSubscriptionsRequest{
+			TenantId: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tURL\tEVENT TYPES")
+		for _, s := range resp.Subscriptions {
+			eventTypes := "*"
+			if len(s.EventTypes) > 0 {
+				eventTypes = strings.Join(s.EventTypes, ",")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Id, s.Url, eventTypes)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var createWebhookSubscriptionCmd = &cobra.Command{
+	Use:   "create [tenant-id] [url] [secret]",
+	Short: "Subscribe a URL to the events of a tenant",
+	Long:  "Subscribe a URL to the events of a tenant, every event type unless --event-types is set. The deliveries are signed with the secret.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventTypes, _ := cmd.Flags().GetStringSlice("event-types")
+
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.CreateWebhookSubscription(ctx, &v0.CreateWebhookSubscriptionRequest{
+			TenantId:   args[0],
+			Url:        args[1],
+			EventTypes: eventTypes,
+			Secret:     args[2],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook subscription: %w", err)
+		}
+
+		fmt.Printf("Webhook subscription created: %s (ID: %s)\n", resp.Url, resp.Id)
+		return nil
+	},
+}
+
+var deleteWebhookSubscriptionCmd = &cobra.Command{
+	Use:   "delete [tenant-id] [subscription-id]",
+	Short: "Delete a webhook subscription and its delivery history",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		_, err = client.DeleteWebhookSubscription(ctx, &v0.DeleteWebhookSubscriptionRequest{
+			TenantId:       args[0],
+			SubscriptionId: args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", err)
+		}
+
+		fmt.Printf("Webhook subscription deleted: %s\n", args[1])
+		return nil
+	},
+}
+
+var listWebhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries [tenant-id] [subscription-id]",
+	Short: "List the latest deliveries of a webhook subscription",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ListWebhookDeliveries(ctx, &v0.ListWebhookDeliveriesRequest{
+			TenantId:       args[0],
+			SubscriptionId: args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list webhook deliveries: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tEVENT TYPE\tSTATUS\tATTEMPTS\tRESPONSE\tLAST ERROR")
+		for _, d := range resp.Deliveries {
+			lastError := "-"
+			if d.LastError != "" {
+				lastError = d.LastError
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", d.Id, d.EventType, d.Status, d.Attempts, d.ResponseStatus, lastError)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var replayWebhookDeliveryCmd = &cobra.Command{
+	Use:   "replay [tenant-id] [subscription-id] [delivery-id]",
+	Short: "Send a failed webhook delivery again",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.ReplayWebhookDelivery(ctx, &v0.ReplayWebhookDeliveryRequest{
+			TenantId:       args[0],
+			SubscriptionId: args[1],
+			DeliveryId:     args[2],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replay webhook delivery: %w", err)
+		}
+
+		fmt.Printf("Webhook delivery replayed: %s (status: %s)\n", resp.Id, resp.Status)
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(webhookSubscriptionsCmd)
+	webhookSubscriptionsCmd.AddCommand(listWebhookSubscriptionsCmd)
+	webhookSubscriptionsCmd.AddCommand(createWebhookSubscriptionCmd)
+	webhookSubscriptionsCmd.AddCommand(deleteWebhookSubscriptionCmd)
+	webhookSubscriptionsCmd.AddCommand(listWebhookDeliveriesCmd)
+	webhookSubscriptionsCmd.AddCommand(replayWebhookDeliveryCmd)
+
+	createWebhookSubscriptionCmd.Flags().StringSlice("event-types", nil, "Event types to deliver, every type when unset")
+}
@@ -5,12 +5,26 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/webhooks"
 )
 
 var (
@@ -56,8 +70,324 @@ var tokenCmd = &cobra.Command{
 	},
 }
 
+var tokenDecodeCmd = &cobra.Command{
+	Use:   "decode <jwt>",
+	Short: "Print the header, the claims and the tenants of a token, without verifying it",
+	Long: `Print the header, the claims and the tenants of a token, without verifying it.
+
+The token is read from the standard input when given as -.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		claimName, _ := cmd.Flags().GetString("claim")
+
+		rawToken, err := readToken(cmd.InOrStdin(), args[0])
+		if err != nil {
+			return err
+		}
+
+		header, claims, err := authentication.DecodeToken(rawToken)
+		if err != nil {
+			return fmt.Errorf("failed to decode token: %w", err)
+		}
+		tenants, err := tokenTenants(claims, claimName)
+		if err != nil {
+			return err
+		}
+
+		decoded := decodedToken{Header: header, Claims: claims, Tenants: tenants}
+		if ok, err := printValue(cmd.OutOrStdout(), decoded); ok {
+			return err
+		}
+
+		for _, part := range []struct {
+			name  string
+			value map[string]any
+		}{{"Header", header}, {"Claims", claims}} {
+			data, err := json.MarshalIndent(part.value, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s:\n%s\n\n", part.name, data)
+		}
+		for _, claim := range []string{"iat", "nbf", "exp"} {
+			if at, ok := claimTime(claims, claim); ok {
+				fmt.Printf("%s: %s (%s)\n", claim, at.Format(time.RFC3339), relativeTime(at, time.Now()))
+			}
+		}
+
+		printTokenTenants(claimName, tenants)
+		return nil
+	},
+}
+
+var tokenVerifyCmd = &cobra.Command{
+	Use:   "verify <jwt>",
+	Short: "Verify a token as the server does and print its principal and tenants",
+	Long: `Verify a token with the verifier of the server, configured by the flags as
+the server is by its AUTHENTICATION_* variables, and print the principal it
+authenticates and its tenants. The reason a token is rejected is printed.
+
+The token is read from the standard input when given as -.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		claimName, _ := cmd.Flags().GetString("claim")
+		clockSkew, _ := cmd.Flags().GetDuration("clock-skew")
+		maxAge, _ := cmd.Flags().GetDuration("max-age")
+
+		rawToken, err := readToken(cmd.InOrStdin(), args[0])
+		if err != nil {
+			return err
+		}
+
+		issuers, err := verifyIssuers(cmd)
+		if err != nil {
+			return err
+		}
+
+		logger := logging.NewNoopLogger()
+		tracer := tracing.NewNoopTracer()
+		monitor := monitoring.NewNoopMonitor("", logger)
+
+		verifier, err := authentication.NewJWTAuthenticator(
+			cmd.Context(),
+			issuers,
+			authentication.TokenLifetime{Leeway: clockSkew, MaxAge: maxAge},
+			nil,
+			nil,
+			tracer,
+			monitor,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to setup JWT authenticator: %w", err)
+		}
+
+		principal, err := verifier.VerifyToken(cmd.Context(), rawToken)
+		if err != nil {
+			return fmt.Errorf("token rejected: %w", err)
+		}
+
+		// the token is verified, its claims can be trusted
+		_, claims, err := authentication.DecodeToken(rawToken)
+		if err != nil {
+			return fmt.Errorf("failed to decode token: %w", err)
+		}
+		tenants, err := tokenTenants(claims, claimName)
+		if err != nil {
+			return err
+		}
+
+		verified := verifiedToken{
+			Subject:      principal.ID,
+			Type:         string(principal.Type),
+			Email:        principal.Email,
+			Scopes:       principal.Scopes,
+			Method:       string(principal.Method),
+			PlatformRole: string(principal.PlatformRole),
+			Tenants:      tenants,
+		}
+		if !principal.AuthTime.IsZero() {
+			verified.AuthTime = principal.AuthTime.Format(time.RFC3339)
+		}
+		if ok, err := printValue(cmd.OutOrStdout(), verified); ok {
+			return err
+		}
+
+		fmt.Printf("Token valid for %s (%s, authenticated by %s)\n", verified.Subject, verified.Type, verified.Method)
+		if verified.Email != "" {
+			fmt.Printf("Email: %s\n", verified.Email)
+		}
+		if len(verified.Scopes) > 0 {
+			fmt.Printf("Scopes: %s\n", strings.Join(verified.Scopes, " "))
+		}
+		if verified.PlatformRole != "" {
+			fmt.Printf("Platform role: %s\n", verified.PlatformRole)
+		}
+		if verified.AuthTime != "" {
+			fmt.Printf("Authenticated at: %s\n", verified.AuthTime)
+		}
+
+		printTokenTenants(claimName, tenants)
+		return nil
+	},
+}
+
+// tokenTenant is a tenant of a token, with the role of the user when the
+// token holds it.
+type tokenTenant struct {
+	ID   string `json:"id"`
+	Role string `json:"role,omitempty"`
+}
+
+// decodedToken is the --output representation of token decode.
+type decodedToken struct {
+	Header  map[string]any `json:"header"`
+	Claims  map[string]any `json:"claims"`
+	Tenants []tokenTenant  `json:"tenants"`
+}
+
+// verifiedToken is the --output representation of token verify.
+type verifiedToken struct {
+	Subject      string        `json:"subject"`
+	Type         string        `json:"type"`
+	Email        string        `json:"email,omitempty"`
+	Scopes       []string      `json:"scopes,omitempty"`
+	Method       string        `json:"method"`
+	PlatformRole string        `json:"platform_role,omitempty"`
+	AuthTime     string        `json:"auth_time,omitempty"`
+	Tenants      []tokenTenant `json:"tenants"`
+}
+
+// readToken returns the token of arg, read from in when arg is -, without
+// the Bearer prefix of an Authorization header.
+func readToken(in io.Reader, arg string) (string, error) {
+	if arg == "-" {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		arg = string(data)
+	}
+
+	rawToken := strings.TrimSpace(arg)
+	if prefix, token, ok := strings.Cut(rawToken, " "); ok && strings.EqualFold(prefix, "Bearer") {
+		rawToken = strings.TrimSpace(token)
+	}
+	if rawToken == "" {
+		return "", errors.New("the token is empty")
+	}
+	return rawToken, nil
+}
+
+// tokenTenants returns the tenants the token hook put in claims: the tenant
+// of a single-tenant token, or the tenants of the claim called name, a list
+// of tenant IDs or a map of the tenant IDs to the role of the user.
+func tokenTenants(claims map[string]any, name string) ([]tokenTenant, error) {
+	if id, ok := claims[webhooks.TenantIDClaim].(string); ok && id != "" {
+		role, _ := claims[webhooks.TenantRoleClaim].(string)
+		return []tokenTenant{{ID: id, Role: role}}, nil
+	}
+
+	var tenants []tokenTenant
+	switch claim := claims[name].(type) {
+	case nil:
+	case []any:
+		for _, v := range claim {
+			id, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid claim %q, expected a list of tenant IDs", name)
+			}
+			tenants = append(tenants, tokenTenant{ID: id})
+		}
+	case map[string]any:
+		for id, v := range claim {
+			role, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid claim %q, expected a map of tenant IDs to roles", name)
+			}
+			tenants = append(tenants, tokenTenant{ID: id, Role: role})
+		}
+		slices.SortFunc(tenants, func(a, b tokenTenant) int { return strings.Compare(a.ID, b.ID) })
+	default:
+		return nil, fmt.Errorf("invalid claim %q, expected a list of tenant IDs or a map of tenant IDs to roles", name)
+	}
+	return tenants, nil
+}
+
+func printTokenTenants(claimName string, tenants []tokenTenant) {
+	if len(tenants) == 0 {
+		fmt.Printf("\nNo tenants: the token has neither a %s nor a %s claim\n", webhooks.TenantIDClaim, claimName)
+		return
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "TENANT_ID\tROLE")
+	for _, t := range tenants {
+		fmt.Fprintf(w, "%s\t%s\n", t.ID, t.Role)
+	}
+	w.Flush()
+}
+
+// claimTime returns the time of a NumericDate claim.
+func claimTime(claims map[string]any, name string) (time.Time, bool) {
+	seconds, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), true
+}
+
+// relativeTime describes at relative to now, to the second.
+func relativeTime(at, now time.Time) string {
+	d := at.Sub(now).Round(time.Second)
+	if d < 0 {
+		return fmt.Sprintf("%s ago", -d)
+	}
+	return fmt.Sprintf("in %s", d)
+}
+
+// verifyIssuers returns the issuers of the flags of token verify, the one of
+// --issuer and the ones of --issuers-file.
+func verifyIssuers(cmd *cobra.Command) ([]authentication.IssuerConfig, error) {
+	issuer, _ := cmd.Flags().GetString("issuer")
+	issuersFile, _ := cmd.Flags().GetString("issuers-file")
+	jwksURL, _ := cmd.Flags().GetString("jwks-url")
+	audiences, _ := cmd.Flags().GetStringSlice("audience")
+	bypassClients, _ := cmd.Flags().GetStringSlice("audience-bypass-client")
+	allowedSubjects, _ := cmd.Flags().GetStringSlice("allowed-subject")
+	requiredScope, _ := cmd.Flags().GetString("required-scope")
+	clients, _ := cmd.Flags().GetStringToString("service-client")
+
+	if issuer == "" && issuersFile == "" {
+		return nil, errors.New("either --issuer or --issuers-file must be provided")
+	}
+
+	var issuers []authentication.IssuerConfig
+	if issuer != "" {
+		serviceClients := make(map[string]authentication.PlatformRole, len(clients))
+		for clientID, role := range clients {
+			serviceClients[clientID] = authentication.PlatformRole(role)
+		}
+
+		issuers = append(issuers, authentication.IssuerConfig{
+			Issuer:                issuer,
+			JWKSURL:               jwksURL,
+			Audiences:             audiences,
+			AudienceBypassClients: bypassClients,
+			AllowedSubjects:       allowedSubjects,
+			RequiredScope:         requiredScope,
+			ServiceClients:        serviceClients,
+		})
+	}
+	if issuersFile != "" {
+		more, err := authentication.LoadIssuers(issuersFile)
+		if err != nil {
+			return nil, err
+		}
+		issuers = append(issuers, more...)
+	}
+	return issuers, nil
+}
+
 func init() {
 	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenDecodeCmd)
+	tokenCmd.AddCommand(tokenVerifyCmd)
+
+	for _, c := range []*cobra.Command{tokenDecodeCmd, tokenVerifyCmd} {
+		c.Flags().String("claim", webhooks.DefaultClaimName, "Claim holding the tenants, TOKEN_HOOK_CLAIM of the server")
+	}
+	tokenVerifyCmd.Flags().String("issuer", "", "Issuer of the token, AUTHENTICATION_ISSUER of the server")
+	tokenVerifyCmd.Flags().String("issuers-file", "", "JSON file of more issuers, AUTHENTICATION_ISSUERS_FILE of the server")
+	tokenVerifyCmd.Flags().String("jwks-url", "", "JWKS URL of --issuer, skipping the OIDC discovery")
+	tokenVerifyCmd.Flags().StringSlice("audience", nil, "Accepted audiences of --issuer, any when empty")
+	tokenVerifyCmd.Flags().StringSlice("audience-bypass-client", nil, "Clients of --issuer whose tokens are accepted whatever their audience")
+	tokenVerifyCmd.Flags().StringSlice("allowed-subject", nil, "Subjects of --issuer allowed without --required-scope")
+	tokenVerifyCmd.Flags().String("required-scope", "", "Scope the tokens of --issuer must hold")
+	tokenVerifyCmd.Flags().StringToString("service-client", nil, "Platform roles of the service clients of --issuer, client-id=role")
+	tokenVerifyCmd.Flags().Duration("clock-skew", time.Minute, "Leeway of the time claims, AUTHENTICATION_CLOCK_SKEW of the server")
+	tokenVerifyCmd.Flags().Duration("max-age", 0, "Maximum age of the tokens, none when 0, AUTHENTICATION_MAX_TOKEN_AGE of the server")
 
 	tokenCmd.Flags().StringVar(&clientID, "client-id", "", "Client ID")
 	tokenCmd.Flags().StringVar(&clientSecret, "client-secret", "", "Client Secret")
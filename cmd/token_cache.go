@@ -0,0 +1,145 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// cachedLogin is the token of a `login`, stored with what refreshing it
+// takes so that the commands need no discovery of the issuer.
+type cachedLogin struct {
+	IssuerURL    string        `json:"issuer_url"`
+	ClientID     string        `json:"client_id"`
+	ClientSecret string        `json:"client_secret,omitempty"`
+	TokenURL     string        `json:"token_url"`
+	Scopes       []string      `json:"scopes,omitempty"`
+	Token        *oauth2.Token `json:"token"`
+}
+
+func (l *cachedLogin) config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     l.ClientID,
+		ClientSecret: l.ClientSecret,
+		Endpoint:     oauth2.Endpoint{TokenURL: l.TokenURL},
+		Scopes:       l.Scopes,
+	}
+}
+
+// tokenCachePath returns the file of the cached login, in the user
+// configuration directory.
+func tokenCachePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the token cache: %w", err)
+	}
+	return filepath.Join(dir, "tenant-service", "token.json"), nil
+}
+
+// readTokenCache returns the cached login, nil when there is none.
+func readTokenCache() (*cachedLogin, error) {
+	path, err := tokenCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token cache: %w", err)
+	}
+
+	login := new(cachedLogin)
+	if err := json.Unmarshal(data, login); err != nil || login.Token == nil {
+		return nil, fmt.Errorf("invalid token cache %s, run login again", path)
+	}
+	return login, nil
+}
+
+// writeTokenCache stores login readable by the user only, the file is
+// replaced at once so that a concurrent command never reads half of it.
+func writeTokenCache(login *cachedLogin) error {
+	path, err := tokenCachePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(login, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the token cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the token cache directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".token-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	// CreateTemp already restricts the file to the user
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+	return nil
+}
+
+// removeTokenCache deletes the cached login, it reports whether there was
+// one.
+func removeTokenCache() (bool, error) {
+	path, err := tokenCachePath()
+	if err != nil {
+		return false, err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to remove the token cache: %w", err)
+	}
+	return true, nil
+}
+
+// cachedAccessToken returns the access token of the cached login, refreshed
+// first when it expired, "" when there is no cached login.
+func cachedAccessToken(ctx context.Context) (string, error) {
+	login, err := readTokenCache()
+	if err != nil || login == nil {
+		return "", err
+	}
+
+	token, err := login.config().TokenSource(ctx, login.Token).Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh the cached token, run login again: %w", err)
+	}
+
+	if token.AccessToken != login.Token.AccessToken {
+		login.Token = token
+		if err := writeTokenCache(login); err != nil {
+			return "", err
+		}
+	}
+	return token.AccessToken, nil
+}
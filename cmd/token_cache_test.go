@@ -0,0 +1,113 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCachedAccessToken(t *testing.T) {
+	refreshes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh-1" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access-2","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		token         *oauth2.Token
+		expected      string
+		wantRefreshes int
+		wantError     bool
+	}{
+		{
+			name:     "Valid token",
+			token:    &oauth2.Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)},
+			expected: "access-1",
+		},
+		{
+			name:          "Expired token is refreshed",
+			token:         &oauth2.Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Hour)},
+			expected:      "access-2",
+			wantRefreshes: 1,
+		},
+		{
+			name:      "Refresh refused",
+			token:     &oauth2.Token{AccessToken: "access-1", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Hour)},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			refreshes = 0
+
+			if err := writeTokenCache(&cachedLogin{ClientID: "cli", TokenURL: server.URL, Token: tt.token}); err != nil {
+				t.Fatalf("writeTokenCache() error = %v", err)
+			}
+
+			token, err := cachedAccessToken(context.Background())
+			if (err != nil) != tt.wantError {
+				t.Fatalf("cachedAccessToken() error = %v, wantError %v", err, tt.wantError)
+			}
+			if token != tt.expected {
+				t.Errorf("cachedAccessToken() = %q, want %q", token, tt.expected)
+			}
+			if !tt.wantError && refreshes != tt.wantRefreshes {
+				t.Errorf("expected %d refreshes, got %d", tt.wantRefreshes, refreshes)
+			}
+
+			login, err := readTokenCache()
+			if err != nil {
+				t.Fatalf("readTokenCache() error = %v", err)
+			}
+			if !tt.wantError && (login.Token.AccessToken != tt.expected || login.Token.RefreshToken != "refresh-1") {
+				t.Errorf("expected the cache to hold the token in use, got %+v", login.Token)
+			}
+		})
+	}
+}
+
+func TestTokenCachePermissions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if token, err := cachedAccessToken(context.Background()); err != nil || token != "" {
+		t.Fatalf("expected no token without a login, got %q, %v", token, err)
+	}
+
+	if err := writeTokenCache(&cachedLogin{Token: &oauth2.Token{AccessToken: "access-1"}}); err != nil {
+		t.Fatalf("writeTokenCache() error = %v", err)
+	}
+
+	path, _ := tokenCachePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected the cache to exist: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the cache to be readable by the user only, got %v", info.Mode().Perm())
+	}
+
+	if removed, err := removeTokenCache(); err != nil || !removed {
+		t.Errorf("expected the cache to be removed, got %v, %v", removed, err)
+	}
+	if removed, err := removeTokenCache(); err != nil || removed {
+		t.Errorf("expected nothing to remove, got %v, %v", removed, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		stdin    string
+		expected string
+		fails    bool
+	}{
+		{name: "Argument", arg: "a.b.c", expected: "a.b.c"},
+		{name: "Bearer prefix", arg: "Bearer a.b.c", expected: "a.b.c"},
+		{name: "Standard input", arg: "-", stdin: "bearer a.b.c\n", expected: "a.b.c"},
+		{name: "Empty", arg: "-", stdin: "\n", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := readToken(strings.NewReader(tt.stdin), tt.arg)
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("readToken() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readToken() error = %v", err)
+			}
+			if token != tt.expected {
+				t.Errorf("readToken() = %q, want %q", token, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTokenTenants(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		expected []tokenTenant
+		fails    bool
+	}{
+		{
+			name:   "No tenants",
+			claims: map[string]any{"sub": "user-1"},
+		},
+		{
+			name:     "IDs",
+			claims:   map[string]any{"tenants": []any{"tenant-2", "tenant-1"}},
+			expected: []tokenTenant{{ID: "tenant-2"}, {ID: "tenant-1"}},
+		},
+		{
+			name:     "Roles",
+			claims:   map[string]any{"tenants": map[string]any{"tenant-2": "member", "tenant-1": "owner"}},
+			expected: []tokenTenant{{ID: "tenant-1", Role: "owner"}, {ID: "tenant-2", Role: "member"}},
+		},
+		{
+			name:     "Single tenant",
+			claims:   map[string]any{"tenant_id": "tenant-1", "tenant_role": "admin", "tenants": []any{"tenant-2"}},
+			expected: []tokenTenant{{ID: "tenant-1", Role: "admin"}},
+		},
+		{
+			name:   "Invalid",
+			claims: map[string]any{"tenants": "tenant-1"},
+			fails:  true,
+		},
+		{
+			name:   "Invalid role",
+			claims: map[string]any{"tenants": map[string]any{"tenant-1": 1.0}},
+			fails:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenants, err := tokenTenants(tt.claims, "tenants")
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("tokenTenants() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("tokenTenants() error = %v", err)
+			}
+			if !reflect.DeepEqual(tenants, tt.expected) {
+				t.Errorf("tokenTenants() = %v, want %v", tenants, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := relativeTime(now.Add(90*time.Second), now); got != "in 1m30s" {
+		t.Errorf("relativeTime() = %q, want %q", got, "in 1m30s")
+	}
+	if got := relativeTime(now.Add(-time.Hour), now); got != "1h0m0s ago" {
+		t.Errorf("relativeTime() = %q, want %q", got, "1h0m0s ago")
+	}
+}
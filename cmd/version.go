@@ -5,6 +5,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"text/tabwriter"
 
 	"github.com/canonical/tenant-service/internal/version"
 	"github.com/spf13/cobra"
@@ -14,9 +16,22 @@ import (
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Get the application's version",
-	Long:  `Get the application's version`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("App Version: %s\n", version.Version)
+	Long: `Get the application's version, git commit, build date and Go version,
+the same build metadata as /api/v0/version and the build_info metric.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := version.Get()
+
+		if ok, err := printValue(cmd.OutOrStdout(), info); ok {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "App Version:\t%s\n", info.Version)
+		fmt.Fprintf(w, "Git Commit:\t%s\n", info.Commit)
+		fmt.Fprintf(w, "Build Date:\t%s\n", info.BuildDate)
+		fmt.Fprintf(w, "Go Version:\t%s\n", info.GoVersion)
+		w.Flush()
+		return nil
 	},
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	v0 "github.com/canonical/tenant-service/v0"
+	"github.com/spf13/cobra"
+)
+
+var whoAmICmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the authenticated caller and their tenant memberships",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, client, err := getClient()
+		if err != nil {
+			return err
+		}
+		defer conn()
+
+		ctx := getAuthenticatedContext(context.Background())
+		resp, err := client.WhoAmI(ctx, &v0.WhoAmIRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get the authenticated caller: %w", err)
+		}
+
+		if ok, err := printMessage(cmd.OutOrStdout(), resp); ok {
+			return err
+		}
+
+		fmt.Printf("Subject: %s (%s, authenticated by %s)\n", resp.Subject, resp.PrincipalType, resp.AuthMethod)
+		if resp.Email != "" {
+			fmt.Printf("Email: %s\n", resp.Email)
+		}
+		if len(resp.Scopes) > 0 {
+			fmt.Printf("Scopes: %s\n", strings.Join(resp.Scopes, " "))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "TENANT_ID\tNAME\tROLE\tPERMISSIONS")
+		for _, m := range resp.Memberships {
+			var granted []string
+			for relation, ok := range m.Permissions {
+				if ok {
+					granted = append(granted, relation)
+				}
+			}
+			slices.Sort(granted)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Tenant.GetId(), m.Tenant.GetName(), m.Role, strings.Join(granted, ","))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whoAmICmd)
+}
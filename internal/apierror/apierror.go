@@ -0,0 +1,79 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package apierror maps the errors of the services to the statuses returned
+// by the API, so that the clients get a meaningful code and the causes of
+// internal errors, such as queries or addresses, stay in the logs.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/storage"
+)
+
+// Error returns the status of the failed operation msg. An err carrying a
+// status keeps it, validation errors included, and the errors of storage and
+// of the context get the code they stand for; msg is then followed by the
+// cause. Any other error is Internal with msg alone, the caller logs it.
+func Error(msg string, err error) error {
+	if st, ok := wrappedStatus(err); ok {
+		p := st.Proto()
+		if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+			p.Code, p.Message = int32(codes.Internal), msg
+			return status.ErrorProto(p)
+		}
+		p.Message = fmt.Sprintf("%s: %s", msg, p.Message)
+		return status.ErrorProto(p)
+	}
+
+	if code := Code(err); code != codes.Internal {
+		return status.Errorf(code, "%s: %v", msg, err)
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+// WithCode is Error for the errors the caller mapped to code already, the
+// errors left Internal are mapped by Error.
+func WithCode(code codes.Code, msg string, err error) error {
+	if code == codes.Internal {
+		return Error(msg, err)
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
+// Code returns the code err stands for, Internal when it is not known.
+func Code(err error) codes.Code {
+	if st, ok := wrappedStatus(err); ok {
+		return st.Code()
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, storage.ErrDuplicateKey):
+		return codes.AlreadyExists
+	case errors.Is(err, storage.ErrForeignKeyViolation):
+		return codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
+
+// wrappedStatus returns the status err wraps, status.FromError would merge
+// the messages of the wrapping errors in it.
+func wrappedStatus(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) || se.GRPCStatus() == nil {
+		return nil, false
+	}
+	return se.GRPCStatus(), true
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/storage"
+)
+
+func TestError(t *testing.T) {
+	invalid, _ := status.New(codes.InvalidArgument, "invalid tenant_id").WithDetails(&errdetails.BadRequest{})
+
+	tests := []struct {
+		name            string
+		err             error
+		expectedCode    codes.Code
+		expectedMessage string
+		expectedDetails int
+	}{
+		{
+			name:            "Not found",
+			err:             fmt.Errorf("tenant: %w", storage.ErrNotFound),
+			expectedCode:    codes.NotFound,
+			expectedMessage: "failed to get tenant: tenant: " + storage.ErrNotFound.Error(),
+		},
+		{
+			name:            "Duplicate key",
+			err:             storage.ErrDuplicateKey,
+			expectedCode:    codes.AlreadyExists,
+			expectedMessage: "failed to get tenant: " + storage.ErrDuplicateKey.Error(),
+		},
+		{
+			name:            "Foreign key violation",
+			err:             storage.ErrForeignKeyViolation,
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "failed to get tenant: " + storage.ErrForeignKeyViolation.Error(),
+		},
+		{
+			name:            "Deadline exceeded",
+			err:             context.DeadlineExceeded,
+			expectedCode:    codes.DeadlineExceeded,
+			expectedMessage: "failed to get tenant: context deadline exceeded",
+		},
+		{
+			name:            "Validation status",
+			err:             fmt.Errorf("validating: %w", invalid.Err()),
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "failed to get tenant: invalid tenant_id",
+			expectedDetails: 1,
+		},
+		{
+			name:            "Internal status",
+			err:             status.Error(codes.Internal, "dial tcp 10.0.0.1:5432: connection refused"),
+			expectedCode:    codes.Internal,
+			expectedMessage: "failed to get tenant",
+		},
+		{
+			name:            "Unknown error",
+			err:             errors.New("SELECT * FROM tenants: connection refused"),
+			expectedCode:    codes.Internal,
+			expectedMessage: "failed to get tenant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(Error("failed to get tenant", tt.err))
+
+			if st.Code() != tt.expectedCode || st.Message() != tt.expectedMessage {
+				t.Errorf("Error() = %s %q, want %s %q", st.Code(), st.Message(), tt.expectedCode, tt.expectedMessage)
+			}
+			if len(st.Details()) != tt.expectedDetails {
+				t.Errorf("expected %d details, got %d", tt.expectedDetails, len(st.Details()))
+			}
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	st := status.Convert(WithCode(codes.NotFound, "failed to get role", errors.New("role not found")))
+	if st.Code() != codes.NotFound || st.Message() != "failed to get role: role not found" {
+		t.Errorf("WithCode() = %s %q", st.Code(), st.Message())
+	}
+
+	st = status.Convert(WithCode(codes.Internal, "failed to get role", errors.New("connection refused")))
+	if st.Code() != codes.Internal || st.Message() != "failed to get role" {
+		t.Errorf("WithCode() = %s %q", st.Code(), st.Message())
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/tenant-service/internal/openfga"
+)
+
+const (
+	AuditOperationWrite  = "write"
+	AuditOperationDelete = "delete"
+)
+
+// auditedClient records every tuple change applied through the wrapped client.
+// Changes are recorded once OpenFGA accepted them, a failure to record is
+// returned so that the caller's transaction is rolled back.
+type auditedClient struct {
+	AuthzClientInterface
+
+	auditor AuditorInterface
+}
+
+func (c *auditedClient) WriteTuple(ctx context.Context, user, relation, object string) error {
+	if err := c.AuthzClientInterface.WriteTuple(ctx, user, relation, object); err != nil {
+		return err
+	}
+	return c.record(ctx, AuditOperationWrite, *openfga.NewTuple(user, relation, object))
+}
+
+func (c *auditedClient) WriteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	if err := c.AuthzClientInterface.WriteTuples(ctx, tuples...); err != nil {
+		return err
+	}
+	return c.record(ctx, AuditOperationWrite, tuples...)
+}
+
+func (c *auditedClient) DeleteTuple(ctx context.Context, user, relation, object string) error {
+	if err := c.AuthzClientInterface.DeleteTuple(ctx, user, relation, object); err != nil {
+		return err
+	}
+	return c.record(ctx, AuditOperationDelete, *openfga.NewTuple(user, relation, object))
+}
+
+func (c *auditedClient) DeleteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	if err := c.AuthzClientInterface.DeleteTuples(ctx, tuples...); err != nil {
+		return err
+	}
+	return c.record(ctx, AuditOperationDelete, tuples...)
+}
+
+func (c *auditedClient) record(ctx context.Context, operation string, tuples ...openfga.Tuple) error {
+	if len(tuples) == 0 {
+		return nil
+	}
+	if err := c.auditor.RecordTupleChanges(ctx, operation, tuples...); err != nil {
+		return fmt.Errorf("failed to audit tuple %s: %w", operation, err)
+	}
+	return nil
+}
+
+// SetAuditor records every tuple the Authorizer writes or deletes from now on.
+func (a *Authorizer) SetAuditor(auditor AuditorInterface) {
+	a.client = &auditedClient{AuthzClientInterface: a.client, auditor: auditor}
+}
@@ -11,11 +11,121 @@ import (
 	openfga "github.com/openfga/go-sdk"
 	"github.com/openfga/language/pkg/go/transformer"
 	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/canonical/tenant-service/internal/types"
 )
 
 //go:embed authorization_model.v0.openfga
 var v0Schema string
 
+//go:embed authorization_model.v1.openfga
+var v1Schema string
+
+// LatestModelVersion is the model version written by create-fga-model and
+// expected by ValidateModel.
+const LatestModelVersion = "v1"
+
+// RelationRename is a relation of an object type renamed by a model version,
+// the tuples holding the old relation are rewritten by migrate-model.
+type RelationRename struct {
+	ObjectType string `json:"object_type"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+// RoleRemap is a membership role mapped to another tenant relation by a model
+// version, the tuples of its memberships are rewritten by migrate-model from
+// the memberships stored in the database.
+type RoleRemap struct {
+	Role types.MembershipRole `json:"role"`
+	From string               `json:"from"`
+	To   string               `json:"to"`
+}
+
+type modelVersion struct {
+	version string
+	schema  string
+	// renames are the relations renamed since the previous version
+	renames []RelationRename
+	// remaps are the membership roles mapped to another relation since the
+	// previous version
+	remaps []RoleRemap
+}
+
+// modelVersions lists the supported model versions, oldest first. A new
+// version adds its DSL file and the relations it renames or the roles it
+// remaps, if any.
+var modelVersions = []modelVersion{
+	{version: "v0", schema: v0Schema},
+	// the admins held member until v1 gave them their own relation
+	{version: "v1", schema: v1Schema, remaps: []RoleRemap{{Role: types.RoleAdmin, From: MEMBER_RELATION, To: ADMIN_RELATION}}},
+}
+
+// ModelVersions returns the supported model versions, oldest first.
+func ModelVersions() []string {
+	versions := make([]string, len(modelVersions))
+	for i, v := range modelVersions {
+		versions[i] = v.version
+	}
+	return versions
+}
+
+func modelVersionIndex(version string) int {
+	for i, v := range modelVersions {
+		if v.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// migrationVersions returns the versions a store moving from one model
+// version to a later one goes through, from excluded.
+func migrationVersions(from, to string) ([]modelVersion, error) {
+	f, t := modelVersionIndex(from), modelVersionIndex(to)
+	if f < 0 {
+		return nil, fmt.Errorf("unknown model version %s", from)
+	}
+	if t < 0 {
+		return nil, fmt.Errorf("unknown model version %s", to)
+	}
+	if f > t {
+		return nil, fmt.Errorf("cannot downgrade model from %s to %s", from, to)
+	}
+
+	return modelVersions[f+1 : t+1], nil
+}
+
+// MigrationRenames returns the relation renames to apply, in order, to the
+// tuples of a store moving from one model version to a later one.
+func MigrationRenames(from, to string) ([]RelationRename, error) {
+	versions, err := migrationVersions(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var renames []RelationRename
+	for _, v := range versions {
+		renames = append(renames, v.renames...)
+	}
+	return renames, nil
+}
+
+// MigrationRemaps returns the role remaps to apply, in order, to the tuples
+// of a store moving from one model version to a later one, after its renames.
+func MigrationRemaps(from, to string) ([]RoleRemap, error) {
+	versions, err := migrationVersions(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var remaps []RoleRemap
+	for _, v := range versions {
+		remaps = append(remaps, v.remaps...)
+	}
+	return remaps, nil
+}
+
 type AuthorizationModelProvider struct {
 	apiVersion string
 	model      *openfga.AuthorizationModel
@@ -42,13 +152,17 @@ func readAuthzModelFromDSLString(dslString string) *openfga.AuthorizationModel {
 }
 
 func (a *AuthorizationModelProvider) prepareModel() *openfga.AuthorizationModel {
-	var model string
-	switch a.apiVersion {
-	default:
-		model = v0Schema
+	return readAuthzModelFromDSLString(a.GetDSL())
+}
+
+// GetDSL returns the model in the OpenFGA DSL, as embedded in the binary.
+func (a *AuthorizationModelProvider) GetDSL() string {
+	i := modelVersionIndex(a.apiVersion)
+	if i < 0 {
+		i = modelVersionIndex(LatestModelVersion)
 	}
 
-	return readAuthzModelFromDSLString(model)
+	return modelVersions[i].schema
 }
 
 func (a *AuthorizationModelProvider) GetModel() *openfga.AuthorizationModel {
@@ -6,18 +6,23 @@ package authorization
 import (
 	"context"
 	"fmt"
-	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/openfga"
 	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
 )
 
 var ErrInvalidAuthModel = fmt.Errorf("invalid authorization model schema")
 
 type Authorizer struct {
 	client AuthzClientInterface
+	cache  *DecisionCache
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
@@ -28,13 +33,111 @@ func (a *Authorizer) Check(ctx context.Context, user string, relation string, ob
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.Check")
 	defer span.End()
 
-	return a.client.Check(ctx, user, relation, object, contextualTuples...)
+	span.SetAttributes(tupleAttributes(user, relation, object)...)
+	span.SetAttributes(attribute.Int("authz.contextual_tuples", len(contextualTuples)))
+
+	// contextual tuples change the outcome, such checks are never cached
+	cacheable := a.cache != nil && len(contextualTuples) == 0
+	if cacheable {
+		if allowed, ok := a.cache.get(user, relation, object); ok {
+			a.countCacheLookup("authz_cache_hit", relation)
+			a.recordDecision(span, relation, object, allowed, true)
+			return allowed, nil
+		}
+		a.countCacheLookup("authz_cache_miss", relation)
+	}
+
+	allowed, err := a.client.Check(ctx, user, relation, object, contextualTuples...)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	if cacheable {
+		a.cache.set(user, relation, object, allowed)
+	}
+	a.recordDecision(span, relation, object, allowed, false)
+	return allowed, nil
+}
+
+// tupleAttributes describes a tuple on a span.
+func tupleAttributes(user, relation, object string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("authz.user", user),
+		attribute.String("authz.relation", relation),
+		attribute.String("authz.object", object),
+	}
+}
+
+// recordDecision sets the outcome of a check on span and counts it.
+func (a *Authorizer) recordDecision(span trace.Span, relation, object string, allowed, cacheHit bool) {
+	span.SetAttributes(
+		attribute.String("authz.decision", a.countDecision(relation, object, allowed)),
+		attribute.Bool("authz.cache_hit", cacheHit),
+	)
+}
+
+// countDecision counts a decision by relation, decision and tenant, the
+// tenant is empty for other objects. It returns the decision label.
+func (a *Authorizer) countDecision(relation, object string, allowed bool) string {
+	decision := "deny"
+	if allowed {
+		decision = "allow"
+	}
+
+	tenantID, _ := TenantIDFromTuple(object)
+	if err := a.monitor.IncrementAuthorizationDecision(map[string]string{"relation": relation, "decision": decision, "tenant": tenantID}); err != nil {
+		a.logger.Warnf("failed to increment authorization decisions: %v", err)
+	}
+	return decision
+}
+
+// SetCache caches the results of Check, writes made through the Authorizer
+// drop the decisions they may change.
+func (a *Authorizer) SetCache(cache *DecisionCache) {
+	a.cache = cache
+}
+
+// forget drops the cached decisions a tuple between user and object may
+// change: a user on a tenant only affects that pair, a userset on a tenant
+// affects the whole tenant and a user on anything else all of the user's.
+func (a *Authorizer) forget(user, object string) {
+	if a.cache == nil {
+		return
+	}
+
+	_, isUser := UserIDFromTuple(user)
+	_, isTenant := TenantIDFromTuple(object)
+	switch {
+	case isUser && isTenant:
+		a.cache.invalidate(user, object)
+	case isTenant:
+		a.cache.invalidate("", object)
+	case isUser:
+		a.cache.invalidate(user, "")
+	default:
+		a.cache.Purge()
+	}
+}
+
+func (a *Authorizer) forgetTuples(tuples ...openfga.Tuple) {
+	for _, t := range tuples {
+		a.forget(t.User, t.Object)
+	}
+}
+
+func (a *Authorizer) countCacheLookup(operation, relation string) {
+	if err := a.monitor.IncrementCounter(map[string]string{"operation": operation, "role": relation}); err != nil {
+		a.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
 }
 
 func (a *Authorizer) ListObjects(ctx context.Context, user string, relation string, objectType string) ([]string, error) {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.ListObjects")
 	defer span.End()
 
+	span.SetAttributes(tupleAttributes(user, relation, objectType)...)
+
 	return a.client.ListObjects(ctx, user, relation, objectType)
 }
 
@@ -47,21 +150,67 @@ func (a *Authorizer) FilterObjects(ctx context.Context, user string, relation st
 		return nil, err
 	}
 
-	var ret []string
+	allowed := make(map[string]bool, len(allowedObjs))
 	for _, obj := range allowedObjs {
-		if slices.Contains(objs, obj) {
+		allowed[obj] = true
+	}
+	return keepObjects(objs, allowed), nil
+}
+
+// FilterObjectsStreamed returns the objects of objs the user holds relation
+// on, in the order of objs. The allowed objects are streamed and intersected
+// as they arrive, without holding them all, and the stream is stopped as soon
+// as every object of objs is found.
+func (a *Authorizer) FilterObjectsStreamed(ctx context.Context, user string, relation string, objectType string, objs []string) ([]string, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.FilterObjectsStreamed")
+	defer span.End()
+
+	span.SetAttributes(tupleAttributes(user, relation, objectType)...)
+
+	if len(objs) == 0 {
+		return nil, nil
+	}
+
+	requested := make(map[string]bool, len(objs))
+	for _, obj := range objs {
+		requested[obj] = true
+	}
+
+	allowed := make(map[string]bool)
+	streamed := 0
+	err := a.client.StreamObjects(ctx, user, relation, objectType, func(obj string) bool {
+		streamed++
+		if requested[obj] {
+			allowed[obj] = true
+		}
+		return len(allowed) < len(requested)
+	})
+	span.SetAttributes(attribute.Int("authz.streamed_objects", streamed))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return keepObjects(objs, allowed), nil
+}
+
+// keepObjects returns the objects of objs in allowed, in order.
+func keepObjects(objs []string, allowed map[string]bool) []string {
+	var ret []string
+	for _, obj := range objs {
+		if allowed[obj] {
 			ret = append(ret, obj)
 		}
 	}
-	return ret, nil
+	return ret
 }
 
 func (a *Authorizer) ValidateModel(ctx context.Context) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.ValidateModel")
 	defer span.End()
 
-	v0AuthzModel := NewAuthorizationModelProvider("v0")
-	model := *v0AuthzModel.GetModel()
+	authzModel := NewAuthorizationModelProvider(LatestModelVersion)
+	model := *authzModel.GetModel()
 
 	eq, err := a.client.CompareModel(ctx, model)
 	if err != nil {
@@ -76,56 +225,279 @@ func (a *Authorizer) ValidateModel(ctx context.Context) error {
 func (a *Authorizer) AssignTenantOwner(ctx context.Context, tenantId, userId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AssignTenantOwner")
 	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
 
+	span.SetAttributes(tupleAttributes(UserTuple(userId), OWNER_RELATION, TenantTuple(tenantId))...)
 	return a.client.WriteTuple(ctx, UserTuple(userId), OWNER_RELATION, TenantTuple(tenantId))
 }
 
+func (a *Authorizer) AssignTenantAdmin(ctx context.Context, tenantId, userId string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AssignTenantAdmin")
+	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
+
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ADMIN_RELATION, TenantTuple(tenantId))...)
+	return a.client.WriteTuple(ctx, UserTuple(userId), ADMIN_RELATION, TenantTuple(tenantId))
+}
+
 func (a *Authorizer) AssignPrivilegedAdmin(ctx context.Context, privilegedId, userId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AssignPrivilegedAdmin")
 	defer span.End()
+	defer a.forget(UserTuple(userId), PrivilegedTuple(privilegedId))
 
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ADMIN_RELATION, PrivilegedTuple(privilegedId))...)
 	return a.client.WriteTuple(ctx, UserTuple(userId), ADMIN_RELATION, PrivilegedTuple(privilegedId))
 }
 
+func (a *Authorizer) RemovePrivilegedAdmin(ctx context.Context, privilegedId, userId string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RemovePrivilegedAdmin")
+	defer span.End()
+	defer a.forget(UserTuple(userId), PrivilegedTuple(privilegedId))
+
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ADMIN_RELATION, PrivilegedTuple(privilegedId))...)
+	return a.client.DeleteTuple(ctx, UserTuple(userId), ADMIN_RELATION, PrivilegedTuple(privilegedId))
+}
+
+// ListPrivilegedAdmins returns the IDs of the users holding admin on the privileged group.
+func (a *Authorizer) ListPrivilegedAdmins(ctx context.Context, privilegedId string) ([]string, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.ListPrivilegedAdmins")
+	defer span.End()
+
+	ts, err := a.readTuples(ctx, PrivilegedTuple(privilegedId))
+	if err != nil {
+		return nil, err
+	}
+
+	admins := make([]string, 0, len(ts))
+	for _, t := range ts {
+		if t.Relation != ADMIN_RELATION {
+			continue
+		}
+		if userID, ok := UserIDFromTuple(t.User); ok {
+			admins = append(admins, userID)
+		}
+	}
+	return admins, nil
+}
+
 func (a *Authorizer) LinkTenantToPrivileged(ctx context.Context, tenantId, privilegedId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.LinkTenantToPrivileged")
 	defer span.End()
+	defer a.forget(PrivilegedTuple(privilegedId), TenantTuple(tenantId))
 
+	span.SetAttributes(tupleAttributes(PrivilegedTuple(privilegedId), PRIVILEGED_RELATION, TenantTuple(tenantId))...)
 	return a.client.WriteTuple(ctx, PrivilegedTuple(privilegedId), PRIVILEGED_RELATION, TenantTuple(tenantId))
 }
 
 func (a *Authorizer) AssignTenantMember(ctx context.Context, tenantId, userId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AssignTenantMember")
 	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
 
+	span.SetAttributes(tupleAttributes(UserTuple(userId), MEMBER_RELATION, TenantTuple(tenantId))...)
 	return a.client.WriteTuple(ctx, UserTuple(userId), MEMBER_RELATION, TenantTuple(tenantId))
 }
 
 func (a *Authorizer) RemoveTenantOwner(ctx context.Context, tenantId, userId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RemoveTenantOwner")
 	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
 
+	span.SetAttributes(tupleAttributes(UserTuple(userId), OWNER_RELATION, TenantTuple(tenantId))...)
 	return a.client.DeleteTuple(ctx, UserTuple(userId), OWNER_RELATION, TenantTuple(tenantId))
 }
 
+func (a *Authorizer) RemoveTenantAdmin(ctx context.Context, tenantId, userId string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RemoveTenantAdmin")
+	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
+
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ADMIN_RELATION, TenantTuple(tenantId))...)
+	return a.client.DeleteTuple(ctx, UserTuple(userId), ADMIN_RELATION, TenantTuple(tenantId))
+}
+
 func (a *Authorizer) RemoveTenantMember(ctx context.Context, tenantId, userId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RemoveTenantMember")
 	defer span.End()
+	defer a.forget(UserTuple(userId), TenantTuple(tenantId))
 
+	span.SetAttributes(tupleAttributes(UserTuple(userId), MEMBER_RELATION, TenantTuple(tenantId))...)
 	return a.client.DeleteTuple(ctx, UserTuple(userId), MEMBER_RELATION, TenantTuple(tenantId))
 }
 
-func (a *Authorizer) CheckTenantAccess(ctx context.Context, tenantId, userId, relation string) (bool, error) {
+// GrantRolePermission lets every assignee of the role hold the permission on the tenant.
+func (a *Authorizer) GrantRolePermission(ctx context.Context, tenantId, roleId, permission string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.GrantRolePermission")
+	defer span.End()
+	defer a.forget(RoleAssigneesTuple(roleId), TenantTuple(tenantId))
+
+	relation, ok := PermissionGrants[permission]
+	if !ok {
+		return fmt.Errorf("unknown permission %s", permission)
+	}
+
+	span.SetAttributes(tupleAttributes(RoleAssigneesTuple(roleId), relation, TenantTuple(tenantId))...)
+	return a.client.WriteTuple(ctx, RoleAssigneesTuple(roleId), relation, TenantTuple(tenantId))
+}
+
+func (a *Authorizer) RevokeRolePermission(ctx context.Context, tenantId, roleId, permission string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RevokeRolePermission")
+	defer span.End()
+	defer a.forget(RoleAssigneesTuple(roleId), TenantTuple(tenantId))
+
+	relation, ok := PermissionGrants[permission]
+	if !ok {
+		return fmt.Errorf("unknown permission %s", permission)
+	}
+
+	span.SetAttributes(tupleAttributes(RoleAssigneesTuple(roleId), relation, TenantTuple(tenantId))...)
+	return a.client.DeleteTuple(ctx, RoleAssigneesTuple(roleId), relation, TenantTuple(tenantId))
+}
+
+// GrantRolePermissions grants several permissions to a custom role with batched writes.
+func (a *Authorizer) GrantRolePermissions(ctx context.Context, tenantId, roleId string, permissions []string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.GrantRolePermissions")
+	defer span.End()
+	defer a.forget(RoleAssigneesTuple(roleId), TenantTuple(tenantId))
+
+	ts, err := rolePermissionTuples(tenantId, roleId, permissions)
+	if err != nil {
+		return err
+	}
+	return a.client.WriteTuples(ctx, ts...)
+}
+
+func (a *Authorizer) RevokeRolePermissions(ctx context.Context, tenantId, roleId string, permissions []string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RevokeRolePermissions")
+	defer span.End()
+	defer a.forget(RoleAssigneesTuple(roleId), TenantTuple(tenantId))
+
+	ts, err := rolePermissionTuples(tenantId, roleId, permissions)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteTuples(ctx, ts...)
+}
+
+func rolePermissionTuples(tenantId, roleId string, permissions []string) ([]openfga.Tuple, error) {
+	ts := make([]openfga.Tuple, 0, len(permissions))
+	for _, p := range permissions {
+		relation, ok := PermissionGrants[p]
+		if !ok {
+			return nil, fmt.Errorf("unknown permission %s", p)
+		}
+		ts = append(ts, *openfga.NewTuple(RoleAssigneesTuple(roleId), relation, TenantTuple(tenantId)))
+	}
+	return ts, nil
+}
+
+func (a *Authorizer) AssignRole(ctx context.Context, roleId, userId string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.AssignRole")
+	defer span.End()
+	defer a.forget(UserTuple(userId), RoleTuple(roleId))
+
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ASSIGNEE_RELATION, RoleTuple(roleId))...)
+	return a.client.WriteTuple(ctx, UserTuple(userId), ASSIGNEE_RELATION, RoleTuple(roleId))
+}
+
+func (a *Authorizer) UnassignRole(ctx context.Context, roleId, userId string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.UnassignRole")
+	defer span.End()
+	defer a.forget(UserTuple(userId), RoleTuple(roleId))
+
+	span.SetAttributes(tupleAttributes(UserTuple(userId), ASSIGNEE_RELATION, RoleTuple(roleId))...)
+	return a.client.DeleteTuple(ctx, UserTuple(userId), ASSIGNEE_RELATION, RoleTuple(roleId))
+}
+
+// UnassignRoleUsers removes several assignees from a custom role with batched deletes.
+func (a *Authorizer) UnassignRoleUsers(ctx context.Context, roleId string, userIds []string) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.UnassignRoleUsers")
+	defer span.End()
+
+	ts := make([]openfga.Tuple, len(userIds))
+	for i, userId := range userIds {
+		ts[i] = *openfga.NewTuple(UserTuple(userId), ASSIGNEE_RELATION, RoleTuple(roleId))
+	}
+	defer a.forgetTuples(ts...)
+
+	return a.client.DeleteTuples(ctx, ts...)
+}
+
+// WriteTuples writes arbitrary tuples, chunked to the OpenFGA request limit.
+func (a *Authorizer) WriteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.WriteTuples")
+	defer span.End()
+	defer a.forgetTuples(tuples...)
+
+	span.SetAttributes(attribute.Int("authz.tuples", len(tuples)))
+
+	return a.client.WriteTuples(ctx, tuples...)
+}
+
+// DeleteTuples deletes arbitrary tuples, chunked to the OpenFGA request limit.
+func (a *Authorizer) DeleteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.DeleteTuples")
+	defer span.End()
+	defer a.forgetTuples(tuples...)
+
+	span.SetAttributes(attribute.Int("authz.tuples", len(tuples)))
+
+	return a.client.DeleteTuples(ctx, tuples...)
+}
+
+// CheckTenantAccess checks relation between a user and a tenant, contextual tuples
+// are considered on top of the stored ones for this check only.
+func (a *Authorizer) CheckTenantAccess(ctx context.Context, tenantId, userId, relation string, tuples ...openfga.Tuple) (bool, error) {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.CheckTenantAccess")
 	defer span.End()
 
-	return a.Check(ctx, UserTuple(userId), relation, TenantTuple(tenantId))
+	span.SetAttributes(attribute.String("authz.tenant_id", tenantId))
+
+	return a.Check(ctx, UserTuple(userId), relation, TenantTuple(tenantId), tuples...)
+}
+
+// TenantPermissions evaluates every relation of TenantPermissionRelations
+// between the user and the tenant with a single batch check.
+func (a *Authorizer) TenantPermissions(ctx context.Context, tenantId, userId string) (map[string]bool, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.TenantPermissions")
+	defer span.End()
+
+	checks := make([]openfga.TupleWithContext, len(TenantPermissionRelations))
+	for i, relation := range TenantPermissionRelations {
+		checks[i] = *openfga.NewTupleWithContext(UserTuple(userId), relation, TenantTuple(tenantId), nil)
+	}
+
+	span.SetAttributes(
+		attribute.String("authz.user", UserTuple(userId)),
+		attribute.String("authz.object", TenantTuple(tenantId)),
+	)
+
+	allowed, err := a.client.BatchCheckEach(ctx, checks...)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	permissions := make(map[string]bool, len(checks))
+	granted := make([]string, 0, len(checks))
+	for i, relation := range TenantPermissionRelations {
+		permissions[relation] = allowed[i]
+		a.countDecision(relation, TenantTuple(tenantId), allowed[i])
+		if allowed[i] {
+			granted = append(granted, relation)
+		}
+	}
+	span.SetAttributes(attribute.StringSlice("authz.granted", granted))
+	return permissions, nil
 }
 
 func (a *Authorizer) DeleteTenant(ctx context.Context, tenantId string) error {
 	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.DeleteTenant")
 	defer span.End()
 
+	span.SetAttributes(attribute.String("authz.object", TenantTuple(tenantId)))
+	defer a.forget("", TenantTuple(tenantId))
+
 	cToken := ""
 	for {
 		r, err := a.client.ReadTuples(ctx, "", "", TenantTuple(tenantId), cToken)
@@ -152,6 +524,176 @@ func (a *Authorizer) DeleteTenant(ctx context.Context, tenantId string) error {
 	return nil
 }
 
+func (a *Authorizer) ListTenantTuples(ctx context.Context, tenantId string) ([]openfga.Tuple, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.ListTenantTuples")
+	defer span.End()
+
+	return a.readTuples(ctx, TenantTuple(tenantId))
+}
+
+// ListAllTenantTuples returns every tuple whose object is a tenant, including
+// tenants that no longer exist in the database.
+func (a *Authorizer) ListAllTenantTuples(ctx context.Context) ([]openfga.Tuple, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.ListAllTenantTuples")
+	defer span.End()
+
+	// an empty filter reads the whole store
+	ts, err := a.readTuples(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []openfga.Tuple
+	for _, t := range ts {
+		if _, ok := TenantIDFromTuple(t.Object); ok {
+			ret = append(ret, t)
+		}
+	}
+	return ret, nil
+}
+
+// RenameRelations rewrites the tuples holding a renamed relation, renames are
+// applied in order so that consecutive versions compose. The tuples to write
+// are written before the old ones are deleted, a rerun after a failure skips
+// those already written. With dryRun set nothing is changed, the tuples that
+// would be written are returned either way.
+func (a *Authorizer) RenameRelations(ctx context.Context, renames []RelationRename, dryRun bool) ([]openfga.Tuple, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RenameRelations")
+	defer span.End()
+
+	if len(renames) == 0 {
+		return nil, nil
+	}
+
+	ts, err := a.readTuples(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[openfga.Tuple]bool, len(ts))
+	for _, t := range ts {
+		held[t] = true
+	}
+
+	var writes, deletes []openfga.Tuple
+	for _, t := range ts {
+		renamed := t
+		for _, r := range renames {
+			if renamed.Relation == r.From && strings.HasPrefix(renamed.Object, r.ObjectType+":") {
+				renamed.Relation = r.To
+			}
+		}
+		if renamed == t {
+			continue
+		}
+
+		deletes = append(deletes, t)
+		if !held[renamed] {
+			held[renamed] = true
+			writes = append(writes, renamed)
+		}
+	}
+
+	if dryRun {
+		return writes, nil
+	}
+	// renamed relations can change any decision
+	if a.cache != nil {
+		defer a.cache.Purge()
+	}
+
+	if err := a.client.WriteTuples(ctx, writes...); err != nil {
+		return nil, fmt.Errorf("failed to write renamed tuples: %w", err)
+	}
+	if err := a.client.DeleteTuples(ctx, deletes...); err != nil {
+		return nil, fmt.Errorf("failed to delete old tuples: %w", err)
+	}
+
+	a.logger.Infow("authorization relations renamed", "written", len(writes), "deleted", len(deletes))
+	return writes, nil
+}
+
+// RemapRoles rewrites the tuples of the members whose role is remapped from
+// the old relation to the new one, and returns the tuples written, or that
+// would be with dryRun. Rewritten tuples are skipped, so that an interrupted
+// run can be started again.
+func (a *Authorizer) RemapRoles(ctx context.Context, remaps []RoleRemap, members []*types.Membership, dryRun bool) ([]openfga.Tuple, error) {
+	ctx, span := a.tracer.Start(ctx, "authorization.Authorizer.RemapRoles")
+	defer span.End()
+
+	if len(remaps) == 0 || len(members) == 0 {
+		return nil, nil
+	}
+
+	ts, err := a.readTuples(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[openfga.Tuple]bool, len(ts))
+	for _, t := range ts {
+		held[t] = true
+	}
+
+	var writes, deletes []openfga.Tuple
+	for _, m := range members {
+		for _, r := range remaps {
+			if m.Role != r.Role {
+				continue
+			}
+
+			user, tenant := UserTuple(m.KratosIdentityID), TenantTuple(m.TenantID)
+			remapped := *openfga.NewTuple(user, r.To, tenant)
+			if !held[remapped] {
+				held[remapped] = true
+				writes = append(writes, remapped)
+			}
+			if old := *openfga.NewTuple(user, r.From, tenant); held[old] {
+				held[old] = false
+				deletes = append(deletes, old)
+			}
+		}
+	}
+
+	if dryRun {
+		return writes, nil
+	}
+	// remapped roles can change any decision
+	if a.cache != nil {
+		defer a.cache.Purge()
+	}
+
+	if err := a.client.WriteTuples(ctx, writes...); err != nil {
+		return nil, fmt.Errorf("failed to write remapped tuples: %w", err)
+	}
+	if err := a.client.DeleteTuples(ctx, deletes...); err != nil {
+		return nil, fmt.Errorf("failed to delete old tuples: %w", err)
+	}
+
+	a.logger.Infow("authorization roles remapped", "written", len(writes), "deleted", len(deletes))
+	return writes, nil
+}
+
+func (a *Authorizer) readTuples(ctx context.Context, object string) ([]openfga.Tuple, error) {
+	var ts []openfga.Tuple
+	cToken := ""
+	for {
+		r, err := a.client.ReadTuples(ctx, "", "", object, cToken)
+		if err != nil {
+			a.logger.Errorf("error when retrieving tuples: %s", err)
+			return nil, err
+		}
+		for _, t := range r.Tuples {
+			ts = append(ts, *openfga.NewTuple(t.Key.User, t.Key.Relation, t.Key.Object))
+		}
+		if r.ContinuationToken == "" {
+			break
+		}
+		cToken = r.ContinuationToken
+	}
+	return ts, nil
+}
+
 func NewAuthorizer(client AuthzClientInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Authorizer {
 	authorizer := new(Authorizer)
 	authorizer.client = client
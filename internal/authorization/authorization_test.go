@@ -6,7 +6,11 @@ package authorization
 import (
 	"context"
 	"errors"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	fga "github.com/openfga/go-sdk"
 	"github.com/openfga/go-sdk/client"
@@ -14,6 +18,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/types"
 )
 
 //go:generate mockgen -build_flags=--mod=mod -package authorization -destination ./mock_interfaces.go -source=./interfaces.go
@@ -74,6 +79,9 @@ func TestAuthorizer_Check(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.Check").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockClient)
+			if !tc.expectedErr {
+				mockMonitor.EXPECT().IncrementAuthorizationDecision(map[string]string{"relation": relation, "decision": decisionLabel(tc.expectedResult), "tenant": "456"}).Return(nil)
+			}
 
 			result, err := a.Check(context.Background(), user, relation, object, contextualTuples...)
 
@@ -227,6 +235,113 @@ func TestAuthorizer_FilterObjects(t *testing.T) {
 	}
 }
 
+func TestAuthorizer_FilterObjectsStreamed(t *testing.T) {
+	user := "user:123"
+	relation := "can_view"
+	objectType := "tenant"
+
+	stream := func(ids ...string) func(context.Context, string, string, string, func(string) bool) error {
+		return func(_ context.Context, _, _, _ string, fn func(string) bool) error {
+			for _, id := range ids {
+				if !fn(id) {
+					return nil
+				}
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		requested      []string
+		setupMocks     func(*MockAuthzClientInterface)
+		expectedResult []string
+		expectedErr    bool
+	}{
+		{
+			name:      "keeps the order of the requested objects",
+			requested: []string{"4", "3", "2", "1"},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().StreamObjects(gomock.Any(), user, relation, objectType, gomock.Any()).DoAndReturn(stream("1", "3", "5"))
+			},
+			expectedResult: []string{"3", "1"},
+		},
+		{
+			name:      "stops once every requested object is found",
+			requested: []string{"1", "2"},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().StreamObjects(gomock.Any(), user, relation, objectType, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _, _ string, fn func(string) bool) error {
+						for _, id := range []string{"2", "1", "3"} {
+							if !fn(id) {
+								return nil
+							}
+							if id == "3" {
+								t.Error("expected the stream to stop before the last object")
+							}
+						}
+						return nil
+					},
+				)
+			},
+			expectedResult: []string{"1", "2"},
+		},
+		{
+			name:      "no overlap",
+			requested: []string{"1"},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().StreamObjects(gomock.Any(), user, relation, objectType, gomock.Any()).DoAndReturn(stream("9"))
+			},
+		},
+		{
+			name:       "no requested objects",
+			requested:  nil,
+			setupMocks: func(mockClient *MockAuthzClientInterface) {},
+		},
+		{
+			name:      "stream error",
+			requested: []string{"1"},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().StreamObjects(gomock.Any(), user, relation, objectType, gomock.Any()).Return(errors.New("client error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.FilterObjectsStreamed").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			result, err := a.FilterObjectsStreamed(context.Background(), user, relation, objectType, tc.requested)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(result, tc.expectedResult) {
+				t.Errorf("expected %v, got %v", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
 func TestAuthorizer_ValidateModel(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -339,6 +454,58 @@ func TestAuthorizer_AssignTenantOwner(t *testing.T) {
 	}
 }
 
+func TestAuthorizer_AssignTenantAdmin(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-456"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), UserTuple(userID), ADMIN_RELATION, TenantTuple(tenantID)).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name: "error - write tuple error",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), UserTuple(userID), ADMIN_RELATION, TenantTuple(tenantID)).Return(errors.New("write error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.AssignTenantAdmin").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			err := a.AssignTenantAdmin(context.Background(), tenantID, userID)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestAuthorizer_AssignPrivilegedAdmin(t *testing.T) {
 	privilegedID := "privileged-123"
 	userID := "user-456"
@@ -443,6 +610,98 @@ func TestAuthorizer_LinkTenantToPrivileged(t *testing.T) {
 	}
 }
 
+func TestAuthorizer_RemovePrivilegedAdmin(t *testing.T) {
+	privilegedID := "privileged-123"
+	userID := "user-456"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockAuthzClientInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.RemovePrivilegedAdmin").
+		Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockClient.EXPECT().DeleteTuple(gomock.Any(), UserTuple(userID), ADMIN_RELATION, PrivilegedTuple(privilegedID)).Return(nil)
+
+	if err := a.RemovePrivilegedAdmin(context.Background(), privilegedID, userID); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthorizer_ListPrivilegedAdmins(t *testing.T) {
+	privilegedID := "privileged-123"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockAuthzClientInterface, *MockLoggerInterface)
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockClient *MockAuthzClientInterface, mockLogger *MockLoggerInterface) {
+				gomock.InOrder(
+					mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", PrivilegedTuple(privilegedID), "").Return(&client.ClientReadResponse{
+						Tuples: []fga.Tuple{
+							{Key: fga.TupleKey{User: "user:1", Relation: ADMIN_RELATION, Object: PrivilegedTuple(privilegedID)}},
+						},
+						ContinuationToken: "token1",
+					}, nil),
+					mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", PrivilegedTuple(privilegedID), "token1").Return(&client.ClientReadResponse{
+						Tuples: []fga.Tuple{
+							{Key: fga.TupleKey{User: "user:2", Relation: ADMIN_RELATION, Object: PrivilegedTuple(privilegedID)}},
+						},
+						ContinuationToken: "",
+					}, nil),
+				)
+			},
+			expected: []string{"1", "2"},
+		},
+		{
+			name: "error - read tuples error",
+			setupMocks: func(mockClient *MockAuthzClientInterface, mockLogger *MockLoggerInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", PrivilegedTuple(privilegedID), "").Return(nil, errors.New("read error"))
+				mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.ListPrivilegedAdmins").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient, mockLogger)
+
+			admins, err := a.ListPrivilegedAdmins(context.Background(), privilegedID)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.expectedErr && !reflect.DeepEqual(admins, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, admins)
+			}
+		})
+	}
+}
+
 func TestAuthorizer_AssignTenantMember(t *testing.T) {
 	tenantID := "tenant-123"
 	userID := "user-456"
@@ -599,6 +858,58 @@ func TestAuthorizer_RemoveTenantMember(t *testing.T) {
 	}
 }
 
+func TestAuthorizer_RemoveTenantAdmin(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-456"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().DeleteTuple(gomock.Any(), UserTuple(userID), ADMIN_RELATION, TenantTuple(tenantID)).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name: "error - delete tuple error",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().DeleteTuple(gomock.Any(), UserTuple(userID), ADMIN_RELATION, TenantTuple(tenantID)).Return(errors.New("delete error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.RemoveTenantAdmin").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			err := a.RemoveTenantAdmin(context.Background(), tenantID, userID)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestAuthorizer_CheckTenantAccess(t *testing.T) {
 	tenantID := "tenant-123"
 	userID := "user-456"
@@ -606,6 +917,7 @@ func TestAuthorizer_CheckTenantAccess(t *testing.T) {
 
 	testCases := []struct {
 		name           string
+		tuples         []openfga.Tuple
 		setupMocks     func(*MockAuthzClientInterface)
 		expectedResult bool
 		expectedErr    bool
@@ -626,6 +938,15 @@ func TestAuthorizer_CheckTenantAccess(t *testing.T) {
 			expectedResult: false,
 			expectedErr:    false,
 		},
+		{
+			name:   "success - allowed by contextual tuple",
+			tuples: []openfga.Tuple{*openfga.NewTuple(UserTuple(userID), relation, TenantTuple(tenantID))},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().Check(gomock.Any(), UserTuple(userID), relation, TenantTuple(tenantID), *openfga.NewTuple(UserTuple(userID), relation, TenantTuple(tenantID))).Return(true, nil)
+			},
+			expectedResult: true,
+			expectedErr:    false,
+		},
 		{
 			name: "error - check error",
 			setupMocks: func(mockClient *MockAuthzClientInterface) {
@@ -653,8 +974,11 @@ func TestAuthorizer_CheckTenantAccess(t *testing.T) {
 			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.Check").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockClient)
+			if !tc.expectedErr {
+				mockMonitor.EXPECT().IncrementAuthorizationDecision(map[string]string{"relation": relation, "decision": decisionLabel(tc.expectedResult), "tenant": tenantID}).Return(nil)
+			}
 
-			result, err := a.CheckTenantAccess(context.Background(), tenantID, userID, relation)
+			result, err := a.CheckTenantAccess(context.Background(), tenantID, userID, relation, tc.tuples...)
 
 			if tc.expectedErr {
 				if err == nil {
@@ -779,3 +1103,687 @@ func TestAuthorizer_DeleteTenant(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizer_ListTenantTuples(t *testing.T) {
+	tenantID := "tenant-123"
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(*MockAuthzClientInterface, *MockLoggerInterface)
+		expectedCount int
+		expectedErr   bool
+	}{
+		{
+			name: "success - multiple batches",
+			setupMocks: func(mockClient *MockAuthzClientInterface, mockLogger *MockLoggerInterface) {
+				gomock.InOrder(
+					mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", TenantTuple(tenantID), "").Return(&client.ClientReadResponse{
+						Tuples: []fga.Tuple{
+							{Key: fga.TupleKey{User: "user:1", Relation: "owner", Object: TenantTuple(tenantID)}},
+						},
+						ContinuationToken: "token1",
+					}, nil),
+					mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", TenantTuple(tenantID), "token1").Return(&client.ClientReadResponse{
+						Tuples: []fga.Tuple{
+							{Key: fga.TupleKey{User: "user:2", Relation: "member", Object: TenantTuple(tenantID)}},
+						},
+						ContinuationToken: "",
+					}, nil),
+				)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "error - read tuples error",
+			setupMocks: func(mockClient *MockAuthzClientInterface, mockLogger *MockLoggerInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", TenantTuple(tenantID), "").Return(nil, errors.New("read error"))
+				mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any())
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.ListTenantTuples").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient, mockLogger)
+
+			tuples, err := a.ListTenantTuples(context.Background(), tenantID)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tuples) != tc.expectedCount {
+				t.Errorf("expected %d tuples, got %d", tc.expectedCount, len(tuples))
+			}
+		})
+	}
+}
+
+func TestAuthorizer_ListAllTenantTuples(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockAuthzClientInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.ListAllTenantTuples").
+		Return(context.Background(), trace.SpanFromContext(context.Background()))
+	gomock.InOrder(
+		mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(&client.ClientReadResponse{
+			Tuples: []fga.Tuple{
+				{Key: fga.TupleKey{User: "user:1", Relation: "owner", Object: TenantTuple("tenant-1")}},
+				{Key: fga.TupleKey{User: "user:1", Relation: "assignee", Object: RoleTuple("role-1")}},
+			},
+			ContinuationToken: "token1",
+		}, nil),
+		mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "token1").Return(&client.ClientReadResponse{
+			Tuples: []fga.Tuple{
+				{Key: fga.TupleKey{User: "user:2", Relation: "member", Object: TenantTuple("tenant-2")}},
+			},
+		}, nil),
+	)
+
+	tuples, err := a.ListAllTenantTuples(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tuples) != 2 {
+		t.Fatalf("expected the 2 tenant tuples, got %v", tuples)
+	}
+}
+
+func TestAuthorizer_GrantRolePermissions(t *testing.T) {
+	tenantID := "tenant-123"
+	roleID := "role-456"
+
+	testCases := []struct {
+		name        string
+		permissions []string
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name:        "single batched write",
+			permissions: []string{CAN_VIEW_PERMISSION, CAN_EDIT_PERMISSION},
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuples(gomock.Any(),
+					*openfga.NewTuple(RoleAssigneesTuple(roleID), VIEWER_RELATION, TenantTuple(tenantID)),
+					*openfga.NewTuple(RoleAssigneesTuple(roleID), EDITOR_RELATION, TenantTuple(tenantID)),
+				).Return(nil)
+			},
+		},
+		{
+			name:        "unknown permission",
+			permissions: []string{CAN_VIEW_PERMISSION, "can_fly"},
+			setupMocks:  func(*MockAuthzClientInterface) {},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.GrantRolePermissions").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			err := a.GrantRolePermissions(context.Background(), tenantID, roleID, tc.permissions)
+			if tc.expectedErr != (err != nil) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_GrantRolePermission(t *testing.T) {
+	tenantID := "tenant-123"
+	roleID := "role-456"
+
+	testCases := []struct {
+		name        string
+		permission  string
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name:       "success",
+			permission: CAN_EDIT_PERMISSION,
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), "role:role-456#assignee", EDITOR_RELATION, TenantTuple(tenantID)).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "error - unknown permission",
+			permission:  "can_fly",
+			setupMocks:  func(mockClient *MockAuthzClientInterface) {},
+			expectedErr: true,
+		},
+		{
+			name:       "error - write tuple error",
+			permission: CAN_VIEW_PERMISSION,
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), RoleAssigneesTuple(roleID), VIEWER_RELATION, TenantTuple(tenantID)).Return(errors.New("write error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.GrantRolePermission").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			err := a.GrantRolePermission(context.Background(), tenantID, roleID, tc.permission)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_AssignRole(t *testing.T) {
+	roleID := "role-123"
+	userID := "user-456"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), UserTuple(userID), ASSIGNEE_RELATION, RoleTuple(roleID)).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name: "error - write tuple error",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().WriteTuple(gomock.Any(), UserTuple(userID), ASSIGNEE_RELATION, RoleTuple(roleID)).Return(errors.New("write error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.AssignRole").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			err := a.AssignRole(context.Background(), roleID, userID)
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMigrationRenames(t *testing.T) {
+	versions := modelVersions
+	defer func() { modelVersions = versions }()
+
+	modelVersions = []modelVersion{
+		{version: "v0", schema: v0Schema},
+		{version: "v1", schema: v0Schema, renames: []RelationRename{{ObjectType: "tenant", From: "member", To: "reader"}}},
+		{version: "v2", schema: v0Schema, renames: []RelationRename{{ObjectType: "tenant", From: "reader", To: "viewer"}}},
+	}
+
+	renames, err := MigrationRenames("v0", "v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(renames) != 2 || renames[0].To != "reader" || renames[1].To != "viewer" {
+		t.Fatalf("expected both renames in order, got %v", renames)
+	}
+
+	renames, err = MigrationRenames("v1", "v1")
+	if err != nil || len(renames) != 0 {
+		t.Fatalf("expected no renames, got %v, %v", renames, err)
+	}
+
+	if _, err := MigrationRenames("v2", "v0"); err == nil {
+		t.Fatal("expected error on downgrade")
+	}
+	if _, err := MigrationRenames("v9", "v2"); err == nil {
+		t.Fatal("expected error on unknown version")
+	}
+}
+
+func TestMigrationRemaps(t *testing.T) {
+	remaps, err := MigrationRemaps("v0", LatestModelVersion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remaps) != 1 || remaps[0] != (RoleRemap{Role: types.RoleAdmin, From: MEMBER_RELATION, To: ADMIN_RELATION}) {
+		t.Fatalf("expected the admins remapped by v1, got %v", remaps)
+	}
+
+	remaps, err = MigrationRemaps(LatestModelVersion, LatestModelVersion)
+	if err != nil || len(remaps) != 0 {
+		t.Fatalf("expected no remaps, got %v, %v", remaps, err)
+	}
+}
+
+func TestLatestModelTenantRelations(t *testing.T) {
+	tenant := relations(typeDefinitions(NewAuthorizationModelProvider(LatestModelVersion).GetModel())["tenant"])
+	for _, relation := range TenantPermissionRelations {
+		if _, ok := tenant[relation]; !ok {
+			t.Errorf("expected the latest model to define tenant#%s", relation)
+		}
+	}
+
+	// released versions are never edited
+	if _, ok := relations(typeDefinitions(readAuthzModelFromDSLString(v0Schema))["tenant"])[CAN_ADMIN_PERMISSION]; ok {
+		t.Errorf("expected %s to be absent from v0", CAN_ADMIN_PERMISSION)
+	}
+}
+
+func TestDiffModels(t *testing.T) {
+	expected := NewAuthorizationModelProvider(LatestModelVersion).GetModel()
+
+	// the deployed model comes back from OpenFGA with an ID and without empty fields
+	deployed := readAuthzModelFromDSLString(v1Schema)
+	deployed.Id = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	if diff := DiffModels(expected, deployed); len(diff) != 0 {
+		t.Fatalf("expected no differences, got %v", diff)
+	}
+
+	schema := strings.Replace(v1Schema, "    define creator: [role#assignee]\n", "", 1)
+	schema = strings.Replace(schema, "define can_create: owner or creator or admin from privileged", "define can_create: owner or admin from privileged", 1)
+	schema = strings.Replace(schema, "define can_view: member or viewer or admin from privileged", "define can_view: member or viewer", 1)
+	schema = strings.Replace(schema, "type user\n", "type user\n\ntype group\n  relations\n    define member: [user]\n", 1)
+
+	diff := DiffModels(expected, readAuthzModelFromDSLString(schema))
+	want := []ModelDifference{
+		{Kind: "type", Name: "group", Change: ModelRemoved},
+		{Kind: "relation", Name: "tenant#can_create", Change: ModelChanged},
+		{Kind: "relation", Name: "tenant#can_view", Change: ModelChanged},
+		{Kind: "relation", Name: "tenant#creator", Change: ModelAdded},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("expected %v, got %v", want, diff)
+	}
+}
+
+func TestAuthorizer_RenameRelations(t *testing.T) {
+	renames := []RelationRename{
+		{ObjectType: "tenant", From: "member", To: "reader"},
+		{ObjectType: "tenant", From: "reader", To: "viewer"},
+	}
+	read := &client.ClientReadResponse{
+		Tuples: []fga.Tuple{
+			{Key: fga.TupleKey{User: "user:1", Relation: "member", Object: TenantTuple("tenant-1")}},
+			// already written by an interrupted run
+			{Key: fga.TupleKey{User: "user:2", Relation: "member", Object: TenantTuple("tenant-1")}},
+			{Key: fga.TupleKey{User: "user:2", Relation: "viewer", Object: TenantTuple("tenant-1")}},
+			// other types keep their relations
+			{Key: fga.TupleKey{User: "user:3", Relation: "member", Object: RoleTuple("role-1")}},
+			{Key: fga.TupleKey{User: "user:4", Relation: "owner", Object: TenantTuple("tenant-1")}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		dryRun      bool
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+			},
+		},
+		{
+			name: "rewrite",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+				gomock.InOrder(
+					mockClient.EXPECT().WriteTuples(gomock.Any(),
+						*openfga.NewTuple("user:1", "viewer", TenantTuple("tenant-1")),
+					).Return(nil),
+					mockClient.EXPECT().DeleteTuples(gomock.Any(),
+						*openfga.NewTuple("user:1", "member", TenantTuple("tenant-1")),
+						*openfga.NewTuple("user:2", "member", TenantTuple("tenant-1")),
+					).Return(nil),
+				)
+			},
+		},
+		{
+			name: "write error keeps the old tuples",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+				mockClient.EXPECT().WriteTuples(gomock.Any(), gomock.Any()).Return(errors.New("write error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.RenameRelations").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			written, err := a.RenameRelations(context.Background(), renames, tc.dryRun)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(written) != 1 || written[0] != *openfga.NewTuple("user:1", "viewer", TenantTuple("tenant-1")) {
+				t.Fatalf("expected the renamed tuple of user 1, got %v", written)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_CheckCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockAuthzClientInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+	a.SetCache(NewDecisionCache(10, time.Minute))
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authz_cache_miss", "role": MEMBER_RELATION}).Return(nil).Times(2)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authz_cache_hit", "role": MEMBER_RELATION}).Return(nil)
+	mockMonitor.EXPECT().IncrementAuthorizationDecision(map[string]string{"relation": MEMBER_RELATION, "decision": "deny", "tenant": "a"}).Return(nil).Times(2)
+	mockMonitor.EXPECT().IncrementAuthorizationDecision(map[string]string{"relation": MEMBER_RELATION, "decision": "allow", "tenant": "a"}).Return(nil).Times(2)
+
+	user, object := UserTuple("1"), TenantTuple("a")
+
+	// the second check is served from the cache
+	mockClient.EXPECT().Check(gomock.Any(), user, MEMBER_RELATION, object).Return(false, nil)
+	for range 2 {
+		if allowed, err := a.Check(context.Background(), user, MEMBER_RELATION, object); err != nil || allowed {
+			t.Fatalf("expected a denial, got %v, %v", allowed, err)
+		}
+	}
+
+	// checks with contextual tuples bypass the cache
+	tuple := *openfga.NewTuple(user, MEMBER_RELATION, object)
+	mockClient.EXPECT().Check(gomock.Any(), user, MEMBER_RELATION, object, tuple).Return(true, nil)
+	if allowed, err := a.Check(context.Background(), user, MEMBER_RELATION, object, tuple); err != nil || !allowed {
+		t.Fatalf("expected the contextual tuple to grant access, got %v, %v", allowed, err)
+	}
+
+	// assigning the user drops the cached denial
+	mockClient.EXPECT().WriteTuple(gomock.Any(), user, MEMBER_RELATION, object).Return(nil)
+	if err := a.AssignTenantMember(context.Background(), "a", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mockClient.EXPECT().Check(gomock.Any(), user, MEMBER_RELATION, object).Return(true, nil)
+	if allowed, err := a.Check(context.Background(), user, MEMBER_RELATION, object); err != nil || !allowed {
+		t.Fatalf("expected access after the assignment, got %v, %v", allowed, err)
+	}
+}
+
+func TestAuthorizer_Audited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockAuthzClientInterface(ctrl)
+	mockAuditor := NewMockAuditorInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+	a.SetAuditor(mockAuditor)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	user, object := UserTuple("1"), TenantTuple("a")
+	tuple := *openfga.NewTuple(user, MEMBER_RELATION, object)
+
+	mockClient.EXPECT().WriteTuple(gomock.Any(), user, MEMBER_RELATION, object).Return(nil)
+	mockAuditor.EXPECT().RecordTupleChanges(gomock.Any(), AuditOperationWrite, tuple).Return(nil)
+	if err := a.AssignTenantMember(context.Background(), "a", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a change rejected by OpenFGA is not recorded
+	mockClient.EXPECT().DeleteTuple(gomock.Any(), user, MEMBER_RELATION, object).Return(errors.New("error"))
+	if err := a.RemoveTenantMember(context.Background(), "a", "1"); err == nil {
+		t.Fatal("expected the OpenFGA error")
+	}
+
+	// a change that cannot be recorded fails
+	tuples := []openfga.Tuple{tuple, *openfga.NewTuple(user, OWNER_RELATION, object)}
+	mockClient.EXPECT().DeleteTuples(gomock.Any(), tuples[0], tuples[1]).Return(nil)
+	mockAuditor.EXPECT().RecordTupleChanges(gomock.Any(), AuditOperationDelete, tuples[0], tuples[1]).Return(errors.New("error"))
+	if err := a.DeleteTuples(context.Background(), tuples...); err == nil {
+		t.Fatal("expected the audit error")
+	}
+}
+
+func TestAuthorizer_TenantPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockAuthzClientInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.TenantPermissions").
+		Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockClient.EXPECT().BatchCheckEach(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, checks ...openfga.TupleWithContext) ([]bool, error) {
+			allowed := make([]bool, len(checks))
+			for i, c := range checks {
+				if c.User != UserTuple("user-1") || c.Object != TenantTuple("tenant-1") {
+					t.Errorf("unexpected check %v", c)
+				}
+				allowed[i] = c.Relation == MEMBER_RELATION || c.Relation == CAN_VIEW_PERMISSION
+			}
+			return allowed, nil
+		},
+	)
+
+	expected := map[string]bool{
+		OWNER_RELATION:        false,
+		ADMIN_RELATION:        false,
+		MEMBER_RELATION:       true,
+		CAN_VIEW_PERMISSION:   true,
+		CAN_EDIT_PERMISSION:   false,
+		CAN_CREATE_PERMISSION: false,
+		CAN_DELETE_PERMISSION: false,
+		CAN_ADMIN_PERMISSION:  false,
+	}
+	for relation, allowed := range expected {
+		mockMonitor.EXPECT().IncrementAuthorizationDecision(map[string]string{"relation": relation, "decision": decisionLabel(allowed), "tenant": "tenant-1"}).Return(nil)
+	}
+
+	permissions, err := a.TenantPermissions(context.Background(), "tenant-1", "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected %v, got %v", expected, permissions)
+	}
+}
+
+func decisionLabel(allowed bool) string {
+	if allowed {
+		return "allow"
+	}
+	return "deny"
+}
+
+func TestAuthorizer_RemapRoles(t *testing.T) {
+	remaps := []RoleRemap{{Role: types.RoleAdmin, From: MEMBER_RELATION, To: ADMIN_RELATION}}
+	members := []*types.Membership{
+		{TenantID: "tenant-1", KratosIdentityID: "1", Role: types.RoleAdmin},
+		{TenantID: "tenant-1", KratosIdentityID: "2", Role: types.RoleAdmin},
+		{TenantID: "tenant-1", KratosIdentityID: "3", Role: types.RoleMember},
+		{TenantID: "tenant-1", KratosIdentityID: "4", Role: types.RoleOwner},
+	}
+	read := &client.ClientReadResponse{
+		Tuples: []fga.Tuple{
+			{Key: fga.TupleKey{User: "user:1", Relation: "member", Object: TenantTuple("tenant-1")}},
+			// already written by an interrupted run
+			{Key: fga.TupleKey{User: "user:2", Relation: "member", Object: TenantTuple("tenant-1")}},
+			{Key: fga.TupleKey{User: "user:2", Relation: "admin", Object: TenantTuple("tenant-1")}},
+			// other roles keep their relations
+			{Key: fga.TupleKey{User: "user:3", Relation: "member", Object: TenantTuple("tenant-1")}},
+			{Key: fga.TupleKey{User: "user:4", Relation: "owner", Object: TenantTuple("tenant-1")}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		dryRun      bool
+		setupMocks  func(*MockAuthzClientInterface)
+		expectedErr bool
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+			},
+		},
+		{
+			name: "rewrite",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+				gomock.InOrder(
+					mockClient.EXPECT().WriteTuples(gomock.Any(),
+						*openfga.NewTuple("user:1", "admin", TenantTuple("tenant-1")),
+					).Return(nil),
+					mockClient.EXPECT().DeleteTuples(gomock.Any(),
+						*openfga.NewTuple("user:1", "member", TenantTuple("tenant-1")),
+						*openfga.NewTuple("user:2", "member", TenantTuple("tenant-1")),
+					).Return(nil),
+				)
+			},
+		},
+		{
+			name: "write error keeps the old tuples",
+			setupMocks: func(mockClient *MockAuthzClientInterface) {
+				mockClient.EXPECT().ReadTuples(gomock.Any(), "", "", "", "").Return(read, nil)
+				mockClient.EXPECT().WriteTuples(gomock.Any(), gomock.Any()).Return(errors.New("write error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockAuthzClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+
+			a := NewAuthorizer(mockClient, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "authorization.Authorizer.RemapRoles").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockClient)
+
+			written, err := a.RemapRoles(context.Background(), remaps, members, tc.dryRun)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(written) != 1 || written[0] != *openfga.NewTuple("user:1", "admin", TenantTuple("tenant-1")) {
+				t.Fatalf("expected the admin tuple of user 1, got %v", written)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type decisionKey struct {
+	user     string
+	relation string
+	object   string
+}
+
+type decision struct {
+	key     decisionKey
+	allowed bool
+	expires time.Time
+}
+
+// DecisionCache is a size bounded LRU of check results. Entries expire after
+// the TTL, which bounds how long a change made through another instance
+// can go unnoticed.
+type DecisionCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[decisionKey]*list.Element
+	order   *list.List
+}
+
+func (c *DecisionCache) get(user, relation, object string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[decisionKey{user, relation, object}]
+	if !ok {
+		return false, false
+	}
+
+	d := e.Value.(*decision)
+	if !c.now().Before(d.expires) {
+		c.remove(e)
+		return false, false
+	}
+
+	c.order.MoveToFront(e)
+	return d.allowed, true
+}
+
+func (c *DecisionCache) set(user, relation, object string, allowed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := decisionKey{user, relation, object}
+	expires := c.now().Add(c.ttl)
+
+	if e, ok := c.entries[key]; ok {
+		d := e.Value.(*decision)
+		d.allowed = allowed
+		d.expires = expires
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&decision{key: key, allowed: allowed, expires: expires})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *DecisionCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*decision).key)
+}
+
+// invalidate drops the decisions matching the given user and object, an
+// empty value matches any.
+func (c *DecisionCache) invalidate(user, object string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for e := c.order.Front(); e != nil; {
+		next := e.Next()
+		d := e.Value.(*decision)
+		if (user == "" || d.key.user == user) && (object == "" || d.key.object == object) {
+			c.remove(e)
+		}
+		e = next
+	}
+}
+
+// Purge drops every decision.
+func (c *DecisionCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[decisionKey]*list.Element)
+	c.order.Init()
+}
+
+// Len returns the number of cached decisions, expired ones included.
+func (c *DecisionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func NewDecisionCache(size int, ttl time.Duration) *DecisionCache {
+	c := new(DecisionCache)
+	c.size = size
+	c.ttl = ttl
+	c.now = time.Now
+	c.entries = make(map[decisionKey]*list.Element)
+	c.order = list.New()
+
+	return c
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecisionCacheEviction(t *testing.T) {
+	c := NewDecisionCache(2, time.Minute)
+
+	c.set("user:1", "member", "tenant:a", true)
+	c.set("user:2", "member", "tenant:a", true)
+	// reading user:1 makes user:2 the least recently used
+	if _, ok := c.get("user:1", "member", "tenant:a"); !ok {
+		t.Fatal("expected a cached decision")
+	}
+	c.set("user:3", "member", "tenant:a", false)
+
+	if _, ok := c.get("user:2", "member", "tenant:a"); ok {
+		t.Error("expected the least recently used decision to be evicted")
+	}
+	if allowed, ok := c.get("user:3", "member", "tenant:a"); !ok || allowed {
+		t.Errorf("expected a cached denial, got %v, %v", allowed, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 decisions, got %d", c.Len())
+	}
+}
+
+func TestDecisionCacheExpiry(t *testing.T) {
+	now := time.Now()
+	c := NewDecisionCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set("user:1", "member", "tenant:a", true)
+
+	now = now.Add(59 * time.Second)
+	if _, ok := c.get("user:1", "member", "tenant:a"); !ok {
+		t.Fatal("expected the decision to be cached until the TTL")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.get("user:1", "member", "tenant:a"); ok {
+		t.Error("expected the decision to expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected the expired decision to be dropped, got %d", c.Len())
+	}
+}
+
+func TestDecisionCacheInvalidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		user   string
+		object string
+		left   int
+	}{
+		{name: "pair", user: "user:1", object: "tenant:a", left: 3},
+		{name: "user", user: "user:1", left: 2},
+		{name: "object", object: "tenant:a", left: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewDecisionCache(10, time.Minute)
+			c.set("user:1", "member", "tenant:a", true)
+			c.set("user:1", "member", "tenant:b", true)
+			c.set("user:2", "member", "tenant:a", true)
+			c.set("user:2", "member", "tenant:b", true)
+
+			c.invalidate(tc.user, tc.object)
+
+			if c.Len() != tc.left {
+				t.Errorf("expected %d decisions left, got %d", tc.left, c.Len())
+			}
+		})
+	}
+}
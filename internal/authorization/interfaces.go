@@ -16,31 +16,68 @@ type AuthorizerInterface interface {
 	ListObjects(context.Context, string, string, string) ([]string, error)
 	Check(context.Context, string, string, string, ...openfga.Tuple) (bool, error)
 	FilterObjects(context.Context, string, string, string, []string) ([]string, error)
+	// FilterObjectsStreamed is FilterObjects over a stream, for users holding
+	// the relation on more objects than a single list returns.
+	FilterObjectsStreamed(context.Context, string, string, string, []string) ([]string, error)
 	ValidateModel(context.Context) error
 
 	AssignTenantOwner(context.Context, string, string) error
+	AssignTenantAdmin(context.Context, string, string) error
 	AssignTenantMember(context.Context, string, string) error
 	RemoveTenantOwner(context.Context, string, string) error
+	RemoveTenantAdmin(context.Context, string, string) error
 	RemoveTenantMember(context.Context, string, string) error
 	// AssignPrivilegedAdmin assigns a user as a privileged admin in the authorization system.
 	// This user will have admin access to all tenants linked to that privileged group.
 	AssignPrivilegedAdmin(context.Context, string, string) error
+	RemovePrivilegedAdmin(context.Context, string, string) error
+	// ListPrivilegedAdmins returns the IDs of the admins of a privileged group.
+	ListPrivilegedAdmins(context.Context, string) ([]string, error)
 	// LinkTenantToPrivileged acts as a binder between a tenant and a privileged group.
 	// This way, privileged admins can access the tenant.
 	LinkTenantToPrivileged(context.Context, string, string) error
 
+	// GrantRolePermission grants a permission on a tenant to the assignees of a custom role.
+	GrantRolePermission(context.Context, string, string, string) error
+	RevokeRolePermission(context.Context, string, string, string) error
+	GrantRolePermissions(context.Context, string, string, []string) error
+	RevokeRolePermissions(context.Context, string, string, []string) error
+	AssignRole(context.Context, string, string) error
+	UnassignRole(context.Context, string, string) error
+	UnassignRoleUsers(context.Context, string, []string) error
+
+	// WriteTuples and DeleteTuples apply many tuples with as few requests as possible.
+	WriteTuples(context.Context, ...openfga.Tuple) error
+	DeleteTuples(context.Context, ...openfga.Tuple) error
+
 	DeleteTenant(context.Context, string) error
-	CheckTenantAccess(context.Context, string, string, string) (bool, error)
+	// ListTenantTuples returns every tuple whose object is the given tenant.
+	ListTenantTuples(context.Context, string) ([]openfga.Tuple, error)
+	// ListAllTenantTuples returns every tuple whose object is a tenant.
+	ListAllTenantTuples(context.Context) ([]openfga.Tuple, error)
+	CheckTenantAccess(context.Context, string, string, string, ...openfga.Tuple) (bool, error)
+	// TenantPermissions evaluates every TenantPermissionRelations for a user in one request.
+	TenantPermissions(context.Context, string, string) (map[string]bool, error)
+	// RenameRelations rewrites the tuples of relations renamed between model versions.
+	RenameRelations(context.Context, []RelationRename, bool) ([]openfga.Tuple, error)
 }
 
 type AuthzClientInterface interface {
 	ListObjects(context.Context, string, string, string) ([]string, error)
+	StreamObjects(ctx context.Context, user, relation, objectType string, fn func(id string) bool) error
 	Check(context.Context, string, string, string, ...openfga.Tuple) (bool, error)
 	BatchCheck(context.Context, ...openfga.TupleWithContext) (bool, error)
+	BatchCheckEach(context.Context, ...openfga.TupleWithContext) ([]bool, error)
 	ReadModel(context.Context) (*fga.AuthorizationModel, error)
 	CompareModel(context.Context, fga.AuthorizationModel) (bool, error)
 	ReadTuples(context.Context, string, string, string, string) (*client.ClientReadResponse, error)
 	WriteTuple(ctx context.Context, user, relation, object string) error
+	WriteTuples(context.Context, ...openfga.Tuple) error
 	DeleteTuple(ctx context.Context, user, relation, object string) error
 	DeleteTuples(context.Context, ...openfga.Tuple) error
 }
+
+// AuditorInterface records the tuples written to or deleted from OpenFGA.
+type AuditorInterface interface {
+	RecordTupleChanges(ctx context.Context, operation string, tuples ...openfga.Tuple) error
+}
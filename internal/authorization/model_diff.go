@@ -0,0 +1,201 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	openfga "github.com/openfga/go-sdk"
+)
+
+const (
+	// ModelAdded is a type, relation or condition only found in the expected model
+	ModelAdded = "added"
+	// ModelRemoved is a type, relation or condition only found in the deployed model
+	ModelRemoved = "removed"
+	// ModelChanged is a relation or condition defined differently by the two models
+	ModelChanged = "changed"
+)
+
+// ModelDifference is a difference between two authorization models. Kind is
+// one of schema_version, type, relation or condition, relations are named
+// type#relation.
+type ModelDifference struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Change string `json:"change"`
+}
+
+// DiffModels returns the changes needed to turn the deployed model into the
+// expected one: the schema version, then the types with their relations and
+// the conditions, in name order. Source metadata is ignored.
+func DiffModels(expected, deployed *openfga.AuthorizationModel) []ModelDifference {
+	diff := make([]ModelDifference, 0)
+
+	if expected.SchemaVersion != deployed.SchemaVersion {
+		diff = append(diff, ModelDifference{Kind: "schema_version", Name: deployed.SchemaVersion + " -> " + expected.SchemaVersion, Change: ModelChanged})
+	}
+
+	expectedTypes, deployedTypes := typeDefinitions(expected), typeDefinitions(deployed)
+	for _, name := range unionKeys(expectedTypes, deployedTypes) {
+		e, inExpected := expectedTypes[name]
+		d, inDeployed := deployedTypes[name]
+
+		switch {
+		case !inDeployed:
+			diff = append(diff, ModelDifference{Kind: "type", Name: name, Change: ModelAdded})
+		case !inExpected:
+			diff = append(diff, ModelDifference{Kind: "type", Name: name, Change: ModelRemoved})
+		default:
+			diff = append(diff, diffRelations(name, e, d)...)
+		}
+	}
+
+	expectedConditions, deployedConditions := conditions(expected), conditions(deployed)
+	for _, name := range unionKeys(expectedConditions, deployedConditions) {
+		e, inExpected := expectedConditions[name]
+		d, inDeployed := deployedConditions[name]
+
+		switch {
+		case !inDeployed:
+			diff = append(diff, ModelDifference{Kind: "condition", Name: name, Change: ModelAdded})
+		case !inExpected:
+			diff = append(diff, ModelDifference{Kind: "condition", Name: name, Change: ModelRemoved})
+		case !sameDefinition(e.Expression, d.Expression) || !sameDefinition(e.Parameters, d.Parameters):
+			diff = append(diff, ModelDifference{Kind: "condition", Name: name, Change: ModelChanged})
+		}
+	}
+
+	return diff
+}
+
+// relationDefinition is what a relation grants: its rewrite and the user
+// types that can be assigned to it directly.
+type relationDefinition struct {
+	Rewrite      openfga.Userset              `json:"rewrite"`
+	DirectlyFrom *[]openfga.RelationReference `json:"directly_related_user_types,omitempty"`
+}
+
+func diffRelations(typeName string, expected, deployed openfga.TypeDefinition) []ModelDifference {
+	diff := make([]ModelDifference, 0)
+
+	expectedRelations, deployedRelations := relations(expected), relations(deployed)
+	for _, name := range unionKeys(expectedRelations, deployedRelations) {
+		e, inExpected := expectedRelations[name]
+		d, inDeployed := deployedRelations[name]
+
+		switch {
+		case !inDeployed:
+			diff = append(diff, ModelDifference{Kind: "relation", Name: typeName + "#" + name, Change: ModelAdded})
+		case !inExpected:
+			diff = append(diff, ModelDifference{Kind: "relation", Name: typeName + "#" + name, Change: ModelRemoved})
+		case !sameDefinition(e, d):
+			diff = append(diff, ModelDifference{Kind: "relation", Name: typeName + "#" + name, Change: ModelChanged})
+		}
+	}
+
+	return diff
+}
+
+func typeDefinitions(model *openfga.AuthorizationModel) map[string]openfga.TypeDefinition {
+	types := make(map[string]openfga.TypeDefinition, len(model.TypeDefinitions))
+	for _, t := range model.TypeDefinitions {
+		types[t.Type] = t
+	}
+	return types
+}
+
+func relations(t openfga.TypeDefinition) map[string]relationDefinition {
+	relations := make(map[string]relationDefinition)
+	if t.Relations == nil {
+		return relations
+	}
+
+	for name, rewrite := range *t.Relations {
+		r := relationDefinition{Rewrite: rewrite}
+		if t.Metadata != nil && t.Metadata.Relations != nil {
+			if m, ok := (*t.Metadata.Relations)[name]; ok {
+				r.DirectlyFrom = m.DirectlyRelatedUserTypes
+			}
+		}
+		relations[name] = r
+	}
+	return relations
+}
+
+func conditions(model *openfga.AuthorizationModel) map[string]openfga.Condition {
+	if model.Conditions == nil {
+		return map[string]openfga.Condition{}
+	}
+	return *model.Conditions
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// sameDefinition compares a and b through their JSON encoding, so that empty
+// and missing fields, which OpenFGA does not return consistently, are equal.
+func sameDefinition(a, b any) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return v
+	}
+
+	return prune(decoded)
+}
+
+// prune drops the empty values of a decoded JSON document.
+func prune(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if e = prune(e); e == nil {
+				delete(t, k)
+			} else {
+				t[k] = e
+			}
+		}
+		if len(t) == 0 {
+			return nil
+		}
+		return t
+	case []any:
+		if len(t) == 0 {
+			return nil
+		}
+		for i, e := range t {
+			t[i] = prune(e)
+		}
+		return t
+	case string:
+		if t == "" {
+			return nil
+		}
+		return t
+	default:
+		return v
+	}
+}
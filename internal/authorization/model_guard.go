@@ -0,0 +1,274 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	fga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+)
+
+// ErrModelNotValidated is returned by the authorization calls made while the
+// model is still being validated, access is denied until it is valid.
+var ErrModelNotValidated = errors.New("authorization model is not validated yet")
+
+const (
+	// ModelPolicyFailFast refuses to start with an invalid model
+	ModelPolicyFailFast = "fail-fast"
+	// ModelPolicyWarn starts with a noop authorizer, allowing every call
+	ModelPolicyWarn = "warn"
+	// ModelPolicyRetry starts denying every call and validates the model
+	// again with backoff until it is valid
+	ModelPolicyRetry = "retry"
+)
+
+const (
+	ModelStateValidating = "validating"
+	ModelStateValid      = "valid"
+	// ModelStateDegraded is an invalid model served with the noop authorizer
+	ModelStateDegraded = "degraded"
+)
+
+var modelStates = []string{ModelStateValidating, ModelStateValid, ModelStateDegraded}
+
+// ModelPolicies returns the policies accepted by NewModelGuard.
+func ModelPolicies() []string {
+	return []string{ModelPolicyFailFast, ModelPolicyWarn, ModelPolicyRetry}
+}
+
+// ModelGuard applies the startup policy chosen for an invalid authorization
+// model. It routes the authorization calls to the enforced client once the
+// model is valid, to the fallback in degraded mode and fails them meanwhile.
+type ModelGuard struct {
+	policy     string
+	backoff    time.Duration
+	maxBackoff time.Duration
+
+	enforced AuthzClientInterface
+	fallback AuthzClientInterface
+
+	mu    sync.RWMutex
+	state string
+
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// State returns the state of the model validation.
+func (g *ModelGuard) State() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.state
+}
+
+// Available reports whether the model in use is the validated one.
+func (g *ModelGuard) Available() bool {
+	return g.State() == ModelStateValid
+}
+
+func (g *ModelGuard) setState(state string) {
+	g.mu.Lock()
+	g.state = state
+	g.mu.Unlock()
+
+	for _, s := range modelStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		g.monitor.SetAuthorizationModelState(map[string]string{"state": s}, value)
+	}
+}
+
+// Validate validates the model once with validate and applies the policy when
+// it fails, only the fail-fast policy returns an error.
+func (g *ModelGuard) Validate(ctx context.Context, validate func(context.Context) error) error {
+	err := validate(ctx)
+	if err == nil {
+		g.setState(ModelStateValid)
+		return nil
+	}
+
+	switch g.policy {
+	case ModelPolicyWarn:
+		g.logger.Errorw("invalid authorization model, serving without authorization", "error", err)
+		g.setState(ModelStateDegraded)
+		return nil
+	case ModelPolicyRetry:
+		g.logger.Errorw("invalid authorization model, denying access until it is valid", "error", err)
+		g.setState(ModelStateValidating)
+		return nil
+	default:
+		return err
+	}
+}
+
+// Run validates the model again with backoff until it is valid or ctx is
+// done, it returns at once unless the model is being validated.
+func (g *ModelGuard) Run(ctx context.Context, validate func(context.Context) error) {
+	for attempt := 1; g.State() == ModelStateValidating; attempt++ {
+		t := time.NewTimer(g.wait(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+
+		err := validate(ctx)
+		if err == nil {
+			g.logger.Info("Authorization model is valid, enforcing authorization")
+			g.setState(ModelStateValid)
+			return
+		}
+		g.logger.Errorw("invalid authorization model", "attempt", attempt, "error", err)
+	}
+}
+
+// wait returns the backoff after the given attempt, with jitter.
+func (g *ModelGuard) wait(attempt int) time.Duration {
+	d := g.backoff << (attempt - 1)
+	if d <= 0 || (g.maxBackoff > 0 && d > g.maxBackoff) {
+		d = g.maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+func (g *ModelGuard) client() (AuthzClientInterface, error) {
+	switch g.State() {
+	case ModelStateValid:
+		return g.enforced, nil
+	case ModelStateDegraded:
+		return g.fallback, nil
+	default:
+		return nil, ErrModelNotValidated
+	}
+}
+
+func (g *ModelGuard) ListObjects(ctx context.Context, user, relation, objectType string) ([]string, error) {
+	c, err := g.client()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListObjects(ctx, user, relation, objectType)
+}
+
+func (g *ModelGuard) StreamObjects(ctx context.Context, user, relation, objectType string, fn func(id string) bool) error {
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	return c.StreamObjects(ctx, user, relation, objectType, fn)
+}
+
+func (g *ModelGuard) Check(ctx context.Context, user, relation, object string, tuples ...openfga.Tuple) (bool, error) {
+	c, err := g.client()
+	if err != nil {
+		return false, err
+	}
+	return c.Check(ctx, user, relation, object, tuples...)
+}
+
+func (g *ModelGuard) BatchCheck(ctx context.Context, tuples ...openfga.TupleWithContext) (bool, error) {
+	c, err := g.client()
+	if err != nil {
+		return false, err
+	}
+	return c.BatchCheck(ctx, tuples...)
+}
+
+func (g *ModelGuard) BatchCheckEach(ctx context.Context, tuples ...openfga.TupleWithContext) ([]bool, error) {
+	c, err := g.client()
+	if err != nil {
+		return nil, err
+	}
+	return c.BatchCheckEach(ctx, tuples...)
+}
+
+// ReadModel always reads from the enforced client, it validates the model.
+func (g *ModelGuard) ReadModel(ctx context.Context) (*fga.AuthorizationModel, error) {
+	return g.enforced.ReadModel(ctx)
+}
+
+// CompareModel always compares with the enforced client, it validates the model.
+func (g *ModelGuard) CompareModel(ctx context.Context, model fga.AuthorizationModel) (bool, error) {
+	return g.enforced.CompareModel(ctx, model)
+}
+
+func (g *ModelGuard) ReadTuples(ctx context.Context, user, relation, object, continuationToken string) (*client.ClientReadResponse, error) {
+	c, err := g.client()
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadTuples(ctx, user, relation, object, continuationToken)
+}
+
+func (g *ModelGuard) WriteTuple(ctx context.Context, user, relation, object string) error {
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	return c.WriteTuple(ctx, user, relation, object)
+}
+
+func (g *ModelGuard) WriteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	return c.WriteTuples(ctx, tuples...)
+}
+
+func (g *ModelGuard) DeleteTuple(ctx context.Context, user, relation, object string) error {
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	return c.DeleteTuple(ctx, user, relation, object)
+}
+
+func (g *ModelGuard) DeleteTuples(ctx context.Context, tuples ...openfga.Tuple) error {
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	return c.DeleteTuples(ctx, tuples...)
+}
+
+// NewModelGuard returns a guard for the model enforced by enforced, calls go
+// to fallback in degraded mode. backoff and maxBackoff pace the retries.
+func NewModelGuard(policy string, backoff, maxBackoff time.Duration, enforced, fallback AuthzClientInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) (*ModelGuard, error) {
+	switch policy {
+	case ModelPolicyFailFast, ModelPolicyWarn, ModelPolicyRetry:
+	default:
+		return nil, fmt.Errorf("unknown authorization model policy %q, expected one of %v", policy, ModelPolicies())
+	}
+
+	g := new(ModelGuard)
+	g.policy = policy
+	g.backoff = backoff
+	g.maxBackoff = maxBackoff
+	g.enforced = enforced
+	g.fallback = fallback
+	g.state = ModelStateValidating
+	g.monitor = monitor
+	g.logger = logger
+
+	return g, nil
+}
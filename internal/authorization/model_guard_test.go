@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestModelGuard_Validate(t *testing.T) {
+	invalid := func(context.Context) error { return ErrInvalidAuthModel }
+
+	testCases := []struct {
+		name          string
+		policy        string
+		validate      func(context.Context) error
+		expectedState string
+		expectedErr   bool
+		expectedCheck string
+	}{
+		{
+			name:          "valid model",
+			policy:        ModelPolicyFailFast,
+			validate:      func(context.Context) error { return nil },
+			expectedState: ModelStateValid,
+			expectedCheck: "enforced",
+		},
+		{
+			name:          "fail fast",
+			policy:        ModelPolicyFailFast,
+			validate:      invalid,
+			expectedState: ModelStateValidating,
+			expectedErr:   true,
+		},
+		{
+			name:          "warn and serve",
+			policy:        ModelPolicyWarn,
+			validate:      invalid,
+			expectedState: ModelStateDegraded,
+			expectedCheck: "fallback",
+		},
+		{
+			name:          "retry",
+			policy:        ModelPolicyRetry,
+			validate:      invalid,
+			expectedState: ModelStateValidating,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEnforced := NewMockAuthzClientInterface(ctrl)
+			mockFallback := NewMockAuthzClientInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			mockMonitor.EXPECT().SetAuthorizationModelState(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+
+			switch tc.expectedCheck {
+			case "enforced":
+				mockEnforced.EXPECT().Check(gomock.Any(), "user:1", "member", "tenant:1").Return(true, nil)
+			case "fallback":
+				mockFallback.EXPECT().Check(gomock.Any(), "user:1", "member", "tenant:1").Return(true, nil)
+			}
+
+			g, err := NewModelGuard(tc.policy, time.Second, time.Minute, mockEnforced, mockFallback, mockMonitor, mockLogger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = g.Validate(context.Background(), tc.validate)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if g.State() != tc.expectedState {
+				t.Fatalf("expected state %s, got %s", tc.expectedState, g.State())
+			}
+			if g.Available() != (tc.expectedState == ModelStateValid) {
+				t.Errorf("expected availability to follow the state %s", g.State())
+			}
+
+			allowed, err := g.Check(context.Background(), "user:1", "member", "tenant:1")
+			if tc.expectedCheck == "" {
+				if !errors.Is(err, ErrModelNotValidated) || allowed {
+					t.Errorf("expected access to be denied, got %v, %v", allowed, err)
+				}
+				return
+			}
+			if err != nil || !allowed {
+				t.Errorf("expected access to be allowed, got %v, %v", allowed, err)
+			}
+		})
+	}
+}
+
+func TestModelGuard_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+
+	mockMonitor.EXPECT().SetAuthorizationModelState(map[string]string{"state": ModelStateValidating}, 1.0)
+	mockMonitor.EXPECT().SetAuthorizationModelState(map[string]string{"state": ModelStateValid}, 0.0)
+	mockMonitor.EXPECT().SetAuthorizationModelState(map[string]string{"state": ModelStateDegraded}, 0.0).Times(2)
+	mockMonitor.EXPECT().SetAuthorizationModelState(map[string]string{"state": ModelStateValidating}, 0.0)
+	mockMonitor.EXPECT().SetAuthorizationModelState(map[string]string{"state": ModelStateValid}, 1.0)
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).Times(2)
+	mockLogger.EXPECT().Info(gomock.Any())
+
+	g, err := NewModelGuard(ModelPolicyRetry, time.Millisecond, 2*time.Millisecond, NewMockAuthzClientInterface(ctrl), nil, mockMonitor, mockLogger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attempts := 0
+	validate := func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return ErrInvalidAuthModel
+		}
+		return nil
+	}
+
+	if err := g.Validate(context.Background(), validate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	g.Run(ctx, validate)
+
+	if attempts != 3 || g.State() != ModelStateValid {
+		t.Fatalf("expected the model to be valid after 3 attempts, got %s after %d", g.State(), attempts)
+	}
+
+	if _, err := NewModelGuard("lenient", 0, 0, nil, nil, mockMonitor, mockLogger); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
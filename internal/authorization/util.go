@@ -3,17 +3,33 @@
 
 package authorization
 
+import (
+	"strings"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
 const (
 	OWNER_RELATION  = "owner"
 	MEMBER_RELATION = "member"
 
 	PRIVILEGED_RELATION = "privileged"
-	ADMIN_RELATION      = "admin"
+	// ADMIN_RELATION is held by the admins of a tenant and of a privileged group
+	ADMIN_RELATION = "admin"
+
+	ASSIGNEE_RELATION = "assignee"
+	VIEWER_RELATION   = "viewer"
+	EDITOR_RELATION   = "editor"
+	CREATOR_RELATION  = "creator"
+	DELETER_RELATION  = "deleter"
 
 	CAN_VIEW_PERMISSION   = "can_view"
 	CAN_EDIT_PERMISSION   = "can_edit"
 	CAN_CREATE_PERMISSION = "can_create"
 	CAN_DELETE_PERMISSION = "can_delete"
+	// CAN_ADMIN_PERMISSION is held by the admins of a tenant, owners
+	// included, and of its privileged group
+	CAN_ADMIN_PERMISSION = "can_admin"
 )
 
 func UserTuple(userId string) string {
@@ -27,3 +43,64 @@ func TenantTuple(tenantId string) string {
 func PrivilegedTuple(privilegedId string) string {
 	return "privileged:" + privilegedId
 }
+
+func RoleTuple(roleId string) string {
+	return "role:" + roleId
+}
+
+// RoleAssigneesTuple is the userset of every user assigned to a custom role.
+func RoleAssigneesTuple(roleId string) string {
+	return RoleTuple(roleId) + "#" + ASSIGNEE_RELATION
+}
+
+// PermissionGrants maps each permission a custom role can hold to the tenant
+// relation granting it.
+var PermissionGrants = map[string]string{
+	CAN_VIEW_PERMISSION:   VIEWER_RELATION,
+	CAN_EDIT_PERMISSION:   EDITOR_RELATION,
+	CAN_CREATE_PERMISSION: CREATOR_RELATION,
+	CAN_DELETE_PERMISSION: DELETER_RELATION,
+}
+
+// TenantPermissionRelations are the tenant relations reported to a user by
+// TenantPermissions.
+var TenantPermissionRelations = []string{
+	OWNER_RELATION,
+	ADMIN_RELATION,
+	MEMBER_RELATION,
+	CAN_VIEW_PERMISSION,
+	CAN_EDIT_PERMISSION,
+	CAN_CREATE_PERMISSION,
+	CAN_DELETE_PERMISSION,
+	CAN_ADMIN_PERMISSION,
+}
+
+// MembershipRelations are the direct tenant relations written for the
+// membership roles, see RelationForRole.
+var MembershipRelations = []string{
+	OWNER_RELATION,
+	ADMIN_RELATION,
+	MEMBER_RELATION,
+}
+
+// RelationForRole maps a membership role to the direct tenant relation it is expected to hold.
+func RelationForRole(role types.MembershipRole) string {
+	switch role {
+	case types.RoleOwner:
+		return OWNER_RELATION
+	case types.RoleAdmin:
+		return ADMIN_RELATION
+	default:
+		return MEMBER_RELATION
+	}
+}
+
+// UserIDFromTuple extracts the user ID from a "user:<id>" tuple, reporting false for any other type.
+func UserIDFromTuple(user string) (string, bool) {
+	return strings.CutPrefix(user, "user:")
+}
+
+// TenantIDFromTuple extracts the tenant ID from a "tenant:<id>" object, reporting false for any other type.
+func TenantIDFromTuple(object string) (string, bool) {
+	return strings.CutPrefix(object, "tenant:")
+}
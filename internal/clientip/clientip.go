@@ -0,0 +1,157 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	forwardedForHeader = "X-Forwarded-For"
+	realIPHeader       = "X-Real-IP"
+)
+
+type contextKey struct{}
+
+// WithClientIP returns a copy of ctx carrying the client IP.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// FromContext returns the client IP of the request served with ctx.
+func FromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(contextKey{}).(string)
+	return ip, ok && ip != ""
+}
+
+// Resolver resolves the client IP of a request. The peer address is the
+// client unless it is a trusted proxy, the client is then the last address
+// of X-Forwarded-For that is not a trusted proxy, or X-Real-IP without
+// X-Forwarded-For. The headers of untrusted peers are ignored, anyone can
+// set them.
+type Resolver struct {
+	trusted []netip.Prefix
+}
+
+// Resolve returns the client IP of a request from the peer address
+// remoteAddr, host:port or host, with the given forwarding headers.
+func (r *Resolver) Resolve(remoteAddr string, forwardedFor []string, realIP string) string {
+	ip := hostIP(remoteAddr)
+	if !r.isTrusted(ip) {
+		return ip
+	}
+
+	// the proxies append the address they got the request from, the last
+	// untrusted one is the client, the addresses before may be forged
+	var hops []string
+	for _, v := range forwardedFor {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hostIP(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			break
+		}
+		ip = hop
+		if !r.isTrusted(hop) {
+			return hop
+		}
+	}
+
+	if len(hops) == 0 && realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+
+	return ip
+}
+
+func (r *Resolver) isTrusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range r.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware stores the client IP of the HTTP requests in their context.
+func (r *Resolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ip := r.Resolve(req.RemoteAddr, req.Header.Values(forwardedForHeader), req.Header.Get(realIPHeader))
+		next.ServeHTTP(w, req.WithContext(WithClientIP(req.Context(), ip)))
+	})
+}
+
+// UnaryServerInterceptor stores the client IP of the gRPC calls in their
+// context, from the x-forwarded-for and x-real-ip metadata of trusted peers.
+func (r *Resolver) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var realIP string
+	if v := md.Get(realIPHeader); len(v) > 0 {
+		realIP = v[0]
+	}
+
+	ip := r.Resolve(p.Addr.String(), md.Get(forwardedForHeader), realIP)
+	return handler(WithClientIP(ctx, ip), req)
+}
+
+// hostIP returns the host of a host:port address, the address itself if it
+// has no port.
+func hostIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// NewResolver returns a resolver trusting the proxies in the given CIDRs, or
+// IPs, nothing but the peer address is used without any.
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	r := new(Resolver)
+	for _, cidr := range trustedProxies {
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+			}
+			r.trusted = append(r.trusted, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		r.trusted = append(r.trusted, prefix.Masked())
+	}
+
+	return r, nil
+}
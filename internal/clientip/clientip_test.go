@@ -0,0 +1,92 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package clientip
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestResolverResolve(t *testing.T) {
+	r, err := NewResolver([]string{"10.0.0.0/8", "192.0.2.10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		expected     string
+	}{
+		{name: "direct client", remoteAddr: "198.51.100.7:4321", expected: "198.51.100.7"},
+		{name: "headers of an untrusted peer", remoteAddr: "198.51.100.7:4321", forwardedFor: []string{"203.0.113.1"}, realIP: "203.0.113.2", expected: "198.51.100.7"},
+		{name: "behind a proxy", remoteAddr: "10.0.0.5:4321", forwardedFor: []string{"203.0.113.1"}, expected: "203.0.113.1"},
+		{name: "forged first hop", remoteAddr: "10.0.0.5:4321", forwardedFor: []string{"1.2.3.4, 203.0.113.1"}, expected: "203.0.113.1"},
+		{name: "behind two proxies", remoteAddr: "10.0.0.5:4321", forwardedFor: []string{"203.0.113.1", "192.0.2.10"}, expected: "203.0.113.1"},
+		{name: "only proxies", remoteAddr: "10.0.0.5:4321", forwardedFor: []string{"10.0.0.9"}, expected: "10.0.0.9"},
+		{name: "malformed hop", remoteAddr: "10.0.0.5:4321", forwardedFor: []string{"203.0.113.1, unknown"}, expected: "10.0.0.5"},
+		{name: "real IP", remoteAddr: "10.0.0.5:4321", realIP: "203.0.113.2", expected: "203.0.113.2"},
+		{name: "ipv4 mapped proxy", remoteAddr: "[::ffff:10.0.0.5]:4321", forwardedFor: []string{"203.0.113.1"}, expected: "203.0.113.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ip := r.Resolve(tt.remoteAddr, tt.forwardedFor, tt.realIP); ip != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, ip)
+			}
+		})
+	}
+}
+
+func TestNewResolverInvalid(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "proxy.example.com"} {
+		if _, err := NewResolver([]string{cidr}); err == nil {
+			t.Errorf("expected an error for %s", cidr)
+		}
+	}
+}
+
+func TestResolverMiddleware(t *testing.T) {
+	r, _ := NewResolver([]string{"10.0.0.0/8"})
+
+	var ip string
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ip, _ = FromContext(req.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil)
+	req.RemoteAddr = "10.0.0.5:4321"
+	req.Header.Set("X-Forwarded-For", "203.0.113.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if ip != "203.0.113.1" {
+		t.Errorf("expected the client IP in the context, got %q", ip)
+	}
+}
+
+func TestResolverUnaryServerInterceptor(t *testing.T) {
+	r, _ := NewResolver([]string{"10.0.0.0/8"})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4321}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "203.0.113.1"))
+
+	var ip string
+	_, _ = r.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		ip, _ = FromContext(ctx)
+		return nil, nil
+	})
+
+	if ip != "203.0.113.1" {
+		t.Errorf("expected the client IP in the context, got %q", ip)
+	}
+}
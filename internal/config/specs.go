@@ -1,42 +1,376 @@
 package config
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 )
 
-// EnvSpec is the basic environment configuration setup needed for the app to start
+// EnvSpec is the basic environment configuration setup needed for the app to start.
+// The fields tagged secret are redacted when it is formatted.
 type EnvSpec struct {
 	OtelGRPCEndpoint string `envconfig:"otel_grpc_endpoint"`
 	OtelHTTPEndpoint string `envconfig:"otel_http_endpoint"`
 	TracingEnabled   bool   `envconfig:"tracing_enabled" default:"true"`
 
+	// SentryDSN reports the panics recovered while serving requests to the
+	// Sentry project, tagged with SentryEnvironment. Empty disables it.
+	SentryDSN         string `envconfig:"sentry_dsn" secret:"true"`
+	SentryEnvironment string `envconfig:"sentry_environment"`
+
 	KratosAdminURL string `envconfig:"kratos_admin_url" required:"true"`
+	// KratosPublicURL serves the sessions of browsers, see AuthenticationSessionEnabled
+	KratosPublicURL string `envconfig:"kratos_public_url"`
+
+	KratosCreateIdentityRate        float64 `envconfig:"kratos_create_identity_rate" default:"10"`
+	KratosCreateIdentityBurst       int     `envconfig:"kratos_create_identity_burst" default:"10"`
+	KratosCreateIdentityConcurrency int     `envconfig:"kratos_create_identity_concurrency" default:"4"`
+
+	OutboundTLSCAFile   string `envconfig:"outbound_tls_ca_file"`
+	OutboundTLSCertFile string `envconfig:"outbound_tls_cert_file"`
+	OutboundTLSKeyFile  string `envconfig:"outbound_tls_key_file"`
+	OutboundProxyURL    string `envconfig:"outbound_proxy_url"`
 
 	InvitationLifetime string `envconfig:"invitation_lifetime" default:"24h"`
 
+	// TenantMemberQuota and TenantInviteQuota limit the members and pending
+	// invitations of every tenant, zero leaves them unlimited. The owners are
+	// warned once a tenant reaches TenantQuotaWarningPercent of a quota.
+	TenantMemberQuota         int `envconfig:"tenant_member_quota" default:"0"`
+	TenantInviteQuota         int `envconfig:"tenant_invite_quota" default:"0"`
+	TenantQuotaWarningPercent int `envconfig:"tenant_quota_warning_percent" default:"80"`
+
+	IdempotencyKeyTTL time.Duration `envconfig:"idempotency_key_ttl" default:"24h"`
+
+	EncryptionKeys        string `envconfig:"encryption_keys" secret:"true"`
+	EncryptionActiveKeyID string `envconfig:"encryption_active_key_id"`
+
+	TenantListingSource string `envconfig:"tenant_listing_source" default:"database"`
+
+	// Region is the region this deployment serves, writes to tenants homed
+	// in another region are rejected with the endpoint from RegionEndpoints,
+	// e.g. us-east-1:https://us.tenants.example.com.
+	Region          string            `envconfig:"region"`
+	RegionEndpoints map[string]string `envconfig:"region_endpoints"`
+
 	LogLevel string `envconfig:"log_level" default:"error"`
 	Debug    bool   `envconfig:"debug" default:"false"`
+	// DebugPayloads logs the redacted API payloads at debug level.
+	DebugPayloads bool `envconfig:"debug_payloads" default:"false"`
+
+	// StrictJSON rejects the REST request bodies holding unknown fields,
+	// StrictWebhookJSON the token hook bodies and the v1 webhook bodies.
+	StrictJSON        bool `envconfig:"strict_json" default:"true"`
+	StrictWebhookJSON bool `envconfig:"strict_webhook_json" default:"false"`
+
+	// WebhookVersion is the schema version of the webhook bodies sent
+	// without the X-Webhook-Version header.
+	WebhookVersion string `envconfig:"webhook_version" default:"v0"`
 
 	Port     int `envconfig:"port" default:"8080"`
 	GRPCPort int `envconfig:"grpc_port" default:"50051"`
 
-	DSN string `envconfig:"DSN" required:"true"`
+	// ShutdownTimeout bounds the draining of the requests in flight and of
+	// the background tasks, the servers are then stopped without waiting.
+	ShutdownTimeout time.Duration `envconfig:"shutdown_timeout" default:"15s"`
+
+	// APIMaxBodySize and APITimeout bound the size of the bodies and the
+	// time given to the handlers of the API, over the gateway and gRPC, and
+	// WebhookMaxBodySize and WebhookTimeout those of the webhooks; 0
+	// disables the limit. HTTPReadHeaderTimeout bounds the reading of the
+	// headers of every HTTP request, against slow clients holding
+	// connections.
+	APIMaxBodySize        int64         `envconfig:"api_max_body_size" default:"1048576"`
+	APITimeout            time.Duration `envconfig:"api_timeout" default:"30s"`
+	WebhookMaxBodySize    int64         `envconfig:"webhook_max_body_size" default:"1048576"`
+	WebhookTimeout        time.Duration `envconfig:"webhook_timeout" default:"10s"`
+	HTTPReadHeaderTimeout time.Duration `envconfig:"http_read_header_timeout" default:"5s"`
+
+	// ReadinessCacheTTL is how long the outcome of the probes of the
+	// dependencies is reused by the readiness endpoint and the gRPC health
+	// service, each probe is given its timeout.
+	ReadinessCacheTTL       time.Duration `envconfig:"readiness_cache_ttl" default:"5s"`
+	ReadinessDBTimeout      time.Duration `envconfig:"readiness_db_timeout" default:"2s"`
+	ReadinessOpenFGATimeout time.Duration `envconfig:"readiness_openfga_timeout" default:"2s"`
+	ReadinessKratosTimeout  time.Duration `envconfig:"readiness_kratos_timeout" default:"2s"`
+
+	// With HTTP disabled, PORT only serves the status and metrics endpoints.
+	HTTPEnabled bool `envconfig:"http_enabled" default:"true"`
+	GRPCEnabled bool `envconfig:"grpc_enabled" default:"true"`
+
+	// SinglePortEnabled serves gRPC on PORT along with HTTP, over cleartext
+	// HTTP/2, instead of on GRPC_PORT.
+	SinglePortEnabled bool `envconfig:"single_port_enabled" default:"false"`
+
+	// GRPCReflectionEnabled serves the gRPC reflection API, unauthenticated.
+	GRPCReflectionEnabled bool `envconfig:"grpc_reflection_enabled" default:"false"`
+
+	// APIDocsEnabled serves a Swagger UI of the OpenAPI document at
+	// /api/v0/docs, loading its assets from APIDocsAssetsURL.
+	APIDocsEnabled   bool   `envconfig:"api_docs_enabled" default:"false"`
+	APIDocsAssetsURL string `envconfig:"api_docs_assets_url" default:"https://unpkg.com/swagger-ui-dist@5"`
+
+	// GRPCHealthCheckInterval is the interval between the checks of the
+	// dependencies reported by the gRPC health service, 0 never checks them.
+	GRPCHealthCheckInterval time.Duration `envconfig:"grpc_health_check_interval" default:"10s"`
+
+	// GRPCTLSCertFile and GRPCTLSKeyFile serve the gRPC API over TLS, the
+	// files are reloaded every GRPCTLSReloadInterval when they change.
+	GRPCTLSCertFile       string        `envconfig:"grpc_tls_cert_file"`
+	GRPCTLSKeyFile        string        `envconfig:"grpc_tls_key_file"`
+	GRPCTLSReloadInterval time.Duration `envconfig:"grpc_tls_reload_interval" default:"1m"`
+	// GRPCTLSClientCAFile requires client certificates issued by its CAs,
+	// restricted to the ones holding one of GRPCTLSClientSANs when set.
+	GRPCTLSClientCAFile string   `envconfig:"grpc_tls_client_ca_file"`
+	GRPCTLSClientSANs   []string `envconfig:"grpc_tls_client_sans"`
+
+	// TrustedProxies are the CIDRs of the proxies whose X-Forwarded-For and
+	// X-Real-IP headers tell the client IP, see clientip.Resolver.
+	TrustedProxies []string `envconfig:"trusted_proxies"`
+
+	// OpsAddress serves the unauthenticated ops API, a loopback host:port or
+	// a unix socket such as unix:///run/tenant-service/ops.sock.
+	OpsAddress string `envconfig:"ops_address"`
+
+	// TokenHookTargets lists the tokens the token hook adds the tenant claims to.
+	TokenHookTargets string `envconfig:"token_hook_targets" default:"id_token,access_token"`
+
+	// TokenHookClaim names the tenant claim, TokenHookClaimFormat lists the
+	// tenant IDs (ids) or maps them to the role of the user (roles), and
+	// TokenHookMaxTenants caps the number of tenants in it, 0 does not.
+	TokenHookClaim       string `envconfig:"token_hook_claim" default:"tenants"`
+	TokenHookClaimFormat string `envconfig:"token_hook_claim_format" default:"ids"`
+	TokenHookMaxTenants  int    `envconfig:"token_hook_max_tenants" default:"0"`
+
+	// TokenHookClientTenants binds clients to a tenant, as client:tenant
+	// pairs, their tokens only hold that tenant.
+	TokenHookClientTenants map[string]string `envconfig:"token_hook_client_tenants"`
+
+	// WebhookRegistrationSecrets, WebhookTokenSecrets and WebhookLoginSecrets
+	// are the shared secrets the Kratos registration, the Hydra token and the
+	// Kratos login hooks must be called with, several allow rotating them.
+	// Without secrets a hook is not authenticated.
+	WebhookRegistrationSecrets []string `envconfig:"webhook_registration_secrets" secret:"true"`
+	WebhookTokenSecrets        []string `envconfig:"webhook_token_secrets" secret:"true"`
+	WebhookLoginSecrets        []string `envconfig:"webhook_login_secrets" secret:"true"`
+
+	// WebhookIdentitySecrets are the shared secrets of the identity-deleted
+	// hook, it is only served when set.
+	WebhookIdentitySecrets []string `envconfig:"webhook_identity_secrets" secret:"true"`
+
+	// WebhookSignatureTolerance is how far from now the timestamp of a
+	// signed webhook call can be, older calls are taken as replayed.
+	WebhookSignatureTolerance time.Duration `envconfig:"webhook_signature_tolerance" default:"5m"`
+
+	// WebhookConsentSecrets are the shared secrets of the consent hook, it
+	// is only served when set.
+	WebhookConsentSecrets []string `envconfig:"webhook_consent_secrets" secret:"true"`
+
+	// ConsentScopeRoles and ConsentAudienceRoles restrict the scopes and
+	// audiences granted by the consent hook to the members holding a role,
+	// as name=role.
+	ConsentScopeRoles    []string `envconfig:"consent_scope_roles"`
+	ConsentAudienceRoles []string `envconfig:"consent_audience_roles"`
+
+	// WebhookRateLimit and WebhookRateBurst are the rate, per second, and
+	// the burst of the webhook calls of all the sources, and
+	// WebhookSourceRateLimit and WebhookSourceRateBurst those of each client
+	// IP, a zero rate disables the limit. At most WebhookRateLimitSources
+	// client IPs are tracked.
+	WebhookRateLimit        float64 `envconfig:"webhook_rate_limit" default:"0"`
+	WebhookRateBurst        int     `envconfig:"webhook_rate_burst" default:"100"`
+	WebhookSourceRateLimit  float64 `envconfig:"webhook_source_rate_limit" default:"0"`
+	WebhookSourceRateBurst  int     `envconfig:"webhook_source_rate_burst" default:"20"`
+	WebhookRateLimitSources int     `envconfig:"webhook_rate_limit_sources" default:"100000"`
+
+	// WebhookQueueEnabled provisions the tenants of the registrations from
+	// the job queue, the webhook returns once the job is persisted and the
+	// failures are retried with backoff.
+	WebhookQueueEnabled bool `envconfig:"webhook_queue_enabled" default:"false"`
+
+	// RegistrationTenantNameTemplate names the tenants of the registrations,
+	// RegistrationTenantEnabled enables them, as does an email domain of
+	// RegistrationTenantEnabledDomains, and RegistrationTenantRole is the
+	// role of the registered identity in its tenant.
+	RegistrationTenantNameTemplate   string   `envconfig:"registration_tenant_name_template" default:"{{.Email}}'s Org"`
+	RegistrationTenantEnabled        bool     `envconfig:"registration_tenant_enabled" default:"false"`
+	RegistrationTenantEnabledDomains []string `envconfig:"registration_tenant_enabled_domains"`
+	RegistrationTenantRole           string   `envconfig:"registration_tenant_role" default:"owner"`
+
+	// RegistrationSkipTenantField is the dot-separated path of the field of
+	// the registration webhook body skipping the tenant of the identity when
+	// set, for the identities invited to an existing tenant.
+	RegistrationSkipTenantField string `envconfig:"registration_skip_tenant_field"`
+
+	// EventSinkURLs are the HTTP targets the tenant lifecycle events are
+	// posted to as CloudEvents, signed with EventSinkSecret when set. The
+	// events are written to the job queue with the mutation and delivered by
+	// the job workers.
+	EventSinkURLs   []string `envconfig:"event_sink_urls"`
+	EventSinkSecret string   `envconfig:"event_sink_secret" secret:"true"`
+	// EventSource is the source attribute of the events.
+	EventSource string `envconfig:"event_source" default:"/tenant-service"`
+	// WebhookSubscriptionsEnabled lets the tenants subscribe callback URLs
+	// to their events, delivered by the job workers like the sinks.
+	WebhookSubscriptionsEnabled bool `envconfig:"webhook_subscriptions_enabled" default:"false"`
+
+	// JobWorkers is the number of jobs a replica runs at once, an attempt
+	// is cancelled after JobTimeout and retried up to JobMaxAttempts times,
+	// waiting JobRetryBackoff doubled on every retry up to JobRetryMaxBackoff.
+	JobWorkers         int           `envconfig:"job_workers" default:"4"`
+	JobPollInterval    time.Duration `envconfig:"job_poll_interval" default:"1s"`
+	JobTimeout         time.Duration `envconfig:"job_timeout" default:"1m"`
+	JobMaxAttempts     int           `envconfig:"job_max_attempts" default:"10"`
+	JobRetryBackoff    time.Duration `envconfig:"job_retry_backoff" default:"5s"`
+	JobRetryMaxBackoff time.Duration `envconfig:"job_retry_max_backoff" default:"10m"`
+
+	DSN string `envconfig:"DSN" required:"true" secret:"true"`
 
 	DBMaxConns        int32         `envconfig:"db_max_conns" default:"25"`
 	DBMinConns        int32         `envconfig:"db_min_conns" default:"2"`
 	DBMaxConnLifetime time.Duration `envconfig:"db_max_conn_lifetime" default:"1h"`
 	DBMaxConnIdleTime time.Duration `envconfig:"db_max_conn_idle_time" default:"30m"`
 
+	LoadSheddingEnabled          bool          `envconfig:"load_shedding_enabled" default:"false"`
+	LoadSheddingWindow           time.Duration `envconfig:"load_shedding_window" default:"30s"`
+	LoadSheddingDBThreshold      time.Duration `envconfig:"load_shedding_db_threshold" default:"500ms"`
+	LoadSheddingOpenFGAThreshold time.Duration `envconfig:"load_shedding_openfga_threshold" default:"500ms"`
+
+	// APIRateLimit and APIRateBurst are the rate, per second, and the burst
+	// of the API calls of all the callers, APISubjectRateLimit and
+	// APISubjectRateBurst those of each subject and APITenantRateLimit and
+	// APITenantRateBurst those on each tenant, a zero rate disables the
+	// limit. The buckets are kept in APIRateLimitRedisURL when set, shared by
+	// the replicas, or in memory, at most APIRateLimitKeys of them.
+	APIRateLimit         float64 `envconfig:"api_rate_limit" default:"0"`
+	APIRateBurst         int     `envconfig:"api_rate_burst" default:"200"`
+	APISubjectRateLimit  float64 `envconfig:"api_subject_rate_limit" default:"0"`
+	APISubjectRateBurst  int     `envconfig:"api_subject_rate_burst" default:"20"`
+	APITenantRateLimit   float64 `envconfig:"api_tenant_rate_limit" default:"0"`
+	APITenantRateBurst   int     `envconfig:"api_tenant_rate_burst" default:"50"`
+	APIRateLimitKeys     int     `envconfig:"api_rate_limit_keys" default:"100000"`
+	APIRateLimitRedisURL string  `envconfig:"api_rate_limit_redis_url" default:"" secret:"true"`
+
 	AuthorizationEnabled bool   `envconfig:"authorization_enabled" default:"false"`
 	OpenfgaApiScheme     string `envconfig:"openfga_api_scheme" default:""`
 	OpenfgaApiHost       string `envconfig:"openfga_api_host"`
-	OpenfgaApiToken      string `envconfig:"openfga_api_token"`
+	OpenfgaApiToken      string `envconfig:"openfga_api_token" secret:"true"`
 	OpenfgaStoreId       string `envconfig:"openfga_store_id"`
 	OpenfgaModelId       string `envconfig:"openfga_authorization_model_id" default:""`
 
+	OpenfgaRetryMaxAttempts int           `envconfig:"openfga_retry_max_attempts" default:"3"`
+	OpenfgaRetryBackoff     time.Duration `envconfig:"openfga_retry_backoff" default:"100ms"`
+	OpenfgaRetryMaxBackoff  time.Duration `envconfig:"openfga_retry_max_backoff" default:"2s"`
+	OpenfgaBreakerThreshold int           `envconfig:"openfga_breaker_threshold" default:"5"`
+	OpenfgaBreakerCooldown  time.Duration `envconfig:"openfga_breaker_cooldown" default:"30s"`
+
+	// AuthorizationEnforceTenantScope requires can_admin on the tenant to
+	// update and remove its users, rather than can_edit, once the model
+	// holding can_admin is written.
+	AuthorizationEnforceTenantScope bool `envconfig:"authorization_enforce_tenant_scope" default:"false"`
+
+	// AuthorizationModelPolicy applies when the model fails validation at
+	// startup: fail-fast, warn or retry.
+	AuthorizationModelPolicy          string        `envconfig:"authorization_model_policy" default:"fail-fast"`
+	AuthorizationModelRetryBackoff    time.Duration `envconfig:"authorization_model_retry_backoff" default:"5s"`
+	AuthorizationModelRetryMaxBackoff time.Duration `envconfig:"authorization_model_retry_max_backoff" default:"5m"`
+
+	AuthorizationCacheTTL  time.Duration `envconfig:"authorization_cache_ttl" default:"0"`
+	AuthorizationCacheSize int           `envconfig:"authorization_cache_size" default:"10000"`
+
+	// PlatformAdminGroup is the support group whose admins can call the
+	// platform RPCs, such as creating tenants or adding platform admins.
+	PlatformAdminGroup string `envconfig:"platform_admin_group" default:"platform"`
+
+	ReconcileFGAInterval time.Duration `envconfig:"reconcile_fga_interval" default:"0"`
+	ReconcileFGAFix      bool          `envconfig:"reconcile_fga_fix" default:"false"`
+
+	TelemetryEnabled  bool          `envconfig:"telemetry_enabled" default:"false"`
+	TelemetryEndpoint string        `envconfig:"telemetry_endpoint"`
+	TelemetryInterval time.Duration `envconfig:"telemetry_interval" default:"24h"`
+
 	AuthenticationEnabled         bool   `envconfig:"authentication_enabled" default:"true"`
 	AuthenticationIssuer          string `envconfig:"authentication_issuer"`
 	AuthenticationJwksURL         string `envconfig:"authentication_jwks_url"`
 	AuthenticationAllowedSubjects string `envconfig:"authentication_allowed_subjects"`
 	AuthenticationRequiredScope   string `envconfig:"authentication_required_scope"`
+	// AuthenticationAllowedAudiences are the audiences tokens must be issued
+	// to, unchecked when empty, except for the tokens of the legacy clients of
+	// AuthenticationAudienceBypassClients
+	AuthenticationAllowedAudiences      []string `envconfig:"authentication_allowed_audiences"`
+	AuthenticationAudienceBypassClients []string `envconfig:"authentication_audience_bypass_clients"`
+	// AuthenticationServiceClients maps the client IDs accepted with client
+	// credentials tokens to their platform role, e.g. billing:viewer,ops:admin
+	AuthenticationServiceClients map[string]string `envconfig:"authentication_service_clients"`
+	// AuthenticationIssuersFile is a JSON file listing more trusted issuers,
+	// each with its own JWKS, audiences and subject policy
+	AuthenticationIssuersFile string `envconfig:"authentication_issuers_file"`
+	// AuthenticationIntrospectionURL is an OAuth2 introspection endpoint
+	// verifying the bearer tokens that are not JWTs, such as Hydra's opaque
+	// access tokens, authenticated with the client credentials when set
+	AuthenticationIntrospectionURL          string `envconfig:"authentication_introspection_url"`
+	AuthenticationIntrospectionClientID     string `envconfig:"authentication_introspection_client_id"`
+	AuthenticationIntrospectionClientSecret string `envconfig:"authentication_introspection_client_secret" secret:"true"`
+	// AuthenticationSessionEnabled authenticates the browser requests without
+	// bearer token to the routes under AuthenticationSessionRoutes with their
+	// Kratos session, checked with KratosPublicURL. The requests carrying an
+	// Origin must come from AuthenticationSessionOrigins
+	AuthenticationSessionEnabled bool     `envconfig:"authentication_session_enabled" default:"false"`
+	AuthenticationSessionRoutes  []string `envconfig:"authentication_session_routes" default:"/api/v0/"`
+	AuthenticationSessionOrigins []string `envconfig:"authentication_session_origins"`
+	// AuthenticationAPIKeysEnabled authenticates the requests without bearer
+	// token presenting a tenant API key in the X-API-Key header
+	AuthenticationAPIKeysEnabled bool `envconfig:"authentication_api_keys_enabled" default:"false"`
+	// AuthenticationPublicPaths are the HTTP path prefixes served without
+	// authentication. AuthenticationRouteModes restricts the other routes,
+	// keyed by path prefix, to one of the jwt, session, api-key or any schemes,
+	// the routes without mode accept any
+	AuthenticationPublicPaths []string          `envconfig:"authentication_public_paths" default:"/api/v0/status,/api/v0/version,/api/v0/metrics,/api/v0/webhooks,/api/v0/openapi.json,/api/v0/docs"`
+	AuthenticationRouteModes  map[string]string `envconfig:"authentication_route_modes"`
+	// AuthenticationCacheEnabled caches verified tokens until they expire, at
+	// most for AuthenticationCacheTTL, and the failures to fetch the keys of
+	// an issuer for AuthenticationKeysFailureTTL
+	AuthenticationCacheEnabled   bool          `envconfig:"authentication_cache_enabled" default:"true"`
+	AuthenticationCacheTTL       time.Duration `envconfig:"authentication_cache_ttl" default:"5m"`
+	AuthenticationCacheSize      int           `envconfig:"authentication_cache_size" default:"10000"`
+	AuthenticationKeysFailureTTL time.Duration `envconfig:"authentication_keys_failure_ttl" default:"30s"`
+	// AuthenticationClockSkew is the leeway on the exp, nbf and iat claims of
+	// the JWTs. AuthenticationMaxTokenAge, when set, rejects the JWTs issued
+	// longer ago whatever their expiry. AuthenticationFreshAuthMaxAge, when
+	// set, is how recently users must have logged in to delete a tenant.
+	AuthenticationClockSkew       time.Duration `envconfig:"authentication_clock_skew" default:"1m"`
+	AuthenticationMaxTokenAge     time.Duration `envconfig:"authentication_max_token_age"`
+	AuthenticationFreshAuthMaxAge time.Duration `envconfig:"authentication_fresh_auth_max_age"`
+	// AuthenticationStepUpEnabled requires the callers deleting a tenant to
+	// hold AuthenticationStepUpScope, or to have logged in with one of
+	// AuthenticationStepUpMFAMethods within AuthenticationStepUpMaxAge
+	AuthenticationStepUpEnabled    bool          `envconfig:"authentication_step_up_enabled" default:"false"`
+	AuthenticationStepUpScope      string        `envconfig:"authentication_step_up_scope" default:"tenant:admin:destroy"`
+	AuthenticationStepUpMFAMethods []string      `envconfig:"authentication_step_up_mfa_methods" default:"mfa,otp,hwk,totp,webauthn"`
+	AuthenticationStepUpMaxAge     time.Duration `envconfig:"authentication_step_up_max_age" default:"15m"`
+	// AuthenticationFailureThreshold failed authentications from a client IP
+	// within AuthenticationFailureWindow block it until they leave the
+	// window, 0 disables the tracking. At most AuthenticationFailureSources
+	// IPs and subjects are tracked.
+	AuthenticationFailureThreshold int           `envconfig:"authentication_failure_threshold" default:"50"`
+	AuthenticationFailureWindow    time.Duration `envconfig:"authentication_failure_window" default:"1m"`
+	AuthenticationFailureSources   int           `envconfig:"authentication_failure_sources" default:"100000"`
+}
+
+// String formats the spec like %+v, with the values of the fields tagged
+// secret replaced, so that it can be logged.
+func (s EnvSpec) String() string {
+	v := reflect.ValueOf(s)
+	t := v.Type()
+
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		value := fmt.Sprintf("%v", v.Field(i).Interface())
+		if t.Field(i).Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+			value = "[REDACTED]"
+		}
+		fields = append(fields, t.Field(i).Name+":"+value)
+	}
+	return "{" + strings.Join(fields, " ") + "}"
 }
@@ -0,0 +1,33 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+type queryStartKey struct{}
+
+// latencyTracer is a pgx.QueryTracer reporting query durations to a latency observer
+type latencyTracer struct {
+	observer monitoring.LatencyObserverInterface
+}
+
+func (t *latencyTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (t *latencyTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+
+	t.observer.ObserveLatency(monitoring.DatabaseDependency, time.Since(start))
+}
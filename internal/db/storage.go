@@ -12,6 +12,8 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 
@@ -39,6 +41,8 @@ type Config struct {
 	MaxConnLifetime time.Duration
 	MaxConnIdleTime time.Duration
 	TracingEnabled  bool
+	// LatencyObserver optionally receives the duration of every query
+	LatencyObserver monitoring.LatencyObserverInterface
 }
 
 // Offset calculates the offset for pagination based on the provided page parameter and page size.
@@ -158,6 +162,13 @@ func ContextWithTx(ctx context.Context, tx TxInterface) context.Context {
 	return context.WithValue(ctx, txContextKey, tx)
 }
 
+// WithoutTx returns a context whose statements run outside of the
+// transaction of ctx, their writes are kept when it is rolled back.
+func WithoutTx(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, txContextKey, nil)
+	return context.WithValue(ctx, lazyTxContextKey, nil)
+}
+
 // TxFromContext extracts a transaction from the context, returning nil if none exists.
 func TxFromContext(ctx context.Context) TxInterface {
 	if tx, ok := ctx.Value(txContextKey).(TxInterface); ok {
@@ -218,6 +229,12 @@ func (d *DBClient) WithTx(ctx context.Context, fn func(context.Context) error) e
 	return nil
 }
 
+// Ping checks that the database answers a query.
+func (d *DBClient) Ping(ctx context.Context) error {
+	var one int
+	return d.pool.QueryRow(ctx, "SELECT 1").Scan(&one)
+}
+
 func (d *DBClient) Close() {
 	if d.db != nil {
 		_ = d.db.Close()
@@ -235,9 +252,16 @@ func NewDBClient(cfg Config, tracer tracing.TracingInterface, monitor monitoring
 		logger.Fatalf("DSN validation failed, shutting down, err: %v", err)
 	}
 
+	tracers := make([]pgx.QueryTracer, 0)
 	if cfg.TracingEnabled {
 		// otelpgx.NewTracer will use default global TracerProvider, just like our tracer struct
-		config.ConnConfig.Tracer = otelpgx.NewTracer()
+		tracers = append(tracers, otelpgx.NewTracer())
+	}
+	if cfg.LatencyObserver != nil {
+		tracers = append(tracers, &latencyTracer{observer: cfg.LatencyObserver})
+	}
+	if len(tracers) > 0 {
+		config.ConnConfig.Tracer = multitracer.New(tracers...)
 	}
 
 	config.MaxConns = cfg.MaxConns
@@ -0,0 +1,147 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// magic prefixes every encrypted value, it cannot start a value stored before
+// encryption was enabled since those are protobuf messages.
+var magic = []byte("\x00enc1")
+
+var (
+	ErrUnknownKey       = errors.New("unknown encryption key")
+	ErrMalformedValue   = errors.New("malformed encrypted value")
+	ErrEncryptionNotSet = errors.New("value is encrypted but encryption is not configured")
+)
+
+// Cipher seals values with AES-256-GCM. Every value carries the ID of its key
+// followed by a random nonce: magic | len(id) | id | nonce | ciphertext.
+type Cipher struct {
+	keys KeyProviderInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+}
+
+func (c *Cipher) Encrypt(ctx context.Context, plaintext, aad []byte) ([]byte, error) {
+	ctx, span := c.tracer.Start(ctx, "encryption.Cipher.Encrypt")
+	defer span.End()
+
+	key, err := c.keys.ActiveKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(magic)+1+len(key.ID)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, magic...)
+	out = append(out, byte(len(key.ID)))
+	out = append(out, key.ID...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, aad), nil
+}
+
+func (c *Cipher) Decrypt(ctx context.Context, value, aad []byte) ([]byte, error) {
+	ctx, span := c.tracer.Start(ctx, "encryption.Cipher.Decrypt")
+	defer span.End()
+
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	id, rest, err := parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.keys.Key(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrMalformedValue
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (c *Cipher) NeedsRotation(ctx context.Context, value []byte) (bool, error) {
+	if !IsEncrypted(value) {
+		return true, nil
+	}
+
+	id, _, err := parse(value)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := c.keys.ActiveKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	return id != key.ID, nil
+}
+
+// IsEncrypted tells whether a stored value was sealed by a Cipher.
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, magic)
+}
+
+func parse(value []byte) (string, []byte, error) {
+	rest := value[len(magic):]
+	if len(rest) < 1 || len(rest) < 1+int(rest[0]) {
+		return "", nil, ErrMalformedValue
+	}
+	n := int(rest[0])
+	return string(rest[1 : 1+n]), rest[1+n:], nil
+}
+
+func newAEAD(key *Key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Material)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key %s: %w", key.ID, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func NewCipher(keys KeyProviderInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface) *Cipher {
+	c := new(Cipher)
+
+	c.keys = keys
+	c.tracer = tracer
+	c.monitor = monitor
+
+	return c
+}
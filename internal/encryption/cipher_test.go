@@ -0,0 +1,118 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize))
+}
+
+func newTestCipher(t *testing.T, keys, active string) *Cipher {
+	t.Helper()
+
+	p, err := NewStaticKeyProvider(keys, active)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewCipher(p, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("", logging.NewNoopLogger()))
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCipher(t, "k1:"+testKey(1), "k1")
+
+	sealed, err := c.Encrypt(ctx, []byte("secret"), []byte("row-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !IsEncrypted(sealed) || bytes.Contains(sealed, []byte("secret")) {
+		t.Fatalf("expected an encrypted value, got %q", sealed)
+	}
+
+	plaintext, err := c.Decrypt(ctx, sealed, []byte("row-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(plaintext) != "secret" {
+		t.Fatalf("expected secret, got %q", plaintext)
+	}
+
+	// a value copied to another row does not decrypt
+	if _, err := c.Decrypt(ctx, sealed, []byte("row-2")); err == nil {
+		t.Fatal("expected error with a different aad")
+	}
+
+	// values stored before encryption was enabled are read as they are
+	plaintext, err = c.Decrypt(ctx, []byte("legacy"), nil)
+	if err != nil || string(plaintext) != "legacy" {
+		t.Fatalf("expected legacy value, got %q, %v", plaintext, err)
+	}
+}
+
+func TestCipherRotation(t *testing.T) {
+	ctx := context.Background()
+	old := newTestCipher(t, "k1:"+testKey(1), "k1")
+	rotated := newTestCipher(t, "k1:"+testKey(1)+",k2:"+testKey(2), "k2")
+
+	sealed, err := old.Encrypt(ctx, []byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rotate, _ := rotated.NeedsRotation(ctx, sealed); !rotate {
+		t.Fatal("expected a value sealed with the old key to need rotation")
+	}
+	if plaintext, err := rotated.Decrypt(ctx, sealed, nil); err != nil || string(plaintext) != "secret" {
+		t.Fatalf("expected the old key to decrypt, got %q, %v", plaintext, err)
+	}
+
+	resealed, err := rotated.Encrypt(ctx, []byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotate, _ := rotated.NeedsRotation(ctx, resealed); rotate {
+		t.Fatal("expected a value sealed with the active key to be up to date")
+	}
+
+	// dropping the old key before re-encrypting loses access
+	if _, err := newTestCipher(t, "k2:"+testKey(2), "k2").Decrypt(ctx, sealed, nil); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+	if _, err := NewNoopCipher().Decrypt(ctx, sealed, nil); !errors.Is(err, ErrEncryptionNotSet) {
+		t.Fatalf("expected encryption not set error, got %v", err)
+	}
+}
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	testCases := []struct {
+		name   string
+		keys   string
+		active string
+	}{
+		{name: "missing active key", keys: "k1:" + testKey(1), active: "k2"},
+		{name: "short key", keys: "k1:" + base64.StdEncoding.EncodeToString([]byte("short")), active: "k1"},
+		{name: "not base64", keys: "k1:***", active: "k1"},
+		{name: "missing id", keys: testKey(1), active: "k1"},
+		{name: "duplicate id", keys: "k1:" + testKey(1) + ",k1:" + testKey(2), active: "k1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewStaticKeyProvider(tc.keys, tc.active); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package encryption
+
+import (
+	"context"
+)
+
+// KeyProviderInterface resolves the data encryption keys, a KMS backed
+// provider implements it to keep the key material out of the configuration.
+type KeyProviderInterface interface {
+	// ActiveKey returns the key new values are encrypted with.
+	ActiveKey(ctx context.Context) (*Key, error)
+	// Key returns a key by ID, older keys are kept to decrypt existing values.
+	Key(ctx context.Context, id string) (*Key, error)
+}
+
+// CipherInterface encrypts individual fields before they are stored.
+type CipherInterface interface {
+	// Encrypt seals plaintext bound to aad, the same aad is required to decrypt it.
+	Encrypt(ctx context.Context, plaintext, aad []byte) ([]byte, error)
+	// Decrypt opens a value sealed by Encrypt, values stored before encryption
+	// was enabled are returned as they are.
+	Decrypt(ctx context.Context, value, aad []byte) ([]byte, error)
+	// NeedsRotation tells whether a stored value is not sealed with the active key.
+	NeedsRotation(ctx context.Context, value []byte) (bool, error)
+}
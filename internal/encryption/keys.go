@@ -0,0 +1,101 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// KeySize is the size of the AES-256 keys.
+const KeySize = 32
+
+// maxKeyIDLength bounds the key ID stored in front of every value.
+const maxKeyIDLength = 255
+
+// Key is a data encryption key.
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+// StaticKeyProvider serves keys read from the configuration.
+type StaticKeyProvider struct {
+	keys   map[string]*Key
+	active string
+}
+
+func (p *StaticKeyProvider) ActiveKey(ctx context.Context) (*Key, error) {
+	return p.Key(ctx, p.active)
+}
+
+func (p *StaticKeyProvider) Key(_ context.Context, id string) (*Key, error) {
+	k, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	return k, nil
+}
+
+// NewStaticKeyProvider parses a comma separated list of id:base64 keys, the
+// active key must be one of them. Rotating a key means adding the new one,
+// making it active and keeping the old one until the values are re-encrypted.
+func NewStaticKeyProvider(keys, activeID string) (*StaticKeyProvider, error) {
+	p := new(StaticKeyProvider)
+	p.keys = make(map[string]*Key)
+	p.active = activeID
+
+	for _, entry := range strings.Split(keys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid encryption key entry, expected id:base64")
+		}
+		if len(id) > maxKeyIDLength {
+			return nil, fmt.Errorf("encryption key ID %s is longer than %d characters", id, maxKeyIDLength)
+		}
+		if _, ok := p.keys[id]; ok {
+			return nil, fmt.Errorf("duplicate encryption key ID %s", id)
+		}
+
+		material, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %s: %w", id, err)
+		}
+		if len(material) != KeySize {
+			return nil, fmt.Errorf("encryption key %s must be %d bytes, got %d", id, KeySize, len(material))
+		}
+
+		p.keys[id] = &Key{ID: id, Material: material}
+	}
+
+	if _, ok := p.keys[activeID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q is not configured", activeID)
+	}
+
+	return p, nil
+}
+
+// NewCipherFromConfig builds a Cipher from the configured keys, values are
+// stored in clear when no key is configured.
+func NewCipherFromConfig(keys, activeID string, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface) (CipherInterface, error) {
+	if strings.TrimSpace(keys) == "" {
+		return NewNoopCipher(), nil
+	}
+
+	p, err := NewStaticKeyProvider(keys, activeID)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(p, tracer, monitor), nil
+}
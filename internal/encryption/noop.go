@@ -0,0 +1,30 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package encryption
+
+import (
+	"context"
+)
+
+// NoopCipher stores values in clear, it is used while no key is configured.
+type NoopCipher struct{}
+
+func (c *NoopCipher) Encrypt(_ context.Context, plaintext, _ []byte) ([]byte, error) {
+	return plaintext, nil
+}
+
+func (c *NoopCipher) Decrypt(_ context.Context, value, _ []byte) ([]byte, error) {
+	if IsEncrypted(value) {
+		return nil, ErrEncryptionNotSet
+	}
+	return value, nil
+}
+
+func (c *NoopCipher) NeedsRotation(context.Context, []byte) (bool, error) {
+	return false, nil
+}
+
+func NewNoopCipher() *NoopCipher {
+	return new(NoopCipher)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package outbound
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Config describes how connections to Kratos, Hydra and OpenFGA are established.
+// Empty fields keep the defaults of http.DefaultTransport, which already
+// honours HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+type Config struct {
+	// CAFile is a PEM bundle trusted on top of the system roots
+	CAFile string
+	// CertFile and KeyFile hold the client certificate presented for mutual TLS
+	CertFile string
+	KeyFile  string
+	// ProxyURL overrides the proxy taken from the environment
+	ProxyURL string
+}
+
+func NewConfig(caFile, certFile, keyFile, proxyURL string) *Config {
+	c := new(Config)
+
+	c.CAFile = caFile
+	c.CertFile = certFile
+	c.KeyFile = keyFile
+	c.ProxyURL = proxyURL
+
+	return c
+}
+
+// NewTransport clones http.DefaultTransport and applies the TLS and proxy settings of cfg.
+func NewTransport(cfg *Config) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		u, err := url.Parse(cfg.ProxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.ProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %v", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("client certificate and key must be provided together")
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	t.TLSClientConfig = tlsConfig
+
+	return t, nil
+}
+
+// NewClient returns an otel instrumented HTTP client using NewTransport.
+func NewClient(cfg *Config) (*http.Client, error) {
+	t, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{Transport: otelhttp.NewTransport(t)}, nil
+}
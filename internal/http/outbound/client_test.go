@@ -0,0 +1,90 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package outbound
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewClientCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// the test server certificate is self signed, so it is not trusted by default
+	c, err := NewClient(NewConfig("", "", "", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get(server.URL); err == nil {
+		t.Fatal("expected an unknown authority error")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, ca, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = NewClient(NewConfig(caFile, "", "", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA bundle to be trusted, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewClientProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	c, err := NewClient(NewConfig("", "", "", proxy.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := c.Get("http://kratos.invalid/admin/identities")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://kratos.invalid/admin/identities" {
+		t.Fatalf("expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestNewTransportInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{name: "Invalid proxy URL", cfg: NewConfig("", "", "", "proxy:3128")},
+		{name: "Missing CA file", cfg: NewConfig(filepath.Join(t.TempDir(), "missing.pem"), "", "", "")},
+		{name: "Certificate without key", cfg: NewConfig("", "client.pem", "", "")},
+		{name: "Key without certificate", cfg: NewConfig("", "", "client.key", "")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewTransport(test.cfg); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
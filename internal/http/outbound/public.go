@@ -0,0 +1,111 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// ErrNotPublic is returned for the destinations resolving to an address that
+// is not public, such as the loopback, the cloud metadata endpoints or the
+// private networks of the deployment.
+var ErrNotPublic = errors.New("destination is not a public address")
+
+// ResolverInterface resolves the host names of the destinations,
+// net.DefaultResolver implements it.
+type ResolverInterface interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// IsPublic reports whether ip can be dialed on behalf of a tenant, it is not
+// loopback, link-local, private, unspecified nor multicast.
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified()
+}
+
+// CheckPublicURL resolves the host of rawURL and fails with ErrNotPublic
+// unless every one of its addresses is public.
+func CheckPublicURL(ctx context.Context, resolver ResolverInterface, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	addrs := []netip.Addr{}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, ip)
+	} else if addrs, err = resolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("failed to resolve %s: no address", host)
+	}
+	for _, ip := range addrs {
+		if !IsPublic(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNotPublic, host, ip)
+		}
+	}
+	return nil
+}
+
+// NewPublicClient returns an otel instrumented HTTP client, with the TLS
+// settings of cfg, for the destinations chosen by the tenants. It only dials
+// public addresses, checked once resolved so that a host cannot be rebound
+// to another address after CheckPublicURL, and returns the redirects as
+// errors. It never goes through a proxy, which would hide the destination
+// from the check.
+func NewPublicClient(cfg *Config) (*http.Client, error) {
+	return newPublicClient(cfg, IsPublic)
+}
+
+func newPublicClient(cfg *Config, allowed func(netip.Addr) bool) (*http.Client, error) {
+	t, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrNotPublic, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	t.Proxy = nil
+	t.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: otelhttp.NewTransport(t),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return fmt.Errorf("redirect to %s not followed", req.URL.Redacted())
+		},
+	}, nil
+}
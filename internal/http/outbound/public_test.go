@@ -0,0 +1,101 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package outbound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+type testResolver map[string][]netip.Addr
+
+func (r testResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestCheckPublicURL(t *testing.T) {
+	resolver := testResolver{
+		"hooks.example.com":    {netip.MustParseAddr("93.184.215.14")},
+		"internal.example.com": {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("10.0.0.7")},
+		"rebound.example.com":  {netip.MustParseAddr("127.0.0.1")},
+	}
+
+	tests := []struct {
+		url      string
+		expected error
+		allowed  bool
+	}{
+		{url: "https://hooks.example.com/events", allowed: true},
+		{url: "https://93.184.215.14:8443/events", allowed: true},
+		{url: "http://127.0.0.1:8080/", expected: ErrNotPublic},
+		{url: "http://[::1]/", expected: ErrNotPublic},
+		{url: "http://169.254.169.254/latest/meta-data/", expected: ErrNotPublic},
+		{url: "http://[fe80::1]/", expected: ErrNotPublic},
+		{url: "http://10.1.2.3/", expected: ErrNotPublic},
+		{url: "http://172.16.0.1/", expected: ErrNotPublic},
+		{url: "http://192.168.1.1/", expected: ErrNotPublic},
+		{url: "http://[fd00::1]/", expected: ErrNotPublic},
+		{url: "http://0.0.0.0/", expected: ErrNotPublic},
+		{url: "http://[::ffff:127.0.0.1]/", expected: ErrNotPublic},
+		{url: "https://internal.example.com/", expected: ErrNotPublic},
+		{url: "https://rebound.example.com/", expected: ErrNotPublic},
+		{url: "https://unknown.example.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckPublicURL(context.Background(), resolver, tt.url)
+			switch {
+			case tt.allowed && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case !tt.allowed && err == nil:
+				t.Fatal("expected the URL to be refused")
+			case tt.expected != nil && !errors.Is(err, tt.expected):
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestNewPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewPublicClient(NewConfig("", "", "", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get(server.URL); !errors.Is(err, ErrNotPublic) {
+		t.Fatalf("expected the loopback server not to be dialed, got %v", err)
+	}
+}
+
+func TestNewPublicClientRedirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the redirect not to be followed")
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer server.Close()
+
+	// the test servers listen on the loopback
+	c, err := newPublicClient(NewConfig("", "", "", ""), func(netip.Addr) bool { return true })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get(server.URL); err == nil {
+		t.Fatal("expected the redirect to be refused")
+	}
+}
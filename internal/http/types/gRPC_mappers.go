@@ -5,36 +5,195 @@ package types
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
-	v0Types "github.com/canonical/identity-platform-api/v0/http"
 	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
 )
 
-// ForwardErrorResponseRewriter rewrites error message to comply with Admin UI
-// standard json response for errors. It doesn't do anything on other messages
+// ProblemContentType is the media type of the error responses of the API.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body of the error responses of the API. Code is
+// stable for clients to match on: the reason of the ErrorInfo detail when
+// there is one, the name of the gRPC code otherwise, e.g. NOT_FOUND. Message
+// repeats Detail for the clients of the former error body.
+type Problem struct {
+	Type             string            `json:"type"`
+	Title            string            `json:"title"`
+	Status           int               `json:"status"`
+	Detail           string            `json:"detail,omitempty"`
+	Instance         string            `json:"instance,omitempty"`
+	Code             string            `json:"code"`
+	RequestID        string            `json:"request_id,omitempty"`
+	Message          string            `json:"message"`
+	LocalizedMessage string            `json:"localized_message,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Errors           []ProblemField    `json:"errors,omitempty"`
+}
+
+// ProblemField is a field of the request failing validation.
+type ProblemField struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type requestContextKey struct{}
+
+// ProblemErrorHandler is the gateway error handler writing Problem bodies
+// as application/problem+json, along with ProblemResponseRewriter. Headers,
+// trailers and statuses are handled by runtime.DefaultHTTPErrorHandler.
 // usage example:
 //
 // mux := runtime.NewServeMux(
 //
-//	runtime.WithForwardResponseRewriter(ForwardErrorResponseRewriter),
+//	runtime.WithErrorHandler(ProblemErrorHandler),
+//	runtime.WithForwardResponseRewriter(ProblemResponseRewriter),
 //
 // )
-func ForwardErrorResponseRewriter(_ context.Context, response proto.Message) (any, error) {
-	codeError, ok := response.(*rpcStatus.Status)
+//
+// The internal errors of the requests past their deadline are reported as
+// DeadlineExceeded, they are the outcome of the timeout.
+func ProblemErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		switch status.Code(err) {
+		case codes.Internal, codes.Unknown, codes.Canceled:
+			err = status.Error(codes.DeadlineExceeded, "request timed out")
+		}
+	}
+
+	ctx = context.WithValue(ctx, requestContextKey{}, r)
+	runtime.DefaultHTTPErrorHandler(ctx, mux, &problemMarshaler{marshaler}, w, r, err)
+}
+
+// ProblemResponseRewriter rewrites the gRPC statuses of the failed requests
+// to Problem bodies. It doesn't do anything on other messages.
+func ProblemResponseRewriter(ctx context.Context, response proto.Message) (any, error) {
+	st, ok := response.(*rpcStatus.Status)
 	if !ok {
 		return response, nil
 	}
 
-	httpStatus := runtime.HTTPStatusFromCode(
-		codes.Code(codeError.Code),
-	)
+	code := codes.Code(st.GetCode())
+	httpStatus := runtime.HTTPStatusFromCode(code)
 
-	return &v0Types.ErrorResponse{
-		Status:  int32(httpStatus),
-		Message: codeError.GetMessage(),
-	}, nil
+	p := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(httpStatus),
+		Status:    httpStatus,
+		Detail:    st.GetMessage(),
+		Code:      codeName(code),
+		RequestID: middleware.GetReqID(ctx),
+		Message:   st.GetMessage(),
+	}
+
+	r, _ := ctx.Value(requestContextKey{}).(*http.Request)
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+
+	var localized []*errdetails.LocalizedMessage
+	for _, a := range st.GetDetails() {
+		detail, err := a.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			p.Code = d.GetReason()
+			p.Metadata = d.GetMetadata()
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				p.Errors = append(p.Errors, ProblemField{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.LocalizedMessage:
+			localized = append(localized, d)
+		}
+	}
+
+	if len(localized) > 0 && r != nil {
+		p.LocalizedMessage = localizedMessage(localized, r.Header.Get("Accept-Language"))
+	}
+
+	return p, nil
+}
+
+// WriteProblem answers r with httpStatus and the Problem of a status of code
+// and message, for the middlewares rejecting calls before the gateway.
+func WriteProblem(w http.ResponseWriter, r *http.Request, httpStatus int, code codes.Code, message string) {
+	ctx := context.WithValue(r.Context(), requestContextKey{}, r)
+	rewritten, _ := ProblemResponseRewriter(ctx, status.New(code, message).Proto())
+	p := rewritten.(*Problem)
+	p.Status, p.Title = httpStatus, http.StatusText(httpStatus)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// codeName returns the name of code as in the gRPC specification, such as
+// NOT_FOUND.
+func codeName(code codes.Code) string {
+	var b strings.Builder
+	lower := false
+	for _, c := range code.String() {
+		upper := c >= 'A' && c <= 'Z'
+		if upper && lower {
+			b.WriteByte('_')
+		}
+		lower = !upper
+		b.WriteRune(c)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// localizedMessage returns the message of the locale acceptLanguage prefers,
+// empty when none of them is accepted.
+func localizedMessage(messages []*errdetails.LocalizedMessage, acceptLanguage string) string {
+	accepted, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(accepted) == 0 {
+		return ""
+	}
+
+	tags := make([]language.Tag, 0, len(messages))
+	for _, m := range messages {
+		tags = append(tags, language.Make(m.GetLocale()))
+	}
+
+	_, i, confidence := language.NewMatcher(tags).Match(accepted...)
+	if confidence == language.No {
+		return ""
+	}
+	return messages[i].GetMessage()
+}
+
+// problemMarshaler writes the Problem bodies as JSON, leaving the other
+// values to the marshaler of the gateway.
+type problemMarshaler struct {
+	runtime.Marshaler
+}
+
+func (m *problemMarshaler) ContentType(v any) string {
+	if _, ok := v.(*Problem); ok {
+		return ProblemContentType
+	}
+	return m.Marshaler.ContentType(v)
+}
+
+func (m *problemMarshaler) Marshal(v any) ([]byte, error) {
+	if p, ok := v.(*Problem); ok {
+		return json.Marshal(p)
+	}
+	return m.Marshaler.Marshal(v)
 }
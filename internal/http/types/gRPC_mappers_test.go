@@ -5,31 +5,91 @@ package types
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
-	v0Types "github.com/canonical/identity-platform-api/v0/http"
 	v0Roles "github.com/canonical/identity-platform-api/v0/roles"
-	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
 )
 
-func TestForwardErrorResponseRewriter(t *testing.T) {
+func detailedStatus(t *testing.T, code codes.Code, msg string, details ...protoadapt.MessageV1) proto.Message {
+	st, err := status.New(code, msg).WithDetails(details...)
+	if err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	return st.Proto()
+}
+
+func TestProblemResponseRewriter(t *testing.T) {
 	untouchedResponse := &v0Roles.ListRolesResp{}
 
 	tests := []struct {
-		name     string
-		response proto.Message
-		expected any
+		name           string
+		response       proto.Message
+		acceptLanguage string
+		expected       any
 	}{
 		{
-			name:     "Valid grpc status",
-			response: &rpcStatus.Status{Code: int32(codes.NotFound), Message: "Resource not found"},
-			expected: &v0Types.ErrorResponse{
-				Status:  int32(http.StatusNotFound),
-				Message: "Resource not found",
+			name:     "Status",
+			response: status.New(codes.NotFound, "tenant not found").Proto(),
+			expected: &Problem{
+				Type:      "about:blank",
+				Title:     "Not Found",
+				Status:    http.StatusNotFound,
+				Detail:    "tenant not found",
+				Instance:  "/api/v0/tenants/1",
+				Code:      "NOT_FOUND",
+				RequestID: "req-1",
+				Message:   "tenant not found",
+			},
+		},
+		{
+			name: "Status with an error reason",
+			response: detailedStatus(t, codes.PermissionDenied, "step-up required",
+				&errdetails.ErrorInfo{Reason: "STEP_UP_REQUIRED", Domain: "tenant-service", Metadata: map[string]string{"max_age": "900"}},
+			),
+			expected: &Problem{
+				Type:      "about:blank",
+				Title:     "Forbidden",
+				Status:    http.StatusForbidden,
+				Detail:    "step-up required",
+				Instance:  "/api/v0/tenants/1",
+				Code:      "STEP_UP_REQUIRED",
+				RequestID: "req-1",
+				Message:   "step-up required",
+				Metadata:  map[string]string{"max_age": "900"},
+			},
+		},
+		{
+			name: "Status with field violations and localized messages",
+			response: detailedStatus(t, codes.InvalidArgument, "invalid request",
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "tenant_id", Description: "must be a UUID"}}},
+				&errdetails.LocalizedMessage{Locale: "en-US", Message: "invalid request"},
+				&errdetails.LocalizedMessage{Locale: "fr-FR", Message: "requête invalide"},
+			),
+			acceptLanguage: "fr-CH, fr;q=0.9, en;q=0.8",
+			expected: &Problem{
+				Type:             "about:blank",
+				Title:            "Bad Request",
+				Status:           http.StatusBadRequest,
+				Detail:           "invalid request",
+				Instance:         "/api/v0/tenants/1",
+				Code:             "INVALID_ARGUMENT",
+				RequestID:        "req-1",
+				Message:          "invalid request",
+				LocalizedMessage: "requête invalide",
+				Errors:           []ProblemField{{Field: "tenant_id", Description: "must be a UUID"}},
 			},
 		},
 		{
@@ -41,10 +101,80 @@ func TestForwardErrorResponseRewriter(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result, _ := ForwardErrorResponseRewriter(context.Background(), test.response)
+			r := httptest.NewRequest(http.MethodGet, "/api/v0/tenants/1", nil)
+			r.Header.Set("Accept-Language", test.acceptLanguage)
+
+			ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+			ctx = context.WithValue(ctx, requestContextKey{}, r)
+
+			result, _ := ProblemResponseRewriter(ctx, test.response)
 
 			if !reflect.DeepEqual(result, test.expected) {
-				t.Errorf("expected result: %v, got: %v", test.expected, result)
+				t.Errorf("expected result: %+v, got: %+v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestProblemErrorHandler(t *testing.T) {
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(ProblemErrorHandler),
+		runtime.WithForwardResponseRewriter(ProblemResponseRewriter),
+	)
+
+	tests := []struct {
+		name           string
+		err            error
+		timedOut       bool
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "Status",
+			err:            status.Error(codes.AlreadyExists, "tenant exists"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "ALREADY_EXISTS",
+		},
+		{
+			name:           "Internal error past the deadline",
+			err:            status.Error(codes.Internal, "failed to list tenants"),
+			timedOut:       true,
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   "DEADLINE_EXCEEDED",
+		},
+		{
+			name:           "Error without status",
+			err:            errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "UNKNOWN",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/v0/tenants", nil)
+			if test.timedOut {
+				ctx, cancel := context.WithDeadline(r.Context(), time.Now())
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			w := httptest.NewRecorder()
+
+			ProblemErrorHandler(r.Context(), mux, &runtime.JSONPb{}, w, r, test.err)
+
+			if w.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("expected content type %s, got %s", ProblemContentType, ct)
+			}
+
+			p := new(Problem)
+			if err := json.Unmarshal(w.Body.Bytes(), p); err != nil {
+				t.Fatalf("expected error to be nil got %v", err)
+			}
+			if p.Code != test.expectedCode || p.Status != test.expectedStatus || p.Instance != "/api/v0/tenants" {
+				t.Errorf("unexpected problem %+v", p)
 			}
 		})
 	}
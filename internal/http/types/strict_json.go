@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// StrictJSONPb rejects request bodies holding fields unknown to the request
+// message, the error names the path of the first one, e.g. users[0].tennant_id.
+type StrictJSONPb struct {
+	*runtime.JSONPb
+}
+
+func (m *StrictJSONPb) Unmarshal(data []byte, v any) error {
+	err := m.JSONPb.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	if msg, ok := v.(proto.Message); ok {
+		var document any
+		if json.Unmarshal(data, &document) == nil {
+			if path := unknownField(document, msg.ProtoReflect().Descriptor(), ""); path != "" {
+				return fmt.Errorf("unknown field %q", path)
+			}
+		}
+	}
+	return err
+}
+
+func (m *StrictJSONPb) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v any) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		// the gateway accepts empty bodies
+		if len(bytes.TrimSpace(data)) == 0 {
+			return io.EOF
+		}
+		return m.Unmarshal(data, v)
+	})
+}
+
+// NewStrictJSONPb returns a marshaler rejecting unknown fields, m must not
+// discard them.
+func NewStrictJSONPb(m *runtime.JSONPb) *StrictJSONPb {
+	m.UnmarshalOptions.DiscardUnknown = false
+
+	return &StrictJSONPb{JSONPb: m}
+}
+
+// unknownField returns the path of the first field of document, in key
+// order, that md does not define, or an empty string.
+func unknownField(document any, md protoreflect.MessageDescriptor, prefix string) string {
+	object, ok := document.(map[string]any)
+	if !ok || md.FullName().Parent() == "google.protobuf" {
+		// well-known types have their own JSON mapping
+		return ""
+	}
+
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		fd := md.Fields().ByJSONName(k)
+		if fd == nil {
+			fd = md.Fields().ByTextName(k)
+		}
+		if fd == nil {
+			return path
+		}
+		if fd.Message() == nil {
+			continue
+		}
+
+		switch value := object[k].(type) {
+		case []any:
+			if !fd.IsList() {
+				continue
+			}
+			for i, e := range value {
+				if p := unknownField(e, fd.Message(), path+"["+strconv.Itoa(i)+"]"); p != "" {
+					return p
+				}
+			}
+		case map[string]any:
+			if fd.IsMap() {
+				if fd.MapValue().Message() == nil {
+					continue
+				}
+				entries := make([]string, 0, len(value))
+				for key := range value {
+					entries = append(entries, key)
+				}
+				slices.Sort(entries)
+				for _, key := range entries {
+					if p := unknownField(value[key], fd.MapValue().Message(), path+"["+key+"]"); p != "" {
+						return p
+					}
+				}
+				continue
+			}
+			if p := unknownField(value, fd.Message(), path); p != "" {
+				return p
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package types
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	v0Roles "github.com/canonical/identity-platform-api/v0/roles"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestStrictJSONPb(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr string
+		eof         bool
+	}{
+		{
+			name: "Known fields",
+			body: `{"data":["admin"],"status":200,"_meta":{"size":1}}`,
+		},
+		{
+			name:        "Unknown field",
+			body:        `{"data":["admin"],"statsu":200}`,
+			expectedErr: `unknown field "statsu"`,
+		},
+		{
+			name:        "Unknown nested field",
+			body:        `{"status":200,"_meta":{"szie":1}}`,
+			expectedErr: `unknown field "_meta.szie"`,
+		},
+		{
+			name:        "Invalid value",
+			body:        `{"status":"ok"}`,
+			expectedErr: "invalid value",
+		},
+		{
+			name: "Empty body",
+			body: " ",
+			eof:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewStrictJSONPb(&runtime.JSONPb{UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true}})
+
+			resp := new(v0Roles.ListRolesResp)
+			err := m.NewDecoder(strings.NewReader(test.body)).Decode(resp)
+
+			switch {
+			case test.eof:
+				if !errors.Is(err, io.EOF) {
+					t.Fatalf("expected io.EOF, got %v", err)
+				}
+			case test.expectedErr == "":
+				if err != nil {
+					t.Fatalf("expected error to be nil got %v", err)
+				}
+				if resp.GetStatus() != 200 || len(resp.GetData()) != 1 {
+					t.Fatalf("expected the body to be decoded, got %v", resp)
+				}
+			default:
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", test.expectedErr, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// StorageInterface defines the storage operations required by the job queue.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CreateJob(ctx context.Context, kind string, payload []byte) (*types.Job, error)
+	ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (*types.Job, error)
+	DeleteJob(ctx context.Context, id string) error
+	RetryJob(ctx context.Context, id string, runAt time.Time, lastError string) error
+	FailJob(ctx context.Context, id string, lastError string) error
+}
+
+// TxRunnerInterface runs a function in a database transaction, see
+// db.DBClient.WithTx.
+type TxRunnerInterface interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,322 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// Handler runs an attempt of a job from its payload, an error retries the
+// job later. Jobs may run more than once, handlers must be idempotent.
+type Handler func(ctx context.Context, payload []byte) error
+
+// FailureHandler is called with the payload of a job out of attempts and
+// the error of its last attempt, outside of any transaction.
+type FailureHandler func(ctx context.Context, payload []byte, err error)
+
+// retryError is returned by the handlers whose failed attempt must keep its
+// writes, see Retry.
+type retryError struct {
+	err error
+}
+
+func (e *retryError) Error() string { return e.err.Error() }
+func (e *retryError) Unwrap() error { return e.err }
+
+// Retry wraps the error of a failed attempt whose writes are committed
+// anyway, such as the record of the attempt, the job is still retried.
+func Retry(err error) error {
+	return &retryError{err: err}
+}
+
+// Config tunes the workers of a Queue.
+type Config struct {
+	// Workers is the number of jobs the replica runs at once.
+	Workers int
+	// PollInterval is the wait between two looks for due jobs when idle.
+	PollInterval time.Duration
+	// Timeout bounds an attempt, the job is leased to its worker as long.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts before a job is failed.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled on every retry
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Queue runs the jobs persisted in the database with a pool of workers. The
+// jobs are shared by every replica, a job is run by one worker at a time and
+// removed once it succeeds. Failed attempts are retried with an exponential
+// backoff until the job is out of attempts.
+type Queue struct {
+	handlers map[string]Handler
+	failures map[string]FailureHandler
+	mu       sync.RWMutex
+
+	config Config
+	wake   chan struct{}
+	now    func() time.Time
+
+	storage StorageInterface
+	db      TxRunnerInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Handle registers the handler of the jobs of the given kind.
+func (q *Queue) Handle(kind string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.handlers[kind] = h
+}
+
+// HandleFailure registers the handler of the jobs of the given kind that
+// run out of attempts.
+func (q *Queue) HandleFailure(kind string, h FailureHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.failures[kind] = h
+}
+
+// Enqueue persists a job of the given kind, payload is encoded to JSON. When
+// ctx holds a transaction the job is only run once it commits.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any) error {
+	ctx, span := q.tracer.Start(ctx, "jobs.Queue.Enqueue")
+	defer span.End()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s job: %w", kind, err)
+	}
+
+	job, err := q.storage.CreateJob(ctx, kind, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	q.logger.Debugw("job enqueued", "job_id", job.ID, "kind", kind)
+	q.countJob("job_enqueued")
+
+	// an idle worker looks for the job right away, the others keep polling
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run runs the workers until ctx is done, then waits for the attempts still
+// running, each bounded by the Timeout. The attempts cut short by the exit of
+// the process are retried once their lease expires, by another replica.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range q.config.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context) {
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// drains the due jobs before waiting
+		for q.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and runs a due job, it reports whether there was one.
+func (q *Queue) runNext(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job, err := q.storage.ClaimJob(ctx, q.now(), q.config.Timeout)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			q.logger.Errorw("failed to claim job", "error", err)
+		}
+		return false
+	}
+
+	// a claimed job runs to its end, the queue stopping only stops claiming
+	q.run(context.WithoutCancel(ctx), job)
+	return true
+}
+
+func (q *Queue) run(ctx context.Context, job *types.Job) {
+	ctx, span := q.tracer.Start(ctx, "jobs.Queue.run")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("job.kind", job.Kind),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+
+	err := q.attempt(ctx, job)
+
+	// the outcome is recorded even when the queue is stopping
+	ctx = context.WithoutCancel(ctx)
+
+	if err == nil {
+		// the job runs again once its lease expires if it cannot be removed
+		if err := q.storage.DeleteJob(ctx, job.ID); err != nil {
+			q.logger.Errorw("failed to remove completed job", "job_id", job.ID, "kind", job.Kind, "error", err)
+		}
+		q.countJob("job_succeeded")
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	if job.Attempts >= q.config.MaxAttempts {
+		q.logger.Errorw("job failed, out of attempts",
+			"job_id", job.ID,
+			"kind", job.Kind,
+			"attempts", job.Attempts,
+			"error", err,
+		)
+		if err := q.storage.FailJob(ctx, job.ID, err.Error()); err != nil {
+			q.logger.Errorw("failed to record failed job", "job_id", job.ID, "kind", job.Kind, "error", err)
+		}
+		q.mu.RLock()
+		failed, ok := q.failures[job.Kind]
+		q.mu.RUnlock()
+		if ok {
+			failed(ctx, job.Payload, err)
+		}
+		q.countJob("job_failed")
+		return
+	}
+
+	delay := q.backoff(job.Attempts)
+	q.logger.Warnw("job attempt failed, retrying",
+		"job_id", job.ID,
+		"kind", job.Kind,
+		"attempt", job.Attempts,
+		"retry_in", delay,
+		"error", err,
+	)
+	if err := q.storage.RetryJob(ctx, job.ID, q.now().Add(delay), err.Error()); err != nil {
+		q.logger.Errorw("failed to schedule job retry", "job_id", job.ID, "kind", job.Kind, "error", err)
+	}
+	q.countJob("job_retried")
+}
+
+// attempt runs the handler of job in a transaction, so that the writes of a
+// failed attempt are rolled back and the retry starts over, unless the
+// handler failed with Retry.
+func (q *Queue) attempt(ctx context.Context, job *types.Job) (err error) {
+	q.mu.RLock()
+	h, ok := q.handlers[job.Kind]
+	q.mu.RUnlock()
+	if !ok {
+		// another replica may know the kind, it is retried as any failure
+		return fmt.Errorf("no handler for %s jobs", job.Kind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.config.Timeout)
+	defer cancel()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			q.logger.Errorw("job panicked", "job_id", job.ID, "kind", job.Kind, "panic", rec, "stack", string(debug.Stack()))
+			err = fmt.Errorf("job panicked: %v", rec)
+		}
+	}()
+
+	var retry *retryError
+	if err := q.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := h(ctx, job.Payload); !errors.As(err, &retry) {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if retry != nil {
+		return retry.err
+	}
+	return nil
+}
+
+// backoff returns the wait before the retry following the given attempt.
+func (q *Queue) backoff(attempts int) time.Duration {
+	d := q.config.Backoff
+	for i := 1; i < attempts && d < q.config.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, q.config.MaxBackoff)
+}
+
+func (q *Queue) countJob(operation string) {
+	if err := q.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		q.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewQueue returns a queue of the jobs held by storage, run in the
+// transactions of db.
+func NewQueue(
+	config Config,
+	storage StorageInterface,
+	db TxRunnerInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Queue {
+	q := new(Queue)
+	q.handlers = make(map[string]Handler)
+	q.failures = make(map[string]FailureHandler)
+	q.config = config
+	q.wake = make(chan struct{}, 1)
+	q.now = time.Now
+	q.storage = storage
+	q.db = db
+	q.tracer = tracer
+	q.monitor = monitor
+	q.logger = logger
+
+	return q
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package jobs -destination ./mock_jobs.go -source=./interfaces.go
+
+var testConfig = Config{
+	Workers:      1,
+	PollInterval: time.Second,
+	Timeout:      time.Minute,
+	MaxAttempts:  3,
+	Backoff:      time.Second,
+	MaxBackoff:   5 * time.Second,
+}
+
+func newTestQueue(ctrl *gomock.Controller) (*Queue, *MockStorageInterface) {
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockDB := NewMockTxRunnerInterface(ctrl)
+	mockDB.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	).AnyTimes()
+
+	logger := logging.NewNoopLogger()
+	q := NewQueue(testConfig, mockStorage, mockDB, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("test", logger), logger)
+	return q, mockStorage
+}
+
+func TestQueue_RunNext(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		attempts   int
+		handlerErr error
+		panics     bool
+		setupMocks func(*MockStorageInterface)
+	}{
+		{
+			name:     "success removes the job",
+			attempts: 1,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().DeleteJob(gomock.Any(), "job-1").Return(nil)
+			},
+		},
+		{
+			name:       "failure is retried with backoff",
+			attempts:   2,
+			handlerErr: errors.New("openfga unavailable"),
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().RetryJob(gomock.Any(), "job-1", now.Add(2*time.Second), "openfga unavailable").Return(nil)
+			},
+		},
+		{
+			name:       "failure keeping its writes is retried",
+			attempts:   1,
+			handlerErr: Retry(errors.New("target unreachable")),
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().RetryJob(gomock.Any(), "job-1", now.Add(time.Second), "target unreachable").Return(nil)
+			},
+		},
+		{
+			name:     "panic is retried",
+			attempts: 1,
+			panics:   true,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().RetryJob(gomock.Any(), "job-1", now.Add(time.Second), "job panicked: boom").Return(nil)
+			},
+		},
+		{
+			name:       "last attempt fails the job",
+			attempts:   3,
+			handlerErr: errors.New("openfga unavailable"),
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().FailJob(gomock.Any(), "job-1", "openfga unavailable").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			q, mockStorage := newTestQueue(ctrl)
+			q.now = func() time.Time { return now }
+
+			var payload []byte
+			q.Handle("registration", func(_ context.Context, p []byte) error {
+				payload = p
+				if tt.panics {
+					panic("boom")
+				}
+				return tt.handlerErr
+			})
+
+			job := &types.Job{ID: "job-1", Kind: "registration", Payload: []byte(`{"identity_id":"user-1"}`), Attempts: tt.attempts}
+			mockStorage.EXPECT().ClaimJob(gomock.Any(), now, testConfig.Timeout).Return(job, nil)
+			tt.setupMocks(mockStorage)
+
+			if !q.runNext(context.Background()) {
+				t.Fatal("expected a job to run")
+			}
+			if string(payload) != `{"identity_id":"user-1"}` {
+				t.Errorf("expected the job payload, got %s", payload)
+			}
+		})
+	}
+}
+
+func TestQueue_RunNextStopping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, mockStorage := newTestQueue(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attemptErr error
+	q.Handle("registration", func(ctx context.Context, _ []byte) error {
+		attemptErr = ctx.Err()
+		return nil
+	})
+
+	job := &types.Job{ID: "job-1", Kind: "registration", Attempts: 1}
+	mockStorage.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), testConfig.Timeout).DoAndReturn(
+		func(context.Context, time.Time, time.Duration) (*types.Job, error) {
+			// the queue is stopped once the job is claimed
+			cancel()
+			return job, nil
+		},
+	)
+	mockStorage.EXPECT().DeleteJob(gomock.Any(), "job-1").Return(nil)
+
+	if !q.runNext(ctx) {
+		t.Fatal("expected a job to run")
+	}
+	if attemptErr != nil {
+		t.Errorf("expected the attempt to run to its end, got %v", attemptErr)
+	}
+	if q.runNext(ctx) {
+		t.Error("expected a stopped queue not to claim jobs")
+	}
+}
+
+func TestQueue_RunNextFailureHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, mockStorage := newTestQueue(ctrl)
+
+	var payload []byte
+	var failure error
+	q.Handle("registration", func(context.Context, []byte) error { return errors.New("openfga unavailable") })
+	q.HandleFailure("registration", func(_ context.Context, p []byte, err error) {
+		payload, failure = p, err
+	})
+
+	job := &types.Job{ID: "job-1", Kind: "registration", Payload: []byte(`{"identity_id":"user-1"}`), Attempts: testConfig.MaxAttempts}
+	mockStorage.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+	mockStorage.EXPECT().FailJob(gomock.Any(), "job-1", "openfga unavailable").Return(nil)
+
+	if !q.runNext(context.Background()) {
+		t.Fatal("expected a job to run")
+	}
+	if string(payload) != `{"identity_id":"user-1"}` || failure == nil || failure.Error() != "openfga unavailable" {
+		t.Errorf("expected the failure handler to get the job, got %s and %v", payload, failure)
+	}
+}
+
+func TestQueue_RunNextUnknownKind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, mockStorage := newTestQueue(ctrl)
+
+	mockStorage.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(&types.Job{ID: "job-1", Kind: "unknown", Attempts: 1}, nil)
+	mockStorage.EXPECT().RetryJob(gomock.Any(), "job-1", gomock.Any(), "no handler for unknown jobs").Return(nil)
+
+	if !q.runNext(context.Background()) {
+		t.Fatal("expected a job to run")
+	}
+}
+
+func TestQueue_RunNextIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, mockStorage := newTestQueue(ctrl)
+	mockStorage.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+
+	if q.runNext(context.Background()) {
+		t.Fatal("expected no job to run")
+	}
+}
+
+func TestQueue_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, mockStorage := newTestQueue(ctrl)
+	mockStorage.EXPECT().CreateJob(gomock.Any(), "registration", []byte(`{"identity_id":"user-1"}`)).Return(&types.Job{ID: "job-1"}, nil)
+
+	if err := q.Enqueue(context.Background(), "registration", map[string]string{"identity_id": "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-q.wake:
+	default:
+		t.Error("expected the enqueue to wake a worker")
+	}
+}
+
+func TestQueue_Backoff(t *testing.T) {
+	q := NewQueue(testConfig, nil, nil, nil, nil, nil)
+
+	for attempts, expected := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		3:  4 * time.Second,
+		4:  5 * time.Second,
+		40: 5 * time.Second,
+	} {
+		if d := q.backoff(attempts); d != expected {
+			t.Errorf("attempt %d: expected %v, got %v", attempts, expected, d)
+		}
+	}
+}
+
+func TestQueue_AttemptRetryCommits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, _ := newTestQueue(ctrl)
+
+	var txErr error
+	mockDB := NewMockTxRunnerInterface(ctrl)
+	mockDB.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			txErr = fn(ctx)
+			return txErr
+		},
+	)
+	q.db = mockDB
+
+	q.Handle("delivery", func(context.Context, []byte) error {
+		return Retry(errors.New("target unreachable"))
+	})
+
+	err := q.attempt(context.Background(), &types.Job{ID: "job-1", Kind: "delivery", Attempts: 1})
+	if txErr != nil {
+		t.Errorf("expected the transaction to commit, got %v", txErr)
+	}
+	if err == nil || err.Error() != "target unreachable" {
+		t.Errorf("expected the attempt to fail, got %v", err)
+	}
+}
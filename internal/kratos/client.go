@@ -5,8 +5,12 @@ package kratos
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
@@ -14,6 +18,33 @@ import (
 	ory "github.com/ory/client-go"
 )
 
+// ErrIdentityNotFound is returned when Kratos has no identity for the requested ID.
+var ErrIdentityNotFound = errors.New("identity not found")
+
+// RateLimitedError is returned when Kratos rejected a call with 429 Too Many Requests.
+type RateLimitedError struct {
+	// RetryAfter is the wait Kratos asked for, zero when it did not say
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited by kratos: %s", e.err)
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.err
+}
+
+func newRateLimitedError(r *http.Response, err error) *RateLimitedError {
+	e := &RateLimitedError{err: err}
+	if seconds, convErr := strconv.Atoi(r.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return e
+}
+
 type ClientInterface interface {
 	GetIdentityIDByEmail(ctx context.Context, email string) (string, error)
 	CreateIdentity(ctx context.Context, email string) (string, error)
@@ -28,9 +59,13 @@ type Client struct {
 	logger  logging.LoggerInterface
 }
 
-func NewClient(kratosAdminURL string, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Client {
+// NewClient creates a Kratos admin client, httpClient is optional and defaults to http.DefaultClient.
+func NewClient(kratosAdminURL string, httpClient *http.Client, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Client {
 	conf := ory.NewConfiguration()
 	conf.Servers = ory.ServerConfigurations{{URL: kratosAdminURL}}
+	if httpClient != nil {
+		conf.HTTPClient = httpClient
+	}
 	return &Client{
 		client:  ory.NewAPIClient(conf),
 		tracer:  tracer,
@@ -39,6 +74,39 @@ func NewClient(kratosAdminURL string, tracer tracing.TracingInterface, monitor m
 	}
 }
 
+// Ready checks that the admin API of Kratos is ready to serve, which
+// includes reaching its database.
+func (c *Client) Ready(ctx context.Context) error {
+	ctx, span := c.tracer.Start(ctx, "kratos.Ready")
+	defer span.End()
+
+	conf := c.client.GetConfig()
+	url, err := conf.Servers.URL(0, nil)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/health/ready", nil)
+	if err != nil {
+		return err
+	}
+
+	httpClient := conf.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach kratos: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kratos is not ready: %s", resp.Status)
+	}
+	return nil
+}
+
 func (c *Client) GetIdentityIDByEmail(ctx context.Context, email string) (string, error) {
 	ctx, span := c.tracer.Start(ctx, "kratos.GetIdentityIDByEmail")
 	defer span.End()
@@ -78,8 +146,11 @@ func (c *Client) CreateIdentity(ctx context.Context, email string) (string, erro
 		Traits:   traits,
 	}
 
-	identity, _, err := c.client.IdentityAPI.CreateIdentity(ctx).CreateIdentityBody(createIdentityBody).Execute()
+	identity, r, err := c.client.IdentityAPI.CreateIdentity(ctx).CreateIdentityBody(createIdentityBody).Execute()
 	if err != nil {
+		if r != nil && r.StatusCode == http.StatusTooManyRequests {
+			return "", fmt.Errorf("failed to create identity: %w", newRateLimitedError(r, err))
+		}
 		return "", fmt.Errorf("failed to create identity: %w", err)
 	}
 
@@ -90,8 +161,11 @@ func (c *Client) GetIdentity(ctx context.Context, id string) (*ory.Identity, err
 	ctx, span := c.tracer.Start(ctx, "kratos.GetIdentity")
 	defer span.End()
 
-	identity, _, err := c.client.IdentityAPI.GetIdentity(ctx, id).Execute()
+	identity, r, err := c.client.IdentityAPI.GetIdentity(ctx, id).Execute()
 	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			return nil, ErrIdentityNotFound
+		}
 		return nil, fmt.Errorf("failed to get identity: %w", err)
 	}
 
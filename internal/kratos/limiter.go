@@ -0,0 +1,101 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package kratos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// maxRateLimitedRetries bounds the retries of a call Kratos rejected with 429.
+const maxRateLimitedRetries = 3
+
+// RateLimitedClient paces the identity creations sent to Kratos, so that bulk
+// provisioning queues up instead of tripping the Kratos rate limits. The other
+// calls are passed through.
+type RateLimitedClient struct {
+	ClientInterface
+
+	limiter *rate.Limiter
+	slots   chan struct{}
+	// wait is the backoff after a 429 without Retry-After, doubled on every retry
+	wait time.Duration
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// CreateIdentity waits for a free slot and for the rate limiter before calling
+// Kratos, calls rejected with 429 are retried after the wait Kratos asked for.
+// It fails once ctx is done or its deadline would pass while waiting.
+func (c *RateLimitedClient) CreateIdentity(ctx context.Context, email string) (string, error) {
+	ctx, span := c.tracer.Start(ctx, "kratos.RateLimitedClient.CreateIdentity")
+	defer span.End()
+
+	select {
+	case c.slots <- struct{}{}:
+		defer func() { <-c.slots }()
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to create identity, waiting for a slot: %w", ctx.Err())
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return "", fmt.Errorf("failed to create identity, waiting for the rate limiter: %w", err)
+		}
+
+		id, err := c.ClientInterface.CreateIdentity(ctx, email)
+
+		var rateLimited *RateLimitedError
+		if !errors.As(err, &rateLimited) || attempt >= maxRateLimitedRetries {
+			return id, err
+		}
+
+		wait := rateLimited.RetryAfter
+		if wait <= 0 {
+			wait = c.wait << attempt
+		}
+		c.logger.Warnw("identity creation rate limited by kratos, retrying", "attempt", attempt+1, "wait", wait)
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return "", err
+		case <-t.C:
+		}
+	}
+}
+
+// NewRateLimitedClient allows perSecond identity creations on average with
+// bursts of burst, and at most concurrency in flight.
+func NewRateLimitedClient(
+	c ClientInterface,
+	perSecond float64,
+	burst int,
+	concurrency int,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *RateLimitedClient {
+	l := new(RateLimitedClient)
+	l.ClientInterface = c
+	l.limiter = rate.NewLimiter(rate.Limit(perSecond), max(burst, 1))
+	l.slots = make(chan struct{}, max(concurrency, 1))
+	l.wait = time.Second
+	l.tracer = tracer
+	l.monitor = monitor
+	l.logger = logger
+
+	return l
+}
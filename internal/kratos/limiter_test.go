@@ -0,0 +1,111 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package kratos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package kratos -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package kratos -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package kratos -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package kratos -destination ./mock_kratos.go -source=./client.go
+
+func TestRateLimitedClientCreateIdentity(t *testing.T) {
+	rateLimited := &RateLimitedError{err: errors.New("429 Too Many Requests")}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			errs: []error{nil},
+		},
+		{
+			name: "retried after being rate limited",
+			errs: []error{rateLimited, rateLimited, nil},
+		},
+		{
+			name:        "gives up after the retries",
+			errs:        []error{rateLimited, rateLimited, rateLimited, rateLimited},
+			expectedErr: true,
+		},
+		{
+			name:        "other errors are not retried",
+			errs:        []error{errors.New("500 Internal Server Error")},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockClientInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "kratos.RateLimitedClient.CreateIdentity").Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
+			for _, err := range test.errs {
+				id := "identity-id"
+				if err != nil {
+					id = ""
+				}
+				mockClient.EXPECT().CreateIdentity(gomock.Any(), "user@example.com").Return(id, err)
+			}
+
+			c := NewRateLimitedClient(mockClient, 1000, 1, 1, mockTracer, mockMonitor, mockLogger)
+			c.wait = time.Millisecond
+
+			id, err := c.CreateIdentity(context.TODO(), "user@example.com")
+
+			if test.expectedErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != "identity-id" {
+				t.Fatalf("expected identity-id, got %s", id)
+			}
+		})
+	}
+}
+
+func TestRateLimitedClientWaitsForASlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockClientInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx))
+
+	c := NewRateLimitedClient(mockClient, 1000, 1, 1, mockTracer, mockMonitor, mockLogger)
+	// another creation is in flight
+	c.slots <- struct{}{}
+
+	if _, err := c.CreateIdentity(ctx, "user@example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package kratos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+	ory "github.com/ory/client-go"
+)
+
+// ErrNoSession is returned when Kratos has no active session for a cookie.
+var ErrNoSession = errors.New("no active session")
+
+// SessionClient checks browser sessions with the Kratos public API.
+type SessionClient struct {
+	client  *ory.APIClient
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ToSession returns the session of the given Cookie header, with its identity.
+func (c *SessionClient) ToSession(ctx context.Context, cookie string) (*ory.Session, error) {
+	ctx, span := c.tracer.Start(ctx, "kratos.ToSession")
+	defer span.End()
+
+	session, r, err := c.client.FrontendAPI.ToSession(ctx).Cookie(cookie).Execute()
+	if err != nil {
+		if r != nil && (r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden) {
+			return nil, ErrNoSession
+		}
+		if r != nil && r.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("failed to get session: %w", newRateLimitedError(r, err))
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// NewSessionClient creates a Kratos public API client, httpClient is optional
+// and defaults to http.DefaultClient.
+func NewSessionClient(kratosPublicURL string, httpClient *http.Client, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *SessionClient {
+	conf := ory.NewConfiguration()
+	conf.Servers = ory.ServerConfigurations{{URL: kratosPublicURL}}
+	if httpClient != nil {
+		conf.HTTPClient = httpClient
+	}
+	return &SessionClient{
+		client:  ory.NewAPIClient(conf),
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
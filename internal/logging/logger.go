@@ -4,6 +4,7 @@
 package logging
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -11,9 +12,19 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// levels maps the names accepted by LOG_LEVEL to the zap levels.
+var levels = map[string]zapcore.Level{
+	"debug":    zap.DebugLevel,
+	"info":     zap.InfoLevel,
+	"warning":  zap.WarnLevel,
+	"error":    zap.ErrorLevel,
+	"critical": zap.DPanicLevel,
+}
+
 type Logger struct {
 	*zap.SugaredLogger
 	security *SecurityLogger
+	level    zap.AtomicLevel
 }
 
 func (l *Logger) Security() SecurityLoggerInterface {
@@ -25,29 +36,42 @@ func (l *Logger) Sync() {
 	l.SugaredLogger.Desugar().Sync()
 }
 
+// Level returns the name of the level of the service logs.
+func (l *Logger) Level() string {
+	current := l.level.Level()
+	for name, lvl := range levels {
+		if lvl == current {
+			return name
+		}
+	}
+	return current.String()
+}
+
+// SetLevel changes the level of the service logs while running, the security
+// logs keep the level they were created with.
+func (l *Logger) SetLevel(level string) error {
+	lvl, ok := levels[strings.ToLower(level)]
+	if !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	l.level.SetLevel(lvl)
+	return nil
+}
+
 func NewLogger(l string) *Logger {
 	logger := new(Logger)
-	logger.SugaredLogger = NewServiceLogger(l)
+	// unknown levels fall back to info
+	logger.level = zap.NewAtomicLevelAt(levels[strings.ToLower(l)])
+	logger.SugaredLogger = newServiceLogger(logger.level)
 	logger.security = NewSecurityLogger(l)
 	return logger
 }
 
 func NewServiceLogger(l string) *zap.SugaredLogger {
-	var lvl zapcore.Level
-
-	switch strings.ToLower(l) {
-	case "debug":
-		lvl = zap.DebugLevel
-	case "info":
-		lvl = zap.InfoLevel
-	case "warning":
-		lvl = zap.WarnLevel
-	case "error":
-		lvl = zap.ErrorLevel
-	case "critical":
-		lvl = zap.DPanicLevel
-	}
+	return newServiceLogger(zap.NewAtomicLevelAt(levels[strings.ToLower(l)]))
+}
 
+func newServiceLogger(lvl zap.AtomicLevel) *zap.SugaredLogger {
 	c := zapcore.EncoderConfig{
 		MessageKey:  "description",
 		LevelKey:    "level",
@@ -5,6 +5,8 @@ package logging
 
 import (
 	"testing"
+
+	"go.uber.org/zap/zapcore"
 )
 
 func TestDebugLogger(t *testing.T) {
@@ -20,3 +22,24 @@ func TestInvalidLevel(t *testing.T) {
 		NewLogger("invalid")
 	}()
 }
+
+func TestSetLevel(t *testing.T) {
+	logger := NewLogger("info")
+	if logger.Level() != "info" || logger.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		t.Fatalf("expected the info level, got %s", logger.Level())
+	}
+
+	if err := logger.SetLevel("DEBUG"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logger.Level() != "debug" || !logger.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		t.Errorf("expected the debug level, got %s", logger.Level())
+	}
+
+	if err := logger.SetLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if logger.Level() != "debug" {
+		t.Errorf("expected the level to be kept, got %s", logger.Level())
+	}
+}
@@ -11,5 +11,6 @@ func NewNoopLogger() *Logger {
 	return &Logger{
 		SugaredLogger: zap.NewNop().Sugar(),
 		security:      &SecurityLogger{l: zap.NewNop()},
+		level:         zap.NewAtomicLevel(),
 	}
 }
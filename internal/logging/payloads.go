@@ -0,0 +1,227 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxPayloadSize bounds the bytes of a body kept for logging, larger bodies
+// are truncated.
+const maxPayloadSize = 16 << 10
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/\-]+=*`)
+	// recovery and verification links carry the code letting anyone in
+	linkPattern = regexp.MustCompile(`https?://[^\s"'<>]*(?:recovery|verification|code=|token=)[^\s"'<>]*`)
+
+	// sensitiveKeys are the JSON keys, or parts of keys, whose string values
+	// are redacted whatever they hold.
+	sensitiveKeys = []string{"token", "password", "secret", "code", "link", "authorization", "cookie", "key"}
+)
+
+// redacted replaces s with a short hash, the same value always gives the same
+// hash so that it can be followed across a trace.
+func redacted(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "redacted:" + hex.EncodeToString(sum[:6])
+}
+
+// RedactString hashes the emails, tokens and recovery links found in s.
+func RedactString(s string) string {
+	for _, p := range []*regexp.Regexp{linkPattern, bearerPattern, jwtPattern, emailPattern} {
+		s = p.ReplaceAllStringFunc(s, redacted)
+	}
+	return s
+}
+
+// RedactPayload hashes the emails, tokens and recovery links of a body, the
+// values of sensitive JSON keys are hashed as well.
+func RedactPayload(data []byte) string {
+	var document any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return RedactString(string(data))
+	}
+
+	out, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return RedactString(string(data))
+	}
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if s, ok := e.(string); ok && isSensitiveKey(k) && s != "" {
+				t[k] = redacted(s)
+				continue
+			}
+			t[k] = redactValue(e)
+		}
+		return t
+	case []any:
+		for i, e := range t {
+			t[i] = redactValue(e)
+		}
+		return t
+	case string:
+		return RedactString(t)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURI hashes the sensitive values of the query of a request URI.
+func redactURI(u *url.URL) string {
+	query := u.Query()
+	for k, values := range query {
+		for i, v := range values {
+			if isSensitiveKey(k) && v != "" {
+				values[i] = redacted(v)
+			} else {
+				values[i] = RedactString(v)
+			}
+		}
+		query[k] = values
+	}
+
+	if len(query) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+// limitedBuffer keeps the first limit bytes written to it and drops the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// PayloadLogger logs the request and response bodies of the HTTP and gRPC
+// APIs at debug level, with emails, tokens and recovery links hashed. Meant
+// to capture reproduction traces, it is only wired when enabled.
+type PayloadLogger struct {
+	logger LoggerInterface
+}
+
+// Middleware logs the payloads of the HTTP requests for which skip is false.
+func (p *PayloadLogger) Middleware(skip func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if skip(r) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				request := &limitedBuffer{limit: maxPayloadSize}
+				if r.Body != nil {
+					// the handler reads the body through the buffer
+					r.Body = struct {
+						io.Reader
+						io.Closer
+					}{io.TeeReader(r.Body, request), r.Body}
+				}
+
+				response := &limitedBuffer{limit: maxPayloadSize}
+				ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+				ww.Tee(response)
+
+				next.ServeHTTP(ww, r)
+
+				p.logger.Debugw(
+					"HTTP payloads",
+					"request_id", middleware.GetReqID(r.Context()),
+					"method", r.Method,
+					"uri", redactURI(r.URL),
+					"status", ww.Status(),
+					"request", RedactPayload(request.Bytes()),
+					"response", RedactPayload(response.Bytes()),
+				)
+			},
+		)
+	}
+}
+
+// UnaryServerInterceptor logs the payloads of the unary gRPC calls.
+func (p *PayloadLogger) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+
+	keysAndValues := []any{
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"request", p.marshal(req),
+	}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", RedactString(err.Error()))
+	} else {
+		keysAndValues = append(keysAndValues, "response", p.marshal(resp))
+	}
+	p.logger.Debugw("gRPC payloads", keysAndValues...)
+
+	return resp, err
+}
+
+func (p *PayloadLogger) marshal(v any) string {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return ""
+	}
+
+	if len(data) > maxPayloadSize {
+		data = data[:maxPayloadSize]
+	}
+	return RedactPayload(data)
+}
+
+func NewPayloadLogger(logger LoggerInterface) *PayloadLogger {
+	p := new(PayloadLogger)
+
+	p.logger = logger
+
+	return p
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+const message = "service in maintenance, only read requests are served"
+
+// Mode rejects the requests that change state while enabled, reads are
+// still served. It is held in memory, every instance is toggled on its own.
+type Mode struct {
+	enabled atomic.Bool
+
+	logger logging.LoggerInterface
+}
+
+// Enabled tells whether the instance is in maintenance.
+func (m *Mode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set enables or disables maintenance and returns the previous state.
+func (m *Mode) Set(enabled bool) bool {
+	previous := m.enabled.Swap(enabled)
+	if previous != enabled {
+		m.logger.Infow("maintenance mode changed", "enabled", enabled)
+	}
+	return previous
+}
+
+// Guard returns an HTTP middleware failing the requests for which readOnly
+// is false with 503 Service Unavailable while in maintenance.
+func (m *Mode) Guard(readOnly func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if !m.Enabled() || readOnly(r) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				m.logger.Debugf("rejecting %s %s during maintenance", r.Method, r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				if err := json.NewEncoder(w).Encode(map[string]interface{}{
+					"status":  http.StatusServiceUnavailable,
+					"message": message,
+				}); err != nil {
+					m.logger.Errorf("failed to encode maintenance response: %v", err)
+				}
+			},
+		)
+	}
+}
+
+// UnaryServerInterceptor fails the gRPC calls for which readOnly is false
+// with Unavailable while in maintenance.
+func (m *Mode) UnaryServerInterceptor(readOnly func(fullMethod string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !m.Enabled() || readOnly(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		m.logger.Debugf("rejecting %s during maintenance", info.FullMethod)
+
+		return nil, status.Error(codes.Unavailable, message)
+	}
+}
+
+func NewMode(logger logging.LoggerInterface) *Mode {
+	m := new(Mode)
+
+	m.logger = logger
+
+	return m
+}
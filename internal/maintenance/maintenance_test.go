@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package maintenance -destination ./mock_logger.go -source=../logging/interfaces.go
+
+func TestModeSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Infow("maintenance mode changed", "enabled", true)
+
+	m := NewMode(mockLogger)
+	if previous := m.Set(true); previous {
+		t.Fatal("expected maintenance to start disabled")
+	}
+	// setting the same state again is not logged
+	if previous := m.Set(true); !previous || !m.Enabled() {
+		t.Fatal("expected maintenance to be enabled")
+	}
+}
+
+func TestModeGuard(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		enabled        bool
+		expectedStatus int
+	}{
+		{name: "Write", method: http.MethodPost, expectedStatus: http.StatusOK},
+		{name: "Read in maintenance", method: http.MethodGet, enabled: true, expectedStatus: http.StatusOK},
+		{name: "Write in maintenance", method: http.MethodPost, enabled: true, expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			m := NewMode(mockLogger)
+			m.Set(test.enabled)
+
+			handler := m.Guard(func(r *http.Request) bool { return r.Method == http.MethodGet })(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(test.method, "/api/v0/tenants", nil))
+
+			if rr.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestModeUnaryServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	m := NewMode(mockLogger)
+	m.Set(true)
+
+	interceptor := m.UnaryServerInterceptor(func(fullMethod string) bool { return fullMethod == "/svc/ListTenants" })
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/CreateTenant"}, handler); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+
+	if resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/ListTenants"}, handler); err != nil || resp != "ok" {
+		t.Fatalf("expected call to go through, got %v, %v", resp, err)
+	}
+}
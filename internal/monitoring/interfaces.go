@@ -3,9 +3,20 @@
 
 package monitoring
 
+import "time"
+
 type MonitorInterface interface {
 	GetService() string
 	SetResponseTimeMetric(map[string]string, float64) error
 	SetDependencyAvailability(map[string]string, float64) error
 	IncrementCounter(map[string]string) error
+	IncrementDeprecatedUsage(map[string]string) error
+	SetAuthorizationModelState(map[string]string, float64) error
+	IncrementAuthorizationDecision(map[string]string) error
+	IncrementPanics(map[string]string) error
+}
+
+// LatencyObserverInterface receives the latency of calls made to external dependencies
+type LatencyObserverInterface interface {
+	ObserveLatency(dependency string, d time.Duration)
 }
@@ -0,0 +1,238 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+const (
+	// DatabaseDependency identifies latencies of Postgres queries
+	DatabaseDependency = "postgres"
+	// OpenFGADependency identifies latencies of OpenFGA API calls
+	OpenFGADependency = "openfga"
+
+	// maxLatencySamples bounds the memory used by a single window
+	maxLatencySamples = 1024
+	// minLatencySamples avoids flagging a dependency on a handful of slow calls
+	minLatencySamples = 10
+	// shedPercentile is the percentile compared against the thresholds
+	shedPercentile = 0.95
+)
+
+type latencySample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// LatencyWindow keeps the latencies observed over a rolling time window.
+type LatencyWindow struct {
+	size    time.Duration
+	samples []latencySample
+
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// Observe records a latency, evicting samples older than the window.
+func (w *LatencyWindow) Observe(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	w.evict(now)
+
+	if len(w.samples) == maxLatencySamples {
+		w.samples = w.samples[1:]
+	}
+	w.samples = append(w.samples, latencySample{at: now, duration: d})
+}
+
+// Percentile returns the p-th percentile (0 < p <= 1) of the latencies in the window
+// together with the number of samples it was computed on.
+func (w *LatencyWindow) Percentile(p float64) (time.Duration, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.evict(w.now())
+
+	if len(w.samples) == 0 {
+		return 0, 0
+	}
+
+	ds := make([]time.Duration, len(w.samples))
+	for i, s := range w.samples {
+		ds[i] = s.duration
+	}
+	slices.Sort(ds)
+
+	idx := int(math.Ceil(p*float64(len(ds)))) - 1
+	idx = max(0, min(idx, len(ds)-1))
+
+	return ds[idx], len(ds)
+}
+
+func (w *LatencyWindow) evict(now time.Time) {
+	cutoff := now.Add(-w.size)
+
+	i := 0
+	for i < len(w.samples) && w.samples[i].at.Before(cutoff) {
+		i++
+	}
+	w.samples = w.samples[i:]
+}
+
+func NewLatencyWindow(size time.Duration) *LatencyWindow {
+	w := new(LatencyWindow)
+
+	w.size = size
+	w.samples = make([]latencySample, 0)
+	w.now = time.Now
+
+	return w
+}
+
+// LoadShedder tracks dependency latencies and rejects low-priority requests
+// while any dependency is slower than its threshold.
+type LoadShedder struct {
+	window     time.Duration
+	thresholds map[string]time.Duration
+	windows    map[string]*LatencyWindow
+
+	monitor MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// ObserveLatency records the latency of a call to a dependency, dependencies
+// without a threshold are ignored.
+func (s *LoadShedder) ObserveLatency(dependency string, d time.Duration) {
+	if w, ok := s.windows[dependency]; ok {
+		w.Observe(d)
+	}
+}
+
+// Degraded returns the dependencies whose latency percentile exceeds their threshold.
+func (s *LoadShedder) Degraded() []string {
+	degraded := make([]string, 0)
+
+	for dependency, w := range s.windows {
+		p, n := w.Percentile(shedPercentile)
+
+		available := 1.0
+		if n >= minLatencySamples && p > s.thresholds[dependency] {
+			degraded = append(degraded, dependency)
+			available = 0
+		}
+
+		s.monitor.SetDependencyAvailability(map[string]string{"component": dependency}, available)
+	}
+
+	slices.Sort(degraded)
+
+	return degraded
+}
+
+// RetryAfter is the delay clients are asked to wait before retrying a shed request.
+func (s *LoadShedder) RetryAfter() time.Duration {
+	return s.window
+}
+
+// Shed returns a middleware rejecting the requests matched by lowPriority with
+// 503 Service Unavailable while a dependency is degraded.
+func (s *LoadShedder) Shed(lowPriority func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if !lowPriority(r) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				degraded := s.Degraded()
+				if len(degraded) == 0 {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				s.logger.Debugf("shedding %s %s, degraded dependencies: %v", r.Method, r.URL.Path, degraded)
+				s.monitor.IncrementCounter(map[string]string{"operation": "load_shed", "status": "rejected"})
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", strconv.Itoa(int(s.RetryAfter().Seconds())))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				if err := json.NewEncoder(w).Encode(map[string]interface{}{
+					"status":  http.StatusServiceUnavailable,
+					"message": s.message(degraded),
+				}); err != nil {
+					s.logger.Errorf("failed to encode load shedding response: %v", err)
+				}
+			},
+		)
+	}
+}
+
+// UnaryServerInterceptor rejects the gRPC calls matched by lowPriority with
+// codes.Unavailable and a retry-after header while a dependency is degraded.
+func (s *LoadShedder) UnaryServerInterceptor(lowPriority func(fullMethod string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !lowPriority(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		degraded := s.Degraded()
+		if len(degraded) == 0 {
+			return handler(ctx, req)
+		}
+
+		s.logger.Debugf("shedding %s, degraded dependencies: %v", info.FullMethod, degraded)
+		s.monitor.IncrementCounter(map[string]string{"operation": "load_shed", "status": "rejected"})
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(s.RetryAfter().Seconds()))))
+
+		return nil, status.Error(codes.Unavailable, s.message(degraded))
+	}
+}
+
+func (s *LoadShedder) message(degraded []string) string {
+	return fmt.Sprintf("service degraded (%v), retry later", degraded)
+}
+
+// NewLoadShedder creates a LoadShedder evaluating latencies over the given window,
+// a zero threshold disables shedding for that dependency.
+func NewLoadShedder(window time.Duration, thresholds map[string]time.Duration, monitor MonitorInterface, logger logging.LoggerInterface) *LoadShedder {
+	s := new(LoadShedder)
+
+	s.window = window
+	s.thresholds = make(map[string]time.Duration)
+	s.windows = make(map[string]*LatencyWindow)
+
+	for dependency, threshold := range thresholds {
+		if threshold <= 0 {
+			continue
+		}
+
+		s.thresholds[dependency] = threshold
+		s.windows[dependency] = NewLatencyWindow(window)
+	}
+
+	s.monitor = monitor
+	s.logger = logger
+
+	return s
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLatencyWindowPercentile(t *testing.T) {
+	now := time.Now()
+
+	w := NewLatencyWindow(time.Minute)
+	w.now = func() time.Time { return now }
+
+	for i := 1; i <= 100; i++ {
+		w.Observe(time.Duration(i) * time.Millisecond)
+	}
+
+	p, n := w.Percentile(0.95)
+	if n != 100 {
+		t.Fatalf("expected 100 samples, got %d", n)
+	}
+	if p != 95*time.Millisecond {
+		t.Fatalf("expected p95 of 95ms, got %v", p)
+	}
+
+	// samples older than the window are evicted
+	now = now.Add(2 * time.Minute)
+	w.Observe(time.Millisecond)
+
+	p, n = w.Percentile(0.95)
+	if n != 1 || p != time.Millisecond {
+		t.Fatalf("expected a single 1ms sample, got %d samples with p95 %v", n, p)
+	}
+}
+
+func TestLatencyWindowBounded(t *testing.T) {
+	w := NewLatencyWindow(time.Hour)
+
+	for i := 0; i < maxLatencySamples+10; i++ {
+		w.Observe(time.Millisecond)
+	}
+
+	if _, n := w.Percentile(0.5); n != maxLatencySamples {
+		t.Fatalf("expected %d samples, got %d", maxLatencySamples, n)
+	}
+}
+
+func TestLoadShedderDegraded(t *testing.T) {
+	tests := []struct {
+		name     string
+		observe  map[string][]time.Duration
+		expected []string
+	}{
+		{
+			name:     "No samples",
+			expected: []string{},
+		},
+		{
+			name: "Too few slow samples",
+			observe: map[string][]time.Duration{
+				DatabaseDependency: repeat(time.Second, minLatencySamples-1),
+			},
+			expected: []string{},
+		},
+		{
+			name: "Slow database",
+			observe: map[string][]time.Duration{
+				DatabaseDependency: repeat(time.Second, minLatencySamples),
+				OpenFGADependency:  repeat(time.Millisecond, minLatencySamples),
+			},
+			expected: []string{DatabaseDependency},
+		},
+		{
+			name: "Unmonitored dependency",
+			observe: map[string][]time.Duration{
+				"kratos": repeat(time.Second, minLatencySamples),
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor.EXPECT().SetDependencyAvailability(gomock.Any(), gomock.Any()).AnyTimes()
+
+			s := NewLoadShedder(
+				time.Minute,
+				map[string]time.Duration{
+					DatabaseDependency: 100 * time.Millisecond,
+					OpenFGADependency:  100 * time.Millisecond,
+				},
+				mockMonitor,
+				mockLogger,
+			)
+
+			for dependency, ds := range test.observe {
+				for _, d := range ds {
+					s.ObserveLatency(dependency, d)
+				}
+			}
+
+			degraded := s.Degraded()
+			if len(degraded) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, degraded)
+			}
+			for i := range degraded {
+				if degraded[i] != test.expected[i] {
+					t.Fatalf("expected %v, got %v", test.expected, degraded)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadShedderShed(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		degraded       bool
+		expectedStatus int
+	}{
+		{name: "Healthy list", method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "Degraded list", method: http.MethodGet, degraded: true, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Degraded write", method: http.MethodPost, degraded: true, expectedStatus: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor.EXPECT().SetDependencyAvailability(gomock.Any(), gomock.Any()).AnyTimes()
+			mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			s := NewLoadShedder(30*time.Second, map[string]time.Duration{DatabaseDependency: time.Millisecond}, mockMonitor, mockLogger)
+			if test.degraded {
+				for i := 0; i < minLatencySamples; i++ {
+					s.ObserveLatency(DatabaseDependency, time.Second)
+				}
+			}
+
+			handler := s.Shed(func(r *http.Request) bool { return r.Method == http.MethodGet })(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(test.method, "/api/v0/tenants", nil))
+
+			if rr.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, rr.Code)
+			}
+			if test.expectedStatus == http.StatusServiceUnavailable && rr.Header().Get("Retry-After") != "30" {
+				t.Fatalf("expected Retry-After 30, got %q", rr.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestLoadShedderUnaryServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor.EXPECT().SetDependencyAvailability(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s := NewLoadShedder(time.Minute, map[string]time.Duration{OpenFGADependency: time.Millisecond}, mockMonitor, mockLogger)
+	for i := 0; i < minLatencySamples; i++ {
+		s.ObserveLatency(OpenFGADependency, time.Second)
+	}
+
+	interceptor := s.UnaryServerInterceptor(func(fullMethod string) bool { return fullMethod == "/svc/ListTenants" })
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/ListTenants"}, handler); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+
+	if resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/CreateTenant"}, handler); err != nil || resp != "ok" {
+		t.Fatalf("expected call to go through, got %v, %v", resp, err)
+	}
+}
+
+func repeat(d time.Duration, n int) []time.Duration {
+	ds := make([]time.Duration, n)
+	for i := range ds {
+		ds[i] = d
+	}
+	return ds
+}
@@ -32,3 +32,15 @@ func (m *NoopMonitor) SetDependencyAvailability(map[string]string, float64) erro
 func (m *NoopMonitor) IncrementCounter(map[string]string) error {
 	return nil
 }
+func (m *NoopMonitor) IncrementDeprecatedUsage(map[string]string) error {
+	return nil
+}
+func (m *NoopMonitor) SetAuthorizationModelState(map[string]string, float64) error {
+	return nil
+}
+func (m *NoopMonitor) IncrementAuthorizationDecision(map[string]string) error {
+	return nil
+}
+func (m *NoopMonitor) IncrementPanics(map[string]string) error {
+	return nil
+}
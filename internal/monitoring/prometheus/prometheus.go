@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/version"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -15,7 +16,12 @@ type Monitor struct {
 
 	responseTime           *prometheus.HistogramVec
 	dependencyAvailability *prometheus.GaugeVec
+	authzModelState        *prometheus.GaugeVec
+	buildInfo              *prometheus.GaugeVec
 	operationsTotal        *prometheus.CounterVec
+	deprecatedUsageTotal   *prometheus.CounterVec
+	authzDecisionsTotal    *prometheus.CounterVec
+	panicsTotal            *prometheus.CounterVec
 
 	logger logging.LoggerInterface
 }
@@ -44,6 +50,16 @@ func (m *Monitor) SetDependencyAvailability(tags map[string]string, value float6
 	return nil
 }
 
+func (m *Monitor) SetAuthorizationModelState(tags map[string]string, value float64) error {
+	if m.authzModelState == nil {
+		return fmt.Errorf("metric not instantiated")
+	}
+
+	m.authzModelState.With(tags).Set(value)
+
+	return nil
+}
+
 func (m *Monitor) IncrementCounter(tags map[string]string) error {
 	if m.operationsTotal == nil {
 		return fmt.Errorf("metric not instantiated")
@@ -54,6 +70,36 @@ func (m *Monitor) IncrementCounter(tags map[string]string) error {
 	return nil
 }
 
+func (m *Monitor) IncrementDeprecatedUsage(tags map[string]string) error {
+	if m.deprecatedUsageTotal == nil {
+		return fmt.Errorf("metric not instantiated")
+	}
+
+	m.deprecatedUsageTotal.With(tags).Inc()
+
+	return nil
+}
+
+func (m *Monitor) IncrementAuthorizationDecision(tags map[string]string) error {
+	if m.authzDecisionsTotal == nil {
+		return fmt.Errorf("metric not instantiated")
+	}
+
+	m.authzDecisionsTotal.With(tags).Inc()
+
+	return nil
+}
+
+func (m *Monitor) IncrementPanics(tags map[string]string) error {
+	if m.panicsTotal == nil {
+		return fmt.Errorf("metric not instantiated")
+	}
+
+	m.panicsTotal.With(tags).Inc()
+
+	return nil
+}
+
 func (m *Monitor) registerHistograms() {
 	histograms := make([]*prometheus.HistogramVec, 0)
 
@@ -102,7 +148,27 @@ func (m *Monitor) registerGauges() {
 		[]string{"component"},
 	)
 
-	gauges = append(gauges, m.dependencyAvailability)
+	m.authzModelState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "authorization_model_state",
+			Help:        "1 for the state of the authorization model validation the instance is in, 0 for the others.",
+			ConstLabels: labels,
+		},
+		[]string{"state"},
+	)
+
+	m.buildInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "build_info",
+			Help:        "Always 1, labeled with the version, git commit, build date and Go version of the binary.",
+			ConstLabels: labels,
+		},
+		[]string{"version", "commit", "build_date", "go_version"},
+	)
+	info := version.Get()
+	m.buildInfo.WithLabelValues(info.Version, info.Commit, info.BuildDate, info.GoVersion).Set(1)
+
+	gauges = append(gauges, m.dependencyAvailability, m.authzModelState, m.buildInfo)
 
 	for _, gauge := range gauges {
 		err := prometheus.Register(gauge)
@@ -134,7 +200,34 @@ func (m *Monitor) registerCounters() {
 		[]string{"operation", "role"},
 	)
 
-	counters = append(counters, m.operationsTotal)
+	m.deprecatedUsageTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "deprecated_api_usage_total",
+			Help:        "Total number of calls relying on deprecated API features, partitioned by feature and client.",
+			ConstLabels: labels,
+		},
+		[]string{"feature", "client"},
+	)
+
+	m.authzDecisionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "authorization_decisions_total",
+			Help:        "Total number of authorization checks, partitioned by relation, decision and tenant.",
+			ConstLabels: labels,
+		},
+		[]string{"relation", "decision", "tenant"},
+	)
+
+	m.panicsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "panics_total",
+			Help:        "Total number of panics recovered while serving requests, partitioned by protocol.",
+			ConstLabels: labels,
+		},
+		[]string{"protocol"},
+	)
+
+	counters = append(counters, m.operationsTotal, m.deprecatedUsageTotal, m.authzDecisionsTotal, m.panicsTotal)
 
 	for _, counter := range counters {
 		err := prometheus.Register(counter)
@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+// ErrCircuitOpen is returned without calling OpenFGA while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("openfga is unavailable, circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker fails the OpenFGA calls fast once threshold consecutive calls
+// failed because OpenFGA was unreachable or erroring. After the cooldown a
+// single call is let through, its outcome closes or reopens the breaker.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// allow returns ErrCircuitOpen when the call must not reach OpenFGA.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		// a probe is in flight
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a call let through by allow.
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		if b.state == breakerHalfOpen {
+			// the probe was abandoned, the cooldown is over so the next call probes again
+			b.state = breakerOpen
+		}
+		return
+	}
+
+	if !isUnavailable(err) {
+		b.failures = 0
+		if b.state != breakerClosed {
+			b.state = breakerClosed
+			b.logger.Info("OpenFGA is available again, circuit breaker closed")
+			b.monitor.SetDependencyAvailability(map[string]string{"component": monitoring.OpenFGADependency}, 1)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state == breakerClosed {
+			b.logger.Errorw("OpenFGA is unavailable, circuit breaker opened", "failures", b.failures, "error", err)
+			b.monitor.SetDependencyAvailability(map[string]string{"component": monitoring.OpenFGADependency}, 0)
+		}
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Available reports whether OpenFGA calls are let through, it is false while
+// the breaker is open or probing.
+func (b *CircuitBreaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state == breakerClosed
+}
+
+// isUnavailable tells the errors caused by OpenFGA being down apart from the
+// ones it answered with, only the former count as breaker failures.
+func isUnavailable(err error) bool {
+	var internalErr openfga.FgaApiInternalError
+	var rateLimitErr openfga.FgaApiRateLimitExceededError
+	var apiErr openfga.FgaApiError
+	var validationErr openfga.FgaApiValidationError
+	var notFoundErr openfga.FgaApiNotFoundError
+	var authErr openfga.FgaApiAuthenticationError
+
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled):
+		// the caller went away, it says nothing about OpenFGA
+		return false
+	case errors.As(err, &internalErr):
+		return true
+	case errors.As(err, &rateLimitErr),
+		errors.As(err, &apiErr),
+		errors.As(err, &validationErr),
+		errors.As(err, &notFoundErr),
+		errors.As(err, &authErr):
+		return false
+	default:
+		// network errors and timeouts
+		return true
+	}
+}
+
+// NewCircuitBreaker returns a breaker opening after threshold consecutive
+// failures for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *CircuitBreaker {
+	b := new(CircuitBreaker)
+	b.threshold = threshold
+	b.cooldown = cooldown
+	b.now = time.Now
+	b.monitor = monitor
+	b.logger = logger
+
+	return b
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+
+	// reopening after a failed probe is not reported again
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).Times(1)
+	mockLogger.EXPECT().Info(gomock.Any()).Times(1)
+	gomock.InOrder(
+		mockMonitor.EXPECT().SetDependencyAvailability(map[string]string{"component": monitoring.OpenFGADependency}, float64(0)),
+		mockMonitor.EXPECT().SetDependencyAvailability(map[string]string{"component": monitoring.OpenFGADependency}, float64(1)),
+	)
+
+	now := time.Now()
+	b := NewCircuitBreaker(2, time.Minute, mockMonitor, mockLogger)
+	b.now = func() time.Time { return now }
+
+	down := errors.New("connection refused")
+
+	// answered errors do not count
+	b.record(openfga.FgaApiValidationError{})
+	b.record(down)
+	b.record(openfga.FgaApiNotFoundError{})
+	b.record(down)
+	if !b.Available() {
+		t.Fatal("expected breaker to stay closed after non consecutive failures")
+	}
+
+	b.record(down)
+	if b.Available() {
+		t.Fatal("expected breaker to open after consecutive failures")
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen got %v", err)
+	}
+
+	// the probe fails, the breaker reopens
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected a single probe got %v", err)
+	}
+	b.record(down)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen got %v", err)
+	}
+
+	// an abandoned probe lets the next call probe
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed got %v", err)
+	}
+	b.record(context.Canceled)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed got %v", err)
+	}
+
+	// the probe succeeds, the breaker closes
+	b.record(nil)
+	if !b.Available() {
+		t.Fatal("expected breaker to close after a successful probe")
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected call to be allowed got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -96,6 +97,30 @@ func (c *Client) ReadModel(ctx context.Context) (*openfga.AuthorizationModel, er
 	return authModel.AuthorizationModel, nil
 }
 
+// ReadLatestModel returns the model last written to the store, nil if there is none.
+func (c *Client) ReadLatestModel(ctx context.Context) (*openfga.AuthorizationModel, error) {
+	ctx, span := c.tracer.Start(ctx, "openfga.Client.ReadLatestModel")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// models are listed newest first
+	r, err := c.c.ReadAuthorizationModelsExecute(
+		c.c.ReadAuthorizationModels(ctx).Options(client.ClientReadAuthorizationModelsOptions{PageSize: openfga.PtrInt32(1)}),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.AuthorizationModels) == 0 {
+		return nil, nil
+	}
+
+	return &r.AuthorizationModels[0], nil
+}
+
 func (c *Client) WriteModel(ctx context.Context, authModel *client.ClientWriteAuthorizationModelRequest) (string, error) {
 	ctx, span := c.tracer.Start(ctx, "openfga.Client.WriteModel")
 	defer span.End()
@@ -160,9 +185,12 @@ func (c *Client) WriteTuple(ctx context.Context, user, relation, object string)
 			OnDuplicateWrites: client.CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE,
 		},
 	})
-	_, err := c.c.WriteExecute(r)
+	if _, err := c.c.WriteExecute(r); err != nil {
+		return err
+	}
 
-	return err
+	markWritten(ctx)
+	return nil
 }
 
 func (c *Client) DeleteTuple(ctx context.Context, user, relation, object string) error {
@@ -180,51 +208,72 @@ func (c *Client) DeleteTuple(ctx context.Context, user, relation, object string)
 			OnMissingDeletes: client.CLIENT_WRITE_REQUEST_ON_MISSING_DELETES_IGNORE,
 		},
 	})
-	_, err := c.c.WriteExecute(r)
+	if _, err := c.c.WriteExecute(r); err != nil {
+		return err
+	}
 
-	return err
+	markWritten(ctx)
+	return nil
 }
 
+// MaxTuplesPerWrite is the number of writes and deletes OpenFGA accepts in a single request.
+const MaxTuplesPerWrite = 100
+
+// WriteTuples writes the tuples in chunks of MaxTuplesPerWrite, each chunk is
+// applied atomically but a failure leaves the preceding chunks written.
 func (c *Client) WriteTuples(ctx context.Context, tuples ...Tuple) error {
 	ctx, span := c.tracer.Start(ctx, "openfga.Client.WriteTuples")
 	defer span.End()
 
-	ts := make([]openfga.TupleKey, len(tuples))
+	for start := 0; start < len(tuples); start += MaxTuplesPerWrite {
+		chunk := tuples[start:min(start+MaxTuplesPerWrite, len(tuples))]
 
-	for i, tuple := range tuples {
-		ts[i] = *openfga.NewTupleKey(tuple.Values())
-	}
+		ts := make([]openfga.TupleKey, len(chunk))
+		for i, tuple := range chunk {
+			ts[i] = *openfga.NewTupleKey(tuple.Values())
+		}
 
-	r := c.c.Write(ctx)
-	body := client.ClientWriteRequest{
-		Writes: ts,
-	}
+		r := c.c.Write(ctx)
+		body := client.ClientWriteRequest{
+			Writes: ts,
+		}
 
-	r = r.Body(body)
-	_, err := c.c.WriteExecute(r)
+		r = r.Body(body)
+		if _, err := c.c.WriteExecute(r); err != nil {
+			return err
+		}
+		markWritten(ctx)
+	}
 
-	return err
+	return nil
 }
 
+// DeleteTuples deletes the tuples in chunks of MaxTuplesPerWrite, see WriteTuples.
 func (c *Client) DeleteTuples(ctx context.Context, tuples ...Tuple) error {
 	ctx, span := c.tracer.Start(ctx, "openfga.Client.DeleteTuples")
 	defer span.End()
 
-	ts := make([]openfga.TupleKeyWithoutCondition, 0)
+	for start := 0; start < len(tuples); start += MaxTuplesPerWrite {
+		chunk := tuples[start:min(start+MaxTuplesPerWrite, len(tuples))]
 
-	for _, tuple := range tuples {
-		ts = append(ts, *openfga.NewTupleKeyWithoutCondition(tuple.Values()))
-	}
+		ts := make([]openfga.TupleKeyWithoutCondition, 0, len(chunk))
+		for _, tuple := range chunk {
+			ts = append(ts, *openfga.NewTupleKeyWithoutCondition(tuple.Values()))
+		}
 
-	r := c.c.Write(ctx)
-	body := client.ClientWriteRequest{
-		Deletes: ts,
-	}
+		r := c.c.Write(ctx)
+		body := client.ClientWriteRequest{
+			Deletes: ts,
+		}
 
-	r = r.Body(body)
-	_, err := c.c.WriteExecute(r)
+		r = r.Body(body)
+		if _, err := c.c.WriteExecute(r); err != nil {
+			return err
+		}
+		markWritten(ctx)
+	}
 
-	return err
+	return nil
 }
 
 // ########################## Write Operations #######################################
@@ -251,6 +300,9 @@ func (c *Client) Check(ctx context.Context, user, relation, object string, tuple
 	}
 
 	r = r.Body(body)
+	if preference := consistency(ctx); preference != nil {
+		r = r.Options(client.ClientCheckOptions{Consistency: preference})
+	}
 
 	check, err := c.c.CheckExecute(r)
 	if err != nil {
@@ -296,6 +348,7 @@ func (c *Client) BatchCheck(ctx context.Context, tuples ...TupleWithContext) (bo
 	options := client.BatchCheckOptions{
 		// You can rely on the model id set in the configuration or override it for this specific request
 		AuthorizationModelId: &modelID,
+		Consistency:          consistency(ctx),
 	}
 
 	r := c.c.BatchCheck(ctx).Options(options).Body(body)
@@ -325,6 +378,58 @@ func (c *Client) BatchCheck(ctx context.Context, tuples ...TupleWithContext) (bo
 	return allowed, nil
 }
 
+// BatchCheckEach runs the checks in one request and returns their results in
+// the order of tuples, a check failing to evaluate fails the whole call.
+func (c *Client) BatchCheckEach(ctx context.Context, tuples ...TupleWithContext) ([]bool, error) {
+	ctx, span := c.tracer.Start(ctx, "openfga.Client.BatchCheckEach")
+	defer span.End()
+
+	modelID, err := c.c.GetAuthorizationModelId()
+	if err != nil {
+		return nil, err
+	}
+
+	body := client.ClientBatchCheckRequest{Checks: make([]client.ClientBatchCheckItem, len(tuples))}
+	for i, t := range tuples {
+		ctxTuples := make([]client.ClientContextualTupleKey, len(t.ContextualTuples))
+		for j, ct := range t.ContextualTuples {
+			ctxTuples[j] = client.ClientContextualTupleKey{
+				User:     ct.User,
+				Relation: ct.Relation,
+				Object:   ct.Object,
+			}
+		}
+		// the correlation ID maps the results back to the tuples
+		body.Checks[i] = client.ClientBatchCheckItem{
+			User:             t.User,
+			Relation:         t.Relation,
+			Object:           t.Object,
+			CorrelationId:    strconv.Itoa(i),
+			ContextualTuples: ctxTuples,
+		}
+	}
+
+	r := c.c.BatchCheck(ctx).Options(client.BatchCheckOptions{AuthorizationModelId: &modelID, Consistency: consistency(ctx)}).Body(body)
+	data, err := c.c.BatchCheckExecute(r)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make([]bool, len(tuples))
+	for i := range tuples {
+		check, ok := data.GetResult()[strconv.Itoa(i)]
+		if !ok {
+			return nil, fmt.Errorf("missing result for check %d", i)
+		}
+		if check.Error != nil {
+			return nil, fmt.Errorf("error while performing check %d: %s", i, check.Error.GetMessage())
+		}
+		allowed[i] = check.GetAllowed()
+	}
+
+	return allowed, nil
+}
+
 // ########################## Check Operations #######################################
 
 // ########################## Read Operations #######################################
@@ -360,6 +465,9 @@ func (c *Client) ListObjects(ctx context.Context, user, relation, objectType str
 		Type:     objectType,
 	}
 	r = r.Body(body)
+	if preference := consistency(ctx); preference != nil {
+		r = r.Options(client.ClientListObjectsOptions{Consistency: preference})
+	}
 	objectsResponse, err := c.c.ListObjectsExecute(r)
 	if err != nil {
 		c.logger.Errorf("issues performing list operation: %s", err)
@@ -376,6 +484,47 @@ func (c *Client) ListObjects(ctx context.Context, user, relation, objectType str
 	return allowedObjs, nil
 }
 
+// StreamObjects calls fn with the ID of each object of objectType the user
+// holds relation on, as OpenFGA streams them, until fn returns false. Unlike
+// ListObjects the result is not bounded by the OpenFGA list limits.
+func (c *Client) StreamObjects(ctx context.Context, user, relation, objectType string, fn func(id string) bool) error {
+	ctx, span := c.tracer.Start(ctx, "openfga.Client.StreamObjects")
+	defer span.End()
+
+	r := c.c.StreamedListObjects(ctx)
+
+	body := client.ClientStreamedListObjectsRequest{
+		User:     user,
+		Relation: relation,
+		Type:     objectType,
+	}
+	r = r.Body(body)
+	if preference := consistency(ctx); preference != nil {
+		r = r.Options(client.ClientStreamedListObjectsOptions{Consistency: preference})
+	}
+	stream, err := c.c.StreamedListObjectsExecute(r)
+	if err != nil {
+		c.logger.Errorf("issues performing streamed list operation: %s", err)
+		return err
+	}
+	// stops the stream when fn returns early
+	defer stream.Close()
+
+	prefix := objectType + ":"
+	for o := range stream.Objects {
+		if !fn(strings.TrimPrefix(o.Object, prefix)) {
+			return nil
+		}
+	}
+
+	// the errors channel is closed once the stream ends, nil when it succeeded
+	if err := <-stream.Errors; err != nil {
+		c.logger.Errorf("issues performing streamed list operation: %s", err)
+		return err
+	}
+	return nil
+}
+
 func (c *Client) ListUsers(ctx context.Context, userFilter, relation, object string) ([]string, error) {
 	ctx, span := c.tracer.Start(ctx, "openfga.Client.ListUsers")
 	defer span.End()
@@ -398,6 +547,9 @@ func (c *Client) ListUsers(ctx context.Context, userFilter, relation, object str
 		Relation:    relation,
 		UserFilters: []openfga.UserTypeFilter{filter},
 	})
+	if preference := consistency(ctx); preference != nil {
+		listUsersReq = listUsersReq.Options(client.ClientListUsersOptions{Consistency: preference})
+	}
 
 	usersResponse, err := c.c.ListUsersExecute(listUsersReq)
 	if err != nil {
@@ -437,27 +589,46 @@ func NewClient(cfg *Config) *Client {
 		panic("OpenFGA config missing")
 	}
 
-	fga, err := client.NewSdkClient(
-		&client.ClientConfiguration{
-			ApiScheme: cfg.ApiScheme,
-			ApiHost:   cfg.ApiHost,
-			StoreId:   cfg.StoreID,
-			Credentials: &credentials.Credentials{
-				Method: credentials.CredentialsMethodApiToken,
-				Config: &credentials.Config{
-					ApiToken: cfg.ApiToken,
-				},
+	conf := &client.ClientConfiguration{
+		ApiScheme: cfg.ApiScheme,
+		ApiHost:   cfg.ApiHost,
+		StoreId:   cfg.StoreID,
+		Credentials: &credentials.Credentials{
+			Method: credentials.CredentialsMethodApiToken,
+			Config: &credentials.Config{
+				ApiToken: cfg.ApiToken,
 			},
-			AuthorizationModelId: cfg.AuthModelID,
-			Debug:                cfg.Debug,
-			Telemetry:            telemetry.DefaultTelemetryConfiguration(),
 		},
-	)
+		AuthorizationModelId: cfg.AuthModelID,
+		Debug:                cfg.Debug,
+		Telemetry:            telemetry.DefaultTelemetryConfiguration(),
+	}
+
+	if cfg.HTTPClient != nil {
+		// the SDK only injects the API token into the client it builds itself
+		conf.HTTPClient = cfg.HTTPClient
+		conf.Credentials = nil
+		conf.DefaultHeaders = map[string]string{"Authorization": "Bearer " + cfg.ApiToken}
+	}
+
+	if cfg.Retry != nil {
+		// MinWaitInMs is unused without retries but must be valid
+		conf.RetryParams = &openfga.RetryParams{MaxRetry: 0, MinWaitInMs: 100}
+	}
+
+	fga, err := client.NewSdkClient(conf)
 	if err != nil {
 		panic(fmt.Sprintf("issues setting up OpenFGA client %s", err))
 	}
 
 	c.c = fga
+	if cfg.LatencyObserver != nil {
+		c.c = &latencyClient{OpenFGACoreClientInterface: fga, observer: cfg.LatencyObserver}
+	}
+	if cfg.Retry != nil || cfg.Breaker != nil {
+		// wraps the latency client so that every attempt is observed
+		c.c = &resilientClient{OpenFGACoreClientInterface: c.c, retry: cfg.Retry, breaker: cfg.Breaker}
+	}
 	c.tracer = cfg.Tracer
 	c.monitor = cfg.Monitor
 	c.logger = cfg.Logger
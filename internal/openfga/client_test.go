@@ -21,7 +21,7 @@ import (
 
 //go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
 //go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_client.go -source=./interfaces.go
-//go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_openfga_client.go github.com/openfga/go-sdk/client SdkClientListObjectsRequestInterface,SdkClientReadRequestInterface,SdkClientWriteRequestInterface,SdkClientBatchCheckRequestInterface
+//go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_openfga_client.go github.com/openfga/go-sdk/client SdkClientListObjectsRequestInterface,SdkClientReadRequestInterface,SdkClientWriteRequestInterface,SdkClientBatchCheckRequestInterface,SdkClientStreamedListObjectsRequestInterface
 //go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
 //go:generate mockgen -build_flags=--mod=mod -package openfga -destination ./mock_tracing.go -source=../tracing/interfaces.go
 
@@ -183,6 +183,90 @@ func TestClientListObjectsFails(t *testing.T) {
 	}
 }
 
+func TestClientStreamObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		streamed  []string
+		streamErr error
+		stopAt    string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "full stream",
+			streamed: []string{"group:test", "group:admin"},
+			expected: []string{"test", "admin"},
+		},
+		{
+			name:     "stopped early",
+			streamed: []string{"group:test", "group:admin", "group:other"},
+			stopAt:   "admin",
+			expected: []string{"test", "admin"},
+		},
+		{
+			name:      "stream error",
+			streamed:  []string{"group:test"},
+			streamErr: fmt.Errorf("stream error"),
+			expected:  []string{"test"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+			mockOpenFGAClient := NewMockOpenFGACoreClientInterface(ctrl)
+			mockRequest := NewMockSdkClientStreamedListObjectsRequestInterface(ctrl)
+
+			c := Client{
+				c:       mockOpenFGAClient,
+				tracer:  mockTracer,
+				monitor: mockMonitor,
+				logger:  mockLogger,
+			}
+
+			objects := make(chan openfga.StreamedListObjectsResponse, len(test.streamed))
+			for _, o := range test.streamed {
+				objects <- openfga.StreamedListObjectsResponse{Object: o}
+			}
+			close(objects)
+			errs := make(chan error, 1)
+			if test.streamErr != nil {
+				errs <- test.streamErr
+			}
+			close(errs)
+
+			body := client.ClientStreamedListObjectsRequest{User: "user:me", Relation: "member", Type: "group"}
+
+			mockTracer.EXPECT().Start(gomock.Any(), "openfga.Client.StreamObjects").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockOpenFGAClient.EXPECT().StreamedListObjects(gomock.Any()).Return(mockRequest)
+			mockRequest.EXPECT().Body(body).Return(mockRequest)
+			mockOpenFGAClient.EXPECT().StreamedListObjectsExecute(mockRequest).Times(1).Return(&client.ClientStreamedListObjectsResponse{Objects: objects, Errors: errs}, nil)
+			if test.expectErr {
+				mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).Times(1)
+			}
+
+			var r []string
+			err := c.StreamObjects(context.TODO(), "user:me", "member", "group", func(id string) bool {
+				r = append(r, id)
+				return id != test.stopAt
+			})
+
+			if (err != nil) != test.expectErr {
+				t.Errorf("expected error %v, got %v", test.expectErr, err)
+			}
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("Objects streamed %v, compared %v", r, test.expected)
+			}
+		})
+	}
+}
+
 func TestClientReadTuplesSuccess(t *testing.T) {
 	type input struct {
 		user     string
@@ -411,6 +495,78 @@ func TestClientWriteTuplesFails(t *testing.T) {
 	}
 }
 
+func TestClientWriteTuplesChunks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+	mockOpenFGAClient := NewMockOpenFGACoreClientInterface(ctrl)
+	mockRequest := NewMockSdkClientWriteRequestInterface(ctrl)
+
+	c := Client{
+		c:       mockOpenFGAClient,
+		tracer:  mockTracer,
+		monitor: mockMonitor,
+		logger:  mockLogger,
+	}
+
+	tuples := make([]Tuple, 2*MaxTuplesPerWrite+1)
+	for i := range tuples {
+		tuples[i] = *NewTuple("user:"+strconv.Itoa(i), "member", "tenant:xyz")
+	}
+
+	var sizes []int
+	mockTracer.EXPECT().Start(gomock.Any(), "openfga.Client.WriteTuples").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockOpenFGAClient.EXPECT().Write(gomock.Any()).Times(3).Return(mockRequest)
+	mockRequest.EXPECT().Body(gomock.Any()).Times(3).DoAndReturn(func(body client.ClientWriteRequest) client.SdkClientWriteRequestInterface {
+		sizes = append(sizes, len(body.Writes))
+		return mockRequest
+	})
+	mockOpenFGAClient.EXPECT().WriteExecute(mockRequest).Times(3).Return(nil, nil)
+
+	if err := c.WriteTuples(context.TODO(), tuples...); err != nil {
+		t.Fatalf("error while calling WriteTuples %s", err)
+	}
+
+	if !reflect.DeepEqual(sizes, []int{MaxTuplesPerWrite, MaxTuplesPerWrite, 1}) {
+		t.Fatalf("expected chunks of %d, got %v", MaxTuplesPerWrite, sizes)
+	}
+}
+
+func TestClientDeleteTuplesStopsOnChunkFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+	mockOpenFGAClient := NewMockOpenFGACoreClientInterface(ctrl)
+	mockRequest := NewMockSdkClientWriteRequestInterface(ctrl)
+
+	c := Client{
+		c:       mockOpenFGAClient,
+		tracer:  mockTracer,
+		monitor: mockMonitor,
+		logger:  mockLogger,
+	}
+
+	tuples := make([]Tuple, 2*MaxTuplesPerWrite)
+	for i := range tuples {
+		tuples[i] = *NewTuple("user:"+strconv.Itoa(i), "member", "tenant:xyz")
+	}
+
+	mockTracer.EXPECT().Start(gomock.Any(), "openfga.Client.DeleteTuples").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockOpenFGAClient.EXPECT().Write(gomock.Any()).Times(1).Return(mockRequest)
+	mockRequest.EXPECT().Body(gomock.Any()).Times(1).Return(mockRequest)
+	mockOpenFGAClient.EXPECT().WriteExecute(mockRequest).Times(1).Return(nil, fmt.Errorf("error"))
+
+	if err := c.DeleteTuples(context.TODO(), tuples...); err == nil {
+		t.Errorf("expected error while calling DeleteTuples")
+	}
+}
+
 func TestClientDeleteTuplesSuccess(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -624,3 +780,56 @@ func TestClientWriteBatchCheckSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestClientBatchCheckEach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+	mockOpenFGAClient := NewMockOpenFGACoreClientInterface(ctrl)
+	mockRequest := NewMockSdkClientBatchCheckRequestInterface(ctrl)
+
+	c := Client{
+		c:       mockOpenFGAClient,
+		tracer:  mockTracer,
+		monitor: mockMonitor,
+		logger:  mockLogger,
+	}
+	modelID := "testModel12345"
+
+	input := []TupleWithContext{
+		*NewTupleWithContext("user:me", "owner", "tenant:1", nil),
+		*NewTupleWithContext("user:me", "member", "tenant:1", nil),
+	}
+	body := client.ClientBatchCheckRequest{Checks: []client.ClientBatchCheckItem{
+		{User: "user:me", Relation: "owner", Object: "tenant:1", CorrelationId: "0", ContextualTuples: []client.ClientContextualTupleKey{}},
+		{User: "user:me", Relation: "member", Object: "tenant:1", CorrelationId: "1", ContextualTuples: []client.ClientContextualTupleKey{}},
+	}}
+
+	mockTracer.EXPECT().Start(gomock.Any(), "openfga.Client.BatchCheckEach").Times(1).Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+	mockOpenFGAClient.EXPECT().GetAuthorizationModelId().Return(modelID, nil)
+	mockOpenFGAClient.EXPECT().BatchCheck(gomock.Any()).Return(mockRequest)
+	mockRequest.EXPECT().Options(client.BatchCheckOptions{AuthorizationModelId: &modelID}).Return(mockRequest)
+	mockRequest.EXPECT().Body(body).Return(mockRequest)
+	mockOpenFGAClient.EXPECT().BatchCheckExecute(mockRequest).Times(1).DoAndReturn(
+		func(client.SdkClientBatchCheckRequestInterface) (*openfga.BatchCheckResponse, error) {
+			result := map[string]openfga.BatchCheckSingleResult{
+				"0": {Allowed: openfga.PtrBool(false)},
+				"1": {Allowed: openfga.PtrBool(true)},
+			}
+			return &openfga.BatchCheckResponse{Result: &result}, nil
+		},
+	)
+
+	allowed, err := c.BatchCheckEach(context.TODO(), input...)
+	if err != nil {
+		t.Fatalf("error while calling BatchCheckEach %s", err)
+	}
+
+	// results follow the order of the input, not of the map
+	if !reflect.DeepEqual(allowed, []bool{false, true}) {
+		t.Errorf("unexpected output while calling BatchCheckEach %v", allowed)
+	}
+}
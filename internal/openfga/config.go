@@ -4,6 +4,8 @@
 package openfga
 
 import (
+	"net/http"
+
 	validator "github.com/go-playground/validator/v10"
 
 	"github.com/canonical/tenant-service/internal/logging"
@@ -19,6 +21,18 @@ type Config struct {
 	AuthModelID string `validate:"required"`
 	Debug       bool
 
+	// HTTPClient optionally replaces the client built by the SDK, e.g. to trust a private CA
+	HTTPClient *http.Client
+
+	// LatencyObserver optionally receives the duration of the OpenFGA calls
+	LatencyObserver monitoring.LatencyObserverInterface
+
+	// Retry optionally replaces the SDK retries with retries of the idempotent calls only
+	Retry *RetryPolicy
+
+	// Breaker optionally fails the calls fast while OpenFGA is down
+	Breaker *CircuitBreaker
+
 	Tracer  tracing.TracingInterface
 	Monitor monitoring.MonitorInterface
 	Logger  logging.LoggerInterface
@@ -0,0 +1,74 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	openfga "github.com/openfga/go-sdk"
+	"google.golang.org/grpc"
+)
+
+const (
+	// MinimizeLatency lets OpenFGA answer from its cache, which may miss recent writes
+	MinimizeLatency = openfga.CONSISTENCYPREFERENCE_MINIMIZE_LATENCY
+	// HigherConsistency makes OpenFGA skip its cache and see every committed write
+	HigherConsistency = openfga.CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY
+)
+
+type consistencyKey struct{}
+type flowKey struct{}
+
+// flow tracks whether tuples were written while serving a request.
+type flow struct {
+	written atomic.Bool
+}
+
+// WithConsistency sets the consistency of the OpenFGA queries made with ctx,
+// overriding the one chosen for the request flow.
+func WithConsistency(ctx context.Context, preference openfga.ConsistencyPreference) context.Context {
+	return context.WithValue(ctx, consistencyKey{}, preference)
+}
+
+// WithFlow starts a request flow, queries made with ctx after it wrote tuples
+// use HigherConsistency so that they see the writes.
+func WithFlow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, flowKey{}, new(flow))
+}
+
+// ConsistencyMiddleware starts a request flow for every HTTP request.
+func ConsistencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithFlow(r.Context())))
+	})
+}
+
+// ConsistencyInterceptor starts a request flow for every unary gRPC call.
+func ConsistencyInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(WithFlow(ctx), req)
+}
+
+// markWritten records that the request flow of ctx wrote tuples.
+func markWritten(ctx context.Context) {
+	if f, ok := ctx.Value(flowKey{}).(*flow); ok {
+		f.written.Store(true)
+	}
+}
+
+// consistency returns the preference of the queries made with ctx, nil leaves
+// the OpenFGA default, MinimizeLatency.
+func consistency(ctx context.Context) *openfga.ConsistencyPreference {
+	if p, ok := ctx.Value(consistencyKey{}).(openfga.ConsistencyPreference); ok {
+		return &p
+	}
+
+	if f, ok := ctx.Value(flowKey{}).(*flow); ok && f.written.Load() {
+		p := HigherConsistency
+		return &p
+	}
+
+	return nil
+}
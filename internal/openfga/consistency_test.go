@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+func TestClientListObjectsConsistency(t *testing.T) {
+	higher := HigherConsistency
+	lower := MinimizeLatency
+
+	tests := []struct {
+		name     string
+		ctx      func() context.Context
+		write    bool
+		expected *openfga.ConsistencyPreference
+	}{
+		{
+			name: "no flow",
+			ctx:  context.Background,
+		},
+		{
+			name: "flow without writes",
+			ctx:  func() context.Context { return WithFlow(context.Background()) },
+		},
+		{
+			name:     "flow after a write",
+			ctx:      func() context.Context { return WithFlow(context.Background()) },
+			write:    true,
+			expected: &higher,
+		},
+		{
+			name:  "write outside a flow",
+			ctx:   context.Background,
+			write: true,
+		},
+		{
+			name:     "explicit preference",
+			ctx:      func() context.Context { return WithConsistency(WithFlow(context.Background()), MinimizeLatency) },
+			write:    true,
+			expected: &lower,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+			mockOpenFGAClient := NewMockOpenFGACoreClientInterface(ctrl)
+			mockWriteRequest := NewMockSdkClientWriteRequestInterface(ctrl)
+			mockListRequest := NewMockSdkClientListObjectsRequestInterface(ctrl)
+
+			c := Client{
+				c:       mockOpenFGAClient,
+				tracer:  mockTracer,
+				monitor: mockMonitor,
+				logger:  mockLogger,
+			}
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+					return ctx, trace.SpanFromContext(ctx)
+				},
+			)
+
+			ctx := test.ctx()
+
+			if test.write {
+				mockOpenFGAClient.EXPECT().Write(gomock.Any()).Return(mockWriteRequest)
+				mockWriteRequest.EXPECT().Body(gomock.Any()).Return(mockWriteRequest)
+				mockOpenFGAClient.EXPECT().WriteExecute(mockWriteRequest).Return(nil, nil)
+
+				if err := c.WriteTuples(ctx, *NewTuple("user:me", "member", "tenant:t1")); err != nil {
+					t.Fatalf("error while calling WriteTuples %s", err)
+				}
+			}
+
+			mockOpenFGAClient.EXPECT().ListObjects(gomock.Any()).Return(mockListRequest)
+			mockListRequest.EXPECT().Body(gomock.Any()).Return(mockListRequest)
+			if test.expected != nil {
+				mockListRequest.EXPECT().Options(client.ClientListObjectsOptions{Consistency: test.expected}).Return(mockListRequest)
+			}
+			mockOpenFGAClient.EXPECT().ListObjectsExecute(mockListRequest).Return(&client.ClientListObjectsResponse{}, nil)
+
+			if _, err := c.ListObjects(ctx, "user:me", "member", "tenant"); err != nil {
+				t.Fatalf("error while calling ListObjects %s", err)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ type OpenFGACoreClientInterface interface {
 	WriteExecute(client.SdkClientWriteRequestInterface) (*client.ClientWriteResponse, error)
 	ListObjects(context.Context) client.SdkClientListObjectsRequestInterface
 	ListObjectsExecute(client.SdkClientListObjectsRequestInterface) (*client.ClientListObjectsResponse, error)
+	StreamedListObjects(context.Context) client.SdkClientStreamedListObjectsRequestInterface
+	StreamedListObjectsExecute(client.SdkClientStreamedListObjectsRequestInterface) (*client.ClientStreamedListObjectsResponse, error)
 	ListUsers(context.Context) client.SdkClientListUsersRequestInterface
 	ListUsersExecute(client.SdkClientListUsersRequestInterface) (*client.ClientListUsersResponse, error)
 }
@@ -39,6 +41,7 @@ type OpenFGAClientInterface interface {
 	ReadModel(context.Context) (*openfga.AuthorizationModel, error)
 	CompareModel(context.Context, openfga.AuthorizationModel) (bool, error)
 	ListObjects(context.Context, string, string, string) ([]string, error)
+	StreamObjects(ctx context.Context, user, relation, objectType string, fn func(id string) bool) error
 	ListUsers(context.Context, string, string, string) ([]string, error)
 	ReadTuples(context.Context, string, string, string, string) (*client.ClientReadResponse, error)
 	WriteTuple(ctx context.Context, user, relation, object string) error
@@ -0,0 +1,60 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+// latencyClient reports the duration of the OpenFGA calls made while serving requests
+type latencyClient struct {
+	OpenFGACoreClientInterface
+
+	observer monitoring.LatencyObserverInterface
+}
+
+func (c *latencyClient) observe(start time.Time) {
+	c.observer.ObserveLatency(monitoring.OpenFGADependency, time.Since(start))
+}
+
+func (c *latencyClient) ReadExecute(r client.SdkClientReadRequestInterface) (*client.ClientReadResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.ReadExecute(r)
+}
+
+func (c *latencyClient) CheckExecute(r client.SdkClientCheckRequestInterface) (*client.ClientCheckResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.CheckExecute(r)
+}
+
+func (c *latencyClient) BatchCheckExecute(r client.SdkClientBatchCheckRequestInterface) (*openfga.BatchCheckResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.BatchCheckExecute(r)
+}
+
+func (c *latencyClient) WriteExecute(r client.SdkClientWriteRequestInterface) (*client.ClientWriteResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.WriteExecute(r)
+}
+
+func (c *latencyClient) ListObjectsExecute(r client.SdkClientListObjectsRequestInterface) (*client.ClientListObjectsResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.ListObjectsExecute(r)
+}
+
+// StreamedListObjectsExecute observes the time taken to open the stream.
+func (c *latencyClient) StreamedListObjectsExecute(r client.SdkClientStreamedListObjectsRequestInterface) (*client.ClientStreamedListObjectsResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.StreamedListObjectsExecute(r)
+}
+
+func (c *latencyClient) ListUsersExecute(r client.SdkClientListUsersRequestInterface) (*client.ClientListUsersResponse, error) {
+	defer c.observe(time.Now())
+	return c.OpenFGACoreClientInterface.ListUsersExecute(r)
+}
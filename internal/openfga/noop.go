@@ -34,6 +34,10 @@ func (c *NoopClient) ListObjects(ctx context.Context, user, relation, objectType
 	return make([]string, 0), nil
 }
 
+func (c *NoopClient) StreamObjects(ctx context.Context, user, relation, objectType string, fn func(id string) bool) error {
+	return nil
+}
+
 func (c *NoopClient) ListUsers(ctx context.Context, userFilter, relation, object string) ([]string, error) {
 	return make([]string, 0), nil
 }
@@ -46,6 +50,14 @@ func (c *NoopClient) BatchCheck(ctx context.Context, tuples ...TupleWithContext)
 	return true, nil
 }
 
+func (c *NoopClient) BatchCheckEach(ctx context.Context, tuples ...TupleWithContext) ([]bool, error) {
+	allowed := make([]bool, len(tuples))
+	for i := range allowed {
+		allowed[i] = true
+	}
+	return allowed, nil
+}
+
 func (c *NoopClient) WriteTuple(ctx context.Context, user, relation, object string) error {
 	return nil
 }
@@ -0,0 +1,156 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+)
+
+// RetryPolicy replaces the SDK retries, which also replay writes. Only the
+// calls without side effects are retried.
+type RetryPolicy struct {
+	// MaxAttempts bounds the calls made, the first one included
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled on every retry
+	Backoff time.Duration
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+}
+
+// wait returns the backoff after the given attempt, with jitter so that
+// instances failing together do not retry together.
+func (p *RetryPolicy) wait(attempt int) time.Duration {
+	d := p.Backoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+func isRetryable(err error) bool {
+	var rateLimitErr openfga.FgaApiRateLimitExceededError
+
+	return errors.As(err, &rateLimitErr) || isUnavailable(err)
+}
+
+// resilientClient retries the idempotent OpenFGA calls and routes every call
+// through the circuit breaker, either can be nil.
+type resilientClient struct {
+	OpenFGACoreClientInterface
+
+	retry   *RetryPolicy
+	breaker *CircuitBreaker
+}
+
+func call[T any](c *resilientClient, ctx context.Context, idempotent bool, f func() (T, error)) (T, error) {
+	maxAttempts := 1
+	if idempotent && c.retry != nil {
+		maxAttempts = c.retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.breaker != nil {
+			if err := c.breaker.allow(); err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+
+		r, err := f()
+
+		if c.breaker != nil {
+			c.breaker.record(err)
+		}
+
+		if err == nil || attempt >= maxAttempts || !isRetryable(err) {
+			return r, err
+		}
+
+		t := time.NewTimer(c.retry.wait(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return r, err
+		case <-t.C:
+		}
+	}
+}
+
+func (c *resilientClient) CreateStoreExecute(r client.SdkClientCreateStoreRequestInterface) (*client.ClientCreateStoreResponse, error) {
+	return call(c, r.GetContext(), false, func() (*client.ClientCreateStoreResponse, error) {
+		return c.OpenFGACoreClientInterface.CreateStoreExecute(r)
+	})
+}
+
+func (c *resilientClient) ReadAuthorizationModelExecute(r client.SdkClientReadAuthorizationModelRequestInterface) (*client.ClientReadAuthorizationModelResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientReadAuthorizationModelResponse, error) {
+		return c.OpenFGACoreClientInterface.ReadAuthorizationModelExecute(r)
+	})
+}
+
+func (c *resilientClient) ReadAuthorizationModelsExecute(r client.SdkClientReadAuthorizationModelsRequestInterface) (*client.ClientReadAuthorizationModelsResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientReadAuthorizationModelsResponse, error) {
+		return c.OpenFGACoreClientInterface.ReadAuthorizationModelsExecute(r)
+	})
+}
+
+func (c *resilientClient) WriteAuthorizationModelExecute(r client.SdkClientWriteAuthorizationModelRequestInterface) (*client.ClientWriteAuthorizationModelResponse, error) {
+	return call(c, r.GetContext(), false, func() (*client.ClientWriteAuthorizationModelResponse, error) {
+		return c.OpenFGACoreClientInterface.WriteAuthorizationModelExecute(r)
+	})
+}
+
+func (c *resilientClient) ReadExecute(r client.SdkClientReadRequestInterface) (*client.ClientReadResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientReadResponse, error) {
+		return c.OpenFGACoreClientInterface.ReadExecute(r)
+	})
+}
+
+func (c *resilientClient) CheckExecute(r client.SdkClientCheckRequestInterface) (*client.ClientCheckResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientCheckResponse, error) {
+		return c.OpenFGACoreClientInterface.CheckExecute(r)
+	})
+}
+
+func (c *resilientClient) BatchCheckExecute(r client.SdkClientBatchCheckRequestInterface) (*openfga.BatchCheckResponse, error) {
+	return call(c, r.GetContext(), true, func() (*openfga.BatchCheckResponse, error) {
+		return c.OpenFGACoreClientInterface.BatchCheckExecute(r)
+	})
+}
+
+func (c *resilientClient) WriteExecute(r client.SdkClientWriteRequestInterface) (*client.ClientWriteResponse, error) {
+	return call(c, r.GetContext(), false, func() (*client.ClientWriteResponse, error) {
+		return c.OpenFGACoreClientInterface.WriteExecute(r)
+	})
+}
+
+func (c *resilientClient) ListObjectsExecute(r client.SdkClientListObjectsRequestInterface) (*client.ClientListObjectsResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientListObjectsResponse, error) {
+		return c.OpenFGACoreClientInterface.ListObjectsExecute(r)
+	})
+}
+
+// StreamedListObjectsExecute only retries opening the stream, a stream
+// failing midway is not replayed.
+func (c *resilientClient) StreamedListObjectsExecute(r client.SdkClientStreamedListObjectsRequestInterface) (*client.ClientStreamedListObjectsResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientStreamedListObjectsResponse, error) {
+		return c.OpenFGACoreClientInterface.StreamedListObjectsExecute(r)
+	})
+}
+
+func (c *resilientClient) ListUsersExecute(r client.SdkClientListUsersRequestInterface) (*client.ClientListUsersResponse, error) {
+	return call(c, r.GetContext(), true, func() (*client.ClientListUsersResponse, error) {
+		return c.OpenFGACoreClientInterface.ListUsersExecute(r)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+func TestResilientClientRetries(t *testing.T) {
+	down := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		errs     []error
+		expected int
+		fails    bool
+	}{
+		{
+			name:     "success",
+			errs:     []error{nil},
+			expected: 1,
+		},
+		{
+			name:     "recovers after network errors",
+			errs:     []error{down, openfga.FgaApiInternalError{}, nil},
+			expected: 3,
+		},
+		{
+			name:     "retries rate limited calls",
+			errs:     []error{openfga.FgaApiRateLimitExceededError{}, nil},
+			expected: 2,
+		},
+		{
+			name:     "gives up after max attempts",
+			errs:     []error{down, down, down},
+			expected: 3,
+			fails:    true,
+		},
+		{
+			name:     "does not retry answered errors",
+			errs:     []error{openfga.FgaApiValidationError{}},
+			expected: 1,
+			fails:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := NewMockOpenFGACoreClientInterface(ctrl)
+			mockRequest := NewMockSdkClientReadRequestInterface(ctrl)
+
+			mockRequest.EXPECT().GetContext().Return(context.TODO()).AnyTimes()
+			for _, err := range test.errs {
+				mockClient.EXPECT().ReadExecute(mockRequest).Return(new(client.ClientReadResponse), err)
+			}
+
+			c := &resilientClient{
+				OpenFGACoreClientInterface: mockClient,
+				retry:                      &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			}
+
+			_, err := c.ReadExecute(mockRequest)
+			if (err != nil) != test.fails {
+				t.Fatalf("expected failure %v got %v", test.fails, err)
+			}
+		})
+	}
+}
+
+func TestResilientClientDoesNotRetryWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockOpenFGACoreClientInterface(ctrl)
+	mockRequest := NewMockSdkClientWriteRequestInterface(ctrl)
+
+	mockRequest.EXPECT().GetContext().Return(context.TODO()).AnyTimes()
+	mockClient.EXPECT().WriteExecute(mockRequest).Times(1).Return(nil, errors.New("connection refused"))
+
+	c := &resilientClient{
+		OpenFGACoreClientInterface: mockClient,
+		retry:                      &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+
+	if _, err := c.WriteExecute(mockRequest); err == nil {
+		t.Fatal("expected error got nil")
+	}
+}
+
+func TestResilientClientFailsFastWhenOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := monitoring.NewMockMonitorInterface(ctrl)
+	mockClient := NewMockOpenFGACoreClientInterface(ctrl)
+	mockRequest := NewMockSdkClientReadRequestInterface(ctrl)
+
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).Times(1)
+	mockMonitor.EXPECT().SetDependencyAvailability(gomock.Any(), float64(0)).Times(1)
+	mockRequest.EXPECT().GetContext().Return(context.TODO()).AnyTimes()
+	// the first call trips the breaker, retries and later calls are rejected
+	mockClient.EXPECT().ReadExecute(mockRequest).Times(1).Return(nil, errors.New("connection refused"))
+
+	c := &resilientClient{
+		OpenFGACoreClientInterface: mockClient,
+		retry:                      &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		breaker:                    NewCircuitBreaker(1, time.Minute, mockMonitor, mockLogger),
+	}
+
+	if _, err := c.ReadExecute(mockRequest); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen got %v", err)
+	}
+	if _, err := c.ReadExecute(mockRequest); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen got %v", err)
+	}
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := &RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 10: 300 * time.Millisecond} {
+		d := p.wait(attempt)
+		if d < max/2 || d > max {
+			t.Fatalf("expected wait after attempt %d within [%v, %v] got %v", attempt, max/2, max, d)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package recovery
+
+import "context"
+
+// ReporterInterface sends the panics to an error tracker.
+type ReporterInterface interface {
+	ReportPanic(ctx context.Context, value any, tags map[string]string)
+}
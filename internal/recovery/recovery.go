@@ -0,0 +1,135 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package recovery turns the panics of the HTTP and gRPC handlers into
+// Internal errors, reported with their stack, so that one request cannot
+// bring the server down nor fail silently.
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/http/types"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+// requestIDMetadataKey carries the ID of the gRPC calls, as in the audit log.
+const requestIDMetadataKey = "x-request-id"
+
+// Recoverer recovers the panics of the requests: they are logged with their
+// stack and request ID, counted in the panics_total metric and sent to the
+// error tracker when there is one, then answered with Internal.
+type Recoverer struct {
+	// reporter is nil without error tracker
+	reporter ReporterInterface
+
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// UnaryServerInterceptor recovers the panics of the gRPC calls. It must come
+// first in the chain to cover the other interceptors.
+func (r *Recoverer) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r.recovered(ctx, v, "grpc", "method", info.FullMethod)
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor recovers the panics of the gRPC streams, such as
+// health watches and reflection. It must come first in the chain.
+func (r *Recoverer) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r.recovered(ss.Context(), v, "grpc", "method", info.FullMethod)
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// Middleware recovers the panics of the HTTP requests, answered with a 500
+// Problem. It must come right after the request ID middleware to cover the
+// others. http.ErrAbortHandler is left to the server, it aborts the response
+// on purpose.
+func (r *Recoverer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			r.recovered(req.Context(), v, "http", "method", req.Method, "path", req.URL.Path)
+			types.WriteProblem(w, req, http.StatusInternalServerError, codes.Internal, "internal error")
+		}()
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// recovered reports the panic v of a request of protocol, described by the
+// key-value pairs of fields.
+func (r *Recoverer) recovered(ctx context.Context, v any, protocol string, fields ...any) {
+	requestID := requestID(ctx)
+
+	fields = append(fields, "request_id", requestID, "panic", fmt.Sprint(v), "stack", string(debug.Stack()))
+	r.logger.Errorw(fmt.Sprintf("panic serving %s request", protocol), fields...)
+
+	if err := r.monitor.IncrementPanics(map[string]string{"protocol": protocol}); err != nil {
+		r.logger.Warnf("failed to increment panics counter: %v", err)
+	}
+
+	if r.reporter != nil {
+		tags := map[string]string{"protocol": protocol, "request_id": requestID}
+		for i := 0; i+1 < len(fields); i += 2 {
+			if k, ok := fields[i].(string); ok && (k == "method" || k == "path") {
+				tags[k] = fmt.Sprint(fields[i+1])
+			}
+		}
+		r.reporter.ReportPanic(ctx, v, tags)
+	}
+}
+
+// requestID returns the ID of the request of ctx, empty if none.
+func requestID(ctx context.Context) string {
+	if id := middleware.GetReqID(ctx); id != "" {
+		return id
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDMetadataKey); len(ids) > 0 {
+			return ids[0]
+		}
+	}
+	return ""
+}
+
+// NewRecoverer returns a recoverer sending the panics to reporter, nil for
+// none.
+func NewRecoverer(reporter ReporterInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Recoverer {
+	r := new(Recoverer)
+
+	r.reporter = reporter
+	r.monitor = monitor
+	r.logger = logger
+
+	return r
+}
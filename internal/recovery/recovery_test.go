@@ -0,0 +1,164 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package recovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package recovery -destination ./mock_logger.go -source=../logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package recovery -destination ./mock_monitor.go -source=../monitoring/interfaces.go
+
+type report struct {
+	value any
+	tags  map[string]string
+}
+
+type recordingReporter struct {
+	reports []report
+}
+
+func (r *recordingReporter) ReportPanic(ctx context.Context, value any, tags map[string]string) {
+	r.reports = append(r.reports, report{value: value, tags: tags})
+}
+
+// expectPanicLogged expects one panic to be logged and returns its fields.
+func expectPanicLogged(mockLogger *MockLoggerInterface, message string) map[string]any {
+	fields := make(map[string]any)
+	mockLogger.EXPECT().Errorw(message, gomock.Any()).Do(func(msg string, kv ...any) {
+		for i := 0; i+1 < len(kv); i += 2 {
+			fields[kv[i].(string)] = kv[i+1]
+		}
+	})
+	return fields
+}
+
+func TestRecovererUnaryServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementPanics(map[string]string{"protocol": "grpc"}).Return(nil)
+	fields := expectPanicLogged(mockLogger, "panic serving grpc request")
+
+	reporter := new(recordingReporter)
+	r := NewRecoverer(reporter, mockMonitor, mockLogger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/tenant.v0.TenantService/ListTenants"}
+
+	resp, err := r.UnaryServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	if err != nil || resp != "ok" {
+		t.Fatalf("expected the response to be returned, got %v, %v", resp, err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+	resp, err = r.UnaryServerInterceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("nil map")
+	})
+	if resp != nil || status.Code(err) != codes.Internal {
+		t.Fatalf("expected an internal error, got %v, %v", resp, err)
+	}
+
+	if fields["method"] != info.FullMethod || fields["request_id"] != "req-1" || fields["panic"] != "nil map" || fields["stack"] == "" {
+		t.Errorf("expected the panic to be logged with its method, request ID and stack, got %v", fields)
+	}
+	if len(reporter.reports) != 1 || reporter.reports[0].value != "nil map" || reporter.reports[0].tags["method"] != info.FullMethod {
+		t.Errorf("expected the panic to be reported once with its method, got %v", reporter.reports)
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestRecovererStreamServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementPanics(map[string]string{"protocol": "grpc"}).Return(nil)
+	fields := expectPanicLogged(mockLogger, "panic serving grpc request")
+
+	r := NewRecoverer(nil, mockMonitor, mockLogger)
+	info := &grpc.StreamServerInfo{FullMethod: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"}
+
+	err := r.StreamServerInterceptor(nil, &testServerStream{ctx: context.Background()}, info, func(srv any, ss grpc.ServerStream) error {
+		panic("nil map")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected an internal error, got %v", err)
+	}
+	if fields["method"] != info.FullMethod || fields["stack"] == "" {
+		t.Errorf("expected the panic to be logged with its method and stack, got %v", fields)
+	}
+}
+
+func TestRecovererMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementPanics(map[string]string{"protocol": "http"}).Return(nil)
+	fields := expectPanicLogged(mockLogger, "panic serving http request")
+
+	// without reporter, the panics are only logged and counted
+	r := NewRecoverer(nil, mockMonitor, mockLogger)
+	handler := middleware.RequestID(r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		panic("nil map")
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	var problem map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem["detail"] != "internal error" {
+		t.Errorf("expected an internal error problem, got %s", w.Body.String())
+	}
+
+	if fields["method"] != http.MethodGet || fields["path"] != "/api/v0/tenants" || fields["request_id"] == "" || fields["stack"] == "" {
+		t.Errorf("expected the panic to be logged with its request, request ID and stack, got %v", fields)
+	}
+}
+
+func TestRecovererMiddlewareAbortHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := NewRecoverer(nil, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl))
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to be left to the server, got %v", v)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil))
+}
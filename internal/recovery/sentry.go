@@ -0,0 +1,56 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryReporter sends the panics to Sentry, with the stack of the panicking
+// goroutine and the tags of the request.
+type SentryReporter struct {
+	client *sentry.Client
+}
+
+func (s *SentryReporter) ReportPanic(ctx context.Context, value any, tags map[string]string) {
+	scope := sentry.NewScope()
+	scope.SetTags(tags)
+
+	hub := sentry.NewHub(s.client, scope)
+	hub.RecoverWithContext(ctx, value)
+}
+
+// Flush waits for the events in flight to be sent, until ctx is done.
+func (s *SentryReporter) Flush(ctx context.Context) error {
+	timeout := 5 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if !s.client.Flush(timeout) {
+		return fmt.Errorf("failed to send the Sentry events in time")
+	}
+	return nil
+}
+
+// NewSentryReporter returns a reporter sending the panics to the Sentry
+// project of dsn, tagged with environment and release.
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: environment,
+		Release:     release,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry configuration: %w", err)
+	}
+
+	s := new(SentryReporter)
+	s.client = client
+
+	return s, nil
+}
@@ -5,6 +5,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/tenant-service/internal/types"
 )
@@ -12,12 +13,70 @@ import (
 type StorageInterface interface {
 	CreateTenant(ctx context.Context, t *types.Tenant) (*types.Tenant, error)
 	GetTenantByID(ctx context.Context, id string) (*types.Tenant, error)
+	GetTenantByOriginIdentityID(ctx context.Context, identityID string) (*types.Tenant, error)
 	ListTenants(ctx context.Context) ([]*types.Tenant, error)
+	ListTenantsPage(ctx context.Context, filter *types.TenantFilter) ([]*types.Tenant, error)
+	CountTenants(ctx context.Context) (int, error)
 	ListTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
+	ListTenantsByIDs(ctx context.Context, ids []string) ([]*types.Tenant, error)
 	ListActiveTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
 	UpdateTenant(ctx context.Context, tenant *types.Tenant, paths []string) error
 	DeleteTenant(ctx context.Context, id string) error
-	AddMember(ctx context.Context, tenantID, userID, role string) (string, error)
-	UpdateMember(ctx context.Context, tenantID, userID, role string) error
+	AddMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) (string, error)
+	UpdateMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) error
+	DeleteMember(ctx context.Context, tenantID, userID string) error
 	ListMembersByTenantID(ctx context.Context, tenantID string) ([]*types.Membership, error)
+	ListMembersPage(ctx context.Context, tenantID string, offset, limit uint64) ([]*types.Membership, error)
+	GetMember(ctx context.Context, tenantID, userID string) (*types.Membership, error)
+	CountMembers(ctx context.Context, tenantID string) (int, error)
+	ListMembershipsByUserID(ctx context.Context, userID string) ([]*types.UserMembership, error)
+	CreateRole(ctx context.Context, r *types.Role) (*types.Role, error)
+	GetRole(ctx context.Context, tenantID, roleID string) (*types.Role, error)
+	ListRolesByTenantID(ctx context.Context, tenantID string) ([]*types.Role, error)
+	UpdateRolePermissions(ctx context.Context, tenantID, roleID string, permissions []string) error
+	DeleteRole(ctx context.Context, tenantID, roleID string) error
+	AddRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error
+	DeleteRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error
+	ListRoleAssignees(ctx context.Context, roleID string) ([]string, error)
+	CreateAPIKey(ctx context.Context, k *types.APIKey) (*types.APIKey, error)
+	ListAPIKeysByTenantID(ctx context.Context, tenantID string) ([]*types.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string) error
+	CreateInvite(ctx context.Context, i *types.Invite) (*types.Invite, error)
+	GetInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, error)
+	ListInvitesByTenantID(ctx context.Context, tenantID string) ([]*types.Invite, error)
+	CountPendingInvites(ctx context.Context, tenantID string, now time.Time) (int, error)
+	RevokeInvite(ctx context.Context, tenantID, inviteID string) error
+	RenewInvite(ctx context.Context, tenantID, inviteID string, expiresAt time.Time) error
+	AcceptInvites(ctx context.Context, userID string) (int64, error)
+	GetIdempotencyKey(ctx context.Context, principal, operation, key string) (*types.IdempotencyKey, error)
+	CreateIdempotencyKey(ctx context.Context, k *types.IdempotencyKey) error
+	CompleteIdempotencyKey(ctx context.Context, principal, operation, key string, response []byte) error
+	ListCompletedIdempotencyKeys(ctx context.Context) ([]*types.IdempotencyKey, error)
+	ReplaceIdempotencyResponse(ctx context.Context, principal, operation, key string, previous, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, principal, operation, key string) error
+	CreateAuthorizationModel(ctx context.Context, m *types.AuthorizationModel) error
+	GetLatestAuthorizationModel(ctx context.Context, storeID string) (*types.AuthorizationModel, error)
+	CreateAuthzAuditEntries(ctx context.Context, entries []*types.AuthzAuditEntry) error
+	ListAuthzAuditEntries(ctx context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error)
+	CreateJob(ctx context.Context, kind string, payload []byte) (*types.Job, error)
+	ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (*types.Job, error)
+	DeleteJob(ctx context.Context, id string) error
+	RetryJob(ctx context.Context, id string, runAt time.Time, lastError string) error
+	FailJob(ctx context.Context, id string, lastError string) error
+	CreateWebhookSubscription(ctx context.Context, sub *types.WebhookSubscription) (*types.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id string) (*types.WebhookSubscription, error)
+	ListWebhookSubscriptionsByTenantID(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error)
+	ListWebhookSubscriptionsForEvent(ctx context.Context, tenantID, eventType string) ([]*types.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, tenantID, id string) error
+	CreateWebhookDelivery(ctx context.Context, d *types.WebhookDelivery) (*types.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, id string) (*types.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit uint64) ([]*types.WebhookDelivery, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, id string, status types.WebhookDeliveryStatus, responseStatus int, lastError string) error
+	ReplayWebhookDelivery(ctx context.Context, id string) error
+	CreateWebhookDeadLetter(ctx context.Context, kind string, payload []byte, lastError string) (*types.WebhookDeadLetter, error)
+	GetWebhookDeadLetter(ctx context.Context, id string) (*types.WebhookDeadLetter, error)
+	ListWebhookDeadLetters(ctx context.Context, kind string, limit uint64) ([]*types.WebhookDeadLetter, error)
+	RecordWebhookDeadLetterReplay(ctx context.Context, id, lastError string) error
+	DeleteWebhookDeadLetter(ctx context.Context, id string) error
 }
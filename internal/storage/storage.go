@@ -5,8 +5,11 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/canonical/tenant-service/internal/db"
@@ -16,10 +19,14 @@ import (
 	"github.com/canonical/tenant-service/internal/types"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var _ StorageInterface = (*Storage)(nil)
 
+// likeEscaper escapes the LIKE wildcards of a user provided pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type Storage struct {
 	db db.DBClientInterface
 
@@ -40,6 +47,8 @@ func NewStorage(c db.DBClientInterface, tracer tracing.TracingInterface, monitor
 	return s
 }
 
+// CreateTenant returns ErrDuplicateKey when a tenant was already provisioned
+// for t.OriginIdentityID.
 func (s *Storage) CreateTenant(ctx context.Context, t *types.Tenant) (*types.Tenant, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.CreateTenant")
 	defer span.End()
@@ -52,13 +61,19 @@ func (s *Storage) CreateTenant(ctx context.Context, t *types.Tenant) (*types.Ten
 	var newTenant types.Tenant
 	err = s.db.Statement(ctx).
 		Insert("tenants").
-		Columns("id", "name", "enabled").
-		Values(id.String(), t.Name, t.Enabled).
-		Suffix("RETURNING id, name, created_at, enabled").
+		Columns("id", "name", "enabled", "region", "origin_identity_id").
+		Values(id.String(), t.Name, t.Enabled, t.Region, t.OriginIdentityID).
+		// skips the insert rather than failing it, so that the transaction
+		// holding it can go on
+		Suffix("ON CONFLICT (origin_identity_id) WHERE origin_identity_id <> '' DO NOTHING").
+		Suffix("RETURNING id, name, created_at, enabled, region, origin_identity_id").
 		QueryRowContext(ctx).
-		Scan(&newTenant.ID, &newTenant.Name, &newTenant.CreatedAt, &newTenant.Enabled)
+		Scan(&newTenant.ID, &newTenant.Name, &newTenant.CreatedAt, &newTenant.Enabled, &newTenant.Region, &newTenant.OriginIdentityID)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDuplicateKey
+		}
 		return nil, fmt.Errorf("failed to insert tenant: %w", err)
 	}
 
@@ -71,11 +86,35 @@ func (s *Storage) GetTenantByID(ctx context.Context, id string) (*types.Tenant,
 
 	var t types.Tenant
 	err := s.db.Statement(ctx).
-		Select("id", "name", "created_at", "enabled").
+		Select("id", "name", "created_at", "enabled", "region").
 		From("tenants").
 		Where(sq.Eq{"id": id}).
 		QueryRowContext(ctx).
-		Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled)
+		Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return &t, nil
+}
+
+// GetTenantByOriginIdentityID returns the tenant provisioned on the
+// registration of the identity, ErrNotFound when there is none.
+func (s *Storage) GetTenantByOriginIdentityID(ctx context.Context, identityID string) (*types.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetTenantByOriginIdentityID")
+	defer span.End()
+
+	var t types.Tenant
+	err := s.db.Statement(ctx).
+		Select("id", "name", "created_at", "enabled", "region", "origin_identity_id").
+		From("tenants").
+		Where(sq.Eq{"origin_identity_id": identityID}).
+		QueryRowContext(ctx).
+		Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region, &t.OriginIdentityID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -92,7 +131,7 @@ func (s *Storage) ListTenants(ctx context.Context) ([]*types.Tenant, error) {
 	defer span.End()
 
 	query := s.db.Statement(ctx).
-		Select("id", "name", "created_at", "enabled").
+		Select("id", "name", "created_at", "enabled", "region").
 		From("tenants")
 
 	rows, err := query.QueryContext(ctx)
@@ -104,7 +143,105 @@ func (s *Storage) ListTenants(ctx context.Context) ([]*types.Tenant, error) {
 	var tenants []*types.Tenant
 	for rows.Next() {
 		var t types.Tenant
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tenant rows: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// ListTenantsPage returns a page of the tenants matching filter, oldest first.
+func (s *Storage) ListTenantsPage(ctx context.Context, filter *types.TenantFilter) ([]*types.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListTenantsPage")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("id", "name", "created_at", "enabled", "region").
+		From("tenants").
+		OrderBy("created_at", "id")
+
+	if filter.Enabled != nil {
+		query = query.Where(sq.Eq{"enabled": *filter.Enabled})
+	}
+	if filter.NameContains != "" {
+		query = query.Where(sq.ILike{"name": "%" + likeEscaper.Replace(filter.NameContains) + "%"})
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*types.Tenant
+	for rows.Next() {
+		var t types.Tenant
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tenant rows: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// ListTenantsByIDs returns the tenants with the given IDs, IDs without a tenant are skipped.
+func (s *Storage) CountTenants(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CountTenants")
+	defer span.End()
+
+	var count int
+	err := s.db.Statement(ctx).
+		Select("COUNT(*)").
+		From("tenants").
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tenants: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) ListTenantsByIDs(ctx context.Context, ids []string) ([]*types.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListTenantsByIDs")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return []*types.Tenant{}, nil
+	}
+
+	query := s.db.Statement(ctx).
+		Select("id", "name", "created_at", "enabled", "region").
+		From("tenants").
+		Where("id = ANY(?)", ids)
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := make([]*types.Tenant, 0, len(ids))
+	for rows.Next() {
+		var t types.Tenant
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region); err != nil {
 			return nil, fmt.Errorf("failed to scan tenant: %w", err)
 		}
 		tenants = append(tenants, &t)
@@ -130,10 +267,11 @@ func (s *Storage) listTenantsByUserID(ctx context.Context, userID string, showDi
 	defer span.End()
 
 	query := s.db.Statement(ctx).
-		Select("t.id", "t.name", "t.created_at", "t.enabled").
+		Select("t.id", "t.name", "t.created_at", "t.enabled", "t.region").
 		From("tenants t").
 		Join("memberships m ON t.id = m.tenant_id").
-		Where(sq.Eq{"m.kratos_identity_id": userID})
+		Where(sq.Eq{"m.kratos_identity_id": userID}).
+		OrderBy("t.name")
 
 	if !showDisabled {
 		query = query.Where(sq.Eq{"t.enabled": true})
@@ -148,7 +286,7 @@ func (s *Storage) listTenantsByUserID(ctx context.Context, userID string, showDi
 	var tenants []*types.Tenant
 	for rows.Next() {
 		var t types.Tenant
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region); err != nil {
 			return nil, fmt.Errorf("failed to scan tenant: %w", err)
 		}
 		tenants = append(tenants, &t)
@@ -161,6 +299,41 @@ func (s *Storage) listTenantsByUserID(ctx context.Context, userID string, showDi
 	return tenants, nil
 }
 
+// ListMembershipsByUserID returns the tenants the user is a member of, with
+// the role they hold in each.
+func (s *Storage) ListMembershipsByUserID(ctx context.Context, userID string) ([]*types.UserMembership, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListMembershipsByUserID")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("t.id", "t.name", "t.created_at", "t.enabled", "t.region", "m.role").
+		From("tenants t").
+		Join("memberships m ON t.id = m.tenant_id").
+		Where(sq.Eq{"m.kratos_identity_id": userID}).
+		OrderBy("t.name").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*types.UserMembership
+	for rows.Next() {
+		var t types.Tenant
+		m := &types.UserMembership{Tenant: &t}
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.Enabled, &t.Region, &m.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return memberships, nil
+}
+
 func (s *Storage) ListMembersByTenantID(ctx context.Context, tenantID string) ([]*types.Membership, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.ListMembersByTenantID")
 	defer span.End()
@@ -168,7 +341,49 @@ func (s *Storage) ListMembersByTenantID(ctx context.Context, tenantID string) ([
 	query := s.db.Statement(ctx).
 		Select("id", "tenant_id", "kratos_identity_id", "role", "created_at").
 		From("memberships").
-		Where(sq.Eq{"tenant_id": tenantID})
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("created_at", "id")
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*types.Membership
+	for rows.Next() {
+		var m types.Membership
+		if err := rows.Scan(&m.ID, &m.TenantID, &m.KratosIdentityID, &m.Role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		members = append(members, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return members, nil
+}
+
+// ListMembersPage returns limit members of the tenant in the order they
+// joined, skipping the first offset ones. A zero limit returns them all.
+func (s *Storage) ListMembersPage(ctx context.Context, tenantID string, offset, limit uint64) ([]*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListMembersPage")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("id", "tenant_id", "kratos_identity_id", "role", "created_at").
+		From("memberships").
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("created_at", "id")
+
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
 
 	rows, err := query.QueryContext(ctx)
 	if err != nil {
@@ -192,7 +407,50 @@ func (s *Storage) ListMembersByTenantID(ctx context.Context, tenantID string) ([
 	return members, nil
 }
 
-func (s *Storage) AddMember(ctx context.Context, tenantID, userID, role string) (string, error) {
+// CountMembers returns the number of members of the tenant.
+func (s *Storage) CountMembers(ctx context.Context, tenantID string) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CountMembers")
+	defer span.End()
+
+	var count int
+	err := s.db.Statement(ctx).
+		Select("COUNT(*)").
+		From("memberships").
+		Where(sq.Eq{"tenant_id": tenantID}).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetMember returns the membership of the user in the tenant, ErrNotFound
+// when the user is not a member.
+func (s *Storage) GetMember(ctx context.Context, tenantID, userID string) (*types.Membership, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetMember")
+	defer span.End()
+
+	var m types.Membership
+	err := s.db.Statement(ctx).
+		Select("id", "tenant_id", "kratos_identity_id", "role", "created_at").
+		From("memberships").
+		Where(sq.Eq{"tenant_id": tenantID, "kratos_identity_id": userID}).
+		QueryRowContext(ctx).
+		Scan(&m.ID, &m.TenantID, &m.KratosIdentityID, &m.Role, &m.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return &m, nil
+}
+
+func (s *Storage) AddMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) (string, error) {
 	ctx, span := s.tracer.Start(ctx, "storage.AddMember")
 	defer span.End()
 
@@ -220,7 +478,7 @@ func (s *Storage) AddMember(ctx context.Context, tenantID, userID, role string)
 	return id.String(), nil
 }
 
-func (s *Storage) UpdateMember(ctx context.Context, tenantID, userID, role string) error {
+func (s *Storage) UpdateMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) error {
 	ctx, span := s.tracer.Start(ctx, "storage.UpdateMember")
 	defer span.End()
 
@@ -248,11 +506,39 @@ func (s *Storage) UpdateMember(ctx context.Context, tenantID, userID, role strin
 	return nil
 }
 
+func (s *Storage) DeleteMember(ctx context.Context, tenantID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteMember")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Delete("memberships").
+		Where(sq.Eq{
+			"tenant_id":          tenantID,
+			"kratos_identity_id": userID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 // UpdateTenant updates fields specified in paths.
 // If paths is empty or nil, no update is performed except if we decide default behavior is full update.
 // Here we follow typical PATCH semantics: update only what's in paths.
 // If paths contains "name", update name.
 // If paths contains "enabled", update enabled status.
+// If paths contains "region", move the tenant to tenant.Region.
 func (s *Storage) UpdateTenant(ctx context.Context, tenant *types.Tenant, paths []string) error {
 	ctx, span := s.tracer.Start(ctx, "storage.UpdateTenant")
 	defer span.End()
@@ -268,6 +554,8 @@ func (s *Storage) UpdateTenant(ctx context.Context, tenant *types.Tenant, paths
 			updateMap["name"] = tenant.Name
 		case "enabled":
 			updateMap["enabled"] = tenant.Enabled
+		case "region":
+			updateMap["region"] = tenant.Region
 		}
 	}
 
@@ -288,11 +576,12 @@ func (s *Storage) UpdateTenant(ctx context.Context, tenant *types.Tenant, paths
 	return nil
 }
 
+// DeleteTenant returns ErrNotFound when no tenant has the given id.
 func (s *Storage) DeleteTenant(ctx context.Context, id string) error {
 	ctx, span := s.tracer.Start(ctx, "storage.DeleteTenant")
 	defer span.End()
 
-	_, err := s.db.Statement(ctx).
+	res, err := s.db.Statement(ctx).
 		Delete("tenants").
 		Where(sq.Eq{"id": id}).
 		ExecContext(ctx)
@@ -300,5 +589,1376 @@ func (s *Storage) DeleteTenant(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete tenant: %w", err)
 	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Storage) GetIdempotencyKey(ctx context.Context, principal, operation, key string) (*types.IdempotencyKey, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetIdempotencyKey")
+	defer span.End()
+
+	var k types.IdempotencyKey
+	err := s.db.Statement(ctx).
+		Select("principal", "operation", "idempotency_key", "fingerprint", "response", "created_at").
+		From("idempotency_keys").
+		Where(sq.Eq{
+			"principal":       principal,
+			"operation":       operation,
+			"idempotency_key": key,
+		}).
+		QueryRowContext(ctx).
+		Scan(&k.Principal, &k.Operation, &k.Key, &k.Fingerprint, &k.Response, &k.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &k, nil
+}
+
+// CreateIdempotencyKey reserves a key before the request is processed.
+// It returns ErrDuplicateKey if the key is already reserved.
+func (s *Storage) CreateIdempotencyKey(ctx context.Context, k *types.IdempotencyKey) error {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateIdempotencyKey")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Insert("idempotency_keys").
+		Columns("principal", "operation", "idempotency_key", "fingerprint").
+		Values(k.Principal, k.Operation, k.Key, k.Fingerprint).
+		ExecContext(ctx)
+
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrDuplicateKey
+		}
+		return fmt.Errorf("failed to create idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, principal, operation, key string, response []byte) error {
+	ctx, span := s.tracer.Start(ctx, "storage.CompleteIdempotencyKey")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Update("idempotency_keys").
+		Set("response", response).
+		Where(sq.Eq{
+			"principal":       principal,
+			"operation":       operation,
+			"idempotency_key": key,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
 	return nil
 }
+
+// ListCompletedIdempotencyKeys returns the keys holding a stored response.
+func (s *Storage) ListCompletedIdempotencyKeys(ctx context.Context) ([]*types.IdempotencyKey, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListCompletedIdempotencyKeys")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("principal", "operation", "idempotency_key", "fingerprint", "response", "created_at").
+		From("idempotency_keys").
+		Where(sq.NotEq{"response": nil}).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idempotency keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*types.IdempotencyKey
+	for rows.Next() {
+		var k types.IdempotencyKey
+		if err := rows.Scan(&k.Principal, &k.Operation, &k.Key, &k.Fingerprint, &k.Response, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan idempotency key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// ReplaceIdempotencyResponse swaps the stored response of a key only if it still
+// holds previous, it returns ErrNotFound when the key was released or replaced meanwhile.
+func (s *Storage) ReplaceIdempotencyResponse(ctx context.Context, principal, operation, key string, previous, response []byte) error {
+	ctx, span := s.tracer.Start(ctx, "storage.ReplaceIdempotencyResponse")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("idempotency_keys").
+		Set("response", response).
+		Where(sq.Eq{
+			"principal":       principal,
+			"operation":       operation,
+			"idempotency_key": key,
+			"response":        previous,
+		}).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to replace idempotency response: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to replace idempotency response: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, principal, operation, key string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteIdempotencyKey")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Delete("idempotency_keys").
+		Where(sq.Eq{
+			"principal":       principal,
+			"operation":       operation,
+			"idempotency_key": key,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateRole(ctx context.Context, r *types.Role) (*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateRole")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate role ID: %w", err)
+	}
+
+	m := pgtype.NewMap()
+	var created types.Role
+	err = s.db.Statement(ctx).
+		Insert("roles").
+		Columns("id", "tenant_id", "name", "permissions").
+		Values(id.String(), r.TenantID, r.Name, r.Permissions).
+		Suffix("RETURNING id, tenant_id, name, permissions, created_at").
+		QueryRowContext(ctx).
+		Scan(&created.ID, &created.TenantID, &created.Name, m.SQLScanner(&created.Permissions), &created.CreatedAt)
+
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return nil, ErrDuplicateKey
+		}
+		if IsForeignKeyViolation(err) {
+			return nil, ErrForeignKeyViolation
+		}
+		return nil, fmt.Errorf("failed to insert role: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *Storage) GetRole(ctx context.Context, tenantID, roleID string) (*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetRole")
+	defer span.End()
+
+	m := pgtype.NewMap()
+	var r types.Role
+	err := s.db.Statement(ctx).
+		Select("id", "tenant_id", "name", "permissions", "created_at").
+		From("roles").
+		Where(sq.Eq{
+			"id":        roleID,
+			"tenant_id": tenantID,
+		}).
+		QueryRowContext(ctx).
+		Scan(&r.ID, &r.TenantID, &r.Name, m.SQLScanner(&r.Permissions), &r.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return &r, nil
+}
+
+func (s *Storage) ListRolesByTenantID(ctx context.Context, tenantID string) ([]*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListRolesByTenantID")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("id", "tenant_id", "name", "permissions", "created_at").
+		From("roles").
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("name").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	m := pgtype.NewMap()
+	var roles []*types.Role
+	for rows.Next() {
+		var r types.Role
+		if err := rows.Scan(&r.ID, &r.TenantID, &r.Name, m.SQLScanner(&r.Permissions), &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, &r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) UpdateRolePermissions(ctx context.Context, tenantID, roleID string, permissions []string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.UpdateRolePermissions")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("roles").
+		Set("permissions", permissions).
+		Where(sq.Eq{
+			"id":        roleID,
+			"tenant_id": tenantID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteRole removes the role together with its assignments.
+func (s *Storage) DeleteRole(ctx context.Context, tenantID, roleID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteRole")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Delete("roles").
+		Where(sq.Eq{
+			"id":        roleID,
+			"tenant_id": tenantID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddRoleAssignment assigns the role to a member of its tenant.
+// It returns ErrForeignKeyViolation if the user is not a member of the tenant.
+func (s *Storage) AddRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.AddRoleAssignment")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Insert("role_assignments").
+		Columns("role_id", "tenant_id", "kratos_identity_id").
+		Values(roleID, tenantID, userID).
+		ExecContext(ctx)
+
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrDuplicateKey
+		}
+		if IsForeignKeyViolation(err) {
+			return ErrForeignKeyViolation
+		}
+		return fmt.Errorf("failed to add role assignment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteRoleAssignment")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Delete("role_assignments").
+		Where(sq.Eq{
+			"role_id":            roleID,
+			"tenant_id":          tenantID,
+			"kratos_identity_id": userID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete role assignment: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) ListRoleAssignees(ctx context.Context, roleID string) ([]string, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListRoleAssignees")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select("kratos_identity_id").
+		From("role_assignments").
+		Where(sq.Eq{"role_id": roleID}).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role assignees: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan role assignee: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (s *Storage) CreateAPIKey(ctx context.Context, k *types.APIKey) (*types.APIKey, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateAPIKey")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key ID: %w", err)
+	}
+
+	var created types.APIKey
+	err = s.db.Statement(ctx).
+		Insert("tenant_api_keys").
+		Columns("id", "tenant_id", "name", "role", "prefix", "key_hash", "created_by").
+		Values(id.String(), k.TenantID, k.Name, k.Role, k.Prefix, k.KeyHash, k.CreatedBy).
+		Suffix("RETURNING " + apiKeyColumns).
+		QueryRowContext(ctx).
+		Scan(apiKeyFields(&created)...)
+
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return nil, ErrDuplicateKey
+		}
+		if IsForeignKeyViolation(err) {
+			return nil, ErrForeignKeyViolation
+		}
+		return nil, fmt.Errorf("failed to insert API key: %w", err)
+	}
+
+	return &created, nil
+}
+
+// ListAPIKeysByTenantID lists the API keys of a tenant, revoked ones included.
+func (s *Storage) ListAPIKeysByTenantID(ctx context.Context, tenantID string) ([]*types.APIKey, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListAPIKeysByTenantID")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select(apiKeyColumns).
+		From("tenant_api_keys").
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("name").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*types.APIKey
+	for rows.Next() {
+		var k types.APIKey
+		if err := rows.Scan(apiKeyFields(&k)...); err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetAPIKeyByHash returns the API key with the given hash, revoked or not.
+func (s *Storage) GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetAPIKeyByHash")
+	defer span.End()
+
+	var k types.APIKey
+	err := s.db.Statement(ctx).
+		Select(apiKeyColumns).
+		From("tenant_api_keys").
+		Where(sq.Eq{"key_hash": keyHash}).
+		QueryRowContext(ctx).
+		Scan(apiKeyFields(&k)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &k, nil
+}
+
+// RevokeAPIKey marks an API key of a tenant revoked, revoking it again is a
+// no-op.
+func (s *Storage) RevokeAPIKey(ctx context.Context, tenantID, keyID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RevokeAPIKey")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("tenant_api_keys").
+		Set("revoked_at", sq.Expr("COALESCE(revoked_at, NOW())")).
+		Where(sq.Eq{
+			"id":        keyID,
+			"tenant_id": tenantID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+const apiKeyColumns = "id, tenant_id, name, role, prefix, key_hash, created_by, created_at, revoked_at"
+
+func apiKeyFields(k *types.APIKey) []any {
+	return []any{&k.ID, &k.TenantID, &k.Name, &k.Role, &k.Prefix, &k.KeyHash, &k.CreatedBy, &k.CreatedAt, &k.RevokedAt}
+}
+
+// CreateInvite records an invitation sent to join a tenant.
+func (s *Storage) CreateInvite(ctx context.Context, i *types.Invite) (*types.Invite, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateInvite")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite ID: %w", err)
+	}
+
+	var created types.Invite
+	err = s.db.Statement(ctx).
+		Insert("tenant_invites").
+		Columns("id", "tenant_id", "kratos_identity_id", "email", "role", "added_member", "invited_by", "expires_at").
+		Values(id.String(), i.TenantID, i.UserID, i.Email, i.Role, i.AddedMember, i.InvitedBy, i.ExpiresAt).
+		Suffix("RETURNING " + inviteColumns).
+		QueryRowContext(ctx).
+		Scan(inviteFields(&created)...)
+
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return nil, ErrForeignKeyViolation
+		}
+		return nil, fmt.Errorf("failed to insert invite: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetInvite returns an invitation of a tenant, whatever its state.
+func (s *Storage) GetInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetInvite")
+	defer span.End()
+
+	var i types.Invite
+	err := s.db.Statement(ctx).
+		Select(inviteColumns).
+		From("tenant_invites").
+		Where(sq.Eq{
+			"id":        inviteID,
+			"tenant_id": tenantID,
+		}).
+		QueryRowContext(ctx).
+		Scan(inviteFields(&i)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	return &i, nil
+}
+
+// ListInvitesByTenantID lists the invitations of a tenant, newest first.
+func (s *Storage) ListInvitesByTenantID(ctx context.Context, tenantID string) ([]*types.Invite, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListInvitesByTenantID")
+	defer span.End()
+
+	rows, err := s.db.Statement(ctx).
+		Select(inviteColumns).
+		From("tenant_invites").
+		Where(sq.Eq{"tenant_id": tenantID}).
+		OrderBy("created_at DESC", "id DESC").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []*types.Invite
+	for rows.Next() {
+		var i types.Invite
+		if err := rows.Scan(inviteFields(&i)...); err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		invites = append(invites, &i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return invites, nil
+}
+
+// CountPendingInvites returns the number of invitations of the tenant
+// neither accepted, revoked nor expired at now.
+func (s *Storage) CountPendingInvites(ctx context.Context, tenantID string, now time.Time) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CountPendingInvites")
+	defer span.End()
+
+	var count int
+	err := s.db.Statement(ctx).
+		Select("COUNT(*)").
+		From("tenant_invites").
+		Where(sq.Eq{"tenant_id": tenantID, "accepted_at": nil, "revoked_at": nil}).
+		Where(sq.Gt{"expires_at": now}).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending invites: %w", err)
+	}
+
+	return count, nil
+}
+
+// RevokeInvite marks an invitation of a tenant revoked, revoking it again is
+// a no-op.
+func (s *Storage) RevokeInvite(ctx context.Context, tenantID, inviteID string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RevokeInvite")
+	defer span.End()
+
+	return s.updateInvite(ctx, tenantID, inviteID, map[string]any{
+		"revoked_at": sq.Expr("COALESCE(revoked_at, NOW())"),
+	})
+}
+
+// RenewInvite records that the link of an invitation was sent again, valid
+// until expiresAt.
+func (s *Storage) RenewInvite(ctx context.Context, tenantID, inviteID string, expiresAt time.Time) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RenewInvite")
+	defer span.End()
+
+	return s.updateInvite(ctx, tenantID, inviteID, map[string]any{
+		"sent_at":    sq.Expr("NOW()"),
+		"expires_at": expiresAt,
+	})
+}
+
+func (s *Storage) updateInvite(ctx context.Context, tenantID, inviteID string, values map[string]any) error {
+	res, err := s.db.Statement(ctx).
+		Update("tenant_invites").
+		SetMap(values).
+		Where(sq.Eq{
+			"id":        inviteID,
+			"tenant_id": tenantID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvites marks the pending invitations of an identity accepted, it
+// returns how many were.
+func (s *Storage) AcceptInvites(ctx context.Context, userID string) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.AcceptInvites")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("tenant_invites").
+		Set("accepted_at", sq.Expr("NOW()")).
+		Where(sq.Eq{
+			"kratos_identity_id": userID,
+			"accepted_at":        nil,
+			"revoked_at":         nil,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to accept invites: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+const inviteColumns = "id, tenant_id, kratos_identity_id, email, role, added_member, invited_by, created_at, sent_at, expires_at, accepted_at, revoked_at"
+
+func inviteFields(i *types.Invite) []any {
+	return []any{&i.ID, &i.TenantID, &i.UserID, &i.Email, &i.Role, &i.AddedMember, &i.InvitedBy, &i.CreatedAt, &i.SentAt, &i.ExpiresAt, &i.AcceptedAt, &i.RevokedAt}
+}
+
+// CreateAuthorizationModel records a model written to OpenFGA, recording the same model twice is a no-op.
+func (s *Storage) CreateAuthorizationModel(ctx context.Context, m *types.AuthorizationModel) error {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateAuthorizationModel")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Insert("authorization_models").
+		Columns("model_id", "store_id", "schema_version").
+		Values(m.ModelID, m.StoreID, m.SchemaVersion).
+		Suffix("ON CONFLICT (model_id) DO NOTHING").
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create authorization model: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestAuthorizationModel returns the last model recorded for the store.
+func (s *Storage) GetLatestAuthorizationModel(ctx context.Context, storeID string) (*types.AuthorizationModel, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetLatestAuthorizationModel")
+	defer span.End()
+
+	var m types.AuthorizationModel
+	err := s.db.Statement(ctx).
+		Select("model_id", "store_id", "schema_version", "created_at").
+		From("authorization_models").
+		Where(sq.Eq{"store_id": storeID}).
+		OrderBy("created_at DESC").
+		Limit(1).
+		QueryRowContext(ctx).
+		Scan(&m.ModelID, &m.StoreID, &m.SchemaVersion, &m.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get authorization model: %w", err)
+	}
+
+	return &m, nil
+}
+
+// CreateAuthzAuditEntries records tuple changes, within the transaction of the request if any.
+func (s *Storage) CreateAuthzAuditEntries(ctx context.Context, entries []*types.AuthzAuditEntry) error {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateAuthzAuditEntries")
+	defer span.End()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := s.db.Statement(ctx).
+		Insert("authz_audit").
+		Columns("actor", "operation", "tuple_user", "tuple_relation", "tuple_object", "request_id", "source_ip")
+	for _, e := range entries {
+		query = query.Values(e.Actor, e.Operation, e.User, e.Relation, e.Object, e.RequestID, e.SourceIP)
+	}
+
+	if _, err := query.ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to create authz audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuthzAuditEntries returns the audit entries matching the filter, newest first.
+func (s *Storage) ListAuthzAuditEntries(ctx context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListAuthzAuditEntries")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select("id", "actor", "operation", "tuple_user", "tuple_relation", "tuple_object", "request_id", "source_ip", "created_at").
+		From("authz_audit").
+		OrderBy("id DESC")
+
+	if filter.Actor != "" {
+		query = query.Where(sq.Eq{"actor": filter.Actor})
+	}
+	if filter.User != "" {
+		query = query.Where(sq.Eq{"tuple_user": filter.User})
+	}
+	if filter.Object != "" {
+		query = query.Where(sq.Eq{"tuple_object": filter.Object})
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.Since})
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where(sq.Lt{"id": filter.BeforeID})
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authz audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*types.AuthzAuditEntry
+	for rows.Next() {
+		var e types.AuthzAuditEntry
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Operation, &e.User, &e.Relation, &e.Object, &e.RequestID, &e.SourceIP, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan authz audit entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CreateJob persists a job of the given kind, due right away.
+func (s *Storage) CreateJob(ctx context.Context, kind string, payload []byte) (*types.Job, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateJob")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	var j types.Job
+	err = s.db.Statement(ctx).
+		Insert("jobs").
+		Columns("id", "kind", "payload").
+		Values(id.String(), kind, string(payload)).
+		Suffix("RETURNING id, kind, payload, status, attempts, last_error, run_at, created_at").
+		QueryRowContext(ctx).
+		Scan(&j.ID, &j.Kind, &j.Payload, &j.Status, &j.Attempts, &j.LastError, &j.RunAt, &j.CreatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return &j, nil
+}
+
+// ClaimJob takes the pending job due the longest, counting the attempt and
+// pushing its run_at to now+lease so that no other worker takes it meanwhile.
+// It returns ErrNotFound when no job is due.
+func (s *Storage) ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (*types.Job, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ClaimJob")
+	defer span.End()
+
+	var j types.Job
+	err := s.db.Statement(ctx).
+		Update("jobs").
+		Set("run_at", now.Add(lease)).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Where(
+			"id = (SELECT id FROM jobs WHERE status = ? AND run_at <= ? ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED)",
+			types.JobPending,
+			now,
+		).
+		Suffix("RETURNING id, kind, payload, status, attempts, last_error, run_at, created_at").
+		QueryRowContext(ctx).
+		Scan(&j.ID, &j.Kind, &j.Payload, &j.Status, &j.Attempts, &j.LastError, &j.RunAt, &j.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	return &j, nil
+}
+
+// DeleteJob removes a job once it succeeded.
+func (s *Storage) DeleteJob(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteJob")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Delete("jobs").
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+
+	return nil
+}
+
+// RetryJob schedules the next attempt of a job at runAt.
+func (s *Storage) RetryJob(ctx context.Context, id string, runAt time.Time, lastError string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RetryJob")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Update("jobs").
+		Set("run_at", runAt).
+		Set("last_error", lastError).
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to retry job: %w", err)
+	}
+
+	return nil
+}
+
+// FailJob gives up on a job, it is kept for inspection but not run again.
+func (s *Storage) FailJob(ctx context.Context, id string, lastError string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.FailJob")
+	defer span.End()
+
+	_, err := s.db.Statement(ctx).
+		Update("jobs").
+		Set("status", types.JobFailed).
+		Set("last_error", lastError).
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to fail job: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateWebhookSubscription(ctx context.Context, sub *types.WebhookSubscription) (*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateWebhookSubscription")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook subscription ID: %w", err)
+	}
+
+	m := pgtype.NewMap()
+	var created types.WebhookSubscription
+	err = s.db.Statement(ctx).
+		Insert("webhook_subscriptions").
+		Columns("id", "tenant_id", "url", "event_types", "secret", "created_by").
+		Values(id.String(), sub.TenantID, sub.URL, sub.EventTypes, sub.Secret, sub.CreatedBy).
+		Suffix("RETURNING " + webhookSubscriptionColumns).
+		QueryRowContext(ctx).
+		Scan(webhookSubscriptionFields(m, &created)...)
+
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return nil, ErrForeignKeyViolation
+		}
+		return nil, fmt.Errorf("failed to insert webhook subscription: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetWebhookSubscription returns a subscription by ID, whatever its tenant.
+func (s *Storage) GetWebhookSubscription(ctx context.Context, id string) (*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetWebhookSubscription")
+	defer span.End()
+
+	m := pgtype.NewMap()
+	var sub types.WebhookSubscription
+	err := s.db.Statement(ctx).
+		Select(webhookSubscriptionColumns).
+		From("webhook_subscriptions").
+		Where(sq.Eq{"id": id}).
+		QueryRowContext(ctx).
+		Scan(webhookSubscriptionFields(m, &sub)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return &sub, nil
+}
+
+func (s *Storage) ListWebhookSubscriptionsByTenantID(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListWebhookSubscriptionsByTenantID")
+	defer span.End()
+
+	return s.listWebhookSubscriptions(ctx, sq.Eq{"tenant_id": tenantID})
+}
+
+// ListWebhookSubscriptionsForEvent lists the subscriptions of a tenant
+// receiving the events of the given type.
+func (s *Storage) ListWebhookSubscriptionsForEvent(ctx context.Context, tenantID, eventType string) ([]*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListWebhookSubscriptionsForEvent")
+	defer span.End()
+
+	return s.listWebhookSubscriptions(ctx, sq.And{
+		sq.Eq{"tenant_id": tenantID},
+		sq.Expr("(cardinality(event_types) = 0 OR ? = ANY(event_types))", eventType),
+	})
+}
+
+func (s *Storage) listWebhookSubscriptions(ctx context.Context, where sq.Sqlizer) ([]*types.WebhookSubscription, error) {
+	rows, err := s.db.Statement(ctx).
+		Select(webhookSubscriptionColumns).
+		From("webhook_subscriptions").
+		Where(where).
+		OrderBy("created_at").
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	m := pgtype.NewMap()
+	var subs []*types.WebhookSubscription
+	for rows.Next() {
+		var sub types.WebhookSubscription
+		if err := rows.Scan(webhookSubscriptionFields(m, &sub)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, &sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return subs, nil
+}
+
+// DeleteWebhookSubscription removes a subscription of a tenant together with
+// its deliveries.
+func (s *Storage) DeleteWebhookSubscription(ctx context.Context, tenantID, id string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteWebhookSubscription")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Delete("webhook_subscriptions").
+		Where(sq.Eq{
+			"id":        id,
+			"tenant_id": tenantID,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+const webhookSubscriptionColumns = "id, tenant_id, url, event_types, secret, created_by, created_at"
+
+func webhookSubscriptionFields(m *pgtype.Map, sub *types.WebhookSubscription) []any {
+	return []any{&sub.ID, &sub.TenantID, &sub.URL, m.SQLScanner(&sub.EventTypes), &sub.Secret, &sub.CreatedBy, &sub.CreatedAt}
+}
+
+// CreateWebhookDelivery records a pending delivery of an event to a
+// subscription.
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, d *types.WebhookDelivery) (*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateWebhookDelivery")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook delivery ID: %w", err)
+	}
+
+	var created types.WebhookDelivery
+	err = s.db.Statement(ctx).
+		Insert("webhook_deliveries").
+		Columns("id", "subscription_id", "event_id", "event_type", "payload").
+		Values(id.String(), d.SubscriptionID, d.EventID, d.EventType, string(d.Payload)).
+		Suffix("RETURNING " + webhookDeliveryColumns).
+		QueryRowContext(ctx).
+		Scan(webhookDeliveryFields(&created)...)
+
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return nil, ErrForeignKeyViolation
+		}
+		return nil, fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *Storage) GetWebhookDelivery(ctx context.Context, id string) (*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetWebhookDelivery")
+	defer span.End()
+
+	var d types.WebhookDelivery
+	err := s.db.Statement(ctx).
+		Select(webhookDeliveryColumns).
+		From("webhook_deliveries").
+		Where(sq.Eq{"id": id}).
+		QueryRowContext(ctx).
+		Scan(webhookDeliveryFields(&d)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &d, nil
+}
+
+// ListWebhookDeliveries returns the latest deliveries of a subscription,
+// newest first, at most limit of them when limit is positive.
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit uint64) ([]*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListWebhookDeliveries")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select(webhookDeliveryColumns).
+		From("webhook_deliveries").
+		Where(sq.Eq{"subscription_id": subscriptionID}).
+		OrderBy("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*types.WebhookDelivery
+	for rows.Next() {
+		var d types.WebhookDelivery
+		if err := rows.Scan(webhookDeliveryFields(&d)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookDeliveryAttempt counts an attempt of a delivery and records
+// its outcome.
+func (s *Storage) RecordWebhookDeliveryAttempt(ctx context.Context, id string, status types.WebhookDeliveryStatus, responseStatus int, lastError string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RecordWebhookDeliveryAttempt")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("webhook_deliveries").
+		Set("status", status).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", responseStatus).
+		Set("last_error", lastError).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ReplayWebhookDelivery puts a failed delivery back to pending. It returns
+// ErrNotFound when there is no failed delivery with this ID.
+func (s *Storage) ReplayWebhookDelivery(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.ReplayWebhookDelivery")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("webhook_deliveries").
+		Set("status", types.WebhookDeliveryPending).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{
+			"id":     id,
+			"status": types.WebhookDeliveryFailed,
+		}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+const webhookDeliveryColumns = "id, subscription_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, updated_at"
+
+func webhookDeliveryFields(d *types.WebhookDelivery) []any {
+	return []any{&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.UpdatedAt}
+}
+
+// CreateWebhookDeadLetter keeps the payload of a webhook call whose
+// processing failed for good, with its error.
+func (s *Storage) CreateWebhookDeadLetter(ctx context.Context, kind string, payload []byte, lastError string) (*types.WebhookDeadLetter, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.CreateWebhookDeadLetter")
+	defer span.End()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook dead letter ID: %w", err)
+	}
+
+	var created types.WebhookDeadLetter
+	err = s.db.Statement(ctx).
+		Insert("webhook_dead_letters").
+		Columns("id", "kind", "payload", "last_error").
+		Values(id.String(), kind, string(payload), lastError).
+		Suffix("RETURNING " + webhookDeadLetterColumns).
+		QueryRowContext(ctx).
+		Scan(webhookDeadLetterFields(&created)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook dead letter: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *Storage) GetWebhookDeadLetter(ctx context.Context, id string) (*types.WebhookDeadLetter, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.GetWebhookDeadLetter")
+	defer span.End()
+
+	var d types.WebhookDeadLetter
+	err := s.db.Statement(ctx).
+		Select(webhookDeadLetterColumns).
+		From("webhook_dead_letters").
+		Where(sq.Eq{"id": id}).
+		QueryRowContext(ctx).
+		Scan(webhookDeadLetterFields(&d)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook dead letter: %w", err)
+	}
+
+	return &d, nil
+}
+
+// ListWebhookDeadLetters returns the dead letters of the given kind, all of
+// them when kind is empty, oldest first and at most limit of them when limit
+// is positive.
+func (s *Storage) ListWebhookDeadLetters(ctx context.Context, kind string, limit uint64) ([]*types.WebhookDeadLetter, error) {
+	ctx, span := s.tracer.Start(ctx, "storage.ListWebhookDeadLetters")
+	defer span.End()
+
+	query := s.db.Statement(ctx).
+		Select(webhookDeadLetterColumns).
+		From("webhook_dead_letters").
+		OrderBy("created_at", "id")
+	if kind != "" {
+		query = query.Where(sq.Eq{"kind": kind})
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	rows, err := query.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []*types.WebhookDeadLetter
+	for rows.Next() {
+		var d types.WebhookDeadLetter
+		if err := rows.Scan(webhookDeadLetterFields(&d)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook dead letter: %w", err)
+		}
+		letters = append(letters, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return letters, nil
+}
+
+// RecordWebhookDeadLetterReplay counts a failed replay of a dead letter and
+// records its error.
+func (s *Storage) RecordWebhookDeadLetterReplay(ctx context.Context, id, lastError string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.RecordWebhookDeadLetterReplay")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Update("webhook_dead_letters").
+		Set("replays", sq.Expr("replays + 1")).
+		Set("last_error", lastError).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to record webhook dead letter replay: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteWebhookDeadLetter(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.DeleteWebhookDeadLetter")
+	defer span.End()
+
+	res, err := s.db.Statement(ctx).
+		Delete("webhook_dead_letters").
+		Where(sq.Eq{"id": id}).
+		ExecContext(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook dead letter: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+const webhookDeadLetterColumns = "id, kind, payload, last_error, replays, created_at, updated_at"
+
+func webhookDeadLetterFields(d *types.WebhookDeadLetter) []any {
+	return []any{&d.ID, &d.Kind, &d.Payload, &d.LastError, &d.Replays, &d.CreatedAt, &d.UpdatedAt}
+}
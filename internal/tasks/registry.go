@@ -0,0 +1,200 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+// Task is a background job, it must return once ctx is done.
+type Task func(ctx context.Context) error
+
+// Hook releases a resource on shutdown, once every task has returned.
+type Hook func(ctx context.Context) error
+
+type task struct {
+	name string
+	run  Task
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type hook struct {
+	name string
+	run  Hook
+}
+
+// Registry owns the background work of the service. On Stop, the drain hooks
+// run first, all at once, so the servers stop accepting requests and finish
+// the ones in flight. Tasks are then stopped in the reverse order of their
+// registration, each one drained before the next is cancelled, then the
+// shutdown hooks run, also in reverse order.
+type Registry struct {
+	tasks  []*task
+	drains []hook
+	hooks  []hook
+
+	ctx     context.Context
+	started bool
+	stopped bool
+	mu      sync.Mutex
+
+	logger logging.LoggerInterface
+}
+
+// Go registers a task, it is started right away if the registry already is.
+// Tasks registered after Stop are ignored.
+func (r *Registry) Go(name string, run Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		r.logger.Warnw("task registered after shutdown, ignoring", "task", name)
+		return
+	}
+
+	t := &task{name: name, run: run}
+	r.tasks = append(r.tasks, t)
+	if r.started {
+		r.start(t)
+	}
+}
+
+// OnDrain registers a hook run by Stop before the tasks are cancelled, along
+// with the other drain hooks. The in-flight requests of a server may still
+// need the tasks and resources released later.
+func (r *Registry) OnDrain(name string, run Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.drains = append(r.drains, hook{name: name, run: run})
+}
+
+// OnShutdown registers a hook run by Stop after the tasks are drained.
+func (r *Registry) OnShutdown(name string, run Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hooks = append(r.hooks, hook{name: name, run: run})
+}
+
+// Start runs every registered task, tasks inherit the values of ctx but are
+// only cancelled by Stop.
+func (r *Registry) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started || r.stopped {
+		return
+	}
+
+	r.ctx = context.WithoutCancel(ctx)
+	r.started = true
+	for _, t := range r.tasks {
+		r.start(t)
+	}
+}
+
+func (r *Registry) start(t *task) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	t.cancel = cancel
+	t.done = make(chan struct{})
+
+	go func() {
+		defer close(t.done)
+		defer func() {
+			if rec := recover(); rec != nil {
+				r.logger.Errorw("background task panicked", "task", t.name, "panic", rec, "stack", string(debug.Stack()))
+			}
+		}()
+
+		r.logger.Debugw("background task started", "task", t.name)
+		if err := t.run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			r.logger.Errorw("background task failed", "task", t.name, "error", err)
+			return
+		}
+		r.logger.Debugw("background task stopped", "task", t.name)
+	}()
+}
+
+// Stop runs the drain hooks, cancels and drains the tasks, then runs the
+// shutdown hooks. When ctx expires first, the remaining tasks are cancelled
+// without waiting and the names of those still running are reported in the
+// error.
+func (r *Registry) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return nil
+	}
+	r.stopped = true
+	tasks := r.tasks
+	drains := r.drains
+	hooks := r.hooks
+	r.mu.Unlock()
+
+	errs := r.drain(ctx, drains)
+	for i := len(tasks) - 1; i >= 0; i-- {
+		t := tasks[i]
+		if t.cancel == nil {
+			continue
+		}
+
+		t.cancel()
+		select {
+		case <-t.done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("task %s did not stop: %w", t.name, ctx.Err()))
+		}
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if err := h.run(ctx); err != nil {
+			r.logger.Errorw("shutdown hook failed", "hook", h.name, "error", err)
+			errs = append(errs, fmt.Errorf("shutdown hook %s failed: %w", h.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// drain runs the drain hooks concurrently and returns their errors.
+func (r *Registry) drain(ctx context.Context, drains []hook) []error {
+	var mu sync.Mutex
+	var errs []error
+
+	var wg sync.WaitGroup
+	for _, h := range drains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := h.run(ctx); err != nil {
+				r.logger.Errorw("drain hook failed", "hook", h.name, "error", err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("drain hook %s failed: %w", h.name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func NewRegistry(logger logging.LoggerInterface) *Registry {
+	r := new(Registry)
+
+	r.logger = logger
+
+	return r
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+func TestRegistryStopOrder(t *testing.T) {
+	r := NewRegistry(logging.NewNoopLogger())
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, s)
+	}
+
+	worker := func(name string) Task {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			record(name)
+			return ctx.Err()
+		}
+	}
+
+	r.OnShutdown("db", func(context.Context) error { record("db"); return nil })
+	r.Go("first", worker("first"))
+	r.Start(context.Background())
+	// registered once started, runs right away
+	r.Go("second", worker("second"))
+	r.OnShutdown("grpc", func(context.Context) error { record("grpc"); return nil })
+
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"second", "first", "grpc", "db"}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected shutdown order %v, got %v", expected, order)
+	}
+
+	// a second stop is a noop
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistryDrainsFirst(t *testing.T) {
+	r := NewRegistry(logging.NewNoopLogger())
+
+	stopped := make(chan struct{})
+	r.Go("jobs", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	})
+
+	// both servers drain at once, while the tasks still run
+	var draining sync.WaitGroup
+	draining.Add(2)
+	drain := func(context.Context) error {
+		draining.Done()
+		draining.Wait()
+		select {
+		case <-stopped:
+			return errors.New("task stopped before the drain")
+		default:
+			return nil
+		}
+	}
+	r.OnDrain("http", drain)
+	r.OnDrain("grpc", drain)
+	r.Start(context.Background())
+
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistryRecoversPanic(t *testing.T) {
+	r := NewRegistry(logging.NewNoopLogger())
+
+	r.Go("panics", func(context.Context) error { panic("boom") })
+	r.Go("fails", func(context.Context) error { return errors.New("failed") })
+	r.Start(context.Background())
+
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistryStopDeadline(t *testing.T) {
+	r := NewRegistry(logging.NewNoopLogger())
+
+	release := make(chan struct{})
+	defer close(release)
+
+	r.Go("stuck", func(context.Context) error { <-release; return nil })
+	hookErr := errors.New("close failed")
+	r.OnShutdown("db", func(context.Context) error { return hookErr })
+	r.Start(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := r.Stop(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+}
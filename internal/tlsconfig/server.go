@@ -0,0 +1,196 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+// ServerConfig describes the certificates a server presents and accepts.
+type ServerConfig struct {
+	// CertFile and KeyFile hold the certificate presented by the server
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a PEM bundle of the CAs issuing the client
+	// certificates, a client certificate is required when set
+	ClientCAFile string
+	// ClientSANs restricts the client certificates to the ones holding one
+	// of these DNS names, URIs, emails or IPs, any verified one when empty
+	ClientSANs []string
+}
+
+// Server holds the certificates of a ServerConfig, reloaded from the files
+// when they change so that they can be renewed without a restart.
+type Server struct {
+	config ServerConfig
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modified time.Time
+
+	logger logging.LoggerInterface
+}
+
+// TLSConfig returns the configuration of the listener, it always uses the
+// latest certificates loaded.
+func (s *Server) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*s.cert},
+			}
+			if s.clientCA != nil {
+				c.ClientCAs = s.clientCA
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				c.VerifyConnection = s.verifyClient
+			}
+			return c, nil
+		},
+	}
+}
+
+// verifyClient checks the SANs of a verified client certificate.
+func (s *Server) verifyClient(cs tls.ConnectionState) error {
+	if len(s.config.ClientSANs) == 0 {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no client certificate")
+	}
+
+	cert := cs.PeerCertificates[0]
+	sans := slices.Concat(cert.DNSNames, cert.EmailAddresses)
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	for _, san := range sans {
+		if slices.Contains(s.config.ClientSANs, san) {
+			return nil
+		}
+	}
+
+	s.logger.Warnw("client certificate rejected, no allowed SAN", "subject", cert.Subject.String(), "sans", sans)
+	return fmt.Errorf("client certificate %s not allowed", cert.Subject)
+}
+
+// Reload loads the files again when one of them changed since the last
+// load, the certificates in use are kept when they cannot be loaded.
+func (s *Server) Reload() error {
+	modified, err := s.lastModified()
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	unchanged := modified.Equal(s.modified)
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.config.CertFile, s.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %v", err)
+	}
+
+	var clientCA *x509.CertPool
+	if s.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(s.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %v", err)
+		}
+		clientCA = x509.NewCertPool()
+		if !clientCA.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA bundle %s", s.config.ClientCAFile)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	first := s.cert == nil
+	s.cert = &cert
+	s.clientCA = clientCA
+	s.modified = modified
+
+	if !first {
+		s.logger.Infof("Reloaded the TLS certificates of %s", s.config.CertFile)
+	}
+	return nil
+}
+
+// Watch reloads the files every interval until ctx is done.
+func (s *Server) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reload(); err != nil {
+				s.logger.Errorf("failed to reload the TLS certificates, keeping the current ones: %v", err)
+			}
+		}
+	}
+}
+
+// lastModified returns the latest modification time of the files, the
+// files of a mounted Kubernetes secret are all replaced at once.
+func (s *Server) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{s.config.CertFile, s.config.KeyFile, s.config.ClientCAFile} {
+		if f == "" {
+			continue
+		}
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read TLS file: %v", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// NewServer loads the certificates of config.
+func NewServer(config ServerConfig, logger logging.LoggerInterface) (*Server, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("server certificate and key must be provided together")
+	}
+	if len(config.ClientSANs) > 0 && config.ClientCAFile == "" {
+		return nil, errors.New("client SANs require a client CA bundle")
+	}
+
+	s := new(Server)
+	s.config = config
+	s.logger = logger
+
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a certificate for name and the given SANs, in PEM.
+func (ca *testCA) issue(t *testing.T, name string, serial int64, dnsNames []string, uris []string) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	for _, u := range uris {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template.URIs = append(template.URIs, parsed)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects to a listener served with s and returns the serial of
+// the server certificate.
+func handshake(t *testing.T, s *Server, ca *testCA, clientCert []byte, clientKey []byte) (int64, error) {
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", s.TLSConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if conn.(*tls.Conn).Handshake() == nil {
+			conn.Write([]byte{1})
+		}
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	config := &tls.Config{RootCAs: roots, ServerName: "tenant-service"}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), config)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// TLS 1.3 reports a rejected client certificate on the first read
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return 0, err
+	}
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestServerClientAuthentication(t *testing.T) {
+	ca := newTestCA(t)
+	other := newTestCA(t)
+
+	dir := t.TempDir()
+	serverCert, serverKey := ca.issue(t, "tenant-service", 2, []string{"tenant-service"}, nil)
+	writeFile(t, filepath.Join(dir, "tls.crt"), serverCert)
+	writeFile(t, filepath.Join(dir, "tls.key"), serverKey)
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.pem)
+
+	allowedCert, allowedKey := ca.issue(t, "charm", 3, nil, []string{"spiffe://example.com/charm"})
+	otherCert, otherKey := ca.issue(t, "other", 4, []string{"other.example.com"}, nil)
+	untrustedCert, untrustedKey := other.issue(t, "charm", 5, nil, []string{"spiffe://example.com/charm"})
+
+	tests := []struct {
+		name      string
+		sans      []string
+		cert      []byte
+		key       []byte
+		wantError bool
+	}{
+		{name: "Trusted client", cert: otherCert, key: otherKey},
+		{name: "Allowed SAN", sans: []string{"spiffe://example.com/charm"}, cert: allowedCert, key: allowedKey},
+		{name: "Other SAN", sans: []string{"spiffe://example.com/charm"}, cert: otherCert, key: otherKey, wantError: true},
+		{name: "Untrusted client", cert: untrustedCert, key: untrustedKey, wantError: true},
+		{name: "No client certificate", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewServer(
+				ServerConfig{
+					CertFile:     filepath.Join(dir, "tls.crt"),
+					KeyFile:      filepath.Join(dir, "tls.key"),
+					ClientCAFile: filepath.Join(dir, "ca.crt"),
+					ClientSANs:   tt.sans,
+				},
+				logging.NewNoopLogger(),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := handshake(t, s, ca, tt.cert, tt.key); (err != nil) != tt.wantError {
+				t.Errorf("handshake error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestServerReload(t *testing.T) {
+	ca := newTestCA(t)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	cert, key := ca.issue(t, "tenant-service", 2, []string{"tenant-service"}, nil)
+	writeFile(t, certFile, cert)
+	writeFile(t, keyFile, key)
+
+	s, err := NewServer(ServerConfig{CertFile: certFile, KeyFile: keyFile}, logging.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a half written pair keeps the certificate in use
+	writeFile(t, keyFile, []byte("not a key"))
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(keyFile, future, future)
+	if err := s.Reload(); err == nil {
+		t.Error("expected the reload of an invalid key to fail")
+	}
+	if serial, err := handshake(t, s, ca, nil, nil); err != nil || serial != 2 {
+		t.Fatalf("expected the first certificate, got %d, %v", serial, err)
+	}
+
+	cert, key = ca.issue(t, "tenant-service", 3, []string{"tenant-service"}, nil)
+	writeFile(t, certFile, cert)
+	writeFile(t, keyFile, key)
+	future = future.Add(time.Minute)
+	os.Chtimes(certFile, future, future)
+	os.Chtimes(keyFile, future, future)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serial, err := handshake(t, s, ca, nil, nil); err != nil || serial != 3 {
+		t.Fatalf("expected the renewed certificate, got %d, %v", serial, err)
+	}
+}
+
+func TestNewServerInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ServerConfig
+	}{
+		{name: "Certificate without key", config: ServerConfig{CertFile: "tls.crt"}},
+		{name: "Client SANs without CA", config: ServerConfig{CertFile: "tls.crt", KeyFile: "tls.key", ClientSANs: []string{"charm"}}},
+		{name: "Missing files", config: ServerConfig{CertFile: filepath.Join(t.TempDir(), "tls.crt"), KeyFile: "tls.key"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewServer(test.config, logging.NewNoopLogger()); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
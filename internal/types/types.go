@@ -4,6 +4,8 @@
 package types
 
 import (
+	"fmt"
+	"slices"
 	"time"
 )
 
@@ -12,18 +14,306 @@ type Tenant struct {
 	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
 	Enabled   bool      `db:"enabled"`
+	// Region is the region the tenant is homed in, writes are only accepted
+	// there. Empty for tenants served by every region.
+	Region string `db:"region"`
+	// OriginIdentityID is the identity whose registration provisioned the
+	// tenant, at most one tenant per identity. Empty for the tenants created
+	// through the API, only set on creation.
+	OriginIdentityID string `db:"origin_identity_id"`
+}
+
+// MembershipRole is the built-in role a member holds in a tenant, see
+// authorization.RelationForRole for the OpenFGA relation it maps to.
+type MembershipRole string
+
+const (
+	RoleOwner  MembershipRole = "owner"
+	RoleAdmin  MembershipRole = "admin"
+	RoleMember MembershipRole = "member"
+)
+
+// MembershipRoles lists the membership roles accepted by the API.
+var MembershipRoles = []MembershipRole{RoleOwner, RoleAdmin, RoleMember}
+
+// ParseMembershipRole returns the membership role named s, or an error when
+// it is not one of MembershipRoles.
+func ParseMembershipRole(s string) (MembershipRole, error) {
+	r := MembershipRole(s)
+	if !r.Valid() {
+		return "", fmt.Errorf("invalid role %q, expected one of %v", s, MembershipRoles)
+	}
+	return r, nil
+}
+
+func (r MembershipRole) Valid() bool {
+	return slices.Contains(MembershipRoles, r)
+}
+
+func (r MembershipRole) String() string {
+	return string(r)
 }
 
 type Membership struct {
-	ID               string    `db:"id"`
-	TenantID         string    `db:"tenant_id"`
-	KratosIdentityID string    `db:"kratos_identity_id"`
-	Role             string    `db:"role"`
-	CreatedAt        time.Time `db:"created_at"`
+	ID               string         `db:"id"`
+	TenantID         string         `db:"tenant_id"`
+	KratosIdentityID string         `db:"kratos_identity_id"`
+	Role             MembershipRole `db:"role"`
+	CreatedAt        time.Time      `db:"created_at"`
+}
+
+// Role is a custom role defined by a tenant, granting its assignees the
+// listed permissions on that tenant on top of their membership role.
+type Role struct {
+	ID          string    `db:"id"`
+	TenantID    string    `db:"tenant_id"`
+	Name        string    `db:"name"`
+	Permissions []string  `db:"permissions"`
+	CreatedAt   time.Time `db:"created_at"`
 }
 
+// APIKey is a long-lived credential of a tenant, its holder has Role on
+// the tenant. The key itself is only known at creation, KeyHash is its
+// SHA-256 and Prefix its first characters.
+type APIKey struct {
+	ID        string         `db:"id"`
+	TenantID  string         `db:"tenant_id"`
+	Name      string         `db:"name"`
+	Role      MembershipRole `db:"role"`
+	Prefix    string         `db:"prefix"`
+	KeyHash   string         `db:"key_hash"`
+	CreatedBy string         `db:"created_by"`
+	CreatedAt time.Time      `db:"created_at"`
+	// RevokedAt is nil until the key is revoked
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// InviteState is the state of an Invite.
+type InviteState string
+
+const (
+	InviteStatePending  InviteState = "pending"
+	InviteStateAccepted InviteState = "accepted"
+	InviteStateExpired  InviteState = "expired"
+	InviteStateRevoked  InviteState = "revoked"
+)
+
+// Invite is an invitation of an identity to join a tenant with Role, the
+// link itself is a Kratos recovery link. AddedMember tells whether the
+// invitation created the membership.
+type Invite struct {
+	ID          string         `db:"id"`
+	TenantID    string         `db:"tenant_id"`
+	UserID      string         `db:"kratos_identity_id"`
+	Email       string         `db:"email"`
+	Role        MembershipRole `db:"role"`
+	AddedMember bool           `db:"added_member"`
+	InvitedBy   string         `db:"invited_by"`
+	CreatedAt   time.Time      `db:"created_at"`
+	SentAt      time.Time      `db:"sent_at"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	// AcceptedAt is nil until the invited identity logs in
+	AcceptedAt *time.Time `db:"accepted_at"`
+	// RevokedAt is nil until the invitation is revoked
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// State returns the state of the invitation at now.
+func (i *Invite) State(now time.Time) InviteState {
+	switch {
+	case i.RevokedAt != nil:
+		return InviteStateRevoked
+	case i.AcceptedAt != nil:
+		return InviteStateAccepted
+	case !now.Before(i.ExpiresAt):
+		return InviteStateExpired
+	default:
+		return InviteStatePending
+	}
+}
+
+// QuotaUsage is the usage of a per-tenant quota.
+type QuotaUsage struct {
+	Used int
+	// Limit is zero when the quota is not enforced.
+	Limit int
+	// Warning is set once Used reaches the warning threshold of Limit.
+	Warning bool
+}
+
+// TenantStats is the usage of a tenant against its quotas.
+type TenantStats struct {
+	TenantID       string
+	Members        QuotaUsage
+	PendingInvites QuotaUsage
+}
+
+// IdentityStatus tells whether the identity of a TenantUser was resolved,
+// it is empty when the lookup was skipped.
+type IdentityStatus string
+
+const (
+	IdentityStatusResolved IdentityStatus = "resolved"
+	IdentityStatusUnknown  IdentityStatus = "unknown"
+)
+
 type TenantUser struct {
-	UserID string
-	Email  string
-	Role   string
+	UserID         string
+	Email          string
+	Role           MembershipRole
+	IdentityStatus IdentityStatus
+	JoinedAt       time.Time
+	// Relations and Permissions are only filled for a single user, they
+	// are the relations the user holds on the tenant and what they grant.
+	Relations   []string
+	Permissions map[string]bool
+}
+
+// UserMembership is a tenant a user is a member of, with the role they hold
+// there and their permissions on it.
+type UserMembership struct {
+	Tenant      *Tenant
+	Role        MembershipRole
+	Permissions map[string]bool
+}
+
+// Anomaly is a single inconsistency found by the tenant diagnostics scan.
+type Anomaly struct {
+	Category string
+	TenantID string
+	UserID   string
+	Detail   string
+	Fixed    bool
+}
+
+// IdempotencyKey records a mutating request so that retries replay the stored response.
+// Response is nil while the original request is still being processed.
+type IdempotencyKey struct {
+	Principal   string    `db:"principal"`
+	Operation   string    `db:"operation"`
+	Key         string    `db:"idempotency_key"`
+	Fingerprint string    `db:"fingerprint"`
+	Response    []byte    `db:"response"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// AuthorizationModel records an OpenFGA model written to a store by create-fga-model.
+type AuthorizationModel struct {
+	ModelID       string    `db:"model_id"`
+	StoreID       string    `db:"store_id"`
+	SchemaVersion string    `db:"schema_version"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// AuthzAuditEntry records a tuple written to or deleted from OpenFGA.
+type AuthzAuditEntry struct {
+	ID        int64     `db:"id"`
+	Actor     string    `db:"actor"`
+	Operation string    `db:"operation"`
+	User      string    `db:"tuple_user"`
+	Relation  string    `db:"tuple_relation"`
+	Object    string    `db:"tuple_object"`
+	RequestID string    `db:"request_id"`
+	SourceIP  string    `db:"source_ip"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// AuthzAuditFilter selects audit entries, empty fields match any entry.
+// Entries are returned newest first, BeforeID resumes after the last one read.
+type AuthzAuditFilter struct {
+	Actor    string
+	User     string
+	Object   string
+	Since    time.Time
+	BeforeID int64
+	Limit    uint64
+}
+
+// TenantFilter selects tenants, empty fields match any tenant. Tenants are
+// returned oldest first, Offset skips the ones already read.
+type TenantFilter struct {
+	Enabled      *bool
+	NameContains string
+	Offset       uint64
+	Limit        uint64
+}
+
+// JobStatus is the state of a background job.
+type JobStatus string
+
+const (
+	// JobPending is a job waiting for its run_at, or running under a lease.
+	JobPending JobStatus = "pending"
+	// JobFailed is a job out of attempts, it is not run again.
+	JobFailed JobStatus = "failed"
+)
+
+// Job is a unit of background work persisted until it succeeds. Payload is
+// the JSON the handler of Kind decodes.
+type Job struct {
+	ID        string    `db:"id"`
+	Kind      string    `db:"kind"`
+	Payload   []byte    `db:"payload"`
+	Status    JobStatus `db:"status"`
+	Attempts  int       `db:"attempts"`
+	LastError string    `db:"last_error"`
+	RunAt     time.Time `db:"run_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// WebhookSubscription is a callback URL a tenant registered to receive its
+// events, all of them when EventTypes is empty. Secret is stored encrypted,
+// the deliveries are signed with its plaintext.
+type WebhookSubscription struct {
+	ID         string    `db:"id"`
+	TenantID   string    `db:"tenant_id"`
+	URL        string    `db:"url"`
+	EventTypes []string  `db:"event_types"`
+	Secret     []byte    `db:"secret"`
+	CreatedBy  string    `db:"created_by"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// WebhookDeliveryStatus is the outcome of the delivery of an event to a
+// webhook subscription.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is a delivery not attempted yet, or replayed.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded is a delivery the target acknowledged.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed is a delivery whose last attempt failed, it is
+	// retried until its job is out of attempts, then only when replayed.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event sent to a webhook subscription. Payload is the
+// CloudEvent, ResponseStatus the HTTP status of the last attempt, zero when
+// the target could not be reached.
+type WebhookDelivery struct {
+	ID             string                `db:"id"`
+	SubscriptionID string                `db:"subscription_id"`
+	EventID        string                `db:"event_id"`
+	EventType      string                `db:"event_type"`
+	Payload        []byte                `db:"payload"`
+	Status         WebhookDeliveryStatus `db:"status"`
+	Attempts       int                   `db:"attempts"`
+	ResponseStatus int                   `db:"response_status"`
+	LastError      string                `db:"last_error"`
+	CreatedAt      time.Time             `db:"created_at"`
+	UpdatedAt      time.Time             `db:"updated_at"`
+}
+
+// WebhookDeadLetter is a webhook call whose processing failed for good,
+// kept so that it can be replayed. Kind names the webhook, Payload is the
+// JSON it is replayed from and Replays counts its failed replays.
+type WebhookDeadLetter struct {
+	ID        string    `db:"id"`
+	Kind      string    `db:"kind"`
+	Payload   []byte    `db:"payload"`
+	LastError string    `db:"last_error"`
+	Replays   int       `db:"replays"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 }
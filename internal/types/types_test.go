@@ -0,0 +1,31 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package types
+
+import "testing"
+
+func TestParseMembershipRole(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected MembershipRole
+		wantErr  bool
+	}{
+		{value: "owner", expected: RoleOwner},
+		{value: "admin", expected: RoleAdmin},
+		{value: "member", expected: RoleMember},
+		{value: "superadmin", wantErr: true},
+		{value: "Owner", wantErr: true},
+		{value: "", wantErr: true},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			role, err := ParseMembershipRole(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if role != tc.expected {
+				t.Errorf("expected role %q, got %q", tc.expected, role)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+const (
+	// MaxEmailLength is the longest address accepted by SMTP (RFC 5321).
+	MaxEmailLength = 254
+	// MaxTenantNameLength is the longest tenant name, counted in characters.
+	MaxTenantNameLength = 128
+	// MaxRoleNameLength is the longest custom role name.
+	MaxRoleNameLength = 64
+	// MaxRegionLength is the longest region name.
+	MaxRegionLength = 64
+	// MaxURLLength is the longest webhook URL.
+	MaxURLLength = 2048
+)
+
+// Roles lists the membership roles accepted by the API, see types.MembershipRoles.
+var Roles = roleNames(types.MembershipRoles)
+
+// Permissions lists the tenant permissions a custom role can grant.
+var Permissions = []string{"can_view", "can_edit", "can_create", "can_delete"}
+
+// EventTypes lists the tenant event types a webhook subscription can filter on.
+var EventTypes = []string{"tenant.created", "tenant.deleted", "member.added", "member.role_changed", "tenant.quota_warning"}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`)
+
+var regionPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Validator collects field violations for a single request.
+// Checks are chained and the result is read once with Err.
+type Validator struct {
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func New() *Validator {
+	return new(Validator)
+}
+
+func (v *Validator) addViolation(field, description string) {
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// Required reports a violation if value is empty.
+func (v *Validator) Required(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+	}
+	return v
+}
+
+// UUID reports a violation if value is not a canonical, hyphenated UUID.
+func (v *Validator) UUID(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if len(value) != 36 || uuid.Validate(value) != nil {
+		v.addViolation(field, "must be a valid UUID")
+	}
+	return v
+}
+
+// Email reports a violation if value is not a bare RFC 5322 address.
+// Display names ("Alice <alice@example.com>") are rejected.
+func (v *Validator) Email(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if len(value) > MaxEmailLength {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", MaxEmailLength))
+		return v
+	}
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" || addr.Address != value {
+		v.addViolation(field, "must be a valid email address")
+	}
+	return v
+}
+
+// Role reports a violation if value is not one of Roles.
+func (v *Validator) Role(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if !types.MembershipRole(value).Valid() {
+		v.addViolation(field, fmt.Sprintf("must be one of %s", strings.Join(Roles, ", ")))
+	}
+	return v
+}
+
+// RoleName reports a violation if value is not a lowercase slug such as
+// "billing-admin", or if it shadows one of the built-in Roles.
+func (v *Validator) RoleName(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if len(value) > MaxRoleNameLength {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", MaxRoleNameLength))
+		return v
+	}
+	if !roleNamePattern.MatchString(value) {
+		v.addViolation(field, "must contain only lowercase letters, digits, '-' and '_'")
+		return v
+	}
+	if slices.Contains(Roles, value) {
+		v.addViolation(field, "must not be a built-in role")
+	}
+	return v
+}
+
+// Region reports a violation if value is set and is not a lowercase slug
+// such as "eu-west-1", an empty region is allowed.
+func (v *Validator) Region(field, value string) *Validator {
+	if value == "" {
+		return v
+	}
+	if len(value) > MaxRegionLength {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", MaxRegionLength))
+		return v
+	}
+	if !regionPattern.MatchString(value) {
+		v.addViolation(field, "must contain only lowercase letters, digits and '-'")
+	}
+	return v
+}
+
+// Permissions reports a violation if values is empty or holds an entry that
+// is not one of Permissions.
+func (v *Validator) Permissions(field string, values []string) *Validator {
+	if len(values) == 0 {
+		v.addViolation(field, "is required")
+		return v
+	}
+	for _, p := range values {
+		if !slices.Contains(Permissions, p) {
+			v.addViolation(field, fmt.Sprintf("must only contain %s", strings.Join(Permissions, ", ")))
+			return v
+		}
+	}
+	return v
+}
+
+// URL reports a violation if value is not an absolute http or https URL.
+func (v *Validator) URL(field, value string) *Validator {
+	if value == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if len(value) > MaxURLLength {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", MaxURLLength))
+		return v
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		v.addViolation(field, "must be an absolute http or https URL without credentials")
+	}
+	return v
+}
+
+// EventTypes reports a violation if values holds an entry that is not one
+// of EventTypes, an empty list is allowed.
+func (v *Validator) EventTypes(field string, values []string) *Validator {
+	for _, t := range values {
+		if !slices.Contains(EventTypes, t) {
+			v.addViolation(field, fmt.Sprintf("must only contain %s", strings.Join(EventTypes, ", ")))
+			return v
+		}
+	}
+	return v
+}
+
+// TenantName reports a violation if value is empty, too long, padded with
+// whitespace or contains non-printable characters.
+func (v *Validator) TenantName(field, value string) *Validator {
+	if strings.TrimSpace(value) == "" {
+		v.addViolation(field, "is required")
+		return v
+	}
+	if !utf8.ValidString(value) {
+		v.addViolation(field, "must be valid UTF-8")
+		return v
+	}
+	if utf8.RuneCountInString(value) > MaxTenantNameLength {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", MaxTenantNameLength))
+	}
+	if strings.TrimSpace(value) != value {
+		v.addViolation(field, "must not start or end with whitespace")
+	}
+	if strings.IndexFunc(value, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		v.addViolation(field, "must contain only printable characters")
+	}
+	return v
+}
+
+// Timestamp reports a violation if value is set and is not an RFC 3339 timestamp.
+func (v *Validator) Timestamp(field, value string) *Validator {
+	if value == "" {
+		return v
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		v.addViolation(field, "must be an RFC 3339 timestamp")
+	}
+	return v
+}
+
+// PageSize reports a violation if value is negative or above max, zero
+// leaves the choice to the server.
+func (v *Validator) PageSize(field string, value, max int32) *Validator {
+	if value < 0 || value > max {
+		v.addViolation(field, fmt.Sprintf("must be between 0 and %d", max))
+	}
+	return v
+}
+
+// Violations returns the field violations collected so far.
+func (v *Validator) Violations() []*errdetails.BadRequest_FieldViolation {
+	return v.violations
+}
+
+// Err returns nil if no violation was recorded, otherwise an InvalidArgument
+// status carrying a google.rpc.BadRequest detail with one entry per violation.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(v.violations))
+	for i, fv := range v.violations {
+		msgs[i] = fv.Field + " " + fv.Description
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(msgs, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.violations})
+	if err != nil {
+		// details could not be attached, the message still lists every violation
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func roleNames(roles []types.MembershipRole) []string {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = r.String()
+	}
+	return names
+}
@@ -3,4 +3,6 @@
 
 package version
 
-const Version = "1.1.1" // x-release-please-version
+// Version is the release of the binary, a variable so that builds can
+// override it with -ldflags "-X ...internal/version.Version=...".
+var Version = "1.1.1" // x-release-please-version
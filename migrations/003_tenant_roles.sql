@@ -0,0 +1,37 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE roles (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL CHECK (name NOT IN ('owner', 'admin', 'member')),
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE(tenant_id, name)
+);
+
+-- Only members of the tenant can hold one of its custom roles, removing the
+-- membership removes the assignment.
+CREATE TABLE role_assignments (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    kratos_identity_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (role_id, kratos_identity_id),
+    FOREIGN KEY (tenant_id, kratos_identity_id) REFERENCES memberships(tenant_id, kratos_identity_id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS role_assignments;
+DROP TABLE IF EXISTS roles;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every OpenFGA model written by create-fga-model, the latest row of a store
+-- is the model instances are expected to enforce.
+CREATE TABLE authorization_models (
+    model_id VARCHAR(64) PRIMARY KEY,
+    store_id VARCHAR(64) NOT NULL,
+    schema_version VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_authorization_models_store_id_created_at ON authorization_models(store_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS authorization_models;
+
+-- +goose StatementEnd
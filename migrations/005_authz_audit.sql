@@ -0,0 +1,32 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every tuple written to or deleted from OpenFGA by the service, with the
+-- principal and request behind the change. Rows are never updated.
+CREATE TABLE authz_audit (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    operation VARCHAR(16) NOT NULL CHECK (operation IN ('write', 'delete')),
+    tuple_user VARCHAR(512) NOT NULL,
+    tuple_relation VARCHAR(64) NOT NULL,
+    tuple_object VARCHAR(512) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_authz_audit_created_at ON authz_audit(created_at);
+CREATE INDEX idx_authz_audit_actor ON authz_audit(actor);
+CREATE INDEX idx_authz_audit_tuple_object ON authz_audit(tuple_object);
+CREATE INDEX idx_authz_audit_tuple_user ON authz_audit(tuple_user);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS authz_audit;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- SHA-256 of each migration file when it was applied, `migrate verify`
+-- compares them with the migrations embedded in the binary.
+CREATE TABLE migration_checksums (
+    version BIGINT PRIMARY KEY,
+    path VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS migration_checksums;
+
+-- +goose StatementEnd
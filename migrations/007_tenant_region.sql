@@ -0,0 +1,17 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- The region a tenant is homed in, empty for tenants served by every region.
+ALTER TABLE tenants ADD COLUMN region VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE tenants DROP COLUMN IF EXISTS region;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- The IP of the client behind the change, empty for background jobs and
+-- for the changes recorded before.
+ALTER TABLE authz_audit ADD COLUMN source_ip VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE authz_audit DROP COLUMN IF EXISTS source_ip;
+
+-- +goose StatementEnd
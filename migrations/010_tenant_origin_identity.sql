@@ -0,0 +1,21 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- The identity whose registration provisioned the tenant, empty for the
+-- tenants created through the API. Kratos retries the registration webhook,
+-- the index makes sure a retry cannot provision a second tenant.
+ALTER TABLE tenants ADD COLUMN origin_identity_id VARCHAR(64) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX idx_tenants_origin_identity_id ON tenants(origin_identity_id) WHERE origin_identity_id <> '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tenants_origin_identity_id;
+ALTER TABLE tenants DROP COLUMN IF EXISTS origin_identity_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Webhook calls whose processing failed for good, kept with their payload so
+-- that the operators can replay them once the outage is over. replays counts
+-- the failed replays, a dead letter is deleted once replayed.
+CREATE TABLE webhook_dead_letters (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    replays INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_dead_letters_kind_created_at ON webhook_dead_letters(kind, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_dead_letters;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+--  Copyright 2026 Canonical Ltd.
+--  SPDX-License-Identifier: AGPL-3.0
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Invitations sent to join a tenant, the recovery links themselves live in
+-- Kratos. added_member tells whether the invitation created the membership,
+-- revoking it before its acceptance removes that membership. accepted_at is
+-- set by the first login of the invited identity.
+CREATE TABLE tenant_invites (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kratos_identity_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    added_member BOOLEAN NOT NULL DEFAULT FALSE,
+    invited_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_tenant_invites_tenant_id_created_at ON tenant_invites(tenant_id, created_at);
+CREATE INDEX idx_tenant_invites_pending_identity ON tenant_invites(kratos_identity_id) WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS tenant_invites;
+
+-- +goose StatementEnd
@@ -0,0 +1,171 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	// ChecksumOK is an applied migration matching the embedded file
+	ChecksumOK = "ok"
+	// ChecksumModified is an applied migration edited since it was applied
+	ChecksumModified = "modified"
+	// ChecksumUnrecorded is a migration applied before checksums were
+	// recorded, its checksum is recorded by the next `migrate up`
+	ChecksumUnrecorded = "unrecorded"
+	// ChecksumMissing is an applied migration not embedded in the binary
+	ChecksumMissing = "missing"
+)
+
+// Checksum is the SHA-256 of a migration file.
+type Checksum struct {
+	Version  int64  `json:"version"`
+	Path     string `json:"path"`
+	Checksum string `json:"checksum"`
+}
+
+// Verification is the result of the comparison of an applied migration with
+// the embedded one.
+type Verification struct {
+	Version  int64  `json:"version"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Recorded string `json:"recorded,omitempty"`
+	Embedded string `json:"embedded,omitempty"`
+}
+
+// Checksums returns the checksums of the SQL migrations of fsys, ordered by
+// version.
+func Checksums(fsys fs.FS) ([]Checksum, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make([]Checksum, 0, len(paths))
+	for _, path := range paths {
+		version, err := goose.NumericComponent(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the version of %s: %w", path, err)
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(data)
+		checksums = append(checksums, Checksum{Version: version, Path: path, Checksum: hex.EncodeToString(sum[:])})
+	}
+
+	slices.SortFunc(checksums, func(a, b Checksum) int { return int(a.Version - b.Version) })
+
+	return checksums, nil
+}
+
+// Verify compares the checksums recorded for the applied versions with the
+// embedded ones, it returns one verification per applied version.
+func Verify(applied []int64, recorded map[int64]string, embedded []Checksum) []Verification {
+	files := make(map[int64]Checksum, len(embedded))
+	for _, c := range embedded {
+		files[c.Version] = c
+	}
+
+	verifications := make([]Verification, 0, len(applied))
+	for _, version := range applied {
+		v := Verification{Version: version, Recorded: recorded[version]}
+
+		file, ok := files[version]
+		v.Path = file.Path
+		v.Embedded = file.Checksum
+
+		switch {
+		case !ok:
+			v.Status = ChecksumMissing
+		case v.Recorded == "":
+			v.Status = ChecksumUnrecorded
+		case v.Recorded != v.Embedded:
+			v.Status = ChecksumModified
+		default:
+			v.Status = ChecksumOK
+		}
+
+		verifications = append(verifications, v)
+	}
+
+	return verifications
+}
+
+// ReadChecksums returns the recorded checksums by version, none when the
+// checksums table is not created yet.
+func ReadChecksums(ctx context.Context, db *sql.DB) (map[int64]string, error) {
+	recorded := make(map[int64]string)
+	if ok, err := hasChecksumsTable(ctx, db); err != nil || !ok {
+		return recorded, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version, checksum FROM migration_checksums")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		recorded[version] = checksum
+	}
+
+	return recorded, rows.Err()
+}
+
+// RecordChecksums records the checksums of the given migrations, the ones
+// already recorded are kept so that later edits are detected.
+func RecordChecksums(ctx context.Context, db *sql.DB, checksums []Checksum) error {
+	for _, c := range checksums {
+		_, err := db.ExecContext(
+			ctx,
+			"INSERT INTO migration_checksums (version, path, checksum) VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING",
+			c.Version, c.Path, c.Checksum,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record the checksum of %s: %w", c.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteChecksums drops the checksums of the versions above version, once
+// they are rolled back.
+func DeleteChecksums(ctx context.Context, db *sql.DB, version int64) error {
+	if ok, err := hasChecksumsTable(ctx, db); err != nil || !ok {
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, "DELETE FROM migration_checksums WHERE version > $1", version)
+	return err
+}
+
+// hasChecksumsTable reports whether the checksums table exists, it is dropped
+// when rolling back past its migration.
+func hasChecksumsTable(ctx context.Context, db *sql.DB) (bool, error) {
+	var table sql.NullString
+	if err := db.QueryRowContext(ctx, "SELECT to_regclass('migration_checksums')::text").Scan(&table); err != nil {
+		return false, err
+	}
+	return table.Valid, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestChecksums(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_second.sql": {Data: []byte("SELECT 2;")},
+		"001_first.sql":  {Data: []byte("SELECT 1;")},
+	}
+
+	checksums, err := Checksums(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checksums) != 2 || checksums[0].Version != 1 || checksums[1].Version != 2 {
+		t.Fatalf("expected the checksums ordered by version, got %v", checksums)
+	}
+	if checksums[0].Checksum == checksums[1].Checksum || len(checksums[0].Checksum) != 64 {
+		t.Errorf("expected distinct SHA-256 checksums, got %v", checksums)
+	}
+
+	if _, err := Checksums(EmbedMigrations); err != nil {
+		t.Errorf("expected the embedded migrations to be valid, got %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	embedded := []Checksum{
+		{Version: 1, Path: "001_first.sql", Checksum: "a"},
+		{Version: 2, Path: "002_second.sql", Checksum: "b"},
+		{Version: 3, Path: "003_third.sql", Checksum: "c"},
+		{Version: 5, Path: "005_pending.sql", Checksum: "e"},
+	}
+	recorded := map[int64]string{1: "a", 2: "edited", 4: "d"}
+
+	verifications := Verify([]int64{1, 2, 3, 4}, recorded, embedded)
+
+	expected := []string{ChecksumOK, ChecksumModified, ChecksumUnrecorded, ChecksumMissing}
+	if len(verifications) != len(expected) {
+		t.Fatalf("expected %d verifications, got %v", len(expected), verifications)
+	}
+	for i, v := range verifications {
+		if v.Status != expected[i] {
+			t.Errorf("expected version %d to be %s, got %s", v.Version, expected[i], v.Status)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package openapi holds the OpenAPI document of the gateway API, generated
+// from the protobuf definitions.
+package openapi
+
+import _ "embed"
+
+// Spec is the OpenAPI 3 document of the gateway API, in YAML.
+//
+//go:embed openapi.yaml
+var Spec []byte
@@ -0,0 +1,25 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apikey
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// StorageInterface defines the storage operations required by the apikey package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CreateAPIKey(ctx context.Context, k *types.APIKey) (*types.APIKey, error)
+	ListAPIKeysByTenantID(ctx context.Context, tenantID string) ([]*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string) error
+}
+
+// ServiceInterface defines the tenant API key operations.
+type ServiceInterface interface {
+	CreateAPIKey(ctx context.Context, tenantID, name string, role types.MembershipRole) (*types.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, tenantID string) ([]*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string) error
+}
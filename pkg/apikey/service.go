@@ -0,0 +1,143 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	// KeyPrefix starts every API key so that leaked keys are easy to scan for.
+	KeyPrefix = "tsk_"
+	keyBytes  = 32
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrAPIKeyExists   = errors.New("an API key with this name already exists in the tenant")
+	ErrTenantNotFound = errors.New("tenant not found")
+)
+
+type Service struct {
+	storage StorageInterface
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	storage StorageInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		storage: storage,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// CreateAPIKey generates a key holding role on the tenant and stores its
+// hash, the key is returned once and cannot be retrieved again.
+func (s *Service) CreateAPIKey(ctx context.Context, tenantID, name string, role types.MembershipRole) (*types.APIKey, string, error) {
+	ctx, span := s.tracer.Start(ctx, "apikey.Service.CreateAPIKey")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	key, err := generateKey()
+	if err != nil {
+		s.recordError(span, "failed to generate API key", err, "tenant_id", tenantID, "name", name)
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	created, err := s.storage.CreateAPIKey(ctx, &types.APIKey{
+		TenantID:  tenantID,
+		Name:      name,
+		Role:      role,
+		Prefix:    key[:authentication.APIKeyPrefixLength],
+		KeyHash:   authentication.HashAPIKey(key),
+		CreatedBy: actor,
+	})
+	switch {
+	case errors.Is(err, storage.ErrDuplicateKey):
+		return nil, "", ErrAPIKeyExists
+	case errors.Is(err, storage.ErrForeignKeyViolation):
+		return nil, "", ErrTenantNotFound
+	case err != nil:
+		s.recordError(span, "failed to create API key", err, "tenant_id", tenantID, "name", name)
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	s.logger.Infow("created API key", "tenant_id", tenantID, "api_key_id", created.ID, "name", name, "role", role)
+	s.logger.Security().AdminAction(actor, "create_api_key", "apikey.Service.CreateAPIKey", tenantID+":"+created.ID, authentication.PrincipalLabel(ctx))
+	return created, key, nil
+}
+
+// ListAPIKeys lists the keys of a tenant, revoked ones included.
+func (s *Service) ListAPIKeys(ctx context.Context, tenantID string) ([]*types.APIKey, error) {
+	ctx, span := s.tracer.Start(ctx, "apikey.Service.ListAPIKeys")
+	defer span.End()
+
+	keys, err := s.storage.ListAPIKeysByTenantID(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to list API keys", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key of the tenant, the key is kept for the audit
+// trail but no longer authenticates.
+func (s *Service) RevokeAPIKey(ctx context.Context, tenantID, keyID string) error {
+	ctx, span := s.tracer.Start(ctx, "apikey.Service.RevokeAPIKey")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	err := s.storage.RevokeAPIKey(ctx, tenantID, keyID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrAPIKeyNotFound
+	case err != nil:
+		s.recordError(span, "failed to revoke API key", err, "tenant_id", tenantID, "api_key_id", keyID)
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "revoke_api_key", "apikey.Service.RevokeAPIKey", tenantID+":"+keyID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+// generateKey returns a new random key starting with KeyPrefix.
+func generateKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package apikey -destination ./mock_apikey.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package apikey -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package apikey -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package apikey -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+const (
+	tenantID = "tenant-123"
+	keyID    = "key-123"
+	userID   = "user-123"
+)
+
+func newTestService(t *testing.T, span string) (*Service, *MockStorageInterface) {
+	ctrl := gomock.NewController(t)
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurityLogger := NewMockSecurityLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurityLogger).AnyTimes()
+	mockSecurityLogger.EXPECT().AdminAction(userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockTracer.EXPECT().Start(gomock.Any(), span).DoAndReturn(func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+		return ctx, trace.SpanFromContext(ctx)
+	})
+
+	return NewService(mockStorage, mockTracer, mockMonitor, mockLogger), mockStorage
+}
+
+func TestService_CreateAPIKey(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{name: "success"},
+		{name: "duplicate name", err: storage.ErrDuplicateKey, expectedErr: ErrAPIKeyExists},
+		{name: "unknown tenant", err: storage.ErrForeignKeyViolation, expectedErr: ErrTenantNotFound},
+		{name: "storage error", err: errors.New("db error"), expectedErr: errors.New("failed to create API key")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage := newTestService(t, "apikey.Service.CreateAPIKey")
+
+			var stored *types.APIKey
+			mockStorage.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *types.APIKey) (*types.APIKey, error) {
+				stored = k
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				created := *k
+				created.ID = keyID
+				return &created, nil
+			})
+
+			ctx := authentication.WithUserID(context.Background(), userID)
+			k, key, err := s.CreateAPIKey(ctx, tenantID, "ci", types.RoleAdmin)
+
+			if tc.expectedErr != nil {
+				if err == nil {
+					t.Fatalf("expected error %v but got none", tc.expectedErr)
+				}
+				if (tc.expectedErr == ErrAPIKeyExists || tc.expectedErr == ErrTenantNotFound) && !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if k.ID != keyID || !strings.HasPrefix(key, KeyPrefix) {
+				t.Errorf("unexpected key %+v, %s", k, key)
+			}
+			if stored.KeyHash != authentication.HashAPIKey(key) || stored.KeyHash == key {
+				t.Errorf("expected the hash of the key to be stored, got %s", stored.KeyHash)
+			}
+			if !strings.HasPrefix(key, stored.Prefix) || stored.Role != types.RoleAdmin || stored.CreatedBy != userID {
+				t.Errorf("unexpected stored key %+v", stored)
+			}
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	first, err := generateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := generateKey()
+
+	if first == second || len(first) != len(KeyPrefix)+43 {
+		t.Errorf("expected distinct 32 byte keys, got %s and %s", first, second)
+	}
+}
+
+func TestService_RevokeAPIKey(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{name: "success"},
+		{name: "key not found", err: storage.ErrNotFound, expectedErr: ErrAPIKeyNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage := newTestService(t, "apikey.Service.RevokeAPIKey")
+			mockStorage.EXPECT().RevokeAPIKey(gomock.Any(), tenantID, keyID).Return(tc.err)
+
+			err := s.RevokeAPIKey(authentication.WithUserID(context.Background(), userID), tenantID, keyID)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package audit
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// StorageInterface defines the storage operations required by the audit package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CreateAuthzAuditEntries(ctx context.Context, entries []*types.AuthzAuditEntry) error
+}
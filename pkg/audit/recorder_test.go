@@ -0,0 +1,124 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/canonical/tenant-service/internal/clientip"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package audit -destination ./mock_audit.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package audit -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package audit -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package audit -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestRecorder_RecordTupleChanges(t *testing.T) {
+	tuples := []openfga.Tuple{
+		*openfga.NewTuple("user:1", "member", "tenant:a"),
+		*openfga.NewTuple("user:2", "owner", "tenant:a"),
+	}
+
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		storeErr  error
+		actor     string
+		requestID string
+		sourceIP  string
+		expectErr bool
+	}{
+		{
+			name:      "HTTP request",
+			ctx:       clientip.WithClientIP(context.WithValue(authentication.WithUserID(context.Background(), "admin"), middleware.RequestIDKey, "req-1"), "203.0.113.1"),
+			actor:     "admin",
+			requestID: "req-1",
+			sourceIP:  "203.0.113.1",
+		},
+		{
+			name:      "gRPC request",
+			ctx:       metadata.NewIncomingContext(authentication.WithUserID(context.Background(), "admin"), metadata.Pairs("x-request-id", "req-2")),
+			actor:     "admin",
+			requestID: "req-2",
+		},
+		{
+			name: "Background job",
+			ctx:  context.Background(),
+		},
+		{
+			name:      "Storage error",
+			ctx:       context.Background(),
+			storeErr:  errors.New("db down"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "audit.Recorder.RecordTupleChanges").DoAndReturn(
+				func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+					return ctx, trace.SpanFromContext(ctx)
+				},
+			)
+
+			expected := []*types.AuthzAuditEntry{
+				{Actor: tc.actor, Operation: "delete", User: "user:1", Relation: "member", Object: "tenant:a", RequestID: tc.requestID, SourceIP: tc.sourceIP},
+				{Actor: tc.actor, Operation: "delete", User: "user:2", Relation: "owner", Object: "tenant:a", RequestID: tc.requestID, SourceIP: tc.sourceIP},
+			}
+			mockStorage.EXPECT().CreateAuthzAuditEntries(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, entries []*types.AuthzAuditEntry) error {
+					if !reflect.DeepEqual(entries, expected) {
+						t.Errorf("expected entries %+v, got %+v", expected, entries)
+					}
+					return tc.storeErr
+				},
+			)
+
+			r := NewRecorder(mockStorage, mockTracer, mockMonitor, mockLogger)
+			err := r.RecordTupleChanges(tc.ctx, "delete", tuples...)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var got string
+	h := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = RequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "from-header")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "from-header" {
+		t.Errorf("expected the request ID of the router, got %q", got)
+	}
+	if id := RequestID(context.Background()); id != "" {
+		t.Errorf("expected no request ID, got %q", id)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+func newTestAPIKeyVerifier(ctrl *gomock.Controller, store APIKeyStoreInterface) *APIKeyVerifier {
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurity.EXPECT().AuthzFailure(gomock.Any(), "api_key_access").AnyTimes()
+
+	return NewAPIKeyVerifier(store, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+}
+
+func TestAPIKeyVerifier_VerifyAPIKey(t *testing.T) {
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		key      *types.APIKey
+		err      error
+		expected bool
+	}{
+		{
+			name:     "active key",
+			key:      &types.APIKey{ID: "key-1", TenantID: "tenant-1", Role: types.RoleAdmin},
+			expected: true,
+		},
+		{
+			name: "revoked key",
+			key:  &types.APIKey{ID: "key-1", TenantID: "tenant-1", Role: types.RoleAdmin, RevokedAt: &revokedAt},
+		},
+		{
+			name: "unknown key",
+			err:  storage.ErrNotFound,
+		},
+		{
+			name: "storage error",
+			err:  errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := NewMockAPIKeyStoreInterface(ctrl)
+			store.EXPECT().GetAPIKeyByHash(gomock.Any(), HashAPIKey("tsk_secret")).Return(tt.key, tt.err)
+
+			principal, err := newTestAPIKeyVerifier(ctrl, store).VerifyAPIKey(context.Background(), "tsk_secret")
+
+			if !tt.expected {
+				if err == nil {
+					t.Fatalf("expected the key to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.ID != "key-1" || principal.Type != PrincipalAPIKey || principal.Method != AuthMethodAPIKey || principal.TenantID != "tenant-1" || principal.TenantRole != types.RoleAdmin {
+				t.Errorf("unexpected principal %+v", principal)
+			}
+		})
+	}
+}
+
+func newTestAPIKeyMiddleware(ctrl *gomock.Controller) *Middleware {
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+		return ctx, trace.SpanFromContext(ctx)
+	}).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	store := NewMockAPIKeyStoreInterface(ctrl)
+	store.EXPECT().GetAPIKeyByHash(gomock.Any(), HashAPIKey("tsk_secret")).Return(&types.APIKey{ID: "key-1", TenantID: "tenant-1", Role: types.RoleMember}, nil).AnyTimes()
+	store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound).AnyTimes()
+
+	verifier := NewMockTokenVerifierInterface(ctrl)
+	verifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(&Principal{ID: "user-1", Type: PrincipalUser}, nil).AnyTimes()
+
+	m := NewMiddleware(verifier, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+	m.SetAPIKeyVerifier(newTestAPIKeyVerifier(ctrl, store))
+	return m
+}
+
+func TestMiddleware_AuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newTestAPIKeyMiddleware(ctrl).Authenticate()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := GetPrincipal(r.Context())
+		w.Write([]byte(principal.ID))
+	}))
+
+	for _, tt := range []struct {
+		name         string
+		bearer       string
+		key          string
+		expectedCode int
+		expectedID   string
+	}{
+		{name: "API key", key: "tsk_secret", expectedCode: http.StatusOK, expectedID: "key-1"},
+		{name: "bearer token over API key", bearer: "valid-token", key: "tsk_secret", expectedCode: http.StatusOK, expectedID: "user-1"},
+		{name: "unknown API key", key: "tsk_unknown", expectedCode: http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil)
+			r.Header.Set(APIKeyHeader, tt.key)
+			if tt.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedCode {
+				t.Fatalf("expected %d, got %d", tt.expectedCode, w.Code)
+			}
+			if tt.expectedID != "" && w.Body.String() != tt.expectedID {
+				t.Errorf("expected %s, got %s", tt.expectedID, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddleware_GRPCInterceptorAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newTestAPIKeyMiddleware(ctrl)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal, _ := GetPrincipal(ctx)
+		return principal.ID, nil
+	}
+
+	for key, expectedCode := range map[string]codes.Code{"tsk_secret": codes.OK, "tsk_unknown": codes.Unauthenticated} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", key))
+
+		resp, err := m.GRPCInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+		if status.Code(err) != expectedCode {
+			t.Fatalf("expected %v for %s, got %v", expectedCode, key, err)
+		}
+		if err == nil && resp != "key-1" {
+			t.Errorf("expected the principal of the key, got %v", resp)
+		}
+	}
+}
+
+func TestMiddleware_GRPCStreamInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newTestAPIKeyMiddleware(ctrl)
+	handler := func(srv any, ss grpc.ServerStream) error {
+		if principal, ok := GetPrincipal(ss.Context()); !ok || principal.ID != "key-1" {
+			t.Errorf("expected the principal of the key in the stream, got %v", principal)
+		}
+		return nil
+	}
+
+	for key, expectedCode := range map[string]codes.Code{"tsk_secret": codes.OK, "tsk_unknown": codes.Unauthenticated, "": codes.Unauthenticated} {
+		md := metadata.MD{}
+		if key != "" {
+			md = metadata.Pairs("x-api-key", key)
+		}
+		ss := &testServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+		err := m.GRPCStreamInterceptor(nil, ss, &grpc.StreamServerInfo{}, handler)
+
+		if status.Code(err) != expectedCode {
+			t.Fatalf("expected %v for %q, got %v", expectedCode, key, err)
+		}
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
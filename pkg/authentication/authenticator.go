@@ -5,47 +5,227 @@ package authentication
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/tracing"
 )
 
-// NewJWTAuthenticator initializes a JWT token verifier.
+// IssuerConfig is a trusted token issuer and the policy its tokens are
+// accepted with: tokens must be issued to one of Audiences when set, and
+// either have a subject in AllowedSubjects, hold RequiredScope or be the
+// client credentials token of one of ServiceClients.
+type IssuerConfig struct {
+	Issuer string `json:"issuer"`
+	// JWKSURL skips the OIDC discovery when set.
+	JWKSURL   string   `json:"jwks_url"`
+	Audiences []string `json:"audiences"`
+	// AudienceBypassClients are the legacy clients whose tokens are accepted
+	// whatever their audience.
+	AudienceBypassClients []string                `json:"audience_bypass_clients"`
+	AllowedSubjects       []string                `json:"allowed_subjects"`
+	RequiredScope         string                  `json:"required_scope"`
+	ServiceClients        map[string]PlatformRole `json:"service_clients"`
+}
+
+// Validate checks that the issuer is set and its service clients have known
+// platform roles.
+func (c IssuerConfig) Validate() error {
+	if c.Issuer == "" {
+		return fmt.Errorf("issuer is required for JWT authentication")
+	}
+
+	for clientID, role := range c.ServiceClients {
+		if !slices.Contains(PlatformRoles(), role) {
+			return fmt.Errorf("unknown platform role %q for service client %s of %s, expected one of %v", role, clientID, c.Issuer, PlatformRoles())
+		}
+	}
+
+	return nil
+}
+
+// LoadIssuers reads the list of issuer configurations of a JSON file.
+func LoadIssuers(path string) ([]IssuerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issuers: %w", err)
+	}
+
+	var issuers []IssuerConfig
+	if err := json.Unmarshal(data, &issuers); err != nil {
+		return nil, fmt.Errorf("failed to parse issuers %s: %w", path, err)
+	}
+
+	return issuers, nil
+}
+
+// MultiIssuerVerifier verifies tokens with the verifier of the issuer named
+// by their iss claim.
+type MultiIssuerVerifier struct {
+	verifiers map[string]TokenVerifierInterface
+
+	logger logging.LoggerInterface
+}
+
+func (m *MultiIssuerVerifier) VerifyToken(ctx context.Context, rawToken string) (*Principal, error) {
+	issuer, err := tokenIssuer(rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := m.verifiers[issuer]
+	if !ok {
+		m.logger.Debugf("JWT issued by untrusted issuer %s", issuer)
+		return nil, fmt.Errorf("untrusted issuer %q", issuer)
+	}
+
+	return v.VerifyToken(ctx, rawToken)
+}
+
+// tokenIssuer returns the iss claim of a JWT without verifying it, the token
+// is verified by the verifier of that issuer.
+func tokenIssuer(rawToken string) (string, error) {
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := decodeTokenPart(rawToken, 1, &claims); err != nil {
+		return "", err
+	}
+
+	return claims.Issuer, nil
+}
+
+// tokenSubject returns the sub claim of a JWT without verifying it, empty
+// for opaque tokens.
+func tokenSubject(rawToken string) string {
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := decodeTokenPart(rawToken, 1, &claims); err != nil {
+		return ""
+	}
+
+	return claims.Subject
+}
+
+// tokenKeyID returns the kid header of a JWT without verifying it.
+func tokenKeyID(rawToken string) (string, error) {
+	var header struct {
+		KeyID string `json:"kid"`
+	}
+	if err := decodeTokenPart(rawToken, 0, &header); err != nil {
+		return "", err
+	}
+
+	return header.KeyID, nil
+}
+
+// DecodeToken returns the header and the claims of a JWT without verifying
+// it, to inspect a token rather than trust it.
+func DecodeToken(rawToken string) (map[string]any, map[string]any, error) {
+	var header, claims map[string]any
+	if err := decodeTokenPart(rawToken, 0, &header); err != nil {
+		return nil, nil, err
+	}
+	if err := decodeTokenPart(rawToken, 1, &claims); err != nil {
+		return nil, nil, err
+	}
+
+	return header, claims, nil
+}
+
+// decodeTokenPart decodes the JSON of the given part of a JWT, 0 for the
+// header and 1 for the claims, into v.
+func decodeTokenPart(rawToken string, part int, v any) error {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed jwt, expected 3 parts got %d", len(parts))
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[part])
+	if err != nil {
+		return fmt.Errorf("malformed jwt part %d: %w", part, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed jwt part %d: %w", part, err)
+	}
+
+	return nil
+}
+
+// NewMultiIssuerVerifier returns a verifier dispatching tokens to verifiers by
+// their issuer.
+func NewMultiIssuerVerifier(verifiers map[string]TokenVerifierInterface, logger logging.LoggerInterface) *MultiIssuerVerifier {
+	m := new(MultiIssuerVerifier)
+
+	m.verifiers = verifiers
+	m.logger = logger
+
+	return m
+}
+
+// NewJWTAuthenticator initializes a JWT token verifier trusting the given
+// issuers, tokens are verified by the one named by their iss claim and
+// within lifetime. The verifications are cached in cache unless nil.
 func NewJWTAuthenticator(
 	ctx context.Context,
-	issuer string,
-	jwksURL string,
-	allowedSubjects []string,
-	requiredScope string,
+	issuers []IssuerConfig,
+	lifetime TokenLifetime,
+	cache *TokenCache,
+	httpClient *http.Client,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
 ) (TokenVerifierInterface, error) {
-	if issuer == "" {
+	if len(issuers) == 0 {
 		return nil, fmt.Errorf("issuer is required for JWT authentication")
 	}
 
-	var verifier *JWTVerifier
+	verifiers := make(map[string]TokenVerifierInterface, len(issuers))
+	for _, issuer := range issuers {
+		if err := issuer.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := verifiers[issuer.Issuer]; ok {
+			return nil, fmt.Errorf("issuer %s is configured twice", issuer.Issuer)
+		}
 
-	if jwksURL != "" {
-		logger.Infof("Using manual JWKS URL: %s", jwksURL)
-		idTokenVerifier, err := NewProviderWithJWKS(ctx, issuer, jwksURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create JWKS verifier: %v", err)
+		if issuer.JWKSURL != "" {
+			logger.Infof("Using manual JWKS URL for issuer %s: %s", issuer.Issuer, issuer.JWKSURL)
+			idTokenVerifier, err := NewProviderWithJWKS(ctx, issuer.Issuer, issuer.JWKSURL, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create JWKS verifier: %v", err)
+			}
+			v := NewJWTVerifierDirect(idTokenVerifier, issuer, tracer, monitor, logger)
+			v.SetLifetime(lifetime)
+			v.SetCache(cache)
+			verifiers[issuer.Issuer] = v
+			continue
 		}
-		verifier = NewJWTVerifierDirect(idTokenVerifier, allowedSubjects, requiredScope, tracer, monitor, logger)
-		logger.Info("JWT authentication is enabled with manual JWKS URL")
-	} else {
-		logger.Infof("Using OIDC discovery for issuer: %s", issuer)
-		provider, err := NewProvider(ctx, issuer)
+
+		logger.Infof("Using OIDC discovery for issuer: %s", issuer.Issuer)
+		provider, err := NewProvider(ctx, issuer.Issuer, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create OIDC provider: %v", err)
 		}
-		verifier = NewJWTVerifier(provider, issuer, allowedSubjects, requiredScope, tracer, monitor, logger)
-		logger.Info("JWT authentication is enabled with OIDC discovery")
+		v := NewJWTVerifier(provider, issuer, tracer, monitor, logger)
+		v.SetLifetime(lifetime)
+		v.SetCache(cache)
+		verifiers[issuer.Issuer] = v
 	}
 
-	return verifier, nil
+	logger.Infof("JWT authentication is enabled for %d issuers", len(verifiers))
+
+	if len(verifiers) == 1 {
+		return verifiers[issuers[0].Issuer], nil
+	}
+	return NewMultiIssuerVerifier(verifiers, logger), nil
 }
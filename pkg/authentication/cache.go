@@ -0,0 +1,196 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type verification struct {
+	key       string
+	principal Principal
+	expires   time.Time
+}
+
+// keyRef names a signing key of an issuer.
+type keyRef struct {
+	issuer string
+	keyID  string
+}
+
+type keysFailure struct {
+	err     error
+	expires time.Time
+}
+
+// TokenCache is a size bounded LRU of the principals of verified tokens,
+// keyed by the hash of the token and the method verifying it, so that a
+// credential is never taken from the cache for one presented another way.
+// Entries expire after the TTL or with the token, whichever comes first.
+//
+// It also remembers the failures to fetch the keys of an issuer for the
+// failure TTL, the tokens signed with a key that was never seen verifying a
+// token are rejected meanwhile instead of fetching the keys again.
+type TokenCache struct {
+	size       int
+	ttl        time.Duration
+	failureTTL time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List
+	keys     map[keyRef]struct{}
+	failures map[keyRef]keysFailure
+}
+
+func tokenHash(method AuthMethod, rawToken string) string {
+	sum := sha256.Sum256([]byte(string(method) + ":" + rawToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *TokenCache) get(method AuthMethod, rawToken string) (*Principal, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[tokenHash(method, rawToken)]
+	if !ok {
+		return nil, false
+	}
+
+	v := e.Value.(*verification)
+	if !c.now().Before(v.expires) {
+		c.remove(e)
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	principal := v.principal
+	return &principal, true
+}
+
+// set caches the principal of a token verified by method expiring at expiry,
+// a zero expiry leaves only the TTL.
+func (c *TokenCache) set(method AuthMethod, rawToken string, principal *Principal, expiry time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if !expiry.IsZero() && expiry.Before(expires) {
+		expires = expiry
+	}
+	if !c.now().Before(expires) {
+		return
+	}
+
+	key := tokenHash(method, rawToken)
+	if e, ok := c.entries[key]; ok {
+		v := e.Value.(*verification)
+		v.principal = *principal
+		v.expires = expires
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&verification{key: key, principal: *principal, expires: expires})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *TokenCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*verification).key)
+}
+
+// verifiedKey records that a key of issuer verified a token, its tokens are
+// always verified.
+func (c *TokenCache) verifiedKey(issuer, keyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ref := keyRef{issuer, keyID}
+	c.keys[ref] = struct{}{}
+	delete(c.failures, ref)
+}
+
+// keysFailed remembers that the keys of issuer could not be fetched to
+// verify a token signed with keyID, unless that key is known.
+func (c *TokenCache) keysFailed(issuer, keyID string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ref := keyRef{issuer, keyID}
+	if _, ok := c.keys[ref]; ok {
+		return
+	}
+
+	now := c.now()
+	for r, f := range c.failures {
+		if !now.Before(f.expires) {
+			delete(c.failures, r)
+		}
+	}
+	// the key IDs come from unverified tokens
+	if len(c.failures) >= c.size {
+		return
+	}
+
+	c.failures[ref] = keysFailure{err: err, expires: now.Add(c.failureTTL)}
+}
+
+// keysFailure returns the error of the last failure to fetch the keys for a
+// token signed with keyID, or nil.
+func (c *TokenCache) keysFailure(issuer, keyID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ref := keyRef{issuer, keyID}
+	f, ok := c.failures[ref]
+	if !ok {
+		return nil
+	}
+	if !c.now().Before(f.expires) {
+		delete(c.failures, ref)
+		return nil
+	}
+
+	return f.err
+}
+
+// Purge drops every cached verification and key failure.
+func (c *TokenCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.failures = make(map[keyRef]keysFailure)
+}
+
+// Len returns the number of cached verifications, expired ones included.
+func (c *TokenCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func NewTokenCache(size int, ttl, failureTTL time.Duration) *TokenCache {
+	c := new(TokenCache)
+	c.size = size
+	c.ttl = ttl
+	c.failureTTL = failureTTL
+	c.now = time.Now
+	c.entries = make(map[string]*list.Element)
+	c.order = list.New()
+	c.keys = make(map[keyRef]struct{})
+	c.failures = make(map[keyRef]keysFailure)
+
+	return c
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTokenCacheEviction(t *testing.T) {
+	c := NewTokenCache(2, time.Minute, time.Minute)
+
+	c.set(AuthMethodJWT, "token-1", &Principal{ID: "user-1"}, time.Time{})
+	c.set(AuthMethodJWT, "token-2", &Principal{ID: "user-2"}, time.Time{})
+	// reading token-1 makes token-2 the least recently used
+	if _, ok := c.get(AuthMethodJWT, "token-1"); !ok {
+		t.Fatal("expected a cached principal")
+	}
+	c.set(AuthMethodJWT, "token-3", &Principal{ID: "user-3"}, time.Time{})
+
+	if _, ok := c.get(AuthMethodJWT, "token-2"); ok {
+		t.Error("expected the least recently used principal to be evicted")
+	}
+	if p, ok := c.get(AuthMethodJWT, "token-3"); !ok || p.ID != "user-3" {
+		t.Errorf("expected user-3 to be cached, got %+v, %v", p, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 principals, got %d", c.Len())
+	}
+}
+
+func TestTokenCacheExpiry(t *testing.T) {
+	now := time.Now()
+	c := NewTokenCache(10, time.Minute, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set(AuthMethodJWT, "long-lived", &Principal{ID: "user-1"}, now.Add(time.Hour))
+	c.set(AuthMethodJWT, "short-lived", &Principal{ID: "user-2"}, now.Add(10*time.Second))
+	c.set(AuthMethodJWT, "expired", &Principal{ID: "user-3"}, now)
+
+	if c.Len() != 2 {
+		t.Errorf("expected the expired token not to be cached, got %d", c.Len())
+	}
+
+	now = now.Add(10 * time.Second)
+	if _, ok := c.get(AuthMethodJWT, "short-lived"); ok {
+		t.Error("expected the principal to expire with the token")
+	}
+	if _, ok := c.get(AuthMethodJWT, "long-lived"); !ok {
+		t.Fatal("expected the principal to be cached until the TTL")
+	}
+
+	now = now.Add(50 * time.Second)
+	if _, ok := c.get(AuthMethodJWT, "long-lived"); ok {
+		t.Error("expected the principal to expire after the TTL")
+	}
+}
+
+func TestTokenCacheKeysFailure(t *testing.T) {
+	now := time.Now()
+	c := NewTokenCache(10, time.Minute, 30*time.Second)
+	c.now = func() time.Time { return now }
+	errFetch := errors.New("fetching keys failed")
+
+	c.verifiedKey("https://issuer.example.com", "known")
+	c.keysFailed("https://issuer.example.com", "known", errFetch)
+	c.keysFailed("https://issuer.example.com", "unknown", errFetch)
+
+	if err := c.keysFailure("https://issuer.example.com", "known"); err != nil {
+		t.Errorf("expected the tokens of a known key to be verified, got %v", err)
+	}
+	if err := c.keysFailure("https://other.example.com", "unknown"); err != nil {
+		t.Errorf("expected the failure to be kept to its issuer, got %v", err)
+	}
+	if err := c.keysFailure("https://issuer.example.com", "unknown"); !errors.Is(err, errFetch) {
+		t.Errorf("expected the cached failure, got %v", err)
+	}
+
+	now = now.Add(30 * time.Second)
+	if err := c.keysFailure("https://issuer.example.com", "unknown"); err != nil {
+		t.Errorf("expected the failure to expire, got %v", err)
+	}
+}
+
+func TestJWTVerifier_VerifyTokenCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := "https://issuer.example.com"
+	key, sign := newTestIssuer(t, issuer)
+
+	v := newTestVerifier(ctrl, issuer, key, IssuerConfig{Issuer: issuer, AllowedSubjects: []string{"user-1"}})
+	v.SetCache(NewTokenCache(10, time.Minute, time.Minute))
+
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_cache_miss", "role": ""}).Times(2)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_cache_hit", "role": ""}).Times(1)
+	v.monitor = mockMonitor
+
+	token := sign(map[string]any{"sub": "user-1"})
+	for range 2 {
+		principal, err := v.VerifyToken(context.Background(), token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if principal.ID != "user-1" {
+			t.Errorf("expected user-1, got %+v", principal)
+		}
+	}
+
+	// rejected tokens are not cached
+	if _, err := v.VerifyToken(context.Background(), sign(map[string]any{"sub": "user-2"})); err == nil {
+		t.Fatal("expected the token to be rejected")
+	}
+}
+
+func TestJWTVerifier_VerifyTokenKeysFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer jwks.Close()
+
+	issuer := "https://issuer.example.com"
+	_, sign := newTestIssuer(t, issuer)
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), "authentication.JWTVerifier.VerifyToken").Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).Times(1)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_cache_miss", "role": ""}).Times(2)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_keys_failure_hit", "role": ""}).Times(1)
+
+	keySet := oidc.NewRemoteKeySet(oidc.ClientContext(context.Background(), jwks.Client()), jwks.URL)
+	v := NewJWTVerifierDirect(
+		oidc.NewVerifier(issuer, keySet, &oidc.Config{SkipClientIDCheck: true}),
+		IssuerConfig{Issuer: issuer, AllowedSubjects: []string{"user-1"}},
+		mockTracer,
+		mockMonitor,
+		mockLogger,
+	)
+	v.SetCache(NewTokenCache(10, time.Minute, time.Minute))
+
+	for range 2 {
+		if _, err := v.VerifyToken(context.Background(), sign(map[string]any{"sub": "user-1"})); err == nil {
+			t.Fatal("expected the token to be rejected")
+		}
+	}
+
+	if fetches.Load() != 1 {
+		t.Errorf("expected the keys to be fetched once, got %d", fetches.Load())
+	}
+}
@@ -3,21 +3,124 @@
 
 package authentication
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/clientip"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/types"
+)
 
 // Define a private custom type to avoid collisions
 type contextKey struct{}
 
-var userContextKey = contextKey{}
+var principalContextKey = contextKey{}
+
+// PrincipalType tells whether a request was made by a person or by a machine client.
+type PrincipalType string
+
+const (
+	PrincipalUser    PrincipalType = "user"
+	PrincipalService PrincipalType = "service"
+	// PrincipalAPIKey is a tenant API key, see APIKeyVerifier.
+	PrincipalAPIKey PrincipalType = "api_key"
+)
+
+// AuthMethod tells how the caller of a request was authenticated.
+type AuthMethod string
+
+const (
+	// AuthMethodJWT is a verified JWT, the principal is its subject.
+	AuthMethodJWT AuthMethod = "jwt"
+	// AuthMethodIntrospection is an opaque access token found active by the
+	// introspection endpoint of its issuer.
+	AuthMethodIntrospection AuthMethod = "introspection"
+	// AuthMethodSession is an active Kratos session cookie of a browser.
+	AuthMethodSession AuthMethod = "session"
+	// AuthMethodAPIKey is a tenant API key presented in the X-API-Key header.
+	AuthMethodAPIKey AuthMethod = "api_key"
+	// AuthMethodIdentity is a bearer value taken as the Kratos identity ID
+	// without verification, when authentication is disabled.
+	AuthMethodIdentity AuthMethod = "identity"
+)
+
+// PlatformRole is the role granted to a service client across every tenant,
+// see NewJWTVerifier.
+type PlatformRole string
+
+const (
+	// PlatformRoleAdmin holds every permission on every tenant.
+	PlatformRoleAdmin PlatformRole = "admin"
+	// PlatformRoleViewer holds can_view on every tenant.
+	PlatformRoleViewer PlatformRole = "viewer"
+)
+
+// PlatformRoles returns the platform roles a service client can be granted.
+func PlatformRoles() []PlatformRole {
+	return []PlatformRole{PlatformRoleAdmin, PlatformRoleViewer}
+}
 
-// WithUserID returns a new context with the given user ID derived from the parent context.
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// ID is the token subject, the Kratos identity ID for users or the client
+	// ID for services, or the ID of an API key.
+	ID     string
+	Email  string
+	Type   PrincipalType
+	Scopes []string
+	Method AuthMethod
+	// PlatformRole is set for the service clients mapped to a platform role.
+	PlatformRole PlatformRole
+	// TenantID and TenantRole are set for API keys, the only tenant they can
+	// act on and the role they hold there.
+	TenantID   string
+	TenantRole types.MembershipRole
+	// AuthTime is when the user last authenticated with the identity
+	// provider, zero when unknown.
+	AuthTime time.Time
+	// AuthMethods are the methods the user logged in with, the amr claim or
+	// the methods of the session.
+	AuthMethods []string
+}
+
+// WithPrincipal returns a new context carrying the given principal derived from the parent context.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, p)
+}
+
+// GetPrincipal retrieves the principal from the context.
+// Returns nil and false if no principal is present.
+func GetPrincipal(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalContextKey).(*Principal)
+	return p, ok && p != nil
+}
+
+// WithUserID returns a new context with a user principal holding only the given ID.
 func WithUserID(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, userContextKey, userID)
+	return WithPrincipal(ctx, &Principal{ID: userID, Type: PrincipalUser})
 }
 
-// GetUserID retrieves the user ID from the context.
-// Returns an empty string and false if the user ID is not present.
+// GetUserID retrieves the ID of the principal from the context.
+// Returns an empty string and false if no principal is present.
 func GetUserID(ctx context.Context) (string, bool) {
-	id, ok := ctx.Value(userContextKey).(string)
-	return id, ok
+	p, ok := GetPrincipal(ctx)
+	if !ok {
+		return "", false
+	}
+	return p.ID, true
+}
+
+// PrincipalLabel labels the security log entries of an action with the type of
+// the principal of ctx, so that audit logs tell people from machines, and
+// with the IP of the client.
+func PrincipalLabel(ctx context.Context) logging.Option {
+	var labels logging.Option
+	if p, ok := GetPrincipal(ctx); ok {
+		labels = append(labels, logging.WithLabel("principal_type", string(p.Type))...)
+	}
+	if ip, ok := clientip.FromContext(ctx); ok {
+		labels = append(labels, logging.WithLabel("source_ip", ip)...)
+	}
+	return labels
 }
@@ -0,0 +1,108 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// FailureTracker counts the failed authentications of each source, a client
+// IP or a claimed subject, in a sliding window. A source with threshold
+// failures in the window is blocked until the oldest of them leaves it.
+//
+// At most size sources are tracked, once full the sources without failure
+// in the window are dropped and new sources are not tracked until some are.
+type FailureTracker struct {
+	threshold int
+	window    time.Duration
+	size      int
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures map[string][]time.Time
+}
+
+// failed records a failure of source and returns the failures of source in
+// the window, this one included.
+func (t *FailureTracker) failed(source string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	times, ok := t.failures[source]
+	if !ok && len(t.failures) >= t.size {
+		t.sweep(now)
+		if len(t.failures) >= t.size {
+			return 1
+		}
+	}
+
+	times = append(t.recent(times, now), now)
+	// only the last threshold failures tell whether the source is blocked
+	if len(times) > t.threshold {
+		times = times[len(times)-t.threshold:]
+	}
+	t.failures[source] = times
+
+	return len(times)
+}
+
+// blocked reports whether source reached the threshold in the window, and
+// how long until it is unblocked.
+func (t *FailureTracker) blocked(source string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	times := t.recent(t.failures[source], now)
+	if len(times) < t.threshold {
+		return 0, false
+	}
+
+	return times[0].Add(t.window).Sub(now), true
+}
+
+// recent returns the failures of times in the window ending at now.
+func (t *FailureTracker) recent(times []time.Time, now time.Time) []time.Time {
+	start := now.Add(-t.window)
+	for i, at := range times {
+		if at.After(start) {
+			return times[i:]
+		}
+	}
+	return nil
+}
+
+func (t *FailureTracker) sweep(now time.Time) {
+	for source, times := range t.failures {
+		if len(t.recent(times, now)) == 0 {
+			delete(t.failures, source)
+		}
+	}
+}
+
+// NewFailureTracker returns a tracker blocking the sources with threshold
+// failed authentications within window, tracking at most size sources.
+func NewFailureTracker(threshold int, window time.Duration, size int) *FailureTracker {
+	t := new(FailureTracker)
+	t.threshold = threshold
+	t.window = window
+	t.size = size
+	t.now = time.Now
+	t.failures = make(map[string][]time.Time)
+
+	return t
+}
+
+// remoteIP returns the IP of a host:port address, the address itself if it
+// has no port.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+func TestFailureTrackerWindow(t *testing.T) {
+	now := time.Now()
+	f := NewFailureTracker(3, time.Minute, 10)
+	f.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		if _, blocked := f.blocked("ip:10.0.0.1"); blocked {
+			t.Fatalf("expected the source not to be blocked after %d failures", i-1)
+		}
+		if n := f.failed("ip:10.0.0.1"); n != i {
+			t.Fatalf("expected %d failures, got %d", i, n)
+		}
+		now = now.Add(10 * time.Second)
+	}
+
+	retryAfter, blocked := f.blocked("ip:10.0.0.1")
+	if !blocked || retryAfter != 30*time.Second {
+		t.Fatalf("expected the source to be blocked for 30s, got %v, %v", retryAfter, blocked)
+	}
+	if _, blocked := f.blocked("ip:10.0.0.2"); blocked {
+		t.Error("expected the other sources not to be blocked")
+	}
+
+	// the first failure leaves the window
+	now = now.Add(30 * time.Second)
+	if _, blocked := f.blocked("ip:10.0.0.1"); blocked {
+		t.Error("expected the source to be unblocked once its oldest failure left the window")
+	}
+}
+
+func TestFailureTrackerSize(t *testing.T) {
+	now := time.Now()
+	f := NewFailureTracker(2, time.Minute, 2)
+	f.now = func() time.Time { return now }
+
+	f.failed("ip:10.0.0.1")
+	f.failed("ip:10.0.0.2")
+	f.failed("ip:10.0.0.3")
+	if len(f.failures) != 2 {
+		t.Fatalf("expected 2 tracked sources, got %d", len(f.failures))
+	}
+
+	// the sources without failure in the window make room for new ones
+	now = now.Add(2 * time.Minute)
+	f.failed("ip:10.0.0.3")
+	if _, ok := f.failures["ip:10.0.0.3"]; !ok || len(f.failures) != 1 {
+		t.Errorf("expected only the new source to be tracked, got %v", f.failures)
+	}
+}
+
+func TestMiddleware_AuthenticateFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+
+	source := logging.WithLabel("source_ip", "192.0.2.1")
+	mockSecurity.EXPECT().FailedLogin("invalid_token", source, logging.WithLabel("subject", "")).Times(2)
+	mockSecurity.EXPECT().AccountLockout("192.0.2.1", source, logging.WithLabel("reason", "invalid_token"))
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_failure", "role": ""}).Times(2)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "authn_blocked", "role": ""})
+
+	verifier := NewMockTokenVerifierInterface(ctrl)
+	verifier.EXPECT().VerifyToken(gomock.Any(), "invalid-token").Return(nil, fmt.Errorf("invalid token")).Times(2)
+
+	m := NewMiddleware(verifier, mockTracer, mockMonitor, mockLogger)
+	m.SetFailureTracker(NewFailureTracker(2, time.Minute, 10))
+	handler := m.Authenticate()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, expected := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		r := httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil)
+		r.RemoteAddr = "192.0.2.1:4321"
+		r.Header.Set("Authorization", "Bearer invalid-token")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != expected {
+			t.Fatalf("expected %d, got %d", expected, w.Code)
+		}
+		if expected == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "60" {
+			t.Errorf("expected to retry after 60s, got %q", w.Header().Get("Retry-After"))
+		}
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"context"
 
 	"github.com/coreos/go-oidc/v3/oidc"
+	ory "github.com/ory/client-go"
+
+	"github.com/canonical/tenant-service/internal/types"
 )
 
 type ProviderInterface interface {
@@ -16,6 +19,16 @@ type ProviderInterface interface {
 
 type TokenVerifierInterface interface {
 	// VerifyToken verifies a raw JWT string and validates authorization claims
-	// Returns the subject (user ID) if the token is valid and authorized, otherwise an error
-	VerifyToken(ctx context.Context, rawToken string) (string, error)
+	// Returns the principal described by the token if it is valid and authorized, otherwise an error
+	VerifyToken(ctx context.Context, rawToken string) (*Principal, error)
+}
+
+type SessionClientInterface interface {
+	// ToSession returns the Kratos session of the given Cookie header
+	ToSession(ctx context.Context, cookie string) (*ory.Session, error)
+}
+
+type APIKeyStoreInterface interface {
+	// GetAPIKeyByHash returns the API key with the given SHA-256, revoked or not
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*types.APIKey, error)
 }
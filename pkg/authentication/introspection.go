@@ -0,0 +1,231 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// audience is the aud member of an introspection response, a string or an
+// array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// introspection is the response of an RFC 7662 introspection endpoint.
+type introspection struct {
+	tokenClaims
+
+	Active    bool     `json:"active"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	// TokenUse is set by Hydra, access_token or refresh_token
+	TokenUse string `json:"token_use"`
+}
+
+// IntrospectionVerifier verifies opaque access tokens with an OAuth2 token
+// introspection endpoint (RFC 7662), such as Hydra's. Active tokens are
+// accepted with the policy of the issuer named by the response.
+type IntrospectionVerifier struct {
+	endpoint     string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+	policies     map[string]*accessPolicy
+	cache        *TokenCache
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (v *IntrospectionVerifier) VerifyToken(ctx context.Context, rawToken string) (*Principal, error) {
+	ctx, span := v.tracer.Start(ctx, "authentication.IntrospectionVerifier.VerifyToken")
+	defer span.End()
+
+	if v.cache != nil {
+		if principal, ok := v.cache.get(AuthMethodIntrospection, rawToken); ok {
+			span.SetAttributes(attribute.Bool("authn.cache_hit", true))
+			v.countCacheLookup("authn_cache_hit")
+			return principal, nil
+		}
+		span.SetAttributes(attribute.Bool("authn.cache_hit", false))
+		v.countCacheLookup("authn_cache_miss")
+	}
+
+	result, err := v.introspect(ctx, rawToken)
+	if err != nil {
+		v.logger.Errorf("failed to introspect token: %v", err)
+		return nil, err
+	}
+
+	if !result.Active {
+		return nil, fmt.Errorf("unauthorized: token is not active")
+	}
+	if result.TokenUse != "" && result.TokenUse != "access_token" {
+		v.logger.Security().AuthzFailure(result.Subject, "jwt_api_access")
+		return nil, fmt.Errorf("unauthorized: %s is not an access token", result.TokenUse)
+	}
+
+	policy, ok := v.policies[result.Issuer]
+	if !ok {
+		v.logger.Debugf("Introspected token issued by untrusted issuer %s", result.Issuer)
+		return nil, fmt.Errorf("untrusted issuer %q", result.Issuer)
+	}
+
+	// the client the token was issued to is the authorized party
+	claims := result.tokenClaims
+	if claims.AuthorizedParty == "" {
+		claims.AuthorizedParty = claims.ClientID
+	}
+
+	principal, err := policy.authorize(claims, result.Audience, AuthMethodIntrospection)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		var expiry time.Time
+		if result.ExpiresAt > 0 {
+			expiry = time.Unix(result.ExpiresAt, 0)
+		}
+		v.cache.set(AuthMethodIntrospection, rawToken, principal, expiry)
+	}
+	return principal, nil
+}
+
+func (v *IntrospectionVerifier) introspect(ctx context.Context, rawToken string) (*introspection, error) {
+	form := url.Values{"token": {rawToken}, "token_type_hint": {"access_token"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if v.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(v.clientID), url.QueryEscape(v.clientSecret))
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("introspection failed: %s %s", resp.Status, body)
+	}
+
+	result := new(introspection)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("malformed introspection response: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetCache caches the principals of the active tokens until they expire, at
+// most for the TTL of cache.
+func (v *IntrospectionVerifier) SetCache(cache *TokenCache) {
+	v.cache = cache
+}
+
+func (v *IntrospectionVerifier) countCacheLookup(operation string) {
+	if err := v.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		v.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewIntrospectionVerifier returns a verifier introspecting tokens with
+// endpoint, authenticated with the client credentials when clientID is set.
+// The tokens are accepted with the policy of their issuer among issuers.
+func NewIntrospectionVerifier(
+	endpoint, clientID, clientSecret string,
+	issuers []IssuerConfig,
+	httpClient *http.Client,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *IntrospectionVerifier {
+	if httpClient == nil {
+		httpClient = &otelHTTPClient
+	}
+
+	v := new(IntrospectionVerifier)
+	v.endpoint = endpoint
+	v.clientID = clientID
+	v.clientSecret = clientSecret
+	v.client = httpClient
+	v.policies = make(map[string]*accessPolicy, len(issuers))
+	for _, issuer := range issuers {
+		v.policies[issuer.Issuer] = newAccessPolicy(issuer, logger)
+	}
+	v.tracer = tracer
+	v.monitor = monitor
+	v.logger = logger
+
+	return v
+}
+
+// FallbackVerifier verifies JWTs with one verifier and the other, opaque,
+// tokens with another.
+type FallbackVerifier struct {
+	jwt    TokenVerifierInterface
+	opaque TokenVerifierInterface
+}
+
+func (f *FallbackVerifier) VerifyToken(ctx context.Context, rawToken string) (*Principal, error) {
+	if isJWT(rawToken) {
+		return f.jwt.VerifyToken(ctx, rawToken)
+	}
+	return f.opaque.VerifyToken(ctx, rawToken)
+}
+
+// isJWT reports whether a token has the shape of a JWS, opaque tokens may
+// hold dots too.
+func isJWT(rawToken string) bool {
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	return decodeTokenPart(rawToken, 0, &header) == nil && header.Algorithm != ""
+}
+
+// NewFallbackVerifier returns a verifier passing the tokens that are not JWTs
+// to opaque.
+func NewFallbackVerifier(jwt, opaque TokenVerifierInterface) *FallbackVerifier {
+	f := new(FallbackVerifier)
+	f.jwt = jwt
+	f.opaque = opaque
+
+	return f
+}
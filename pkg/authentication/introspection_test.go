@@ -0,0 +1,186 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestIntrospectionVerifier(ctrl *gomock.Controller, endpoint string, issuers []IssuerConfig) *IntrospectionVerifier {
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "authentication.IntrospectionVerifier.VerifyToken").Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurity.EXPECT().AuthzFailure(gomock.Any(), "jwt_api_access").AnyTimes()
+	mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+
+	return NewIntrospectionVerifier(endpoint, "tenant-service", "s3cr3t", issuers, nil, mockTracer, mockMonitor, mockLogger)
+}
+
+func TestIntrospectionVerifier_VerifyToken(t *testing.T) {
+	issuer := "https://hydra.example.com"
+	issuers := []IssuerConfig{{
+		Issuer:          issuer,
+		Audiences:       []string{"tenant-service"},
+		AllowedSubjects: []string{"user-1"},
+		ServiceClients:  map[string]PlatformRole{"ops": PlatformRoleAdmin},
+	}}
+
+	tests := []struct {
+		name     string
+		status   int
+		response map[string]any
+		expected *Principal
+	}{
+		{
+			name:     "active user token",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "user-1", "client_id": "tenant-cli", "aud": []string{"tenant-service"}, "token_use": "access_token"},
+			expected: &Principal{ID: "user-1", Type: PrincipalUser},
+		},
+		{
+			name:     "active client credentials token",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "ops", "client_id": "ops", "aud": "tenant-service"},
+			expected: &Principal{ID: "ops", Type: PrincipalService, PlatformRole: PlatformRoleAdmin},
+		},
+		{
+			name:     "several audiences with the client as authorized party",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "user-1", "client_id": "tenant-cli", "aud": []string{"account", "tenant-service"}},
+			expected: &Principal{ID: "user-1", Type: PrincipalUser},
+		},
+		{
+			name:     "inactive token",
+			status:   http.StatusOK,
+			response: map[string]any{"active": false},
+		},
+		{
+			name:     "refresh token",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "user-1", "aud": "tenant-service", "token_use": "refresh_token"},
+		},
+		{
+			name:     "untrusted issuer",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": "https://evil.example.com", "sub": "user-1", "aud": "tenant-service"},
+		},
+		{
+			name:     "subject not allowed",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "user-2", "aud": "tenant-service"},
+		},
+		{
+			name:     "audience not accepted",
+			status:   http.StatusOK,
+			response: map[string]any{"active": true, "iss": issuer, "sub": "user-1", "aud": "account"},
+		},
+		{
+			name:   "introspection failure",
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if id, secret, ok := r.BasicAuth(); !ok || id != "tenant-service" || secret != "s3cr3t" {
+					t.Errorf("expected the client credentials, got %q, %q", id, secret)
+				}
+				if err := r.ParseForm(); err != nil || r.PostForm.Get("token") != "ory_at_opaque.token" {
+					t.Errorf("expected the token in the form, got %v, %v", r.PostForm, err)
+				}
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			v := newTestIntrospectionVerifier(ctrl, server.URL, issuers)
+
+			principal, err := v.VerifyToken(context.Background(), "ory_at_opaque.token")
+
+			if tt.expected == nil {
+				if err == nil {
+					t.Fatalf("expected the token to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.ID != tt.expected.ID || principal.Type != tt.expected.Type || principal.PlatformRole != tt.expected.PlatformRole || principal.Method != AuthMethodIntrospection {
+				t.Errorf("expected %+v, got %+v", tt.expected, principal)
+			}
+		})
+	}
+}
+
+func TestIntrospectionVerifier_VerifyTokenCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := "https://hydra.example.com"
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": true, "iss": issuer, "sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()})
+	}))
+	defer server.Close()
+
+	v := newTestIntrospectionVerifier(ctrl, server.URL, []IssuerConfig{{Issuer: issuer, AllowedSubjects: []string{"user-1"}}})
+	v.SetCache(NewTokenCache(10, time.Minute, time.Minute))
+
+	for range 2 {
+		if _, err := v.VerifyToken(context.Background(), "ory_at_opaque"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the token to be introspected once, got %d", calls.Load())
+	}
+}
+
+func TestFallbackVerifier_VerifyToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, sign := newTestIssuer(t, "https://issuer.example.com")
+	jwt := sign(map[string]any{"sub": "user-1"})
+
+	mockJWT := NewMockTokenVerifierInterface(ctrl)
+	mockOpaque := NewMockTokenVerifierInterface(ctrl)
+	mockJWT.EXPECT().VerifyToken(gomock.Any(), jwt).Return(&Principal{ID: "user-1"}, nil)
+	mockOpaque.EXPECT().VerifyToken(gomock.Any(), "ory_at_opaque.token").Return(&Principal{ID: "user-2"}, nil)
+	mockOpaque.EXPECT().VerifyToken(gomock.Any(), "a.b.c").Return(&Principal{ID: "user-3"}, nil)
+
+	f := NewFallbackVerifier(mockJWT, mockOpaque)
+
+	for token, expected := range map[string]string{jwt: "user-1", "ory_at_opaque.token": "user-2", "a.b.c": "user-3"} {
+		principal, err := f.VerifyToken(context.Background(), token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if principal.ID != expected {
+			t.Errorf("expected %s for %s, got %+v", expected, token, principal)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// TokenLifetime is how the time claims of the JWTs are checked. Leeway
+// tolerates the clock skew with the issuers on exp, nbf and iat. MaxAge,
+// when set, rejects the tokens issued longer ago whatever their expiry, and
+// the tokens without iat.
+type TokenLifetime struct {
+	Leeway time.Duration
+	MaxAge time.Duration
+}
+
+// check returns why token is not valid at now, the reason is logged and the
+// error returned to the caller.
+func (l TokenLifetime) check(token *oidc.IDToken, claims tokenClaims, now time.Time) (string, error) {
+	if now.After(token.Expiry.Add(l.Leeway)) {
+		return "token_expired", fmt.Errorf("unauthorized: token expired at %s", token.Expiry.Format(time.RFC3339))
+	}
+
+	if claims.NotBefore != 0 {
+		nbf := unixTime(claims.NotBefore)
+		if now.Add(l.Leeway).Before(nbf) {
+			return "token_not_yet_valid", fmt.Errorf("unauthorized: token is not valid before %s", nbf.Format(time.RFC3339))
+		}
+	}
+
+	if !token.IssuedAt.IsZero() && now.Add(l.Leeway).Before(token.IssuedAt) {
+		return "token_issued_in_future", fmt.Errorf("unauthorized: token is issued at %s, in the future", token.IssuedAt.Format(time.RFC3339))
+	}
+
+	if l.MaxAge > 0 {
+		if token.IssuedAt.IsZero() {
+			return "token_without_iat", fmt.Errorf("unauthorized: token has no issue time")
+		}
+		if now.Sub(token.IssuedAt) > l.MaxAge+l.Leeway {
+			return "token_too_old", fmt.Errorf("unauthorized: token is issued more than %s ago", l.MaxAge)
+		}
+	}
+
+	return "", nil
+}
+
+// expiry returns when a token stops being valid, its expiry or the end of
+// its maximum age if sooner.
+func (l TokenLifetime) expiry(token *oidc.IDToken) time.Time {
+	expiry := token.Expiry.Add(l.Leeway)
+	if l.MaxAge > 0 {
+		if end := token.IssuedAt.Add(l.MaxAge + l.Leeway); end.Before(expiry) {
+			return end
+		}
+	}
+	return expiry
+}
+
+// unixTime returns the time of a NumericDate claim, which may hold a fraction
+// of second.
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
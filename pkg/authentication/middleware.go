@@ -7,16 +7,23 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/canonical/tenant-service/internal/clientip"
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/tracing"
@@ -24,6 +31,10 @@ import (
 
 type Middleware struct {
 	verifier TokenVerifierInterface
+	sessions *SessionVerifier
+	apiKeys  *APIKeyVerifier
+	routes   *RoutePolicy
+	failures *FailureTracker
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
@@ -33,10 +44,63 @@ type Middleware struct {
 func (m *Middleware) Authenticate() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if m.routes != nil && m.routes.IsPublic(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			ctx, span := m.tracer.Start(r.Context(), "authentication.Middleware.Authenticate")
 			defer span.End()
 
+			source := clientIP(ctx, r.RemoteAddr)
+			if retryAfter, blocked := m.blocked(source); blocked {
+				span.SetStatus(otelcodes.Error, "too many failed authentications")
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				m.errorResponse(w, http.StatusTooManyRequests, "too many failed authentications, retry later")
+				return
+			}
+
+			mode := RouteModeAny
+			if m.routes != nil {
+				mode = m.routes.Mode(r.URL.Path)
+			}
+
 			token, found := m.getBearerToken(r.Header)
+			if found && !mode.accepts(RouteModeJWT) {
+				err := fmt.Errorf("bearer tokens are not accepted on %s routes", mode)
+				span.RecordError(err)
+				span.SetStatus(otelcodes.Error, err.Error())
+				m.unauthorizedResponse(w, "bearer tokens are not accepted on this route")
+				return
+			}
+			if key := r.Header.Get(APIKeyHeader); !found && key != "" && m.apiKeys != nil && mode.accepts(RouteModeAPIKey) {
+				principal, err := m.apiKeys.VerifyAPIKey(ctx, key)
+				if err != nil {
+					m.logger.Debugf("API key verification failed: %v", err)
+					m.failed(source, apiKeySubject(key), "invalid_api_key")
+					span.RecordError(err)
+					span.SetStatus(otelcodes.Error, err.Error())
+					m.unauthorizedResponse(w, "invalid API key")
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+				return
+			}
+			if !found && m.sessions != nil && mode.accepts(RouteModeSession) && m.sessions.Applies(r) {
+				principal, err := m.sessions.VerifySession(ctx, r)
+				if err != nil {
+					m.logger.Debugf("session verification failed: %v", err)
+					m.failed(source, "", "invalid_session")
+					span.RecordError(err)
+					span.SetStatus(otelcodes.Error, err.Error())
+					m.unauthorizedResponse(w, "invalid session")
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+				return
+			}
 			if !found {
 				err := errors.New("missing authorization header")
 				span.RecordError(err)
@@ -45,17 +109,18 @@ func (m *Middleware) Authenticate() func(http.Handler) http.Handler {
 				return
 			}
 
-			userID, err := m.verifier.VerifyToken(ctx, token)
+			principal, err := m.verifier.VerifyToken(ctx, token)
 			if err != nil {
 				m.logger.Debugf("JWT verification failed: %v", err)
+				m.failed(source, tokenSubject(token), "invalid_token")
 				span.RecordError(err)
 				span.SetStatus(otelcodes.Error, err.Error())
 				m.unauthorizedResponse(w, "invalid token")
 				return
 			}
 
-			// Token is valid, inject user ID into context
-			ctx = WithUserID(ctx, userID)
+			// Token is valid, inject the principal into context
+			ctx = WithPrincipal(ctx, principal)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -66,6 +131,44 @@ func (m *Middleware) GRPCInterceptor(ctx context.Context, req interface{}, info
 	ctx, span := m.tracer.Start(ctx, "authentication.Middleware.GRPCInterceptor")
 	defer span.End()
 
+	principal, err := m.authenticateGRPC(ctx, span)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(WithPrincipal(ctx, principal), req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return resp, err
+}
+
+// GRPCStreamInterceptor is the stream interceptor for gRPC authentication,
+// the credentials are checked once when the stream opens.
+func (m *Middleware) GRPCStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := m.tracer.Start(ss.Context(), "authentication.Middleware.GRPCStreamInterceptor")
+	principal, err := m.authenticateGRPC(ctx, span)
+	span.End()
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: WithPrincipal(ss.Context(), principal)})
+}
+
+// authenticateGRPC returns the principal of the bearer token, or API key, in
+// the metadata of the call of ctx.
+func (m *Middleware) authenticateGRPC(ctx context.Context, span trace.Span) (*Principal, error) {
+	var source string
+	if p, ok := peer.FromContext(ctx); ok {
+		source = clientIP(ctx, p.Addr.String())
+	}
+	if retryAfter, blocked := m.blocked(source); blocked {
+		span.SetStatus(otelcodes.Error, "too many failed authentications")
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed authentications, retry in %s", retryAfter.Round(time.Second))
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		err := errors.New("metadata is not provided")
@@ -75,6 +178,18 @@ func (m *Middleware) GRPCInterceptor(ctx context.Context, req interface{}, info
 	}
 
 	values := md.Get("authorization")
+	if keys := md.Get(APIKeyHeader); len(values) == 0 && len(keys) > 0 && m.apiKeys != nil {
+		principal, err := m.apiKeys.VerifyAPIKey(ctx, keys[0])
+		if err != nil {
+			m.logger.Debugf("gRPC API key verification failed: %v", err)
+			m.failed(source, apiKeySubject(keys[0]), "invalid_api_key")
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+
+		return principal, nil
+	}
 	if len(values) == 0 {
 		err := errors.New("authorization token is not provided")
 		span.RecordError(err)
@@ -91,21 +206,110 @@ func (m *Middleware) GRPCInterceptor(ctx context.Context, req interface{}, info
 	}
 
 	token := strings.TrimPrefix(authHeader, "Bearer ")
-	userID, err := m.verifier.VerifyToken(ctx, token)
+	principal, err := m.verifier.VerifyToken(ctx, token)
 	if err != nil {
 		m.logger.Debugf("gRPC JWT verification failed: %v", err)
+		m.failed(source, tokenSubject(token), "invalid_token")
 		span.RecordError(err)
 		span.SetStatus(otelcodes.Error, err.Error())
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
-	ctx = WithUserID(ctx, userID)
-	resp, err := handler(ctx, req)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(otelcodes.Error, err.Error())
+	return principal, nil
+}
+
+// authenticatedStream carries the principal of the stream in its context.
+type authenticatedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetSessionVerifier authenticates the HTTP requests without bearer token
+// with their Kratos session on the routes of sessions.
+func (m *Middleware) SetSessionVerifier(sessions *SessionVerifier) {
+	m.sessions = sessions
+}
+
+// SetAPIKeyVerifier authenticates the requests without bearer token
+// presenting a tenant API key in the X-API-Key header or gRPC metadata.
+func (m *Middleware) SetAPIKeyVerifier(apiKeys *APIKeyVerifier) {
+	m.apiKeys = apiKeys
+}
+
+// SetRoutePolicy serves the public routes of routes without authentication
+// and restricts the others to their route mode. Without a policy every route
+// is authenticated with any enabled scheme.
+func (m *Middleware) SetRoutePolicy(routes *RoutePolicy) {
+	m.routes = routes
+}
+
+// SetFailureTracker tracks the failed authentications, the clients reaching
+// the threshold of failures get 429 or ResourceExhausted until they fall
+// below it. Without a tracker the failures are only logged at debug level.
+func (m *Middleware) SetFailureTracker(failures *FailureTracker) {
+	m.failures = failures
+}
+
+// blocked reports whether the client IP source reached the threshold of
+// failures, and how long until it is unblocked.
+func (m *Middleware) blocked(source string) (time.Duration, bool) {
+	if m.failures == nil || source == "" {
+		return 0, false
+	}
+
+	retryAfter, blocked := m.failures.blocked("ip:" + source)
+	if blocked {
+		m.countFailure("authn_blocked")
+	}
+	return retryAfter, blocked
+}
+
+// failed records a failed authentication from the client IP source claiming
+// subject, if known. Only the IPs are blocked, the subjects are not verified
+// and would let anyone lock a user out, their repeated failures are logged.
+func (m *Middleware) failed(source, subject, reason string) {
+	if m.failures == nil {
+		return
+	}
+
+	m.countFailure("authn_failure")
+	m.logger.Security().FailedLogin(reason, logging.WithLabel("source_ip", source), logging.WithLabel("subject", subject))
+
+	if source != "" && m.failures.failed("ip:"+source) == m.failures.threshold {
+		m.logger.Security().AccountLockout(source, logging.WithLabel("source_ip", source), logging.WithLabel("reason", reason))
+	}
+	if subject != "" && m.failures.failed("sub:"+subject) == m.failures.threshold {
+		m.logger.Security().FailedLogin("repeated_failures", logging.WithLabel("source_ip", source), logging.WithLabel("subject", subject))
 	}
-	return resp, err
+}
+
+func (m *Middleware) countFailure(operation string) {
+	if err := m.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		m.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// clientIP returns the client IP resolved for the request of ctx, the IP of
+// the peer address remoteAddr if none was.
+func clientIP(ctx context.Context, remoteAddr string) string {
+	if ip, ok := clientip.FromContext(ctx); ok {
+		return ip
+	}
+	return remoteIP(remoteAddr)
+}
+
+// apiKeySubject returns the prefix of key, which names it without
+// revealing it.
+func apiKeySubject(key string) string {
+	if len(key) < APIKeyPrefixLength {
+		return ""
+	}
+	return "api_key:" + key[:APIKeyPrefixLength]
 }
 
 func (m *Middleware) getBearerToken(headers http.Header) (string, bool) {
@@ -123,13 +327,17 @@ func (m *Middleware) getBearerToken(headers http.Header) (string, bool) {
 }
 
 func (m *Middleware) unauthorizedResponse(w http.ResponseWriter, message string) {
+	m.errorResponse(w, http.StatusUnauthorized, message)
+}
+
+func (m *Middleware) errorResponse(w http.ResponseWriter, code int, message string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":  http.StatusUnauthorized,
+		"status":  code,
 		"message": message,
 	}); err != nil {
-		m.logger.Errorf("failed to encode unauthorized response: %v", err)
+		m.logger.Errorf("failed to encode error response: %v", err)
 	}
 }
 
@@ -50,7 +50,7 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			authHeader: "Bearer invalid-token",
 			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
 				mockVerifier := NewMockTokenVerifierInterface(ctrl)
-				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "invalid-token").Return("", fmt.Errorf("invalid token"))
+				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "invalid-token").Return(nil, fmt.Errorf("invalid token"))
 				return mockVerifier
 			},
 			expectedStatusCode: http.StatusUnauthorized,
@@ -60,11 +60,31 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			authHeader: "Bearer valid-token",
 			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
 				mockVerifier := NewMockTokenVerifierInterface(ctrl)
-				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return("user-123", nil)
+				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(&Principal{ID: "user-123", Type: PrincipalUser}, nil)
 				return mockVerifier
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedBody:       "success",
+			expectedBody:       "user-123:user",
+		},
+		{
+			name:       "Valid service token",
+			authHeader: "Bearer client-token",
+			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
+				mockVerifier := NewMockTokenVerifierInterface(ctrl)
+				mockVerifier.EXPECT().VerifyToken(gomock.Any(), "client-token").Return(&Principal{ID: "client-1", Type: PrincipalService}, nil)
+				return mockVerifier
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "client-1:service",
+		},
+		{
+			name:       "Kratos identity ID without verification",
+			authHeader: "Bearer identity-1",
+			setupMocks: func(ctrl *gomock.Controller) TokenVerifierInterface {
+				return NewNoopVerifier()
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "identity-1:user",
 		},
 	}
 
@@ -86,8 +106,13 @@ func TestMiddleware_Authenticate(t *testing.T) {
 			middleware := NewMiddleware(mockVerifier, mockTracer, mockMonitor, mockLogger)
 
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p, ok := GetPrincipal(r.Context())
+				if !ok {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
 				w.WriteHeader(http.StatusOK)
-				w.Write([]byte("success"))
+				w.Write([]byte(p.ID + ":" + string(p.Type)))
 			})
 
 			req := httptest.NewRequest(http.MethodGet, "/test", nil)
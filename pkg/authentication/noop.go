@@ -15,6 +15,6 @@ func NewNoopVerifier() *NoopVerifier {
 }
 
 // VerifyToken treats the token as the user ID for development purposes.
-func (n *NoopVerifier) VerifyToken(ctx context.Context, rawIDToken string) (string, error) {
-	return rawIDToken, nil
+func (n *NoopVerifier) VerifyToken(ctx context.Context, rawIDToken string) (*Principal, error) {
+	return &Principal{ID: rawIDToken, Type: PrincipalUser, Method: AuthMethodIdentity}, nil
 }
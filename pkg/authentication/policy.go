@@ -0,0 +1,143 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+// tokenClaims are the claims of a verified token the access policy of its
+// issuer applies to.
+type tokenClaims struct {
+	Subject         string   `json:"sub"`
+	Email           string   `json:"email"`
+	ClientID        string   `json:"client_id"`
+	AuthorizedParty string   `json:"azp"`
+	Scope           string   `json:"scope"`
+	Scopes          []string `json:"scp"`
+	NotBefore       float64  `json:"nbf"`
+	AuthTime        float64  `json:"auth_time"`
+	AuthMethods     []string `json:"amr"`
+}
+
+// accessPolicy is the policy of an IssuerConfig, the tokens of the issuer it
+// accepts whatever the way they are verified.
+type accessPolicy struct {
+	audiences       []string
+	audienceBypass  []string
+	allowedSubjects []string
+	requiredScope   string
+	serviceClients  map[string]PlatformRole
+
+	logger logging.LoggerInterface
+}
+
+// authorize returns the principal of the claims of a verified token, issued
+// to audience, if the policy accepts it.
+func (p *accessPolicy) authorize(claims tokenClaims, audience []string, method AuthMethod) (*Principal, error) {
+	if err := p.checkAudience(audience, claims.AuthorizedParty, claims.ClientID); err != nil {
+		p.logger.Debugf("Token audience rejected: %v", err)
+		p.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access")
+		return nil, err
+	}
+
+	principal := &Principal{
+		ID:          claims.Subject,
+		Email:       claims.Email,
+		Type:        PrincipalUser,
+		Scopes:      append(strings.Fields(claims.Scope), claims.Scopes...),
+		Method:      method,
+		AuthMethods: claims.AuthMethods,
+	}
+	if claims.AuthTime != 0 {
+		principal.AuthTime = unixTime(claims.AuthTime)
+	}
+	// client credentials tokens are issued to the client itself, some issuers
+	// leave the subject out
+	if claims.ClientID != "" && (claims.Subject == "" || claims.ClientID == claims.Subject) {
+		principal.ID = claims.ClientID
+		principal.Type = PrincipalService
+	}
+
+	if principal.Type == PrincipalService {
+		if role, ok := p.serviceClients[principal.ID]; ok {
+			principal.PlatformRole = role
+			return principal, nil
+		}
+	}
+
+	if principal.ID == "" {
+		p.logger.Security().AuthzFailure(principal.ID, "jwt_api_access")
+		return nil, fmt.Errorf("unauthorized: token has no subject")
+	}
+
+	if err := p.checkPrincipal(principal, "jwt_api_access"); err != nil {
+		return nil, err
+	}
+	return principal, nil
+}
+
+// checkPrincipal checks that the policy accepts principal, through its
+// subject or its scopes, the failures are logged as action.
+func (p *accessPolicy) checkPrincipal(principal *Principal, action string) error {
+	if len(p.allowedSubjects) > 0 && slices.Contains(p.allowedSubjects, principal.ID) {
+		return nil
+	}
+
+	if p.requiredScope != "" && slices.Contains(principal.Scopes, p.requiredScope) {
+		return nil
+	}
+
+	if len(p.allowedSubjects) == 0 && p.requiredScope == "" {
+		p.logger.Debugf("No authorization criteria configured")
+		p.logger.Security().AuthzFailure(principal.ID, action)
+		return fmt.Errorf("unauthorized: no access policy configured")
+	}
+
+	p.logger.Security().AuthzFailure(principal.ID, action)
+	return fmt.Errorf("unauthorized: missing required scope or subject not allowed")
+}
+
+// checkAudience checks that a token was issued to one of the accepted
+// audiences. As in OIDC, a token issued to several audiences must name the
+// party it was issued for with azp. The tokens of the legacy clients, named
+// by azp or client_id, are not checked.
+func (p *accessPolicy) checkAudience(audience []string, azp, clientID string) error {
+	if len(p.audiences) == 0 {
+		return nil
+	}
+
+	client := azp
+	if client == "" {
+		client = clientID
+	}
+	if client != "" && slices.Contains(p.audienceBypass, client) {
+		return nil
+	}
+
+	if !slices.ContainsFunc(audience, func(aud string) bool { return slices.Contains(p.audiences, aud) }) {
+		return fmt.Errorf("unauthorized: token audience not accepted")
+	}
+	if len(audience) > 1 && azp == "" {
+		return fmt.Errorf("unauthorized: token issued to several audiences has no authorized party")
+	}
+
+	return nil
+}
+
+func newAccessPolicy(config IssuerConfig, logger logging.LoggerInterface) *accessPolicy {
+	p := new(accessPolicy)
+	p.audiences = config.Audiences
+	p.audienceBypass = config.AudienceBypassClients
+	p.allowedSubjects = config.AllowedSubjects
+	p.requiredScope = config.RequiredScope
+	p.serviceClients = config.ServiceClients
+	p.logger = logger
+
+	return p
+}
@@ -16,10 +16,17 @@ var (
 	otelHTTPClient = http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
 )
 
+// clientContext makes the OIDC library use httpClient, or the otel-instrumented default client when nil
+func clientContext(ctx context.Context, httpClient *http.Client) context.Context {
+	if httpClient == nil {
+		httpClient = &otelHTTPClient
+	}
+	return oidc.ClientContext(ctx, httpClient)
+}
+
 // NewProvider creates an OIDC provider using the issuer's well-known configuration
-func NewProvider(ctx context.Context, issuer string) (*oidc.Provider, error) {
-	// Use otel-instrumented HTTP client
-	ctx = oidc.ClientContext(ctx, &otelHTTPClient)
+func NewProvider(ctx context.Context, issuer string, httpClient *http.Client) (*oidc.Provider, error) {
+	ctx = clientContext(ctx, httpClient)
 
 	provider, err := oidc.NewProvider(ctx, issuer)
 	if err != nil {
@@ -32,14 +39,16 @@ func NewProvider(ctx context.Context, issuer string) (*oidc.Provider, error) {
 // NewProviderWithJWKS creates an OIDC provider or a manual key set when JWKS URL is provided
 // If jwksURL is provided, it creates a RemoteKeySet directly and wraps it
 // If jwksURL is empty, it uses the standard OIDC discovery
-func NewProviderWithJWKS(ctx context.Context, issuer, jwksURL string) (*oidc.IDTokenVerifier, error) {
-	ctx = oidc.ClientContext(ctx, &otelHTTPClient)
+func NewProviderWithJWKS(ctx context.Context, issuer, jwksURL string, httpClient *http.Client) (*oidc.IDTokenVerifier, error) {
+	ctx = clientContext(ctx, httpClient)
 
 	keySet := oidc.NewRemoteKeySet(ctx, jwksURL)
 
 	verifier := oidc.NewVerifier(issuer, keySet, &oidc.Config{
 		SkipClientIDCheck: true,
 		SkipIssuerCheck:   false,
+		// checked with the leeway of the TokenLifetime
+		SkipExpiryCheck: true,
 	})
 
 	return verifier, nil
@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RouteMode is the authentication scheme the HTTP requests to a route must use.
+type RouteMode string
+
+const (
+	// RouteModeJWT accepts bearer tokens, JWTs or introspected opaque tokens.
+	RouteModeJWT RouteMode = "jwt"
+	// RouteModeSession accepts Kratos session cookies.
+	RouteModeSession RouteMode = "session"
+	// RouteModeAPIKey accepts tenant API keys.
+	RouteModeAPIKey RouteMode = "api-key"
+	// RouteModeAny accepts every enabled scheme, bearer tokens first.
+	RouteModeAny RouteMode = "any"
+)
+
+// ParseRouteMode returns the route mode named s.
+func ParseRouteMode(s string) (RouteMode, error) {
+	m := RouteMode(s)
+	switch m {
+	case RouteModeJWT, RouteModeSession, RouteModeAPIKey, RouteModeAny:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown route mode %q, must be one of jwt, session, api-key or any", s)
+}
+
+func (m RouteMode) accepts(scheme RouteMode) bool {
+	return m == RouteModeAny || m == scheme
+}
+
+type routeMode struct {
+	prefix string
+	mode   RouteMode
+}
+
+// RoutePolicy tells which HTTP routes are served without authentication and
+// which scheme the others accept. Routes are matched by path prefix, on
+// segment boundaries, the longest prefix wins.
+type RoutePolicy struct {
+	public []string
+	modes  []routeMode
+}
+
+// IsPublic reports whether path is served without authentication.
+func (p *RoutePolicy) IsPublic(path string) bool {
+	return slices.ContainsFunc(p.public, func(prefix string) bool { return matchesPrefix(path, prefix) })
+}
+
+// Mode returns the scheme accepted on path, RouteModeAny when no route
+// mode matches.
+func (p *RoutePolicy) Mode(path string) RouteMode {
+	for _, m := range p.modes {
+		if matchesPrefix(path, m.prefix) {
+			return m.mode
+		}
+	}
+	return RouteModeAny
+}
+
+// matchesPrefix reports whether path is prefix or lies under it, so that
+// /api/v0/status matches /api/v0/status/authorization-model but not
+// /api/v0/statuses.
+func matchesPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
+
+// NewRoutePolicy returns the policy serving the routes under the public
+// prefixes without authentication, the others with the mode of their
+// longest prefix in modes, keyed by prefix and mode name.
+func NewRoutePolicy(public []string, modes map[string]string) (*RoutePolicy, error) {
+	p := new(RoutePolicy)
+	// an empty prefix would match every route
+	for _, prefix := range public {
+		if prefix != "" {
+			p.public = append(p.public, prefix)
+		}
+	}
+	for prefix, name := range modes {
+		if prefix == "" {
+			return nil, fmt.Errorf("route mode %s has no path prefix", name)
+		}
+		mode, err := ParseRouteMode(name)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", prefix, err)
+		}
+		p.modes = append(p.modes, routeMode{prefix: prefix, mode: mode})
+	}
+	slices.SortFunc(p.modes, func(a, b routeMode) int { return len(b.prefix) - len(a.prefix) })
+
+	return p, nil
+}
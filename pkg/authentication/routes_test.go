@@ -0,0 +1,151 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+func TestRoutePolicy(t *testing.T) {
+	p, err := NewRoutePolicy(
+		[]string{"/api/v0/status", "/api/v0/webhooks/", ""},
+		map[string]string{"/api/v0/tenants": "jwt", "/api/v0/tenants/export": "api-key", "/api/v0/me": "session"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		public bool
+		mode   RouteMode
+	}{
+		{path: "/api/v0/status", public: true},
+		{path: "/api/v0/status/authorization-model", public: true},
+		{path: "/api/v0/statuses", mode: RouteModeAny},
+		{path: "/api/v0/webhooks/token", public: true},
+		{path: "/api/v0/tenants", mode: RouteModeJWT},
+		{path: "/api/v0/tenants/t-1/users", mode: RouteModeJWT},
+		{path: "/api/v0/tenants/export/t-1", mode: RouteModeAPIKey},
+		{path: "/api/v0/me", mode: RouteModeSession},
+		{path: "/api/v0/platform-admins", mode: RouteModeAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if public := p.IsPublic(tt.path); public != tt.public {
+				t.Fatalf("expected public %v, got %v", tt.public, public)
+			}
+			if tt.public {
+				return
+			}
+			if mode := p.Mode(tt.path); mode != tt.mode {
+				t.Errorf("expected mode %s, got %s", tt.mode, mode)
+			}
+		})
+	}
+}
+
+func TestNewRoutePolicy_Invalid(t *testing.T) {
+	for name, modes := range map[string]map[string]string{
+		"unknown mode": {"/api/v0/tenants": "basic"},
+		"empty prefix": {"": "jwt"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRoutePolicy(nil, modes); err == nil {
+				t.Errorf("expected %v to be rejected", modes)
+			}
+		})
+	}
+}
+
+func TestMiddleware_AuthenticateRouteModes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	verifier := NewMockTokenVerifierInterface(ctrl)
+	verifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(&Principal{ID: "user-1", Type: PrincipalUser}, nil).AnyTimes()
+
+	store := NewMockAPIKeyStoreInterface(ctrl)
+	store.EXPECT().GetAPIKeyByHash(gomock.Any(), HashAPIKey("tsk_secret")).Return(&types.APIKey{ID: "key-1", TenantID: "tenant-1", Role: types.RoleMember}, nil).AnyTimes()
+
+	client := NewMockSessionClientInterface(ctrl)
+	client.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "user-2"), nil).AnyTimes()
+
+	routes, err := NewRoutePolicy([]string{"/api/v0/status"}, map[string]string{"/api/v0/tenants": "jwt", "/api/v0/automation": "api-key"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := NewMiddleware(verifier, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+	m.SetAPIKeyVerifier(newTestAPIKeyVerifier(ctrl, store))
+	m.SetSessionVerifier(newTestSessionVerifier(ctrl, client))
+	m.SetRoutePolicy(routes)
+
+	handler := m.Authenticate()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := GetPrincipal(r.Context())
+		if !ok {
+			w.Write([]byte("anonymous"))
+			return
+		}
+		w.Write([]byte(principal.ID))
+	}))
+
+	tests := []struct {
+		name         string
+		path         string
+		bearer       string
+		key          string
+		cookie       bool
+		expectedCode int
+		expectedID   string
+	}{
+		{name: "public route", path: "/api/v0/status", expectedCode: http.StatusOK, expectedID: "anonymous"},
+		{name: "bearer token on jwt route", path: "/api/v0/tenants", bearer: "valid-token", expectedCode: http.StatusOK, expectedID: "user-1"},
+		{name: "API key on jwt route", path: "/api/v0/tenants", key: "tsk_secret", expectedCode: http.StatusUnauthorized},
+		{name: "session on jwt route", path: "/api/v0/tenants", cookie: true, expectedCode: http.StatusUnauthorized},
+		{name: "API key on api-key route", path: "/api/v0/automation", key: "tsk_secret", expectedCode: http.StatusOK, expectedID: "key-1"},
+		{name: "bearer token on api-key route", path: "/api/v0/automation", bearer: "valid-token", expectedCode: http.StatusUnauthorized},
+		{name: "session on route without mode", path: "/api/v0/me", cookie: true, expectedCode: http.StatusOK, expectedID: "user-2"},
+		{name: "API key on route without mode", path: "/api/v0/me", key: "tsk_secret", expectedCode: http.StatusOK, expectedID: "key-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			if tt.key != "" {
+				r.Header.Set(APIKeyHeader, tt.key)
+			}
+			if tt.cookie {
+				r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedCode {
+				t.Fatalf("expected %d, got %d", tt.expectedCode, w.Code)
+			}
+			if tt.expectedID != "" && w.Body.String() != tt.expectedID {
+				t.Errorf("expected %s, got %s", tt.expectedID, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// SessionCookie is the cookie holding the Kratos session of a browser.
+const SessionCookie = "ory_kratos_session"
+
+// SessionVerifier authenticates browsers with their Kratos session cookie on
+// the routes under one of its path prefixes, the caller is the identity of
+// the session.
+//
+// The API allows credentialed requests from any origin, so a request carrying
+// an Origin must come from one of the trusted origins, and the requests
+// changing state must carry one.
+//
+// The identities of the sessions must be accepted by the access policy, as
+// the subjects of tokens are. Sessions hold no scope.
+type SessionVerifier struct {
+	client  SessionClientInterface
+	routes  []string
+	origins []string
+	policy  *accessPolicy
+	cache   *TokenCache
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Applies tells whether r is authenticated with its session, it must carry
+// the session cookie on one of the session routes.
+func (v *SessionVerifier) Applies(r *http.Request) bool {
+	if _, err := r.Cookie(SessionCookie); err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(v.routes, func(prefix string) bool { return strings.HasPrefix(r.URL.Path, prefix) })
+}
+
+// VerifySession returns the principal of the session of r.
+func (v *SessionVerifier) VerifySession(ctx context.Context, r *http.Request) (*Principal, error) {
+	ctx, span := v.tracer.Start(ctx, "authentication.SessionVerifier.VerifySession")
+	defer span.End()
+
+	if err := v.checkOrigin(r); err != nil {
+		v.logger.Security().AuthzFailure("", "session_api_access")
+		return nil, err
+	}
+
+	cookie, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		if principal, ok := v.cache.get(AuthMethodSession, cookie.String()); ok {
+			span.SetAttributes(attribute.Bool("authn.cache_hit", true))
+			v.countCacheLookup("authn_cache_hit")
+			return principal, nil
+		}
+		span.SetAttributes(attribute.Bool("authn.cache_hit", false))
+		v.countCacheLookup("authn_cache_miss")
+	}
+
+	// only the session cookie is sent to Kratos
+	session, err := v.client.ToSession(ctx, cookie.String())
+	if err != nil {
+		return nil, err
+	}
+	if !session.GetActive() || session.Identity == nil {
+		return nil, fmt.Errorf("unauthorized: session is not active")
+	}
+
+	principal := &Principal{
+		ID:     session.Identity.Id,
+		Type:   PrincipalUser,
+		Method: AuthMethodSession,
+	}
+	if session.AuthenticatedAt != nil {
+		principal.AuthTime = *session.AuthenticatedAt
+	}
+	for _, m := range session.GetAuthenticationMethods() {
+		principal.AuthMethods = append(principal.AuthMethods, m.GetMethod())
+	}
+	if traits, ok := session.Identity.Traits.(map[string]any); ok {
+		principal.Email, _ = traits["email"].(string)
+	}
+
+	if err := v.policy.checkPrincipal(principal, "session_api_access"); err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		var expiry time.Time
+		if session.ExpiresAt != nil {
+			expiry = *session.ExpiresAt
+		}
+		v.cache.set(AuthMethodSession, cookie.String(), principal, expiry)
+	}
+	return principal, nil
+}
+
+func (v *SessionVerifier) checkOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return nil
+		}
+		return fmt.Errorf("unauthorized: %s request authenticated with a session has no origin", r.Method)
+	}
+
+	if !slices.Contains(v.origins, origin) {
+		return fmt.Errorf("unauthorized: origin %s is not trusted with sessions", origin)
+	}
+	return nil
+}
+
+// SetCache caches the principals of the active sessions until they expire,
+// at most for the TTL of cache.
+func (v *SessionVerifier) SetCache(cache *TokenCache) {
+	v.cache = cache
+}
+
+func (v *SessionVerifier) countCacheLookup(operation string) {
+	if err := v.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		v.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewSessionVerifier returns a verifier of the sessions of the requests to
+// the routes under the given path prefixes, from the trusted origins. The
+// allowed subjects and required scope of policy apply to the identities of
+// the sessions.
+func NewSessionVerifier(
+	client SessionClientInterface,
+	routes, origins []string,
+	policy IssuerConfig,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *SessionVerifier {
+	v := new(SessionVerifier)
+	v.client = client
+	v.routes = routes
+	v.origins = origins
+	v.policy = newAccessPolicy(policy, logger)
+	v.tracer = tracer
+	v.monitor = monitor
+	v.logger = logger
+
+	return v
+}
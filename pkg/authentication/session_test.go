@@ -0,0 +1,309 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ory "github.com/ory/client-go"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestSessionVerifier(ctrl *gomock.Controller, client SessionClientInterface) *SessionVerifier {
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurity.EXPECT().AuthzFailure(gomock.Any(), "session_api_access").AnyTimes()
+	mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+
+	policy := IssuerConfig{AllowedSubjects: []string{"user-1", "user-2"}}
+	return NewSessionVerifier(client, []string{"/api/v0/"}, []string{"https://dashboard.example.com"}, policy, mockTracer, mockMonitor, mockLogger)
+}
+
+func newTestSession(active bool, identityID string) *ory.Session {
+	session := ory.NewSession("session-1")
+	session.SetActive(active)
+	session.SetExpiresAt(time.Now().Add(time.Hour))
+	session.Identity = ory.NewIdentity(identityID, "default", "", map[string]any{"email": "jane@example.com"})
+	return session
+}
+
+func TestSessionVerifier_Applies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	v := newTestSessionVerifier(ctrl, NewMockSessionClientInterface(ctrl))
+
+	tests := []struct {
+		name     string
+		path     string
+		cookie   bool
+		expected bool
+	}{
+		{name: "session route with cookie", path: "/api/v0/me", cookie: true, expected: true},
+		{name: "session route without cookie", path: "/api/v0/me"},
+		{name: "other route with cookie", path: "/webhooks/token", cookie: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.cookie {
+				r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+			}
+
+			if applies := v.Applies(r); applies != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, applies)
+			}
+		})
+	}
+}
+
+func TestSessionVerifier_VerifySession(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		setupMocks func(*MockSessionClientInterface)
+		expectedID string
+	}{
+		{
+			name:   "active session",
+			method: http.MethodGet,
+			setupMocks: func(c *MockSessionClientInterface) {
+				c.EXPECT().ToSession(gomock.Any(), SessionCookie+"=abc").Return(newTestSession(true, "user-1"), nil)
+			},
+			expectedID: "user-1",
+		},
+		{
+			name:   "write from the trusted origin",
+			method: http.MethodPost,
+			origin: "https://dashboard.example.com",
+			setupMocks: func(c *MockSessionClientInterface) {
+				c.EXPECT().ToSession(gomock.Any(), SessionCookie+"=abc").Return(newTestSession(true, "user-1"), nil)
+			},
+			expectedID: "user-1",
+		},
+		{
+			name:   "subject not allowed",
+			method: http.MethodGet,
+			setupMocks: func(c *MockSessionClientInterface) {
+				c.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "self-registered"), nil)
+			},
+		},
+		{
+			name:   "inactive session",
+			method: http.MethodGet,
+			setupMocks: func(c *MockSessionClientInterface) {
+				c.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(false, "user-1"), nil)
+			},
+		},
+		{
+			name:   "no session",
+			method: http.MethodGet,
+			setupMocks: func(c *MockSessionClientInterface) {
+				c.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(nil, errors.New("no active session"))
+			},
+		},
+		{
+			name:       "untrusted origin",
+			method:     http.MethodGet,
+			origin:     "https://evil.example.com",
+			setupMocks: func(c *MockSessionClientInterface) {},
+		},
+		{
+			name:       "write without origin",
+			method:     http.MethodDelete,
+			setupMocks: func(c *MockSessionClientInterface) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := NewMockSessionClientInterface(ctrl)
+			tt.setupMocks(client)
+			v := newTestSessionVerifier(ctrl, client)
+
+			r := httptest.NewRequest(tt.method, "/api/v0/tenants", nil)
+			r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+			r.AddCookie(&http.Cookie{Name: "other", Value: "not-for-kratos"})
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			principal, err := v.VerifySession(context.Background(), r)
+
+			if tt.expectedID == "" {
+				if err == nil {
+					t.Fatalf("expected the session to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.ID != tt.expectedID || principal.Type != PrincipalUser || principal.Method != AuthMethodSession || principal.Email != "jane@example.com" {
+				t.Errorf("expected the session identity %s, got %+v", tt.expectedID, principal)
+			}
+		})
+	}
+}
+
+func TestSessionVerifier_VerifySessionCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := NewMockSessionClientInterface(ctrl)
+	client.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "user-1"), nil).Times(1)
+
+	v := newTestSessionVerifier(ctrl, client)
+	v.SetCache(NewTokenCache(10, time.Minute, time.Minute))
+
+	for range 2 {
+		r := httptest.NewRequest(http.MethodGet, "/api/v0/me", nil)
+		r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+		if _, err := v.VerifySession(context.Background(), r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestTokenCache_SessionNotBearer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := NewTokenCache(10, time.Minute, time.Minute)
+
+	client := NewMockSessionClientInterface(ctrl)
+	client.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "user-1"), nil)
+
+	sessions := newTestSessionVerifier(ctrl, client)
+	sessions.SetCache(cache)
+
+	cookie := &http.Cookie{Name: SessionCookie, Value: "abc"}
+	r := httptest.NewRequest(http.MethodGet, "/api/v0/me", nil)
+	r.AddCookie(cookie)
+	if _, err := sessions.VerifySession(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	issuer := "https://hydra.example.com"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": false})
+	}))
+	defer server.Close()
+
+	introspection := newTestIntrospectionVerifier(ctrl, server.URL, []IssuerConfig{{Issuer: issuer, AllowedSubjects: []string{"user-1"}}})
+	introspection.SetCache(cache)
+	if principal, err := introspection.VerifyToken(context.Background(), cookie.String()); err == nil {
+		t.Errorf("expected the cookie as bearer token to be introspected and rejected, got %+v", principal)
+	}
+
+	key, _ := newTestIssuer(t, issuer)
+	jwts := newTestVerifier(ctrl, issuer, key, IssuerConfig{Issuer: issuer, AllowedSubjects: []string{"user-1"}})
+	jwts.SetCache(cache)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+	jwts.monitor = mockMonitor
+	if principal, err := jwts.VerifyToken(context.Background(), cookie.String()); err == nil {
+		t.Errorf("expected the cookie as bearer token to be rejected, got %+v", principal)
+	}
+}
+
+func TestMiddleware_AuthenticateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	client := NewMockSessionClientInterface(ctrl)
+	client.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "user-1"), nil)
+
+	verifier := NewMockTokenVerifierInterface(ctrl)
+	verifier.EXPECT().VerifyToken(gomock.Any(), "valid-token").Return(&Principal{ID: "user-2", Type: PrincipalUser}, nil)
+
+	m := NewMiddleware(verifier, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+	m.SetSessionVerifier(newTestSessionVerifier(ctrl, client))
+
+	handler := m.Authenticate()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := GetPrincipal(r.Context())
+		w.Write([]byte(principal.ID))
+	}))
+
+	for _, tt := range []struct {
+		name       string
+		bearer     string
+		expectedID string
+	}{
+		{name: "session", expectedID: "user-1"},
+		{name: "bearer token over session", bearer: "valid-token", expectedID: "user-2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v0/me", nil)
+			r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+			if tt.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK || w.Body.String() != tt.expectedID {
+				t.Errorf("expected %s, got %d %s", tt.expectedID, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddleware_AuthenticateSessionNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(NewMockSecurityLoggerInterface(ctrl)).AnyTimes()
+	mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+
+	client := NewMockSessionClientInterface(ctrl)
+	client.EXPECT().ToSession(gomock.Any(), gomock.Any()).Return(newTestSession(true, "self-registered"), nil)
+
+	m := NewMiddleware(NewMockTokenVerifierInterface(ctrl), mockTracer, mockMonitor, mockLogger)
+	m.SetSessionVerifier(newTestSessionVerifier(ctrl, client))
+
+	called := false
+	handler := m.Authenticate()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v0/admin/support-groups/platform/admins", nil)
+	r.AddCookie(&http.Cookie{Name: SessionCookie, Value: "abc"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("expected a session whose subject is not allowed to get 401, got %d", w.Code)
+	}
+}
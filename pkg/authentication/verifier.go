@@ -5,11 +5,11 @@ package authentication
 
 import (
 	"context"
-	"fmt"
-	"slices"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
@@ -17,102 +17,135 @@ import (
 )
 
 type JWTVerifier struct {
-	verifier        *oidc.IDTokenVerifier
-	allowedSubjects []string
-	requiredScope   string
+	verifier *oidc.IDTokenVerifier
+	policy   *accessPolicy
+	issuer   string
+	lifetime TokenLifetime
+	cache    *TokenCache
 
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
 	logger  logging.LoggerInterface
 }
 
-func (v *JWTVerifier) VerifyToken(ctx context.Context, rawToken string) (string, error) {
+func (v *JWTVerifier) VerifyToken(ctx context.Context, rawToken string) (*Principal, error) {
 	ctx, span := v.tracer.Start(ctx, "authentication.JWTVerifier.VerifyToken")
 	defer span.End()
 
+	if v.cache == nil {
+		token, err := v.verifier.Verify(ctx, rawToken)
+		if err != nil {
+			return nil, err
+		}
+		return v.authorize(token)
+	}
+
+	if principal, ok := v.cache.get(AuthMethodJWT, rawToken); ok {
+		span.SetAttributes(attribute.Bool("authn.cache_hit", true))
+		v.countCacheLookup("authn_cache_hit")
+		return principal, nil
+	}
+	span.SetAttributes(attribute.Bool("authn.cache_hit", false))
+	v.countCacheLookup("authn_cache_miss")
+
+	// a malformed token has no key ID and fails the verification
+	keyID, _ := tokenKeyID(rawToken)
+	if err := v.cache.keysFailure(v.issuer, keyID); err != nil {
+		v.countCacheLookup("authn_keys_failure_hit")
+		return nil, err
+	}
+
 	token, err := v.verifier.Verify(ctx, rawToken)
 	if err != nil {
-		return "", err
+		// the OIDC library does not wrap the errors of the key set
+		if strings.Contains(err.Error(), "fetching keys") {
+			v.logger.Errorf("failed to fetch the keys of %s: %v", v.issuer, err)
+			v.cache.keysFailed(v.issuer, keyID, err)
+		}
+		return nil, err
 	}
+	v.cache.verifiedKey(v.issuer, keyID)
 
-	var claims struct {
-		Subject string   `json:"sub"`
-		Scope   string   `json:"scope"`
-		Scopes  []string `json:"scp"`
+	principal, err := v.authorize(token)
+	if err != nil {
+		return nil, err
 	}
 
+	v.cache.set(AuthMethodJWT, rawToken, principal, v.lifetime.expiry(token))
+	return principal, nil
+}
+
+// authorize returns the principal of a verified token if it is within its
+// lifetime and the policy of the issuer accepts it.
+func (v *JWTVerifier) authorize(token *oidc.IDToken) (*Principal, error) {
+	var claims tokenClaims
 	if err := token.Claims(&claims); err != nil {
 		v.logger.Debugf("Failed to extract claims: %v", err)
-		return "", err
+		return nil, err
 	}
 
-	if len(v.allowedSubjects) > 0 && slices.Contains(v.allowedSubjects, claims.Subject) {
-		return claims.Subject, nil
+	if reason, err := v.lifetime.check(token, claims, time.Now()); err != nil {
+		v.logger.Debugf("Token lifetime rejected: %v", err)
+		v.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access", logging.WithLabel("reason", reason))
+		return nil, err
 	}
 
-	if v.requiredScope != "" {
-		if claims.Scope != "" {
-			scopes := strings.Fields(claims.Scope)
-			if slices.Contains(scopes, v.requiredScope) {
-				return claims.Subject, nil
-			}
-		}
-		if slices.Contains(claims.Scopes, v.requiredScope) {
-			return claims.Subject, nil
-		}
-	}
+	return v.policy.authorize(claims, token.Audience, AuthMethodJWT)
+}
 
-	if len(v.allowedSubjects) == 0 && v.requiredScope == "" {
-		v.logger.Debugf("No authorization criteria configured")
-		v.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access")
-		return "", fmt.Errorf("unauthorized: no access policy configured")
-	}
+// SetCache caches the principals of the verified tokens and the failures to
+// fetch the keys of the issuer.
+func (v *JWTVerifier) SetCache(cache *TokenCache) {
+	v.cache = cache
+}
 
-	v.logger.Security().AuthzFailure(claims.Subject, "jwt_api_access")
-	return "", fmt.Errorf("unauthorized: missing required scope or subject not allowed")
+// SetLifetime sets how the time claims of the tokens are checked, without
+// leeway nor maximum age by default.
+func (v *JWTVerifier) SetLifetime(lifetime TokenLifetime) {
+	v.lifetime = lifetime
 }
 
+func (v *JWTVerifier) countCacheLookup(operation string) {
+	if err := v.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		v.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewJWTVerifier returns a verifier for the tokens of the issuer of config,
+// discovered with provider, see IssuerConfig for the tokens it accepts.
 func NewJWTVerifier(
 	provider ProviderInterface,
-	issuer string,
-	allowedSubjects []string,
-	requiredScope string,
+	config IssuerConfig,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
 ) *JWTVerifier {
-	v := &JWTVerifier{
-		allowedSubjects: allowedSubjects,
-		requiredScope:   requiredScope,
-		tracer:          tracer,
-		monitor:         monitor,
-		logger:          logger,
-	}
-
-	config := &oidc.Config{
+	oidcConfig := &oidc.Config{
 		SkipClientIDCheck: true,
 		SkipIssuerCheck:   false,
+		// checked with the leeway of the TokenLifetime
+		SkipExpiryCheck: true,
 	}
 
-	v.verifier = provider.Verifier(config)
-
-	return v
+	return NewJWTVerifierDirect(provider.Verifier(oidcConfig), config, tracer, monitor, logger)
 }
 
+// NewJWTVerifierDirect returns a verifier for the tokens verified by verifier,
+// see IssuerConfig for the tokens it accepts. verifier should skip the expiry
+// check, left to the TokenLifetime.
 func NewJWTVerifierDirect(
 	verifier *oidc.IDTokenVerifier,
-	allowedSubjects []string,
-	requiredScope string,
+	config IssuerConfig,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
 ) *JWTVerifier {
 	return &JWTVerifier{
-		verifier:        verifier,
-		allowedSubjects: allowedSubjects,
-		requiredScope:   requiredScope,
-		tracer:          tracer,
-		monitor:         monitor,
-		logger:          logger,
+		verifier: verifier,
+		policy:   newAccessPolicy(config, logger),
+		issuer:   config.Issuer,
+		tracer:   tracer,
+		monitor:  monitor,
+		logger:   logger,
 	}
 }
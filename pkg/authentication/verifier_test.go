@@ -0,0 +1,346 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package authentication
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-jose/go-jose/v4"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+// newTestIssuer returns the key of an issuer and a function signing the given
+// claims as that issuer, with an expiry and the tenant-service audience unless
+// set.
+func newTestIssuer(t *testing.T, issuer string) (*rsa.PrivateKey, func(map[string]any) string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	return key, func(claims map[string]any) string {
+		if _, ok := claims["iss"]; !ok {
+			claims["iss"] = issuer
+		}
+		if _, ok := claims["aud"]; !ok {
+			claims["aud"] = "tenant-service"
+		}
+		if _, ok := claims["exp"]; !ok {
+			claims["exp"] = time.Now().Add(time.Hour).Unix()
+		}
+		payload, _ := json.Marshal(claims)
+		jws, err := signer.Sign(payload)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		token, _ := jws.CompactSerialize()
+		return token
+	}
+}
+
+func newTestVerifier(ctrl *gomock.Controller, issuer string, key *rsa.PrivateKey, config IssuerConfig) *JWTVerifier {
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "authentication.JWTVerifier.VerifyToken").Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurity.EXPECT().AuthzFailure(gomock.Any(), "jwt_api_access").AnyTimes()
+
+	keySet := &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{key.Public()}}
+	return NewJWTVerifierDirect(
+		oidc.NewVerifier(issuer, keySet, &oidc.Config{SkipClientIDCheck: true, SkipExpiryCheck: true}),
+		config,
+		mockTracer,
+		NewMockMonitorInterface(ctrl),
+		mockLogger,
+	)
+}
+
+func TestJWTVerifier_VerifyToken(t *testing.T) {
+	issuer := "https://issuer.example.com"
+	key, sign := newTestIssuer(t, issuer)
+
+	config := IssuerConfig{
+		Issuer:                issuer,
+		Audiences:             []string{"tenant-service", "tenant-cli"},
+		AudienceBypassClients: []string{"legacy-cli"},
+		AllowedSubjects:       []string{"user-1"},
+		RequiredScope:         "tenant-service",
+		ServiceClients:        map[string]PlatformRole{"ops": PlatformRoleAdmin},
+	}
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		expected *Principal
+	}{
+		{
+			name:     "allowed subject",
+			claims:   map[string]any{"sub": "user-1", "email": "jane@example.com"},
+			expected: &Principal{ID: "user-1", Type: PrincipalUser},
+		},
+		{
+			name:     "required scope",
+			claims:   map[string]any{"sub": "user-2", "scope": "openid tenant-service"},
+			expected: &Principal{ID: "user-2", Type: PrincipalUser},
+		},
+		{
+			name:     "mapped service client",
+			claims:   map[string]any{"sub": "ops", "client_id": "ops"},
+			expected: &Principal{ID: "ops", Type: PrincipalService, PlatformRole: PlatformRoleAdmin},
+		},
+		{
+			name:     "mapped service client without subject",
+			claims:   map[string]any{"client_id": "ops"},
+			expected: &Principal{ID: "ops", Type: PrincipalService, PlatformRole: PlatformRoleAdmin},
+		},
+		{
+			name:     "unmapped service client with scope",
+			claims:   map[string]any{"client_id": "billing", "scope": "tenant-service"},
+			expected: &Principal{ID: "billing", Type: PrincipalService},
+		},
+		{
+			name:   "unmapped service client",
+			claims: map[string]any{"client_id": "billing"},
+		},
+		{
+			name:   "user token issued to a mapped client",
+			claims: map[string]any{"sub": "user-3", "client_id": "ops"},
+		},
+		{
+			name:     "several audiences with an authorized party",
+			claims:   map[string]any{"sub": "user-1", "aud": []string{"account", "tenant-cli"}, "azp": "tenant-cli"},
+			expected: &Principal{ID: "user-1", Type: PrincipalUser},
+		},
+		{
+			name:   "several audiences without authorized party",
+			claims: map[string]any{"sub": "user-1", "aud": []string{"account", "tenant-cli"}},
+		},
+		{
+			name:   "audience not accepted",
+			claims: map[string]any{"sub": "user-1", "aud": "account"},
+		},
+		{
+			name:     "legacy client bypassing the audience",
+			claims:   map[string]any{"sub": "user-1", "aud": "account", "azp": "legacy-cli"},
+			expected: &Principal{ID: "user-1", Type: PrincipalUser},
+		},
+		{
+			name:     "legacy service client bypassing the audience",
+			claims:   map[string]any{"client_id": "legacy-cli", "aud": []string{}, "scope": "tenant-service"},
+			expected: &Principal{ID: "legacy-cli", Type: PrincipalService},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			v := newTestVerifier(ctrl, issuer, key, config)
+
+			principal, err := v.VerifyToken(context.Background(), sign(tt.claims))
+
+			if tt.expected == nil {
+				if err == nil {
+					t.Fatalf("expected the token to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.ID != tt.expected.ID || principal.Type != tt.expected.Type || principal.PlatformRole != tt.expected.PlatformRole || principal.Method != AuthMethodJWT {
+				t.Errorf("expected %+v, got %+v", tt.expected, principal)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_Lifetime(t *testing.T) {
+	issuer := "https://issuer.example.com"
+	key, sign := newTestIssuer(t, issuer)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		lifetime TokenLifetime
+		reason   string
+	}{
+		{name: "valid", claims: map[string]any{"iat": now.Unix()}},
+		{name: "expired", claims: map[string]any{"exp": now.Add(-time.Minute).Unix()}, reason: "token_expired"},
+		{name: "expired within leeway", claims: map[string]any{"exp": now.Add(-time.Minute).Unix()}, lifetime: TokenLifetime{Leeway: 2 * time.Minute}},
+		{name: "not yet valid", claims: map[string]any{"nbf": now.Add(time.Minute).Unix()}, reason: "token_not_yet_valid"},
+		{name: "not yet valid within leeway", claims: map[string]any{"nbf": now.Add(time.Minute).Unix()}, lifetime: TokenLifetime{Leeway: 2 * time.Minute}},
+		{name: "issued in the future", claims: map[string]any{"iat": now.Add(time.Minute).Unix()}, reason: "token_issued_in_future"},
+		{name: "too old", claims: map[string]any{"iat": now.Add(-2 * time.Hour).Unix()}, lifetime: TokenLifetime{MaxAge: time.Hour}, reason: "token_too_old"},
+		{name: "without issue time", claims: map[string]any{}, lifetime: TokenLifetime{MaxAge: time.Hour}, reason: "token_without_iat"},
+		{name: "within max age", claims: map[string]any{"iat": now.Add(-time.Minute).Unix()}, lifetime: TokenLifetime{MaxAge: time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			v := newTestVerifier(ctrl, issuer, key, IssuerConfig{Issuer: issuer, AllowedSubjects: []string{"user-1"}})
+			v.SetLifetime(tt.lifetime)
+			if tt.reason != "" {
+				mockSecurity := v.logger.Security().(*MockSecurityLoggerInterface)
+				mockSecurity.EXPECT().AuthzFailure("user-1", "jwt_api_access", logging.WithLabel("reason", tt.reason))
+			}
+
+			tt.claims["sub"] = "user-1"
+			principal, err := v.VerifyToken(context.Background(), sign(tt.claims))
+
+			if tt.reason != "" {
+				if err == nil {
+					t.Fatalf("expected the token to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMultiIssuerVerifier_VerifyToken(t *testing.T) {
+	hydra := "https://hydra.example.com"
+	idp := "https://idp.example.com"
+	hydraKey, signHydra := newTestIssuer(t, hydra)
+	idpKey, signIdP := newTestIssuer(t, idp)
+
+	tests := []struct {
+		name       string
+		token      func() string
+		expectedID string
+	}{
+		{
+			name:       "machine token from hydra",
+			token:      func() string { return signHydra(map[string]any{"sub": "ops", "client_id": "ops"}) },
+			expectedID: "ops",
+		},
+		{
+			name:       "human token from the idp",
+			token:      func() string { return signIdP(map[string]any{"sub": "user-1"}) },
+			expectedID: "user-1",
+		},
+		{
+			name:  "subject allowed by another issuer",
+			token: func() string { return signHydra(map[string]any{"sub": "user-1"}) },
+		},
+		{
+			name:  "untrusted issuer",
+			token: func() string { return signIdP(map[string]any{"sub": "user-1", "iss": "https://evil.example.com"}) },
+		},
+		{
+			name:  "issuer claimed with another issuer's key",
+			token: func() string { return signIdP(map[string]any{"sub": "ops", "client_id": "ops", "iss": hydra}) },
+		},
+		{
+			name:  "malformed token",
+			token: func() string { return "not-a-jwt" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			m := NewMultiIssuerVerifier(map[string]TokenVerifierInterface{
+				hydra: newTestVerifier(ctrl, hydra, hydraKey, IssuerConfig{Issuer: hydra, ServiceClients: map[string]PlatformRole{"ops": PlatformRoleViewer}}),
+				idp:   newTestVerifier(ctrl, idp, idpKey, IssuerConfig{Issuer: idp, AllowedSubjects: []string{"user-1"}}),
+			}, mockLogger)
+
+			principal, err := m.VerifyToken(context.Background(), tt.token())
+
+			if tt.expectedID == "" {
+				if err == nil {
+					t.Fatalf("expected the token to be rejected, got %+v", principal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.ID != tt.expectedID {
+				t.Errorf("expected principal %s, got %+v", tt.expectedID, principal)
+			}
+		})
+	}
+}
+
+func TestNewJWTAuthenticator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+
+	for _, issuers := range [][]IssuerConfig{
+		nil,
+		{{JWKSURL: "https://issuer.example.com/jwks"}},
+		{{Issuer: "https://issuer.example.com", JWKSURL: "https://issuer.example.com/jwks", ServiceClients: map[string]PlatformRole{"ops": "root"}}},
+		{{Issuer: "https://issuer.example.com", JWKSURL: "https://issuer.example.com/jwks"}, {Issuer: "https://issuer.example.com", JWKSURL: "https://issuer.example.com/jwks"}},
+	} {
+		if _, err := NewJWTAuthenticator(context.Background(), issuers, TokenLifetime{}, nil, nil, NewMockTracingInterface(ctrl), NewMockMonitorInterface(ctrl), mockLogger); err == nil {
+			t.Errorf("expected an error for issuers %+v", issuers)
+		}
+	}
+
+	v, err := NewJWTAuthenticator(context.Background(), []IssuerConfig{
+		{Issuer: "https://hydra.example.com", JWKSURL: "https://hydra.example.com/jwks"},
+		{Issuer: "https://idp.example.com", JWKSURL: "https://idp.example.com/jwks"},
+	}, TokenLifetime{}, nil, nil, NewMockTracingInterface(ctrl), NewMockMonitorInterface(ctrl), mockLogger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := v.(*MultiIssuerVerifier); !ok {
+		t.Errorf("expected a multi issuer verifier, got %T", v)
+	}
+}
+
+func TestDecodeToken(t *testing.T) {
+	_, sign := newTestIssuer(t, "https://issuer.example.com")
+
+	header, claims, err := DecodeToken(sign(map[string]any{"sub": "user-1", "tenants": []string{"tenant-1"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header["alg"] != "RS256" {
+		t.Errorf("expected the RS256 alg header, got %v", header["alg"])
+	}
+	if claims["sub"] != "user-1" || claims["iss"] != "https://issuer.example.com" {
+		t.Errorf("unexpected claims %v", claims)
+	}
+
+	for _, token := range []string{"", "opaque-token", "a.b.c", "e30.bm90LWpzb24.c2ln"} {
+		if _, _, err := DecodeToken(token); err == nil {
+			t.Errorf("expected an error for token %q", token)
+		}
+	}
+}
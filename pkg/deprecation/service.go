@@ -0,0 +1,108 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package deprecation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	// HeaderName is the HTTP header carrying deprecation warnings (RFC 7234).
+	HeaderName = "Warning"
+	// MetadataKey is the gRPC metadata key carrying deprecation warnings.
+	MetadataKey = "warning"
+
+	// warnCode is the "miscellaneous persistent warning" code of RFC 7234.
+	warnCode = 299
+
+	anonymousClient = "anonymous"
+)
+
+// Feature is a legacy part of the v0 API that is going away in v1.
+type Feature string
+
+const (
+	// StringRole is a membership role passed as a free-form string.
+	StringRole Feature = "string_role"
+	// UnpaginatedList is a listing returning every item at once.
+	UnpaginatedList Feature = "unpaginated_list"
+)
+
+var messages = map[Feature]string{
+	StringRole:      "free-form role strings are deprecated and will be replaced by an enum in v1",
+	UnpaginatedList: "unpaginated listings are deprecated, v1 listings are paginated",
+}
+
+type Service struct {
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
+
+// Warn attaches a deprecation warning for feature to the response headers of
+// the current call and records which client relied on it.
+func (s *Service) Warn(ctx context.Context, feature Feature) {
+	message, ok := messages[feature]
+	if !ok {
+		message = fmt.Sprintf("%s is deprecated", feature)
+	}
+
+	client := clientIdentity(ctx)
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, fmt.Sprintf("%d - %q", warnCode, message))); err != nil {
+		s.logger.Debugw("failed to attach deprecation warning", "feature", feature, "error", err)
+	}
+
+	if err := s.monitor.IncrementDeprecatedUsage(map[string]string{"feature": string(feature), "client": client}); err != nil {
+		s.logger.Warnf("failed to increment deprecated usage counter %s: %v", feature, err)
+	}
+
+	s.logger.Debugw("deprecated feature used", "feature", feature, "client", client)
+}
+
+// clientIdentity names the caller in metrics. Service principals are reported
+// by client ID, users are grouped together to keep the label cardinality low.
+func clientIdentity(ctx context.Context) string {
+	p, ok := authentication.GetPrincipal(ctx)
+	if !ok {
+		return anonymousClient
+	}
+
+	if p.Type == authentication.PrincipalService {
+		return p.ID
+	}
+
+	return string(p.Type)
+}
+
+// OutgoingHeaderMatcher exposes deprecation warnings as the HTTP Warning header
+// and defers to the default grpc-gateway behaviour for every other metadata key.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == MetadataKey {
+		return HeaderName, true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
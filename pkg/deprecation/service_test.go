@@ -0,0 +1,85 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package deprecation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package deprecation -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package deprecation -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package deprecation -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestService_Warn(t *testing.T) {
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		feature        Feature
+		expectedClient string
+	}{
+		{
+			name:           "Anonymous caller",
+			ctx:            context.Background(),
+			feature:        UnpaginatedList,
+			expectedClient: "anonymous",
+		},
+		{
+			name:           "User caller",
+			ctx:            authentication.WithUserID(context.Background(), "user-1"),
+			feature:        StringRole,
+			expectedClient: "user",
+		},
+		{
+			name:           "Service caller",
+			ctx:            authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService}),
+			feature:        StringRole,
+			expectedClient: "billing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+
+			mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockMonitor.EXPECT().IncrementDeprecatedUsage(map[string]string{"feature": string(tt.feature), "client": tt.expectedClient}).Return(nil)
+
+			var stream runtime.ServerTransportStream
+			ctx := grpc.NewContextWithServerTransportStream(tt.ctx, &stream)
+
+			NewService(mockTracer, mockMonitor, mockLogger).Warn(ctx, tt.feature)
+
+			warnings := stream.Header().Get(MetadataKey)
+			if len(warnings) != 1 {
+				t.Fatalf("expected 1 warning, got %v", warnings)
+			}
+			if !strings.HasPrefix(warnings[0], "299 - \"") || !strings.Contains(warnings[0], messages[tt.feature]) {
+				t.Fatalf("unexpected warning %q", warnings[0])
+			}
+		})
+	}
+}
+
+func TestOutgoingHeaderMatcher(t *testing.T) {
+	if h, ok := OutgoingHeaderMatcher(MetadataKey); !ok || h != HeaderName {
+		t.Fatalf("expected %s to map to %s, got %q", MetadataKey, HeaderName, h)
+	}
+
+	if h, ok := OutgoingHeaderMatcher("x-other"); !ok || h != runtime.MetadataHeaderPrefix+"x-other" {
+		t.Fatalf("expected default mapping, got %q", h)
+	}
+}
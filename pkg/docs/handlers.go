@@ -0,0 +1,110 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package docs serves the OpenAPI document of the gateway API and a Swagger
+// UI browsing it.
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"sigs.k8s.io/yaml"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/version"
+)
+
+const specPath = "/api/v0/openapi.json"
+
+// uiTemplate loads Swagger UI from assetsURL, the browsers of the users need
+// to reach it.
+var uiTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Tenant Service API</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.AssetsURL}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: {{.SpecPath}}, dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
+
+type API struct {
+	spec []byte
+	ui   []byte
+
+	logger logging.LoggerInterface
+}
+
+func (a *API) RegisterEndpoints(mux *chi.Mux) {
+	mux.Get(specPath, a.openapi)
+	if a.ui != nil {
+		mux.Get("/api/v0/docs", a.docs)
+	}
+}
+
+func (a *API) openapi(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(a.spec)
+}
+
+func (a *API) docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(a.ui)
+}
+
+// specJSON returns the OpenAPI document spec, in YAML, as JSON stamped with
+// the version of the service.
+func specJSON(spec []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	info, _ := doc["info"].(map[string]any)
+	if info == nil {
+		info = make(map[string]any)
+		doc["info"] = info
+	}
+	info["title"] = "Tenant Service API"
+	info["version"] = version.Get().Version
+
+	return json.Marshal(doc)
+}
+
+// NewAPI returns the API serving spec, the OpenAPI document in YAML, and the
+// Swagger UI loading its assets from uiAssetsURL when uiEnabled.
+func NewAPI(spec []byte, uiEnabled bool, uiAssetsURL string, logger logging.LoggerInterface) (*API, error) {
+	a := new(API)
+
+	s, err := specJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+	a.spec = s
+
+	if uiEnabled {
+		var ui bytes.Buffer
+		if err := uiTemplate.Execute(&ui, map[string]string{"AssetsURL": uiAssetsURL, "SpecPath": specPath}); err != nil {
+			return nil, fmt.Errorf("failed to render the API docs: %w", err)
+		}
+		a.ui = ui.Bytes()
+	}
+
+	a.logger = logger
+
+	return a, nil
+}
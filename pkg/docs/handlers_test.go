@@ -0,0 +1,70 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/tenant-service/internal/version"
+	"github.com/canonical/tenant-service/openapi"
+)
+
+func TestAPI(t *testing.T) {
+	tests := []struct {
+		name      string
+		uiEnabled bool
+		path      string
+	}{
+		{name: "Spec", path: specPath},
+		{name: "Docs enabled", uiEnabled: true, path: "/api/v0/docs"},
+		{name: "Docs disabled", path: "/api/v0/docs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAPI(openapi.Spec, tt.uiEnabled, "https://assets.example.com/swagger-ui", nil)
+			if err != nil {
+				t.Fatalf("expected error to be nil got %v", err)
+			}
+
+			mux := chi.NewMux()
+			a.RegisterEndpoints(mux)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			switch {
+			case tt.path == specPath:
+				var doc struct {
+					OpenAPI string                    `json:"openapi"`
+					Info    struct{ Version string }  `json:"info"`
+					Paths   map[string]map[string]any `json:"paths"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+					t.Fatalf("expected error to be nil got %v", err)
+				}
+				if doc.Info.Version != version.Get().Version {
+					t.Errorf("expected version %s, got %s", version.Get().Version, doc.Info.Version)
+				}
+				if _, ok := doc.Paths["/api/v0/tenants"]; !ok {
+					t.Errorf("expected the tenant routes in the document")
+				}
+			case tt.uiEnabled:
+				if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "https://assets.example.com/swagger-ui/swagger-ui-bundle.js") {
+					t.Errorf("expected the docs page, got %d %s", w.Code, w.Body.String())
+				}
+			default:
+				if w.Code != http.StatusNotFound {
+					t.Errorf("expected no docs page, got %d", w.Code)
+				}
+			}
+		})
+	}
+}
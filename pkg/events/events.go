@@ -0,0 +1,203 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// The types of the tenant lifecycle events.
+const (
+	TenantCreated     = "tenant.created"
+	TenantDeleted     = "tenant.deleted"
+	MemberAdded       = "member.added"
+	MemberRoleChanged = "member.role_changed"
+	// TenantQuotaWarning is emitted once a quota of a tenant reaches its
+	// warning threshold.
+	TenantQuotaWarning = "tenant.quota_warning"
+)
+
+// DeliveryJob is the kind of the jobs delivering an event to a sink.
+const DeliveryJob = "event_delivery"
+
+// Event is a CloudEvents 1.0 event in the structured JSON format.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// TenantData is the data of the tenant.* events.
+type TenantData struct {
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// MemberData is the data of the member.* events.
+type MemberData struct {
+	TenantID     string `json:"tenant_id"`
+	UserID       string `json:"user_id"`
+	Role         string `json:"role"`
+	PreviousRole string `json:"previous_role,omitempty"`
+}
+
+// QuotaData is the data of the tenant.quota_warning events.
+type QuotaData struct {
+	TenantID string `json:"tenant_id"`
+	Quota    string `json:"quota"`
+	Used     int    `json:"used"`
+	Limit    int    `json:"limit"`
+}
+
+type deliveryPayload struct {
+	Sink  string `json:"sink"`
+	Event *Event `json:"event"`
+}
+
+// Publisher emits the events through a transactional outbox: an event is
+// written to the job queue in the transaction of the mutation it describes,
+// and delivered by the queue workers once it commits. Each sink gets its own
+// job so that a failing sink is retried alone, with the backoff of the queue.
+// An event may be delivered more than once, consumers dedupe on its ID.
+type Publisher struct {
+	source string
+	sinks  map[string]SinkInterface
+	names  []string
+	now    func() time.Time
+
+	queue         QueueInterface
+	subscriptions SubscriptionsInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Publish writes an event of the given type about subject to the outbox of
+// every sink and of the webhook subscriptions, data is encoded to JSON. The
+// subject of the tenant events is the tenant ID. The event is only delivered
+// if the transaction of ctx commits.
+func (p *Publisher) Publish(ctx context.Context, eventType, subject string, data any) error {
+	ctx, span := p.tracer.Start(ctx, "events.Publisher.Publish")
+	defer span.End()
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	e := &Event{
+		SpecVersion:     "1.0",
+		ID:              uuid.NewString(),
+		Source:          p.source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            p.now().UTC(),
+		DataContentType: "application/json",
+		Data:            raw,
+	}
+	span.SetAttributes(attribute.String("event.id", e.ID), attribute.String("event.type", eventType))
+
+	for _, name := range p.names {
+		if err := p.queue.Enqueue(ctx, DeliveryJob, deliveryPayload{Sink: name, Event: e}); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("failed to enqueue %s event for %s: %w", eventType, name, err)
+		}
+	}
+
+	if p.subscriptions != nil {
+		if err := p.subscriptions.Fanout(ctx, e); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("failed to enqueue %s event for the webhook subscriptions: %w", eventType, err)
+		}
+	}
+
+	p.logger.Debugw("event published", "event_id", e.ID, "type", eventType, "subject", subject, "sinks", len(p.names))
+	p.countEvent("event_published")
+	return nil
+}
+
+// deliver is the handler of the delivery jobs.
+func (p *Publisher) deliver(ctx context.Context, payload []byte) error {
+	var d deliveryPayload
+	if err := json.Unmarshal(payload, &d); err != nil || d.Event == nil {
+		// retrying cannot fix the payload
+		p.logger.Errorw("dropping malformed event delivery", "error", err)
+		return nil
+	}
+
+	sink, ok := p.sinks[d.Sink]
+	if !ok {
+		p.logger.Warnw("dropping event for a sink no longer configured", "event_id", d.Event.ID, "sink", d.Sink)
+		return nil
+	}
+
+	if err := sink.Deliver(ctx, d.Event); err != nil {
+		p.countEvent("event_delivery_failed")
+		return fmt.Errorf("failed to deliver %s event to %s: %w", d.Event.Type, d.Sink, err)
+	}
+
+	p.logger.Debugw("event delivered", "event_id", d.Event.ID, "type", d.Event.Type, "sink", d.Sink)
+	p.countEvent("event_delivered")
+	return nil
+}
+
+// SetSubscriptions also delivers the events to the webhook subscriptions of
+// their tenant.
+func (p *Publisher) SetSubscriptions(subscriptions SubscriptionsInterface) {
+	p.subscriptions = subscriptions
+}
+
+func (p *Publisher) countEvent(operation string) {
+	if err := p.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		p.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewPublisher returns a publisher of the events from source to sinks, it
+// registers their delivery with queue.
+func NewPublisher(
+	source string,
+	sinks []SinkInterface,
+	queue QueueInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Publisher {
+	p := new(Publisher)
+	p.source = source
+	p.sinks = make(map[string]SinkInterface, len(sinks))
+	for _, s := range sinks {
+		p.sinks[s.Name()] = s
+		p.names = append(p.names, s.Name())
+	}
+	p.now = time.Now
+	p.queue = queue
+	p.tracer = tracer
+	p.monitor = monitor
+	p.logger = logger
+
+	queue.Handle(DeliveryJob, p.deliver)
+	return p
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package events -destination ./mock_events.go -source=./interfaces.go
+
+func newTestPublisher(ctrl *gomock.Controller, sinks ...SinkInterface) (*Publisher, *MockQueueInterface, *jobs.Handler) {
+	mockQueue := NewMockQueueInterface(ctrl)
+	handler := new(jobs.Handler)
+	mockQueue.EXPECT().Handle(DeliveryJob, gomock.Any()).Do(func(_ string, h jobs.Handler) { *handler = h })
+
+	logger := logging.NewNoopLogger()
+	p := NewPublisher("/tenant-service", sinks, mockQueue, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("test", logger), logger)
+	return p, mockQueue, handler
+}
+
+func newTestSink(ctrl *gomock.Controller, name string) *MockSinkInterface {
+	sink := NewMockSinkInterface(ctrl)
+	sink.EXPECT().Name().Return(name).AnyTimes()
+	return sink
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, mockQueue, _ := newTestPublisher(ctrl, newTestSink(ctrl, "a"), newTestSink(ctrl, "b"))
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	var deliveries []deliveryPayload
+	mockQueue.EXPECT().Enqueue(gomock.Any(), DeliveryJob, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, payload any) error {
+			deliveries = append(deliveries, payload.(deliveryPayload))
+			return nil
+		}).Times(2)
+
+	if err := p.Publish(context.Background(), TenantCreated, "tenant-1", TenantData{TenantID: "tenant-1", Name: "Acme", Enabled: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deliveries[0].Sink != "a" || deliveries[1].Sink != "b" {
+		t.Fatalf("expected a delivery per sink, got %+v", deliveries)
+	}
+	e := deliveries[0].Event
+	if e != deliveries[1].Event {
+		t.Error("expected the sinks to get the same event")
+	}
+	if e.SpecVersion != "1.0" || e.Type != TenantCreated || e.Source != "/tenant-service" || e.Subject != "tenant-1" || !e.Time.Equal(now) || e.ID == "" {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if string(e.Data) != `{"tenant_id":"tenant-1","name":"Acme","enabled":true}` {
+		t.Errorf("unexpected event data %s", e.Data)
+	}
+}
+
+func TestPublisher_PublishEnqueueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, mockQueue, _ := newTestPublisher(ctrl, newTestSink(ctrl, "a"))
+	mockQueue.EXPECT().Enqueue(gomock.Any(), DeliveryJob, gomock.Any()).Return(errors.New("db error"))
+
+	if err := p.Publish(context.Background(), TenantDeleted, "tenant-1", TenantData{TenantID: "tenant-1"}); err == nil {
+		t.Fatal("expected the outbox error to fail the publication")
+	}
+}
+
+func TestPublisher_PublishSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, _, _ := newTestPublisher(ctrl)
+	mockSubscriptions := NewMockSubscriptionsInterface(ctrl)
+	p.SetSubscriptions(mockSubscriptions)
+
+	mockSubscriptions.EXPECT().Fanout(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *Event) error {
+			if e.Type != MemberAdded || e.Subject != "tenant-1" {
+				t.Errorf("unexpected event %+v", e)
+			}
+			return nil
+		})
+
+	if err := p.Publish(context.Background(), MemberAdded, "tenant-1", MemberData{TenantID: "tenant-1", UserID: "user-1", Role: "member"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mockSubscriptions.EXPECT().Fanout(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+	if err := p.Publish(context.Background(), MemberAdded, "tenant-1", MemberData{TenantID: "tenant-1"}); err == nil {
+		t.Fatal("expected the fanout error to fail the publication")
+	}
+}
+
+func TestPublisher_Deliver(t *testing.T) {
+	event := &Event{ID: "event-1", Type: MemberAdded, Data: json.RawMessage(`{}`)}
+
+	tests := []struct {
+		name        string
+		sink        string
+		deliverErr  error
+		expectCall  bool
+		expectedErr bool
+	}{
+		{name: "delivered", sink: "a", expectCall: true},
+		{name: "sink failure is retried", sink: "a", deliverErr: errors.New("unavailable"), expectCall: true, expectedErr: true},
+		{name: "removed sink is dropped", sink: "gone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sink := newTestSink(ctrl, "a")
+			_, _, handler := newTestPublisher(ctrl, sink)
+			if tt.expectCall {
+				sink.EXPECT().Deliver(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, e *Event) error {
+						if e.ID != event.ID {
+							t.Errorf("expected event %s, got %s", event.ID, e.ID)
+						}
+						return tt.deliverErr
+					})
+			}
+
+			payload, _ := json.Marshal(deliveryPayload{Sink: tt.sink, Event: event})
+			err := (*handler)(context.Background(), payload)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("expected error: %v, got: %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestHTTPSink_Deliver(t *testing.T) {
+	event := &Event{SpecVersion: "1.0", ID: "event-1", Type: TenantCreated, Data: json.RawMessage(`{"tenant_id":"tenant-1"}`)}
+
+	tests := []struct {
+		name        string
+		secret      string
+		status      int
+		expectedErr bool
+	}{
+		{name: "signed", secret: "secret", status: http.StatusAccepted},
+		{name: "unsigned", status: http.StatusOK},
+		{name: "rejected", secret: "secret", status: http.StatusInternalServerError, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if ct := r.Header.Get("Content-Type"); ct != ContentType {
+					t.Errorf("expected content type %s, got %s", ContentType, ct)
+				}
+
+				signature := r.Header.Get(SignatureHeader)
+				if tt.secret == "" {
+					if signature != "" {
+						t.Errorf("expected no signature, got %s", signature)
+					}
+				} else {
+					mac := hmac.New(sha256.New, []byte(tt.secret))
+					mac.Write(body)
+					if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != expected {
+						t.Errorf("expected signature %s, got %s", expected, signature)
+					}
+				}
+
+				var e Event
+				if err := json.Unmarshal(body, &e); err != nil || e.ID != event.ID {
+					t.Errorf("expected the event, got %s", body)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sink := NewHTTPSink(server.URL, tt.secret, server.Client())
+			err := sink.Deliver(context.Background(), event)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("expected error: %v, got: %v", tt.expectedErr, err)
+			}
+			var statusErr *StatusError
+			if tt.expectedErr && (!errors.As(err, &statusErr) || statusErr.StatusCode != tt.status) {
+				t.Errorf("expected the status %d in the error, got %v", tt.status, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/jobs"
+)
+
+// QueueInterface defines the job queue operations required by the events
+// package, the jobs table is the outbox of the events.
+type QueueInterface interface {
+	Handle(kind string, h jobs.Handler)
+	Enqueue(ctx context.Context, kind string, payload any) error
+}
+
+// SinkInterface delivers the events to a consumer.
+type SinkInterface interface {
+	// Name identifies the sink in the outbox, it must stay the same across
+	// restarts for the pending deliveries to reach it.
+	Name() string
+	Deliver(ctx context.Context, e *Event) error
+}
+
+// SubscriptionsInterface delivers the events to the webhook subscriptions of
+// the tenants, in the transaction of the mutation like the sinks.
+type SubscriptionsInterface interface {
+	Fanout(ctx context.Context, e *Event) error
+}
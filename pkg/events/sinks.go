@@ -0,0 +1,96 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// SignatureHeader holds sha256=<hex HMAC-SHA256 of the body> keyed with
+	// the secret of the sink, as the webhooks of the service expect it.
+	SignatureHeader = "X-Webhook-Signature"
+	// ContentType is the media type of the structured CloudEvents.
+	ContentType = "application/cloudevents+json"
+)
+
+// StatusError is the error of a delivery the target answered with a status
+// other than 2xx.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sink responded with status %d", e.StatusCode)
+}
+
+// HTTPSink posts the events to a webhook target in the structured mode, any
+// status but 2xx fails the delivery.
+type HTTPSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// Name returns the URL of the target.
+func (s *HTTPSink) Name() string {
+	return s.url
+}
+
+func (s *HTTPSink) Deliver(ctx context.Context, e *Event) error {
+	_, err := s.Send(ctx, e)
+	return err
+}
+
+// Send posts e and returns the status of the response, zero when the target
+// could not be reached.
+func (s *HTTPSink) Send(ctx context.Context, e *Event) (int, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if len(s.secret) > 0 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drained so that the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, &StatusError{StatusCode: resp.StatusCode}
+	}
+	return resp.StatusCode, nil
+}
+
+// NewHTTPSink returns a sink posting to url with client, the bodies are
+// signed with secret unless it is empty.
+func NewHTTPSink(url, secret string, client *http.Client) *HTTPSink {
+	s := new(HTTPSink)
+	s.url = url
+	s.secret = []byte(secret)
+	s.client = client
+
+	return s
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package idempotency
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// StorageInterface defines the storage operations required by the idempotency package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	GetIdempotencyKey(ctx context.Context, principal, operation, key string) (*types.IdempotencyKey, error)
+	CreateIdempotencyKey(ctx context.Context, k *types.IdempotencyKey) error
+	CompleteIdempotencyKey(ctx context.Context, principal, operation, key string, response []byte) error
+	ListCompletedIdempotencyKeys(ctx context.Context) ([]*types.IdempotencyKey, error)
+	ReplaceIdempotencyResponse(ctx context.Context, principal, operation, key string, previous, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, principal, operation, key string) error
+}
+
+// ServiceInterface defines the idempotency service operations.
+type ServiceInterface interface {
+	Execute(ctx context.Context, operation, key string, req proto.Message, fn func(context.Context) (proto.Message, error)) (proto.Message, error)
+	// Reencrypt seals the stored responses that are not encrypted with the active key yet.
+	Reencrypt(ctx context.Context) (int, error)
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	// HeaderName is the HTTP header carrying the idempotency key.
+	HeaderName = "Idempotency-Key"
+	// MetadataKey is the gRPC metadata key carrying the idempotency key.
+	MetadataKey = "idempotency-key"
+	// KeyField is the request field carrying the idempotency key, it takes precedence over metadata.
+	KeyField = "idempotency_key"
+
+	maxKeyLength = 255
+)
+
+type Service struct {
+	storage StorageInterface
+	cipher  encryption.CipherInterface
+	ttl     time.Duration
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	storage StorageInterface,
+	cipher encryption.CipherInterface,
+	ttl time.Duration,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		storage: storage,
+		cipher:  cipher,
+		ttl:     ttl,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// Execute runs fn at most once per (principal, operation, key).
+// When key is empty it falls back to the idempotency-key metadata, and when
+// neither is set fn is simply called. A retry with the same key and request
+// replays the stored response, a retry with a different request is rejected.
+func (s *Service) Execute(ctx context.Context, operation, key string, req proto.Message, fn func(context.Context) (proto.Message, error)) (proto.Message, error) {
+	if key == "" {
+		key = keyFromMetadata(ctx)
+	}
+	if key == "" {
+		return fn(ctx)
+	}
+
+	ctx, span := s.tracer.Start(ctx, "idempotency.Service.Execute")
+	defer span.End()
+
+	if len(key) > maxKeyLength {
+		return nil, status.Errorf(grpcCodes.InvalidArgument, "idempotency key must be at most %d characters", maxKeyLength)
+	}
+
+	principal, _ := authentication.GetUserID(ctx)
+	fingerprint, err := Fingerprint(req)
+	if err != nil {
+		s.recordError(span, "failed to fingerprint request", err, "operation", operation)
+		return nil, status.Errorf(grpcCodes.Internal, "failed to fingerprint request: %v", err)
+	}
+
+	existing, err := s.storage.GetIdempotencyKey(ctx, principal, operation, key)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+	case err != nil:
+		s.recordError(span, "failed to look up idempotency key", err, "operation", operation, "key", key)
+		return nil, status.Errorf(grpcCodes.Internal, "failed to look up idempotency key: %v", err)
+	case time.Since(existing.CreatedAt) > s.ttl:
+		// expired keys are treated as unused
+		if err := s.storage.DeleteIdempotencyKey(ctx, principal, operation, key); err != nil {
+			s.recordError(span, "failed to delete expired idempotency key", err, "operation", operation, "key", key)
+			return nil, status.Errorf(grpcCodes.Internal, "failed to delete expired idempotency key: %v", err)
+		}
+	default:
+		return s.replay(ctx, existing, fingerprint)
+	}
+
+	reservation := &types.IdempotencyKey{
+		Principal:   principal,
+		Operation:   operation,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+	if err := s.storage.CreateIdempotencyKey(ctx, reservation); err != nil {
+		if errors.Is(err, storage.ErrDuplicateKey) {
+			return nil, status.Error(grpcCodes.Aborted, "a request with this idempotency key is already in progress")
+		}
+		s.recordError(span, "failed to reserve idempotency key", err, "operation", operation, "key", key)
+		return nil, status.Errorf(grpcCodes.Internal, "failed to reserve idempotency key: %v", err)
+	}
+
+	resp, err := fn(ctx)
+	if err != nil {
+		// release the key so that the client can retry a failed request
+		if dErr := s.storage.DeleteIdempotencyKey(ctx, principal, operation, key); dErr != nil {
+			s.logger.Errorw("failed to release idempotency key", "operation", operation, "key", key, "error", dErr)
+		}
+		return nil, err
+	}
+
+	stored, err := marshalResponse(resp)
+	if err != nil {
+		s.recordError(span, "failed to encode response for idempotency key", err, "operation", operation, "key", key)
+		return resp, nil
+	}
+	// stored responses hold invitation links and codes
+	stored, err = s.cipher.Encrypt(ctx, stored, responseAAD(principal, operation, key))
+	if err != nil {
+		s.recordError(span, "failed to encrypt response for idempotency key", err, "operation", operation, "key", key)
+		return resp, nil
+	}
+	if err := s.storage.CompleteIdempotencyKey(ctx, principal, operation, key, stored); err != nil {
+		// the operation already succeeded, a retry will be reported as in progress until the key expires
+		s.recordError(span, "failed to store response for idempotency key", err, "operation", operation, "key", key)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) replay(ctx context.Context, existing *types.IdempotencyKey, fingerprint string) (proto.Message, error) {
+	if existing.Fingerprint != fingerprint {
+		return nil, status.Error(grpcCodes.InvalidArgument, "idempotency key was already used with a different request")
+	}
+	if existing.Response == nil {
+		return nil, status.Error(grpcCodes.Aborted, "a request with this idempotency key is already in progress")
+	}
+
+	stored, err := s.cipher.Decrypt(ctx, existing.Response, responseAAD(existing.Principal, existing.Operation, existing.Key))
+	if err != nil {
+		s.logger.Errorw("failed to decrypt stored response", "operation", existing.Operation, "key", existing.Key, "error", err)
+		return nil, status.Error(grpcCodes.Internal, "failed to decrypt stored response")
+	}
+
+	resp, err := unmarshalResponse(stored)
+	if err != nil {
+		return nil, status.Errorf(grpcCodes.Internal, "failed to decode stored response: %v", err)
+	}
+
+	s.logger.Infow("replaying idempotent request", "operation", existing.Operation, "key", existing.Key)
+	return resp, nil
+}
+
+// Reencrypt seals every stored response not encrypted with the active key yet,
+// run after a key rotation before the old key is dropped. Keys released or
+// replaced meanwhile are skipped, it returns the number of responses rewritten.
+func (s *Service) Reencrypt(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "idempotency.Service.Reencrypt")
+	defer span.End()
+
+	keys, err := s.storage.ListCompletedIdempotencyKeys(ctx)
+	if err != nil {
+		s.recordError(span, "failed to list idempotency keys", err)
+		return 0, err
+	}
+
+	n := 0
+	for _, k := range keys {
+		rotate, err := s.cipher.NeedsRotation(ctx, k.Response)
+		if err != nil {
+			s.recordError(span, "failed to inspect stored response", err, "operation", k.Operation, "key", k.Key)
+			return n, err
+		}
+		if !rotate {
+			continue
+		}
+
+		aad := responseAAD(k.Principal, k.Operation, k.Key)
+		plaintext, err := s.cipher.Decrypt(ctx, k.Response, aad)
+		if err != nil {
+			s.recordError(span, "failed to decrypt stored response", err, "operation", k.Operation, "key", k.Key)
+			return n, err
+		}
+		sealed, err := s.cipher.Encrypt(ctx, plaintext, aad)
+		if err != nil {
+			s.recordError(span, "failed to encrypt stored response", err, "operation", k.Operation, "key", k.Key)
+			return n, err
+		}
+
+		err = s.storage.ReplaceIdempotencyResponse(ctx, k.Principal, k.Operation, k.Key, k.Response, sealed)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			continue
+		case err != nil:
+			s.recordError(span, "failed to store re-encrypted response", err, "operation", k.Operation, "key", k.Key)
+			return n, err
+		}
+		n++
+	}
+
+	s.logger.Infow("idempotency responses re-encrypted", "count", n, "total", len(keys))
+	return n, nil
+}
+
+// responseAAD binds a stored response to its key, so that it cannot be
+// replayed for another principal or operation.
+func responseAAD(principal, operation, key string) []byte {
+	return []byte(principal + "\x00" + operation + "\x00" + key)
+}
+
+// Fingerprint hashes the request with its idempotency_key field cleared, so
+// that the same payload sent with the key in the body or in metadata matches.
+func Fingerprint(req proto.Message) (string, error) {
+	clone := proto.Clone(req)
+	m := clone.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName(KeyField); fd != nil {
+		m.Clear(fd)
+	}
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(append([]byte(string(m.Descriptor().FullName())+":"), b...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func marshalResponse(resp proto.Message) ([]byte, error) {
+	a, err := anypb.New(resp)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(a)
+}
+
+func unmarshalResponse(b []byte) (proto.Message, error) {
+	a := new(anypb.Any)
+	if err := proto.Unmarshal(b, a); err != nil {
+		return nil, err
+	}
+	return a.UnmarshalNew()
+}
+
+func keyFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(MetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// HeaderMatcher forwards the Idempotency-Key header to gRPC metadata and
+// defers to the default grpc-gateway behaviour for every other header.
+func HeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == HeaderName {
+		return MetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,388 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	v0 "github.com/canonical/tenant-service/v0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package idempotency -destination ./mock_idempotency.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package idempotency -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package idempotency -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package idempotency -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestService_Execute(t *testing.T) {
+	principal := "user-123"
+	operation := "CreateTenant"
+	key := "retry-1"
+	req := &v0.CreateTenantRequest{Name: "Acme", IdempotencyKey: key}
+	resp := &v0.CreateTenantResponse{Tenant: &v0.Tenant{Id: "tenant-123", Name: "Acme"}}
+
+	fingerprint, err := Fingerprint(req)
+	if err != nil {
+		t.Fatalf("failed to fingerprint request: %v", err)
+	}
+	stored, err := marshalResponse(resp)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		ctx          func() context.Context
+		key          string
+		req          proto.Message
+		setupMocks   func(*MockStorageInterface)
+		fnErr        error
+		expectCalled bool
+		expectedCode codes.Code
+	}{
+		{
+			name:         "no key calls through",
+			key:          "",
+			req:          &v0.CreateTenantRequest{Name: "Acme"},
+			setupMocks:   func(*MockStorageInterface) {},
+			expectCalled: true,
+		},
+		{
+			name: "new key is reserved and completed",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil, storage.ErrNotFound)
+				s.EXPECT().CreateIdempotencyKey(gomock.Any(), &types.IdempotencyKey{
+					Principal:   principal,
+					Operation:   operation,
+					Key:         key,
+					Fingerprint: fingerprint,
+				}).Return(nil)
+				s.EXPECT().CompleteIdempotencyKey(gomock.Any(), principal, operation, key, stored).Return(nil)
+			},
+			expectCalled: true,
+		},
+		{
+			name: "key from metadata",
+			ctx: func() context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, key))
+			},
+			key: "",
+			req: &v0.CreateTenantRequest{Name: "Acme"},
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil, storage.ErrNotFound)
+				s.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+				s.EXPECT().CompleteIdempotencyKey(gomock.Any(), principal, operation, key, stored).Return(nil)
+			},
+			expectCalled: true,
+		},
+		{
+			name: "completed key replays response",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(&types.IdempotencyKey{
+					Principal:   principal,
+					Operation:   operation,
+					Key:         key,
+					Fingerprint: fingerprint,
+					Response:    stored,
+					CreatedAt:   time.Now(),
+				}, nil)
+			},
+		},
+		{
+			name: "key reused with different request",
+			key:  key,
+			req:  &v0.CreateTenantRequest{Name: "Other", IdempotencyKey: key},
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(&types.IdempotencyKey{
+					Fingerprint: fingerprint,
+					Response:    stored,
+					CreatedAt:   time.Now(),
+				}, nil)
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "key still in progress",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(&types.IdempotencyKey{
+					Fingerprint: fingerprint,
+					CreatedAt:   time.Now(),
+				}, nil)
+			},
+			expectedCode: codes.Aborted,
+		},
+		{
+			name: "concurrent reservation",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil, storage.ErrNotFound)
+				s.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(storage.ErrDuplicateKey)
+			},
+			expectedCode: codes.Aborted,
+		},
+		{
+			name: "expired key is reused",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(&types.IdempotencyKey{
+					Fingerprint: "stale",
+					Response:    stored,
+					CreatedAt:   time.Now().Add(-48 * time.Hour),
+				}, nil)
+				s.EXPECT().DeleteIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil)
+				s.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+				s.EXPECT().CompleteIdempotencyKey(gomock.Any(), principal, operation, key, stored).Return(nil)
+			},
+			expectCalled: true,
+		},
+		{
+			name: "failed operation releases key",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil, storage.ErrNotFound)
+				s.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+				s.EXPECT().DeleteIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil)
+			},
+			fnErr:        status.Error(codes.FailedPrecondition, "tenant disabled"),
+			expectCalled: true,
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "storage error",
+			key:  key,
+			req:  req,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().GetIdempotencyKey(gomock.Any(), principal, operation, key).Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "idempotency.Service.Execute").
+				DoAndReturn(func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+					return ctx, trace.SpanFromContext(ctx)
+				}).AnyTimes()
+
+			tc.setupMocks(mockStorage)
+
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+			ctx = authentication.WithUserID(ctx, principal)
+
+			called := false
+			fn := func(context.Context) (proto.Message, error) {
+				called = true
+				if tc.fnErr != nil {
+					return nil, tc.fnErr
+				}
+				return resp, nil
+			}
+
+			svc := NewService(mockStorage, encryption.NewNoopCipher(), 24*time.Hour, mockTracer, mockMonitor, mockLogger)
+			got, err := svc.Execute(ctx, operation, tc.key, tc.req, fn)
+
+			if called != tc.expectCalled {
+				t.Errorf("expected operation called %v, got %v", tc.expectCalled, called)
+			}
+			if tc.expectedCode != codes.OK {
+				if status.Code(err) != tc.expectedCode {
+					t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !proto.Equal(got, resp) {
+				t.Errorf("expected response %v, got %v", resp, got)
+			}
+		})
+	}
+}
+
+func newTestCipher(t *testing.T, keys, active string) *encryption.Cipher {
+	t.Helper()
+
+	p, err := encryption.NewStaticKeyProvider(keys, active)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return encryption.NewCipher(p, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("", logging.NewNoopLogger()))
+}
+
+func TestService_ExecuteEncrypted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	principal := "user-123"
+	req := &v0.InviteMemberRequest{TenantId: "tenant-123", Email: "jane@example.com", IdempotencyKey: "retry-1"}
+	resp := &v0.InviteMemberResponse{Status: "invited", Link: "https://login/recovery?code=secret", Code: "secret"}
+	cipher := newTestCipher(t, "k1:"+base64.StdEncoding.EncodeToString(make([]byte, encryption.KeySize)), "k1")
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockTracer.EXPECT().Start(gomock.Any(), "idempotency.Service.Execute").
+		DoAndReturn(func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+			return ctx, trace.SpanFromContext(ctx)
+		}).AnyTimes()
+
+	var stored []byte
+	mockStorage.EXPECT().GetIdempotencyKey(gomock.Any(), principal, "InviteMember", "retry-1").Return(nil, storage.ErrNotFound)
+	mockStorage.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+	mockStorage.EXPECT().CompleteIdempotencyKey(gomock.Any(), principal, "InviteMember", "retry-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, response []byte) error {
+			stored = response
+			return nil
+		})
+
+	svc := NewService(mockStorage, cipher, 24*time.Hour, mockTracer, mockMonitor, mockLogger)
+	ctx := authentication.WithUserID(context.Background(), principal)
+	fn := func(context.Context) (proto.Message, error) { return resp, nil }
+
+	if _, err := svc.Execute(ctx, "InviteMember", req.IdempotencyKey, req, fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !encryption.IsEncrypted(stored) || bytes.Contains(stored, []byte("secret")) {
+		t.Fatalf("expected the stored response to be encrypted, got %q", stored)
+	}
+
+	fingerprint, _ := Fingerprint(req)
+	mockStorage.EXPECT().GetIdempotencyKey(gomock.Any(), principal, "InviteMember", "retry-1").Return(&types.IdempotencyKey{
+		Principal:   principal,
+		Operation:   "InviteMember",
+		Key:         "retry-1",
+		Fingerprint: fingerprint,
+		Response:    stored,
+		CreatedAt:   time.Now(),
+	}, nil)
+
+	got, err := svc.Execute(ctx, "InviteMember", req.IdempotencyKey, req, fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !proto.Equal(got, resp) {
+		t.Errorf("expected response %v, got %v", resp, got)
+	}
+}
+
+func TestService_Reencrypt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	k1 := "k1:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, encryption.KeySize))
+	k2 := "k2:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, encryption.KeySize))
+	old := newTestCipher(t, k1, "k1")
+	rotated := newTestCipher(t, k1+","+k2, "k2")
+
+	plaintext, err := marshalResponse(&v0.InviteMemberResponse{Code: "secret"})
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	sealedOld, _ := old.Encrypt(context.Background(), plaintext, responseAAD("u1", "InviteMember", "a"))
+	sealedNew, _ := rotated.Encrypt(context.Background(), plaintext, responseAAD("u1", "InviteMember", "b"))
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockTracer.EXPECT().Start(gomock.Any(), "idempotency.Service.Reencrypt").
+		Return(context.Background(), trace.SpanFromContext(context.Background()))
+
+	mockStorage.EXPECT().ListCompletedIdempotencyKeys(gomock.Any()).Return([]*types.IdempotencyKey{
+		{Principal: "u1", Operation: "InviteMember", Key: "a", Response: sealedOld},
+		{Principal: "u1", Operation: "InviteMember", Key: "b", Response: sealedNew},
+		// stored before encryption was enabled
+		{Principal: "u1", Operation: "InviteMember", Key: "c", Response: plaintext},
+		// released while re-encrypting
+		{Principal: "u1", Operation: "InviteMember", Key: "d", Response: plaintext},
+	}, nil)
+	mockStorage.EXPECT().ReplaceIdempotencyResponse(gomock.Any(), "u1", "InviteMember", "a", sealedOld, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, key string, _, response []byte) error {
+			if rotate, _ := rotated.NeedsRotation(context.Background(), response); rotate {
+				t.Errorf("expected key %s to be sealed with the active key", key)
+			}
+			return nil
+		})
+	mockStorage.EXPECT().ReplaceIdempotencyResponse(gomock.Any(), "u1", "InviteMember", "c", plaintext, gomock.Any()).Return(nil)
+	mockStorage.EXPECT().ReplaceIdempotencyResponse(gomock.Any(), "u1", "InviteMember", "d", plaintext, gomock.Any()).Return(storage.ErrNotFound)
+
+	svc := NewService(mockStorage, rotated, 24*time.Hour, mockTracer, mockMonitor, mockLogger)
+	n, err := svc.Reencrypt(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 responses re-encrypted, got %d", n)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a, _ := Fingerprint(&v0.InviteMemberRequest{TenantId: "t", Email: "a@example.com", Role: "member", IdempotencyKey: "k1"})
+	b, _ := Fingerprint(&v0.InviteMemberRequest{TenantId: "t", Email: "a@example.com", Role: "member"})
+	c, _ := Fingerprint(&v0.InviteMemberRequest{TenantId: "t", Email: "a@example.com", Role: "admin"})
+	d, _ := Fingerprint(&v0.ProvisionUserRequest{TenantId: "t", Email: "a@example.com", Role: "member"})
+
+	if a != b {
+		t.Error("expected fingerprint to ignore the idempotency key")
+	}
+	if a == c {
+		t.Error("expected different payloads to have different fingerprints")
+	}
+	if a == d {
+		t.Error("expected different message types to have different fingerprints")
+	}
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	if key, ok := HeaderMatcher("idempotency-key"); !ok || key != MetadataKey {
+		t.Errorf("expected Idempotency-Key to map to %s, got %s", MetadataKey, key)
+	}
+	if _, ok := HeaderMatcher("X-Custom"); ok {
+		t.Error("expected unrelated headers to be dropped")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ops
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/apierror"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+	opsv0 "github.com/canonical/tenant-service/ops/v0"
+	"github.com/canonical/tenant-service/pkg/webhooks"
+)
+
+// actor is reported in the audit log for the actions taken through the ops API.
+const actor = "ops"
+
+// Handler serves the ops API. The reconciler and the cache are nil when
+// authorization, respectively the decision cache, is disabled.
+type Handler struct {
+	opsv0.UnimplementedOpsServiceServer
+
+	reconciler  ReconcilerInterface
+	cache       CacheInterface
+	maintenance MaintenanceInterface
+	levels      LogLevelInterface
+	// the dead letters are replayed in a transaction of db
+	deadLetters DeadLettersInterface
+	db          TxRunnerInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewHandler(
+	reconciler ReconcilerInterface,
+	cache CacheInterface,
+	maintenance MaintenanceInterface,
+	levels LogLevelInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Handler {
+	return &Handler{
+		reconciler:  reconciler,
+		cache:       cache,
+		maintenance: maintenance,
+		levels:      levels,
+		tracer:      tracer,
+		monitor:     monitor,
+		logger:      logger,
+	}
+}
+
+// SetDeadLetters serves the webhook dead letters, the replays run in the
+// transactions of db.
+func (h *Handler) SetDeadLetters(deadLetters DeadLettersInterface, db TxRunnerInterface) {
+	h.deadLetters = deadLetters
+	h.db = db
+}
+
+func (h *Handler) Reconcile(ctx context.Context, req *opsv0.ReconcileRequest) (*opsv0.ReconcileResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "ops.Handler.Reconcile")
+	defer span.End()
+
+	if h.reconciler == nil {
+		return nil, status.Error(codes.FailedPrecondition, "reconciliation requires authorization to be enabled")
+	}
+
+	drifts, err := h.reconciler.Reconcile(ctx, req.TenantIds, req.Fix)
+	if err != nil {
+		h.logger.Errorw("failed to reconcile", "tenant_ids", req.TenantIds, "fix", req.Fix, "error", err)
+		return nil, apierror.Error("failed to reconcile", err)
+	}
+
+	pbDrifts := make([]*opsv0.Drift, len(drifts))
+	for i, d := range drifts {
+		pbDrifts[i] = &opsv0.Drift{
+			Kind:     d.Kind,
+			TenantId: d.TenantID,
+			UserId:   d.UserID,
+			Relation: d.Relation,
+			Fixed:    d.Fixed,
+		}
+	}
+
+	return &opsv0.ReconcileResponse{Drifts: pbDrifts}, nil
+}
+
+func (h *Handler) FlushAuthzCache(ctx context.Context, req *opsv0.FlushAuthzCacheRequest) (*opsv0.FlushAuthzCacheResponse, error) {
+	_, span := h.tracer.Start(ctx, "ops.Handler.FlushAuthzCache")
+	defer span.End()
+
+	if h.cache == nil {
+		return &opsv0.FlushAuthzCacheResponse{}, nil
+	}
+
+	flushed := h.cache.Len()
+	h.cache.Purge()
+
+	h.logger.Security().AdminAction(actor, "flush_authz_cache", "ops.Handler.FlushAuthzCache", strconv.Itoa(flushed))
+	return &opsv0.FlushAuthzCacheResponse{Flushed: int32(flushed)}, nil
+}
+
+func (h *Handler) GetMaintenanceMode(ctx context.Context, req *opsv0.GetMaintenanceModeRequest) (*opsv0.GetMaintenanceModeResponse, error) {
+	return &opsv0.GetMaintenanceModeResponse{Enabled: h.maintenance.Enabled()}, nil
+}
+
+func (h *Handler) SetMaintenanceMode(ctx context.Context, req *opsv0.SetMaintenanceModeRequest) (*opsv0.SetMaintenanceModeResponse, error) {
+	_, span := h.tracer.Start(ctx, "ops.Handler.SetMaintenanceMode")
+	defer span.End()
+
+	previous := h.maintenance.Set(req.Enabled)
+
+	h.logger.Security().AdminAction(actor, "set_maintenance_mode", "ops.Handler.SetMaintenanceMode", strconv.FormatBool(req.Enabled))
+	return &opsv0.SetMaintenanceModeResponse{Enabled: req.Enabled, Previous: previous}, nil
+}
+
+func (h *Handler) GetLogLevel(ctx context.Context, req *opsv0.GetLogLevelRequest) (*opsv0.GetLogLevelResponse, error) {
+	return &opsv0.GetLogLevelResponse{Level: h.levels.Level()}, nil
+}
+
+func (h *Handler) SetLogLevel(ctx context.Context, req *opsv0.SetLogLevelRequest) (*opsv0.SetLogLevelResponse, error) {
+	_, span := h.tracer.Start(ctx, "ops.Handler.SetLogLevel")
+	defer span.End()
+
+	previous := h.levels.Level()
+	if err := h.levels.SetLevel(req.Level); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	h.logger.Security().AdminAction(actor, "set_log_level", "ops.Handler.SetLogLevel", req.Level)
+	return &opsv0.SetLogLevelResponse{Level: h.levels.Level(), Previous: previous}, nil
+}
+
+func (h *Handler) ListDeadLetters(ctx context.Context, req *opsv0.ListDeadLettersRequest) (*opsv0.ListDeadLettersResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "ops.Handler.ListDeadLetters")
+	defer span.End()
+
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.FailedPrecondition, "dead letters are not available")
+	}
+
+	letters, err := h.deadLetters.ListDeadLetters(ctx, req.Kind, int(req.Limit))
+	if err != nil {
+		h.logger.Errorw("failed to list dead letters", "kind", req.Kind, "error", err)
+		return nil, apierror.Error("failed to list dead letters", err)
+	}
+
+	pbLetters := make([]*opsv0.DeadLetter, len(letters))
+	for i, l := range letters {
+		pbLetters[i] = &opsv0.DeadLetter{
+			Id:        l.ID,
+			Kind:      l.Kind,
+			Payload:   string(l.Payload),
+			LastError: l.LastError,
+			Replays:   int32(l.Replays),
+			CreatedAt: l.CreatedAt.String(),
+			UpdatedAt: l.UpdatedAt.String(),
+		}
+	}
+
+	return &opsv0.ListDeadLettersResponse{DeadLetters: pbLetters}, nil
+}
+
+func (h *Handler) ReplayDeadLetter(ctx context.Context, req *opsv0.ReplayDeadLetterRequest) (*opsv0.ReplayDeadLetterResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "ops.Handler.ReplayDeadLetter")
+	defer span.End()
+
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.FailedPrecondition, "dead letters are not available")
+	}
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	err := h.db.WithTx(ctx, func(ctx context.Context) error {
+		return h.deadLetters.ReplayDeadLetter(ctx, req.Id)
+	})
+	switch {
+	case errors.Is(err, webhooks.ErrDeadLetterNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, webhooks.ErrDeadLetterNotReplayable):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		h.logger.Errorw("failed to replay dead letter", "dead_letter_id", req.Id, "error", err)
+		return nil, apierror.Error("failed to replay dead letter", err)
+	}
+
+	h.logger.Security().AdminAction(actor, "replay_dead_letter", "ops.Handler.ReplayDeadLetter", req.Id)
+	return &opsv0.ReplayDeadLetterResponse{}, nil
+}
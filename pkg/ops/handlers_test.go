@@ -0,0 +1,215 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	opsv0 "github.com/canonical/tenant-service/ops/v0"
+	"github.com/canonical/tenant-service/pkg/reconcile"
+	"github.com/canonical/tenant-service/pkg/webhooks"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package ops -destination ./mock_ops.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package ops -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package ops -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package ops -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+type mocks struct {
+	reconciler  *MockReconcilerInterface
+	cache       *MockCacheInterface
+	maintenance *MockMaintenanceInterface
+	levels      *MockLogLevelInterface
+	security    *MockSecurityLoggerInterface
+}
+
+func setupHandler(ctrl *gomock.Controller) (*Handler, *mocks) {
+	m := &mocks{
+		reconciler:  NewMockReconcilerInterface(ctrl),
+		cache:       NewMockCacheInterface(ctrl),
+		maintenance: NewMockMaintenanceInterface(ctrl),
+		levels:      NewMockLogLevelInterface(ctrl),
+		security:    NewMockSecurityLoggerInterface(ctrl),
+	}
+
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(m.security).AnyTimes()
+
+	return NewHandler(m.reconciler, m.cache, m.maintenance, m.levels, mockTracer, NewMockMonitorInterface(ctrl), mockLogger), m
+}
+
+func TestHandler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "reconcile error", err: errors.New("fga error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, m := setupHandler(ctrl)
+
+			drifts := []*reconcile.Drift{{Kind: reconcile.DriftMissingTuple, TenantID: "a", UserID: "1", Relation: "member", Fixed: true}}
+			m.reconciler.EXPECT().Reconcile(gomock.Any(), []string{"a"}, true).Return(drifts, tt.err)
+
+			resp, err := h.Reconcile(context.Background(), &opsv0.ReconcileRequest{TenantIds: []string{"a"}, Fix: true})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && (len(resp.Drifts) != 1 || !resp.Drifts[0].Fixed || resp.Drifts[0].Kind != reconcile.DriftMissingTuple) {
+				t.Errorf("unexpected drifts %v", resp.Drifts)
+			}
+		})
+	}
+}
+
+func TestHandler_ReconcileWithoutAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := setupHandler(ctrl)
+	h.reconciler = nil
+
+	if _, err := h.Reconcile(context.Background(), &opsv0.ReconcileRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestHandler_FlushAuthzCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, m := setupHandler(ctrl)
+
+	gomock.InOrder(
+		m.cache.EXPECT().Len().Return(3),
+		m.cache.EXPECT().Purge(),
+	)
+	m.security.EXPECT().AdminAction(actor, "flush_authz_cache", gomock.Any(), "3")
+
+	resp, err := h.FlushAuthzCache(context.Background(), &opsv0.FlushAuthzCacheRequest{})
+	if err != nil || resp.Flushed != 3 {
+		t.Fatalf("expected 3 decisions flushed, got %v, %v", resp, err)
+	}
+
+	// without a cache there is nothing to flush
+	h.cache = nil
+	if resp, err := h.FlushAuthzCache(context.Background(), &opsv0.FlushAuthzCacheRequest{}); err != nil || resp.Flushed != 0 {
+		t.Fatalf("expected nothing flushed, got %v, %v", resp, err)
+	}
+}
+
+func TestHandler_SetMaintenanceMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, m := setupHandler(ctrl)
+
+	m.maintenance.EXPECT().Set(true).Return(false)
+	m.security.EXPECT().AdminAction(actor, "set_maintenance_mode", gomock.Any(), "true")
+
+	resp, err := h.SetMaintenanceMode(context.Background(), &opsv0.SetMaintenanceModeRequest{Enabled: true})
+	if err != nil || !resp.Enabled || resp.Previous {
+		t.Fatalf("unexpected response %v, %v", resp, err)
+	}
+
+	m.maintenance.EXPECT().Enabled().Return(true)
+	if resp, err := h.GetMaintenanceMode(context.Background(), &opsv0.GetMaintenanceModeRequest{}); err != nil || !resp.Enabled {
+		t.Fatalf("expected maintenance to be enabled, got %v, %v", resp, err)
+	}
+}
+
+func TestHandler_SetLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "success", level: "debug", wantCode: codes.OK},
+		{name: "unknown level", level: "verbose", err: fmt.Errorf("unknown log level %q", "verbose"), wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, m := setupHandler(ctrl)
+
+			m.levels.EXPECT().Level().Return("info")
+			m.levels.EXPECT().SetLevel(tt.level).Return(tt.err)
+			if tt.err == nil {
+				m.levels.EXPECT().Level().Return(tt.level)
+				m.security.EXPECT().AdminAction(actor, "set_log_level", gomock.Any(), tt.level)
+			}
+
+			resp, err := h.SetLogLevel(context.Background(), &opsv0.SetLogLevelRequest{Level: tt.level})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && (resp.Level != tt.level || resp.Previous != "info") {
+				t.Errorf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ReplayDeadLetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "not found", err: webhooks.ErrDeadLetterNotFound, wantCode: codes.NotFound},
+		{name: "token hook", err: fmt.Errorf("%w: token_hook", webhooks.ErrDeadLetterNotReplayable), wantCode: codes.FailedPrecondition},
+		{name: "replay error", err: errors.New("fga error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, m := setupHandler(ctrl)
+			deadLetters := NewMockDeadLettersInterface(ctrl)
+			db := NewMockTxRunnerInterface(ctrl)
+			h.SetDeadLetters(deadLetters, db)
+
+			db.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			deadLetters.EXPECT().ReplayDeadLetter(gomock.Any(), "letter-1").Return(tt.err)
+			if tt.err == nil {
+				m.security.EXPECT().AdminAction(actor, "replay_dead_letter", gomock.Any(), "letter-1")
+			}
+
+			_, err := h.ReplayDeadLetter(context.Background(), &opsv0.ReplayDeadLetterRequest{Id: "letter-1"})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ops
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/reconcile"
+)
+
+// ReconcilerInterface compares the memberships with the OpenFGA relations, see pkg/reconcile.
+type ReconcilerInterface interface {
+	Reconcile(ctx context.Context, tenantIDs []string, fix bool) ([]*reconcile.Drift, error)
+}
+
+// CacheInterface is the authorization decision cache, see internal/authorization.
+type CacheInterface interface {
+	Purge()
+	Len() int
+}
+
+// MaintenanceInterface toggles the maintenance mode, see internal/maintenance.
+type MaintenanceInterface interface {
+	Enabled() bool
+	Set(enabled bool) bool
+}
+
+// LogLevelInterface changes the level of the service logs, see internal/logging.
+type LogLevelInterface interface {
+	Level() string
+	SetLevel(level string) error
+}
+
+// DeadLettersInterface lists and replays the webhook dead letters, see pkg/webhooks.
+type DeadLettersInterface interface {
+	ListDeadLetters(ctx context.Context, kind string, limit int) ([]*types.WebhookDeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) error
+}
+
+// TxRunnerInterface runs a function in a database transaction, see internal/db.
+type TxRunnerInterface interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
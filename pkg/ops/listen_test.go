@@ -0,0 +1,57 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListen(t *testing.T) {
+	for _, address := range []string{"0.0.0.0:0", "10.0.0.1:50052", ":50052", "localhost"} {
+		if lis, err := Listen(address); err == nil {
+			lis.Close()
+			t.Errorf("expected %q to be refused", address)
+		}
+	}
+
+	lis, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lis.Close()
+}
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ops.sock")
+
+	// a stale socket is replaced
+	for range 2 {
+		lis, err := Listen("unix://" + path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("expected the socket to be private, got %v", info.Mode().Perm())
+		}
+		// keeps the socket file behind, as a crash would
+		if ul, ok := lis.(interface{ SetUnlinkOnClose(bool) }); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+		lis.Close()
+	}
+
+	regular := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(regular, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen("unix:" + regular); err == nil {
+		t.Error("expected a regular file not to be replaced")
+	}
+}
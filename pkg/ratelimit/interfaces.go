@@ -0,0 +1,17 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// StoreInterface holds the token buckets of the limiter.
+type StoreInterface interface {
+	// Take takes a token of the bucket of key, refilled with limit tokens
+	// per second up to burst. When it is empty no token is taken and Take
+	// returns how long until it holds one.
+	Take(ctx context.Context, key string, limit float64, burst int) (time.Duration, error)
+}
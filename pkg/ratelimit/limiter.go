@@ -0,0 +1,200 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+// Package ratelimit limits the calls of the API with token buckets, one
+// shared by all the callers, one per subject and one per tenant.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/clientip"
+	"github.com/canonical/tenant-service/internal/http/types"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	scopeGlobal  = "global"
+	scopeSubject = "subject"
+	scopeTenant  = "tenant"
+)
+
+// Config tunes a Limiter, a zero limit disables its buckets.
+type Config struct {
+	// Limit and Burst are the rate, per second, and the burst of the calls
+	// of all the callers.
+	Limit float64
+	Burst int
+	// SubjectLimit and SubjectBurst are those of each authenticated
+	// subject, or of each client IP for the anonymous calls.
+	SubjectLimit float64
+	SubjectBurst int
+	// TenantLimit and TenantBurst are those of the calls on each tenant.
+	TenantLimit float64
+	TenantBurst int
+}
+
+// bucket is a bucket a call takes a token of.
+type bucket struct {
+	scope string
+	key   string
+	limit float64
+	burst int
+}
+
+// Limiter rejects the calls finding one of their buckets empty. The buckets
+// are taken from the narrowest, the subject, to the widest, so that a noisy
+// caller empties its own bucket before the shared ones; the tokens taken
+// from the narrower buckets by a call limited by a wider one are lost.
+//
+// A failing store lets the calls through, the limiter protects the service
+// and must not take it down with the store.
+type Limiter struct {
+	config Config
+	store  StoreInterface
+
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Middleware rejects the calls over the limits with 429 and the wait before
+// a retry in Retry-After. It runs after the authentication, to know the
+// subject, and reads the tenant from the /api/v0/tenants/{id} paths.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		retryAfter, scope, limited := l.limited(ctx, l.subject(ctx, r.RemoteAddr), tenantFromPath(r.URL.Path))
+		if limited {
+			l.logger.Debugw("rate limited", "path", r.URL.Path, "scope", scope)
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			types.WriteProblem(w, r, http.StatusTooManyRequests, codes.ResourceExhausted, "rate limit exceeded, retry later")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UnaryServerInterceptor rejects the calls over the limits with
+// ResourceExhausted and the wait before a retry in the retry-after header.
+// It runs after the authentication, the tenant is the tenant_id of the
+// request.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	tenantID := ""
+	if r, ok := req.(interface{ GetTenantId() string }); ok {
+		tenantID = r.GetTenantId()
+	}
+
+	retryAfter, scope, limited := l.limited(ctx, l.subject(ctx, ""), tenantID)
+	if limited {
+		l.logger.Debugw("rate limited", "method", info.FullMethod, "scope", scope)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfterSeconds(retryAfter)))
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded, retry later")
+	}
+
+	return handler(ctx, req)
+}
+
+// limited takes a token of the buckets of the call, it reports whether one
+// of them is empty, the scope of that one and how long until it holds a
+// token.
+func (l *Limiter) limited(ctx context.Context, subject, tenantID string) (time.Duration, string, bool) {
+	for _, b := range l.buckets(subject, tenantID) {
+		wait, err := l.store.Take(ctx, b.key, b.limit, b.burst)
+		if err != nil {
+			l.logger.Warnf("rate limiter failed, letting the call through: %v", err)
+			return 0, "", false
+		}
+		if wait > 0 {
+			l.countLimited(b.scope)
+			return wait, b.scope, true
+		}
+	}
+	return 0, "", false
+}
+
+// buckets returns the enabled buckets of a call, from the narrowest.
+func (l *Limiter) buckets(subject, tenantID string) []bucket {
+	buckets := make([]bucket, 0, 3)
+	if l.config.SubjectLimit > 0 && subject != "" {
+		buckets = append(buckets, bucket{scopeSubject, scopeSubject + ":" + subject, l.config.SubjectLimit, max(l.config.SubjectBurst, 1)})
+	}
+	if l.config.TenantLimit > 0 && tenantID != "" {
+		buckets = append(buckets, bucket{scopeTenant, scopeTenant + ":" + tenantID, l.config.TenantLimit, max(l.config.TenantBurst, 1)})
+	}
+	if l.config.Limit > 0 {
+		buckets = append(buckets, bucket{scopeGlobal, scopeGlobal, l.config.Limit, max(l.config.Burst, 1)})
+	}
+	return buckets
+}
+
+// subject returns the ID of the principal of ctx, or the client IP of an
+// anonymous call.
+func (l *Limiter) subject(ctx context.Context, remoteAddr string) string {
+	if p, ok := authentication.GetPrincipal(ctx); ok && p.ID != "" {
+		return p.ID
+	}
+	if ip, ok := clientip.FromContext(ctx); ok {
+		return "ip:" + ip
+	}
+	if remoteAddr == "" {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + remoteAddr
+}
+
+func (l *Limiter) countLimited(scope string) {
+	operation := "rate_limited_" + scope
+	if err := l.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		l.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// tenantFromPath returns the ID of the tenant of a gateway path, empty when
+// it is not under /api/v0/tenants/{id}.
+func tenantFromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v0/tenants/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// NewLimiter returns a limiter of the calls with the buckets of config in
+// store, nil when they are all disabled.
+func NewLimiter(config Config, store StoreInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *Limiter {
+	if config.Limit <= 0 && config.SubjectLimit <= 0 && config.TenantLimit <= 0 {
+		return nil
+	}
+
+	l := new(Limiter)
+
+	l.config = config
+	l.store = store
+	l.monitor = monitor
+	l.logger = logger
+
+	return l
+}
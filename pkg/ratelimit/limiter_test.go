@@ -0,0 +1,146 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/pkg/authentication"
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package ratelimit -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package ratelimit -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, float64, int) (time.Duration, error) {
+	return 0, errors.New("connection refused")
+}
+
+func withPrincipal(r *http.Request, id string) *http.Request {
+	return r.WithContext(authentication.WithPrincipal(r.Context(), &authentication.Principal{ID: id}))
+}
+
+func TestLimiterMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "rate_limited_subject", "role": ""}).Return(nil)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "rate_limited_tenant", "role": ""}).Return(nil)
+
+	store := NewMemoryStore(10)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	l := NewLimiter(Config{SubjectLimit: 1, SubjectBurst: 1, TenantLimit: 1, TenantBurst: 2}, store, mockMonitor, mockLogger)
+	handler := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name           string
+		subject        string
+		path           string
+		expectedStatus int
+	}{
+		{name: "First call", subject: "alice", path: "/api/v0/tenants/t1", expectedStatus: http.StatusOK},
+		{name: "Subject limited", subject: "alice", path: "/api/v0/tenants/t2", expectedStatus: http.StatusTooManyRequests},
+		{name: "Other subject", subject: "bob", path: "/api/v0/tenants/t1/users", expectedStatus: http.StatusOK},
+		{name: "Tenant limited", subject: "carol", path: "/api/v0/tenants/t1", expectedStatus: http.StatusTooManyRequests},
+		{name: "Other tenant", subject: "dave", path: "/api/v0/tenants/t2", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, withPrincipal(httptest.NewRequest(http.MethodGet, tt.path, nil), tt.subject))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "1" {
+				t.Errorf("expected Retry-After 1, got %q", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestLimiterUnaryServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "rate_limited_global", "role": ""}).Return(nil)
+
+	l := NewLimiter(Config{Limit: 1, Burst: 1}, NewMemoryStore(10), mockMonitor, mockLogger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/tenant.v0.TenantService/DeleteTenant"}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	if _, err := l.UnaryServerInterceptor(context.Background(), &v0.DeleteTenantRequest{TenantId: "t1"}, info, handler); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	_, err := l.UnaryServerInterceptor(context.Background(), &v0.DeleteTenantRequest{TenantId: "t2"}, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestLimiterStoreFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+
+	l := NewLimiter(Config{Limit: 1}, failingStore{}, NewMockMonitorInterface(ctrl), mockLogger)
+
+	w := httptest.NewRecorder()
+	l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v0/tenants", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected the call through, got %d", w.Code)
+	}
+}
+
+func TestNewLimiterDisabled(t *testing.T) {
+	if l := NewLimiter(Config{Burst: 10}, NewMemoryStore(10), nil, nil); l != nil {
+		t.Errorf("expected no limiter without limits")
+	}
+}
+
+func TestMemoryStoreSize(t *testing.T) {
+	s := NewMemoryStore(1)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	if wait, _ := s.Take(context.Background(), "a", 1, 1); wait != 0 {
+		t.Fatalf("expected a token, wait %v", wait)
+	}
+	// "a" is not full, "b" is not tracked and let through
+	for range 3 {
+		if wait, _ := s.Take(context.Background(), "b", 1, 1); wait != 0 {
+			t.Fatalf("expected an untracked key let through, wait %v", wait)
+		}
+	}
+
+	now = now.Add(time.Second)
+	s.Take(context.Background(), "b", 1, 1)
+	if wait, _ := s.Take(context.Background(), "b", 1, 1); wait != time.Second {
+		t.Errorf("expected b tracked once a was full, wait %v", wait)
+	}
+}
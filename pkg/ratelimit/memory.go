@@ -0,0 +1,82 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// MemoryStore holds the buckets in memory, each replica limits the calls it
+// serves on its own.
+//
+// At most size buckets are tracked, once full the full buckets are dropped,
+// and the calls of new keys are let through until some are.
+type MemoryStore struct {
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit float64, burst int) (time.Duration, error) {
+	now := s.now()
+
+	b := s.bucket(key, limit, burst, now)
+	if b == nil {
+		return 0, nil
+	}
+
+	r := b.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay, nil
+	}
+	return 0, nil
+}
+
+// bucket returns the bucket of key, nil when too many are tracked.
+func (s *MemoryStore) bucket(key string, limit float64, burst int, now time.Time) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if ok {
+		return b
+	}
+	if len(s.buckets) >= s.size {
+		s.sweep(now)
+		if len(s.buckets) >= s.size {
+			return nil
+		}
+	}
+
+	b = rate.NewLimiter(rate.Limit(limit), burst)
+	s.buckets[key] = b
+	return b
+}
+
+// sweep drops the full buckets, a new bucket is full as well.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if b.TokensAt(now) >= float64(b.Burst()) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// NewMemoryStore returns a store of at most size buckets.
+func NewMemoryStore(size int) *MemoryStore {
+	s := new(MemoryStore)
+
+	s.size = size
+	s.now = time.Now
+	s.buckets = make(map[string]*rate.Limiter)
+
+	return s
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// StorageInterface defines the storage operations required by the reconcile package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	ListTenants(ctx context.Context) ([]*types.Tenant, error)
+	ListMembersByTenantID(ctx context.Context, tenantID string) ([]*types.Membership, error)
+}
+
+// AuthzInterface defines the authorization operations required by the reconcile package.
+// It is a subset of the internal/authorization interface.
+type AuthzInterface interface {
+	ListTenantTuples(ctx context.Context, tenantID string) ([]openfga.Tuple, error)
+	ListAllTenantTuples(ctx context.Context) ([]openfga.Tuple, error)
+	WriteTuples(ctx context.Context, tuples ...openfga.Tuple) error
+	DeleteTuples(ctx context.Context, tuples ...openfga.Tuple) error
+}
+
+// ServiceInterface compares the memberships stored in Postgres with the tenant relations in OpenFGA.
+type ServiceInterface interface {
+	Reconcile(ctx context.Context, tenantIDs []string, fix bool) ([]*Drift, error)
+}
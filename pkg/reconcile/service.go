@@ -0,0 +1,278 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+const (
+	// DriftMissingTuple is a membership stored in Postgres without the matching OpenFGA relation.
+	DriftMissingTuple = "missing_tuple"
+	// DriftOrphanedTuple is an OpenFGA owner or member relation without the matching membership.
+	DriftOrphanedTuple = "orphaned_tuple"
+
+	// actor is reported in the audit log for the repairs made by the reconciler.
+	actor = "reconcile-fga"
+)
+
+// Drift is a tenant relation that differs between Postgres and OpenFGA.
+type Drift struct {
+	Kind     string `json:"kind"`
+	TenantID string `json:"tenant_id"`
+	UserID   string `json:"user_id"`
+	Relation string `json:"relation"`
+	Fixed    bool   `json:"fixed"`
+}
+
+type Service struct {
+	storage StorageInterface
+	authz   AuthzInterface
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	storage StorageInterface,
+	authz AuthzInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		storage: storage,
+		authz:   authz,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// Reconcile diffs the membership relations of the given tenants, or of
+// every tenant known to either side when tenantIDs is empty, against their
+// memberships. With fix set, missing relations are written and orphaned ones
+// deleted in batches, Fixed reports whether the batch holding the drift succeeded.
+func (s *Service) Reconcile(ctx context.Context, tenantIDs []string, fix bool) ([]*Drift, error) {
+	ctx, span := s.tracer.Start(ctx, "reconcile.Service.Reconcile")
+	defer span.End()
+
+	// tuples are read before memberships, so that a membership created in
+	// between shows up as a missing tuple rather than its tuple as an orphan
+	tuples, err := s.listTuples(ctx, tenantIDs)
+	if err != nil {
+		s.recordError(span, "failed to list tenant tuples", err)
+		return nil, fmt.Errorf("failed to list tenant tuples: %w", err)
+	}
+
+	if len(tenantIDs) == 0 {
+		tenants, err := s.storage.ListTenants(ctx)
+		if err != nil {
+			s.recordError(span, "failed to list tenants", err)
+			return nil, fmt.Errorf("failed to list tenants: %w", err)
+		}
+
+		seen := make(map[string]bool)
+		for _, t := range tenants {
+			seen[t.ID] = true
+			tenantIDs = append(tenantIDs, t.ID)
+		}
+		// tenants deleted from Postgres that still hold relations
+		for tenantID := range tuples {
+			if !seen[tenantID] {
+				tenantIDs = append(tenantIDs, tenantID)
+			}
+		}
+		sort.Strings(tenantIDs)
+	}
+
+	drifts := make([]*Drift, 0)
+	for _, tenantID := range tenantIDs {
+		members, err := s.storage.ListMembersByTenantID(ctx, tenantID)
+		if err != nil {
+			s.recordError(span, "failed to list members", err, "tenant_id", tenantID)
+			return nil, fmt.Errorf("failed to list members of tenant %s: %w", tenantID, err)
+		}
+
+		for _, d := range diff(tenantID, members, tuples[tenantID]) {
+			s.logger.Warnw("tenant relation drift detected",
+				"kind", d.Kind,
+				"tenant_id", d.TenantID,
+				"user_id", d.UserID,
+				"relation", d.Relation,
+			)
+			s.incrementCounter(d)
+			drifts = append(drifts, d)
+		}
+	}
+
+	if fix {
+		s.repair(ctx, drifts)
+	}
+
+	s.logger.Infow("tenant relation reconciliation completed", "tenants", len(tenantIDs), "drifts", len(drifts), "fix", fix)
+	return drifts, nil
+}
+
+// Run reconciles every tenant each interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration, fix bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Reconcile(ctx, nil, fix); err != nil {
+				s.logger.Errorw("periodic tenant relation reconciliation failed", "error", err)
+			}
+		}
+	}
+}
+
+// listTuples returns the membership tuples held by users, keyed by tenant ID.
+func (s *Service) listTuples(ctx context.Context, tenantIDs []string) (map[string][]openfga.Tuple, error) {
+	var tuples []openfga.Tuple
+	if len(tenantIDs) == 0 {
+		ts, err := s.authz.ListAllTenantTuples(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tuples = ts
+	}
+	for _, tenantID := range tenantIDs {
+		ts, err := s.authz.ListTenantTuples(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, ts...)
+	}
+
+	ret := make(map[string][]openfga.Tuple)
+	for _, t := range tuples {
+		tenantID, ok := authorization.TenantIDFromTuple(t.Object)
+		if !ok {
+			continue
+		}
+		if _, ok := authorization.UserIDFromTuple(t.User); !ok {
+			continue
+		}
+		if !slices.Contains(authorization.MembershipRelations, t.Relation) {
+			continue
+		}
+		ret[tenantID] = append(ret[tenantID], t)
+	}
+	return ret, nil
+}
+
+// diff compares the memberships of a tenant with its membership tuples, both directions.
+func diff(tenantID string, members []*types.Membership, tuples []openfga.Tuple) []*Drift {
+	held := make(map[openfga.Tuple]bool)
+	for _, t := range tuples {
+		held[t] = true
+	}
+
+	var drifts []*Drift
+	expected := make(map[openfga.Tuple]bool)
+	for _, m := range members {
+		relation := authorization.RelationForRole(m.Role)
+		t := *openfga.NewTuple(authorization.UserTuple(m.KratosIdentityID), relation, authorization.TenantTuple(tenantID))
+		expected[t] = true
+
+		if !held[t] {
+			drifts = append(drifts, &Drift{
+				Kind:     DriftMissingTuple,
+				TenantID: tenantID,
+				UserID:   m.KratosIdentityID,
+				Relation: relation,
+			})
+		}
+	}
+
+	for _, t := range tuples {
+		if expected[t] {
+			continue
+		}
+		userID, _ := authorization.UserIDFromTuple(t.User)
+		drifts = append(drifts, &Drift{
+			Kind:     DriftOrphanedTuple,
+			TenantID: tenantID,
+			UserID:   userID,
+			Relation: t.Relation,
+		})
+	}
+
+	return drifts
+}
+
+// repair writes the missing relations and deletes the orphaned ones with one
+// batched call each.
+func (s *Service) repair(ctx context.Context, drifts []*Drift) {
+	var missing, orphaned []*Drift
+	for _, d := range drifts {
+		if d.Kind == DriftMissingTuple {
+			missing = append(missing, d)
+		} else {
+			orphaned = append(orphaned, d)
+		}
+	}
+
+	if len(missing) > 0 {
+		err := s.authz.WriteTuples(ctx, driftTuples(missing)...)
+		s.markFixed(missing, err)
+	}
+	if len(orphaned) > 0 {
+		err := s.authz.DeleteTuples(ctx, driftTuples(orphaned)...)
+		s.markFixed(orphaned, err)
+	}
+}
+
+func (s *Service) markFixed(drifts []*Drift, err error) {
+	if err != nil {
+		s.logger.Errorw("failed to repair tenant relation drift", "kind", drifts[0].Kind, "drifts", len(drifts), "error", err)
+		return
+	}
+
+	for _, d := range drifts {
+		d.Fixed = true
+		s.logger.Security().AdminAction(actor, "fix_"+d.Kind, "reconcile.Service.Reconcile", d.TenantID+":"+d.UserID)
+	}
+}
+
+func driftTuples(drifts []*Drift) []openfga.Tuple {
+	ts := make([]openfga.Tuple, len(drifts))
+	for i, d := range drifts {
+		ts[i] = *openfga.NewTuple(authorization.UserTuple(d.UserID), d.Relation, authorization.TenantTuple(d.TenantID))
+	}
+	return ts
+}
+
+func (s *Service) incrementCounter(d *Drift) {
+	if err := s.monitor.IncrementCounter(map[string]string{"operation": "fga_drift_" + d.Kind, "role": d.Relation}); err != nil {
+		s.logger.Warnf("failed to increment counter fga_drift_%s: %v", d.Kind, err)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package reconcile -destination ./mock_reconcile.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package reconcile -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package reconcile -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package reconcile -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+// setupLoggerMock configures a MockLoggerInterface with AnyTimes() stubs for all
+// structured logging methods (w-suffix) and for the security logger.
+func setupLoggerMock(ctrl *gomock.Controller, mockLogger *MockLoggerInterface) *MockSecurityLoggerInterface {
+	mockSecurityLogger := NewMockSecurityLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurityLogger).AnyTimes()
+	mockSecurityLogger.EXPECT().AdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return mockSecurityLogger
+}
+
+func tuple(user, relation, tenantID string) openfga.Tuple {
+	return *openfga.NewTuple("user:"+user, relation, "tenant:"+tenantID)
+}
+
+func TestService_Reconcile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tenantIDs  []string
+		fix        bool
+		setupMocks func(*MockStorageInterface, *MockAuthzInterface)
+		expected   []Drift
+		expectErr  bool
+	}{
+		{
+			name: "In sync",
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListAllTenantTuples(gomock.Any()).Return([]openfga.Tuple{
+					tuple("u1", "owner", "t1"),
+					tuple("u2", "admin", "t1"),
+					// custom role grants are not memberships
+					*openfga.NewTuple("role:r1#assignee", "viewer", "tenant:t1"),
+				}, nil)
+				s.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{{ID: "t1"}}, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t1").Return([]*types.Membership{
+					{TenantID: "t1", KratosIdentityID: "u1", Role: "owner"},
+					{TenantID: "t1", KratosIdentityID: "u2", Role: "admin"},
+				}, nil)
+			},
+			expected: []Drift{},
+		},
+		{
+			name: "Drift in both directions",
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListAllTenantTuples(gomock.Any()).Return([]openfga.Tuple{
+					tuple("u1", "member", "t1"),
+					tuple("u3", "owner", "deleted"),
+				}, nil)
+				s.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{{ID: "t1"}}, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "deleted").Return(nil, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t1").Return([]*types.Membership{
+					{TenantID: "t1", KratosIdentityID: "u1", Role: "owner"},
+				}, nil)
+			},
+			expected: []Drift{
+				{Kind: DriftOrphanedTuple, TenantID: "deleted", UserID: "u3", Relation: "owner"},
+				{Kind: DriftMissingTuple, TenantID: "t1", UserID: "u1", Relation: "owner"},
+				{Kind: DriftOrphanedTuple, TenantID: "t1", UserID: "u1", Relation: "member"},
+			},
+		},
+		{
+			name:      "Fix selected tenant",
+			tenantIDs: []string{"t1"},
+			fix:       true,
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListTenantTuples(gomock.Any(), "t1").Return([]openfga.Tuple{
+					tuple("u2", "owner", "t1"),
+				}, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t1").Return([]*types.Membership{
+					{TenantID: "t1", KratosIdentityID: "u1", Role: "member"},
+				}, nil)
+				a.EXPECT().WriteTuples(gomock.Any(), tuple("u1", "member", "t1")).Return(nil)
+				a.EXPECT().DeleteTuples(gomock.Any(), tuple("u2", "owner", "t1")).Return(errors.New("fga down"))
+			},
+			expected: []Drift{
+				{Kind: DriftMissingTuple, TenantID: "t1", UserID: "u1", Relation: "member", Fixed: true},
+				{Kind: DriftOrphanedTuple, TenantID: "t1", UserID: "u2", Relation: "owner", Fixed: false},
+			},
+		},
+		{
+			name: "Fix in one batch per kind",
+			fix:  true,
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListAllTenantTuples(gomock.Any()).Return(nil, nil)
+				s.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{{ID: "t1"}, {ID: "t2"}}, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t1").Return([]*types.Membership{
+					{TenantID: "t1", KratosIdentityID: "u1", Role: "owner"},
+				}, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t2").Return([]*types.Membership{
+					{TenantID: "t2", KratosIdentityID: "u2", Role: "member"},
+				}, nil)
+				a.EXPECT().WriteTuples(gomock.Any(), tuple("u1", "owner", "t1"), tuple("u2", "member", "t2")).Return(nil)
+			},
+			expected: []Drift{
+				{Kind: DriftMissingTuple, TenantID: "t1", UserID: "u1", Relation: "owner", Fixed: true},
+				{Kind: DriftMissingTuple, TenantID: "t2", UserID: "u2", Relation: "member", Fixed: true},
+			},
+		},
+		{
+			name: "Tuples error",
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListAllTenantTuples(gomock.Any()).Return(nil, errors.New("fga down"))
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Members error",
+			tenantIDs: []string{"t1"},
+			setupMocks: func(s *MockStorageInterface, a *MockAuthzInterface) {
+				a.EXPECT().ListTenantTuples(gomock.Any(), "t1").Return(nil, nil)
+				s.EXPECT().ListMembersByTenantID(gomock.Any(), "t1").Return(nil, errors.New("db down"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "reconcile.Service.Reconcile").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockMonitor.EXPECT().IncrementCounter(gomock.Any()).AnyTimes()
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			drifts, err := s.Reconcile(context.Background(), tc.tenantIDs, tc.fix)
+
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(drifts) != len(tc.expected) {
+				t.Fatalf("expected %d drifts, got %d", len(tc.expected), len(drifts))
+			}
+			for i := range drifts {
+				if *drifts[i] != tc.expected[i] {
+					t.Errorf("expected drift %+v, got %+v", tc.expected[i], *drifts[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package role
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// StorageInterface defines the storage operations required by the role package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CreateRole(ctx context.Context, r *types.Role) (*types.Role, error)
+	GetRole(ctx context.Context, tenantID, roleID string) (*types.Role, error)
+	ListRolesByTenantID(ctx context.Context, tenantID string) ([]*types.Role, error)
+	UpdateRolePermissions(ctx context.Context, tenantID, roleID string, permissions []string) error
+	DeleteRole(ctx context.Context, tenantID, roleID string) error
+	AddRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error
+	DeleteRoleAssignment(ctx context.Context, tenantID, roleID, userID string) error
+	ListRoleAssignees(ctx context.Context, roleID string) ([]string, error)
+}
+
+// AuthzInterface defines the authorization operations required by the role package.
+// It is a subset of the internal/authorization interface.
+type AuthzInterface interface {
+	GrantRolePermissions(ctx context.Context, tenantID, roleID string, permissions []string) error
+	RevokeRolePermissions(ctx context.Context, tenantID, roleID string, permissions []string) error
+	AssignRole(ctx context.Context, roleID, userID string) error
+	UnassignRole(ctx context.Context, roleID, userID string) error
+	UnassignRoleUsers(ctx context.Context, roleID string, userIDs []string) error
+}
+
+// ServiceInterface defines the custom role operations.
+type ServiceInterface interface {
+	CreateRole(ctx context.Context, tenantID, name string, permissions []string) (*types.Role, error)
+	ListRoles(ctx context.Context, tenantID string) ([]*types.Role, error)
+	UpdateRole(ctx context.Context, tenantID, roleID string, permissions []string) (*types.Role, error)
+	DeleteRole(ctx context.Context, tenantID, roleID string) error
+	AssignRole(ctx context.Context, tenantID, roleID, userID string) error
+	UnassignRole(ctx context.Context, tenantID, roleID, userID string) error
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+var (
+	ErrRoleNotFound     = errors.New("role not found")
+	ErrRoleExists       = errors.New("a role with this name already exists in the tenant")
+	ErrTenantNotFound   = errors.New("tenant not found")
+	ErrNotMember        = errors.New("user is not a member of the tenant")
+	ErrAlreadyAssigned  = errors.New("role is already assigned to the user")
+	ErrAssignmentAbsent = errors.New("role is not assigned to the user")
+)
+
+type Service struct {
+	storage StorageInterface
+	authz   AuthzInterface
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	storage StorageInterface,
+	authz AuthzInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		storage: storage,
+		authz:   authz,
+		tracer:  tracer,
+		monitor: monitor,
+		logger:  logger,
+	}
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// CreateRole stores a new custom role and grants its permissions on the tenant.
+func (s *Service) CreateRole(ctx context.Context, tenantID, name string, permissions []string) (*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "role.Service.CreateRole")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	permissions = normalize(permissions)
+
+	created, err := s.storage.CreateRole(ctx, &types.Role{
+		TenantID:    tenantID,
+		Name:        name,
+		Permissions: permissions,
+	})
+	switch {
+	case errors.Is(err, storage.ErrDuplicateKey):
+		return nil, ErrRoleExists
+	case errors.Is(err, storage.ErrForeignKeyViolation):
+		return nil, ErrTenantNotFound
+	case err != nil:
+		s.recordError(span, "failed to create role", err, "tenant_id", tenantID, "name", name)
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	if err := s.authz.GrantRolePermissions(ctx, tenantID, created.ID, permissions); err != nil {
+		s.recordError(span, "failed to grant role permissions", err, "tenant_id", tenantID, "role_id", created.ID, "permissions", permissions)
+		return nil, fmt.Errorf("failed to grant role permissions: %w", err)
+	}
+
+	s.logger.Infow("created role", "tenant_id", tenantID, "role_id", created.ID, "name", name, "permissions", permissions)
+	s.logger.Security().AdminAction(actor, "create_role", "role.Service.CreateRole", tenantID+":"+created.ID, authentication.PrincipalLabel(ctx))
+	return created, nil
+}
+
+func (s *Service) ListRoles(ctx context.Context, tenantID string) ([]*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "role.Service.ListRoles")
+	defer span.End()
+
+	roles, err := s.storage.ListRolesByTenantID(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to list roles", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+// UpdateRole replaces the permissions of a role, granting the added ones and
+// revoking the removed ones.
+func (s *Service) UpdateRole(ctx context.Context, tenantID, roleID string, permissions []string) (*types.Role, error) {
+	ctx, span := s.tracer.Start(ctx, "role.Service.UpdateRole")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	permissions = normalize(permissions)
+
+	r, err := s.getRole(ctx, span, tenantID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.UpdateRolePermissions(ctx, tenantID, roleID, permissions); err != nil {
+		s.recordError(span, "failed to update role", err, "tenant_id", tenantID, "role_id", roleID)
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	var granted, revoked []string
+	for _, p := range permissions {
+		if !slices.Contains(r.Permissions, p) {
+			granted = append(granted, p)
+		}
+	}
+	for _, p := range r.Permissions {
+		if !slices.Contains(permissions, p) {
+			revoked = append(revoked, p)
+		}
+	}
+
+	if err := s.authz.GrantRolePermissions(ctx, tenantID, roleID, granted); err != nil {
+		s.recordError(span, "failed to grant role permissions", err, "tenant_id", tenantID, "role_id", roleID, "permissions", granted)
+		return nil, fmt.Errorf("failed to grant role permissions: %w", err)
+	}
+	if err := s.authz.RevokeRolePermissions(ctx, tenantID, roleID, revoked); err != nil {
+		s.recordError(span, "failed to revoke role permissions", err, "tenant_id", tenantID, "role_id", roleID, "permissions", revoked)
+		return nil, fmt.Errorf("failed to revoke role permissions: %w", err)
+	}
+
+	r.Permissions = permissions
+	s.logger.Security().AdminAction(actor, "update_role", "role.Service.UpdateRole", tenantID+":"+roleID, authentication.PrincipalLabel(ctx))
+	return r, nil
+}
+
+// DeleteRole removes the role, its permissions and its assignments.
+func (s *Service) DeleteRole(ctx context.Context, tenantID, roleID string) error {
+	ctx, span := s.tracer.Start(ctx, "role.Service.DeleteRole")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	r, err := s.getRole(ctx, span, tenantID, roleID)
+	if err != nil {
+		return err
+	}
+
+	assignees, err := s.storage.ListRoleAssignees(ctx, roleID)
+	if err != nil {
+		s.recordError(span, "failed to list role assignees", err, "tenant_id", tenantID, "role_id", roleID)
+		return fmt.Errorf("failed to list role assignees: %w", err)
+	}
+
+	if err := s.storage.DeleteRole(ctx, tenantID, roleID); err != nil {
+		s.recordError(span, "failed to delete role", err, "tenant_id", tenantID, "role_id", roleID)
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	if err := s.authz.RevokeRolePermissions(ctx, tenantID, roleID, r.Permissions); err != nil {
+		s.recordError(span, "failed to revoke role permissions", err, "tenant_id", tenantID, "role_id", roleID, "permissions", r.Permissions)
+		return fmt.Errorf("failed to revoke role permissions: %w", err)
+	}
+	if err := s.authz.UnassignRoleUsers(ctx, roleID, assignees); err != nil {
+		s.recordError(span, "failed to unassign role", err, "tenant_id", tenantID, "role_id", roleID, "assignees", len(assignees))
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "delete_role", "role.Service.DeleteRole", tenantID+":"+roleID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+// AssignRole assigns the role to a user, who must already be a member of the tenant.
+func (s *Service) AssignRole(ctx context.Context, tenantID, roleID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "role.Service.AssignRole")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	if _, err := s.getRole(ctx, span, tenantID, roleID); err != nil {
+		return err
+	}
+
+	err := s.storage.AddRoleAssignment(ctx, tenantID, roleID, userID)
+	switch {
+	case errors.Is(err, storage.ErrDuplicateKey):
+		return ErrAlreadyAssigned
+	case errors.Is(err, storage.ErrForeignKeyViolation):
+		return ErrNotMember
+	case err != nil:
+		s.recordError(span, "failed to assign role", err, "tenant_id", tenantID, "role_id", roleID, "user_id", userID)
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	if err := s.authz.AssignRole(ctx, roleID, userID); err != nil {
+		s.recordError(span, "failed to assign role", err, "tenant_id", tenantID, "role_id", roleID, "user_id", userID)
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "assign_role", "role.Service.AssignRole", tenantID+":"+roleID+":"+userID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+func (s *Service) UnassignRole(ctx context.Context, tenantID, roleID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "role.Service.UnassignRole")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	err := s.storage.DeleteRoleAssignment(ctx, tenantID, roleID, userID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrAssignmentAbsent
+	case err != nil:
+		s.recordError(span, "failed to unassign role", err, "tenant_id", tenantID, "role_id", roleID, "user_id", userID)
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+
+	if err := s.authz.UnassignRole(ctx, roleID, userID); err != nil {
+		s.recordError(span, "failed to unassign role", err, "tenant_id", tenantID, "role_id", roleID, "user_id", userID)
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "unassign_role", "role.Service.UnassignRole", tenantID+":"+roleID+":"+userID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+func (s *Service) getRole(ctx context.Context, span trace.Span, tenantID, roleID string) (*types.Role, error) {
+	r, err := s.storage.GetRole(ctx, tenantID, roleID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		s.recordError(span, "failed to get role", err, "tenant_id", tenantID, "role_id", roleID)
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return r, nil
+}
+
+// normalize sorts the permissions and drops duplicates.
+func normalize(permissions []string) []string {
+	ps := slices.Clone(permissions)
+	slices.Sort(ps)
+	return slices.Compact(ps)
+}
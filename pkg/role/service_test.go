@@ -0,0 +1,306 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package role
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package role -destination ./mock_role.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package role -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package role -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package role -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+const (
+	tenantID = "tenant-123"
+	roleID   = "role-123"
+	userID   = "user-123"
+)
+
+// setupLoggerMock configures a MockLoggerInterface with AnyTimes() stubs for all
+// structured logging methods (w-suffix) and for the security logger.
+func setupLoggerMock(ctrl *gomock.Controller, mockLogger *MockLoggerInterface) *MockSecurityLoggerInterface {
+	mockSecurityLogger := NewMockSecurityLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Security().Return(mockSecurityLogger).AnyTimes()
+	mockSecurityLogger.EXPECT().AdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return mockSecurityLogger
+}
+
+func newTestService(t *testing.T, span string) (*Service, *MockStorageInterface, *MockAuthzInterface) {
+	ctrl := gomock.NewController(t)
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockAuthz := NewMockAuthzInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), span).Return(context.Background(), trace.SpanFromContext(context.Background()))
+
+	return NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger), mockStorage, mockAuthz
+}
+
+func TestService_CreateRole(t *testing.T) {
+	testCases := []struct {
+		name        string
+		permissions []string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			permissions: []string{"can_view", "can_edit", "can_view"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().CreateRole(gomock.Any(), &types.Role{
+					TenantID:    tenantID,
+					Name:        "auditor",
+					Permissions: []string{"can_edit", "can_view"},
+				}).Return(&types.Role{ID: roleID, TenantID: tenantID, Name: "auditor", Permissions: []string{"can_edit", "can_view"}}, nil)
+				mockAuthz.EXPECT().GrantRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_edit", "can_view"}).Return(nil)
+			},
+		},
+		{
+			name:        "duplicate name",
+			permissions: []string{"can_view"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(nil, storage.ErrDuplicateKey)
+			},
+			expectedErr: ErrRoleExists,
+		},
+		{
+			name:        "unknown tenant",
+			permissions: []string{"can_view"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(nil, storage.ErrForeignKeyViolation)
+			},
+			expectedErr: ErrTenantNotFound,
+		},
+		{
+			name:        "authorization error",
+			permissions: []string{"can_view"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(&types.Role{ID: roleID}, nil)
+				mockAuthz.EXPECT().GrantRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_view"}).Return(errors.New("fga error"))
+			},
+			expectedErr: errors.New("failed to grant role permissions"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage, mockAuthz := newTestService(t, "role.Service.CreateRole")
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			r, err := s.CreateRole(context.Background(), tenantID, "auditor", tc.permissions)
+
+			if tc.expectedErr != nil {
+				if err == nil {
+					t.Fatalf("expected error %v but got none", tc.expectedErr)
+				}
+				if (tc.expectedErr == ErrRoleExists || tc.expectedErr == ErrTenantNotFound) && !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.ID != roleID {
+				t.Errorf("expected role %s, got %s", roleID, r.ID)
+			}
+		})
+	}
+}
+
+func TestService_UpdateRole(t *testing.T) {
+	existing := &types.Role{ID: roleID, TenantID: tenantID, Name: "auditor", Permissions: []string{"can_edit", "can_view"}}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name: "grants added and revokes removed permissions",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(&types.Role{
+					ID: existing.ID, TenantID: existing.TenantID, Name: existing.Name, Permissions: slices.Clone(existing.Permissions),
+				}, nil)
+				mockStorage.EXPECT().UpdateRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_delete", "can_view"}).Return(nil)
+				mockAuthz.EXPECT().GrantRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_delete"}).Return(nil)
+				mockAuthz.EXPECT().RevokeRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_edit"}).Return(nil)
+			},
+		},
+		{
+			name: "role not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrRoleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage, mockAuthz := newTestService(t, "role.Service.UpdateRole")
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			r, err := s.UpdateRole(context.Background(), tenantID, roleID, []string{"can_view", "can_delete"})
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(r.Permissions, []string{"can_delete", "can_view"}) {
+				t.Errorf("unexpected permissions %v", r.Permissions)
+			}
+		})
+	}
+}
+
+func TestService_DeleteRole(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(&types.Role{ID: roleID, Permissions: []string{"can_view"}}, nil)
+				mockStorage.EXPECT().ListRoleAssignees(gomock.Any(), roleID).Return([]string{userID}, nil)
+				mockStorage.EXPECT().DeleteRole(gomock.Any(), tenantID, roleID).Return(nil)
+				mockAuthz.EXPECT().RevokeRolePermissions(gomock.Any(), tenantID, roleID, []string{"can_view"}).Return(nil)
+				mockAuthz.EXPECT().UnassignRoleUsers(gomock.Any(), roleID, []string{userID}).Return(nil)
+			},
+		},
+		{
+			name: "role not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrRoleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage, mockAuthz := newTestService(t, "role.Service.DeleteRole")
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			err := s.DeleteRole(context.Background(), tenantID, roleID)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_AssignRole(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(&types.Role{ID: roleID}, nil)
+				mockStorage.EXPECT().AddRoleAssignment(gomock.Any(), tenantID, roleID, userID).Return(nil)
+				mockAuthz.EXPECT().AssignRole(gomock.Any(), roleID, userID).Return(nil)
+			},
+		},
+		{
+			name: "user is not a member",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(&types.Role{ID: roleID}, nil)
+				mockStorage.EXPECT().AddRoleAssignment(gomock.Any(), tenantID, roleID, userID).Return(storage.ErrForeignKeyViolation)
+			},
+			expectedErr: ErrNotMember,
+		},
+		{
+			name: "already assigned",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(&types.Role{ID: roleID}, nil)
+				mockStorage.EXPECT().AddRoleAssignment(gomock.Any(), tenantID, roleID, userID).Return(storage.ErrDuplicateKey)
+			},
+			expectedErr: ErrAlreadyAssigned,
+		},
+		{
+			name: "role not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetRole(gomock.Any(), tenantID, roleID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrRoleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage, mockAuthz := newTestService(t, "role.Service.AssignRole")
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			err := s.AssignRole(context.Background(), tenantID, roleID, userID)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_UnassignRole(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteRoleAssignment(gomock.Any(), tenantID, roleID, userID).Return(nil)
+				mockAuthz.EXPECT().UnassignRole(gomock.Any(), roleID, userID).Return(nil)
+			},
+		},
+		{
+			name: "not assigned",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteRoleAssignment(gomock.Any(), tenantID, roleID, userID).Return(storage.ErrNotFound)
+			},
+			expectedErr: ErrAssignmentAbsent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mockStorage, mockAuthz := newTestService(t, "role.Service.UnassignRole")
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			err := s.UnassignRole(context.Background(), tenantID, roleID, userID)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ type BuildInfo struct {
 	Version    string `json:"version"`
 	CommitHash string `json:"commit_hash"`
 	Name       string `json:"name"`
+	BuildDate  string `json:"build_date"`
+	GoVersion  string `json:"go_version"`
 }
 
 func buildInfo() *BuildInfo {
@@ -22,20 +24,14 @@ func buildInfo() *BuildInfo {
 		return nil
 	}
 
+	v := version.Get()
+
 	buildInfo := new(BuildInfo)
 	buildInfo.Name = info.Main.Path
-	buildInfo.Version = version.Version
-	buildInfo.CommitHash = gitRevision(info.Settings)
+	buildInfo.Version = v.Version
+	buildInfo.CommitHash = v.Commit
+	buildInfo.BuildDate = v.BuildDate
+	buildInfo.GoVersion = v.GoVersion
 
 	return buildInfo
 }
-
-func gitRevision(settings []debug.BuildSetting) string {
-	for _, setting := range settings {
-		if setting.Key == "vcs.revision" {
-			return setting.Value
-		}
-	}
-
-	return "n/a"
-}
@@ -14,14 +14,21 @@ import (
 	"github.com/canonical/tenant-service/internal/tracing"
 )
 
-const okValue = "ok"
+const (
+	okValue       = "ok"
+	degradedValue = "degraded"
+	downValue     = "down"
+)
 
 type Status struct {
-	Status    string     `json:"status"`
-	BuildInfo *BuildInfo `json:"buildInfo"`
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+	BuildInfo    *BuildInfo        `json:"buildInfo"`
 }
 
 type API struct {
+	dependencies map[string]DependencyInterface
+
 	tracer  tracing.TracingInterface
 	monitor monitoring.MonitorInterface
 	logger  logging.LoggerInterface
@@ -41,6 +48,18 @@ func (a *API) alive(w http.ResponseWriter, r *http.Request) {
 		Status: okValue,
 	}
 
+	// a dependency being down degrades the service without failing liveness probes
+	if len(a.dependencies) > 0 {
+		rr.Dependencies = make(map[string]string, len(a.dependencies))
+	}
+	for name, d := range a.dependencies {
+		rr.Dependencies[name] = okValue
+		if !d.Available() {
+			rr.Dependencies[name] = downValue
+			rr.Status = degradedValue
+		}
+	}
+
 	_, span := a.tracer.Start(r.Context(), "buildInfo")
 
 	if buildInfo := buildInfo(); buildInfo != nil {
@@ -66,9 +85,11 @@ func (a *API) version(w http.ResponseWriter, r *http.Request) {
 
 }
 
-func NewAPI(tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *API {
+func NewAPI(dependencies map[string]DependencyInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *API {
 	a := new(API)
 
+	a.dependencies = dependencies
+
 	a.tracer = tracer
 	a.monitor = monitor
 	a.logger = logger
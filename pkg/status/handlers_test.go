@@ -10,16 +10,20 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/version"
 )
 
 //go:generate mockgen -build_flags=--mod=mod -package status -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
 //go:generate mockgen -build_flags=--mod=mod -package status -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
 //go:generate mockgen -build_flags=--mod=mod -package status -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package status -destination ./mock_status.go -source=./interfaces.go
 
 func TestAliveOK(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -35,7 +39,7 @@ func TestAliveOK(t *testing.T) {
 	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Times(1).Return(context.TODO(), trace.SpanFromContext(req.Context()))
 
 	mux := chi.NewMux()
-	NewAPI(mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
+	NewAPI(nil, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
 
 	mux.ServeHTTP(w, req)
 	res := w.Result()
@@ -52,3 +56,70 @@ func TestAliveOK(t *testing.T) {
 		t.Fatalf("expected status to be ok got %v", receivedStatus.Status)
 	}
 }
+
+func TestAliveDegraded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockOpenFGA := NewMockDependencyInterface(ctrl)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/status", nil)
+	w := httptest.NewRecorder()
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Times(1).Return(context.TODO(), trace.SpanFromContext(req.Context()))
+	mockOpenFGA.EXPECT().Available().Return(false)
+
+	mux := chi.NewMux()
+	NewAPI(map[string]DependencyInterface{"openfga": mockOpenFGA}, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
+
+	mux.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %v got %v", http.StatusOK, res.StatusCode)
+	}
+
+	receivedStatus := new(Status)
+	if err := json.NewDecoder(res.Body).Decode(receivedStatus); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	if receivedStatus.Status != "degraded" {
+		t.Fatalf("expected status to be degraded got %v", receivedStatus.Status)
+	}
+	if receivedStatus.Dependencies["openfga"] != "down" {
+		t.Fatalf("expected openfga to be down got %v", receivedStatus.Dependencies["openfga"])
+	}
+}
+
+func TestVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	defer func(commit, buildDate string) { version.Commit, version.BuildDate = commit, buildDate }(version.Commit, version.BuildDate)
+	version.Commit, version.BuildDate = "0123abcd", "2026-01-02T03:04:05Z"
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v0/version", nil)
+	w := httptest.NewRecorder()
+
+	mux := chi.NewMux()
+	NewAPI(nil, NewMockTracingInterface(ctrl), NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl)).RegisterEndpoints(mux)
+
+	mux.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	info := new(BuildInfo)
+	if err := json.NewDecoder(res.Body).Decode(info); err != nil {
+		t.Fatalf("expected error to be nil got %v", err)
+	}
+	if info.Version != version.Version || info.CommitHash != "0123abcd" || info.BuildDate != "2026-01-02T03:04:05Z" {
+		t.Fatalf("expected the injected build metadata got %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Fatalf("expected go version %s got %s", runtime.Version(), info.GoVersion)
+	}
+}
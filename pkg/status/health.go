@@ -0,0 +1,95 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+// HealthChecker drives the gRPC health service from the readiness of the
+// dependencies. Every dependency is reported as a service of its own, and
+// service as serving only while they all are. The server as a whole, the
+// empty service name, is left serving as the status endpoint is, so liveness
+// probes do not restart the pods of an unavailable dependency.
+type HealthChecker struct {
+	service   string
+	readiness *ReadinessChecker
+
+	server HealthServerInterface
+	logger logging.LoggerInterface
+
+	// available holds the last status of the dependencies, to log the changes
+	available map[string]bool
+}
+
+// Check updates the serving statuses from the readiness of the dependencies.
+func (c *HealthChecker) Check(ctx context.Context) {
+	r := c.readiness.Check(ctx)
+
+	names := make([]string, 0, len(r.Dependencies))
+	for name := range r.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		d := r.Dependencies[name]
+		available := d.Status == okValue
+		if previous, ok := c.available[name]; !ok || previous != available {
+			if available {
+				c.logger.Infow("dependency is available", "dependency", name)
+			} else {
+				c.logger.Warnw("dependency is unavailable", "dependency", name, "error", d.Error)
+			}
+		}
+		c.available[name] = available
+
+		c.server.SetServingStatus(name, servingStatus(available))
+	}
+
+	c.server.SetServingStatus(c.service, servingStatus(r.Ready()))
+}
+
+// Run checks on start, then every interval until ctx is done.
+func (c *HealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func servingStatus(serving bool) healthpb.HealthCheckResponse_ServingStatus {
+	if serving {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// NewHealthChecker returns a checker reporting service and the dependencies
+// checked by readiness, by their names, to server.
+func NewHealthChecker(service string, readiness *ReadinessChecker, server HealthServerInterface, logger logging.LoggerInterface) *HealthChecker {
+	c := new(HealthChecker)
+
+	c.service = service
+	c.readiness = readiness
+	c.server = server
+	c.logger = logger
+	c.available = make(map[string]bool)
+
+	return c
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker(t *testing.T) {
+	const service = "tenant.v0.TenantService"
+
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name         string
+		dependencies []Dependency
+		expected     map[string]healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:         "All dependencies available",
+			dependencies: []Dependency{{Name: "postgres", Probe: up, Timeout: time.Second}, {Name: "kratos", Probe: up, Timeout: time.Second}},
+			expected: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":         healthpb.HealthCheckResponse_SERVING,
+				service:    healthpb.HealthCheckResponse_SERVING,
+				"postgres": healthpb.HealthCheckResponse_SERVING,
+				"kratos":   healthpb.HealthCheckResponse_SERVING,
+			},
+		},
+		{
+			name:         "Dependency unavailable",
+			dependencies: []Dependency{{Name: "postgres", Probe: up, Timeout: time.Second}, {Name: "kratos", Probe: down, Timeout: time.Second}},
+			expected: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":         healthpb.HealthCheckResponse_SERVING,
+				service:    healthpb.HealthCheckResponse_NOT_SERVING,
+				"postgres": healthpb.HealthCheckResponse_SERVING,
+				"kratos":   healthpb.HealthCheckResponse_NOT_SERVING,
+			},
+		},
+		{
+			name:         "Probe timed out",
+			dependencies: []Dependency{{Name: "openfga", Probe: func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }, Timeout: 10 * time.Millisecond}},
+			expected: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				service:   healthpb.HealthCheckResponse_NOT_SERVING,
+				"openfga": healthpb.HealthCheckResponse_NOT_SERVING,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Infow(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
+
+			server := health.NewServer()
+			NewHealthChecker(service, NewReadinessChecker(tt.dependencies, 0), server, mockLogger).Check(context.Background())
+
+			for name, expected := range tt.expected {
+				resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", name, err)
+				}
+				if resp.Status != expected {
+					t.Errorf("Check(%q) = %s, want %s", name, resp.Status, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthCheckerLogsChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	available := true
+	probe := func(context.Context) error {
+		if available {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	mockLogger := NewMockLoggerInterface(ctrl)
+	gomock.InOrder(
+		mockLogger.EXPECT().Infow("dependency is available", "dependency", "postgres"),
+		mockLogger.EXPECT().Warnw("dependency is unavailable", "dependency", "postgres", "error", "connection refused"),
+	)
+
+	readiness := NewReadinessChecker([]Dependency{{Name: "postgres", Probe: probe, Timeout: time.Second}}, 0)
+	checker := NewHealthChecker("tenant.v0.TenantService", readiness, health.NewServer(), mockLogger)
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+	available = false
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+// ModelStorageInterface reads the OpenFGA models recorded by create-fga-model.
+// It is a subset of the internal/storage interface.
+type ModelStorageInterface interface {
+	GetLatestAuthorizationModel(ctx context.Context, storeID string) (*types.AuthorizationModel, error)
+}
+
+// DependencyInterface reports whether a dependency is currently usable.
+type DependencyInterface interface {
+	Available() bool
+}
+
+// ModelValidationInterface reports the state of the validation of the
+// authorization model at startup.
+type ModelValidationInterface interface {
+	State() string
+}
+
+// HealthServerInterface is the gRPC health server the HealthChecker reports
+// to, as served by google.golang.org/grpc/health.
+type HealthServerInterface interface {
+	SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus)
+}
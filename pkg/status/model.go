@@ -0,0 +1,111 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// ModelConfig is the OpenFGA store and model enforced by the running instance,
+// StoreID is empty when authorization is disabled.
+type ModelConfig struct {
+	StoreID string
+	ModelID string
+	// Validation reports whether the model is enforced, nil leaves it out
+	Validation ModelValidationInterface
+}
+
+// ModelStatus compares the model enforced by the instance with the last model
+// create-fga-model recorded for its store.
+type ModelStatus struct {
+	StoreID       string     `json:"store_id"`
+	ModelID       string     `json:"model_id"`
+	LatestModelID string     `json:"latest_model_id"`
+	SchemaVersion string     `json:"schema_version"`
+	WrittenAt     *time.Time `json:"written_at"`
+	UpToDate      bool       `json:"up_to_date"`
+	// Validation is validating, valid, or degraded when served without authorization
+	Validation string `json:"validation,omitempty"`
+}
+
+type ModelAPI struct {
+	config  ModelConfig
+	storage ModelStorageInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (a *ModelAPI) RegisterEndpoints(mux *chi.Mux) {
+	mux.Get("/api/v0/status/authorization-model", a.model)
+}
+
+func (a *ModelAPI) model(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "status.ModelAPI.model")
+	defer span.End()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if a.config.StoreID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  http.StatusNotFound,
+			"message": "authorization is disabled",
+		})
+		return
+	}
+
+	rr := ModelStatus{
+		StoreID: a.config.StoreID,
+		ModelID: a.config.ModelID,
+	}
+	if a.config.Validation != nil {
+		rr.Validation = a.config.Validation.State()
+	}
+
+	m, err := a.storage.GetLatestAuthorizationModel(ctx, a.config.StoreID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		// the model was written without recording it, nothing to compare against
+	case err != nil:
+		a.logger.Errorw("failed to get authorization model", "store_id", a.config.StoreID, "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  http.StatusInternalServerError,
+			"message": "failed to get authorization model",
+		})
+		return
+	default:
+		rr.LatestModelID = m.ModelID
+		rr.SchemaVersion = m.SchemaVersion
+		rr.WrittenAt = &m.CreatedAt
+		rr.UpToDate = m.ModelID == a.config.ModelID
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rr)
+}
+
+func NewModelAPI(config ModelConfig, storage ModelStorageInterface, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *ModelAPI {
+	a := new(ModelAPI)
+
+	a.config = config
+	a.storage = storage
+	a.tracer = tracer
+	a.monitor = monitor
+	a.logger = logger
+
+	return a
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+)
+
+type modelState string
+
+func (s modelState) State() string {
+	return string(s)
+}
+
+func TestModelAPI(t *testing.T) {
+	writtenAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		config         ModelConfig
+		setupMocks     func(*MockModelStorageInterface)
+		expectedStatus int
+		expected       ModelStatus
+	}{
+		{
+			name:           "Authorization disabled",
+			setupMocks:     func(*MockModelStorageInterface) {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Latest model enforced",
+			config: ModelConfig{StoreID: "store", ModelID: "model-2"},
+			setupMocks: func(m *MockModelStorageInterface) {
+				m.EXPECT().GetLatestAuthorizationModel(gomock.Any(), "store").Return(&types.AuthorizationModel{
+					ModelID: "model-2", StoreID: "store", SchemaVersion: "1.1", CreatedAt: writtenAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expected: ModelStatus{
+				StoreID: "store", ModelID: "model-2", LatestModelID: "model-2", SchemaVersion: "1.1", WrittenAt: &writtenAt, UpToDate: true,
+			},
+		},
+		{
+			name:   "Outdated model enforced",
+			config: ModelConfig{StoreID: "store", ModelID: "model-1"},
+			setupMocks: func(m *MockModelStorageInterface) {
+				m.EXPECT().GetLatestAuthorizationModel(gomock.Any(), "store").Return(&types.AuthorizationModel{
+					ModelID: "model-2", StoreID: "store", SchemaVersion: "1.1", CreatedAt: writtenAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expected: ModelStatus{
+				StoreID: "store", ModelID: "model-1", LatestModelID: "model-2", SchemaVersion: "1.1", WrittenAt: &writtenAt,
+			},
+		},
+		{
+			name:   "No model recorded",
+			config: ModelConfig{StoreID: "store", ModelID: "model-1"},
+			setupMocks: func(m *MockModelStorageInterface) {
+				m.EXPECT().GetLatestAuthorizationModel(gomock.Any(), "store").Return(nil, storage.ErrNotFound)
+			},
+			expectedStatus: http.StatusOK,
+			expected:       ModelStatus{StoreID: "store", ModelID: "model-1"},
+		},
+		{
+			name:   "Serving without authorization",
+			config: ModelConfig{StoreID: "store", ModelID: "model-1", Validation: modelState("degraded")},
+			setupMocks: func(m *MockModelStorageInterface) {
+				m.EXPECT().GetLatestAuthorizationModel(gomock.Any(), "store").Return(nil, storage.ErrNotFound)
+			},
+			expectedStatus: http.StatusOK,
+			expected:       ModelStatus{StoreID: "store", ModelID: "model-1", Validation: "degraded"},
+		},
+		{
+			name:   "Storage error",
+			config: ModelConfig{StoreID: "store", ModelID: "model-1"},
+			setupMocks: func(m *MockModelStorageInterface) {
+				m.EXPECT().GetLatestAuthorizationModel(gomock.Any(), "store").Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockStorage := NewMockModelStorageInterface(ctrl)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "status.ModelAPI.model").Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+			mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+			test.setupMocks(mockStorage)
+
+			mux := chi.NewMux()
+			NewModelAPI(test.config, mockStorage, mockTracer, mockMonitor, mockLogger).RegisterEndpoints(mux)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v0/status/authorization-model", nil))
+
+			if w.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, w.Code)
+			}
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+
+			received := ModelStatus{}
+			if err := json.NewDecoder(w.Body).Decode(&received); err != nil {
+				t.Fatalf("expected error to be nil got %v", err)
+			}
+			if received.ModelID != test.expected.ModelID || received.LatestModelID != test.expected.LatestModelID ||
+				received.SchemaVersion != test.expected.SchemaVersion || received.UpToDate != test.expected.UpToDate ||
+				received.Validation != test.expected.Validation {
+				t.Fatalf("expected %+v, got %+v", test.expected, received)
+			}
+			if (received.WrittenAt == nil) != (test.expected.WrittenAt == nil) ||
+				(received.WrittenAt != nil && !received.WrittenAt.Equal(*test.expected.WrittenAt)) {
+				t.Fatalf("expected written at %v, got %v", test.expected.WrittenAt, received.WrittenAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+)
+
+// Probe checks a dependency, it fails when the dependency is not usable.
+type Probe func(context.Context) error
+
+// Dependency is a dependency the instance needs to serve, its probe is given
+// Timeout.
+type Dependency struct {
+	Name    string
+	Probe   Probe
+	Timeout time.Duration
+}
+
+// DependencyStatus is the outcome of the probe of a dependency.
+type DependencyStatus struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Readiness is the outcome of the probes of all the dependencies, Status is
+// ok when they all succeeded, down otherwise.
+type Readiness struct {
+	Status       string                      `json:"status"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Ready tells whether every dependency is usable.
+func (r *Readiness) Ready() bool {
+	return r.Status == okValue
+}
+
+// ReadinessChecker probes the dependencies, all at once. The outcome is
+// cached for ttl, so that frequent probes of several replicas do not load
+// the dependencies.
+type ReadinessChecker struct {
+	dependencies []Dependency
+	ttl          time.Duration
+
+	// mu is held during the probes, the concurrent checks wait for them
+	mu   sync.Mutex
+	last *Readiness
+
+	now func() time.Time
+}
+
+// Check returns the readiness cached less than ttl ago, or probes the
+// dependencies again.
+func (c *ReadinessChecker) Check(ctx context.Context) *Readiness {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && c.now().Sub(c.last.CheckedAt) < c.ttl {
+		return c.last
+	}
+
+	r := &Readiness{
+		Status:       okValue,
+		CheckedAt:    c.now(),
+		Dependencies: make(map[string]DependencyStatus, len(c.dependencies)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, d := range c.dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, d.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := d.Probe(probeCtx)
+			s := DependencyStatus{Status: okValue, Latency: time.Since(start).Round(time.Millisecond).String()}
+			if err != nil {
+				s.Status, s.Error = downValue, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			r.Dependencies[d.Name] = s
+			if err != nil {
+				r.Status = downValue
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.last = r
+	return r
+}
+
+// NewReadinessChecker returns a checker of dependencies caching the outcome
+// for ttl, 0 probes them on every check.
+func NewReadinessChecker(dependencies []Dependency, ttl time.Duration) *ReadinessChecker {
+	c := new(ReadinessChecker)
+
+	c.dependencies = dependencies
+	c.ttl = ttl
+	c.now = time.Now
+
+	return c
+}
+
+// ReadyAPI serves the readiness of the instance, unlike the status endpoint
+// it fails while a dependency is unavailable.
+type ReadyAPI struct {
+	checker *ReadinessChecker
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func (a *ReadyAPI) RegisterEndpoints(mux *chi.Mux) {
+	mux.Get("/api/v0/status/ready", a.ready)
+}
+
+func (a *ReadyAPI) ready(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "status.ReadyAPI.ready")
+	defer span.End()
+
+	rr := a.checker.Check(ctx)
+
+	w.Header().Set("Content-Type", "application/json")
+	if rr.Ready() {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(rr)
+}
+
+func NewReadyAPI(checker *ReadinessChecker, tracer tracing.TracingInterface, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *ReadyAPI {
+	a := new(ReadyAPI)
+
+	a.checker = checker
+	a.tracer = tracer
+	a.monitor = monitor
+	a.logger = logger
+
+	return a
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadyAPI(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+
+	tests := []struct {
+		name           string
+		dependencies   []Dependency
+		expectedStatus int
+		expected       map[string]string
+	}{
+		{
+			name:           "Ready",
+			dependencies:   []Dependency{{Name: "postgres", Probe: up, Timeout: time.Second}, {Name: "kratos", Probe: up, Timeout: time.Second}},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"postgres": okValue, "kratos": okValue},
+		},
+		{
+			name:           "Dependency unavailable",
+			dependencies:   []Dependency{{Name: "postgres", Probe: up, Timeout: time.Second}, {Name: "kratos", Probe: down, Timeout: time.Second}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expected:       map[string]string{"postgres": okValue, "kratos": downValue},
+		},
+		{
+			name:           "Dependency timed out",
+			dependencies:   []Dependency{{Name: "openfga", Probe: slow, Timeout: 10 * time.Millisecond}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expected:       map[string]string{"openfga": downValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockTracer.EXPECT().Start(gomock.Any(), "status.ReadyAPI.ready").Return(context.TODO(), trace.SpanFromContext(context.TODO()))
+
+			mux := chi.NewMux()
+			NewReadyAPI(NewReadinessChecker(tt.dependencies, time.Minute), mockTracer, NewMockMonitorInterface(ctrl), NewMockLoggerInterface(ctrl)).RegisterEndpoints(mux)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v0/status/ready", nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			received := new(Readiness)
+			if err := json.Unmarshal(w.Body.Bytes(), received); err != nil {
+				t.Fatalf("expected error to be nil got %v", err)
+			}
+			for name, expected := range tt.expected {
+				d, ok := received.Dependencies[name]
+				if !ok || d.Status != expected {
+					t.Errorf("expected %s to be %s, got %+v", name, expected, d)
+				}
+				if expected == downValue && d.Error == "" {
+					t.Errorf("expected the error of %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestReadinessCheckerCache(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	probes := 0
+	c := NewReadinessChecker([]Dependency{{Name: "postgres", Probe: func(context.Context) error { probes++; return nil }, Timeout: time.Second}}, 5*time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Check(context.Background())
+	now = now.Add(4 * time.Second)
+	c.Check(context.Background())
+	if probes != 1 {
+		t.Fatalf("expected the cached readiness, probed %d times", probes)
+	}
+
+	now = now.Add(time.Second)
+	if r := c.Check(context.Background()); !r.CheckedAt.Equal(now) {
+		t.Errorf("expected a check at %v, got %v", now, r.CheckedAt)
+	}
+	if probes != 2 {
+		t.Errorf("expected the dependencies probed again, probed %d times", probes)
+	}
+}
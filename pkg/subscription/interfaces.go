@@ -0,0 +1,44 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package subscription
+
+import (
+	"context"
+
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/events"
+)
+
+// StorageInterface defines the storage operations required by the
+// subscription package. It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CreateWebhookSubscription(ctx context.Context, sub *types.WebhookSubscription) (*types.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id string) (*types.WebhookSubscription, error)
+	ListWebhookSubscriptionsByTenantID(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error)
+	ListWebhookSubscriptionsForEvent(ctx context.Context, tenantID, eventType string) ([]*types.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, tenantID, id string) error
+	CreateWebhookDelivery(ctx context.Context, d *types.WebhookDelivery) (*types.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, id string) (*types.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, limit uint64) ([]*types.WebhookDelivery, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, id string, status types.WebhookDeliveryStatus, responseStatus int, lastError string) error
+	ReplayWebhookDelivery(ctx context.Context, id string) error
+}
+
+// QueueInterface defines the job queue operations required by the
+// subscription package, the deliveries are run as jobs.
+type QueueInterface interface {
+	Handle(kind string, h jobs.Handler)
+	Enqueue(ctx context.Context, kind string, payload any) error
+}
+
+// ServiceInterface defines the webhook subscription operations.
+type ServiceInterface interface {
+	CreateSubscription(ctx context.Context, tenantID, url string, eventTypes []string, secret string) (*types.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, subscriptionID string) error
+	ListDeliveries(ctx context.Context, tenantID, subscriptionID string) ([]*types.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, tenantID, subscriptionID, deliveryID string) (*types.WebhookDelivery, error)
+	Fanout(ctx context.Context, e *events.Event) error
+}
@@ -0,0 +1,365 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/http/outbound"
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/events"
+)
+
+const (
+	// DeliveryJob is the kind of the jobs delivering an event to a webhook
+	// subscription.
+	DeliveryJob = "webhook_delivery"
+	// MaxListedDeliveries is the number of latest deliveries listed for a
+	// subscription.
+	MaxListedDeliveries = 100
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrDeliveryNotFailed    = errors.New("only failed webhook deliveries can be replayed")
+	ErrTenantNotFound       = errors.New("tenant not found")
+	ErrURLNotPublic         = errors.New("webhook URL must resolve to public addresses")
+)
+
+type deliveryJob struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// Service manages the webhook subscriptions of the tenants and delivers them
+// the events of their tenant. Each delivery is recorded with the outcome of
+// its last attempt and run as a job, so that it is retried with the backoff
+// of the queue and can be replayed once the job gave up.
+type Service struct {
+	storage  StorageInterface
+	queue    QueueInterface
+	cipher   encryption.CipherInterface
+	client   *http.Client
+	resolver outbound.ResolverInterface
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// CreateSubscription registers url to receive the events of the tenant of
+// the given types, all of them when eventTypes is empty. The deliveries are
+// signed with secret, which is stored encrypted and never returned. The host
+// of url must only resolve to public addresses, the client of the service
+// checks them again when delivering.
+func (s *Service) CreateSubscription(ctx context.Context, tenantID, url string, eventTypes []string, secret string) (*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.CreateSubscription")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	if err := outbound.CheckPublicURL(ctx, s.resolver, url); err != nil {
+		s.logger.Security().AuthzFailure(actor, "subscription.Service.CreateSubscription", authentication.PrincipalLabel(ctx), logging.WithLabel("reason", "url_not_public"))
+		return nil, fmt.Errorf("%w: %v", ErrURLNotPublic, err)
+	}
+
+	sealed, err := s.cipher.Encrypt(ctx, []byte(secret), secretAAD(tenantID))
+	if err != nil {
+		s.recordError(span, "failed to encrypt webhook secret", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	created, err := s.storage.CreateWebhookSubscription(ctx, &types.WebhookSubscription{
+		TenantID:   tenantID,
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     sealed,
+		CreatedBy:  actor,
+	})
+	switch {
+	case errors.Is(err, storage.ErrForeignKeyViolation):
+		return nil, ErrTenantNotFound
+	case err != nil:
+		s.recordError(span, "failed to create webhook subscription", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	s.logger.Infow("created webhook subscription", "tenant_id", tenantID, "subscription_id", created.ID, "url", url, "event_types", eventTypes)
+	s.logger.Security().AdminAction(actor, "create_webhook_subscription", "subscription.Service.CreateSubscription", tenantID+":"+created.ID, authentication.PrincipalLabel(ctx))
+	return created, nil
+}
+
+// ListSubscriptions lists the webhook subscriptions of a tenant.
+func (s *Service) ListSubscriptions(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.ListSubscriptions")
+	defer span.End()
+
+	subs, err := s.storage.ListWebhookSubscriptionsByTenantID(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to list webhook subscriptions", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+// DeleteSubscription removes a webhook subscription of the tenant with its
+// delivery history, the pending deliveries are dropped.
+func (s *Service) DeleteSubscription(ctx context.Context, tenantID, subscriptionID string) error {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.DeleteSubscription")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	err := s.storage.DeleteWebhookSubscription(ctx, tenantID, subscriptionID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrSubscriptionNotFound
+	case err != nil:
+		s.recordError(span, "failed to delete webhook subscription", err, "tenant_id", tenantID, "subscription_id", subscriptionID)
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "delete_webhook_subscription", "subscription.Service.DeleteSubscription", tenantID+":"+subscriptionID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+// ListDeliveries lists the latest deliveries of a webhook subscription of
+// the tenant, newest first.
+func (s *Service) ListDeliveries(ctx context.Context, tenantID, subscriptionID string) ([]*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.ListDeliveries")
+	defer span.End()
+
+	if _, err := s.getSubscription(ctx, span, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.storage.ListWebhookDeliveries(ctx, subscriptionID, MaxListedDeliveries)
+	if err != nil {
+		s.recordError(span, "failed to list webhook deliveries", err, "tenant_id", tenantID, "subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ReplayDelivery sends a failed delivery again, with the event it was
+// created with.
+func (s *Service) ReplayDelivery(ctx context.Context, tenantID, subscriptionID, deliveryID string) (*types.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.ReplayDelivery")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	if _, err := s.getSubscription(ctx, span, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	d, err := s.storage.GetWebhookDelivery(ctx, deliveryID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, ErrDeliveryNotFound
+	case err != nil:
+		s.recordError(span, "failed to get webhook delivery", err, "tenant_id", tenantID, "delivery_id", deliveryID)
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if d.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	// a concurrent replay may have won the race since the read
+	err = s.storage.ReplayWebhookDelivery(ctx, deliveryID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, ErrDeliveryNotFailed
+	case err != nil:
+		s.recordError(span, "failed to replay webhook delivery", err, "tenant_id", tenantID, "delivery_id", deliveryID)
+		return nil, fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+
+	if err := s.queue.Enqueue(ctx, DeliveryJob, deliveryJob{DeliveryID: deliveryID}); err != nil {
+		s.recordError(span, "failed to enqueue webhook delivery", err, "tenant_id", tenantID, "delivery_id", deliveryID)
+		return nil, fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+	}
+
+	s.logger.Security().AdminAction(actor, "replay_webhook_delivery", "subscription.Service.ReplayDelivery", tenantID+":"+subscriptionID+":"+deliveryID, authentication.PrincipalLabel(ctx))
+	d.Status = types.WebhookDeliveryPending
+	return d, nil
+}
+
+func (s *Service) getSubscription(ctx context.Context, span trace.Span, tenantID, subscriptionID string) (*types.WebhookSubscription, error) {
+	sub, err := s.storage.GetWebhookSubscription(ctx, subscriptionID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, ErrSubscriptionNotFound
+	case err != nil:
+		s.recordError(span, "failed to get webhook subscription", err, "tenant_id", tenantID, "subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	// the subscriptions of other tenants are not disclosed
+	if sub.TenantID != tenantID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// Fanout records a delivery of e to every subscription of its tenant
+// receiving its type and enqueues them, in the transaction of ctx. The
+// subject of the tenant events is the tenant ID.
+func (s *Service) Fanout(ctx context.Context, e *events.Event) error {
+	ctx, span := s.tracer.Start(ctx, "subscription.Service.Fanout")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event.id", e.ID), attribute.String("event.type", e.Type))
+
+	subs, err := s.storage.ListWebhookSubscriptionsForEvent(ctx, e.Subject, e.Type)
+	if err != nil {
+		s.recordError(span, "failed to list webhook subscriptions", err, "tenant_id", e.Subject, "event_type", e.Type)
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", e.Type, err)
+	}
+
+	for _, sub := range subs {
+		d, err := s.storage.CreateWebhookDelivery(ctx, &types.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+		})
+		if err != nil {
+			s.recordError(span, "failed to create webhook delivery", err, "subscription_id", sub.ID, "event_id", e.ID)
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+		if err := s.queue.Enqueue(ctx, DeliveryJob, deliveryJob{DeliveryID: d.ID}); err != nil {
+			s.recordError(span, "failed to enqueue webhook delivery", err, "subscription_id", sub.ID, "event_id", e.ID)
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// deliver is the handler of the delivery jobs. The outcome of every attempt
+// is recorded, failed ones included, so that the job is retried with Retry.
+func (s *Service) deliver(ctx context.Context, payload []byte) error {
+	var j deliveryJob
+	if err := json.Unmarshal(payload, &j); err != nil || j.DeliveryID == "" {
+		// retrying cannot fix the payload
+		s.logger.Errorw("dropping malformed webhook delivery", "error", err)
+		return nil
+	}
+
+	d, err := s.storage.GetWebhookDelivery(ctx, j.DeliveryID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// the subscription was deleted with its deliveries
+		s.logger.Debugw("dropping webhook delivery of a deleted subscription", "delivery_id", j.DeliveryID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if d.Status == types.WebhookDeliverySucceeded {
+		return nil
+	}
+
+	sub, err := s.storage.GetWebhookSubscription(ctx, d.SubscriptionID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	secret, err := s.cipher.Decrypt(ctx, sub.Secret, secretAAD(sub.TenantID))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	var e events.Event
+	if err := json.Unmarshal(d.Payload, &e); err != nil {
+		s.logger.Errorw("dropping webhook delivery with a malformed event", "delivery_id", d.ID, "error", err)
+		return nil
+	}
+
+	code, sendErr := events.NewHTTPSink(sub.URL, string(secret), s.client).Send(ctx, &e)
+	if sendErr == nil {
+		if err := s.storage.RecordWebhookDeliveryAttempt(ctx, d.ID, types.WebhookDeliverySucceeded, code, ""); err != nil {
+			return fmt.Errorf("failed to record webhook delivery: %w", err)
+		}
+		s.logger.Debugw("webhook delivered", "delivery_id", d.ID, "subscription_id", sub.ID, "event_id", e.ID, "status", code)
+		s.countDelivery("webhook_delivered")
+		return nil
+	}
+
+	if err := s.storage.RecordWebhookDeliveryAttempt(ctx, d.ID, types.WebhookDeliveryFailed, code, sendErr.Error()); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	s.countDelivery("webhook_delivery_failed")
+	return jobs.Retry(fmt.Errorf("failed to deliver %s event to subscription %s: %w", e.Type, sub.ID, sendErr))
+}
+
+func (s *Service) countDelivery(operation string) {
+	if err := s.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		s.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// secretAAD binds the secret of a subscription to its tenant.
+func secretAAD(tenantID string) []byte {
+	return []byte("webhook_subscription:" + tenantID)
+}
+
+// NewService returns the service of the webhook subscriptions, their
+// deliveries are registered with queue and posted with client, which must
+// only dial public addresses, see outbound.NewPublicClient.
+func NewService(
+	storage StorageInterface,
+	queue QueueInterface,
+	cipher encryption.CipherInterface,
+	client *http.Client,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	s := new(Service)
+	s.storage = storage
+	s.queue = queue
+	s.cipher = cipher
+	s.client = client
+	s.resolver = net.DefaultResolver
+	s.tracer = tracer
+	s.monitor = monitor
+	s.logger = logger
+
+	queue.Handle(DeliveryJob, s.deliver)
+	return s
+}
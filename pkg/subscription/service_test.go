@@ -0,0 +1,343 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/encryption"
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/events"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package subscription -destination ./mock_subscription.go -source=./interfaces.go
+
+const (
+	tenantID       = "tenant-123"
+	subscriptionID = "subscription-123"
+	deliveryID     = "delivery-123"
+	userID         = "user-123"
+)
+
+func newTestService(ctrl *gomock.Controller, client *http.Client) (*Service, *MockStorageInterface, *MockQueueInterface, *jobs.Handler) {
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockQueue := NewMockQueueInterface(ctrl)
+	handler := new(jobs.Handler)
+	mockQueue.EXPECT().Handle(DeliveryJob, gomock.Any()).Do(func(_ string, h jobs.Handler) { *handler = h })
+
+	logger := logging.NewNoopLogger()
+	s := NewService(mockStorage, mockQueue, encryption.NewNoopCipher(), client, tracing.NewNoopTracer(), monitoring.NewNoopMonitor("test", logger), logger)
+	s.resolver = testResolver{
+		"hooks.example.com":    {netip.MustParseAddr("93.184.215.14")},
+		"internal.example.com": {netip.MustParseAddr("10.0.0.7")},
+	}
+	return s, mockStorage, mockQueue, handler
+}
+
+type testResolver map[string][]netip.Addr
+
+func (r testResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{name: "success"},
+		{name: "unknown tenant", err: storage.ErrForeignKeyViolation, expectedErr: ErrTenantNotFound},
+		{name: "storage error", err: errors.New("db error")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockStorage, _, _ := newTestService(ctrl, nil)
+
+			mockStorage.EXPECT().CreateWebhookSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, sub *types.WebhookSubscription) (*types.WebhookSubscription, error) {
+					if sub.TenantID != tenantID || sub.URL != "https://hooks.example.com" || string(sub.Secret) != "s3cret" || sub.CreatedBy != userID {
+						t.Errorf("unexpected subscription %+v", sub)
+					}
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					created := *sub
+					created.ID = subscriptionID
+					return &created, nil
+				})
+
+			ctx := authentication.WithUserID(context.Background(), userID)
+			sub, err := s.CreateSubscription(ctx, tenantID, "https://hooks.example.com", []string{events.TenantCreated}, "s3cret")
+
+			if tc.err != nil {
+				if err == nil {
+					t.Fatalf("expected error %v but got none", tc.err)
+				}
+				if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sub.ID != subscriptionID {
+				t.Errorf("expected subscription %s, got %s", subscriptionID, sub.ID)
+			}
+		})
+	}
+}
+
+func TestService_CreateSubscriptionNotPublic(t *testing.T) {
+	for _, url := range []string{
+		"http://127.0.0.1:8080/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+		"http://192.168.1.1/",
+		"http://[::1]/",
+		"http://[fe80::1]/",
+		"http://0.0.0.0/",
+		"https://internal.example.com/",
+		"https://unknown.example.com/",
+	} {
+		t.Run(url, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, _, _, _ := newTestService(ctrl, nil)
+
+			ctx := authentication.WithUserID(context.Background(), userID)
+			if _, err := s.CreateSubscription(ctx, tenantID, url, nil, "s3cret"); !errors.Is(err, ErrURLNotPublic) {
+				t.Fatalf("expected %v, got %v", ErrURLNotPublic, err)
+			}
+		})
+	}
+}
+
+func TestService_DeleteSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockStorage, _, _ := newTestService(ctrl, nil)
+	mockStorage.EXPECT().DeleteWebhookSubscription(gomock.Any(), tenantID, subscriptionID).Return(storage.ErrNotFound)
+
+	if err := s.DeleteSubscription(context.Background(), tenantID, subscriptionID); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Fatalf("expected %v, got %v", ErrSubscriptionNotFound, err)
+	}
+}
+
+func TestService_ListDeliveries(t *testing.T) {
+	testCases := []struct {
+		name        string
+		owner       string
+		getErr      error
+		expectList  bool
+		expectedErr error
+	}{
+		{name: "success", owner: tenantID, expectList: true},
+		{name: "subscription of another tenant", owner: "tenant-456", expectedErr: ErrSubscriptionNotFound},
+		{name: "unknown subscription", getErr: storage.ErrNotFound, expectedErr: ErrSubscriptionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockStorage, _, _ := newTestService(ctrl, nil)
+
+			if tc.getErr != nil {
+				mockStorage.EXPECT().GetWebhookSubscription(gomock.Any(), subscriptionID).Return(nil, tc.getErr)
+			} else {
+				mockStorage.EXPECT().GetWebhookSubscription(gomock.Any(), subscriptionID).Return(&types.WebhookSubscription{ID: subscriptionID, TenantID: tc.owner}, nil)
+			}
+			if tc.expectList {
+				mockStorage.EXPECT().ListWebhookDeliveries(gomock.Any(), subscriptionID, uint64(MaxListedDeliveries)).Return([]*types.WebhookDelivery{{ID: deliveryID}}, nil)
+			}
+
+			deliveries, err := s.ListDeliveries(context.Background(), tenantID, subscriptionID)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectList && len(deliveries) != 1 {
+				t.Errorf("expected a delivery, got %v", deliveries)
+			}
+		})
+	}
+}
+
+func TestService_ReplayDelivery(t *testing.T) {
+	testCases := []struct {
+		name         string
+		delivery     *types.WebhookDelivery
+		getErr       error
+		replayErr    error
+		expectReplay bool
+		expectedErr  error
+	}{
+		{
+			name:         "success",
+			delivery:     &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Status: types.WebhookDeliveryFailed},
+			expectReplay: true,
+		},
+		{
+			name:         "delivery not failed",
+			delivery:     &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Status: types.WebhookDeliverySucceeded},
+			replayErr:    storage.ErrNotFound,
+			expectReplay: true,
+			expectedErr:  ErrDeliveryNotFailed,
+		},
+		{
+			name:        "delivery of another subscription",
+			delivery:    &types.WebhookDelivery{ID: deliveryID, SubscriptionID: "subscription-456", Status: types.WebhookDeliveryFailed},
+			expectedErr: ErrDeliveryNotFound,
+		},
+		{
+			name:        "unknown delivery",
+			getErr:      storage.ErrNotFound,
+			expectedErr: ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, mockStorage, mockQueue, _ := newTestService(ctrl, nil)
+
+			mockStorage.EXPECT().GetWebhookSubscription(gomock.Any(), subscriptionID).Return(&types.WebhookSubscription{ID: subscriptionID, TenantID: tenantID}, nil)
+			mockStorage.EXPECT().GetWebhookDelivery(gomock.Any(), deliveryID).Return(tc.delivery, tc.getErr)
+			if tc.expectReplay {
+				mockStorage.EXPECT().ReplayWebhookDelivery(gomock.Any(), deliveryID).Return(tc.replayErr)
+			}
+			if tc.expectedErr == nil {
+				mockQueue.EXPECT().Enqueue(gomock.Any(), DeliveryJob, deliveryJob{DeliveryID: deliveryID}).Return(nil)
+			}
+
+			d, err := s.ReplayDelivery(context.Background(), tenantID, subscriptionID, deliveryID)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && d.Status != types.WebhookDeliveryPending {
+				t.Errorf("expected the delivery to be pending, got %s", d.Status)
+			}
+		})
+	}
+}
+
+func TestService_Fanout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mockStorage, mockQueue, _ := newTestService(ctrl, nil)
+	e := &events.Event{ID: "event-1", Type: events.MemberAdded, Subject: tenantID, Data: json.RawMessage(`{}`)}
+
+	mockStorage.EXPECT().ListWebhookSubscriptionsForEvent(gomock.Any(), tenantID, events.MemberAdded).Return([]*types.WebhookSubscription{{ID: "a"}, {ID: "b"}}, nil)
+	mockStorage.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, d *types.WebhookDelivery) (*types.WebhookDelivery, error) {
+			if d.EventID != e.ID || d.EventType != e.Type {
+				t.Errorf("unexpected delivery %+v", d)
+			}
+			created := *d
+			created.ID = "delivery-" + d.SubscriptionID
+			return &created, nil
+		}).Times(2)
+	mockQueue.EXPECT().Enqueue(gomock.Any(), DeliveryJob, deliveryJob{DeliveryID: "delivery-a"}).Return(nil)
+	mockQueue.EXPECT().Enqueue(gomock.Any(), DeliveryJob, deliveryJob{DeliveryID: "delivery-b"}).Return(nil)
+
+	if err := s.Fanout(context.Background(), e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestService_Deliver(t *testing.T) {
+	event := events.Event{SpecVersion: "1.0", ID: "event-1", Type: events.TenantCreated, Subject: tenantID, Data: json.RawMessage(`{}`)}
+	payload, _ := json.Marshal(event)
+
+	testCases := []struct {
+		name           string
+		status         int
+		delivery       *types.WebhookDelivery
+		getErr         error
+		expectAttempt  bool
+		expectedStatus types.WebhookDeliveryStatus
+		expectedErr    bool
+	}{
+		{
+			name:           "delivered",
+			status:         http.StatusOK,
+			delivery:       &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Payload: payload, Status: types.WebhookDeliveryPending},
+			expectAttempt:  true,
+			expectedStatus: types.WebhookDeliverySucceeded,
+		},
+		{
+			name:           "failure is recorded and retried",
+			status:         http.StatusServiceUnavailable,
+			delivery:       &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Payload: payload, Status: types.WebhookDeliveryPending},
+			expectAttempt:  true,
+			expectedStatus: types.WebhookDeliveryFailed,
+			expectedErr:    true,
+		},
+		{
+			name:     "succeeded delivery is skipped",
+			delivery: &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Status: types.WebhookDeliverySucceeded},
+		},
+		{
+			name:   "delivery of a deleted subscription is dropped",
+			getErr: storage.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(events.SignatureHeader) == "" {
+					t.Error("expected the delivery to be signed")
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			_, mockStorage, _, handler := newTestService(ctrl, server.Client())
+
+			mockStorage.EXPECT().GetWebhookDelivery(gomock.Any(), deliveryID).Return(tc.delivery, tc.getErr)
+			if tc.expectAttempt {
+				mockStorage.EXPECT().GetWebhookSubscription(gomock.Any(), subscriptionID).Return(&types.WebhookSubscription{ID: subscriptionID, TenantID: tenantID, URL: server.URL, Secret: []byte("s3cret")}, nil)
+				mockStorage.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), deliveryID, tc.expectedStatus, tc.status, gomock.Any()).Return(nil)
+			}
+
+			err := (*handler)(context.Background(), []byte(`{"delivery_id":"`+deliveryID+`"}`))
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
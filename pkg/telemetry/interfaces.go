@@ -0,0 +1,19 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package telemetry
+
+import (
+	"context"
+)
+
+// StorageInterface defines the storage operations required by the telemetry package.
+// It is a subset of the internal/storage interface.
+type StorageInterface interface {
+	CountTenants(ctx context.Context) (int, error)
+}
+
+// ServiceInterface reports anonymized deployment statistics.
+type ServiceInterface interface {
+	Report(ctx context.Context) error
+}
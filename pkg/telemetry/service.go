@@ -0,0 +1,154 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/internal/version"
+)
+
+// tenantBuckets are the upper bounds of the reported tenant count ranges,
+// the exact count is never sent.
+var tenantBuckets = []struct {
+	max   int
+	label string
+}{
+	{0, "0"},
+	{10, "1-10"},
+	{100, "11-100"},
+	{1000, "101-1000"},
+	{10000, "1001-10000"},
+}
+
+// Report is the payload posted to the telemetry endpoint. It holds no
+// identifier of the installation, its tenants or its users.
+type Report struct {
+	Version           string          `json:"version"`
+	GoVersion         string          `json:"go_version"`
+	TenantCountBucket string          `json:"tenant_count_bucket"`
+	Features          map[string]bool `json:"features"`
+}
+
+type Service struct {
+	endpoint string
+	features map[string]bool
+	client   *http.Client
+
+	storage StorageInterface
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+func NewService(
+	endpoint string,
+	features map[string]bool,
+	client *http.Client,
+	storage StorageInterface,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *Service {
+	return &Service{
+		endpoint: endpoint,
+		features: features,
+		client:   client,
+		storage:  storage,
+		tracer:   tracer,
+		monitor:  monitor,
+		logger:   logger,
+	}
+}
+
+// recordError records an error on the span and emits a structured error log.
+// The "error" key is always appended to keysAndValues automatically.
+func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
+}
+
+// Report posts the current statistics to the telemetry endpoint.
+func (s *Service) Report(ctx context.Context) error {
+	ctx, span := s.tracer.Start(ctx, "telemetry.Service.Report")
+	defer span.End()
+
+	count, err := s.storage.CountTenants(ctx)
+	if err != nil {
+		s.recordError(span, "failed to count tenants for telemetry", err)
+		return fmt.Errorf("failed to count tenants: %w", err)
+	}
+
+	body, err := json.Marshal(Report{
+		Version:           version.Version,
+		GoVersion:         runtime.Version(),
+		TenantCountBucket: TenantCountBucket(count),
+		Features:          s.features,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.recordError(span, "failed to send telemetry report", err)
+		return fmt.Errorf("failed to send telemetry report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		err := fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+		s.recordError(span, "telemetry report rejected", err)
+		return err
+	}
+
+	s.logger.Debugw("telemetry report sent", "endpoint", s.endpoint)
+	return nil
+}
+
+// Run reports once on start, then every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// failures are logged by Report, the next tick retries
+		_ = s.Report(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TenantCountBucket maps a tenant count to the coarse range reported.
+func TenantCountBucket(count int) string {
+	for _, b := range tenantBuckets {
+		if count <= b.max {
+			return b.label
+		}
+	}
+	return "10001+"
+}
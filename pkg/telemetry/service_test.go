@@ -0,0 +1,112 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/version"
+)
+
+//go:generate mockgen -build_flags=--mod=mod -package telemetry -destination ./mock_telemetry.go -source=./interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package telemetry -destination ./mock_logger.go -source=../../internal/logging/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package telemetry -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
+//go:generate mockgen -build_flags=--mod=mod -package telemetry -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
+
+func TestService_Report(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     int
+		setupMocks func(*MockStorageInterface)
+		expectErr  bool
+		expectSent bool
+	}{
+		{
+			name:   "Report sent",
+			status: http.StatusAccepted,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().CountTenants(gomock.Any()).Return(42, nil)
+			},
+			expectSent: true,
+		},
+		{
+			name:   "Endpoint rejects",
+			status: http.StatusInternalServerError,
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().CountTenants(gomock.Any()).Return(42, nil)
+			},
+			expectErr:  true,
+			expectSent: true,
+		},
+		{
+			name: "Count error",
+			setupMocks: func(s *MockStorageInterface) {
+				s.EXPECT().CountTenants(gomock.Any()).Return(0, errors.New("db down"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var received *Report
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = new(Report)
+				if err := json.NewDecoder(r.Body).Decode(received); err != nil {
+					t.Errorf("failed to decode report: %v", err)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockTracer.EXPECT().Start(gomock.Any(), "telemetry.Service.Report").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage)
+
+			features := map[string]bool{"authorization": true, "encryption": false}
+			s := NewService(srv.URL, features, srv.Client(), mockStorage, mockTracer, mockMonitor, mockLogger)
+			err := s.Report(context.Background())
+
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if !tc.expectSent {
+				if received != nil {
+					t.Fatal("expected no report to be sent")
+				}
+				return
+			}
+			if received == nil {
+				t.Fatal("expected a report to be sent")
+			}
+			if received.TenantCountBucket != "11-100" || received.Version != version.Version || !received.Features["authorization"] {
+				t.Errorf("unexpected report %+v", received)
+			}
+		})
+	}
+}
+
+func TestTenantCountBucket(t *testing.T) {
+	for count, expected := range map[int]string{0: "0", 1: "1-10", 10: "1-10", 11: "11-100", 1000: "101-1000", 5000: "1001-10000", 20000: "10001+"} {
+		if got := TenantCountBucket(count); got != expected {
+			t.Errorf("expected bucket %s for %d, got %s", expected, count, got)
+		}
+	}
+}
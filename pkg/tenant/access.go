@@ -0,0 +1,710 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/tracing"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+// methodPermissions maps the tenant scoped RPCs to the permission the caller
+// needs on the tenant targeted by the request. RPCs missing from the map, and
+// from selfServiceMethods and platformMethods, are denied.
+var methodPermissions = map[string]string{
+	v0.TenantService_InviteMember_FullMethodName:              authorization.CAN_CREATE_PERMISSION,
+	v0.TenantService_ListInvites_FullMethodName:               authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_RevokeInvite_FullMethodName:              authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_ResendInvite_FullMethodName:              authorization.CAN_CREATE_PERMISSION,
+	v0.TenantService_GetTenantStats_FullMethodName:            authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_ListTenantUsers_FullMethodName:           authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_GetTenantUser_FullMethodName:             authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_UpdateTenant_FullMethodName:              authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_DeleteTenant_FullMethodName:              authorization.CAN_DELETE_PERMISSION,
+	v0.TenantService_ProvisionUser_FullMethodName:             authorization.CAN_CREATE_PERMISSION,
+	v0.TenantService_UpdateTenantUser_FullMethodName:          authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_RemoveTenantUser_FullMethodName:          authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_CreateRole_FullMethodName:                authorization.CAN_CREATE_PERMISSION,
+	v0.TenantService_ListRoles_FullMethodName:                 authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_UpdateRole_FullMethodName:                authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_DeleteRole_FullMethodName:                authorization.CAN_DELETE_PERMISSION,
+	v0.TenantService_AssignRole_FullMethodName:                authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_UnassignRole_FullMethodName:              authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_CreateAPIKey_FullMethodName:              authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_ListAPIKeys_FullMethodName:               authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_RevokeAPIKey_FullMethodName:              authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_CreateWebhookSubscription_FullMethodName: authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_ListWebhookSubscriptions_FullMethodName:  authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_DeleteWebhookSubscription_FullMethodName: authorization.CAN_EDIT_PERMISSION,
+	v0.TenantService_ListWebhookDeliveries_FullMethodName:     authorization.CAN_VIEW_PERMISSION,
+	v0.TenantService_ReplayWebhookDelivery_FullMethodName:     authorization.CAN_EDIT_PERMISSION,
+}
+
+// tenantScopePermissions replace methodPermissions once the tenant scope is
+// enforced: managing the users of a tenant takes can_admin, which custom
+// roles cannot grant, rather than can_edit.
+var tenantScopePermissions = map[string]string{
+	v0.TenantService_UpdateTenantUser_FullMethodName: authorization.CAN_ADMIN_PERMISSION,
+	v0.TenantService_RemoveTenantUser_FullMethodName: authorization.CAN_ADMIN_PERMISSION,
+}
+
+// selfServiceMethods are the RPCs about the caller itself, every
+// authenticated user and service can call them.
+var selfServiceMethods = []string{
+	v0.TenantService_WhoAmI_FullMethodName,
+	v0.TenantService_ListMyTenants_FullMethodName,
+	v0.TenantService_GetMyPermissions_FullMethodName,
+}
+
+// platformMethods are the RPCs not scoped to a single tenant, reserved to the
+// platform admins: the admins of the platform support group and the service
+// clients with the admin platform role.
+var platformMethods = []string{
+	v0.TenantService_CreateTenant_FullMethodName,
+	v0.TenantService_ListTenants_FullMethodName,
+	v0.TenantService_ListUserTenants_FullMethodName,
+	v0.TenantService_RunDiagnostics_FullMethodName,
+	v0.TenantService_ListAuthzAudit_FullMethodName,
+	v0.TenantService_AddPlatformAdmin_FullMethodName,
+	v0.TenantService_RemovePlatformAdmin_FullMethodName,
+	v0.TenantService_ListPlatformAdmins_FullMethodName,
+	v0.TenantService_LinkTenantToSupportGroup_FullMethodName,
+}
+
+// viewerMethods are the only RPCs the platform viewers can call, on every
+// tenant. They only read.
+var viewerMethods = []string{
+	v0.TenantService_ListInvites_FullMethodName,
+	v0.TenantService_GetTenantStats_FullMethodName,
+	v0.TenantService_ListTenantUsers_FullMethodName,
+	v0.TenantService_GetTenantUser_FullMethodName,
+	v0.TenantService_ListRoles_FullMethodName,
+	v0.TenantService_ListAPIKeys_FullMethodName,
+	v0.TenantService_ListWebhookSubscriptions_FullMethodName,
+	v0.TenantService_ListWebhookDeliveries_FullMethodName,
+	v0.TenantService_ListTenants_FullMethodName,
+	v0.TenantService_ListUserTenants_FullMethodName,
+	v0.TenantService_ListAuthzAudit_FullMethodName,
+	v0.TenantService_ListPlatformAdmins_FullMethodName,
+	// without fixes, see viewerAllowed
+	v0.TenantService_RunDiagnostics_FullMethodName,
+}
+
+// DefaultPlatformAdminGroup is the support group whose admins are the
+// platform admins, unless set otherwise.
+const DefaultPlatformAdminGroup = "platform"
+
+// apiKeyManagementMethods are the RPCs an API key cannot call whatever its
+// role, so that a key cannot mint or revoke keys.
+var apiKeyManagementMethods = []string{
+	v0.TenantService_CreateAPIKey_FullMethodName,
+	v0.TenantService_ListAPIKeys_FullMethodName,
+	v0.TenantService_RevokeAPIKey_FullMethodName,
+}
+
+// destructiveMethods are the RPCs that cannot be undone, users must have
+// logged in recently to call them when a fresh authentication age is set,
+// and every caller must satisfy the step-up policy when one is set.
+var destructiveMethods = []string{
+	v0.TenantService_DeleteTenant_FullMethodName,
+}
+
+// ErrorReasonWrongRegion is the reason of the error returned for writes to a
+// tenant homed in another region.
+const ErrorReasonWrongRegion = "TENANT_HOMED_IN_OTHER_REGION"
+
+// AccessControl enforces methodPermissions before the tenant RPCs are
+// dispatched. When the serving region is set, it also rejects the writes to
+// tenants homed in another region.
+type AccessControl struct {
+	authz   AuthzInterface
+	storage StorageInterface
+
+	region             string
+	regionEndpoints    map[string]string
+	freshAuthMaxAge    time.Duration
+	stepUp             *StepUpPolicy
+	platformAdminGroup string
+	enforceTenantScope bool
+
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Authorize checks that the caller holds the permission required by the RPC
+// on the tenant targeted by req, or is a platform admin for the RPCs of
+// platformMethods. API keys can only call the RPCs scoped to their own tenant.
+func (a *AccessControl) Authorize(ctx context.Context, fullMethod string, req any) error {
+	if principal, found := authentication.GetPrincipal(ctx); found && principal.Type == authentication.PrincipalAPIKey {
+		if _, ok := methodPermissions[fullMethod]; !ok {
+			a.logger.Security().AuthzFailure(principal.ID, fullMethod, authentication.PrincipalLabel(ctx))
+			return status.Errorf(codes.PermissionDenied, "API keys cannot call %s", fullMethod)
+		}
+	}
+
+	if slices.Contains(selfServiceMethods, fullMethod) {
+		return nil
+	}
+
+	if slices.Contains(platformMethods, fullMethod) {
+		return a.authorizePlatform(ctx, fullMethod, req)
+	}
+
+	permission, ok := methodPermissions[fullMethod]
+	if !ok {
+		userID, _ := authentication.GetUserID(ctx)
+		a.logger.Security().AuthzFailure(userID, fullMethod, authentication.PrincipalLabel(ctx))
+		return status.Errorf(codes.PermissionDenied, "%s is not authorized", fullMethod)
+	}
+	if scoped, ok := tenantScopePermissions[fullMethod]; ok && a.enforceTenantScope {
+		permission = scoped
+	}
+
+	ctx, span := a.tracer.Start(ctx, "tenant.AccessControl.Authorize")
+	defer span.End()
+
+	principal, ok := authentication.GetPrincipal(ctx)
+	if !ok || principal.ID == "" {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	userID := principal.ID
+
+	if err := a.checkFreshAuth(ctx, principal, fullMethod); err != nil {
+		return err
+	}
+
+	tenantID := tenantIDFromRequest(req)
+	if tenantID == "" {
+		return status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+
+	// service clients are not tenant members, their platform role stands in
+	// for the check
+	if !platformRoleGrants(principal.PlatformRole, fullMethod, req) {
+		allowed := false
+		if principal.PlatformRole == "" {
+			var err error
+			allowed, err = a.checkTenantAccess(ctx, principal, fullMethod, tenantID, permission)
+			if err != nil {
+				a.logger.Errorw("failed to check tenant access", "tenant_id", tenantID, "user_id", userID, "permission", permission, "error", err)
+				return status.Error(codes.Internal, "failed to check tenant access")
+			}
+		}
+
+		if !allowed {
+			a.logger.Security().AuthzFailureInsufficientPermissions(userID, permission, fullMethod, authentication.PrincipalLabel(ctx))
+			return status.Errorf(codes.PermissionDenied, "%s permission required on tenant %s", permission, tenantID)
+		}
+	}
+
+	// after the permission, so that only the callers allowed to proceed are
+	// asked to authenticate again
+	if err := a.checkStepUp(ctx, principal, fullMethod); err != nil {
+		return err
+	}
+
+	if permission != authorization.CAN_VIEW_PERMISSION {
+		return a.checkRegion(ctx, tenantID)
+	}
+
+	return nil
+}
+
+// authorizePlatform checks that the caller of one of platformMethods is a
+// platform admin.
+func (a *AccessControl) authorizePlatform(ctx context.Context, fullMethod string, req any) error {
+	principal, ok := authentication.GetPrincipal(ctx)
+	if !ok || principal.ID == "" {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if platformRoleGrants(principal.PlatformRole, fullMethod, req) {
+		return nil
+	}
+
+	allowed := false
+	if principal.PlatformRole == "" && a.platformAdminGroup != "" {
+		var err error
+		allowed, err = a.authz.Check(ctx, authorization.UserTuple(principal.ID), authorization.ADMIN_RELATION, authorization.PrivilegedTuple(a.platformAdminGroup))
+		if err != nil {
+			a.logger.Errorw("failed to check platform admin", "user_id", principal.ID, "group_id", a.platformAdminGroup, "error", err)
+			return status.Error(codes.Internal, "failed to check platform admin")
+		}
+	}
+
+	if !allowed {
+		a.logger.Security().AuthzFailureInsufficientPermissions(principal.ID, "platform_admin", fullMethod, authentication.PrincipalLabel(ctx))
+		return status.Errorf(codes.PermissionDenied, "%s is reserved to platform admins", fullMethod)
+	}
+
+	return nil
+}
+
+// checkTenantAccess checks permission on the tenant. An API key is checked
+// with the relation of its role as a contextual tuple, on its own tenant only.
+func (a *AccessControl) checkTenantAccess(ctx context.Context, principal *authentication.Principal, fullMethod, tenantID, permission string) (bool, error) {
+	if principal.Type != authentication.PrincipalAPIKey {
+		return a.authz.CheckTenantAccess(ctx, tenantID, principal.ID, permission)
+	}
+
+	if tenantID != principal.TenantID || slices.Contains(apiKeyManagementMethods, fullMethod) {
+		return false, nil
+	}
+
+	role := openfga.Tuple{
+		User:     authorization.UserTuple(principal.ID),
+		Relation: authorization.RelationForRole(principal.TenantRole),
+		Object:   authorization.TenantTuple(tenantID),
+	}
+	return a.authz.CheckTenantAccess(ctx, tenantID, principal.ID, permission, role)
+}
+
+// checkFreshAuth requires the users calling one of destructiveMethods to have
+// logged in within the fresh authentication age. Services and API keys do
+// not log in and are not checked.
+func (a *AccessControl) checkFreshAuth(ctx context.Context, principal *authentication.Principal, fullMethod string) error {
+	if a.freshAuthMaxAge == 0 || principal.Type != authentication.PrincipalUser || !slices.Contains(destructiveMethods, fullMethod) {
+		return nil
+	}
+
+	if !principal.AuthTime.IsZero() && time.Since(principal.AuthTime) <= a.freshAuthMaxAge {
+		return nil
+	}
+
+	a.logger.Security().AuthzFailure(principal.ID, fullMethod, authentication.PrincipalLabel(ctx), logging.WithLabel("reason", "stale_auth_time"))
+	return status.Errorf(codes.Unauthenticated, "log in again, %s requires a login within the last %s", fullMethod, a.freshAuthMaxAge)
+}
+
+// checkRegion rejects the writes to a tenant homed in a region other than
+// the serving one, the error details name the home region and its endpoint
+// when known so that clients can redirect.
+func (a *AccessControl) checkRegion(ctx context.Context, tenantID string) error {
+	if a.region == "" {
+		return nil
+	}
+
+	t, err := a.storage.GetTenantByID(ctx, tenantID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// left to the handler
+		return nil
+	}
+	if err != nil {
+		a.logger.Errorw("failed to get tenant region", "tenant_id", tenantID, "error", err)
+		return status.Error(codes.Internal, "failed to get tenant region")
+	}
+
+	if t.Region == "" || t.Region == a.region {
+		return nil
+	}
+
+	a.logger.Infow("rejecting write to a tenant homed in another region", "tenant_id", tenantID, "region", t.Region, "serving_region", a.region)
+
+	st := status.Newf(codes.FailedPrecondition, "tenant %s is homed in region %s, send writes to that region", tenantID, t.Region)
+	metadata := map[string]string{"region": t.Region}
+	if endpoint, ok := a.regionEndpoints[t.Region]; ok {
+		metadata["endpoint"] = endpoint
+	}
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   ErrorReasonWrongRegion,
+		Domain:   "tenant-service",
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// UnaryServerInterceptor authorizes gRPC calls, it must run after authentication.
+func (a *AccessControl) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.Authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Server wraps server so that the tenant scoped RPCs are authorized when it
+// is called in-process, as the grpc-gateway does, bypassing interceptors.
+func (a *AccessControl) Server(server v0.TenantServiceServer) v0.TenantServiceServer {
+	return &authorizedServer{TenantServiceServer: server, access: a}
+}
+
+// platformRoleGrants reports whether role grants the call of fullMethod with
+// req on every tenant. Viewers are denied anything but viewerMethods.
+func platformRoleGrants(role authentication.PlatformRole, fullMethod string, req any) bool {
+	switch role {
+	case authentication.PlatformRoleAdmin:
+		return true
+	case authentication.PlatformRoleViewer:
+		return viewerAllowed(fullMethod, req)
+	default:
+		return false
+	}
+}
+
+// viewerAllowed reports whether a platform viewer can call fullMethod with
+// req: one of viewerMethods, and diagnostics only when they fix nothing.
+func viewerAllowed(fullMethod string, req any) bool {
+	if !slices.Contains(viewerMethods, fullMethod) {
+		return false
+	}
+	if r, ok := req.(*v0.RunDiagnosticsRequest); ok {
+		return len(r.GetFix()) == 0
+	}
+	return true
+}
+
+func tenantIDFromRequest(req any) string {
+	switch r := req.(type) {
+	case *v0.UpdateTenantRequest:
+		return r.GetTenant().GetId()
+	case interface{ GetTenantId() string }:
+		return r.GetTenantId()
+	}
+
+	return ""
+}
+
+// NewAccessControl returns the access control of the tenant RPCs. region is
+// the serving region, empty to accept writes to every tenant, and
+// regionEndpoints the API endpoints of the other regions.
+func NewAccessControl(
+	authz AuthzInterface,
+	storage StorageInterface,
+	region string,
+	regionEndpoints map[string]string,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) *AccessControl {
+	a := new(AccessControl)
+
+	a.authz = authz
+	a.storage = storage
+	a.region = region
+	a.regionEndpoints = regionEndpoints
+	a.platformAdminGroup = DefaultPlatformAdminGroup
+	a.tracer = tracer
+	a.monitor = monitor
+	a.logger = logger
+
+	return a
+}
+
+// SetFreshAuthMaxAge requires the users calling the RPCs that cannot be
+// undone to have logged in within maxAge, zero disables the check.
+func (a *AccessControl) SetFreshAuthMaxAge(maxAge time.Duration) {
+	a.freshAuthMaxAge = maxAge
+}
+
+// SetPlatformAdminGroup makes the admins of the support group groupID the
+// platform admins, empty leaves the platform RPCs to the admin service
+// clients.
+func (a *AccessControl) SetPlatformAdminGroup(groupID string) {
+	a.platformAdminGroup = groupID
+}
+
+// SetEnforceTenantScope requires can_admin on the tenant, rather than
+// can_edit, to update and remove its users.
+func (a *AccessControl) SetEnforceTenantScope(enforce bool) {
+	a.enforceTenantScope = enforce
+}
+
+// SetStepUpPolicy requires the callers of the RPCs that cannot be undone to
+// satisfy policy, nil disables the check.
+func (a *AccessControl) SetStepUpPolicy(policy *StepUpPolicy) {
+	a.stepUp = policy
+}
+
+// authorizedServer overrides every RPC, so that none is served without being
+// authorized.
+type authorizedServer struct {
+	v0.TenantServiceServer
+
+	access *AccessControl
+}
+
+func (s *authorizedServer) InviteMember(ctx context.Context, req *v0.InviteMemberRequest) (*v0.InviteMemberResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_InviteMember_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.InviteMember(ctx, req)
+}
+
+func (s *authorizedServer) ListInvites(ctx context.Context, req *v0.ListInvitesRequest) (*v0.ListInvitesResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListInvites_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListInvites(ctx, req)
+}
+
+func (s *authorizedServer) RevokeInvite(ctx context.Context, req *v0.RevokeInviteRequest) (*v0.Invite, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_RevokeInvite_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.RevokeInvite(ctx, req)
+}
+
+func (s *authorizedServer) ResendInvite(ctx context.Context, req *v0.ResendInviteRequest) (*v0.ResendInviteResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ResendInvite_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ResendInvite(ctx, req)
+}
+
+func (s *authorizedServer) GetTenantStats(ctx context.Context, req *v0.GetTenantStatsRequest) (*v0.GetTenantStatsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_GetTenantStats_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.GetTenantStats(ctx, req)
+}
+
+func (s *authorizedServer) ListTenantUsers(ctx context.Context, req *v0.ListTenantUsersRequest) (*v0.ListTenantUsersResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListTenantUsers_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListTenantUsers(ctx, req)
+}
+
+func (s *authorizedServer) GetTenantUser(ctx context.Context, req *v0.GetTenantUserRequest) (*v0.GetTenantUserResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_GetTenantUser_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.GetTenantUser(ctx, req)
+}
+
+func (s *authorizedServer) UpdateTenant(ctx context.Context, req *v0.UpdateTenantRequest) (*v0.UpdateTenantResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_UpdateTenant_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.UpdateTenant(ctx, req)
+}
+
+func (s *authorizedServer) DeleteTenant(ctx context.Context, req *v0.DeleteTenantRequest) (*v0.DeleteTenantResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_DeleteTenant_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.DeleteTenant(ctx, req)
+}
+
+func (s *authorizedServer) ProvisionUser(ctx context.Context, req *v0.ProvisionUserRequest) (*v0.ProvisionUserResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ProvisionUser_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ProvisionUser(ctx, req)
+}
+
+func (s *authorizedServer) UpdateTenantUser(ctx context.Context, req *v0.UpdateTenantUserRequest) (*v0.UpdateTenantUserResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_UpdateTenantUser_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.UpdateTenantUser(ctx, req)
+}
+
+func (s *authorizedServer) RemoveTenantUser(ctx context.Context, req *v0.RemoveTenantUserRequest) (*v0.RemoveTenantUserResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_RemoveTenantUser_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.RemoveTenantUser(ctx, req)
+}
+
+func (s *authorizedServer) CreateRole(ctx context.Context, req *v0.CreateRoleRequest) (*v0.CreateRoleResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_CreateRole_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.CreateRole(ctx, req)
+}
+
+func (s *authorizedServer) ListRoles(ctx context.Context, req *v0.ListRolesRequest) (*v0.ListRolesResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListRoles_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListRoles(ctx, req)
+}
+
+func (s *authorizedServer) UpdateRole(ctx context.Context, req *v0.UpdateRoleRequest) (*v0.UpdateRoleResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_UpdateRole_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.UpdateRole(ctx, req)
+}
+
+func (s *authorizedServer) DeleteRole(ctx context.Context, req *v0.DeleteRoleRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_DeleteRole_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.DeleteRole(ctx, req)
+}
+
+func (s *authorizedServer) AssignRole(ctx context.Context, req *v0.AssignRoleRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_AssignRole_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.AssignRole(ctx, req)
+}
+
+func (s *authorizedServer) UnassignRole(ctx context.Context, req *v0.UnassignRoleRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_UnassignRole_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.UnassignRole(ctx, req)
+}
+
+func (s *authorizedServer) WhoAmI(ctx context.Context, req *v0.WhoAmIRequest) (*v0.WhoAmIResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_WhoAmI_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.WhoAmI(ctx, req)
+}
+
+func (s *authorizedServer) ListMyTenants(ctx context.Context, req *v0.ListMyTenantsRequest) (*v0.ListMyTenantsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListMyTenants_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListMyTenants(ctx, req)
+}
+
+func (s *authorizedServer) GetMyPermissions(ctx context.Context, req *v0.GetMyPermissionsRequest) (*v0.GetMyPermissionsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_GetMyPermissions_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.GetMyPermissions(ctx, req)
+}
+
+func (s *authorizedServer) ListTenants(ctx context.Context, req *v0.ListTenantsRequest) (*v0.ListTenantsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListTenants_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListTenants(ctx, req)
+}
+
+func (s *authorizedServer) ListUserTenants(ctx context.Context, req *v0.ListUserTenantsRequest) (*v0.ListUserTenantsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListUserTenants_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListUserTenants(ctx, req)
+}
+
+func (s *authorizedServer) CreateTenant(ctx context.Context, req *v0.CreateTenantRequest) (*v0.CreateTenantResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_CreateTenant_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.CreateTenant(ctx, req)
+}
+
+func (s *authorizedServer) RunDiagnostics(ctx context.Context, req *v0.RunDiagnosticsRequest) (*v0.RunDiagnosticsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_RunDiagnostics_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.RunDiagnostics(ctx, req)
+}
+
+func (s *authorizedServer) CreateAPIKey(ctx context.Context, req *v0.CreateAPIKeyRequest) (*v0.CreateAPIKeyResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_CreateAPIKey_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.CreateAPIKey(ctx, req)
+}
+
+func (s *authorizedServer) ListAPIKeys(ctx context.Context, req *v0.ListAPIKeysRequest) (*v0.ListAPIKeysResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListAPIKeys_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListAPIKeys(ctx, req)
+}
+
+func (s *authorizedServer) RevokeAPIKey(ctx context.Context, req *v0.RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_RevokeAPIKey_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.RevokeAPIKey(ctx, req)
+}
+
+func (s *authorizedServer) CreateWebhookSubscription(ctx context.Context, req *v0.CreateWebhookSubscriptionRequest) (*v0.WebhookSubscription, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_CreateWebhookSubscription_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.CreateWebhookSubscription(ctx, req)
+}
+
+func (s *authorizedServer) ListWebhookSubscriptions(ctx context.Context, req *v0.ListWebhookSubscriptionsRequest) (*v0.ListWebhookSubscriptionsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListWebhookSubscriptions_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListWebhookSubscriptions(ctx, req)
+}
+
+func (s *authorizedServer) DeleteWebhookSubscription(ctx context.Context, req *v0.DeleteWebhookSubscriptionRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_DeleteWebhookSubscription_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.DeleteWebhookSubscription(ctx, req)
+}
+
+func (s *authorizedServer) ListWebhookDeliveries(ctx context.Context, req *v0.ListWebhookDeliveriesRequest) (*v0.ListWebhookDeliveriesResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListWebhookDeliveries_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListWebhookDeliveries(ctx, req)
+}
+
+func (s *authorizedServer) ReplayWebhookDelivery(ctx context.Context, req *v0.ReplayWebhookDeliveryRequest) (*v0.WebhookDelivery, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ReplayWebhookDelivery_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ReplayWebhookDelivery(ctx, req)
+}
+
+func (s *authorizedServer) AddPlatformAdmin(ctx context.Context, req *v0.AddPlatformAdminRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_AddPlatformAdmin_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.AddPlatformAdmin(ctx, req)
+}
+
+func (s *authorizedServer) RemovePlatformAdmin(ctx context.Context, req *v0.RemovePlatformAdminRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_RemovePlatformAdmin_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.RemovePlatformAdmin(ctx, req)
+}
+
+func (s *authorizedServer) ListPlatformAdmins(ctx context.Context, req *v0.ListPlatformAdminsRequest) (*v0.ListPlatformAdminsResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListPlatformAdmins_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListPlatformAdmins(ctx, req)
+}
+
+func (s *authorizedServer) LinkTenantToSupportGroup(ctx context.Context, req *v0.LinkTenantToSupportGroupRequest) (*emptypb.Empty, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_LinkTenantToSupportGroup_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.LinkTenantToSupportGroup(ctx, req)
+}
+
+func (s *authorizedServer) ListAuthzAudit(ctx context.Context, req *v0.ListAuthzAuditRequest) (*v0.ListAuthzAuditResponse, error) {
+	if err := s.access.Authorize(ctx, v0.TenantService_ListAuthzAudit_FullMethodName, req); err != nil {
+		return nil, err
+	}
+	return s.TenantServiceServer.ListAuthzAudit(ctx, req)
+}
@@ -0,0 +1,895 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	v0 "github.com/canonical/tenant-service/v0"
+)
+
+func TestAccessControl_Authorize(t *testing.T) {
+	tenantID := "tenant-1"
+	userID := "user-1"
+	apiKey := &authentication.Principal{ID: "key-1", Type: authentication.PrincipalAPIKey, TenantID: tenantID, TenantRole: types.RoleAdmin}
+
+	testCases := []struct {
+		name         string
+		ctx          context.Context
+		method       string
+		req          any
+		setupMocks   func(*MockAuthzInterface, *MockSecurityLoggerInterface)
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Self-service method",
+			ctx:          authentication.WithUserID(context.Background(), userID),
+			method:       v0.TenantService_WhoAmI_FullMethodName,
+			req:          &v0.WhoAmIRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Method not mapped",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: "/tenant.v0.TenantService/NewMethod",
+			req:    &v0.WhoAmIRequest{},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailure(userID, "/tenant.v0.TenantService/NewMethod", gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Platform method unauthenticated",
+			ctx:          context.Background(),
+			method:       v0.TenantService_CreateTenant_FullMethodName,
+			req:          &v0.CreateTenantRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:   "Plain user adding a platform admin",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_AddPlatformAdmin_FullMethodName,
+			req:    &v0.AddPlatformAdminRequest{GroupId: "platform", UserId: userID},
+			setupMocks: func(authz *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				authz.EXPECT().Check(gomock.Any(), "user:"+userID, "admin", "privileged:platform").Return(false, nil)
+				security.EXPECT().AuthzFailureInsufficientPermissions(userID, "platform_admin", v0.TenantService_AddPlatformAdmin_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "Platform admin adding a platform admin",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_AddPlatformAdmin_FullMethodName,
+			req:    &v0.AddPlatformAdminRequest{GroupId: "support", UserId: "user-2"},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().Check(gomock.Any(), "user:"+userID, "admin", "privileged:platform").Return(true, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Platform admin check error",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_ListTenants_FullMethodName,
+			req:    &v0.ListTenantsRequest{},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().Check(gomock.Any(), "user:"+userID, "admin", "privileged:platform").Return(false, errors.New("fga down"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "Platform admin service client creating a tenant",
+			ctx:          authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "ops", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleAdmin}),
+			method:       v0.TenantService_CreateTenant_FullMethodName,
+			req:          &v0.CreateTenantRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Unauthenticated",
+			ctx:          context.Background(),
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			req:          &v0.DeleteTenantRequest{TenantId: tenantID},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Missing tenant",
+			ctx:          authentication.WithUserID(context.Background(), userID),
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			req:          &v0.DeleteTenantRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:   "Allowed",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_ListTenantUsers_FullMethodName,
+			req:    &v0.ListTenantUsersRequest{TenantId: tenantID},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, "can_view").Return(true, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Tenant ID nested in update",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_UpdateTenant_FullMethodName,
+			req:    &v0.UpdateTenantRequest{Tenant: &v0.Tenant{Id: tenantID}},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, "can_edit").Return(true, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Denied",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_DeleteTenant_FullMethodName,
+			req:    &v0.DeleteTenantRequest{TenantId: tenantID},
+			setupMocks: func(authz *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				authz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, "can_delete").Return(false, nil)
+				security.EXPECT().AuthzFailureInsufficientPermissions(userID, "can_delete", v0.TenantService_DeleteTenant_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Platform admin service client",
+			ctx:          authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "ops", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleAdmin}),
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			req:          &v0.DeleteTenantRequest{TenantId: tenantID},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Platform viewer service client reading",
+			ctx:          authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method:       v0.TenantService_ListTenantUsers_FullMethodName,
+			req:          &v0.ListTenantUsersRequest{TenantId: tenantID},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Platform viewer service client writing",
+			ctx:    authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method: v0.TenantService_DeleteTenant_FullMethodName,
+			req:    &v0.DeleteTenantRequest{TenantId: tenantID},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("billing", "can_delete", v0.TenantService_DeleteTenant_FullMethodName, logging.WithLabel("principal_type", "service"))
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Platform viewer service client listing tenants",
+			ctx:          authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method:       v0.TenantService_ListTenants_FullMethodName,
+			req:          &v0.ListTenantsRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Platform viewer service client creating a tenant",
+			ctx:    authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method: v0.TenantService_CreateTenant_FullMethodName,
+			req:    &v0.CreateTenantRequest{},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("billing", "platform_admin", v0.TenantService_CreateTenant_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "Platform viewer service client adding a platform admin",
+			ctx:    authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method: v0.TenantService_AddPlatformAdmin_FullMethodName,
+			req:    &v0.AddPlatformAdminRequest{GroupId: "platform", UserId: "billing"},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("billing", "platform_admin", v0.TenantService_AddPlatformAdmin_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Platform viewer service client running diagnostics",
+			ctx:          authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method:       v0.TenantService_RunDiagnostics_FullMethodName,
+			req:          &v0.RunDiagnosticsRequest{},
+			setupMocks:   func(*MockAuthzInterface, *MockSecurityLoggerInterface) {},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Platform viewer service client fixing anomalies",
+			ctx:    authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "billing", Type: authentication.PrincipalService, PlatformRole: authentication.PlatformRoleViewer}),
+			method: v0.TenantService_RunDiagnostics_FullMethodName,
+			req:    &v0.RunDiagnosticsRequest{Fix: []string{"orphaned_membership"}},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("billing", "platform_admin", v0.TenantService_RunDiagnostics_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "API key on its tenant",
+			ctx:    authentication.WithPrincipal(context.Background(), apiKey),
+			method: v0.TenantService_ListTenantUsers_FullMethodName,
+			req:    &v0.ListTenantUsersRequest{TenantId: tenantID},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, "key-1", "can_view", openfga.Tuple{User: "user:key-1", Relation: "admin", Object: "tenant:" + tenantID}).Return(true, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "API key on another tenant",
+			ctx:    authentication.WithPrincipal(context.Background(), apiKey),
+			method: v0.TenantService_ListTenantUsers_FullMethodName,
+			req:    &v0.ListTenantUsersRequest{TenantId: "tenant-2"},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("key-1", "can_view", v0.TenantService_ListTenantUsers_FullMethodName, logging.WithLabel("principal_type", "api_key"))
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "API key managing keys",
+			ctx:    authentication.WithPrincipal(context.Background(), apiKey),
+			method: v0.TenantService_ListAPIKeys_FullMethodName,
+			req:    &v0.ListAPIKeysRequest{TenantId: tenantID},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailureInsufficientPermissions("key-1", "can_view", v0.TenantService_ListAPIKeys_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "API key on a method not tenant scoped",
+			ctx:    authentication.WithPrincipal(context.Background(), apiKey),
+			method: v0.TenantService_CreateTenant_FullMethodName,
+			req:    &v0.CreateTenantRequest{},
+			setupMocks: func(_ *MockAuthzInterface, security *MockSecurityLoggerInterface) {
+				security.EXPECT().AuthzFailure("key-1", v0.TenantService_CreateTenant_FullMethodName, gomock.Any())
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:   "Check error",
+			ctx:    authentication.WithUserID(context.Background(), userID),
+			method: v0.TenantService_CreateRole_FullMethodName,
+			req:    &v0.CreateRoleRequest{TenantId: tenantID},
+			setupMocks: func(authz *MockAuthzInterface, _ *MockSecurityLoggerInterface) {
+				authz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, "can_create").Return(false, errors.New("fga down"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.AccessControl.Authorize").Return(tc.ctx, trace.SpanFromContext(tc.ctx)).AnyTimes()
+			tc.setupMocks(mockAuthz, mockSecurity)
+
+			a := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, mockMonitor, mockLogger)
+			err := a.Authorize(tc.ctx, tc.method, tc.req)
+
+			if status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestAccessControl_Region(t *testing.T) {
+	tenantID := "tenant-1"
+	userID := "user-1"
+
+	testCases := []struct {
+		name             string
+		method           string
+		req              any
+		tenant           *types.Tenant
+		tenantErr        error
+		expectedCode     codes.Code
+		expectedEndpoint string
+	}{
+		{
+			name:         "Write to local tenant",
+			method:       v0.TenantService_UpdateTenant_FullMethodName,
+			req:          &v0.UpdateTenantRequest{Tenant: &v0.Tenant{Id: tenantID}},
+			tenant:       &types.Tenant{ID: tenantID, Region: "eu-west-1"},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Write to tenant without region",
+			method:       v0.TenantService_ProvisionUser_FullMethodName,
+			req:          &v0.ProvisionUserRequest{TenantId: tenantID},
+			tenant:       &types.Tenant{ID: tenantID},
+			expectedCode: codes.OK,
+		},
+		{
+			name:             "Write to tenant homed elsewhere",
+			method:           v0.TenantService_DeleteTenant_FullMethodName,
+			req:              &v0.DeleteTenantRequest{TenantId: tenantID},
+			tenant:           &types.Tenant{ID: tenantID, Region: "us-east-1"},
+			expectedCode:     codes.FailedPrecondition,
+			expectedEndpoint: "https://us.example.com",
+		},
+		{
+			name:         "Read of tenant homed elsewhere",
+			method:       v0.TenantService_ListTenantUsers_FullMethodName,
+			req:          &v0.ListTenantUsersRequest{TenantId: tenantID},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Write to missing tenant",
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			req:          &v0.DeleteTenantRequest{TenantId: tenantID},
+			tenantErr:    storage.ErrNotFound,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Storage error",
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			req:          &v0.DeleteTenantRequest{TenantId: tenantID},
+			tenantErr:    errors.New("db down"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			ctx := authentication.WithUserID(context.Background(), userID)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+			mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, gomock.Any()).Return(true, nil)
+			if tc.tenant != nil || tc.tenantErr != nil {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(tc.tenant, tc.tenantErr)
+			}
+
+			a := NewAccessControl(mockAuthz, mockStorage, "eu-west-1", map[string]string{"us-east-1": "https://us.example.com"}, mockTracer, mockMonitor, mockLogger)
+			err := a.Authorize(ctx, tc.method, tc.req)
+
+			st := status.Convert(err)
+			if st.Code() != tc.expectedCode {
+				t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+			}
+			if tc.expectedEndpoint == "" {
+				return
+			}
+
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					if info.Reason != ErrorReasonWrongRegion || info.Metadata["endpoint"] != tc.expectedEndpoint {
+						t.Errorf("expected a redirect to %s, got %v", tc.expectedEndpoint, info)
+					}
+					return
+				}
+			}
+			t.Errorf("expected the error to hold the home region, got %v", st.Details())
+		})
+	}
+}
+
+func TestAccessControl_FreshAuth(t *testing.T) {
+	tenantID := "tenant-1"
+	userID := "user-1"
+
+	testCases := []struct {
+		name         string
+		principal    *authentication.Principal
+		method       string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Recent login",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Minute)},
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Stale login",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Hour)},
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Unknown login time",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser},
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "Stale login on another method",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Hour)},
+			method:       v0.TenantService_UpdateTenant_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Service",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalService},
+			method:       v0.TenantService_DeleteTenant_FullMethodName,
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+			ctx := authentication.WithPrincipal(context.Background(), tc.principal)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+			if tc.expectedCode == codes.OK {
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, gomock.Any()).Return(true, nil)
+			} else {
+				mockSecurity.EXPECT().AuthzFailure(userID, tc.method, gomock.Any(), logging.WithLabel("reason", "stale_auth_time"))
+			}
+
+			a := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			a.SetFreshAuthMaxAge(10 * time.Minute)
+
+			var req any = &v0.DeleteTenantRequest{TenantId: tenantID}
+			if tc.method == v0.TenantService_UpdateTenant_FullMethodName {
+				req = &v0.UpdateTenantRequest{Tenant: &v0.Tenant{Id: tenantID}}
+			}
+			if err := a.Authorize(ctx, tc.method, req); status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestAccessControl_StepUp(t *testing.T) {
+	tenantID := "tenant-1"
+	userID := "user-1"
+	policy := &StepUpPolicy{Scope: "tenant:admin:destroy", MFAMethods: []string{"totp", "webauthn"}, MaxAge: 15 * time.Minute}
+
+	testCases := []struct {
+		name         string
+		principal    *authentication.Principal
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Elevated scope",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, Scopes: []string{"tenant:admin:destroy"}},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Recent MFA login",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Minute), AuthMethods: []string{"password", "totp"}},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Recent login without MFA",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Minute), AuthMethods: []string{"password"}},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Stale MFA login",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalUser, AuthTime: time.Now().Add(-time.Hour), AuthMethods: []string{"webauthn"}},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Service without scope",
+			principal:    &authentication.Principal{ID: userID, Type: authentication.PrincipalService},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+			ctx := authentication.WithPrincipal(context.Background(), tc.principal)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+			mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, "can_delete").Return(true, nil)
+			if tc.expectedCode != codes.OK {
+				mockSecurity.EXPECT().AuthzFailure(userID, v0.TenantService_DeleteTenant_FullMethodName, gomock.Any(), logging.WithLabel("reason", "step_up_required"))
+			}
+
+			a := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			a.SetStepUpPolicy(policy)
+
+			err := a.Authorize(ctx, v0.TenantService_DeleteTenant_FullMethodName, &v0.DeleteTenantRequest{TenantId: tenantID})
+			if status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+			}
+			if err == nil {
+				return
+			}
+
+			details := status.Convert(err).Details()
+			if len(details) != 1 {
+				t.Fatalf("expected the error info, got %v", details)
+			}
+			info, ok := details[0].(*errdetails.ErrorInfo)
+			if !ok || info.Reason != ErrorReasonStepUpRequired || info.Metadata["required_scope"] != policy.Scope || info.Metadata["amr_values"] != "totp webauthn" || info.Metadata["max_age"] != "900" {
+				t.Errorf("expected the step-up error info, got %v", details[0])
+			}
+		})
+	}
+}
+
+func TestAccessControl_EnforceTenantScope(t *testing.T) {
+	tenantID := "tenant-1"
+	userID := "user-1"
+
+	testCases := []struct {
+		name         string
+		enforce      bool
+		method       string
+		req          any
+		allowed      bool
+		permission   string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Update without the toggle",
+			method:       v0.TenantService_UpdateTenantUser_FullMethodName,
+			req:          &v0.UpdateTenantUserRequest{TenantId: tenantID},
+			allowed:      true,
+			permission:   "can_edit",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Update by a tenant admin",
+			enforce:      true,
+			method:       v0.TenantService_UpdateTenantUser_FullMethodName,
+			req:          &v0.UpdateTenantUserRequest{TenantId: tenantID},
+			allowed:      true,
+			permission:   "can_admin",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Update by an editor",
+			enforce:      true,
+			method:       v0.TenantService_UpdateTenantUser_FullMethodName,
+			req:          &v0.UpdateTenantUserRequest{TenantId: tenantID},
+			permission:   "can_admin",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Remove by an editor",
+			enforce:      true,
+			method:       v0.TenantService_RemoveTenantUser_FullMethodName,
+			req:          &v0.RemoveTenantUserRequest{TenantId: tenantID},
+			permission:   "can_admin",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "List by a viewer",
+			enforce:      true,
+			method:       v0.TenantService_ListTenantUsers_FullMethodName,
+			req:          &v0.ListTenantUsersRequest{TenantId: tenantID},
+			allowed:      true,
+			permission:   "can_view",
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+			ctx := authentication.WithUserID(context.Background(), userID)
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+			mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenantID, userID, tc.permission).Return(tc.allowed, nil)
+			if !tc.allowed {
+				mockSecurity.EXPECT().AuthzFailureInsufficientPermissions(userID, tc.permission, tc.method, gomock.Any())
+			}
+
+			a := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			a.SetEnforceTenantScope(tc.enforce)
+
+			if err := a.Authorize(ctx, tc.method, tc.req); status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %v, got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestAccessControl_UnaryServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthz := NewMockAuthzInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+	ctx := authentication.WithUserID(context.Background(), "user-1")
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+	mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", "user-1", "can_delete").Return(false, nil)
+	mockSecurity.EXPECT().AuthzFailureInsufficientPermissions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	a := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, mockMonitor, mockLogger)
+
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	_, err := a.UnaryServerInterceptor(ctx, &v0.DeleteTenantRequest{TenantId: "tenant-1"}, &grpc.UnaryServerInfo{FullMethod: v0.TenantService_DeleteTenant_FullMethodName}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	if called {
+		t.Fatal("handler must not be called when access is denied")
+	}
+}
+
+// TestAccessControl_Server checks that every RPC of methodPermissions is
+// authorized when the server is called in-process.
+func TestAccessControl_Server(t *testing.T) {
+	calls := map[string]func(context.Context, v0.TenantServiceServer) error{
+		v0.TenantService_InviteMember_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.InviteMember(ctx, &v0.InviteMemberRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListTenantUsers_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListTenantUsers(ctx, &v0.ListTenantUsersRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_UpdateTenant_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.UpdateTenant(ctx, &v0.UpdateTenantRequest{Tenant: &v0.Tenant{Id: "tenant-1"}})
+			return err
+		},
+		v0.TenantService_DeleteTenant_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.DeleteTenant(ctx, &v0.DeleteTenantRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ProvisionUser_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ProvisionUser(ctx, &v0.ProvisionUserRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_UpdateTenantUser_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.UpdateTenantUser(ctx, &v0.UpdateTenantUserRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_GetTenantUser_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.GetTenantUser(ctx, &v0.GetTenantUserRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_RemoveTenantUser_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.RemoveTenantUser(ctx, &v0.RemoveTenantUserRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_CreateRole_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.CreateRole(ctx, &v0.CreateRoleRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListRoles_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListRoles(ctx, &v0.ListRolesRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_UpdateRole_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.UpdateRole(ctx, &v0.UpdateRoleRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_DeleteRole_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.DeleteRole(ctx, &v0.DeleteRoleRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_AssignRole_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.AssignRole(ctx, &v0.AssignRoleRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_UnassignRole_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.UnassignRole(ctx, &v0.UnassignRoleRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_CreateAPIKey_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.CreateAPIKey(ctx, &v0.CreateAPIKeyRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListAPIKeys_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListAPIKeys(ctx, &v0.ListAPIKeysRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_RevokeAPIKey_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.RevokeAPIKey(ctx, &v0.RevokeAPIKeyRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListInvites_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListInvites(ctx, &v0.ListInvitesRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_RevokeInvite_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.RevokeInvite(ctx, &v0.RevokeInviteRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ResendInvite_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ResendInvite(ctx, &v0.ResendInviteRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_GetTenantStats_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.GetTenantStats(ctx, &v0.GetTenantStatsRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_CreateWebhookSubscription_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.CreateWebhookSubscription(ctx, &v0.CreateWebhookSubscriptionRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListWebhookSubscriptions_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListWebhookSubscriptions(ctx, &v0.ListWebhookSubscriptionsRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_DeleteWebhookSubscription_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.DeleteWebhookSubscription(ctx, &v0.DeleteWebhookSubscriptionRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListWebhookDeliveries_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListWebhookDeliveries(ctx, &v0.ListWebhookDeliveriesRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ReplayWebhookDelivery_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ReplayWebhookDelivery(ctx, &v0.ReplayWebhookDeliveryRequest{TenantId: "tenant-1"})
+			return err
+		},
+	}
+
+	if len(calls) != len(methodPermissions) {
+		t.Fatalf("expected a call for each of the %d tenant scoped methods, got %d", len(methodPermissions), len(calls))
+	}
+
+	for method, permission := range methodPermissions {
+		t.Run(method, func(t *testing.T) {
+			call, ok := calls[method]
+			if !ok {
+				t.Fatalf("no call defined for %s", method)
+			}
+
+			for _, allowed := range []bool{false, true} {
+				ctrl := gomock.NewController(t)
+
+				mockAuthz := NewMockAuthzInterface(ctrl)
+				mockTracer := NewMockTracingInterface(ctrl)
+				mockMonitor := NewMockMonitorInterface(ctrl)
+				mockLogger := NewMockLoggerInterface(ctrl)
+				mockSecurity := setupLoggerMock(ctrl, mockLogger)
+
+				ctx := authentication.WithUserID(context.Background(), "user-1")
+				mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(ctx, trace.SpanFromContext(ctx)).AnyTimes()
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", "user-1", permission).Return(allowed, nil)
+				mockSecurity.EXPECT().AuthzFailureInsufficientPermissions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+				server := NewAccessControl(mockAuthz, NewMockStorageInterface(ctrl), "", nil, mockTracer, mockMonitor, mockLogger).Server(&v0.UnimplementedTenantServiceServer{})
+
+				// an allowed call reaches the wrapped server
+				expected := codes.PermissionDenied
+				if allowed {
+					expected = codes.Unimplemented
+				}
+
+				if err := call(ctx, server); status.Code(err) != expected {
+					t.Fatalf("expected %v, got %v", expected, err)
+				}
+
+				ctrl.Finish()
+			}
+		})
+	}
+}
+
+// TestAccessControl_ServerAPIKey checks that API keys are denied the RPCs not
+// scoped to a tenant when the server is called in-process.
+func TestAccessControl_ServerAPIKey(t *testing.T) {
+	calls := map[string]func(context.Context, v0.TenantServiceServer) error{
+		v0.TenantService_WhoAmI_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.WhoAmI(ctx, &v0.WhoAmIRequest{})
+			return err
+		},
+		v0.TenantService_ListMyTenants_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListMyTenants(ctx, &v0.ListMyTenantsRequest{})
+			return err
+		},
+		v0.TenantService_GetMyPermissions_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.GetMyPermissions(ctx, &v0.GetMyPermissionsRequest{})
+			return err
+		},
+		v0.TenantService_ListTenants_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListTenants(ctx, &v0.ListTenantsRequest{})
+			return err
+		},
+		v0.TenantService_ListUserTenants_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListUserTenants(ctx, &v0.ListUserTenantsRequest{})
+			return err
+		},
+		v0.TenantService_CreateTenant_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.CreateTenant(ctx, &v0.CreateTenantRequest{})
+			return err
+		},
+		v0.TenantService_RunDiagnostics_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.RunDiagnostics(ctx, &v0.RunDiagnosticsRequest{})
+			return err
+		},
+		v0.TenantService_AddPlatformAdmin_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.AddPlatformAdmin(ctx, &v0.AddPlatformAdminRequest{})
+			return err
+		},
+		v0.TenantService_RemovePlatformAdmin_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.RemovePlatformAdmin(ctx, &v0.RemovePlatformAdminRequest{})
+			return err
+		},
+		v0.TenantService_ListPlatformAdmins_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListPlatformAdmins(ctx, &v0.ListPlatformAdminsRequest{})
+			return err
+		},
+		v0.TenantService_LinkTenantToSupportGroup_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.LinkTenantToSupportGroup(ctx, &v0.LinkTenantToSupportGroupRequest{TenantId: "tenant-1"})
+			return err
+		},
+		v0.TenantService_ListAuthzAudit_FullMethodName: func(ctx context.Context, s v0.TenantServiceServer) error {
+			_, err := s.ListAuthzAudit(ctx, &v0.ListAuthzAuditRequest{})
+			return err
+		},
+	}
+
+	if len(calls)+len(methodPermissions) != len(v0.TenantService_ServiceDesc.Methods) {
+		t.Fatalf("expected a call for each of the methods not tenant scoped, got %d", len(calls))
+	}
+
+	for method, call := range calls {
+		t.Run(method, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := setupLoggerMock(ctrl, mockLogger)
+			mockSecurity.EXPECT().AuthzFailure("key-1", method, gomock.Any())
+
+			ctx := authentication.WithPrincipal(context.Background(), &authentication.Principal{ID: "key-1", Type: authentication.PrincipalAPIKey, TenantID: "tenant-1", TenantRole: types.RoleOwner})
+			server := NewAccessControl(NewMockAuthzInterface(ctrl), NewMockStorageInterface(ctrl), "", nil, NewMockTracingInterface(ctrl), NewMockMonitorInterface(ctrl), mockLogger).Server(&v0.UnimplementedTenantServiceServer{})
+
+			if err := call(ctx, server); status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("expected %v, got %v", codes.PermissionDenied, err)
+			}
+		})
+	}
+}
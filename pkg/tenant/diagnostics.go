@@ -0,0 +1,243 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/kratos"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+const (
+	// AnomalyOrphanedMembership is a membership pointing at a Kratos identity that no longer exists.
+	AnomalyOrphanedMembership = "orphaned_membership"
+	// AnomalyOwnerlessTenant is an enabled tenant without any owner membership.
+	AnomalyOwnerlessTenant = "ownerless_tenant"
+	// AnomalyMissingTuple is a membership without the matching OpenFGA relation.
+	AnomalyMissingTuple = "missing_tuple"
+	// AnomalyDuplicateMembership is a user holding OpenFGA relations that do not match the stored role.
+	AnomalyDuplicateMembership = "duplicate_membership"
+)
+
+// AnomalyCategories lists every category reported by RunDiagnostics.
+var AnomalyCategories = []string{
+	AnomalyOrphanedMembership,
+	AnomalyOwnerlessTenant,
+	AnomalyMissingTuple,
+	AnomalyDuplicateMembership,
+}
+
+// RunDiagnostics scans every tenant for inconsistencies between the database,
+// Kratos and OpenFGA. Anomalies whose category is listed in fix are repaired
+// in place and reported with Fixed set.
+func (s *Service) RunDiagnostics(ctx context.Context, fix []string) ([]*types.Anomaly, error) {
+	ctx, span := s.tracer.Start(ctx, "admin.RunDiagnostics")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	s.logger.Debugw("running tenant diagnostics", "fix", fix, "actor", actor)
+
+	tenants, err := s.storage.ListTenants(ctx)
+	if err != nil {
+		s.recordError(span, "failed to list tenants", err)
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	anomalies := make([]*types.Anomaly, 0)
+	for _, t := range tenants {
+		found, err := s.diagnoseTenant(ctx, t, fix, actor)
+		if err != nil {
+			s.recordError(span, "failed to diagnose tenant", err, "tenant_id", t.ID)
+			return nil, err
+		}
+		anomalies = append(anomalies, found...)
+	}
+
+	s.logger.Infow("tenant diagnostics completed", "tenants", len(tenants), "anomalies", len(anomalies))
+	return anomalies, nil
+}
+
+func (s *Service) diagnoseTenant(ctx context.Context, t *types.Tenant, fix []string, actor string) ([]*types.Anomaly, error) {
+	members, err := s.storage.ListMembersByTenantID(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of tenant %s: %w", t.ID, err)
+	}
+
+	tuples, err := s.authz.ListTenantTuples(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tuples of tenant %s: %w", t.ID, err)
+	}
+
+	// relations holds the direct user relations recorded in OpenFGA, keyed by user ID
+	relations := make(map[string][]string)
+	for _, tuple := range tuples {
+		userID, ok := authorization.UserIDFromTuple(tuple.User)
+		if !ok {
+			continue
+		}
+		relations[userID] = append(relations[userID], tuple.Relation)
+	}
+
+	var anomalies []*types.Anomaly
+	owners := 0
+	for _, m := range members {
+		_, err := s.kratos.GetIdentity(ctx, m.KratosIdentityID)
+		if errors.Is(err, kratos.ErrIdentityNotFound) {
+			a := &types.Anomaly{
+				Category: AnomalyOrphanedMembership,
+				TenantID: t.ID,
+				UserID:   m.KratosIdentityID,
+				Detail:   fmt.Sprintf("membership with role %s references a deleted identity", m.Role),
+			}
+			if slices.Contains(fix, a.Category) {
+				a.Fixed = s.fixOrphanedMembership(ctx, m, relations[m.KratosIdentityID], actor)
+			}
+			anomalies = append(anomalies, a)
+			continue
+		}
+		if err != nil {
+			// Kratos might be temporarily unavailable, we cannot tell whether the identity is gone
+			s.logger.Warnw("failed to get identity; skipping orphan check",
+				"tenant_id", t.ID,
+				"user_id", m.KratosIdentityID,
+				"error", err,
+			)
+		}
+
+		if m.Role == types.RoleOwner {
+			owners++
+		}
+
+		expected := authorization.RelationForRole(m.Role)
+		held := relations[m.KratosIdentityID]
+		if !slices.Contains(held, expected) {
+			a := &types.Anomaly{
+				Category: AnomalyMissingTuple,
+				TenantID: t.ID,
+				UserID:   m.KratosIdentityID,
+				Detail:   fmt.Sprintf("role %s has no %s relation", m.Role, expected),
+			}
+			if slices.Contains(fix, a.Category) {
+				a.Fixed = s.fixMissingTuple(ctx, m, actor)
+			}
+			anomalies = append(anomalies, a)
+		}
+
+		var extra []string
+		for _, r := range held {
+			if r != expected && slices.Contains(authorization.MembershipRelations, r) {
+				extra = append(extra, r)
+			}
+		}
+		if len(extra) > 0 {
+			a := &types.Anomaly{
+				Category: AnomalyDuplicateMembership,
+				TenantID: t.ID,
+				UserID:   m.KratosIdentityID,
+				Detail:   fmt.Sprintf("role %s also holds relations %v", m.Role, extra),
+			}
+			if slices.Contains(fix, a.Category) {
+				a.Fixed = s.fixDuplicateMembership(ctx, m, extra, actor)
+			}
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	if owners == 0 && t.Enabled {
+		a := &types.Anomaly{
+			Category: AnomalyOwnerlessTenant,
+			TenantID: t.ID,
+			Detail:   "tenant has no owner",
+		}
+		if slices.Contains(fix, a.Category) {
+			a.Fixed = s.fixOwnerlessTenant(ctx, t, actor)
+		}
+		anomalies = append(anomalies, a)
+	}
+
+	return anomalies, nil
+}
+
+// fixOrphanedMembership drops any relation the deleted identity still holds,
+// then the membership row in the transaction of the request. The row is kept
+// when the relations cannot be removed, so that a later run finds them again.
+func (s *Service) fixOrphanedMembership(ctx context.Context, m *types.Membership, held []string, actor string) bool {
+	if !s.removeRelations(ctx, m, held) {
+		return false
+	}
+
+	if err := s.storage.DeleteMember(ctx, m.TenantID, m.KratosIdentityID); err != nil {
+		s.logger.Errorw("failed to delete orphaned membership",
+			"tenant_id", m.TenantID,
+			"user_id", m.KratosIdentityID,
+			"error", err,
+		)
+		return false
+	}
+
+	s.logger.Security().AdminAction(actor, "fix_orphaned_membership", "tenant.Service.RunDiagnostics", m.TenantID+":"+m.KratosIdentityID, authentication.PrincipalLabel(ctx))
+	return true
+}
+
+// fixMissingTuple writes the relation matching the stored role.
+func (s *Service) fixMissingTuple(ctx context.Context, m *types.Membership, actor string) bool {
+	if err := s.assignRole(ctx, m.TenantID, m.KratosIdentityID, m.Role); err != nil {
+		s.logger.Errorw("failed to restore missing relation",
+			"tenant_id", m.TenantID,
+			"user_id", m.KratosIdentityID,
+			"role", m.Role,
+			"error", err,
+		)
+		return false
+	}
+
+	s.logger.Security().AdminAction(actor, "fix_missing_tuple", "tenant.Service.RunDiagnostics", m.TenantID+":"+m.KratosIdentityID, authentication.PrincipalLabel(ctx))
+	return true
+}
+
+// fixDuplicateMembership removes the relations that do not match the stored role.
+func (s *Service) fixDuplicateMembership(ctx context.Context, m *types.Membership, extra []string, actor string) bool {
+	if !s.removeRelations(ctx, m, extra) {
+		return false
+	}
+
+	s.logger.Security().AdminAction(actor, "fix_duplicate_membership", "tenant.Service.RunDiagnostics", m.TenantID+":"+m.KratosIdentityID, authentication.PrincipalLabel(ctx))
+	return true
+}
+
+// fixOwnerlessTenant disables the tenant so that it can no longer be used until an owner is assigned.
+func (s *Service) fixOwnerlessTenant(ctx context.Context, t *types.Tenant, actor string) bool {
+	disabled := &types.Tenant{ID: t.ID, Enabled: false}
+	if err := s.storage.UpdateTenant(ctx, disabled, []string{"enabled"}); err != nil {
+		s.logger.Errorw("failed to disable ownerless tenant", "tenant_id", t.ID, "error", err)
+		return false
+	}
+
+	s.logger.Security().AdminAction(actor, "fix_ownerless_tenant", "tenant.Service.RunDiagnostics", t.ID, authentication.PrincipalLabel(ctx))
+	return true
+}
+
+func (s *Service) removeRelations(ctx context.Context, m *types.Membership, relations []string) bool {
+	for _, r := range relations {
+		if !slices.Contains(authorization.MembershipRelations, r) {
+			continue
+		}
+		if err := s.removeRelation(ctx, m.TenantID, m.KratosIdentityID, r); err != nil {
+			s.logger.Errorw("failed to remove relation",
+				"tenant_id", m.TenantID,
+				"user_id", m.KratosIdentityID,
+				"relation", r,
+				"error", err,
+			)
+			return false
+		}
+	}
+	return true
+}
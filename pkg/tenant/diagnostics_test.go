@@ -0,0 +1,190 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/canonical/tenant-service/internal/kratos"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/types"
+	ory "github.com/ory/client-go"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_RunDiagnostics(t *testing.T) {
+	tenant := &types.Tenant{ID: "tenant-1", Name: "Tenant 1", Enabled: true}
+	owner := &types.Membership{TenantID: "tenant-1", KratosIdentityID: "user-1", Role: "owner"}
+	member := &types.Membership{TenantID: "tenant-1", KratosIdentityID: "user-2", Role: "member"}
+	ownerTuple := *openfga.NewTuple("user:user-1", "owner", "tenant:tenant-1")
+	memberTuple := *openfga.NewTuple("user:user-2", "member", "tenant:tenant-1")
+	identity := &ory.Identity{}
+
+	testCases := []struct {
+		name        string
+		fix         []string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface)
+		expected    []types.Anomaly
+		expectedErr bool
+	}{
+		{
+			name: "consistent tenant",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner, member}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{ownerTuple, memberTuple}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(identity, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-2").Return(identity, nil)
+			},
+			expected: []types.Anomaly{},
+		},
+		{
+			name: "orphaned membership fixed",
+			fix:  []string{AnomalyOrphanedMembership},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner, member}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{ownerTuple, memberTuple}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(identity, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-2").Return(nil, kratos.ErrIdentityNotFound)
+				gomock.InOrder(
+					mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), "tenant-1", "user-2").Return(nil),
+					mockStorage.EXPECT().DeleteMember(gomock.Any(), "tenant-1", "user-2").Return(nil),
+				)
+			},
+			expected: []types.Anomaly{
+				{Category: AnomalyOrphanedMembership, TenantID: "tenant-1", UserID: "user-2", Fixed: true},
+			},
+		},
+		{
+			name: "orphaned membership kept when its relations cannot be removed",
+			fix:  []string{AnomalyOrphanedMembership},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner, member}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{ownerTuple, memberTuple}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(identity, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-2").Return(nil, kratos.ErrIdentityNotFound)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), "tenant-1", "user-2").Return(errors.New("fga down"))
+				// no DeleteMember, the row is left for the next run
+			},
+			expected: []types.Anomaly{
+				{Category: AnomalyOrphanedMembership, TenantID: "tenant-1", UserID: "user-2", Fixed: false},
+			},
+		},
+		{
+			name: "kratos error does not report orphan",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{ownerTuple}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(nil, errors.New("kratos unavailable"))
+			},
+			expected: []types.Anomaly{},
+		},
+		{
+			name: "missing tuple fixed",
+			fix:  []string{AnomalyMissingTuple},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return(nil, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(identity, nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), "tenant-1", "user-1").Return(nil)
+			},
+			expected: []types.Anomaly{
+				{Category: AnomalyMissingTuple, TenantID: "tenant-1", UserID: "user-1", Fixed: true},
+			},
+		},
+		{
+			name: "duplicate membership reported only",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{
+					ownerTuple,
+					*openfga.NewTuple("user:user-1", "member", "tenant:tenant-1"),
+				}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-1").Return(identity, nil)
+			},
+			expected: []types.Anomaly{
+				{Category: AnomalyDuplicateMembership, TenantID: "tenant-1", UserID: "user-1"},
+			},
+		},
+		{
+			name: "ownerless tenant fixed",
+			fix:  []string{AnomalyOwnerlessTenant},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{member}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return([]openfga.Tuple{memberTuple}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-2").Return(identity, nil)
+				mockStorage.EXPECT().UpdateTenant(gomock.Any(), &types.Tenant{ID: "tenant-1", Enabled: false}, []string{"enabled"}).Return(nil)
+			},
+			expected: []types.Anomaly{
+				{Category: AnomalyOwnerlessTenant, TenantID: "tenant-1", Fixed: true},
+			},
+		},
+		{
+			name: "storage error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().ListTenants(gomock.Any()).Return([]*types.Tenant{tenant}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), "tenant-1").Return([]*types.Membership{owner}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), "tenant-1").Return(nil, errors.New("fga error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.RunDiagnostics").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz, mockKratos)
+
+			anomalies, err := s.RunDiagnostics(context.Background(), tc.fix)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(anomalies) != len(tc.expected) {
+				t.Fatalf("expected %d anomalies, got %d: %+v", len(tc.expected), len(anomalies), anomalies)
+			}
+			for i, want := range tc.expected {
+				got := anomalies[i]
+				if got.Category != want.Category || got.TenantID != want.TenantID || got.UserID != want.UserID || got.Fixed != want.Fixed {
+					t.Errorf("anomaly %d: expected %+v, got %+v", i, want, *got)
+				}
+			}
+		})
+	}
+}
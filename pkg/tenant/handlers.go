@@ -5,49 +5,103 @@ package tenant
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"github.com/canonical/tenant-service/internal/apierror"
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/tracing"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/internal/validation"
+	"github.com/canonical/tenant-service/pkg/apikey"
 	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/deprecation"
+	"github.com/canonical/tenant-service/pkg/role"
+	"github.com/canonical/tenant-service/pkg/subscription"
 	v0 "github.com/canonical/tenant-service/v0"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+	// maxListPageSize bounds the page_size of the tenant and user listings,
+	// which return every item when it is 0
+	maxListPageSize = 1000
+)
+
 type Handler struct {
 	v0.UnimplementedTenantServiceServer
-	service ServiceInterface
-	tracer  tracing.TracingInterface
-	monitor monitoring.MonitorInterface
-	logger  logging.LoggerInterface
+	service       ServiceInterface
+	roles         RoleServiceInterface
+	apiKeys       APIKeyServiceInterface
+	subscriptions SubscriptionServiceInterface
+	idempotency   IdempotencyInterface
+	deprecations  DeprecationInterface
+	tracer        tracing.TracingInterface
+	monitor       monitoring.MonitorInterface
+	logger        logging.LoggerInterface
 }
 
 func NewHandler(
 	service ServiceInterface,
+	roles RoleServiceInterface,
+	apiKeys APIKeyServiceInterface,
+	idempotency IdempotencyInterface,
+	deprecations DeprecationInterface,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
 ) *Handler {
 	return &Handler{
-		service: service,
-		tracer:  tracer,
-		monitor: monitor,
-		logger:  logger,
+		service:      service,
+		roles:        roles,
+		apiKeys:      apiKeys,
+		idempotency:  idempotency,
+		deprecations: deprecations,
+		tracer:       tracer,
+		monitor:      monitor,
+		logger:       logger,
 	}
 }
 
+// SetSubscriptions serves the webhook subscriptions of the tenants, their
+// RPCs are unimplemented otherwise.
+func (h *Handler) SetSubscriptions(subscriptions SubscriptionServiceInterface) {
+	h.subscriptions = subscriptions
+}
+
 func (h *Handler) InviteMember(ctx context.Context, req *v0.InviteMemberRequest) (*v0.InviteMemberResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.InviteMember")
 	defer span.End()
 
-	if req.TenantId == "" || req.Email == "" || req.Role == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id, email, and role are required")
+	h.deprecations.Warn(ctx, deprecation.StringRole)
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		Email("email", req.Email).
+		Role("role", req.Role).
+		Err(); err != nil {
+		return nil, err
 	}
 
-	link, code, err := h.service.InviteMember(ctx, req.TenantId, req.Email, req.Role)
+	resp, err := h.idempotency.Execute(ctx, "InviteMember", req.IdempotencyKey, req, func(ctx context.Context) (proto.Message, error) {
+		return h.inviteMember(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*v0.InviteMemberResponse), nil
+}
+
+func (h *Handler) inviteMember(ctx context.Context, req *v0.InviteMemberRequest) (*v0.InviteMemberResponse, error) {
+	link, code, err := h.service.InviteMember(ctx, req.TenantId, req.Email, types.MembershipRole(req.Role))
 	if err != nil {
 		h.logger.Errorw("failed to invite member",
 			"tenant_id", req.TenantId,
@@ -55,8 +109,7 @@ func (h *Handler) InviteMember(ctx context.Context, req *v0.InviteMemberRequest)
 			"role", req.Role,
 			"error", err,
 		)
-		// In a real app, you might map specific error types to gRPC codes here
-		return nil, status.Errorf(codes.Internal, "failed to invite member: %v", err)
+		return nil, quotaError("failed to invite member", err)
 	}
 
 	return &v0.InviteMemberResponse{
@@ -66,10 +119,142 @@ func (h *Handler) InviteMember(ctx context.Context, req *v0.InviteMemberRequest)
 	}, nil
 }
 
+func (h *Handler) ListInvites(ctx context.Context, req *v0.ListInvitesRequest) (*v0.ListInvitesResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListInvites")
+	defer span.End()
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	invites, err := h.service.ListInvites(ctx, req.TenantId)
+	if err != nil {
+		h.logger.Errorw("failed to list invites", "tenant_id", req.TenantId, "error", err)
+		return nil, inviteError("failed to list invites", err)
+	}
+
+	now := time.Now()
+	pbInvites := make([]*v0.Invite, len(invites))
+	for i, invite := range invites {
+		pbInvites[i] = toProtoInvite(invite, now)
+	}
+
+	return &v0.ListInvitesResponse{
+		Invites: pbInvites,
+	}, nil
+}
+
+func (h *Handler) RevokeInvite(ctx context.Context, req *v0.RevokeInviteRequest) (*v0.Invite, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.RevokeInvite")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("invite_id", req.InviteId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	invite, err := h.service.RevokeInvite(ctx, req.TenantId, req.InviteId)
+	if err != nil {
+		h.logger.Errorw("failed to revoke invite", "tenant_id", req.TenantId, "invite_id", req.InviteId, "error", err)
+		return nil, inviteError("failed to revoke invite", err)
+	}
+
+	return toProtoInvite(invite, time.Now()), nil
+}
+
+func (h *Handler) ResendInvite(ctx context.Context, req *v0.ResendInviteRequest) (*v0.ResendInviteResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ResendInvite")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("invite_id", req.InviteId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	invite, link, code, err := h.service.ResendInvite(ctx, req.TenantId, req.InviteId)
+	if err != nil {
+		h.logger.Errorw("failed to resend invite", "tenant_id", req.TenantId, "invite_id", req.InviteId, "error", err)
+		return nil, inviteError("failed to resend invite", err)
+	}
+
+	return &v0.ResendInviteResponse{
+		Invite: toProtoInvite(invite, time.Now()),
+		Link:   link,
+		Code:   code,
+	}, nil
+}
+
+func (h *Handler) GetTenantStats(ctx context.Context, req *v0.GetTenantStatsRequest) (*v0.GetTenantStatsResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.GetTenantStats")
+	defer span.End()
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	stats, err := h.service.GetTenantStats(ctx, req.TenantId)
+	if err != nil {
+		h.logger.Errorw("failed to get tenant stats", "tenant_id", req.TenantId, "error", err)
+		return nil, apierror.Error("failed to get tenant stats", err)
+	}
+
+	return &v0.GetTenantStatsResponse{
+		TenantId:       stats.TenantID,
+		Members:        toProtoQuotaUsage(stats.Members),
+		PendingInvites: toProtoQuotaUsage(stats.PendingInvites),
+	}, nil
+}
+
+func (h *Handler) WhoAmI(ctx context.Context, req *v0.WhoAmIRequest) (*v0.WhoAmIResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.WhoAmI")
+	defer span.End()
+
+	principal, ok := authentication.GetPrincipal(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	memberships, err := h.service.ListUserMemberships(ctx, principal.ID)
+	if err != nil {
+		h.logger.Errorw("failed to list memberships", "user_id", principal.ID, "error", err)
+		return nil, apierror.Error("failed to list memberships", err)
+	}
+
+	pbMemberships := make([]*v0.Membership, len(memberships))
+	for i, m := range memberships {
+		pbMemberships[i] = &v0.Membership{
+			Tenant: &v0.Tenant{
+				Id:        m.Tenant.ID,
+				Name:      m.Tenant.Name,
+				CreatedAt: m.Tenant.CreatedAt.String(),
+				Enabled:   m.Tenant.Enabled,
+				Region:    m.Tenant.Region,
+			},
+			Role:        string(m.Role),
+			Permissions: m.Permissions,
+		}
+	}
+
+	return &v0.WhoAmIResponse{
+		Subject:       principal.ID,
+		Email:         principal.Email,
+		PrincipalType: string(principal.Type),
+		AuthMethod:    string(principal.Method),
+		Scopes:        principal.Scopes,
+		Memberships:   pbMemberships,
+	}, nil
+}
+
 func (h *Handler) ListMyTenants(ctx context.Context, req *v0.ListMyTenantsRequest) (*v0.ListMyTenantsResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListMyTenants")
 	defer span.End()
 
+	h.deprecations.Warn(ctx, deprecation.UnpaginatedList)
+
 	// Extract user_id from context
 	userID, ok := authentication.GetUserID(ctx)
 	if !ok {
@@ -79,7 +264,7 @@ func (h *Handler) ListMyTenants(ctx context.Context, req *v0.ListMyTenantsReques
 	tenants, err := h.service.ListTenantsByUserID(ctx, userID)
 	if err != nil {
 		h.logger.Errorw("failed to list tenants", "user_id", userID, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to list tenants: %v", err)
+		return nil, apierror.Error("failed to list tenants", err)
 	}
 
 	pbTenants := make([]*v0.Tenant, len(tenants))
@@ -89,6 +274,7 @@ func (h *Handler) ListMyTenants(ctx context.Context, req *v0.ListMyTenantsReques
 			Name:      t.Name,
 			CreatedAt: t.CreatedAt.String(),
 			Enabled:   t.Enabled,
+			Region:    t.Region,
 		}
 	}
 
@@ -97,14 +283,53 @@ func (h *Handler) ListMyTenants(ctx context.Context, req *v0.ListMyTenantsReques
 	}, nil
 }
 
+func (h *Handler) GetMyPermissions(ctx context.Context, req *v0.GetMyPermissionsRequest) (*v0.GetMyPermissionsResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.GetMyPermissions")
+	defer span.End()
+
+	userID, ok := authentication.GetUserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	permissions, err := h.service.GetUserPermissions(ctx, req.TenantId, userID)
+	if err != nil {
+		h.logger.Errorw("failed to get permissions", "tenant_id", req.TenantId, "user_id", userID, "error", err)
+		return nil, apierror.Error("failed to get permissions", err)
+	}
+
+	return &v0.GetMyPermissionsResponse{Permissions: permissions}, nil
+}
+
 func (h *Handler) ListTenants(ctx context.Context, req *v0.ListTenantsRequest) (*v0.ListTenantsResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListTenants")
 	defer span.End()
 
-	tenants, err := h.service.ListTenants(ctx)
+	if err := validation.New().PageSize("page_size", req.PageSize, maxListPageSize).Err(); err != nil {
+		return nil, err
+	}
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if req.PageSize == 0 {
+		h.deprecations.Warn(ctx, deprecation.UnpaginatedList)
+	}
+
+	filter := &types.TenantFilter{
+		Enabled:      req.Enabled,
+		NameContains: req.NameContains,
+		Offset:       offset,
+		Limit:        uint64(req.PageSize),
+	}
+	tenants, err := h.service.ListTenants(ctx, filter)
 	if err != nil {
 		h.logger.Errorw("failed to list all tenants", "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to list all tenants: %v", err)
+		return nil, apierror.Error("failed to list all tenants", err)
 	}
 
 	pbTenants := make([]*v0.Tenant, len(tenants))
@@ -114,11 +339,13 @@ func (h *Handler) ListTenants(ctx context.Context, req *v0.ListTenantsRequest) (
 			Name:      t.Name,
 			CreatedAt: t.CreatedAt.String(),
 			Enabled:   t.Enabled,
+			Region:    t.Region,
 		}
 	}
 
 	return &v0.ListTenantsResponse{
-		Tenants: pbTenants,
+		Tenants:       pbTenants,
+		NextPageToken: nextPageToken(offset, filter.Limit, len(tenants)),
 	}, nil
 }
 
@@ -126,14 +353,24 @@ func (h *Handler) CreateTenant(ctx context.Context, req *v0.CreateTenantRequest)
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.CreateTenant")
 	defer span.End()
 
-	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant name is required")
+	if err := validation.New().TenantName("name", req.Name).Region("region", req.Region).Err(); err != nil {
+		return nil, err
 	}
 
-	tenant, err := h.service.CreateTenant(ctx, req.Name)
+	resp, err := h.idempotency.Execute(ctx, "CreateTenant", req.IdempotencyKey, req, func(ctx context.Context) (proto.Message, error) {
+		return h.createTenant(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*v0.CreateTenantResponse), nil
+}
+
+func (h *Handler) createTenant(ctx context.Context, req *v0.CreateTenantRequest) (*v0.CreateTenantResponse, error) {
+	tenant, err := h.service.CreateTenant(ctx, req.Name, req.Region)
 	if err != nil {
 		h.logger.Errorw("failed to create tenant", "name", req.Name, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to create tenant: %v", err)
+		return nil, apierror.Error("failed to create tenant", err)
 	}
 
 	return &v0.CreateTenantResponse{
@@ -142,6 +379,7 @@ func (h *Handler) CreateTenant(ctx context.Context, req *v0.CreateTenantRequest)
 			Name:      tenant.Name,
 			CreatedAt: tenant.CreatedAt.String(),
 			Enabled:   tenant.Enabled,
+			Region:    tenant.Region,
 		},
 	}, nil
 }
@@ -160,16 +398,28 @@ func (h *Handler) UpdateTenant(ctx context.Context, req *v0.UpdateTenantRequest)
 		paths = req.UpdateMask.Paths
 	}
 
+	v := validation.New().UUID("tenant.id", req.Tenant.Id)
+	if slices.Contains(paths, "name") {
+		v.TenantName("tenant.name", req.Tenant.Name)
+	}
+	if slices.Contains(paths, "region") {
+		v.Region("tenant.region", req.Tenant.Region)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
 	updateData := &types.Tenant{
 		ID:      req.Tenant.Id, // From URL usually
 		Name:    req.Tenant.Name,
 		Enabled: req.Tenant.Enabled,
+		Region:  req.Tenant.Region,
 	}
 
 	tenant, err := h.service.UpdateTenant(ctx, updateData, paths)
 	if err != nil {
 		h.logger.Errorw("failed to update tenant", "tenant_id", req.Tenant.Id, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to update tenant: %v", err)
+		return nil, apierror.Error("failed to update tenant", err)
 	}
 
 	return &v0.UpdateTenantResponse{
@@ -178,34 +428,66 @@ func (h *Handler) UpdateTenant(ctx context.Context, req *v0.UpdateTenantRequest)
 			Name:      tenant.Name,
 			CreatedAt: tenant.CreatedAt.String(),
 			Enabled:   tenant.Enabled,
+			Region:    tenant.Region,
 		},
 	}, nil
 }
 
-func (h *Handler) DeleteTenant(ctx context.Context, req *v0.DeleteTenantRequest) (*emptypb.Empty, error) {
+// DeleteTenant succeeds when the tenant does not exist, unless the request is
+// strict, the response tells whether it existed.
+func (h *Handler) DeleteTenant(ctx context.Context, req *v0.DeleteTenantRequest) (*v0.DeleteTenantResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.DeleteTenant")
 	defer span.End()
 
-	if err := h.service.DeleteTenant(ctx, req.TenantId); err != nil {
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	existed, err := h.service.DeleteTenant(ctx, req.TenantId)
+	if err != nil {
 		h.logger.Errorw("failed to delete tenant", "tenant_id", req.TenantId, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to delete tenant: %v", err)
+		return nil, apierror.Error("failed to delete tenant", err)
 	}
 
-	return &emptypb.Empty{}, nil
+	if !existed && req.Strict {
+		return nil, status.Errorf(codes.NotFound, "tenant %s not found", req.TenantId)
+	}
+
+	return &v0.DeleteTenantResponse{Existed: existed}, nil
 }
 
 func (h *Handler) ProvisionUser(ctx context.Context, req *v0.ProvisionUserRequest) (*v0.ProvisionUserResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ProvisionUser")
 	defer span.End()
 
-	if err := h.service.ProvisionUser(ctx, req.TenantId, req.Email, req.Role); err != nil {
+	h.deprecations.Warn(ctx, deprecation.StringRole)
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		Email("email", req.Email).
+		Role("role", req.Role).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.idempotency.Execute(ctx, "ProvisionUser", req.IdempotencyKey, req, func(ctx context.Context) (proto.Message, error) {
+		return h.provisionUser(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*v0.ProvisionUserResponse), nil
+}
+
+func (h *Handler) provisionUser(ctx context.Context, req *v0.ProvisionUserRequest) (*v0.ProvisionUserResponse, error) {
+	if err := h.service.ProvisionUser(ctx, req.TenantId, req.Email, types.MembershipRole(req.Role)); err != nil {
 		h.logger.Errorw("failed to provision user",
 			"tenant_id", req.TenantId,
 			"email", req.Email,
 			"role", req.Role,
 			"error", err,
 		)
-		return nil, status.Errorf(codes.Internal, "failed to provision user: %v", err)
+		return nil, quotaError("failed to provision user", err)
 	}
 
 	return &v0.ProvisionUserResponse{
@@ -217,11 +499,17 @@ func (h *Handler) UpdateTenantUser(ctx context.Context, req *v0.UpdateTenantUser
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.UpdateTenantUser")
 	defer span.End()
 
-	if req.TenantId == "" || req.UserId == "" || req.Role == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id, user_id, and role are required")
+	h.deprecations.Warn(ctx, deprecation.StringRole)
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("user_id", req.UserId).
+		Role("role", req.Role).
+		Err(); err != nil {
+		return nil, err
 	}
 
-	user, err := h.service.UpdateTenantUser(ctx, req.TenantId, req.UserId, req.Role)
+	user, err := h.service.UpdateTenantUser(ctx, req.TenantId, req.UserId, types.MembershipRole(req.Role))
 	if err != nil {
 		h.logger.Errorw("failed to update tenant user",
 			"tenant_id", req.TenantId,
@@ -229,26 +517,91 @@ func (h *Handler) UpdateTenantUser(ctx context.Context, req *v0.UpdateTenantUser
 			"role", req.Role,
 			"error", err,
 		)
-		return nil, status.Errorf(codes.Internal, "failed to update tenant user: %v", err)
+		return nil, apierror.Error("failed to update tenant user", err)
 	}
 
 	return &v0.UpdateTenantUserResponse{
 		User: &v0.TenantUser{
 			UserId: user.UserID,
-			Role:   user.Role,
+			Role:   user.Role.String(),
 			Email:  user.Email,
 		},
 	}, nil
 }
 
+// RemoveTenantUser succeeds when the user is not a member, unless the request
+// is strict, the response tells whether it was.
+func (h *Handler) GetTenantUser(ctx context.Context, req *v0.GetTenantUserRequest) (*v0.GetTenantUserResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.GetTenantUser")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.GetTenantUser(ctx, req.TenantId, req.UserId)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %s not found in tenant %s", req.UserId, req.TenantId)
+	}
+	if err != nil {
+		h.logger.Errorw("failed to get tenant user", "tenant_id", req.TenantId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Error("failed to get tenant user", err)
+	}
+
+	return &v0.GetTenantUserResponse{
+		User: &v0.TenantUser{
+			UserId:         user.UserID,
+			Email:          user.Email,
+			Role:           user.Role.String(),
+			IdentityStatus: toProtoIdentityStatus(user.IdentityStatus),
+			JoinedAt:       user.JoinedAt.String(),
+		},
+		Relations:   user.Relations,
+		Permissions: user.Permissions,
+	}, nil
+}
+
+func (h *Handler) RemoveTenantUser(ctx context.Context, req *v0.RemoveTenantUserRequest) (*v0.RemoveTenantUserResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.RemoveTenantUser")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	existed, err := h.service.RemoveTenantUser(ctx, req.TenantId, req.UserId)
+	if err != nil {
+		h.logger.Errorw("failed to remove tenant user", "tenant_id", req.TenantId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Error("failed to remove tenant user", err)
+	}
+
+	if !existed && req.Strict {
+		return nil, status.Errorf(codes.NotFound, "user %s not found in tenant %s", req.UserId, req.TenantId)
+	}
+
+	return &v0.RemoveTenantUserResponse{Existed: existed}, nil
+}
+
 func (h *Handler) ListUserTenants(ctx context.Context, req *v0.ListUserTenantsRequest) (*v0.ListUserTenantsResponse, error) {
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListUserTenants")
 	defer span.End()
 
+	h.deprecations.Warn(ctx, deprecation.UnpaginatedList)
+
+	if err := validation.New().UUID("user_id", req.UserId).Err(); err != nil {
+		return nil, err
+	}
+
 	tenants, err := h.service.ListUserTenants(ctx, req.UserId)
 	if err != nil {
 		h.logger.Errorw("failed to list user tenants", "user_id", req.UserId, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to list user tenants: %v", err)
+		return nil, apierror.Error("failed to list user tenants", err)
 	}
 
 	pbTenants := make([]*v0.Tenant, len(tenants))
@@ -258,6 +611,7 @@ func (h *Handler) ListUserTenants(ctx context.Context, req *v0.ListUserTenantsRe
 			Name:      t.Name,
 			CreatedAt: t.CreatedAt.String(),
 			Enabled:   t.Enabled,
+			Region:    t.Region,
 		}
 	}
 
@@ -270,22 +624,681 @@ func (h *Handler) ListTenantUsers(ctx context.Context, req *v0.ListTenantUsersRe
 	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListTenantUsers")
 	defer span.End()
 
-	users, err := h.service.ListTenantUsers(ctx, req.TenantId)
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		PageSize("page_size", req.PageSize, maxListPageSize).
+		Err(); err != nil {
+		return nil, err
+	}
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if req.PageSize == 0 {
+		h.deprecations.Warn(ctx, deprecation.UnpaginatedList)
+	}
+
+	users, err := h.service.ListTenantUsers(ctx, req.TenantId, req.SkipIdentityLookup, offset, uint64(req.PageSize))
 	if err != nil {
 		h.logger.Errorw("failed to list tenant users", "tenant_id", req.TenantId, "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to list tenant users: %v", err)
+		return nil, apierror.Error("failed to list tenant users", err)
 	}
 
 	pbUsers := make([]*v0.TenantUser, len(users))
 	for i, u := range users {
 		pbUsers[i] = &v0.TenantUser{
-			UserId: u.UserID,
-			Email:  u.Email,
-			Role:   u.Role,
+			UserId:         u.UserID,
+			Email:          u.Email,
+			Role:           u.Role.String(),
+			IdentityStatus: toProtoIdentityStatus(u.IdentityStatus),
+			JoinedAt:       u.JoinedAt.String(),
 		}
 	}
 
 	return &v0.ListTenantUsersResponse{
-		Users: pbUsers,
+		Users:         pbUsers,
+		NextPageToken: nextPageToken(offset, uint64(req.PageSize), len(users)),
 	}, nil
 }
+
+func (h *Handler) RunDiagnostics(ctx context.Context, req *v0.RunDiagnosticsRequest) (*v0.RunDiagnosticsResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.RunDiagnostics")
+	defer span.End()
+
+	for _, category := range req.Fix {
+		if !slices.Contains(AnomalyCategories, category) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown anomaly category: %s", category)
+		}
+	}
+
+	anomalies, err := h.service.RunDiagnostics(ctx, req.Fix)
+	if err != nil {
+		h.logger.Errorw("failed to run diagnostics", "fix", req.Fix, "error", err)
+		return nil, apierror.Error("failed to run diagnostics", err)
+	}
+
+	pbAnomalies := make([]*v0.Anomaly, len(anomalies))
+	for i, a := range anomalies {
+		pbAnomalies[i] = &v0.Anomaly{
+			Category: a.Category,
+			TenantId: a.TenantID,
+			UserId:   a.UserID,
+			Detail:   a.Detail,
+			Fixed:    a.Fixed,
+		}
+	}
+
+	return &v0.RunDiagnosticsResponse{
+		Anomalies: pbAnomalies,
+	}, nil
+}
+
+func (h *Handler) CreateRole(ctx context.Context, req *v0.CreateRoleRequest) (*v0.CreateRoleResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.CreateRole")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		RoleName("name", req.Name).
+		Permissions("permissions", req.Permissions).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	r, err := h.roles.CreateRole(ctx, req.TenantId, req.Name, req.Permissions)
+	if err != nil {
+		h.logger.Errorw("failed to create role", "tenant_id", req.TenantId, "name", req.Name, "error", err)
+		return nil, roleError("failed to create role", err)
+	}
+
+	return &v0.CreateRoleResponse{Role: toProtoRole(r)}, nil
+}
+
+func (h *Handler) ListRoles(ctx context.Context, req *v0.ListRolesRequest) (*v0.ListRolesResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListRoles")
+	defer span.End()
+
+	h.deprecations.Warn(ctx, deprecation.UnpaginatedList)
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	roles, err := h.roles.ListRoles(ctx, req.TenantId)
+	if err != nil {
+		h.logger.Errorw("failed to list roles", "tenant_id", req.TenantId, "error", err)
+		return nil, roleError("failed to list roles", err)
+	}
+
+	pbRoles := make([]*v0.Role, len(roles))
+	for i, r := range roles {
+		pbRoles[i] = toProtoRole(r)
+	}
+
+	return &v0.ListRolesResponse{
+		Roles: pbRoles,
+	}, nil
+}
+
+func (h *Handler) UpdateRole(ctx context.Context, req *v0.UpdateRoleRequest) (*v0.UpdateRoleResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.UpdateRole")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("role_id", req.RoleId).
+		Permissions("permissions", req.Permissions).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	r, err := h.roles.UpdateRole(ctx, req.TenantId, req.RoleId, req.Permissions)
+	if err != nil {
+		h.logger.Errorw("failed to update role", "tenant_id", req.TenantId, "role_id", req.RoleId, "error", err)
+		return nil, roleError("failed to update role", err)
+	}
+
+	return &v0.UpdateRoleResponse{Role: toProtoRole(r)}, nil
+}
+
+func (h *Handler) DeleteRole(ctx context.Context, req *v0.DeleteRoleRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.DeleteRole")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("role_id", req.RoleId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.roles.DeleteRole(ctx, req.TenantId, req.RoleId); err != nil {
+		h.logger.Errorw("failed to delete role", "tenant_id", req.TenantId, "role_id", req.RoleId, "error", err)
+		return nil, roleError("failed to delete role", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) AssignRole(ctx context.Context, req *v0.AssignRoleRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.AssignRole")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("role_id", req.RoleId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.roles.AssignRole(ctx, req.TenantId, req.RoleId, req.UserId); err != nil {
+		h.logger.Errorw("failed to assign role", "tenant_id", req.TenantId, "role_id", req.RoleId, "user_id", req.UserId, "error", err)
+		return nil, roleError("failed to assign role", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) UnassignRole(ctx context.Context, req *v0.UnassignRoleRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.UnassignRole")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("role_id", req.RoleId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.roles.UnassignRole(ctx, req.TenantId, req.RoleId, req.UserId); err != nil {
+		h.logger.Errorw("failed to unassign role", "tenant_id", req.TenantId, "role_id", req.RoleId, "user_id", req.UserId, "error", err)
+		return nil, roleError("failed to unassign role", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) CreateAPIKey(ctx context.Context, req *v0.CreateAPIKeyRequest) (*v0.CreateAPIKeyResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.CreateAPIKey")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		Required("name", req.Name).
+		Role("role", req.Role).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	k, key, err := h.apiKeys.CreateAPIKey(ctx, req.TenantId, req.Name, types.MembershipRole(req.Role))
+	if err != nil {
+		h.logger.Errorw("failed to create API key", "tenant_id", req.TenantId, "name", req.Name, "error", err)
+		return nil, apiKeyError("failed to create API key", err)
+	}
+
+	return &v0.CreateAPIKeyResponse{ApiKey: toProtoAPIKey(k), Key: key}, nil
+}
+
+func (h *Handler) ListAPIKeys(ctx context.Context, req *v0.ListAPIKeysRequest) (*v0.ListAPIKeysResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListAPIKeys")
+	defer span.End()
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	keys, err := h.apiKeys.ListAPIKeys(ctx, req.TenantId)
+	if err != nil {
+		h.logger.Errorw("failed to list API keys", "tenant_id", req.TenantId, "error", err)
+		return nil, apiKeyError("failed to list API keys", err)
+	}
+
+	pbKeys := make([]*v0.APIKey, len(keys))
+	for i, k := range keys {
+		pbKeys[i] = toProtoAPIKey(k)
+	}
+
+	return &v0.ListAPIKeysResponse{
+		ApiKeys: pbKeys,
+	}, nil
+}
+
+func (h *Handler) RevokeAPIKey(ctx context.Context, req *v0.RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.RevokeAPIKey")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("api_key_id", req.ApiKeyId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.apiKeys.RevokeAPIKey(ctx, req.TenantId, req.ApiKeyId); err != nil {
+		h.logger.Errorw("failed to revoke API key", "tenant_id", req.TenantId, "api_key_id", req.ApiKeyId, "error", err)
+		return nil, apiKeyError("failed to revoke API key", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) CreateWebhookSubscription(ctx context.Context, req *v0.CreateWebhookSubscriptionRequest) (*v0.WebhookSubscription, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.CreateWebhookSubscription")
+	defer span.End()
+
+	if h.subscriptions == nil {
+		return nil, errWebhooksDisabled
+	}
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		URL("url", req.Url).
+		EventTypes("event_types", req.EventTypes).
+		Required("secret", req.Secret).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := h.subscriptions.CreateSubscription(ctx, req.TenantId, req.Url, req.EventTypes, req.Secret)
+	if err != nil {
+		h.logger.Errorw("failed to create webhook subscription", "tenant_id", req.TenantId, "url", req.Url, "error", err)
+		return nil, subscriptionError("failed to create webhook subscription", err)
+	}
+
+	return toProtoWebhookSubscription(sub), nil
+}
+
+func (h *Handler) ListWebhookSubscriptions(ctx context.Context, req *v0.ListWebhookSubscriptionsRequest) (*v0.ListWebhookSubscriptionsResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListWebhookSubscriptions")
+	defer span.End()
+
+	if h.subscriptions == nil {
+		return nil, errWebhooksDisabled
+	}
+
+	if err := validation.New().UUID("tenant_id", req.TenantId).Err(); err != nil {
+		return nil, err
+	}
+
+	subs, err := h.subscriptions.ListSubscriptions(ctx, req.TenantId)
+	if err != nil {
+		h.logger.Errorw("failed to list webhook subscriptions", "tenant_id", req.TenantId, "error", err)
+		return nil, subscriptionError("failed to list webhook subscriptions", err)
+	}
+
+	pbSubs := make([]*v0.WebhookSubscription, len(subs))
+	for i, sub := range subs {
+		pbSubs[i] = toProtoWebhookSubscription(sub)
+	}
+
+	return &v0.ListWebhookSubscriptionsResponse{
+		Subscriptions: pbSubs,
+	}, nil
+}
+
+func (h *Handler) DeleteWebhookSubscription(ctx context.Context, req *v0.DeleteWebhookSubscriptionRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.DeleteWebhookSubscription")
+	defer span.End()
+
+	if h.subscriptions == nil {
+		return nil, errWebhooksDisabled
+	}
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("subscription_id", req.SubscriptionId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.subscriptions.DeleteSubscription(ctx, req.TenantId, req.SubscriptionId); err != nil {
+		h.logger.Errorw("failed to delete webhook subscription", "tenant_id", req.TenantId, "subscription_id", req.SubscriptionId, "error", err)
+		return nil, subscriptionError("failed to delete webhook subscription", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListWebhookDeliveries(ctx context.Context, req *v0.ListWebhookDeliveriesRequest) (*v0.ListWebhookDeliveriesResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListWebhookDeliveries")
+	defer span.End()
+
+	if h.subscriptions == nil {
+		return nil, errWebhooksDisabled
+	}
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("subscription_id", req.SubscriptionId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := h.subscriptions.ListDeliveries(ctx, req.TenantId, req.SubscriptionId)
+	if err != nil {
+		h.logger.Errorw("failed to list webhook deliveries", "tenant_id", req.TenantId, "subscription_id", req.SubscriptionId, "error", err)
+		return nil, subscriptionError("failed to list webhook deliveries", err)
+	}
+
+	pbDeliveries := make([]*v0.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		pbDeliveries[i] = toProtoWebhookDelivery(d)
+	}
+
+	return &v0.ListWebhookDeliveriesResponse{
+		Deliveries: pbDeliveries,
+	}, nil
+}
+
+func (h *Handler) ReplayWebhookDelivery(ctx context.Context, req *v0.ReplayWebhookDeliveryRequest) (*v0.WebhookDelivery, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ReplayWebhookDelivery")
+	defer span.End()
+
+	if h.subscriptions == nil {
+		return nil, errWebhooksDisabled
+	}
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		UUID("subscription_id", req.SubscriptionId).
+		UUID("delivery_id", req.DeliveryId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	d, err := h.subscriptions.ReplayDelivery(ctx, req.TenantId, req.SubscriptionId, req.DeliveryId)
+	if err != nil {
+		h.logger.Errorw("failed to replay webhook delivery", "tenant_id", req.TenantId, "subscription_id", req.SubscriptionId, "delivery_id", req.DeliveryId, "error", err)
+		return nil, subscriptionError("failed to replay webhook delivery", err)
+	}
+
+	return toProtoWebhookDelivery(d), nil
+}
+
+func (h *Handler) AddPlatformAdmin(ctx context.Context, req *v0.AddPlatformAdminRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.AddPlatformAdmin")
+	defer span.End()
+
+	if err := validation.New().
+		Required("group_id", req.GroupId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.AddPlatformAdmin(ctx, req.GroupId, req.UserId); err != nil {
+		h.logger.Errorw("failed to add platform admin", "group_id", req.GroupId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Error("failed to add platform admin", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) RemovePlatformAdmin(ctx context.Context, req *v0.RemovePlatformAdminRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.RemovePlatformAdmin")
+	defer span.End()
+
+	if err := validation.New().
+		Required("group_id", req.GroupId).
+		UUID("user_id", req.UserId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.RemovePlatformAdmin(ctx, req.GroupId, req.UserId); err != nil {
+		h.logger.Errorw("failed to remove platform admin", "group_id", req.GroupId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Error("failed to remove platform admin", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListPlatformAdmins(ctx context.Context, req *v0.ListPlatformAdminsRequest) (*v0.ListPlatformAdminsResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListPlatformAdmins")
+	defer span.End()
+
+	if err := validation.New().Required("group_id", req.GroupId).Err(); err != nil {
+		return nil, err
+	}
+
+	admins, err := h.service.ListPlatformAdmins(ctx, req.GroupId)
+	if err != nil {
+		h.logger.Errorw("failed to list platform admins", "group_id", req.GroupId, "error", err)
+		return nil, apierror.Error("failed to list platform admins", err)
+	}
+
+	return &v0.ListPlatformAdminsResponse{UserIds: admins}, nil
+}
+
+func (h *Handler) LinkTenantToSupportGroup(ctx context.Context, req *v0.LinkTenantToSupportGroupRequest) (*emptypb.Empty, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.LinkTenantToSupportGroup")
+	defer span.End()
+
+	if err := validation.New().
+		UUID("tenant_id", req.TenantId).
+		Required("group_id", req.GroupId).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.LinkTenantToSupportGroup(ctx, req.TenantId, req.GroupId); err != nil {
+		h.logger.Errorw("failed to link tenant to support group", "tenant_id", req.TenantId, "group_id", req.GroupId, "error", err)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "tenant %s not found", req.TenantId)
+		}
+		return nil, apierror.Error("failed to link tenant to support group", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// roleError maps the errors returned by the role service to gRPC statuses.
+func roleError(msg string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, role.ErrRoleNotFound), errors.Is(err, role.ErrTenantNotFound), errors.Is(err, role.ErrAssignmentAbsent):
+		code = codes.NotFound
+	case errors.Is(err, role.ErrRoleExists), errors.Is(err, role.ErrAlreadyAssigned):
+		code = codes.AlreadyExists
+	case errors.Is(err, role.ErrNotMember):
+		code = codes.FailedPrecondition
+	}
+	return apierror.WithCode(code, msg, err)
+}
+
+func apiKeyError(msg string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, apikey.ErrAPIKeyNotFound), errors.Is(err, apikey.ErrTenantNotFound):
+		code = codes.NotFound
+	case errors.Is(err, apikey.ErrAPIKeyExists):
+		code = codes.AlreadyExists
+	}
+	return apierror.WithCode(code, msg, err)
+}
+
+func inviteError(msg string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, ErrInviteClosed):
+		code = codes.FailedPrecondition
+	}
+	return apierror.WithCode(code, msg, err)
+}
+
+func quotaError(msg string, err error) error {
+	code := codes.Internal
+	if errors.Is(err, ErrQuotaExceeded) {
+		code = codes.ResourceExhausted
+	}
+	return apierror.WithCode(code, msg, err)
+}
+
+func toProtoQuotaUsage(u types.QuotaUsage) *v0.QuotaUsage {
+	return &v0.QuotaUsage{
+		Used:    int32(u.Used),
+		Limit:   int32(u.Limit),
+		Warning: u.Warning,
+	}
+}
+
+var errWebhooksDisabled = status.Error(codes.Unimplemented, "webhook subscriptions are not enabled")
+
+func subscriptionError(msg string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, subscription.ErrSubscriptionNotFound), errors.Is(err, subscription.ErrDeliveryNotFound), errors.Is(err, subscription.ErrTenantNotFound):
+		code = codes.NotFound
+	case errors.Is(err, subscription.ErrDeliveryNotFailed):
+		code = codes.FailedPrecondition
+	case errors.Is(err, subscription.ErrURLNotPublic):
+		code = codes.InvalidArgument
+	}
+	return apierror.WithCode(code, msg, err)
+}
+
+func toProtoIdentityStatus(s types.IdentityStatus) v0.IdentityStatus {
+	switch s {
+	case types.IdentityStatusResolved:
+		return v0.IdentityStatus_IDENTITY_STATUS_RESOLVED
+	case types.IdentityStatusUnknown:
+		return v0.IdentityStatus_IDENTITY_STATUS_UNKNOWN
+	}
+	return v0.IdentityStatus_IDENTITY_STATUS_UNSPECIFIED
+}
+
+func toProtoRole(r *types.Role) *v0.Role {
+	return &v0.Role{
+		Id:          r.ID,
+		TenantId:    r.TenantID,
+		Name:        r.Name,
+		Permissions: r.Permissions,
+		CreatedAt:   r.CreatedAt.String(),
+	}
+}
+
+func toProtoAPIKey(k *types.APIKey) *v0.APIKey {
+	pb := &v0.APIKey{
+		Id:        k.ID,
+		TenantId:  k.TenantID,
+		Name:      k.Name,
+		Role:      k.Role.String(),
+		Prefix:    k.Prefix,
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt.String(),
+	}
+	if k.RevokedAt != nil {
+		pb.RevokedAt = k.RevokedAt.String()
+	}
+	return pb
+}
+
+func toProtoInvite(i *types.Invite, now time.Time) *v0.Invite {
+	pb := &v0.Invite{
+		Id:        i.ID,
+		TenantId:  i.TenantID,
+		UserId:    i.UserID,
+		Email:     i.Email,
+		Role:      i.Role.String(),
+		State:     string(i.State(now)),
+		InvitedBy: i.InvitedBy,
+		CreatedAt: i.CreatedAt.String(),
+		SentAt:    i.SentAt.String(),
+		ExpiresAt: i.ExpiresAt.String(),
+	}
+	if i.AcceptedAt != nil {
+		pb.AcceptedAt = i.AcceptedAt.String()
+	}
+	if i.RevokedAt != nil {
+		pb.RevokedAt = i.RevokedAt.String()
+	}
+	return pb
+}
+
+func toProtoWebhookSubscription(sub *types.WebhookSubscription) *v0.WebhookSubscription {
+	return &v0.WebhookSubscription{
+		Id:         sub.ID,
+		TenantId:   sub.TenantID,
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		CreatedBy:  sub.CreatedBy,
+		CreatedAt:  sub.CreatedAt.String(),
+	}
+}
+
+func toProtoWebhookDelivery(d *types.WebhookDelivery) *v0.WebhookDelivery {
+	return &v0.WebhookDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       int32(d.Attempts),
+		ResponseStatus: int32(d.ResponseStatus),
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.String(),
+		UpdatedAt:      d.UpdatedAt.String(),
+	}
+}
+
+func (h *Handler) ListAuthzAudit(ctx context.Context, req *v0.ListAuthzAuditRequest) (*v0.ListAuthzAuditResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "tenant.Handler.ListAuthzAudit")
+	defer span.End()
+
+	if err := validation.New().
+		Timestamp("since", req.Since).
+		PageSize("page_size", req.PageSize, maxAuditPageSize).
+		Err(); err != nil {
+		return nil, err
+	}
+
+	beforeID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	filter := &types.AuthzAuditFilter{
+		Actor:    req.Actor,
+		User:     req.User,
+		Object:   req.Object,
+		BeforeID: int64(beforeID),
+		Limit:    defaultAuditPageSize,
+	}
+	if req.PageSize > 0 {
+		filter.Limit = uint64(req.PageSize)
+	}
+	if req.Since != "" {
+		// validated above
+		filter.Since, _ = time.Parse(time.RFC3339, req.Since)
+	}
+
+	entries, err := h.service.ListAuthzAudit(ctx, filter)
+	if err != nil {
+		h.logger.Errorw("failed to list authz audit entries", "error", err)
+		return nil, apierror.Error("failed to list authz audit entries", err)
+	}
+
+	resp := &v0.ListAuthzAuditResponse{Entries: make([]*v0.AuthzAuditEntry, len(entries))}
+	for i, e := range entries {
+		resp.Entries[i] = &v0.AuthzAuditEntry{
+			Actor:     e.Actor,
+			Operation: e.Operation,
+			User:      e.User,
+			Relation:  e.Relation,
+			Object:    e.Object,
+			RequestId: e.RequestID,
+			SourceIp:  e.SourceIP,
+			CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	// a full page may be followed by more entries
+	if uint64(len(entries)) == filter.Limit {
+		resp.NextPageToken = encodePageToken(uint64(entries[len(entries)-1].ID))
+	}
+
+	return resp, nil
+}
@@ -6,16 +6,23 @@ package tenant
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/apikey"
 	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/role"
+	"github.com/canonical/tenant-service/pkg/subscription"
 	v0 "github.com/canonical/tenant-service/v0"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
@@ -24,6 +31,22 @@ import (
 //go:generate mockgen -build_flags=--mod=mod -package tenant -destination ./mock_monitor.go -source=../../internal/monitoring/interfaces.go
 //go:generate mockgen -build_flags=--mod=mod -package tenant -destination ./mock_tracing.go -source=../../internal/tracing/interfaces.go
 
+// setupIdempotencyMock returns an idempotency mock that runs every operation directly.
+func setupIdempotencyMock(ctrl *gomock.Controller) *MockIdempotencyInterface {
+	mockIdempotency := NewMockIdempotencyInterface(ctrl)
+	mockIdempotency.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ string, _ proto.Message, fn func(context.Context) (proto.Message, error)) (proto.Message, error) {
+			return fn(ctx)
+		}).AnyTimes()
+	return mockIdempotency
+}
+
+func setupDeprecationMock(ctrl *gomock.Controller) *MockDeprecationInterface {
+	mockDeprecations := NewMockDeprecationInterface(ctrl)
+	mockDeprecations.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	return mockDeprecations
+}
+
 func TestHandler_InviteMember(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -35,12 +58,12 @@ func TestHandler_InviteMember(t *testing.T) {
 		{
 			name: "success",
 			request: &v0.InviteMemberRequest{
-				TenantId: "tenant-123",
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
 				Email:    "user@example.com",
 				Role:     "member",
 			},
 			setupMocks: func(mockSvc *MockServiceInterface) {
-				mockSvc.EXPECT().InviteMember(gomock.Any(), "tenant-123", "user@example.com", "member").
+				mockSvc.EXPECT().InviteMember(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "user@example.com", types.RoleMember).
 					Return("https://link", "code123", nil)
 			},
 			wantErr: false,
@@ -58,27 +81,74 @@ func TestHandler_InviteMember(t *testing.T) {
 		{
 			name: "missing email",
 			request: &v0.InviteMemberRequest{
-				TenantId: "tenant-123",
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Role:     "member",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name: "invalid tenant_id",
+			request: &v0.InviteMemberRequest{
+				TenantId: "not-a-uuid",
+				Email:    "user@example.com",
+				Role:     "member",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name: "invalid email",
+			request: &v0.InviteMemberRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Email:    "Bob <bob@example.com>",
 				Role:     "member",
 			},
 			setupMocks: func(mockSvc *MockServiceInterface) {},
 			wantErr:    true,
 			wantCode:   codes.InvalidArgument,
 		},
+		{
+			name: "invalid role",
+			request: &v0.InviteMemberRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Email:    "user@example.com",
+				Role:     "superadmin",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
 		{
 			name: "service error",
 			request: &v0.InviteMemberRequest{
-				TenantId: "tenant-123",
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
 				Email:    "user@example.com",
 				Role:     "member",
 			},
 			setupMocks: func(mockSvc *MockServiceInterface) {
-				mockSvc.EXPECT().InviteMember(gomock.Any(), "tenant-123", "user@example.com", "member").
+				mockSvc.EXPECT().InviteMember(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "user@example.com", types.RoleMember).
 					Return("", "", errors.New("service error"))
 			},
 			wantErr:  true,
 			wantCode: codes.Internal,
 		},
+		{
+			name: "quota exceeded",
+			request: &v0.InviteMemberRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Email:    "user@example.com",
+				Role:     "member",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().InviteMember(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "user@example.com", types.RoleMember).
+					Return("", "", fmt.Errorf("%w: 10 members of 10", ErrQuotaExceeded))
+			},
+			wantErr:  true,
+			wantCode: codes.ResourceExhausted,
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,7 +162,7 @@ func TestHandler_InviteMember(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.InviteMember").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -120,6 +190,123 @@ func TestHandler_InviteMember(t *testing.T) {
 	}
 }
 
+func TestHandler_RevokeInvite(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	inviteID := "0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e"
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		inviteID   string
+		serviceErr error
+		wantCode   codes.Code
+	}{
+		{name: "success", inviteID: inviteID, wantCode: codes.OK},
+		{name: "invalid invite ID", inviteID: "invite-1", wantCode: codes.InvalidArgument},
+		{name: "invite not found", inviteID: inviteID, serviceErr: fmt.Errorf("failed to get invite: %w", storage.ErrNotFound), wantCode: codes.NotFound},
+		{name: "invite accepted", inviteID: inviteID, serviceErr: ErrInviteClosed, wantCode: codes.FailedPrecondition},
+		{name: "service error", inviteID: inviteID, serviceErr: errors.New("db error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(mockService, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.RevokeInvite").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			if tt.wantCode != codes.InvalidArgument {
+				invite := &types.Invite{ID: inviteID, TenantID: tenantID, Role: types.RoleMember, RevokedAt: &revokedAt}
+				if tt.serviceErr != nil {
+					invite = nil
+				}
+				mockService.EXPECT().RevokeInvite(gomock.Any(), tenantID, inviteID).Return(invite, tt.serviceErr)
+			}
+
+			resp, err := h.RevokeInvite(context.Background(), &v0.RevokeInviteRequest{TenantId: tenantID, InviteId: tt.inviteID})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && resp.State != string(types.InviteStateRevoked) {
+				t.Errorf("expected state %s, got %s", types.InviteStateRevoked, resp.State)
+			}
+		})
+	}
+}
+
+func TestHandler_GetTenantStats(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		request    *v0.GetTenantStatsRequest
+		setupMocks func(*MockServiceInterface)
+		wantCode   codes.Code
+	}{
+		{
+			name:    "success",
+			request: &v0.GetTenantStatsRequest{TenantId: tenantID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetTenantStats(gomock.Any(), tenantID).Return(&types.TenantStats{
+					TenantID:       tenantID,
+					Members:        types.QuotaUsage{Used: 8, Limit: 10, Warning: true},
+					PendingInvites: types.QuotaUsage{Used: 1},
+				}, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:    "missing tenant",
+			request: &v0.GetTenantStatsRequest{TenantId: tenantID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetTenantStats(gomock.Any(), tenantID).Return(nil, storage.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:       "invalid tenant id",
+			request:    &v0.GetTenantStatsRequest{TenantId: "not-a-uuid"},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.GetTenantStats").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSvc)
+
+			resp, err := h.GetTenantStats(context.Background(), tt.request)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && (resp.Members.GetLimit() != 10 || !resp.Members.GetWarning() || resp.PendingInvites.GetUsed() != 1) {
+				t.Errorf("unexpected stats %v", resp)
+			}
+		})
+	}
+}
+
 func TestHandler_ListMyTenants(t *testing.T) {
 	now := time.Now()
 	tenants := []*types.Tenant{
@@ -136,9 +323,9 @@ func TestHandler_ListMyTenants(t *testing.T) {
 	}{
 		{
 			name: "success",
-			ctx:  authentication.WithUserID(context.Background(), "user-123"),
+			ctx:  authentication.WithUserID(context.Background(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"),
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenantsByUserID(gomock.Any(), "user-123").Return(tenants, nil)
+				mockSvc.EXPECT().ListTenantsByUserID(gomock.Any(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f").Return(tenants, nil)
 			},
 			wantErr: false,
 		},
@@ -151,9 +338,9 @@ func TestHandler_ListMyTenants(t *testing.T) {
 		},
 		{
 			name: "service error",
-			ctx:  authentication.WithUserID(context.Background(), "user-123"),
+			ctx:  authentication.WithUserID(context.Background(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"),
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenantsByUserID(gomock.Any(), "user-123").Return(nil, errors.New("service error"))
+				mockSvc.EXPECT().ListTenantsByUserID(gomock.Any(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f").Return(nil, errors.New("service error"))
 			},
 			wantErr:  true,
 			wantCode: codes.Internal,
@@ -171,7 +358,7 @@ func TestHandler_ListMyTenants(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListMyTenants").
 				Return(tt.ctx, trace.SpanFromContext(tt.ctx))
@@ -205,22 +392,55 @@ func TestHandler_ListTenants(t *testing.T) {
 		{ID: "tenant-1", Name: "Tenant 1", CreatedAt: now, Enabled: true},
 	}
 
+	enabled := true
+
 	tests := []struct {
-		name       string
-		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
-		wantErr    bool
+		name         string
+		request      *v0.ListTenantsRequest
+		setupMocks   func(*MockServiceInterface, *MockLoggerInterface)
+		expectedNext string
+		wantErr      bool
 	}{
 		{
-			name: "success",
+			name:    "success",
+			request: &v0.ListTenantsRequest{},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenants(gomock.Any()).Return(tenants, nil)
+				mockSvc.EXPECT().ListTenants(gomock.Any(), &types.TenantFilter{}).Return(tenants, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "service error",
+			name:    "full page with filters",
+			request: &v0.ListTenantsRequest{PageSize: 1, PageToken: encodePageToken(3), Enabled: &enabled, NameContains: "acme"},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().ListTenants(gomock.Any(), &types.TenantFilter{Enabled: &enabled, NameContains: "acme", Offset: 3, Limit: 1}).Return(tenants, nil)
+			},
+			expectedNext: encodePageToken(4),
+		},
+		{
+			name:    "last page",
+			request: &v0.ListTenantsRequest{PageSize: 2},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().ListTenants(gomock.Any(), &types.TenantFilter{Limit: 2}).Return(tenants, nil)
+			},
+		},
+		{
+			name:       "invalid page token",
+			request:    &v0.ListTenantsRequest{PageToken: "not-a-token"},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			wantErr:    true,
+		},
+		{
+			name:       "page size too large",
+			request:    &v0.ListTenantsRequest{PageSize: maxListPageSize + 1},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			wantErr:    true,
+		},
+		{
+			name:    "service error",
+			request: &v0.ListTenantsRequest{},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenants(gomock.Any()).Return(nil, errors.New("service error"))
+				mockSvc.EXPECT().ListTenants(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
 			},
 			wantErr: true,
 		},
@@ -237,13 +457,13 @@ func TestHandler_ListTenants(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListTenants").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tt.setupMocks(mockSvc, mockLogger)
 
-			resp, err := h.ListTenants(context.Background(), &v0.ListTenantsRequest{})
+			resp, err := h.ListTenants(context.Background(), tt.request)
 
 			if tt.wantErr {
 				if err == nil {
@@ -251,10 +471,10 @@ func TestHandler_ListTenants(t *testing.T) {
 				}
 			} else {
 				if err != nil {
-					t.Errorf("unexpected error: %v", err)
+					t.Fatalf("unexpected error: %v", err)
 				}
-				if resp == nil {
-					t.Error("expected response but got nil")
+				if resp.NextPageToken != tt.expectedNext {
+					t.Errorf("expected next page token %q, got %q", tt.expectedNext, resp.NextPageToken)
 				}
 			}
 		})
@@ -263,7 +483,7 @@ func TestHandler_ListTenants(t *testing.T) {
 
 func TestHandler_CreateTenant(t *testing.T) {
 	now := time.Now()
-	tenant := &types.Tenant{ID: "tenant-123", Name: "Test Tenant", CreatedAt: now, Enabled: true}
+	tenant := &types.Tenant{ID: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", Name: "Test Tenant", CreatedAt: now, Enabled: true}
 
 	tests := []struct {
 		name       string
@@ -276,10 +496,25 @@ func TestHandler_CreateTenant(t *testing.T) {
 			name:    "success",
 			request: &v0.CreateTenantRequest{Name: "Test Tenant"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().CreateTenant(gomock.Any(), "Test Tenant").Return(tenant, nil)
+				mockSvc.EXPECT().CreateTenant(gomock.Any(), "Test Tenant", "").Return(tenant, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "with region",
+			request: &v0.CreateTenantRequest{Name: "Test Tenant", Region: "eu-west-1"},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().CreateTenant(gomock.Any(), "Test Tenant", "eu-west-1").Return(tenant, nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:       "invalid region",
+			request:    &v0.CreateTenantRequest{Name: "Test Tenant", Region: "EU West"},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
 		{
 			name:       "missing name",
 			request:    &v0.CreateTenantRequest{},
@@ -287,11 +522,18 @@ func TestHandler_CreateTenant(t *testing.T) {
 			wantErr:    true,
 			wantCode:   codes.InvalidArgument,
 		},
+		{
+			name:       "name with control characters",
+			request:    &v0.CreateTenantRequest{Name: "Acme\nCorp"},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
 		{
 			name:    "service error",
 			request: &v0.CreateTenantRequest{Name: "Test Tenant"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().CreateTenant(gomock.Any(), "Test Tenant").Return(nil, errors.New("service error"))
+				mockSvc.EXPECT().CreateTenant(gomock.Any(), "Test Tenant", "").Return(nil, errors.New("service error"))
 			},
 			wantErr:  true,
 			wantCode: codes.Internal,
@@ -309,7 +551,7 @@ func TestHandler_CreateTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.CreateTenant").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -339,7 +581,7 @@ func TestHandler_CreateTenant(t *testing.T) {
 
 func TestHandler_UpdateTenant(t *testing.T) {
 	now := time.Now()
-	tenant := &types.Tenant{ID: "tenant-123", Name: "Updated", CreatedAt: now, Enabled: true}
+	tenant := &types.Tenant{ID: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", Name: "Updated", CreatedAt: now, Enabled: true}
 
 	tests := []struct {
 		name       string
@@ -351,7 +593,7 @@ func TestHandler_UpdateTenant(t *testing.T) {
 		{
 			name: "success",
 			request: &v0.UpdateTenantRequest{
-				Tenant:     &v0.Tenant{Id: "tenant-123", Name: "Updated", Enabled: true},
+				Tenant:     &v0.Tenant{Id: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", Name: "Updated", Enabled: true},
 				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
 			},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
@@ -369,7 +611,7 @@ func TestHandler_UpdateTenant(t *testing.T) {
 		{
 			name: "service error",
 			request: &v0.UpdateTenantRequest{
-				Tenant: &v0.Tenant{Id: "tenant-123", Name: "Updated"},
+				Tenant: &v0.Tenant{Id: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", Name: "Updated"},
 			},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
 				mockSvc.EXPECT().UpdateTenant(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
@@ -390,7 +632,7 @@ func TestHandler_UpdateTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.UpdateTenant").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -419,27 +661,48 @@ func TestHandler_UpdateTenant(t *testing.T) {
 }
 
 func TestHandler_DeleteTenant(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
 	tests := []struct {
-		name       string
-		request    *v0.DeleteTenantRequest
-		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
-		wantErr    bool
+		name        string
+		request     *v0.DeleteTenantRequest
+		setupMocks  func(*MockServiceInterface, *MockLoggerInterface)
+		wantExisted bool
+		wantCode    codes.Code
 	}{
 		{
 			name:    "success",
-			request: &v0.DeleteTenantRequest{TenantId: "tenant-123"},
+			request: &v0.DeleteTenantRequest{TenantId: tenantID},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().DeleteTenant(gomock.Any(), "tenant-123").Return(nil)
+				mockSvc.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(true, nil)
 			},
-			wantErr: false,
+			wantExisted: true,
+			wantCode:    codes.OK,
+		},
+		{
+			name:    "missing tenant",
+			request: &v0.DeleteTenantRequest{TenantId: tenantID},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(false, nil)
+			},
+			wantExisted: false,
+			wantCode:    codes.OK,
+		},
+		{
+			name:    "missing tenant, strict",
+			request: &v0.DeleteTenantRequest{TenantId: tenantID, Strict: true},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(false, nil)
+			},
+			wantCode: codes.NotFound,
 		},
 		{
 			name:    "service error",
-			request: &v0.DeleteTenantRequest{TenantId: "tenant-123"},
+			request: &v0.DeleteTenantRequest{TenantId: tenantID},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().DeleteTenant(gomock.Any(), "tenant-123").Return(errors.New("service error"))
+				mockSvc.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(false, errors.New("service error"))
 			},
-			wantErr: true,
+			wantCode: codes.Internal,
 		},
 	}
 
@@ -454,56 +717,72 @@ func TestHandler_DeleteTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.DeleteTenant").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tt.setupMocks(mockSvc, mockLogger)
 
-			_, err := h.DeleteTenant(context.Background(), tt.request)
+			resp, err := h.DeleteTenant(context.Background(), tt.request)
 
-			if tt.wantErr {
-				if err == nil {
-					t.Error("expected error but got none")
-				}
-			} else if err != nil {
-				t.Errorf("unexpected error: %v", err)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && resp.Existed != tt.wantExisted {
+				t.Errorf("expected existed %v, got %v", tt.wantExisted, resp.Existed)
 			}
 		})
 	}
 }
 
-func TestHandler_ProvisionUser(t *testing.T) {
+func TestHandler_GetTenantUser(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	userID := "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+	joinedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name       string
-		request    *v0.ProvisionUserRequest
-		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
-		wantErr    bool
+		request    *v0.GetTenantUserRequest
+		setupMocks func(*MockServiceInterface)
+		wantCode   codes.Code
 	}{
 		{
-			name: "success",
-			request: &v0.ProvisionUserRequest{
-				TenantId: "tenant-123",
-				Email:    "user@example.com",
-				Role:     "member",
-			},
-			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ProvisionUser(gomock.Any(), "tenant-123", "user@example.com", "member").Return(nil)
+			name:    "success",
+			request: &v0.GetTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetTenantUser(gomock.Any(), tenantID, userID).Return(&types.TenantUser{
+					UserID:         userID,
+					Email:          "user@example.com",
+					Role:           types.RoleAdmin,
+					IdentityStatus: types.IdentityStatusResolved,
+					JoinedAt:       joinedAt,
+					Relations:      []string{"admin"},
+					Permissions:    map[string]bool{"can_view": true},
+				}, nil)
 			},
-			wantErr: false,
+			wantCode: codes.OK,
 		},
 		{
-			name: "service error",
-			request: &v0.ProvisionUserRequest{
-				TenantId: "tenant-123",
-				Email:    "user@example.com",
-				Role:     "member",
+			name:    "not a member",
+			request: &v0.GetTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetTenantUser(gomock.Any(), tenantID, userID).Return(nil, storage.ErrNotFound)
 			},
-			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ProvisionUser(gomock.Any(), "tenant-123", "user@example.com", "member").
-					Return(errors.New("service error"))
+			wantCode: codes.NotFound,
+		},
+		{
+			name:       "invalid user id",
+			request:    &v0.GetTenantUserRequest{TenantId: tenantID, UserId: "not-a-uuid"},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "service error",
+			request: &v0.GetTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetTenantUser(gomock.Any(), tenantID, userID).Return(nil, errors.New("service error"))
 			},
-			wantErr: true,
+			wantCode: codes.Internal,
 		},
 	}
 
@@ -516,76 +795,225 @@ func TestHandler_ProvisionUser(t *testing.T) {
 			mockTracer := NewMockTracingInterface(ctrl)
 			mockLogger := NewMockLoggerInterface(ctrl)
 			setupLoggerMock(ctrl, mockLogger)
-			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
 
-			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ProvisionUser").
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.GetTenantUser").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
-			tt.setupMocks(mockSvc, mockLogger)
+			tt.setupMocks(mockSvc)
 
-			resp, err := h.ProvisionUser(context.Background(), tt.request)
+			resp, err := h.GetTenantUser(context.Background(), tt.request)
 
-			if tt.wantErr {
-				if err == nil {
-					t.Error("expected error but got none")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("unexpected error: %v", err)
-				}
-				if resp == nil || resp.Status != "provisioned" {
-					t.Error("expected provisioned status")
-				}
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.User.Email != "user@example.com" || resp.User.Role != "admin" || resp.User.JoinedAt != joinedAt.String() {
+				t.Errorf("unexpected user %v", resp.User)
+			}
+			if !reflect.DeepEqual(resp.Relations, []string{"admin"}) || !resp.Permissions["can_view"] {
+				t.Errorf("unexpected relations %v and permissions %v", resp.Relations, resp.Permissions)
 			}
 		})
 	}
 }
 
-func TestHandler_UpdateTenantUser(t *testing.T) {
-	user := &types.TenantUser{UserID: "user-123", Email: "user@example.com", Role: "owner"}
+func TestHandler_RemoveTenantUser(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	userID := "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
 
 	tests := []struct {
-		name       string
-		request    *v0.UpdateTenantUserRequest
-		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
-		wantErr    bool
-		wantCode   codes.Code
+		name        string
+		request     *v0.RemoveTenantUserRequest
+		setupMocks  func(*MockServiceInterface)
+		wantExisted bool
+		wantCode    codes.Code
 	}{
 		{
-			name: "success",
-			request: &v0.UpdateTenantUserRequest{
-				TenantId: "tenant-123",
-				UserId:   "user-123",
-				Role:     "owner",
+			name:    "success",
+			request: &v0.RemoveTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RemoveTenantUser(gomock.Any(), tenantID, userID).Return(true, nil)
 			},
-			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().UpdateTenantUser(gomock.Any(), "tenant-123", "user-123", "owner").Return(user, nil)
+			wantExisted: true,
+			wantCode:    codes.OK,
+		},
+		{
+			name:    "not a member",
+			request: &v0.RemoveTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RemoveTenantUser(gomock.Any(), tenantID, userID).Return(false, nil)
 			},
-			wantErr: false,
+			wantExisted: false,
+			wantCode:    codes.OK,
 		},
 		{
-			name: "missing tenant_id",
-			request: &v0.UpdateTenantUserRequest{
-				UserId: "user-123",
-				Role:   "owner",
+			name:    "not a member, strict",
+			request: &v0.RemoveTenantUserRequest{TenantId: tenantID, UserId: userID, Strict: true},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RemoveTenantUser(gomock.Any(), tenantID, userID).Return(false, nil)
 			},
-			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
-			wantErr:    true,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:       "invalid user id",
+			request:    &v0.RemoveTenantUserRequest{TenantId: tenantID, UserId: "not-a-uuid"},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
 			wantCode:   codes.InvalidArgument,
 		},
 		{
-			name: "service error",
-			request: &v0.UpdateTenantUserRequest{
-				TenantId: "tenant-123",
-				UserId:   "user-123",
-				Role:     "owner",
-			},
-			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().UpdateTenantUser(gomock.Any(), "tenant-123", "user-123", "owner").
-					Return(nil, errors.New("service error"))
+			name:    "service error",
+			request: &v0.RemoveTenantUserRequest{TenantId: tenantID, UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RemoveTenantUser(gomock.Any(), tenantID, userID).Return(false, errors.New("service error"))
 			},
-			wantErr:  true,
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.RemoveTenantUser").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSvc)
+
+			resp, err := h.RemoveTenantUser(context.Background(), tt.request)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err == nil && resp.Existed != tt.wantExisted {
+				t.Errorf("expected existed %v, got %v", tt.wantExisted, resp.Existed)
+			}
+		})
+	}
+}
+
+func TestHandler_ProvisionUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *v0.ProvisionUserRequest
+		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			request: &v0.ProvisionUserRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Email:    "user@example.com",
+				Role:     "member",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().ProvisionUser(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "user@example.com", types.RoleMember).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "service error",
+			request: &v0.ProvisionUserRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				Email:    "user@example.com",
+				Role:     "member",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().ProvisionUser(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "user@example.com", types.RoleMember).
+					Return(errors.New("service error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ProvisionUser").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSvc, mockLogger)
+
+			resp, err := h.ProvisionUser(context.Background(), tt.request)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if resp == nil || resp.Status != "provisioned" {
+					t.Error("expected provisioned status")
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateTenantUser(t *testing.T) {
+	user := &types.TenantUser{UserID: "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f", Email: "user@example.com", Role: "owner"}
+
+	tests := []struct {
+		name       string
+		request    *v0.UpdateTenantUserRequest
+		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
+		wantErr    bool
+		wantCode   codes.Code
+	}{
+		{
+			name: "success",
+			request: &v0.UpdateTenantUserRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				UserId:   "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f",
+				Role:     "owner",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().UpdateTenantUser(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f", types.RoleOwner).Return(user, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing tenant_id",
+			request: &v0.UpdateTenantUserRequest{
+				UserId: "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f",
+				Role:   "owner",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name: "service error",
+			request: &v0.UpdateTenantUserRequest{
+				TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b",
+				UserId:   "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f",
+				Role:     "owner",
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().UpdateTenantUser(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f", types.RoleOwner).
+					Return(nil, errors.New("service error"))
+			},
+			wantErr:  true,
 			wantCode: codes.Internal,
 		},
 	}
@@ -601,7 +1029,7 @@ func TestHandler_UpdateTenantUser(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.UpdateTenantUser").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -643,17 +1071,17 @@ func TestHandler_ListUserTenants(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			request: &v0.ListUserTenantsRequest{UserId: "user-123"},
+			request: &v0.ListUserTenantsRequest{UserId: "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListUserTenants(gomock.Any(), "user-123").Return(tenants, nil)
+				mockSvc.EXPECT().ListUserTenants(gomock.Any(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f").Return(tenants, nil)
 			},
 			wantErr: false,
 		},
 		{
 			name:    "service error",
-			request: &v0.ListUserTenantsRequest{UserId: "user-123"},
+			request: &v0.ListUserTenantsRequest{UserId: "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListUserTenants(gomock.Any(), "user-123").Return(nil, errors.New("service error"))
+				mockSvc.EXPECT().ListUserTenants(gomock.Any(), "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f").Return(nil, errors.New("service error"))
 			},
 			wantErr: true,
 		},
@@ -670,7 +1098,7 @@ func TestHandler_ListUserTenants(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListUserTenants").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -696,28 +1124,38 @@ func TestHandler_ListUserTenants(t *testing.T) {
 
 func TestHandler_ListTenantUsers(t *testing.T) {
 	users := []*types.TenantUser{
-		{UserID: "user-1", Email: "user1@example.com", Role: "owner"},
+		{UserID: "user-1", Email: "user1@example.com", Role: "owner", IdentityStatus: types.IdentityStatusResolved},
+		{UserID: "user-2", Role: "member", IdentityStatus: types.IdentityStatusUnknown},
 	}
 
 	tests := []struct {
-		name       string
-		request    *v0.ListTenantUsersRequest
-		setupMocks func(*MockServiceInterface, *MockLoggerInterface)
-		wantErr    bool
+		name         string
+		request      *v0.ListTenantUsersRequest
+		setupMocks   func(*MockServiceInterface, *MockLoggerInterface)
+		expectedNext string
+		wantErr      bool
 	}{
 		{
 			name:    "success",
-			request: &v0.ListTenantUsersRequest{TenantId: "tenant-123"},
+			request: &v0.ListTenantUsersRequest{TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenantUsers(gomock.Any(), "tenant-123").Return(users, nil)
+				mockSvc.EXPECT().ListTenantUsers(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", false, uint64(0), uint64(0)).Return(users, nil)
 			},
 			wantErr: false,
 		},
+		{
+			name:    "full page",
+			request: &v0.ListTenantUsersRequest{TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", PageSize: 2, PageToken: encodePageToken(2)},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().ListTenantUsers(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", false, uint64(2), uint64(2)).Return(users, nil)
+			},
+			expectedNext: encodePageToken(4),
+		},
 		{
 			name:    "service error",
-			request: &v0.ListTenantUsersRequest{TenantId: "tenant-123"},
+			request: &v0.ListTenantUsersRequest{TenantId: "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().ListTenantUsers(gomock.Any(), "tenant-123").Return(nil, errors.New("service error"))
+				mockSvc.EXPECT().ListTenantUsers(gomock.Any(), "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b", false, uint64(0), uint64(0)).Return(nil, errors.New("service error"))
 			},
 			wantErr: true,
 		},
@@ -734,7 +1172,7 @@ func TestHandler_ListTenantUsers(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			h := NewHandler(mockSvc, mockTracer, mockMonitor, mockLogger)
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListTenantUsers").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
@@ -751,8 +1189,841 @@ func TestHandler_ListTenantUsers(t *testing.T) {
 					t.Errorf("unexpected error: %v", err)
 				}
 				if resp == nil {
-					t.Error("expected response but got nil")
+					t.Fatal("expected response but got nil")
+				}
+				if resp.Users[1].IdentityStatus != v0.IdentityStatus_IDENTITY_STATUS_UNKNOWN || resp.Users[1].Email != "" {
+					t.Errorf("expected an unknown identity without email, got %v", resp.Users[1])
+				}
+				if resp.NextPageToken != tt.expectedNext {
+					t.Errorf("expected next page token %q, got %q", tt.expectedNext, resp.NextPageToken)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_RunDiagnostics(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *v0.RunDiagnosticsRequest
+		setupMocks func(*MockServiceInterface)
+		wantErr    bool
+		wantCode   codes.Code
+		wantCount  int
+	}{
+		{
+			name:    "success",
+			request: &v0.RunDiagnosticsRequest{Fix: []string{AnomalyMissingTuple}},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RunDiagnostics(gomock.Any(), []string{AnomalyMissingTuple}).
+					Return([]*types.Anomaly{
+						{Category: AnomalyMissingTuple, TenantID: "tenant-1", UserID: "user-1", Fixed: true},
+						{Category: AnomalyOwnerlessTenant, TenantID: "tenant-2"},
+					}, nil)
+			},
+			wantErr:   false,
+			wantCount: 2,
+		},
+		{
+			name:       "unknown category",
+			request:    &v0.RunDiagnosticsRequest{Fix: []string{"everything"}},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "service error",
+			request: &v0.RunDiagnosticsRequest{},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().RunDiagnostics(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("service error"))
+			},
+			wantErr:  true,
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.RunDiagnostics").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSvc)
+
+			resp, err := h.RunDiagnostics(context.Background(), tt.request)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				st, ok := status.FromError(err)
+				if ok && st.Code() != tt.wantCode {
+					t.Errorf("expected code %v, got %v", tt.wantCode, st.Code())
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if len(resp.Anomalies) != tt.wantCount {
+					t.Errorf("expected %d anomalies, got %d", tt.wantCount, len(resp.Anomalies))
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_CreateRole(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		request    *v0.CreateRoleRequest
+		setupMocks func(*MockRoleServiceInterface)
+		wantErr    bool
+		wantCode   codes.Code
+	}{
+		{
+			name:    "success",
+			request: &v0.CreateRoleRequest{TenantId: tenantID, Name: "billing-admin", Permissions: []string{"can_view", "can_edit"}},
+			setupMocks: func(mockRoles *MockRoleServiceInterface) {
+				mockRoles.EXPECT().CreateRole(gomock.Any(), tenantID, "billing-admin", []string{"can_view", "can_edit"}).
+					Return(&types.Role{ID: "role-1", TenantID: tenantID, Name: "billing-admin", Permissions: []string{"can_edit", "can_view"}}, nil)
+			},
+		},
+		{
+			name:       "built-in role name",
+			request:    &v0.CreateRoleRequest{TenantId: tenantID, Name: "owner", Permissions: []string{"can_view"}},
+			setupMocks: func(mockRoles *MockRoleServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "unknown permission",
+			request:    &v0.CreateRoleRequest{TenantId: tenantID, Name: "auditor", Permissions: []string{"can_audit"}},
+			setupMocks: func(mockRoles *MockRoleServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "duplicate role",
+			request: &v0.CreateRoleRequest{TenantId: tenantID, Name: "auditor", Permissions: []string{"can_view"}},
+			setupMocks: func(mockRoles *MockRoleServiceInterface) {
+				mockRoles.EXPECT().CreateRole(gomock.Any(), tenantID, "auditor", gomock.Any()).Return(nil, role.ErrRoleExists)
+			},
+			wantErr:  true,
+			wantCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockRoles := NewMockRoleServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, mockRoles, NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.CreateRole").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockRoles)
+
+			resp, err := h.CreateRole(context.Background(), tt.request)
+
+			if tt.wantErr {
+				if status.Code(err) != tt.wantCode {
+					t.Errorf("expected code %v, got %v", tt.wantCode, err)
 				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Role.Id != "role-1" {
+				t.Errorf("expected role role-1, got %s", resp.Role.Id)
+			}
+		})
+	}
+}
+
+func TestHandler_AssignRole(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	roleID := "0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e"
+	userID := "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "role not found", serviceErr: role.ErrRoleNotFound, wantCode: codes.NotFound},
+		{name: "user is not a member", serviceErr: role.ErrNotMember, wantCode: codes.FailedPrecondition},
+		{name: "already assigned", serviceErr: role.ErrAlreadyAssigned, wantCode: codes.AlreadyExists},
+		{name: "service error", serviceErr: errors.New("fga error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockRoles := NewMockRoleServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, mockRoles, NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.AssignRole").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockRoles.EXPECT().AssignRole(gomock.Any(), tenantID, roleID, userID).Return(tt.serviceErr)
+
+			_, err := h.AssignRole(context.Background(), &v0.AssignRoleRequest{TenantId: tenantID, RoleId: roleID, UserId: userID})
+
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestHandler_CreateAPIKey(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		request    *v0.CreateAPIKeyRequest
+		setupMocks func(*MockAPIKeyServiceInterface)
+		wantErr    bool
+		wantCode   codes.Code
+	}{
+		{
+			name:    "success",
+			request: &v0.CreateAPIKeyRequest{TenantId: tenantID, Name: "ci", Role: "member"},
+			setupMocks: func(mockAPIKeys *MockAPIKeyServiceInterface) {
+				mockAPIKeys.EXPECT().CreateAPIKey(gomock.Any(), tenantID, "ci", types.RoleMember).
+					Return(&types.APIKey{ID: "key-1", TenantID: tenantID, Name: "ci", Role: types.RoleMember, Prefix: "tsk_abcdefgh"}, "tsk_abcdefghijk", nil)
+			},
+		},
+		{
+			name:       "unknown role",
+			request:    &v0.CreateAPIKeyRequest{TenantId: tenantID, Name: "ci", Role: "superuser"},
+			setupMocks: func(mockAPIKeys *MockAPIKeyServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "missing name",
+			request:    &v0.CreateAPIKeyRequest{TenantId: tenantID, Role: "member"},
+			setupMocks: func(mockAPIKeys *MockAPIKeyServiceInterface) {},
+			wantErr:    true,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "duplicate key",
+			request: &v0.CreateAPIKeyRequest{TenantId: tenantID, Name: "ci", Role: "member"},
+			setupMocks: func(mockAPIKeys *MockAPIKeyServiceInterface) {
+				mockAPIKeys.EXPECT().CreateAPIKey(gomock.Any(), tenantID, "ci", types.RoleMember).Return(nil, "", apikey.ErrAPIKeyExists)
+			},
+			wantErr:  true,
+			wantCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeys := NewMockAPIKeyServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(NewMockServiceInterface(ctrl), NewMockRoleServiceInterface(ctrl), mockAPIKeys, setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.CreateAPIKey").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockAPIKeys)
+
+			resp, err := h.CreateAPIKey(context.Background(), tt.request)
+
+			if tt.wantErr {
+				if status.Code(err) != tt.wantCode {
+					t.Errorf("expected code %v, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.ApiKey.Id != "key-1" || resp.Key != "tsk_abcdefghijk" || resp.ApiKey.Role != "member" {
+				t.Errorf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
+func TestHandler_RevokeAPIKey(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	keyID := "0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e"
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "key not found", serviceErr: apikey.ErrAPIKeyNotFound, wantCode: codes.NotFound},
+		{name: "service error", serviceErr: errors.New("db error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeys := NewMockAPIKeyServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(NewMockServiceInterface(ctrl), NewMockRoleServiceInterface(ctrl), mockAPIKeys, setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.RevokeAPIKey").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockAPIKeys.EXPECT().RevokeAPIKey(gomock.Any(), tenantID, keyID).Return(tt.serviceErr)
+
+			_, err := h.RevokeAPIKey(context.Background(), &v0.RevokeAPIKeyRequest{TenantId: tenantID, ApiKeyId: keyID})
+
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestHandler_CreateWebhookSubscription(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		request    *v0.CreateWebhookSubscriptionRequest
+		disabled   bool
+		setupMocks func(*MockSubscriptionServiceInterface)
+		wantCode   codes.Code
+	}{
+		{
+			name:    "success",
+			request: &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "https://hooks.example.com", EventTypes: []string{"member.added"}, Secret: "s3cret"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {
+				mockSubs.EXPECT().CreateSubscription(gomock.Any(), tenantID, "https://hooks.example.com", []string{"member.added"}, "s3cret").
+					Return(&types.WebhookSubscription{ID: "subscription-1", TenantID: tenantID, URL: "https://hooks.example.com", EventTypes: []string{"member.added"}}, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "invalid url",
+			request:    &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "hooks.example.com", Secret: "s3cret"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "unknown event type",
+			request:    &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "https://hooks.example.com", EventTypes: []string{"tenant.renamed"}, Secret: "s3cret"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "missing secret",
+			request:    &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "https://hooks.example.com"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "unknown tenant",
+			request: &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "https://hooks.example.com", Secret: "s3cret"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {
+				mockSubs.EXPECT().CreateSubscription(gomock.Any(), tenantID, "https://hooks.example.com", gomock.Any(), "s3cret").Return(nil, subscription.ErrTenantNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:    "internal URL",
+			request: &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "http://169.254.169.254/latest/meta-data/", Secret: "s3cret"},
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {
+				mockSubs.EXPECT().CreateSubscription(gomock.Any(), tenantID, "http://169.254.169.254/latest/meta-data/", gomock.Any(), "s3cret").Return(nil, subscription.ErrURLNotPublic)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:       "subscriptions disabled",
+			request:    &v0.CreateWebhookSubscriptionRequest{TenantId: tenantID, Url: "https://hooks.example.com", Secret: "s3cret"},
+			disabled:   true,
+			setupMocks: func(mockSubs *MockSubscriptionServiceInterface) {},
+			wantCode:   codes.Unimplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSubs := NewMockSubscriptionServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(NewMockServiceInterface(ctrl), NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			if !tt.disabled {
+				h.SetSubscriptions(mockSubs)
+			}
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.CreateWebhookSubscription").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSubs)
+
+			resp, err := h.CreateWebhookSubscription(context.Background(), tt.request)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && (resp.Id != "subscription-1" || len(resp.EventTypes) != 1) {
+				t.Errorf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ReplayWebhookDelivery(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	subscriptionID := "0b9c8d7e-6f5a-4b3c-9d2e-1f0a9b8c7d6e"
+	deliveryID := "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "delivery not found", serviceErr: subscription.ErrDeliveryNotFound, wantCode: codes.NotFound},
+		{name: "delivery not failed", serviceErr: subscription.ErrDeliveryNotFailed, wantCode: codes.FailedPrecondition},
+		{name: "service error", serviceErr: errors.New("db error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSubs := NewMockSubscriptionServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			h := NewHandler(NewMockServiceInterface(ctrl), NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			h.SetSubscriptions(mockSubs)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ReplayWebhookDelivery").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			var delivery *types.WebhookDelivery
+			if tt.serviceErr == nil {
+				delivery = &types.WebhookDelivery{ID: deliveryID, SubscriptionID: subscriptionID, Status: types.WebhookDeliveryPending}
+			}
+			mockSubs.EXPECT().ReplayDelivery(gomock.Any(), tenantID, subscriptionID, deliveryID).Return(delivery, tt.serviceErr)
+
+			resp, err := h.ReplayWebhookDelivery(context.Background(), &v0.ReplayWebhookDeliveryRequest{TenantId: tenantID, SubscriptionId: subscriptionID, DeliveryId: deliveryID})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && resp.Status != "pending" {
+				t.Errorf("expected a pending delivery, got %v", resp)
+			}
+		})
+	}
+}
+
+func TestHandler_AddPlatformAdmin(t *testing.T) {
+	userID := "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"
+
+	tests := []struct {
+		name       string
+		request    *v0.AddPlatformAdminRequest
+		setupMocks func(*MockServiceInterface)
+		wantCode   codes.Code
+	}{
+		{
+			name:    "success",
+			request: &v0.AddPlatformAdminRequest{GroupId: "support", UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().AddPlatformAdmin(gomock.Any(), "support", userID).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "missing group",
+			request:    &v0.AddPlatformAdminRequest{UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "invalid user id",
+			request:    &v0.AddPlatformAdminRequest{GroupId: "support", UserId: "not-a-uuid"},
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:    "service error",
+			request: &v0.AddPlatformAdminRequest{GroupId: "support", UserId: userID},
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().AddPlatformAdmin(gomock.Any(), "support", userID).Return(errors.New("fga error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.AddPlatformAdmin").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tt.setupMocks(mockSvc)
+
+			_, err := h.AddPlatformAdmin(context.Background(), tt.request)
+
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestHandler_ListPlatformAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := NewMockServiceInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+	mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListPlatformAdmins").
+		Return(context.Background(), trace.SpanFromContext(context.Background()))
+	mockSvc.EXPECT().ListPlatformAdmins(gomock.Any(), "support").Return([]string{"user-1", "user-2"}, nil)
+
+	resp, err := h.ListPlatformAdmins(context.Background(), &v0.ListPlatformAdminsRequest{GroupId: "support"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.UserIds) != 2 || resp.UserIds[0] != "user-1" {
+		t.Errorf("unexpected admins: %v", resp.UserIds)
+	}
+}
+
+func TestHandler_LinkTenantToSupportGroup(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "tenant not found", serviceErr: fmt.Errorf("failed to get tenant: %w", storage.ErrNotFound), wantCode: codes.NotFound},
+		{name: "service error", serviceErr: errors.New("fga error"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.LinkTenantToSupportGroup").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockSvc.EXPECT().LinkTenantToSupportGroup(gomock.Any(), tenantID, "support").Return(tt.serviceErr)
+
+			_, err := h.LinkTenantToSupportGroup(context.Background(), &v0.LinkTenantToSupportGroupRequest{TenantId: tenantID, GroupId: "support"})
+
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestHandler_GetMyPermissions(t *testing.T) {
+	tenantID := "6f1c2a4e-3b5d-4e7f-8a9b-0c1d2e3f4a5b"
+	userID := "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"
+	permissions := map[string]bool{"owner": false, "member": true, "can_view": true, "can_edit": false}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		setupMocks func(*MockServiceInterface)
+		wantCode   codes.Code
+	}{
+		{
+			name: "success",
+			ctx:  authentication.WithUserID(context.Background(), userID),
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetUserPermissions(gomock.Any(), tenantID, userID).Return(permissions, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "unauthenticated",
+			ctx:        context.Background(),
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.Unauthenticated,
+		},
+		{
+			name: "service error",
+			ctx:  authentication.WithUserID(context.Background(), userID),
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().GetUserPermissions(gomock.Any(), tenantID, userID).Return(nil, errors.New("fga error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.GetMyPermissions").
+				Return(tt.ctx, trace.SpanFromContext(tt.ctx))
+			tt.setupMocks(mockSvc)
+
+			resp, err := h.GetMyPermissions(tt.ctx, &v0.GetMyPermissionsRequest{TenantId: tenantID})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && !resp.Permissions["can_view"] {
+				t.Errorf("expected can_view to be granted, got %v", resp.Permissions)
+			}
+		})
+	}
+}
+
+func TestHandler_WhoAmI(t *testing.T) {
+	userID := "2d7e9f10-4a6b-4c8d-9e0f-1a2b3c4d5e6f"
+	principal := &authentication.Principal{
+		ID:     userID,
+		Email:  "jane@example.com",
+		Type:   authentication.PrincipalUser,
+		Scopes: []string{"openid"},
+		Method: authentication.AuthMethodJWT,
+	}
+	memberships := []*types.UserMembership{
+		{
+			Tenant:      &types.Tenant{ID: "tenant-1", Name: "Tenant 1", Enabled: true},
+			Role:        types.RoleAdmin,
+			Permissions: map[string]bool{"admin": true, "can_edit": true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		setupMocks func(*MockServiceInterface)
+		wantCode   codes.Code
+	}{
+		{
+			name: "success",
+			ctx:  authentication.WithPrincipal(context.Background(), principal),
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().ListUserMemberships(gomock.Any(), userID).Return(memberships, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "unauthenticated",
+			ctx:        context.Background(),
+			setupMocks: func(mockSvc *MockServiceInterface) {},
+			wantCode:   codes.Unauthenticated,
+		},
+		{
+			name: "service error",
+			ctx:  authentication.WithPrincipal(context.Background(), principal),
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().ListUserMemberships(gomock.Any(), userID).Return(nil, errors.New("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.WhoAmI").
+				Return(tt.ctx, trace.SpanFromContext(tt.ctx))
+			tt.setupMocks(mockSvc)
+
+			resp, err := h.WhoAmI(tt.ctx, &v0.WhoAmIRequest{})
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			if resp.Subject != userID || resp.AuthMethod != "jwt" || resp.PrincipalType != "user" || resp.Email != principal.Email {
+				t.Errorf("expected the principal to be returned, got %v", resp)
+			}
+			if len(resp.Memberships) != 1 || resp.Memberships[0].Tenant.Id != "tenant-1" || resp.Memberships[0].Role != "admin" || !resp.Memberships[0].Permissions["can_edit"] {
+				t.Errorf("expected the memberships to be returned, got %v", resp.Memberships)
+			}
+		})
+	}
+}
+
+func TestHandler_ListAuthzAudit(t *testing.T) {
+	since := "2026-01-02T15:04:05Z"
+	entries := []*types.AuthzAuditEntry{
+		{ID: 12, Actor: "admin", Operation: "write", User: "user:1", Relation: "member", Object: "tenant:a", RequestID: "req-1"},
+		{ID: 7, Actor: "admin", Operation: "delete", User: "user:2", Relation: "owner", Object: "tenant:a", RequestID: "req-2"},
+	}
+
+	tests := []struct {
+		name          string
+		req           *v0.ListAuthzAuditRequest
+		expectedCall  bool
+		expectedLimit uint64
+		expectedNext  string
+		wantCode      codes.Code
+	}{
+		{
+			name:          "default page size",
+			req:           &v0.ListAuthzAuditRequest{Object: "tenant:a", Since: since},
+			expectedCall:  true,
+			expectedLimit: defaultAuditPageSize,
+			wantCode:      codes.OK,
+		},
+		{
+			name:          "full page",
+			req:           &v0.ListAuthzAuditRequest{Object: "tenant:a", Since: since, PageSize: 2},
+			expectedCall:  true,
+			expectedLimit: 2,
+			expectedNext:  encodePageToken(7),
+			wantCode:      codes.OK,
+		},
+		{
+			name:     "invalid since",
+			req:      &v0.ListAuthzAuditRequest{Since: "yesterday"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "page size too large",
+			req:      &v0.ListAuthzAuditRequest{PageSize: maxAuditPageSize + 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid page token",
+			req:      &v0.ListAuthzAuditRequest{PageToken: "not-a-token"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockServiceInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			h := NewHandler(mockSvc, NewMockRoleServiceInterface(ctrl), NewMockAPIKeyServiceInterface(ctrl), setupIdempotencyMock(ctrl), setupDeprecationMock(ctrl), mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Handler.ListAuthzAudit").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			if tt.expectedCall {
+				mockSvc.EXPECT().ListAuthzAudit(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error) {
+						if filter.Object != "tenant:a" || filter.Limit != tt.expectedLimit || filter.Since.Format(time.RFC3339) != since {
+							t.Errorf("unexpected filter %+v", filter)
+						}
+						return entries, nil
+					},
+				)
+			}
+
+			resp, err := h.ListAuthzAudit(context.Background(), tt.req)
+
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(resp.Entries) != 2 || resp.Entries[1].Operation != "delete" || resp.Entries[1].RequestId != "req-2" {
+				t.Errorf("unexpected entries %v", resp.Entries)
+			}
+			if resp.NextPageToken != tt.expectedNext {
+				t.Errorf("expected next page token %q, got %q", tt.expectedNext, resp.NextPageToken)
 			}
 		})
 	}
@@ -5,45 +5,130 @@ package tenant
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/tenant-service/internal/openfga"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/deprecation"
 	ory "github.com/ory/client-go"
+	"google.golang.org/protobuf/proto"
 )
 
 type ServiceInterface interface {
-	InviteMember(ctx context.Context, tenantID, email, role string) (string, string, error)
-	CreateTenant(ctx context.Context, name string) (*types.Tenant, error)
+	InviteMember(ctx context.Context, tenantID, email string, role types.MembershipRole) (string, string, error)
+	ListInvites(ctx context.Context, tenantID string) ([]*types.Invite, error)
+	RevokeInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, error)
+	ResendInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, string, string, error)
+	CreateTenant(ctx context.Context, name, region string) (*types.Tenant, error)
 	UpdateTenant(ctx context.Context, tenant *types.Tenant, paths []string) (*types.Tenant, error)
-	DeleteTenant(ctx context.Context, id string) error
-	ProvisionUser(ctx context.Context, tenantID, email, role string) error
-	UpdateTenantUser(ctx context.Context, tenantID, userID, role string) (*types.TenantUser, error)
+	DeleteTenant(ctx context.Context, id string) (bool, error)
+	ProvisionUser(ctx context.Context, tenantID, email string, role types.MembershipRole) error
+	UpdateTenantUser(ctx context.Context, tenantID, userID string, role types.MembershipRole) (*types.TenantUser, error)
+	RemoveTenantUser(ctx context.Context, tenantID, userID string) (bool, error)
 	ListUserTenants(ctx context.Context, userID string) ([]*types.Tenant, error)
 	ListTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
-	ListTenants(ctx context.Context) ([]*types.Tenant, error)
-	ListTenantUsers(ctx context.Context, tenantID string) ([]*types.TenantUser, error)
+	ListTenants(ctx context.Context, filter *types.TenantFilter) ([]*types.Tenant, error)
+	GetUserPermissions(ctx context.Context, tenantID, userID string) (map[string]bool, error)
+	ListUserMemberships(ctx context.Context, userID string) ([]*types.UserMembership, error)
+	ListTenantUsers(ctx context.Context, tenantID string, skipIdentityLookup bool, offset, limit uint64) ([]*types.TenantUser, error)
+	GetTenantUser(ctx context.Context, tenantID, userID string) (*types.TenantUser, error)
+	RunDiagnostics(ctx context.Context, fix []string) ([]*types.Anomaly, error)
+	AddPlatformAdmin(ctx context.Context, groupID, userID string) error
+	RemovePlatformAdmin(ctx context.Context, groupID, userID string) error
+	ListPlatformAdmins(ctx context.Context, groupID string) ([]string, error)
+	LinkTenantToSupportGroup(ctx context.Context, tenantID, groupID string) error
+	ListAuthzAudit(ctx context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error)
+	GetTenantStats(ctx context.Context, tenantID string) (*types.TenantStats, error)
+}
+
+// EventsInterface publishes the tenant lifecycle events, see events.Publisher.
+type EventsInterface interface {
+	Publish(ctx context.Context, eventType, subject string, data any) error
 }
 
 type StorageInterface interface {
 	CreateTenant(ctx context.Context, t *types.Tenant) (*types.Tenant, error)
 	UpdateTenant(ctx context.Context, tenant *types.Tenant, paths []string) error
 	DeleteTenant(ctx context.Context, id string) error
-	AddMember(ctx context.Context, tenantID, userID, role string) (string, error)
+	AddMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) (string, error)
 	GetTenantByID(ctx context.Context, id string) (*types.Tenant, error)
 	ListTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
+	ListTenantsByIDs(ctx context.Context, ids []string) ([]*types.Tenant, error)
 	ListTenants(ctx context.Context) ([]*types.Tenant, error)
+	ListTenantsPage(ctx context.Context, filter *types.TenantFilter) ([]*types.Tenant, error)
 	ListActiveTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
 	ListMembersByTenantID(ctx context.Context, tenantID string) ([]*types.Membership, error)
-	UpdateMember(ctx context.Context, tenantID, userID, role string) error
+	ListMembersPage(ctx context.Context, tenantID string, offset, limit uint64) ([]*types.Membership, error)
+	GetMember(ctx context.Context, tenantID, userID string) (*types.Membership, error)
+	CountMembers(ctx context.Context, tenantID string) (int, error)
+	ListMembershipsByUserID(ctx context.Context, userID string) ([]*types.UserMembership, error)
+	UpdateMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) error
+	DeleteMember(ctx context.Context, tenantID, userID string) error
+	CreateInvite(ctx context.Context, i *types.Invite) (*types.Invite, error)
+	GetInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, error)
+	ListInvitesByTenantID(ctx context.Context, tenantID string) ([]*types.Invite, error)
+	CountPendingInvites(ctx context.Context, tenantID string, now time.Time) (int, error)
+	RevokeInvite(ctx context.Context, tenantID, inviteID string) error
+	RenewInvite(ctx context.Context, tenantID, inviteID string, expiresAt time.Time) error
+	ListAuthzAuditEntries(ctx context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error)
 }
 
 type AuthzInterface interface {
 	Check(ctx context.Context, user, relation, object string, tuples ...openfga.Tuple) (bool, error)
+	ListObjects(ctx context.Context, user, relation, objectType string) ([]string, error)
+	FilterObjectsStreamed(ctx context.Context, user, relation, objectType string, objs []string) ([]string, error)
+	CheckTenantAccess(ctx context.Context, tenantID, userID, relation string, tuples ...openfga.Tuple) (bool, error)
+	TenantPermissions(ctx context.Context, tenantID, userID string) (map[string]bool, error)
 	AssignTenantOwner(ctx context.Context, tenantID, userID string) error
+	AssignTenantAdmin(ctx context.Context, tenantID, userID string) error
 	AssignTenantMember(ctx context.Context, tenantID, userID string) error
 	RemoveTenantOwner(ctx context.Context, tenantID, userID string) error
+	RemoveTenantAdmin(ctx context.Context, tenantID, userID string) error
 	RemoveTenantMember(ctx context.Context, tenantID, userID string) error
+	UnassignRole(ctx context.Context, roleID, userID string) error
 	DeleteTenant(ctx context.Context, tenantID string) error
+	ListTenantTuples(ctx context.Context, tenantID string) ([]openfga.Tuple, error)
+	AssignPrivilegedAdmin(ctx context.Context, privilegedID, userID string) error
+	RemovePrivilegedAdmin(ctx context.Context, privilegedID, userID string) error
+	ListPrivilegedAdmins(ctx context.Context, privilegedID string) ([]string, error)
+	LinkTenantToPrivileged(ctx context.Context, tenantID, privilegedID string) error
+}
+
+// RoleServiceInterface manages the custom roles of a tenant, see pkg/role.
+type RoleServiceInterface interface {
+	CreateRole(ctx context.Context, tenantID, name string, permissions []string) (*types.Role, error)
+	ListRoles(ctx context.Context, tenantID string) ([]*types.Role, error)
+	UpdateRole(ctx context.Context, tenantID, roleID string, permissions []string) (*types.Role, error)
+	DeleteRole(ctx context.Context, tenantID, roleID string) error
+	AssignRole(ctx context.Context, tenantID, roleID, userID string) error
+	UnassignRole(ctx context.Context, tenantID, roleID, userID string) error
+}
+
+// APIKeyServiceInterface manages the API keys of a tenant, see pkg/apikey.
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context, tenantID, name string, role types.MembershipRole) (*types.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, tenantID string) ([]*types.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string) error
+}
+
+// SubscriptionServiceInterface manages the webhook subscriptions of a
+// tenant, see pkg/subscription.
+type SubscriptionServiceInterface interface {
+	CreateSubscription(ctx context.Context, tenantID, url string, eventTypes []string, secret string) (*types.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*types.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, subscriptionID string) error
+	ListDeliveries(ctx context.Context, tenantID, subscriptionID string) ([]*types.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, tenantID, subscriptionID, deliveryID string) (*types.WebhookDelivery, error)
+}
+
+// IdempotencyInterface makes retried mutating requests replay their original response.
+type IdempotencyInterface interface {
+	Execute(ctx context.Context, operation, key string, req proto.Message, fn func(context.Context) (proto.Message, error)) (proto.Message, error)
+}
+
+// DeprecationInterface reports the use of deprecated API features to clients.
+type DeprecationInterface interface {
+	Warn(ctx context.Context, feature deprecation.Feature)
 }
 
 type KratosClientInterface interface {
@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/events"
+)
+
+// ErrQuotaExceeded is returned for the invitations and provisionings that
+// would take a tenant past one of its quotas.
+var ErrQuotaExceeded = errors.New("tenant quota exceeded")
+
+// The quotas named in the tenant.quota_warning events.
+const (
+	QuotaMembers        = "members"
+	QuotaPendingInvites = "pending_invites"
+)
+
+// Quotas are the limits of every tenant, zero leaves a limit unenforced.
+type Quotas struct {
+	Members int
+	// PendingInvites counts the invitations neither accepted, revoked nor
+	// expired.
+	PendingInvites int
+	// WarningPercent is the usage, in percent of a limit, from which the
+	// owners of the tenant are warned, zero warns nobody.
+	WarningPercent int
+}
+
+// SetQuotas enforces q on every tenant.
+func (s *Service) SetQuotas(q Quotas) {
+	s.quotas = q
+}
+
+// GetTenantStats returns the usage of the tenant against its quotas.
+func (s *Service) GetTenantStats(ctx context.Context, tenantID string) (*types.TenantStats, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.GetTenantStats")
+	defer span.End()
+
+	if _, err := s.storage.GetTenantByID(ctx, tenantID); err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.recordError(span, "failed to get tenant", err, "tenant_id", tenantID)
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	stats, err := s.tenantStats(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to get tenant stats", err, "tenant_id", tenantID)
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *Service) tenantStats(ctx context.Context, tenantID string) (*types.TenantStats, error) {
+	members, err := s.storage.CountMembers(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	invites, err := s.storage.CountPendingInvites(ctx, tenantID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending invites: %w", err)
+	}
+
+	return &types.TenantStats{
+		TenantID:       tenantID,
+		Members:        s.quotaUsage(members, s.quotas.Members),
+		PendingInvites: s.quotaUsage(invites, s.quotas.PendingInvites),
+	}, nil
+}
+
+func (s *Service) quotaUsage(used, limit int) types.QuotaUsage {
+	return types.QuotaUsage{
+		Used:    used,
+		Limit:   limit,
+		Warning: limit > 0 && s.quotas.WarningPercent > 0 && used*100 >= limit*s.quotas.WarningPercent,
+	}
+}
+
+// checkQuotas checks that userID can join the tenant, unless a member
+// already, and be invited to it when invite is set. It returns the usage
+// before the change, for warnQuotas. Concurrent changes are not serialized,
+// they can take a tenant a few past a limit.
+func (s *Service) checkQuotas(ctx context.Context, tenantID, userID string, invite bool) (*types.TenantStats, error) {
+	if s.quotas.Members == 0 && s.quotas.PendingInvites == 0 {
+		return nil, nil
+	}
+
+	stats, err := s.tenantStats(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invite && stats.PendingInvites.Limit > 0 && stats.PendingInvites.Used >= stats.PendingInvites.Limit {
+		return nil, fmt.Errorf("%w: %d pending invitations of %d", ErrQuotaExceeded, stats.PendingInvites.Used, stats.PendingInvites.Limit)
+	}
+
+	if stats.Members.Limit > 0 && stats.Members.Used >= stats.Members.Limit {
+		_, err := s.storage.GetMember(ctx, tenantID, userID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return nil, fmt.Errorf("%w: %d members of %d", ErrQuotaExceeded, stats.Members.Used, stats.Members.Limit)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get member: %w", err)
+		}
+	}
+
+	return stats, nil
+}
+
+// warnQuotas warns the owners of the tenant, with a tenant.quota_warning
+// event, of the quotas the change from before takes past their warning
+// threshold. A quota past it already is not warned of again.
+func (s *Service) warnQuotas(ctx context.Context, before *types.TenantStats, members, invites int) error {
+	if before == nil {
+		return nil
+	}
+
+	for _, q := range []struct {
+		name  string
+		usage types.QuotaUsage
+		added int
+	}{
+		{name: QuotaMembers, usage: before.Members, added: members},
+		{name: QuotaPendingInvites, usage: before.PendingInvites, added: invites},
+	} {
+		after := s.quotaUsage(q.usage.Used+q.added, q.usage.Limit)
+		if q.usage.Warning || !after.Warning {
+			continue
+		}
+
+		s.logger.Warnw("tenant quota past its warning threshold", "tenant_id", before.TenantID, "quota", q.name, "used", after.Used, "limit", after.Limit)
+		data := events.QuotaData{TenantID: before.TenantID, Quota: q.name, Used: after.Used, Limit: after.Limit}
+		if err := s.publish(ctx, events.TenantQuotaWarning, before.TenantID, data); err != nil {
+			return err
+		}
+		s.incrementCounter("quota_warning", "")
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/storage"
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/events"
+)
+
+func TestService_GetTenantStats(t *testing.T) {
+	tenantID := "tenant-123"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface)
+		expected    *types.TenantStats
+		expectedErr error
+	}{
+		{
+			name: "usage against the quotas",
+			setupMocks: func(mockStorage *MockStorageInterface) {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(&types.Tenant{ID: tenantID}, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(8, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(1, nil)
+			},
+			expected: &types.TenantStats{
+				TenantID:       tenantID,
+				Members:        types.QuotaUsage{Used: 8, Limit: 10, Warning: true},
+				PendingInvites: types.QuotaUsage{Used: 1, Limit: 5},
+			},
+		},
+		{
+			name: "missing tenant",
+			setupMocks: func(mockStorage *MockStorageInterface) {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: storage.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.GetTenantStats").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage)
+
+			s := NewService(mockStorage, NewMockAuthzInterface(ctrl), NewMockKratosClientInterface(ctrl), "1h", true, TenantSourceDatabase, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			s.SetQuotas(Quotas{Members: 10, PendingInvites: 5, WarningPercent: 80})
+
+			stats, err := s.GetTenantStats(context.Background(), tenantID)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("expected %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *stats != *tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, stats)
+			}
+		})
+	}
+}
+
+func TestService_Quotas(t *testing.T) {
+	tenantID := "tenant-123"
+	email := "user@example.com"
+	identityID := "identity-456"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface, *MockEventsInterface, *MockMonitorInterface)
+		call        func(*Service) error
+		expectedErr error
+	}{
+		{
+			name: "provisioning under the warning threshold",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockEvents *MockEventsInterface, mockMonitor *MockMonitorInterface) {
+				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(6, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(0, nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.MemberAdded, tenantID, gomock.Any()).Return(nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "user_provisioned", "role": "member"}).Return(nil)
+			},
+			call: func(s *Service) error {
+				return s.ProvisionUser(context.Background(), tenantID, email, types.RoleMember)
+			},
+		},
+		{
+			name: "provisioning reaching the warning threshold",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockEvents *MockEventsInterface, mockMonitor *MockMonitorInterface) {
+				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(7, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(0, nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.MemberAdded, tenantID, gomock.Any()).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.TenantQuotaWarning, tenantID, events.QuotaData{TenantID: tenantID, Quota: QuotaMembers, Used: 8, Limit: 10}).Return(nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "quota_warning", "role": ""}).Return(nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "user_provisioned", "role": "member"}).Return(nil)
+			},
+			call: func(s *Service) error {
+				return s.ProvisionUser(context.Background(), tenantID, email, types.RoleMember)
+			},
+		},
+		{
+			name: "provisioning past the member quota",
+			setupMocks: func(mockStorage *MockStorageInterface, _ *MockAuthzInterface, mockKratos *MockKratosClientInterface, _ *MockEventsInterface, _ *MockMonitorInterface) {
+				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(10, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(0, nil)
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenantID, identityID).Return(nil, storage.ErrNotFound)
+			},
+			call: func(s *Service) error {
+				return s.ProvisionUser(context.Background(), tenantID, email, types.RoleMember)
+			},
+			expectedErr: ErrQuotaExceeded,
+		},
+		{
+			name: "invitation past the invite quota",
+			setupMocks: func(mockStorage *MockStorageInterface, _ *MockAuthzInterface, mockKratos *MockKratosClientInterface, _ *MockEventsInterface, _ *MockMonitorInterface) {
+				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(1, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(5, nil)
+			},
+			call: func(s *Service) error {
+				_, _, err := s.InviteMember(context.Background(), tenantID, email, types.RoleMember)
+				return err
+			},
+			expectedErr: ErrQuotaExceeded,
+		},
+		{
+			name: "member invited again at the member quota",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, _ *MockEventsInterface, mockMonitor *MockMonitorInterface) {
+				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
+				mockStorage.EXPECT().CountMembers(gomock.Any(), tenantID).Return(10, nil)
+				mockStorage.EXPECT().CountPendingInvites(gomock.Any(), tenantID, gomock.Any()).Return(0, nil)
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenantID, identityID).Return(&types.Membership{KratosIdentityID: identityID}, nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("", storage.ErrDuplicateKey)
+				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
+				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), identityID, "1h").Return("https://link", "code", nil)
+				mockStorage.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).Return(&types.Invite{}, nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "invitation_sent", "role": "member"}).Return(nil)
+			},
+			call: func(s *Service) error {
+				_, _, err := s.InviteMember(context.Background(), tenantID, email, types.RoleMember)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockEvents := NewMockEventsInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+			tc.setupMocks(mockStorage, mockAuthz, mockKratos, mockEvents, mockMonitor)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+			s.SetEvents(mockEvents)
+			s.SetQuotas(Quotas{Members: 10, PendingInvites: 5, WarningPercent: 80})
+
+			err := tc.call(s)
+			if tc.expectedErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
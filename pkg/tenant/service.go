@@ -8,27 +8,59 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/canonical/tenant-service/internal/authorization"
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
 	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/tracing"
 	"github.com/canonical/tenant-service/internal/types"
 	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/events"
 )
 
+const (
+	// TenantSourceDatabase lists the tenants of a user from the membership rows.
+	TenantSourceDatabase = "database"
+	// TenantSourceOpenFGA lists the tenants of a user from the OpenFGA member
+	// relations, hydrated from the database.
+	TenantSourceOpenFGA = "openfga"
+)
+
+// ErrInviteClosed is returned for the invitations accepted or revoked
+// already, which cannot be revoked nor sent again.
+var ErrInviteClosed = errors.New("invite is closed")
+
+// identityLookupConcurrency bounds the Kratos lookups made in parallel when
+// listing the users of a tenant.
+const identityLookupConcurrency = 10
+
 type Service struct {
 	storage            StorageInterface
 	authz              AuthzInterface
 	kratos             KratosClientInterface
 	invitationLifetime string
-	tracer             tracing.TracingInterface
-	monitor            monitoring.MonitorInterface
-	logger             logging.LoggerInterface
+	// authorizationEnabled controls whether admin listings are filtered
+	// through OpenFGA or served with an audited bypass.
+	authorizationEnabled bool
+	// tenantSource is where ListTenantsByUserID reads memberships from,
+	// TenantSourceDatabase or TenantSourceOpenFGA.
+	tenantSource string
+	quotas       Quotas
+	// no event is published without a publisher
+	events  EventsInterface
+	tracer  tracing.TracingInterface
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
 }
 
 func NewService(
@@ -36,19 +68,37 @@ func NewService(
 	authz AuthzInterface,
 	kratos KratosClientInterface,
 	invitationLifetime string,
+	authorizationEnabled bool,
+	tenantSource string,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
 ) *Service {
 	return &Service{
-		storage:            storage,
-		authz:              authz,
-		kratos:             kratos,
-		invitationLifetime: invitationLifetime,
-		tracer:             tracer,
-		monitor:            monitor,
-		logger:             logger,
+		storage:              storage,
+		authz:                authz,
+		kratos:               kratos,
+		invitationLifetime:   invitationLifetime,
+		authorizationEnabled: authorizationEnabled,
+		tenantSource:         tenantSource,
+		tracer:               tracer,
+		monitor:              monitor,
+		logger:               logger,
+	}
+}
+
+// SetEvents publishes the tenant lifecycle events with p, in the
+// transaction of the mutation.
+func (s *Service) SetEvents(p EventsInterface) {
+	s.events = p
+}
+
+// publish publishes an event about subject when events are enabled.
+func (s *Service) publish(ctx context.Context, eventType, subject string, data any) error {
+	if s.events == nil {
+		return nil
 	}
+	return s.events.Publish(ctx, eventType, subject, data)
 }
 
 // recordError records an error on the span and emits a structured error log.
@@ -63,7 +113,11 @@ func (s *Service) ListTenantsByUserID(ctx context.Context, userID string) ([]*ty
 	ctx, span := s.tracer.Start(ctx, "tenant.Service.ListTenantsByUserID")
 	defer span.End()
 
-	s.logger.Debugw("listing tenants for user", "user_id", userID)
+	s.logger.Debugw("listing tenants for user", "user_id", userID, "source", s.tenantSource)
+
+	if s.tenantSource == TenantSourceOpenFGA {
+		return s.listTenantsByUserIDFromAuthz(ctx, span, userID)
+	}
 
 	tenants, err := s.storage.ListTenantsByUserID(ctx, userID)
 	if err != nil {
@@ -72,13 +126,48 @@ func (s *Service) ListTenantsByUserID(ctx context.Context, userID string) ([]*ty
 	return tenants, err
 }
 
-func (s *Service) ListTenants(ctx context.Context) ([]*types.Tenant, error) {
+// listTenantsByUserIDFromAuthz treats the OpenFGA member relations as the source
+// of truth and hydrates the tenants from storage, reporting tenants that exist
+// in OpenFGA only.
+func (s *Service) listTenantsByUserIDFromAuthz(ctx context.Context, span trace.Span, userID string) ([]*types.Tenant, error) {
+	objects, err := s.authz.ListObjects(ctx, authorization.UserTuple(userID), authorization.MEMBER_RELATION, "tenant")
+	if err != nil {
+		s.recordError(span, "failed to list tenants for user from authorization", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list tenants for user: %w", err)
+	}
+
+	ids := make([]string, 0, len(objects))
+	for _, object := range objects {
+		id, ok := authorization.TenantIDFromTuple(object)
+		if !ok || uuid.Validate(id) != nil {
+			s.logger.Warnw("ignoring malformed tenant object", "user_id", userID, "object", object)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	tenants, err := s.storage.ListTenantsByIDs(ctx, ids)
+	if err != nil {
+		s.recordError(span, "failed to hydrate tenants for user", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list tenants for user: %w", err)
+	}
+
+	if missing := len(ids) - len(tenants); missing > 0 {
+		s.logger.Warnw("tenants granted in authorization are missing from storage", "user_id", userID, "missing", missing)
+		s.incrementCounter("tenant_drift", "")
+	}
+
+	return tenants, nil
+}
+
+// ListTenants returns a page of the tenants matching filter, oldest first.
+func (s *Service) ListTenants(ctx context.Context, filter *types.TenantFilter) ([]*types.Tenant, error) {
 	ctx, span := s.tracer.Start(ctx, "tenant.Service.ListTenants")
 	defer span.End()
 
-	s.logger.Debugw("listing all tenants")
+	s.logger.Debugw("listing tenants", "offset", filter.Offset, "limit", filter.Limit)
 
-	tenants, err := s.storage.ListTenants(ctx)
+	tenants, err := s.storage.ListTenantsPage(ctx, filter)
 	if err != nil {
 		s.recordError(span, "failed to list tenants", err)
 		return nil, err
@@ -87,7 +176,44 @@ func (s *Service) ListTenants(ctx context.Context) ([]*types.Tenant, error) {
 	return tenants, nil
 }
 
-func (s *Service) InviteMember(ctx context.Context, tenantID, email, role string) (string, string, error) {
+// GetUserPermissions reports which tenant relations the user holds.
+func (s *Service) GetUserPermissions(ctx context.Context, tenantID, userID string) (map[string]bool, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.GetUserPermissions")
+	defer span.End()
+
+	permissions, err := s.authz.TenantPermissions(ctx, tenantID, userID)
+	if err != nil {
+		s.recordError(span, "failed to evaluate permissions", err, "tenant_id", tenantID, "user_id", userID)
+		return nil, fmt.Errorf("failed to evaluate permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// ListUserMemberships returns the tenants the user is a member of with their
+// role, from storage, and the tenant relations they hold, from the authorizer.
+func (s *Service) ListUserMemberships(ctx context.Context, userID string) ([]*types.UserMembership, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.ListUserMemberships")
+	defer span.End()
+
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		s.recordError(span, "failed to list memberships", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	for _, m := range memberships {
+		m.Permissions, err = s.authz.TenantPermissions(ctx, m.Tenant.ID, userID)
+		if err != nil {
+			s.recordError(span, "failed to evaluate permissions", err, "tenant_id", m.Tenant.ID, "user_id", userID)
+			return nil, fmt.Errorf("failed to evaluate permissions: %w", err)
+		}
+	}
+
+	return memberships, nil
+}
+
+func (s *Service) InviteMember(ctx context.Context, tenantID, email string, role types.MembershipRole) (string, string, error) {
 	ctx, span := s.tracer.Start(ctx, "tenant.Service.InviteMember")
 	defer span.End()
 
@@ -124,8 +250,19 @@ func (s *Service) InviteMember(ctx context.Context, tenantID, email, role string
 		}
 	}
 
+	before, err := s.checkQuotas(ctx, tenantID, identityID, true)
+	if err != nil {
+		if !errors.Is(err, ErrQuotaExceeded) {
+			s.recordError(span, "failed to check tenant quotas", err, "tenant_id", tenantID)
+			return "", "", fmt.Errorf("failed to check tenant quotas")
+		}
+		return "", "", err
+	}
+
 	// 2. Add Member to Database (idempotent for duplicate key)
+	added := true
 	if _, err := s.storage.AddMember(ctx, tenantID, identityID, role); err != nil {
+		added = false
 		if !errors.Is(err, storage.ErrDuplicateKey) {
 			s.recordError(span, "failed to add member to storage", err,
 				"tenant_id", tenantID,
@@ -138,15 +275,7 @@ func (s *Service) InviteMember(ctx context.Context, tenantID, email, role string
 	}
 
 	// 3. Assign Role in OpenFGA (Authorization)
-	// Map 'role' string to specific authz method
-	if role == "owner" {
-		err = s.authz.AssignTenantOwner(ctx, tenantID, identityID)
-	} else {
-		// Default to member for 'member' and 'admin' roles, as OpenFGA model might not distinguish them yet
-		err = s.authz.AssignTenantMember(ctx, tenantID, identityID)
-	}
-
-	if err != nil {
+	if err := s.assignRole(ctx, tenantID, identityID, role); err != nil {
 		s.recordError(span, "failed to assign role in authz", err,
 			"tenant_id", tenantID,
 			"user_id", identityID,
@@ -155,6 +284,13 @@ func (s *Service) InviteMember(ctx context.Context, tenantID, email, role string
 		return "", "", fmt.Errorf("failed to assign permissions")
 	}
 
+	if added {
+		if err := s.publish(ctx, events.MemberAdded, tenantID, events.MemberData{TenantID: tenantID, UserID: identityID, Role: role.String()}); err != nil {
+			s.recordError(span, "failed to publish member event", err, "tenant_id", tenantID, "user_id", identityID)
+			return "", "", fmt.Errorf("failed to add member")
+		}
+	}
+
 	// 4. Generate Kratos Recovery Link
 	// We use the configured lifetime for the link
 	link, code, err := s.kratos.CreateRecoveryLink(ctx, identityID, s.invitationLifetime)
@@ -166,27 +302,172 @@ func (s *Service) InviteMember(ctx context.Context, tenantID, email, role string
 		return "", "", fmt.Errorf("failed to generate invitation link")
 	}
 
+	expiresAt, err := s.invitationExpiry(time.Now())
+	if err != nil {
+		s.recordError(span, "failed to compute invitation expiry", err, "tenant_id", tenantID)
+		return "", "", fmt.Errorf("failed to record invitation")
+	}
+	if _, err := s.storage.CreateInvite(ctx, &types.Invite{
+		TenantID:    tenantID,
+		UserID:      identityID,
+		Email:       email,
+		Role:        role,
+		AddedMember: added,
+		InvitedBy:   actor,
+		ExpiresAt:   expiresAt,
+	}); err != nil {
+		s.recordError(span, "failed to record invitation", err,
+			"tenant_id", tenantID,
+			"user_id", identityID,
+		)
+		return "", "", fmt.Errorf("failed to record invitation")
+	}
+
+	membersAdded := 0
+	if added {
+		membersAdded = 1
+	}
+	if err := s.warnQuotas(ctx, before, membersAdded, 1); err != nil {
+		s.recordError(span, "failed to warn of tenant quotas", err, "tenant_id", tenantID)
+		return "", "", fmt.Errorf("failed to record invitation")
+	}
+
 	s.logger.Infow("member invited successfully",
 		"tenant_id", tenantID,
 		"user_id", identityID,
 		"email", email,
 		"role", role,
 	)
-	s.logger.Security().AdminAction(actor, "invite_member", "tenant.Service.InviteMember", tenantID+":"+email)
-	s.incrementCounter("invitation_sent", role)
+	s.logger.Security().AdminAction(actor, "invite_member", "tenant.Service.InviteMember", tenantID+":"+email, authentication.PrincipalLabel(ctx))
+	s.incrementCounter("invitation_sent", role.String())
 	return link, code, nil
 }
 
-func (s *Service) CreateTenant(ctx context.Context, name string) (*types.Tenant, error) {
+// CreateTenant creates a tenant homed in region, an empty region lets every
+// region serve it.
+// ListInvites lists the invitations of a tenant, newest first.
+func (s *Service) ListInvites(ctx context.Context, tenantID string) ([]*types.Invite, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.ListInvites")
+	defer span.End()
+
+	invites, err := s.storage.ListInvitesByTenantID(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to list invites", err, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	return invites, nil
+}
+
+// RevokeInvite revokes an invitation not accepted yet, and removes the
+// membership it created. The link itself stays valid in Kratos but no longer
+// grants access to the tenant. Revoking a revoked invitation is a no-op.
+func (s *Service) RevokeInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.RevokeInvite")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	invite, err := s.storage.GetInvite(ctx, tenantID, inviteID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.recordError(span, "failed to get invite", err, "tenant_id", tenantID, "invite_id", inviteID)
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	switch invite.State(time.Now()) {
+	case types.InviteStateRevoked:
+		return invite, nil
+	case types.InviteStateAccepted:
+		return nil, fmt.Errorf("%w: accepted, remove the user from the tenant instead", ErrInviteClosed)
+	}
+
+	if err := s.storage.RevokeInvite(ctx, tenantID, inviteID); err != nil {
+		s.recordError(span, "failed to revoke invite", err, "tenant_id", tenantID, "invite_id", inviteID)
+		return nil, fmt.Errorf("failed to revoke invite: %w", err)
+	}
+
+	if invite.AddedMember {
+		if _, err := s.RemoveTenantUser(ctx, tenantID, invite.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Infow("invite revoked", "tenant_id", tenantID, "invite_id", inviteID, "user_id", invite.UserID, "member_removed", invite.AddedMember)
+	s.logger.Security().AdminAction(actor, "revoke_invite", "tenant.Service.RevokeInvite", tenantID+":"+inviteID, authentication.PrincipalLabel(ctx))
+
+	now := time.Now()
+	invite.RevokedAt = &now
+	return invite, nil
+}
+
+// ResendInvite creates a new link for an invitation not accepted yet, valid
+// for the invitation lifetime, and returns it with its code.
+func (s *Service) ResendInvite(ctx context.Context, tenantID, inviteID string) (*types.Invite, string, string, error) {
+	ctx, span := s.tracer.Start(ctx, "tenant.Service.ResendInvite")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+
+	invite, err := s.storage.GetInvite(ctx, tenantID, inviteID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.recordError(span, "failed to get invite", err, "tenant_id", tenantID, "invite_id", inviteID)
+		}
+		return nil, "", "", fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	if state := invite.State(time.Now()); state == types.InviteStateAccepted || state == types.InviteStateRevoked {
+		return nil, "", "", fmt.Errorf("%w: %s", ErrInviteClosed, state)
+	}
+
+	link, code, err := s.kratos.CreateRecoveryLink(ctx, invite.UserID, s.invitationLifetime)
+	if err != nil {
+		s.recordError(span, "failed to create recovery link", err, "tenant_id", tenantID, "user_id", invite.UserID)
+		return nil, "", "", fmt.Errorf("failed to generate invitation link")
+	}
+
+	now := time.Now()
+	expiresAt, err := s.invitationExpiry(now)
+	if err != nil {
+		s.recordError(span, "failed to compute invitation expiry", err, "tenant_id", tenantID)
+		return nil, "", "", fmt.Errorf("failed to record invitation")
+	}
+	if err := s.storage.RenewInvite(ctx, tenantID, inviteID, expiresAt); err != nil {
+		s.recordError(span, "failed to renew invite", err, "tenant_id", tenantID, "invite_id", inviteID)
+		return nil, "", "", fmt.Errorf("failed to record invitation: %w", err)
+	}
+
+	s.logger.Infow("invite sent again", "tenant_id", tenantID, "invite_id", inviteID, "user_id", invite.UserID)
+	s.logger.Security().AdminAction(actor, "resend_invite", "tenant.Service.ResendInvite", tenantID+":"+inviteID, authentication.PrincipalLabel(ctx))
+	s.incrementCounter("invitation_resent", invite.Role.String())
+
+	invite.SentAt = now
+	invite.ExpiresAt = expiresAt
+	return invite, link, code, nil
+}
+
+// invitationExpiry returns when a link created at now expires, the lifetime
+// is a duration as Kratos takes it.
+func (s *Service) invitationExpiry(now time.Time) (time.Time, error) {
+	lifetime, err := time.ParseDuration(s.invitationLifetime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid invitation lifetime %q: %w", s.invitationLifetime, err)
+	}
+	return now.Add(lifetime), nil
+}
+
+func (s *Service) CreateTenant(ctx context.Context, name, region string) (*types.Tenant, error) {
 	ctx, span := s.tracer.Start(ctx, "admin.CreateTenant")
 	defer span.End()
 
 	actor, _ := authentication.GetUserID(ctx)
-	s.logger.Debugw("creating tenant", "name", name, "actor", actor)
+	s.logger.Debugw("creating tenant", "name", name, "region", region, "actor", actor)
 
 	t := &types.Tenant{
 		Name:    name,
 		Enabled: true, // Admin created tenants are enabled by default
+		Region:  region,
 	}
 
 	created, err := s.storage.CreateTenant(ctx, t)
@@ -195,8 +476,13 @@ func (s *Service) CreateTenant(ctx context.Context, name string) (*types.Tenant,
 		return nil, fmt.Errorf("failed to create tenant: %w", err)
 	}
 
+	if err := s.publish(ctx, events.TenantCreated, created.ID, events.TenantData{TenantID: created.ID, Name: created.Name, Region: created.Region, Enabled: created.Enabled}); err != nil {
+		s.recordError(span, "failed to publish tenant event", err, "tenant_id", created.ID)
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+
 	s.logger.Infow("tenant created", "tenant_id", created.ID, "name", created.Name)
-	s.logger.Security().AdminAction(actor, "create_tenant", "tenant.Service.CreateTenant", created.ID)
+	s.logger.Security().AdminAction(actor, "create_tenant", "tenant.Service.CreateTenant", created.ID, authentication.PrincipalLabel(ctx))
 	return created, nil
 }
 
@@ -218,21 +504,36 @@ func (s *Service) UpdateTenant(ctx context.Context, tenant *types.Tenant, paths
 		return nil, fmt.Errorf("failed to get updated tenant: %w", err)
 	}
 
-	s.logger.Infow("tenant updated", "tenant_id", updated.ID, "name", updated.Name, "enabled", updated.Enabled)
-	s.logger.Security().AdminAction(actor, "update_tenant", "tenant.Service.UpdateTenant", updated.ID)
+	s.logger.Infow("tenant updated", "tenant_id", updated.ID, "name", updated.Name, "enabled", updated.Enabled, "region", updated.Region)
+	s.logger.Security().AdminAction(actor, "update_tenant", "tenant.Service.UpdateTenant", updated.ID, authentication.PrincipalLabel(ctx))
 	return updated, nil
 }
 
-func (s *Service) DeleteTenant(ctx context.Context, id string) error {
+// DeleteTenant deletes the tenant and its tuples, it reports whether the
+// tenant existed. Deleting a missing tenant succeeds and still removes the
+// tuples a previous attempt may have left behind.
+func (s *Service) DeleteTenant(ctx context.Context, id string) (bool, error) {
 	ctx, span := s.tracer.Start(ctx, "admin.DeleteTenant")
 	defer span.End()
 
 	actor, _ := authentication.GetUserID(ctx)
 	s.logger.Debugw("deleting tenant", "tenant_id", id, "actor", actor)
 
-	if err := s.storage.DeleteTenant(ctx, id); err != nil {
+	existed := true
+	err := s.storage.DeleteTenant(ctx, id)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		existed = false
+	case err != nil:
 		s.recordError(span, "failed to delete tenant from storage", err, "tenant_id", id)
-		return fmt.Errorf("failed to delete tenant from storage: %w", err)
+		return false, fmt.Errorf("failed to delete tenant from storage: %w", err)
+	}
+
+	if existed {
+		if err := s.publish(ctx, events.TenantDeleted, id, events.TenantData{TenantID: id}); err != nil {
+			s.recordError(span, "failed to publish tenant event", err, "tenant_id", id)
+			return false, fmt.Errorf("failed to delete tenant: %w", err)
+		}
 	}
 
 	if err := s.authz.DeleteTenant(ctx, id); err != nil {
@@ -240,12 +541,17 @@ func (s *Service) DeleteTenant(ctx context.Context, id string) error {
 		s.logger.Errorw("failed to delete tenant from authz", "tenant_id", id, "error", err)
 	}
 
+	if !existed {
+		s.logger.Debugw("tenant already deleted", "tenant_id", id)
+		return false, nil
+	}
+
 	s.logger.Infow("tenant deleted", "tenant_id", id)
-	s.logger.Security().AdminAction(actor, "delete_tenant", "tenant.Service.DeleteTenant", id)
-	return nil
+	s.logger.Security().AdminAction(actor, "delete_tenant", "tenant.Service.DeleteTenant", id, authentication.PrincipalLabel(ctx))
+	return true, nil
 }
 
-func (s *Service) ProvisionUser(ctx context.Context, tenantID, email, role string) error {
+func (s *Service) ProvisionUser(ctx context.Context, tenantID, email string, role types.MembershipRole) error {
 	ctx, span := s.tracer.Start(ctx, "admin.ProvisionUser")
 	defer span.End()
 
@@ -281,6 +587,14 @@ func (s *Service) ProvisionUser(ctx context.Context, tenantID, email, role strin
 		}
 	}
 
+	before, err := s.checkQuotas(ctx, tenantID, identityID, false)
+	if err != nil {
+		if !errors.Is(err, ErrQuotaExceeded) {
+			s.recordError(span, "failed to check tenant quotas", err, "tenant_id", tenantID)
+		}
+		return err
+	}
+
 	// 2. Add to Storage
 	if _, err := s.storage.AddMember(ctx, tenantID, identityID, role); err != nil {
 		s.recordError(span, "failed to add provisioned member to storage", err,
@@ -292,21 +606,14 @@ func (s *Service) ProvisionUser(ctx context.Context, tenantID, email, role strin
 	}
 
 	// 3. Add to AuthZ
-	var authzErr error
-	switch role {
-	case "owner":
-		authzErr = s.authz.AssignTenantOwner(ctx, tenantID, identityID)
-	case "member", "admin":
-		// Proto has owner, admin, member.
-		authzErr = s.authz.AssignTenantMember(ctx, tenantID, identityID)
-	default:
+	if !role.Valid() {
 		err := fmt.Errorf("unknown role: %s", role)
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
-	if authzErr != nil {
+	if authzErr := s.assignRole(ctx, tenantID, identityID, role); authzErr != nil {
 		s.recordError(span, "failed to assign role in authz", authzErr,
 			"tenant_id", tenantID,
 			"user_id", identityID,
@@ -315,14 +622,24 @@ func (s *Service) ProvisionUser(ctx context.Context, tenantID, email, role strin
 		return fmt.Errorf("failed to assign role in authz: %w", authzErr)
 	}
 
+	if err := s.publish(ctx, events.MemberAdded, tenantID, events.MemberData{TenantID: tenantID, UserID: identityID, Role: role.String()}); err != nil {
+		s.recordError(span, "failed to publish member event", err, "tenant_id", tenantID, "user_id", identityID)
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	if err := s.warnQuotas(ctx, before, 1, 0); err != nil {
+		s.recordError(span, "failed to warn of tenant quotas", err, "tenant_id", tenantID)
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
 	s.logger.Infow("user provisioned",
 		"tenant_id", tenantID,
 		"user_id", identityID,
 		"email", email,
 		"role", role,
 	)
-	s.logger.Security().AdminAction(actor, "provision_user", "tenant.Service.ProvisionUser", tenantID+":"+email)
-	s.incrementCounter("user_provisioned", role)
+	s.logger.Security().AdminAction(actor, "provision_user", "tenant.Service.ProvisionUser", tenantID+":"+email, authentication.PrincipalLabel(ctx))
+	s.incrementCounter("user_provisioned", role.String())
 	return nil
 }
 
@@ -338,54 +655,167 @@ func (s *Service) ListUserTenants(ctx context.Context, userID string) ([]*types.
 		return nil, fmt.Errorf("failed to list tenants for user: %w", err)
 	}
 
-	return tenants, nil
+	actor, _ := authentication.GetUserID(ctx)
+	if !s.authorizationEnabled {
+		// without OpenFGA every caller reaching the admin API is trusted
+		s.logger.Security().AdminAction(actor, "list_user_tenants_bypass", "tenant.Service.ListUserTenants", userID, authentication.PrincipalLabel(ctx))
+		return tenants, nil
+	}
+
+	if actor == "" {
+		err := fmt.Errorf("no authenticated viewer to filter tenants for")
+		s.recordError(span, "failed to list tenants for user", err, "user_id", userID)
+		return nil, err
+	}
+
+	if p, _ := authentication.GetPrincipal(ctx); p.PlatformRole != "" {
+		// every platform role can view every tenant
+		s.logger.Security().AdminAction(actor, "list_user_tenants", "tenant.Service.ListUserTenants", userID, authentication.PrincipalLabel(ctx))
+		return tenants, nil
+	}
+
+	// only return the tenants the viewer can see, directly or as a privileged admin
+	ids := make([]string, 0, len(tenants))
+	for _, t := range tenants {
+		ids = append(ids, t.ID)
+	}
+	// streamed, platform admins can see tens of thousands of tenants
+	visibleIDs, err := s.authz.FilterObjectsStreamed(ctx, authorization.UserTuple(actor), authorization.CAN_VIEW_PERMISSION, "tenant", ids)
+	if err != nil {
+		s.recordError(span, "failed to list tenants visible to viewer", err, "user_id", userID, "actor", actor)
+		return nil, fmt.Errorf("failed to list tenants visible to viewer: %w", err)
+	}
+
+	visible := make(map[string]bool, len(visibleIDs))
+	for _, id := range visibleIDs {
+		visible[id] = true
+	}
+	filtered := make([]*types.Tenant, 0, len(tenants))
+	for _, t := range tenants {
+		if visible[t.ID] {
+			filtered = append(filtered, t)
+		}
+	}
+	if hidden := len(tenants) - len(filtered); hidden > 0 {
+		s.logger.Debugw("hiding tenants not visible to viewer", "user_id", userID, "actor", actor, "hidden", hidden)
+	}
+
+	s.logger.Security().AdminAction(actor, "list_user_tenants", "tenant.Service.ListUserTenants", userID, authentication.PrincipalLabel(ctx))
+	return filtered, nil
 }
 
-func (s *Service) ListTenantUsers(ctx context.Context, tenantID string) ([]*types.TenantUser, error) {
+// ListTenantUsers returns limit members of the tenant in the order they
+// joined, skipping the first offset ones. A zero limit returns them all.
+func (s *Service) ListTenantUsers(ctx context.Context, tenantID string, skipIdentityLookup bool, offset, limit uint64) ([]*types.TenantUser, error) {
 	ctx, span := s.tracer.Start(ctx, "admin.ListTenantUsers")
 	defer span.End()
 
-	s.logger.Debugw("listing members for tenant", "tenant_id", tenantID)
+	s.logger.Debugw("listing members for tenant", "tenant_id", tenantID, "offset", offset, "limit", limit)
 
-	members, err := s.storage.ListMembersByTenantID(ctx, tenantID)
+	members, err := s.storage.ListMembersPage(ctx, tenantID, offset, limit)
 	if err != nil {
 		s.recordError(span, "failed to list members", err, "tenant_id", tenantID)
 		return nil, fmt.Errorf("failed to list members: %w", err)
 	}
 
-	var users []*types.TenantUser
-	for _, m := range members {
-		email := ""
-		// Fetch identity details from Kratos to get email
-		identity, err := s.kratos.GetIdentity(ctx, m.KratosIdentityID)
-		if err != nil {
-			// Log error but continue, user might have been deleted from Kratos but not from our DB
-			s.logger.Warnw("failed to get identity for user; continuing with unknown email",
-				"tenant_id", tenantID,
-				"user_id", m.KratosIdentityID,
-				"error", err,
-			)
-			email = "unknown"
-		} else {
-			// Extract email from traits
-			if traits, ok := identity.Traits.(map[string]interface{}); ok {
-				if e, ok := traits["email"].(string); ok {
-					email = e
-				}
-			}
+	users := make([]*types.TenantUser, len(members))
+	for i, m := range members {
+		users[i] = &types.TenantUser{
+			UserID:   m.KratosIdentityID,
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt,
 		}
+	}
 
-		users = append(users, &types.TenantUser{
-			UserID: m.KratosIdentityID,
-			Email:  email,
-			Role:   m.Role,
-		})
+	if skipIdentityLookup {
+		return users, nil
 	}
 
+	// each lookup fills its own slot, so users keep the members order
+	sem := make(chan struct{}, identityLookupConcurrency)
+	var wg sync.WaitGroup
+	for _, u := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			s.resolveIdentity(ctx, tenantID, u)
+		}()
+	}
+	wg.Wait()
+
 	return users, nil
 }
 
-func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID, role string) (*types.TenantUser, error) {
+// GetTenantUser returns the membership of the user in the tenant, with their
+// email, the relations they hold on the tenant and their permissions, or
+// storage.ErrNotFound when the user is not a member.
+func (s *Service) GetTenantUser(ctx context.Context, tenantID, userID string) (*types.TenantUser, error) {
+	ctx, span := s.tracer.Start(ctx, "admin.GetTenantUser")
+	defer span.End()
+
+	member, err := s.storage.GetMember(ctx, tenantID, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		s.recordError(span, "failed to get member", err, "tenant_id", tenantID, "user_id", userID)
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	user := &types.TenantUser{
+		UserID:   member.KratosIdentityID,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt,
+	}
+	s.resolveIdentity(ctx, tenantID, user)
+
+	tuples, err := s.authz.ListTenantTuples(ctx, tenantID)
+	if err != nil {
+		s.recordError(span, "failed to list tenant relations", err, "tenant_id", tenantID, "user_id", userID)
+		return nil, fmt.Errorf("failed to list tenant relations: %w", err)
+	}
+	for _, t := range tuples {
+		if t.User == authorization.UserTuple(userID) {
+			user.Relations = append(user.Relations, t.Relation)
+		}
+	}
+	slices.Sort(user.Relations)
+
+	user.Permissions, err = s.authz.TenantPermissions(ctx, tenantID, userID)
+	if err != nil {
+		s.recordError(span, "failed to evaluate permissions", err, "tenant_id", tenantID, "user_id", userID)
+		return nil, fmt.Errorf("failed to evaluate permissions: %w", err)
+	}
+
+	return user, nil
+}
+
+// resolveIdentity fills the email of the user from Kratos.
+func (s *Service) resolveIdentity(ctx context.Context, tenantID string, u *types.TenantUser) {
+	identity, err := s.kratos.GetIdentity(ctx, u.UserID)
+	if err != nil {
+		// the user might have been deleted from Kratos but not from our DB
+		s.logger.Warnw("failed to get identity for user; continuing with unknown identity",
+			"tenant_id", tenantID,
+			"user_id", u.UserID,
+			"error", err,
+		)
+		u.IdentityStatus = types.IdentityStatusUnknown
+		return
+	}
+
+	if traits, ok := identity.Traits.(map[string]interface{}); ok {
+		if e, ok := traits["email"].(string); ok {
+			u.Email = e
+		}
+	}
+	u.IdentityStatus = types.IdentityStatusResolved
+}
+
+func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID string, role types.MembershipRole) (*types.TenantUser, error) {
 	ctx, span := s.tracer.Start(ctx, "admin.UpdateTenantUser")
 	defer span.End()
 
@@ -435,52 +865,32 @@ func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID, role s
 	// If promoting member -> owner: Add owner, remove member (optional but clean).
 
 	// Add new role
-	switch role {
-	case "owner":
-		if err := s.authz.AssignTenantOwner(ctx, tenantID, userID); err != nil {
-			s.recordError(span, "failed to assign owner role in authz", err,
-				"tenant_id", tenantID,
-				"user_id", userID,
-			)
-			return nil, fmt.Errorf("failed to assign owner role: %w", err)
-		}
-	case "member", "admin":
-		if err := s.authz.AssignTenantMember(ctx, tenantID, userID); err != nil {
-			s.recordError(span, "failed to assign member role in authz", err,
-				"tenant_id", tenantID,
-				"user_id", userID,
-			)
-			return nil, fmt.Errorf("failed to assign member role: %w", err)
-		}
-	default:
+	if !role.Valid() {
 		err := fmt.Errorf("invalid role: %s", role)
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
+	if err := s.assignRole(ctx, tenantID, userID, role); err != nil {
+		s.recordError(span, "failed to assign role in authz", err,
+			"tenant_id", tenantID,
+			"user_id", userID,
+			"role", role,
+		)
+		return nil, fmt.Errorf("failed to assign %s role: %w", role, err)
+	}
 
-	// Remove old role
-	switch currentMember.Role {
-	case "owner":
-		if err := s.authz.RemoveTenantOwner(ctx, tenantID, userID); err != nil {
-			s.logger.Errorw("failed to remove old owner relation from authz",
+	// Remove old role, unless both roles map onto the same relation, e.g. member and admin
+	if authorization.RelationForRole(currentMember.Role) != authorization.RelationForRole(role) {
+		if err := s.removeRole(ctx, tenantID, userID, currentMember.Role); err != nil {
+			s.logger.Errorw("failed to remove old relation from authz",
 				"tenant_id", tenantID,
 				"user_id", userID,
+				"role", currentMember.Role,
 				"error", err,
 			)
 			// Continue, as new role is assigned.
 		}
-	case "member", "admin":
-		if role == "owner" {
-			// If promoting to owner, we can remove the member relation to be clean
-			if err := s.authz.RemoveTenantMember(ctx, tenantID, userID); err != nil {
-				s.logger.Errorw("failed to remove old member relation from authz",
-					"tenant_id", tenantID,
-					"user_id", userID,
-					"error", err,
-				)
-			}
-		}
 	}
 
 	// 3. Storage Update
@@ -493,6 +903,12 @@ func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID, role s
 		return nil, err
 	}
 
+	memberData := events.MemberData{TenantID: tenantID, UserID: userID, Role: role.String(), PreviousRole: currentMember.Role.String()}
+	if err := s.publish(ctx, events.MemberRoleChanged, tenantID, memberData); err != nil {
+		s.recordError(span, "failed to publish member event", err, "tenant_id", tenantID, "user_id", userID)
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+
 	// 4. Return updated user
 	identity, err := s.kratos.GetIdentity(ctx, userID)
 	email := ""
@@ -516,7 +932,7 @@ func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID, role s
 		"role", role,
 		"previous_role", currentMember.Role,
 	)
-	s.logger.Security().AdminAction(actor, "update_tenant_user", "tenant.Service.UpdateTenantUser", tenantID+":"+userID)
+	s.logger.Security().AdminAction(actor, "update_tenant_user", "tenant.Service.UpdateTenantUser", tenantID+":"+userID, authentication.PrincipalLabel(ctx))
 
 	return &types.TenantUser{
 		UserID: userID,
@@ -525,6 +941,192 @@ func (s *Service) UpdateTenantUser(ctx context.Context, tenantID, userID, role s
 	}, nil
 }
 
+// RemoveTenantUser removes the user from the tenant along with its relations
+// and custom role assignments, it reports whether the user was a member.
+// Removing a non member succeeds and still removes any relation left behind.
+func (s *Service) RemoveTenantUser(ctx context.Context, tenantID, userID string) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "admin.RemoveTenantUser")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	s.logger.Debugw("removing tenant user", "tenant_id", tenantID, "user_id", userID, "actor", actor)
+
+	existed := true
+	err := s.storage.DeleteMember(ctx, tenantID, userID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		existed = false
+	case err != nil:
+		s.recordError(span, "failed to delete member from storage", err, "tenant_id", tenantID, "user_id", userID)
+		return false, fmt.Errorf("failed to delete member from storage: %w", err)
+	}
+
+	// the membership is gone, a retry removes whatever relation is left
+	for _, relation := range authorization.MembershipRelations {
+		if err := s.removeRelation(ctx, tenantID, userID, relation); err != nil {
+			s.recordError(span, "failed to remove relation from authz", err, "tenant_id", tenantID, "user_id", userID, "relation", relation)
+			return false, fmt.Errorf("failed to remove %s relation: %w", relation, err)
+		}
+	}
+	if err := s.unassignTenantRoles(ctx, tenantID, userID); err != nil {
+		s.recordError(span, "failed to remove role assignments from authz", err, "tenant_id", tenantID, "user_id", userID)
+		return false, fmt.Errorf("failed to remove role assignments: %w", err)
+	}
+
+	if !existed {
+		s.logger.Debugw("user already removed from tenant", "tenant_id", tenantID, "user_id", userID)
+		return false, nil
+	}
+
+	s.logger.Infow("tenant user removed", "tenant_id", tenantID, "user_id", userID)
+	s.logger.Security().AdminAction(actor, "remove_tenant_user", "tenant.Service.RemoveTenantUser", tenantID+":"+userID, authentication.PrincipalLabel(ctx))
+	return true, nil
+}
+
+// assignRole writes the tenant relation the membership role maps to.
+func (s *Service) assignRole(ctx context.Context, tenantID, userID string, role types.MembershipRole) error {
+	switch authorization.RelationForRole(role) {
+	case authorization.OWNER_RELATION:
+		return s.authz.AssignTenantOwner(ctx, tenantID, userID)
+	case authorization.ADMIN_RELATION:
+		return s.authz.AssignTenantAdmin(ctx, tenantID, userID)
+	default:
+		return s.authz.AssignTenantMember(ctx, tenantID, userID)
+	}
+}
+
+// removeRole deletes the tenant relation the membership role maps to.
+func (s *Service) removeRole(ctx context.Context, tenantID, userID string, role types.MembershipRole) error {
+	return s.removeRelation(ctx, tenantID, userID, authorization.RelationForRole(role))
+}
+
+// removeRelation deletes a direct membership relation of the user on the tenant.
+func (s *Service) removeRelation(ctx context.Context, tenantID, userID, relation string) error {
+	switch relation {
+	case authorization.OWNER_RELATION:
+		return s.authz.RemoveTenantOwner(ctx, tenantID, userID)
+	case authorization.ADMIN_RELATION:
+		return s.authz.RemoveTenantAdmin(ctx, tenantID, userID)
+	default:
+		return s.authz.RemoveTenantMember(ctx, tenantID, userID)
+	}
+}
+
+// unassignTenantRoles unassigns the user from the custom roles granting
+// permissions on the tenant, the storage drops the assignments with the membership.
+func (s *Service) unassignTenantRoles(ctx context.Context, tenantID, userID string) error {
+	tuples, err := s.authz.ListTenantTuples(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	var roles []string
+	for _, t := range tuples {
+		object, relation, ok := strings.Cut(t.User, "#")
+		if !ok || relation != authorization.ASSIGNEE_RELATION {
+			continue
+		}
+		roleID := strings.TrimPrefix(object, authorization.RoleTuple(""))
+		if !slices.Contains(roles, roleID) {
+			roles = append(roles, roleID)
+		}
+	}
+
+	for _, roleID := range roles {
+		if err := s.authz.UnassignRole(ctx, roleID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddPlatformAdmin makes the user an admin of the support group, granting
+// access to every tenant linked to it.
+func (s *Service) AddPlatformAdmin(ctx context.Context, groupID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "admin.AddPlatformAdmin")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	s.logger.Debugw("adding platform admin", "group_id", groupID, "user_id", userID, "actor", actor)
+
+	if err := s.authz.AssignPrivilegedAdmin(ctx, groupID, userID); err != nil {
+		s.recordError(span, "failed to assign platform admin", err, "group_id", groupID, "user_id", userID)
+		return fmt.Errorf("failed to assign platform admin: %w", err)
+	}
+
+	s.logger.Infow("platform admin added", "group_id", groupID, "user_id", userID)
+	s.logger.Security().AdminAction(actor, "add_platform_admin", "tenant.Service.AddPlatformAdmin", groupID+":"+userID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+func (s *Service) RemovePlatformAdmin(ctx context.Context, groupID, userID string) error {
+	ctx, span := s.tracer.Start(ctx, "admin.RemovePlatformAdmin")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	s.logger.Debugw("removing platform admin", "group_id", groupID, "user_id", userID, "actor", actor)
+
+	if err := s.authz.RemovePrivilegedAdmin(ctx, groupID, userID); err != nil {
+		s.recordError(span, "failed to remove platform admin", err, "group_id", groupID, "user_id", userID)
+		return fmt.Errorf("failed to remove platform admin: %w", err)
+	}
+
+	s.logger.Infow("platform admin removed", "group_id", groupID, "user_id", userID)
+	s.logger.Security().AdminAction(actor, "remove_platform_admin", "tenant.Service.RemovePlatformAdmin", groupID+":"+userID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+func (s *Service) ListPlatformAdmins(ctx context.Context, groupID string) ([]string, error) {
+	ctx, span := s.tracer.Start(ctx, "admin.ListPlatformAdmins")
+	defer span.End()
+
+	admins, err := s.authz.ListPrivilegedAdmins(ctx, groupID)
+	if err != nil {
+		s.recordError(span, "failed to list platform admins", err, "group_id", groupID)
+		return nil, fmt.Errorf("failed to list platform admins: %w", err)
+	}
+
+	return admins, nil
+}
+
+// LinkTenantToSupportGroup lets the admins of the support group access the tenant.
+func (s *Service) LinkTenantToSupportGroup(ctx context.Context, tenantID, groupID string) error {
+	ctx, span := s.tracer.Start(ctx, "admin.LinkTenantToSupportGroup")
+	defer span.End()
+
+	actor, _ := authentication.GetUserID(ctx)
+	s.logger.Debugw("linking tenant to support group", "tenant_id", tenantID, "group_id", groupID, "actor", actor)
+
+	// avoid leaving a tuple behind for a tenant that does not exist
+	if _, err := s.storage.GetTenantByID(ctx, tenantID); err != nil {
+		s.recordError(span, "failed to get tenant", err, "tenant_id", tenantID)
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	if err := s.authz.LinkTenantToPrivileged(ctx, tenantID, groupID); err != nil {
+		s.recordError(span, "failed to link tenant to support group", err, "tenant_id", tenantID, "group_id", groupID)
+		return fmt.Errorf("failed to link tenant to support group: %w", err)
+	}
+
+	s.logger.Infow("tenant linked to support group", "tenant_id", tenantID, "group_id", groupID)
+	s.logger.Security().AdminAction(actor, "link_tenant_to_support_group", "tenant.Service.LinkTenantToSupportGroup", tenantID+":"+groupID, authentication.PrincipalLabel(ctx))
+	return nil
+}
+
+// ListAuthzAudit returns the recorded tuple changes matching the filter, newest first.
+func (s *Service) ListAuthzAudit(ctx context.Context, filter *types.AuthzAuditFilter) ([]*types.AuthzAuditEntry, error) {
+	ctx, span := s.tracer.Start(ctx, "admin.ListAuthzAudit")
+	defer span.End()
+
+	entries, err := s.storage.ListAuthzAuditEntries(ctx, filter)
+	if err != nil {
+		s.recordError(span, "failed to list authz audit entries", err)
+		return nil, fmt.Errorf("failed to list authz audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 func (s *Service) incrementCounter(operation, role string) {
 	if err := s.monitor.IncrementCounter(map[string]string{"operation": operation, "role": role}); err != nil {
 		s.logger.Warnf("failed to increment counter %s: %v", operation, err)
@@ -535,6 +1137,15 @@ func encodePageToken(offset uint64) string {
 	return base64.URLEncoding.EncodeToString([]byte(strconv.FormatUint(offset, 10)))
 }
 
+// nextPageToken resumes an offset listing after a page of count items, a
+// full page may be followed by more items.
+func nextPageToken(offset, limit uint64, count int) string {
+	if limit == 0 || uint64(count) < limit {
+		return ""
+	}
+	return encodePageToken(offset + limit)
+}
+
 func decodePageToken(token string) (uint64, error) {
 	if token == "" {
 		return 0, nil
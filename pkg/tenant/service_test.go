@@ -6,10 +6,15 @@ package tenant
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/canonical/tenant-service/internal/openfga"
 	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/events"
 	ory "github.com/ory/client-go"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
@@ -29,7 +34,7 @@ func setupLoggerMock(ctrl *gomock.Controller, mockLogger *MockLoggerInterface) *
 	mockLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Security().Return(mockSecurityLogger).AnyTimes()
-	mockSecurityLogger.EXPECT().AdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurityLogger.EXPECT().AdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	return mockSecurityLogger
 }
 
@@ -86,7 +91,7 @@ func TestService_ListTenantsByUserID(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.ListTenantsByUserID").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -108,12 +113,105 @@ func TestService_ListTenantsByUserID(t *testing.T) {
 	}
 }
 
+func TestService_ListTenantsByUserIDFromAuthz(t *testing.T) {
+	userID := "user-123"
+	id1 := "0195c1a2-1111-7000-8000-000000000001"
+	id2 := "0195c1a2-2222-7000-8000-000000000002"
+	hydrated := []*types.Tenant{
+		{ID: id1, Name: "Tenant 1"},
+		{ID: id2, Name: "Tenant 2"},
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(*MockStorageInterface, *MockAuthzInterface, *MockMonitorInterface)
+		expectedCount int
+		expectErr     bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockMonitorInterface) {
+				mockAuthz.EXPECT().ListObjects(gomock.Any(), "user:"+userID, "member", "tenant").Return([]string{"tenant:" + id1, "tenant:" + id2}, nil)
+				mockStorage.EXPECT().ListTenantsByIDs(gomock.Any(), []string{id1, id2}).Return(hydrated, nil)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "malformed objects are skipped",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockMonitorInterface) {
+				mockAuthz.EXPECT().ListObjects(gomock.Any(), "user:"+userID, "member", "tenant").Return([]string{"tenant:" + id1, "tenant:not-a-uuid"}, nil)
+				mockStorage.EXPECT().ListTenantsByIDs(gomock.Any(), []string{id1}).Return(hydrated[:1], nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "drift between tuples and storage",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockMonitor *MockMonitorInterface) {
+				mockAuthz.EXPECT().ListObjects(gomock.Any(), "user:"+userID, "member", "tenant").Return([]string{"tenant:" + id1, "tenant:" + id2}, nil)
+				mockStorage.EXPECT().ListTenantsByIDs(gomock.Any(), []string{id1, id2}).Return(hydrated[:1], nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "tenant_drift", "role": ""}).Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(_ *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockMonitorInterface) {
+				mockAuthz.EXPECT().ListObjects(gomock.Any(), "user:"+userID, "member", "tenant").Return(nil, errors.New("fga down"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "storage error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockMonitorInterface) {
+				mockAuthz.EXPECT().ListObjects(gomock.Any(), "user:"+userID, "member", "tenant").Return([]string{"tenant:" + id1}, nil)
+				mockStorage.EXPECT().ListTenantsByIDs(gomock.Any(), []string{id1}).Return(nil, errors.New("db error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceOpenFGA, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.ListTenantsByUserID").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz, mockMonitor)
+
+			tenants, err := s.ListTenantsByUserID(context.Background(), userID)
+
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tenants) != tc.expectedCount {
+				t.Errorf("expected %d tenants, got %d", tc.expectedCount, len(tenants))
+			}
+		})
+	}
+}
 func TestService_ListTenants(t *testing.T) {
 	expectedTenants := []*types.Tenant{
 		{ID: "tenant-1", Name: "Tenant 1"},
 		{ID: "tenant-2", Name: "Tenant 2"},
 	}
 	dbErr := errors.New("db error")
+	filter := &types.TenantFilter{NameContains: "Tenant", Limit: 2}
 
 	testCases := []struct {
 		name            string
@@ -124,7 +222,7 @@ func TestService_ListTenants(t *testing.T) {
 		{
 			name: "success",
 			setupMocks: func(mockStorage *MockStorageInterface) {
-				mockStorage.EXPECT().ListTenants(gomock.Any()).Return(expectedTenants, nil)
+				mockStorage.EXPECT().ListTenantsPage(gomock.Any(), filter).Return(expectedTenants, nil)
 			},
 			expectedTenants: expectedTenants,
 			expectedErr:     nil,
@@ -132,7 +230,7 @@ func TestService_ListTenants(t *testing.T) {
 		{
 			name: "storage error",
 			setupMocks: func(mockStorage *MockStorageInterface) {
-				mockStorage.EXPECT().ListTenants(gomock.Any()).Return(nil, dbErr)
+				mockStorage.EXPECT().ListTenantsPage(gomock.Any(), filter).Return(nil, dbErr)
 			},
 			expectedTenants: nil,
 			expectedErr:     dbErr,
@@ -152,12 +250,12 @@ func TestService_ListTenants(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.ListTenants").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
 
-			tenants, err := s.ListTenants(context.Background())
+			tenants, err := s.ListTenants(context.Background(), filter)
 
 			if tc.expectedErr != nil {
 				if !errors.Is(err, tc.expectedErr) {
@@ -174,6 +272,84 @@ func TestService_ListTenants(t *testing.T) {
 	}
 }
 
+func TestService_ListUserMemberships(t *testing.T) {
+	userID := "user-1"
+	dbErr := errors.New("db error")
+	fgaErr := errors.New("fga error")
+
+	memberships := func() []*types.UserMembership {
+		return []*types.UserMembership{
+			{Tenant: &types.Tenant{ID: "tenant-1"}, Role: types.RoleOwner},
+			{Tenant: &types.Tenant{ID: "tenant-2"}, Role: types.RoleMember},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships(), nil)
+				mockAuthz.EXPECT().TenantPermissions(gomock.Any(), "tenant-1", userID).Return(map[string]bool{"owner": true}, nil)
+				mockAuthz.EXPECT().TenantPermissions(gomock.Any(), "tenant-2", userID).Return(map[string]bool{"member": true}, nil)
+			},
+		},
+		{
+			name: "storage error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(nil, dbErr)
+			},
+			expectedErr: dbErr,
+		},
+		{
+			name: "authorization error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships(), nil)
+				mockAuthz.EXPECT().TenantPermissions(gomock.Any(), "tenant-1", userID).Return(nil, fgaErr)
+			},
+			expectedErr: fgaErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.ListUserMemberships").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			result, err := s.ListUserMemberships(context.Background(), userID)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != 2 || !result[0].Permissions["owner"] || !result[1].Permissions["member"] {
+				t.Errorf("expected the permissions of each membership, got %v", result)
+			}
+		})
+	}
+}
+
 func TestService_InviteMember(t *testing.T) {
 	tenantID := "tenant-123"
 	email := "user@example.com"
@@ -183,7 +359,7 @@ func TestService_InviteMember(t *testing.T) {
 
 	testCases := []struct {
 		name         string
-		role         string
+		role         types.MembershipRole
 		setupMocks   func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface, *MockLoggerInterface, *MockMonitorInterface)
 		expectedLink string
 		expectedCode string
@@ -195,9 +371,17 @@ func TestService_InviteMember(t *testing.T) {
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return("", nil)
 				mockKratos.EXPECT().CreateIdentity(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
 				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), identityID, "1h").Return(recoveryLink, recoveryCode, nil)
+				mockStorage.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, i *types.Invite) (*types.Invite, error) {
+						if i.TenantID != tenantID || i.UserID != identityID || i.AddedMember != true {
+							return nil, errors.New("wrong invite")
+						}
+						return i, nil
+					},
+				)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "invitation_sent", "role": "member"}).Return(nil)
 			},
 			expectedLink: recoveryLink,
@@ -209,9 +393,17 @@ func TestService_InviteMember(t *testing.T) {
 			role: "owner",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "owner").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleOwner).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenantID, identityID).Return(nil)
 				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), identityID, "1h").Return(recoveryLink, recoveryCode, nil)
+				mockStorage.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, i *types.Invite) (*types.Invite, error) {
+						if i.TenantID != tenantID || i.UserID != identityID || i.AddedMember != true {
+							return nil, errors.New("wrong invite")
+						}
+						return i, nil
+					},
+				)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "invitation_sent", "role": "owner"}).Return(nil)
 			},
 			expectedLink: recoveryLink,
@@ -223,9 +415,17 @@ func TestService_InviteMember(t *testing.T) {
 			role: "member",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("", storage.ErrDuplicateKey)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("", storage.ErrDuplicateKey)
 				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
 				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), identityID, "1h").Return(recoveryLink, recoveryCode, nil)
+				mockStorage.EXPECT().CreateInvite(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, i *types.Invite) (*types.Invite, error) {
+						if i.TenantID != tenantID || i.UserID != identityID || i.AddedMember != false {
+							return nil, errors.New("wrong invite")
+						}
+						return i, nil
+					},
+				)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "invitation_sent", "role": "member"}).Return(nil)
 			},
 			expectedLink: recoveryLink,
@@ -254,7 +454,7 @@ func TestService_InviteMember(t *testing.T) {
 			role: "member",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("", errors.New("storage error"))
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("", errors.New("storage error"))
 			},
 			expectedErr: true,
 		},
@@ -263,7 +463,7 @@ func TestService_InviteMember(t *testing.T) {
 			role: "member",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(errors.New("authz error"))
 			},
 			expectedErr: true,
@@ -273,7 +473,7 @@ func TestService_InviteMember(t *testing.T) {
 			role: "member",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
 				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), identityID, "1h").Return("", "", errors.New("kratos error"))
 			},
@@ -294,7 +494,7 @@ func TestService_InviteMember(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.InviteMember").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockAuthz, mockKratos, mockLogger, mockMonitor)
@@ -320,6 +520,175 @@ func TestService_InviteMember(t *testing.T) {
 	}
 }
 
+func TestService_RevokeInvite(t *testing.T) {
+	tenantID := "tenant-123"
+	inviteID := "invite-456"
+	userID := "user-789"
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name        string
+		invite      *types.Invite
+		getErr      error
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockTracingInterface)
+		expectedErr error
+	}{
+		{
+			name:   "pending invite of an existing member",
+			invite: &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: future},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockTracer *MockTracingInterface) {
+				mockStorage.EXPECT().RevokeInvite(gomock.Any(), tenantID, inviteID).Return(nil)
+			},
+		},
+		{
+			name:   "expired invite removes the member it added",
+			invite: &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, AddedMember: true, ExpiresAt: past},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockTracer *MockTracingInterface) {
+				mockStorage.EXPECT().RevokeInvite(gomock.Any(), tenantID, inviteID).Return(nil)
+				mockTracer.EXPECT().Start(gomock.Any(), "admin.RemoveTenantUser").Return(context.Background(), trace.SpanFromContext(context.Background()))
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), tenantID).Return(nil, nil)
+			},
+		},
+		{
+			name:   "revoked invite is left as is",
+			invite: &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: future, RevokedAt: &past},
+		},
+		{
+			name:        "accepted invite",
+			invite:      &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: future, AcceptedAt: &past},
+			expectedErr: ErrInviteClosed,
+		},
+		{
+			name:        "not found",
+			getErr:      storage.ErrNotFound,
+			expectedErr: storage.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			s := NewService(mockStorage, mockAuthz, NewMockKratosClientInterface(ctrl), "1h", true, TenantSourceDatabase, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.RevokeInvite").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockStorage.EXPECT().GetInvite(gomock.Any(), tenantID, inviteID).Return(tc.invite, tc.getErr)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockStorage, mockAuthz, mockTracer)
+			}
+
+			invite, err := s.RevokeInvite(context.Background(), tenantID, inviteID)
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if state := invite.State(time.Now()); state != types.InviteStateRevoked {
+				t.Errorf("expected state %s, got %s", types.InviteStateRevoked, state)
+			}
+		})
+	}
+}
+
+func TestService_ResendInvite(t *testing.T) {
+	tenantID := "tenant-123"
+	inviteID := "invite-456"
+	userID := "user-789"
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name        string
+		invite      *types.Invite
+		setupMocks  func(*MockStorageInterface, *MockKratosClientInterface, *MockMonitorInterface)
+		expectedErr bool
+	}{
+		{
+			name:   "expired invite",
+			invite: &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, Role: types.RoleMember, ExpiresAt: past},
+			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
+				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), userID, "1h").Return("https://example.com/recovery", "123456", nil)
+				mockStorage.EXPECT().RenewInvite(gomock.Any(), tenantID, inviteID, gomock.Any()).Return(nil)
+				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "invitation_resent", "role": "member"}).Return(nil)
+			},
+		},
+		{
+			name:        "revoked invite",
+			invite:      &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: past, RevokedAt: &past},
+			expectedErr: true,
+		},
+		{
+			name:        "accepted invite",
+			invite:      &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: past, AcceptedAt: &past},
+			expectedErr: true,
+		},
+		{
+			name:   "recovery link error",
+			invite: &types.Invite{ID: inviteID, TenantID: tenantID, UserID: userID, ExpiresAt: past},
+			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
+				mockKratos.EXPECT().CreateRecoveryLink(gomock.Any(), userID, "1h").Return("", "", errors.New("kratos error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, NewMockAuthzInterface(ctrl), mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "tenant.Service.ResendInvite").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockStorage.EXPECT().GetInvite(gomock.Any(), tenantID, inviteID).Return(tc.invite, nil)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockStorage, mockKratos, mockMonitor)
+			}
+
+			invite, link, _, err := s.ResendInvite(context.Background(), tenantID, inviteID)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if link == "" {
+				t.Error("expected a link")
+			}
+			if state := invite.State(time.Now()); state != types.InviteStatePending {
+				t.Errorf("expected state %s, got %s", types.InviteStatePending, state)
+			}
+		})
+	}
+}
+
 func TestService_CreateTenant(t *testing.T) {
 	name := "Test Tenant"
 	createdTenant := &types.Tenant{ID: "tenant-123", Name: name, Enabled: true}
@@ -340,6 +709,9 @@ func TestService_CreateTenant(t *testing.T) {
 						if !t.Enabled {
 							return nil, errors.New("should be enabled")
 						}
+						if t.Region != "eu-west-1" {
+							return nil, errors.New("wrong region")
+						}
 						return createdTenant, nil
 					})
 			},
@@ -367,12 +739,12 @@ func TestService_CreateTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.CreateTenant").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
 
-			tenant, err := s.CreateTenant(context.Background(), name)
+			tenant, err := s.CreateTenant(context.Background(), name, "eu-west-1")
 
 			if tc.expectedErr {
 				if err == nil {
@@ -438,7 +810,7 @@ func TestService_UpdateTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.UpdateTenant").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage)
@@ -465,9 +837,10 @@ func TestService_DeleteTenant(t *testing.T) {
 	tenantID := "tenant-123"
 
 	testCases := []struct {
-		name        string
-		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockLoggerInterface)
-		expectedErr bool
+		name            string
+		setupMocks      func(*MockStorageInterface, *MockAuthzInterface, *MockLoggerInterface)
+		expectedExisted bool
+		expectedErr     bool
 	}{
 		{
 			name: "success",
@@ -475,7 +848,15 @@ func TestService_DeleteTenant(t *testing.T) {
 				mockStorage.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(nil)
 				mockAuthz.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(nil)
 			},
-			expectedErr: false,
+			expectedExisted: true,
+		},
+		{
+			name: "missing tenant - succeeds and cleans authz",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(storage.ErrNotFound)
+				mockAuthz.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(nil)
+			},
+			expectedExisted: false,
 		},
 		{
 			name: "storage error",
@@ -490,7 +871,7 @@ func TestService_DeleteTenant(t *testing.T) {
 				mockStorage.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(nil)
 				mockAuthz.EXPECT().DeleteTenant(gomock.Any(), tenantID).Return(errors.New("authz error"))
 			},
-			expectedErr: false,
+			expectedExisted: true,
 		},
 	}
 
@@ -507,12 +888,100 @@ func TestService_DeleteTenant(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.DeleteTenant").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockAuthz, mockLogger)
 
-			err := s.DeleteTenant(context.Background(), tenantID)
+			existed, err := s.DeleteTenant(context.Background(), tenantID)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if existed != tc.expectedExisted {
+				t.Errorf("expected existed %v, got %v", tc.expectedExisted, existed)
+			}
+		})
+	}
+}
+
+func TestService_RemoveTenantUser(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-456"
+
+	tenantTuples := []openfga.Tuple{
+		*openfga.NewTuple("user:"+userID, "member", "tenant:"+tenantID),
+		*openfga.NewTuple("role:role-1#assignee", "viewer", "tenant:"+tenantID),
+		*openfga.NewTuple("role:role-1#assignee", "editor", "tenant:"+tenantID),
+	}
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(*MockStorageInterface, *MockAuthzInterface)
+		expectedExisted bool
+		expectedErr     bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), tenantID).Return(tenantTuples, nil)
+				mockAuthz.EXPECT().UnassignRole(gomock.Any(), "role-1", userID).Return(nil)
+			},
+			expectedExisted: true,
+		},
+		{
+			name: "not a member - succeeds and cleans authz",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenantID, userID).Return(storage.ErrNotFound)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), tenantID).Return(nil, nil)
+			},
+			expectedExisted: false,
+		},
+		{
+			name: "storage error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenantID, userID).Return(errors.New("storage error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenantID, userID).Return(errors.New("authz error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			s := NewService(mockStorage, mockAuthz, NewMockKratosClientInterface(ctrl), "1h", true, TenantSourceDatabase, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.RemoveTenantUser").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			existed, err := s.RemoveTenantUser(context.Background(), tenantID, userID)
 
 			if tc.expectedErr {
 				if err == nil {
@@ -521,6 +990,9 @@ func TestService_DeleteTenant(t *testing.T) {
 			} else if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
+			if existed != tc.expectedExisted {
+				t.Errorf("expected existed %v, got %v", tc.expectedExisted, existed)
+			}
 		})
 	}
 }
@@ -532,7 +1004,7 @@ func TestService_ProvisionUser(t *testing.T) {
 
 	testCases := []struct {
 		name        string
-		role        string
+		role        types.MembershipRole
 		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface, *MockMonitorInterface)
 		expectedErr bool
 	}{
@@ -542,7 +1014,7 @@ func TestService_ProvisionUser(t *testing.T) {
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return("", nil)
 				mockKratos.EXPECT().CreateIdentity(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "member").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleMember).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "user_provisioned", "role": "member"}).Return(nil)
 			},
@@ -553,7 +1025,7 @@ func TestService_ProvisionUser(t *testing.T) {
 			role: "owner",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "owner").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleOwner).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenantID, identityID).Return(nil)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "user_provisioned", "role": "owner"}).Return(nil)
 			},
@@ -564,8 +1036,8 @@ func TestService_ProvisionUser(t *testing.T) {
 			role: "admin",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "admin").Return("member-id", nil)
-				mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), tenantID, identityID).Return(nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.RoleAdmin).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantAdmin(gomock.Any(), tenantID, identityID).Return(nil)
 				mockMonitor.EXPECT().IncrementCounter(map[string]string{"operation": "user_provisioned", "role": "admin"}).Return(nil)
 			},
 			expectedErr: false,
@@ -583,7 +1055,7 @@ func TestService_ProvisionUser(t *testing.T) {
 			role: "superadmin",
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockMonitor *MockMonitorInterface) {
 				mockKratos.EXPECT().GetIdentityIDByEmail(gomock.Any(), email).Return(identityID, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, "superadmin").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenantID, identityID, types.MembershipRole("superadmin")).Return("member-id", nil)
 			},
 			expectedErr: true,
 		},
@@ -602,7 +1074,7 @@ func TestService_ProvisionUser(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.ProvisionUser").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockAuthz, mockKratos, mockMonitor)
@@ -622,26 +1094,72 @@ func TestService_ProvisionUser(t *testing.T) {
 
 func TestService_ListUserTenants(t *testing.T) {
 	userID := "user-123"
+	actor := "admin-1"
 	expectedTenants := []*types.Tenant{
 		{ID: "tenant-1", Name: "Tenant 1"},
 		{ID: "tenant-2", Name: "Tenant 2"},
 	}
 
 	testCases := []struct {
-		name        string
-		setupMocks  func(*MockStorageInterface)
-		expectedErr bool
+		name                 string
+		authorizationEnabled bool
+		actor                string
+		setupMocks           func(*MockStorageInterface, *MockAuthzInterface)
+		expectedIDs          []string
+		expectedErr          bool
 	}{
 		{
-			name: "success",
-			setupMocks: func(mockStorage *MockStorageInterface) {
+			name:                 "success",
+			authorizationEnabled: true,
+			actor:                actor,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
 				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(expectedTenants, nil)
+				mockAuthz.EXPECT().FilterObjectsStreamed(gomock.Any(), "user:"+actor, "can_view", "tenant", []string{"tenant-1", "tenant-2"}).Return([]string{"tenant-1", "tenant-2"}, nil)
 			},
-			expectedErr: false,
+			expectedIDs: []string{"tenant-1", "tenant-2"},
 		},
 		{
-			name: "storage error",
-			setupMocks: func(mockStorage *MockStorageInterface) {
+			name:                 "tenants not visible to viewer are hidden",
+			authorizationEnabled: true,
+			actor:                actor,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(expectedTenants, nil)
+				mockAuthz.EXPECT().FilterObjectsStreamed(gomock.Any(), "user:"+actor, "can_view", "tenant", []string{"tenant-1", "tenant-2"}).Return([]string{"tenant-2"}, nil)
+			},
+			expectedIDs: []string{"tenant-2"},
+		},
+		{
+			name:                 "bypass when authorization is disabled",
+			authorizationEnabled: false,
+			actor:                actor,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(expectedTenants, nil)
+			},
+			expectedIDs: []string{"tenant-1", "tenant-2"},
+		},
+		{
+			name:                 "no viewer",
+			authorizationEnabled: true,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(expectedTenants, nil)
+			},
+			expectedErr: true,
+		},
+		{
+			name:                 "authorization error",
+			authorizationEnabled: true,
+			actor:                actor,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(expectedTenants, nil)
+				mockAuthz.EXPECT().FilterObjectsStreamed(gomock.Any(), "user:"+actor, "can_view", "tenant", []string{"tenant-1", "tenant-2"}).Return(nil, errors.New("fga error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name:                 "storage error",
+			authorizationEnabled: true,
+			actor:                actor,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
 				mockStorage.EXPECT().ListTenantsByUserID(gomock.Any(), userID).Return(nil, errors.New("storage error"))
 			},
 			expectedErr: true,
@@ -661,24 +1179,121 @@ func TestService_ListUserTenants(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", tc.authorizationEnabled, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
-			mockTracer.EXPECT().Start(gomock.Any(), "admin.ListUserTenants").Return(context.Background(), trace.SpanFromContext(context.Background()))
-			tc.setupMocks(mockStorage)
+			ctx := context.Background()
+			if tc.actor != "" {
+				ctx = authentication.WithUserID(ctx, tc.actor)
+			}
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.ListUserTenants").Return(ctx, trace.SpanFromContext(ctx))
+			tc.setupMocks(mockStorage, mockAuthz)
 
-			tenants, err := s.ListUserTenants(context.Background(), userID)
+			tenants, err := s.ListUserTenants(ctx, userID)
 
 			if tc.expectedErr {
 				if err == nil {
 					t.Error("expected error but got none")
 				}
-			} else {
-				if err != nil {
-					t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tenants) != len(tc.expectedIDs) {
+				t.Fatalf("expected %d tenants, got %d", len(tc.expectedIDs), len(tenants))
+			}
+			for i, id := range tc.expectedIDs {
+				if tenants[i].ID != id {
+					t.Errorf("expected tenant %s, got %s", id, tenants[i].ID)
 				}
-				if len(tenants) != len(expectedTenants) {
-					t.Errorf("expected %d tenants, got %d", len(expectedTenants), len(tenants))
+			}
+		})
+	}
+}
+
+func TestService_GetTenantUser(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "identity-1"
+	joinedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	permissions := map[string]bool{"can_view": true, "can_edit": true, "can_create": false, "can_delete": false}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface)
+		expected    *types.TenantUser
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenantID, userID).
+					Return(&types.Membership{TenantID: tenantID, KratosIdentityID: userID, Role: types.RoleAdmin, CreatedAt: joinedAt}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), userID).
+					Return(&ory.Identity{Traits: map[string]interface{}{"email": "user1@example.com"}}, nil)
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), tenantID).Return([]openfga.Tuple{
+					{User: "user:identity-1", Relation: "member", Object: "tenant:tenant-123"},
+					{User: "user:identity-2", Relation: "owner", Object: "tenant:tenant-123"},
+					{User: "user:identity-1", Relation: "admin", Object: "tenant:tenant-123"},
+				}, nil)
+				mockAuthz.EXPECT().TenantPermissions(gomock.Any(), tenantID, userID).Return(permissions, nil)
+			},
+			expected: &types.TenantUser{
+				UserID:         userID,
+				Email:          "user1@example.com",
+				Role:           types.RoleAdmin,
+				IdentityStatus: types.IdentityStatusResolved,
+				JoinedAt:       joinedAt,
+				Relations:      []string{"admin", "member"},
+				Permissions:    permissions,
+			},
+		},
+		{
+			name: "not a member",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenantID, userID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: storage.ErrNotFound,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface) {
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenantID, userID).
+					Return(&types.Membership{KratosIdentityID: userID, Role: types.RoleMember}, nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), userID).Return(nil, errors.New("kratos error"))
+				mockAuthz.EXPECT().ListTenantTuples(gomock.Any(), tenantID).Return(nil, errors.New("openfga error"))
+			},
+			expectedErr: errors.New("failed to list tenant relations: openfga error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.GetTenantUser").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz, mockKratos)
+
+			user, err := s.GetTenantUser(context.Background(), tenantID, userID)
+
+			if tc.expectedErr != nil {
+				if err == nil || (!errors.Is(err, tc.expectedErr) && err.Error() != tc.expectedErr.Error()) {
+					t.Errorf("expected error %v, got %v", tc.expectedErr, err)
 				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if !reflect.DeepEqual(user, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, user)
 			}
 		})
 	}
@@ -701,31 +1316,53 @@ func TestService_ListTenantUsers(t *testing.T) {
 
 	testCases := []struct {
 		name        string
+		skip        bool
 		setupMocks  func(*MockStorageInterface, *MockKratosClientInterface, *MockLoggerInterface)
+		expected    []*types.TenantUser
 		expectedErr bool
 	}{
 		{
 			name: "success",
 			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface) {
-				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenantID).Return(members, nil)
+				mockStorage.EXPECT().ListMembersPage(gomock.Any(), tenantID, uint64(0), uint64(0)).Return(members, nil)
 				mockKratos.EXPECT().GetIdentity(gomock.Any(), identityID1).Return(identity1, nil)
 				mockKratos.EXPECT().GetIdentity(gomock.Any(), identityID2).Return(identity2, nil)
 			},
+			expected: []*types.TenantUser{
+				{UserID: identityID1, Email: "user1@example.com", Role: "owner", IdentityStatus: types.IdentityStatusResolved},
+				{UserID: identityID2, Email: "user2@example.com", Role: "member", IdentityStatus: types.IdentityStatusResolved},
+			},
 			expectedErr: false,
 		},
 		{
 			name: "success - kratos error handled",
 			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface) {
-				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenantID).Return(members, nil)
+				mockStorage.EXPECT().ListMembersPage(gomock.Any(), tenantID, uint64(0), uint64(0)).Return(members, nil)
 				mockKratos.EXPECT().GetIdentity(gomock.Any(), identityID1).Return(nil, errors.New("kratos error"))
 				mockKratos.EXPECT().GetIdentity(gomock.Any(), identityID2).Return(identity2, nil)
 			},
+			expected: []*types.TenantUser{
+				{UserID: identityID1, Role: "owner", IdentityStatus: types.IdentityStatusUnknown},
+				{UserID: identityID2, Email: "user2@example.com", Role: "member", IdentityStatus: types.IdentityStatusResolved},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "success - identity lookup skipped",
+			skip: true,
+			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembersPage(gomock.Any(), tenantID, uint64(0), uint64(0)).Return(members, nil)
+			},
+			expected: []*types.TenantUser{
+				{UserID: identityID1, Role: "owner"},
+				{UserID: identityID2, Role: "member"},
+			},
 			expectedErr: false,
 		},
 		{
 			name: "storage error",
 			setupMocks: func(mockStorage *MockStorageInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface) {
-				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenantID).Return(nil, errors.New("storage error"))
+				mockStorage.EXPECT().ListMembersPage(gomock.Any(), tenantID, uint64(0), uint64(0)).Return(nil, errors.New("storage error"))
 			},
 			expectedErr: true,
 		},
@@ -744,12 +1381,12 @@ func TestService_ListTenantUsers(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.ListTenantUsers").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockKratos, mockLogger)
 
-			users, err := s.ListTenantUsers(context.Background(), tenantID)
+			users, err := s.ListTenantUsers(context.Background(), tenantID, tc.skip, 0, 0)
 
 			if tc.expectedErr {
 				if err == nil {
@@ -757,8 +1394,8 @@ func TestService_ListTenantUsers(t *testing.T) {
 				}
 			} else if err != nil {
 				t.Errorf("unexpected error: %v", err)
-			} else if users == nil {
-				t.Error("expected users but got nil")
+			} else if !reflect.DeepEqual(users, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, users)
 			}
 		})
 	}
@@ -776,7 +1413,7 @@ func TestService_UpdateTenantUser(t *testing.T) {
 
 	testCases := []struct {
 		name        string
-		newRole     string
+		newRole     types.MembershipRole
 		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface, *MockLoggerInterface)
 		expectedErr bool
 	}{
@@ -787,7 +1424,19 @@ func TestService_UpdateTenantUser(t *testing.T) {
 				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenantID).Return(currentMembers, nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenantID, userID).Return(nil)
 				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenantID, userID).Return(nil)
-				mockStorage.EXPECT().UpdateMember(gomock.Any(), tenantID, userID, "owner").Return(nil)
+				mockStorage.EXPECT().UpdateMember(gomock.Any(), tenantID, userID, types.RoleOwner).Return(nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), userID).Return(identity, nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:    "success - promote member to admin",
+			newRole: "admin",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenantID).Return(currentMembers, nil)
+				mockAuthz.EXPECT().AssignTenantAdmin(gomock.Any(), tenantID, userID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenantID, userID).Return(nil)
+				mockStorage.EXPECT().UpdateMember(gomock.Any(), tenantID, userID, types.RoleAdmin).Return(nil)
 				mockKratos.EXPECT().GetIdentity(gomock.Any(), userID).Return(identity, nil)
 			},
 			expectedErr: false,
@@ -831,7 +1480,7 @@ func TestService_UpdateTenantUser(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", mockTracer, mockMonitor, mockLogger)
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "admin.UpdateTenantUser").Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockAuthz, mockKratos, mockLogger)
@@ -853,3 +1502,241 @@ func TestService_UpdateTenantUser(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Events(t *testing.T) {
+	tenant := &types.Tenant{ID: "tenant-123", Name: "Test Tenant", Enabled: true}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface, *MockKratosClientInterface, *MockEventsInterface)
+		call        func(*Service) error
+		expectedErr bool
+	}{
+		{
+			name: "tenant created",
+			setupMocks: func(mockStorage *MockStorageInterface, _ *MockAuthzInterface, _ *MockKratosClientInterface, mockEvents *MockEventsInterface) {
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.TenantCreated, tenant.ID, events.TenantData{TenantID: tenant.ID, Name: tenant.Name, Enabled: true}).Return(nil)
+			},
+			call: func(s *Service) error {
+				_, err := s.CreateTenant(context.Background(), tenant.Name, "")
+				return err
+			},
+		},
+		{
+			name: "outbox error fails the mutation",
+			setupMocks: func(mockStorage *MockStorageInterface, _ *MockAuthzInterface, _ *MockKratosClientInterface, mockEvents *MockEventsInterface) {
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.TenantCreated, tenant.ID, gomock.Any()).Return(errors.New("db error"))
+			},
+			call: func(s *Service) error {
+				_, err := s.CreateTenant(context.Background(), tenant.Name, "")
+				return err
+			},
+			expectedErr: true,
+		},
+		{
+			name: "tenant deleted",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockKratosClientInterface, mockEvents *MockEventsInterface) {
+				mockStorage.EXPECT().DeleteTenant(gomock.Any(), tenant.ID).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.TenantDeleted, tenant.ID, events.TenantData{TenantID: tenant.ID}).Return(nil)
+				mockAuthz.EXPECT().DeleteTenant(gomock.Any(), tenant.ID).Return(nil)
+			},
+			call: func(s *Service) error {
+				_, err := s.DeleteTenant(context.Background(), tenant.ID)
+				return err
+			},
+		},
+		{
+			name: "missing tenant is not announced",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, _ *MockKratosClientInterface, _ *MockEventsInterface) {
+				mockStorage.EXPECT().DeleteTenant(gomock.Any(), tenant.ID).Return(storage.ErrNotFound)
+				mockAuthz.EXPECT().DeleteTenant(gomock.Any(), tenant.ID).Return(nil)
+			},
+			call: func(s *Service) error {
+				_, err := s.DeleteTenant(context.Background(), tenant.ID)
+				return err
+			},
+		},
+		{
+			name: "member role changed",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface, mockKratos *MockKratosClientInterface, mockEvents *MockEventsInterface) {
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenant.ID).Return([]*types.Membership{{KratosIdentityID: "user-456", Role: types.RoleMember}}, nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "user-456").Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenant.ID, "user-456").Return(nil)
+				mockStorage.EXPECT().UpdateMember(gomock.Any(), tenant.ID, "user-456", types.RoleOwner).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any(), events.MemberRoleChanged, tenant.ID, events.MemberData{TenantID: tenant.ID, UserID: "user-456", Role: "owner", PreviousRole: "member"}).Return(nil)
+				mockKratos.EXPECT().GetIdentity(gomock.Any(), "user-456").Return(&ory.Identity{}, nil)
+			},
+			call: func(s *Service) error {
+				_, err := s.UpdateTenantUser(context.Background(), tenant.ID, "user-456", types.RoleOwner)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockEvents := NewMockEventsInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+			tc.setupMocks(mockStorage, mockAuthz, mockKratos, mockEvents)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			s.SetEvents(mockEvents)
+
+			err := tc.call(s)
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_AddPlatformAdmin(t *testing.T) {
+	testCases := []struct {
+		name        string
+		authzErr    error
+		expectedErr bool
+	}{
+		{name: "success"},
+		{name: "authz error", authzErr: errors.New("fga error"), expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.AddPlatformAdmin").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockAuthz.EXPECT().AssignPrivilegedAdmin(gomock.Any(), "support", "user-1").Return(tc.authzErr)
+
+			err := s.AddPlatformAdmin(context.Background(), "support", "user-1")
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestService_ListAuthzAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockAuthz := NewMockAuthzInterface(ctrl)
+	mockKratos := NewMockKratosClientInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+	mockMonitor := NewMockMonitorInterface(ctrl)
+
+	s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+	filter := &types.AuthzAuditFilter{Object: "tenant:a", Limit: 10}
+	entries := []*types.AuthzAuditEntry{{ID: 1, Operation: "write", User: "user:1", Relation: "member", Object: "tenant:a"}}
+
+	mockTracer.EXPECT().Start(gomock.Any(), "admin.ListAuthzAudit").Return(context.Background(), trace.SpanFromContext(context.Background())).Times(2)
+	mockStorage.EXPECT().ListAuthzAuditEntries(gomock.Any(), filter).Return(entries, nil)
+
+	got, err := s.ListAuthzAudit(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("expected %v, got %v", entries, got)
+	}
+
+	mockStorage.EXPECT().ListAuthzAuditEntries(gomock.Any(), filter).Return(nil, errors.New("db error"))
+	if _, err := s.ListAuthzAudit(context.Background(), filter); err == nil {
+		t.Error("expected error but got none")
+	}
+}
+
+func TestService_LinkTenantToSupportGroup(t *testing.T) {
+	tenantID := "tenant-123"
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthzInterface)
+		expectedErr bool
+		notFound    bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(&types.Tenant{ID: tenantID}, nil)
+				mockAuthz.EXPECT().LinkTenantToPrivileged(gomock.Any(), tenantID, "support").Return(nil)
+			},
+		},
+		{
+			name: "tenant not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: true,
+			notFound:    true,
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthzInterface) {
+				mockStorage.EXPECT().GetTenantByID(gomock.Any(), tenantID).Return(&types.Tenant{ID: tenantID}, nil)
+				mockAuthz.EXPECT().LinkTenantToPrivileged(gomock.Any(), tenantID, "support").Return(errors.New("fga error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthzInterface(ctrl)
+			mockKratos := NewMockKratosClientInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(mockStorage, mockAuthz, mockKratos, "1h", true, TenantSourceDatabase, mockTracer, mockMonitor, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "admin.LinkTenantToSupportGroup").Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			err := s.LinkTenantToSupportGroup(context.Background(), tenantID, "support")
+
+			if tc.expectedErr && err == nil {
+				t.Error("expected error but got none")
+			} else if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.notFound && !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("expected not found error, got %v", err)
+			}
+		})
+	}
+}
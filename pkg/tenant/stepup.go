@@ -0,0 +1,80 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/pkg/authentication"
+)
+
+// ErrorReasonStepUpRequired is the reason of the error returned to the
+// callers of the destructive RPCs without the elevated scope nor a recent
+// MFA login.
+const ErrorReasonStepUpRequired = "STEP_UP_REQUIRED"
+
+// StepUpPolicy is what the callers of the destructive RPCs must hold on top
+// of their permission: Scope, or a login within MaxAge with one of
+// MFAMethods.
+type StepUpPolicy struct {
+	Scope      string
+	MFAMethods []string
+	MaxAge     time.Duration
+}
+
+// satisfiedBy reports whether principal holds the scope or logged in
+// recently with MFA.
+func (p *StepUpPolicy) satisfiedBy(principal *authentication.Principal, now time.Time) bool {
+	if p.Scope != "" && slices.Contains(principal.Scopes, p.Scope) {
+		return true
+	}
+
+	if principal.AuthTime.IsZero() || now.Sub(principal.AuthTime) > p.MaxAge {
+		return false
+	}
+	return slices.ContainsFunc(principal.AuthMethods, func(m string) bool { return slices.Contains(p.MFAMethods, m) })
+}
+
+// error returns the PermissionDenied error of the callers not satisfying
+// the policy, its details tell clients how to authenticate again.
+func (p *StepUpPolicy) error(fullMethod string) error {
+	st := status.Newf(codes.PermissionDenied, "%s requires the %s scope or a login with MFA within the last %s", fullMethod, p.Scope, p.MaxAge)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ErrorReasonStepUpRequired,
+		Domain: "tenant-service",
+		Metadata: map[string]string{
+			"required_scope": p.Scope,
+			"amr_values":     strings.Join(p.MFAMethods, " "),
+			"max_age":        fmt.Sprint(int64(p.MaxAge.Seconds())),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// checkStepUp requires the callers of the destructiveMethods to satisfy the
+// step-up policy, when one is set.
+func (a *AccessControl) checkStepUp(ctx context.Context, principal *authentication.Principal, fullMethod string) error {
+	if a.stepUp == nil || !slices.Contains(destructiveMethods, fullMethod) {
+		return nil
+	}
+
+	if a.stepUp.satisfiedBy(principal, time.Now()) {
+		return nil
+	}
+
+	a.logger.Security().AuthzFailure(principal.ID, fullMethod, authentication.PrincipalLabel(ctx), logging.WithLabel("reason", "step_up_required"))
+	return a.stepUp.error(fullMethod)
+}
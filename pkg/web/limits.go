@@ -0,0 +1,76 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/canonical/tenant-service/internal/http/types"
+)
+
+// RouteLimits bounds the requests of a group of routes, zero values disable
+// the limits.
+type RouteLimits struct {
+	// MaxBodySize is the size, in bytes, of the largest body accepted.
+	MaxBodySize int64
+	// Timeout is the time given to the handler, through the deadline of
+	// the context of the request.
+	Timeout time.Duration
+}
+
+// Limits are the RouteLimits of the groups of routes: the webhooks, called
+// by Kratos and Hydra, and the API served by the gateway. The status and
+// metrics endpoints have none.
+type Limits struct {
+	API      RouteLimits
+	Webhooks RouteLimits
+}
+
+// middleware returns the middleware applying the limits of the group of
+// each request, before its body is read by the payload logger or the
+// handler.
+func (l Limits) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case isProbeRequest(r):
+			next.ServeHTTP(w, r)
+		case strings.HasPrefix(r.URL.Path, "/api/v0/webhooks/"):
+			// the webhooks keep the plain bodies of their errors
+			l.Webhooks.serve(w, r, next, func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			})
+		default:
+			l.API.serve(w, r, next, func(w http.ResponseWriter, r *http.Request) {
+				types.WriteProblem(w, r, http.StatusRequestEntityTooLarge, codes.ResourceExhausted, "request body too large")
+			})
+		}
+	})
+}
+
+// serve applies the limits to r before passing it to next. The requests
+// announcing a body over MaxBodySize are rejected with tooLarge, the others
+// fail to read past it.
+func (l RouteLimits) serve(w http.ResponseWriter, r *http.Request, next http.Handler, tooLarge http.HandlerFunc) {
+	if l.MaxBodySize > 0 {
+		if r.ContentLength > l.MaxBodySize {
+			w.Header().Set("Connection", "close")
+			tooLarge(w, r)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, l.MaxBodySize)
+	}
+
+	if l.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), l.Timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+
+	next.ServeHTTP(w, r)
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/http/types"
+)
+
+func TestLimitsMiddleware(t *testing.T) {
+	limits := Limits{
+		API:      RouteLimits{MaxBodySize: 8, Timeout: time.Minute},
+		Webhooks: RouteLimits{MaxBodySize: 4},
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		body                string
+		chunked             bool
+		expectedStatus      int
+		expectedContentType string
+		expectedDeadline    bool
+	}{
+		{name: "API body within the limit", path: "/api/v0/tenants", body: "{}", expectedStatus: http.StatusOK, expectedDeadline: true},
+		{name: "API body over the limit", path: "/api/v0/tenants", body: `{"name":"acme"}`, expectedStatus: http.StatusRequestEntityTooLarge, expectedContentType: types.ProblemContentType},
+		{name: "API chunked body over the limit", path: "/api/v0/tenants", body: `{"name":"acme"}`, chunked: true, expectedStatus: http.StatusBadRequest, expectedDeadline: true},
+		{name: "Webhook body over the limit", path: "/api/v0/webhooks/registration", body: "{}{}{}", expectedStatus: http.StatusRequestEntityTooLarge, expectedContentType: "text/plain; charset=utf-8"},
+		{name: "Status is not limited", path: "/api/v0/status", body: `{"name":"acme"}`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := limits.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := r.Context().Deadline(); ok != tt.expectedDeadline {
+					t.Errorf("expected a deadline %v, got %v", tt.expectedDeadline, ok)
+				}
+				if _, err := io.ReadAll(r.Body); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				r.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedContentType != "" && w.Header().Get("Content-Type") != tt.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tt.expectedContentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
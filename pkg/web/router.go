@@ -6,16 +6,24 @@ package web
 import (
 	"context"
 	"net/http"
+	"strings"
 
-	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/clientip"
 	"github.com/canonical/tenant-service/internal/db"
 	"github.com/canonical/tenant-service/internal/http/types"
 	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/maintenance"
 	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/recovery"
 	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/tracing"
 	"github.com/canonical/tenant-service/pkg/authentication"
+	"github.com/canonical/tenant-service/pkg/deprecation"
+	"github.com/canonical/tenant-service/pkg/docs"
+	"github.com/canonical/tenant-service/pkg/idempotency"
 	"github.com/canonical/tenant-service/pkg/metrics"
+	"github.com/canonical/tenant-service/pkg/ratelimit"
 	"github.com/canonical/tenant-service/pkg/status"
 	"github.com/canonical/tenant-service/pkg/webhooks"
 	v0 "github.com/canonical/tenant-service/v0"
@@ -27,10 +35,25 @@ import (
 
 func NewRouter(
 	tenantHandler v0.TenantServiceServer,
+	clientIPs *clientip.Resolver,
+	recoverer *recovery.Recoverer,
 	authMiddleware *authentication.Middleware,
+	rateLimiter *ratelimit.Limiter,
+	shedder *monitoring.LoadShedder,
+	maintenanceMode *maintenance.Mode,
+	limits Limits,
+	payloadLogger *logging.PayloadLogger,
 	s storage.StorageInterface,
 	dbClient db.DBClientInterface,
-	authz authorization.AuthorizerInterface,
+	authzModel status.ModelConfig,
+	dependencies map[string]status.DependencyInterface,
+	readiness *status.ReadinessChecker,
+	webhooksService webhooks.ServiceInterface,
+	strictJSON, strictWebhookJSON bool,
+	webhookVersion webhooks.Version,
+	registrationAuth, tokenAuth, loginAuth, identityAuth, consentAuth *webhooks.SecretVerifier,
+	webhookLimiter *webhooks.RateLimiter,
+	docsAPI *docs.API,
 	tracer tracing.TracingInterface,
 	monitor monitoring.MonitorInterface,
 	logger logging.LoggerInterface,
@@ -41,40 +64,128 @@ func NewRouter(
 	middlewares = append(
 		middlewares,
 		middleware.RequestID,
+		// first after the request ID, to cover the other middlewares
+		recoverer.Middleware,
+		// before anything logging or limiting by client IP
+		clientIPs.Middleware,
+		// queries following a tuple write in the same request see it
+		openfga.ConsistencyMiddleware,
 		monitoring.NewMiddleware(monitor, logger).ResponseTime(),
 		middlewareCORS([]string{"*"}),
 		middleware.RequestLogger(logging.NewLogFormatter(logger)),
+		// before the bodies are read, and the transactions begun
+		limits.middleware,
 	)
 
+	if payloadLogger != nil {
+		middlewares = append(middlewares, payloadLogger.Middleware(isProbeRequest))
+	}
+
 	if dbClient != nil {
 		middlewares = append(middlewares, db.TransactionMiddleware(dbClient, logger))
 	}
 
+	// the public routes, status, metrics and webhooks, are allowlisted by
+	// the route policy of authMiddleware
+	middlewares = append(middlewares, authMiddleware.Authenticate())
+
+	jsonPb := &runtime.JSONPb{
+		// Use proto field names (snake_case) in JSON output instead of lowerCamelCase.
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: !strictJSON,
+		},
+	}
+	var marshaler runtime.Marshaler = jsonPb
+	if strictJSON {
+		// a typo in a field name fails with its path instead of using defaults
+		marshaler = types.NewStrictJSONPb(jsonPb)
+	}
+
 	gRPCGatewayMux := runtime.NewServeMux(
-		runtime.WithForwardResponseRewriter(types.ForwardErrorResponseRewriter),
+		runtime.WithErrorHandler(types.ProblemErrorHandler),
+		runtime.WithForwardResponseRewriter(types.ProblemResponseRewriter),
 		runtime.WithDisablePathLengthFallback(),
-		// Use proto field names (snake_case) in JSON output instead of lowerCamelCase.
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				UseProtoNames:   true,
-				EmitUnpopulated: true,
-			},
-		}),
+		runtime.WithIncomingHeaderMatcher(idempotency.HeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(deprecation.OutgoingHeaderMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
 	)
 	_ = v0.RegisterTenantServiceHandlerServer(context.Background(), gRPCGatewayMux, tenantHandler)
 
 	router.Use(middlewares...)
 
 	metrics.NewAPI(logger).RegisterEndpoints(router)
-	status.NewAPI(tracer, monitor, logger).RegisterEndpoints(router)
-	webhooks.NewAPI(webhooks.NewService(s, authz, tracer, monitor, logger), logger).RegisterEndpoints(router)
+	status.NewAPI(dependencies, tracer, monitor, logger).RegisterEndpoints(router)
+	status.NewReadyAPI(readiness, tracer, monitor, logger).RegisterEndpoints(router)
+	status.NewModelAPI(authzModel, s, tracer, monitor, logger).RegisterEndpoints(router)
+	docsAPI.RegisterEndpoints(router)
+	webhooksAPI := webhooks.NewAPI(webhooksService, strictWebhookJSON, logger)
+	webhooksAPI.SetVersion(webhookVersion)
+	webhooksAPI.SetVerifiers(registrationAuth, tokenAuth, loginAuth, identityAuth, consentAuth)
+	webhooksAPI.SetRateLimiter(webhookLimiter)
+	webhooksAPI.RegisterEndpoints(router)
 
-	// Protected routes
+	// API routes
 	authRouter := chi.NewRouter()
-	authRouter.Use(authMiddleware.Authenticate())
+	if rateLimiter != nil {
+		authRouter.Use(rateLimiter.Middleware)
+	}
+	authRouter.Use(maintenanceMode.Guard(isReadRequest))
+	if shedder != nil {
+		authRouter.Use(shedder.Shed(isListRequest))
+	}
 	authRouter.Mount("/", gRPCGatewayMux)
 
 	router.Mount("/", authRouter)
 
 	return tracing.NewMiddleware(monitor, logger).OpenTelemetry(router)
 }
+
+// NewAdminRouter serves the status and metrics endpoints only, for
+// deployments exposing the API over gRPC alone.
+func NewAdminRouter(
+	s storage.StorageInterface,
+	authzModel status.ModelConfig,
+	dependencies map[string]status.DependencyInterface,
+	readiness *status.ReadinessChecker,
+	recoverer *recovery.Recoverer,
+	tracer tracing.TracingInterface,
+	monitor monitoring.MonitorInterface,
+	logger logging.LoggerInterface,
+) http.Handler {
+	router := chi.NewMux()
+	router.Use(
+		middleware.RequestID,
+		recoverer.Middleware,
+		monitoring.NewMiddleware(monitor, logger).ResponseTime(),
+		middleware.RequestLogger(logging.NewLogFormatter(logger)),
+	)
+
+	metrics.NewAPI(logger).RegisterEndpoints(router)
+	status.NewAPI(dependencies, tracer, monitor, logger).RegisterEndpoints(router)
+	status.NewReadyAPI(readiness, tracer, monitor, logger).RegisterEndpoints(router)
+	status.NewModelAPI(authzModel, s, tracer, monitor, logger).RegisterEndpoints(router)
+
+	return tracing.NewMiddleware(monitor, logger).OpenTelemetry(router)
+}
+
+// isListRequest tells whether a gateway request is a listing, every GET route
+// of the API is one. Status and webhook routes are never shed.
+func isListRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet
+}
+
+// isProbeRequest tells whether a request comes from monitoring, their
+// payloads are not logged.
+func isProbeRequest(r *http.Request) bool {
+	return r.URL.Path == "/api/v0/metrics" || r.URL.Path == "/api/v0/version" || strings.HasPrefix(r.URL.Path, "/api/v0/status")
+}
+
+// isReadRequest tells whether a gateway request only reads, the requests
+// still served in maintenance.
+func isReadRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead
+}
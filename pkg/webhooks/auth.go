@@ -0,0 +1,146 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/tenant-service/internal/logging"
+)
+
+const (
+	// SecretHeader holds a shared secret, as sent by the api_key auth of the
+	// Kratos and Hydra webhooks.
+	SecretHeader = "X-Webhook-Secret"
+	// SignatureHeader holds sha256=<hex HMAC-SHA256 of timestamp.body> keyed
+	// with a shared secret, for the senders able to sign, the timestamp being
+	// the value of TimestampHeader.
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader holds the Unix time, in seconds, a signed call was sent
+	// at, so that a captured call cannot be replayed past the tolerance.
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	signaturePrefix = "sha256="
+	// maxSignedBodySize bounds the bodies read to check their signature.
+	maxSignedBodySize = 1 << 20
+)
+
+// SecretVerifier authenticates the calls of a webhook with one of its shared
+// secrets, in SecretHeader or as the key of the HMAC in SignatureHeader.
+// Several secrets let them be rotated without downtime. The secrets are
+// compared in constant time, through their digests so that their lengths do
+// not show either.
+type SecretVerifier struct {
+	hook      string
+	secrets   [][]byte
+	digests   [][sha256.Size]byte
+	tolerance time.Duration
+	now       func() time.Time
+
+	logger logging.LoggerInterface
+}
+
+// Middleware rejects the calls without a valid secret or signature with
+// 401, before their body is decoded.
+func (v *SecretVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, err := v.verify(r)
+		if err != nil {
+			v.logger.Errorw("webhook: failed to read body", "hook", v.hook, "error", err)
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			v.logger.Security().AuthzFailure("", "webhook_"+v.hook, logging.WithRequest(r))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (v *SecretVerifier) verify(r *http.Request) (bool, error) {
+	if secret := r.Header.Get(SecretHeader); secret != "" {
+		return v.matchesSecret([]byte(secret)), nil
+	}
+
+	signature, found := strings.CutPrefix(r.Header.Get(SignatureHeader), signaturePrefix)
+	if !found {
+		return false, nil
+	}
+	mac, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, nil
+	}
+
+	timestamp := r.Header.Get(TimestampHeader)
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false, nil
+	}
+	if age := v.now().Sub(time.Unix(sent, 0)); age > v.tolerance || age < -v.tolerance {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodySize+1))
+	if err != nil {
+		return false, err
+	}
+	if len(body) > maxSignedBodySize {
+		return false, nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return v.matchesSignature(timestamp, body, mac), nil
+}
+
+func (v *SecretVerifier) matchesSecret(secret []byte) bool {
+	digest := sha256.Sum256(secret)
+	matched := 0
+	// every secret is compared so that the timing tells nothing of which
+	for _, d := range v.digests {
+		matched |= subtle.ConstantTimeCompare(digest[:], d[:])
+	}
+	return matched == 1
+}
+
+func (v *SecretVerifier) matchesSignature(timestamp string, body, mac []byte) bool {
+	matched := false
+	for _, s := range v.secrets {
+		h := hmac.New(sha256.New, s)
+		h.Write([]byte(timestamp + "."))
+		h.Write(body)
+		matched = hmac.Equal(mac, h.Sum(nil)) || matched
+	}
+	return matched
+}
+
+// NewSecretVerifier returns a verifier of the calls of hook with one of
+// secrets, empty secrets are ignored. The signed calls sent more than
+// tolerance away from now are rejected.
+func NewSecretVerifier(hook string, secrets []string, tolerance time.Duration, logger logging.LoggerInterface) *SecretVerifier {
+	v := new(SecretVerifier)
+	v.hook = hook
+	for _, s := range secrets {
+		if s != "" {
+			v.secrets = append(v.secrets, []byte(s))
+			v.digests = append(v.digests, sha256.Sum256([]byte(s)))
+		}
+	}
+	v.tolerance = tolerance
+	v.now = time.Now
+	v.logger = logger
+
+	return v
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func sign(secret string, sent time.Time, body string) map[string]string {
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "." + body))
+	return map[string]string{SignatureHeader: "sha256=" + hex.EncodeToString(h.Sum(nil)), TimestampHeader: timestamp}
+}
+
+func TestSecretVerifier_Middleware(t *testing.T) {
+	body := `{"id":"identity-1","traits":{"email":"jane@example.com"}}`
+	now := time.Unix(1760000000, 0)
+	unsigned := sign("current", now, body)
+	unsigned[SignatureHeader] = func() string {
+		h := hmac.New(sha256.New, []byte("current"))
+		h.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(h.Sum(nil))
+	}()
+	untimed := sign("current", now, body)
+	delete(untimed, TimestampHeader)
+	retimed := sign("current", now.Add(-time.Hour), body)
+	retimed[TimestampHeader] = strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{name: "secret", headers: map[string]string{SecretHeader: "current"}, expectedStatus: http.StatusOK},
+		{name: "previous secret", headers: map[string]string{SecretHeader: "previous"}, expectedStatus: http.StatusOK},
+		{name: "wrong secret", headers: map[string]string{SecretHeader: "guess"}, expectedStatus: http.StatusUnauthorized},
+		{name: "prefix of a secret", headers: map[string]string{SecretHeader: "curr"}, expectedStatus: http.StatusUnauthorized},
+		{name: "signature", headers: sign("current", now, body), expectedStatus: http.StatusOK},
+		{name: "signature within the tolerance", headers: sign("previous", now.Add(-4*time.Minute), body), expectedStatus: http.StatusOK},
+		{name: "replayed signature", headers: sign("current", now.Add(-6*time.Minute), body), expectedStatus: http.StatusUnauthorized},
+		{name: "signature from the future", headers: sign("current", now.Add(6*time.Minute), body), expectedStatus: http.StatusUnauthorized},
+		{name: "replayed signature with a new timestamp", headers: retimed, expectedStatus: http.StatusUnauthorized},
+		{name: "signature without timestamp", headers: untimed, expectedStatus: http.StatusUnauthorized},
+		{name: "signature of the body alone", headers: unsigned, expectedStatus: http.StatusUnauthorized},
+		{name: "signature of another body", headers: sign("current", now, "{}"), expectedStatus: http.StatusUnauthorized},
+		{name: "signature with an unknown secret", headers: sign("guess", now, body), expectedStatus: http.StatusUnauthorized},
+		{name: "malformed signature", headers: map[string]string{SignatureHeader: "sha256=zz", TimestampHeader: strconv.FormatInt(now.Unix(), 10)}, expectedStatus: http.StatusUnauthorized},
+		{name: "no credentials", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockSecurity := NewMockSecurityLoggerInterface(ctrl)
+			mockLogger.EXPECT().Security().Return(mockSecurity).AnyTimes()
+			if tt.expectedStatus == http.StatusUnauthorized {
+				mockSecurity.EXPECT().AuthzFailure("", "webhook_registration", gomock.Any())
+			}
+
+			v := NewSecretVerifier("registration", []string{"current", "previous", ""}, 5*time.Minute, mockLogger)
+			v.now = func() time.Time { return now }
+			var received string
+			handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				received = string(b)
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v0/webhooks/registration", strings.NewReader(body))
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Code == http.StatusOK && received != body {
+				t.Errorf("expected the handler to read the body, got %q", received)
+			}
+		})
+	}
+}
@@ -4,40 +4,113 @@
 package webhooks
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/go-chi/chi/v5"
 	"github.com/ory/hydra/v2/oauth2"
+	"google.golang.org/grpc/status"
 )
 
 type API struct {
-	service ServiceInterface
+	service    ServiceInterface
+	strictJSON bool
+	// the version of the bodies without a version header
+	version Version
+	// the calls are not authenticated without verifiers
+	registrationAuth *SecretVerifier
+	tokenAuth        *SecretVerifier
+	loginAuth        *SecretVerifier
+	// the identity-deleted and consent hooks are only served with a verifier
+	identityAuth *SecretVerifier
+	consentAuth  *SecretVerifier
+	// the calls are not rate limited without a limiter
+	limiter *RateLimiter
 	logger  logging.LoggerInterface
 }
 
-func NewAPI(service ServiceInterface, logger logging.LoggerInterface) *API {
+// NewAPI returns the webhooks API, strictJSON rejects the token hook bodies
+// and the v1 bodies holding fields unknown to the service.
+func NewAPI(service ServiceInterface, strictJSON bool, logger logging.LoggerInterface) *API {
 	return &API{
-		service: service,
-		logger:  logger,
+		service:    service,
+		strictJSON: strictJSON,
+		version:    V0,
+		logger:     logger,
 	}
 }
 
+// SetVersion reads the bodies of the calls without a version header with
+// the given schema version, v0 by default.
+func (a *API) SetVersion(v Version) {
+	a.version = v
+}
+
+// SetVerifiers authenticates the calls of the registration, token, login,
+// identity-deleted and consent hooks with their verifier, nil leaves the
+// registration, token and login hooks unauthenticated and the
+// identity-deleted and consent hooks unserved.
+func (a *API) SetVerifiers(registration, token, login, identity, consent *SecretVerifier) {
+	a.registrationAuth = registration
+	a.tokenAuth = token
+	a.loginAuth = login
+	a.identityAuth = identity
+	a.consentAuth = consent
+}
+
+// SetRateLimiter limits the calls of every webhook with limiter, before
+// they are authenticated, nil leaves them unlimited.
+func (a *API) SetRateLimiter(limiter *RateLimiter) {
+	a.limiter = limiter
+}
+
 func (a *API) RegisterEndpoints(mux *chi.Mux) {
-	mux.Post("/api/v0/webhooks/registration", a.registration)
-	mux.Post("/api/v0/webhooks/token", a.tokenHook)
+	mux.With(a.middlewares(a.registrationAuth)...).Post("/api/v0/webhooks/registration", a.registration)
+	mux.With(a.middlewares(a.tokenAuth)...).Post("/api/v0/webhooks/token", a.tokenHook)
+	mux.With(a.middlewares(a.loginAuth)...).Post("/api/v0/webhooks/login", a.login)
+	if a.identityAuth != nil {
+		mux.With(a.middlewares(a.identityAuth)...).Post("/api/v0/webhooks/identity-deleted", a.identityDeleted)
+	}
+	if a.consentAuth != nil {
+		mux.With(a.middlewares(a.consentAuth)...).Post("/api/v0/webhooks/consent", a.consent)
+	}
+}
+
+// middlewares returns the rate limiting and the authentication of a hook,
+// the limits also bound the guesses of its secrets.
+func (a *API) middlewares(v *SecretVerifier) []func(http.Handler) http.Handler {
+	var m []func(http.Handler) http.Handler
+	if a.limiter != nil {
+		m = append(m, a.limiter.Middleware)
+	}
+	if v != nil {
+		m = append(m, v.Middleware)
+	}
+	return m
 }
 
 func (a *API) tokenHook(w http.ResponseWriter, r *http.Request) {
-	req := new(oauth2.TokenHookRequest)
-	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
-		a.logger.Errorw("token hook: invalid request body", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	version, ok := a.negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := a.decodeTokenHook(r, version)
+	if err != nil {
+		a.logger.Errorw("token hook: invalid request body", "version", version, "error", err)
+		http.Error(w, "Invalid request body: "+errorMessage(err), http.StatusBadRequest)
 		return
 	}
 
 	resp, err := a.service.HandleTokenHook(r.Context(), req)
+	if errors.Is(err, ErrTenantNotAllowed) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		a.logger.Errorw("token hook: service error", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -52,16 +125,21 @@ func (a *API) tokenHook(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *API) registration(w http.ResponseWriter, r *http.Request) {
-	var identity KratosIdentity
-	if err := json.NewDecoder(r.Body).Decode(&identity); err != nil {
-		a.logger.Errorw("registration: invalid request body", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	version, ok := a.negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	identity, err := a.decodeIdentity(r, version)
+	if err != nil {
+		a.logger.Errorw("registration: invalid request body", "version", version, "error", err)
+		http.Error(w, "Invalid request body: "+errorMessage(err), http.StatusBadRequest)
 		return
 	}
 
 	a.logger.Debugw("received registration webhook", "identity_id", identity.ID, "email", identity.Email)
 
-	if err := a.service.HandleRegistration(r.Context(), identity.ID, identity.Email); err != nil {
+	if err := a.service.HandleRegistration(r.Context(), identity); err != nil {
 		a.logger.Errorw("registration: service error",
 			"identity_id", identity.ID,
 			"email", identity.Email,
@@ -73,3 +151,179 @@ func (a *API) registration(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (a *API) login(w http.ResponseWriter, r *http.Request) {
+	version, ok := a.negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	identity, err := a.decodeIdentity(r, version)
+	if err != nil {
+		a.logger.Errorw("login: invalid request body", "version", version, "error", err)
+		http.Error(w, "Invalid request body: "+errorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	a.logger.Debugw("received login webhook", "identity_id", identity.ID)
+
+	err = a.service.HandleLogin(r.Context(), identity.ID)
+	if errors.Is(err, ErrTenantsDisabled) {
+		// Kratos shows the message and interrupts the login
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		resp := NewKratosError(LoginBlockedMessageID, "Your organization is suspended.", map[string]any{"reason": "tenants_disabled"})
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			a.logger.Errorw("login: response encoding error", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		a.logger.Errorw("login: service error", "identity_id", identity.ID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *API) identityDeleted(w http.ResponseWriter, r *http.Request) {
+	version, ok := a.negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	identity, err := a.decodeIdentity(r, version)
+	if err != nil {
+		a.logger.Errorw("identity deleted: invalid request body", "version", version, "error", err)
+		http.Error(w, "Invalid request body: "+errorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	a.logger.Debugw("received identity deleted webhook", "identity_id", identity.ID)
+
+	if err := a.service.HandleIdentityDeleted(r.Context(), identity.ID); err != nil {
+		a.logger.Errorw("identity deleted: service error", "identity_id", identity.ID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *API) consent(w http.ResponseWriter, r *http.Request) {
+	if _, ok := a.negotiate(w, r); !ok {
+		return
+	}
+
+	// the consent requests of Hydra hold many fields, the unknown ones are
+	// ignored even in strict mode
+	req := new(ConsentRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		a.logger.Errorw("consent: invalid request body", "error", err)
+		http.Error(w, "Invalid request body: "+errorMessage(err), http.StatusBadRequest)
+		return
+	}
+	if req.Subject == "" {
+		http.Error(w, "Invalid request body: subject is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := a.service.HandleConsent(r.Context(), req)
+	if errors.Is(err, ErrTenantNotAllowed) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		a.logger.Errorw("consent: service error", "user_id", req.Subject, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		a.logger.Errorw("consent: response encoding error", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// negotiate returns the schema version of the body of r, named by its
+// VersionHeader or the default one, and sets it on the response. An
+// unsupported version is answered with 400.
+func (a *API) negotiate(w http.ResponseWriter, r *http.Request) (Version, bool) {
+	version := a.version
+	if h := r.Header.Get(VersionHeader); h != "" {
+		v, err := ParseVersion(h)
+		if err != nil {
+			a.logger.Warnw("webhook: unsupported version", "path", r.URL.Path, "version", h)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return "", false
+		}
+		version = v
+	}
+
+	w.Header().Set(VersionHeader, string(version))
+	return version, true
+}
+
+// decodeTokenHook reads the token hook body of r with the given schema
+// version.
+func (a *API) decodeTokenHook(r *http.Request, version Version) (*oauth2.TokenHookRequest, error) {
+	if version == V0 {
+		req := new(oauth2.TokenHookRequest)
+		return req, a.decode(r.Body, req)
+	}
+
+	req := new(TokenHookRequestV1)
+	if err := a.decode(r.Body, req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &oauth2.TokenHookRequest{Session: req.Session, Request: *req.Request}, nil
+}
+
+// decodeIdentity reads the Kratos identity of the body of r with the given
+// schema version. The identity keeps every field of the body in Extra, so
+// that v0 has no unknown field.
+func (a *API) decodeIdentity(r *http.Request, version Version) (*KratosIdentity, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == V1 {
+		req := new(IdentityRequestV1)
+		if err := a.decode(bytes.NewReader(body), req); err != nil {
+			return nil, err
+		}
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	identity := new(KratosIdentity)
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// decode reads the JSON body r into v, rejecting unknown fields in strict mode.
+func (a *API) decode(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	if a.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+// errorMessage returns the message of the validation errors, without their
+// gRPC code, or err as is.
+func errorMessage(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return st.Message()
+	}
+	return err.Error()
+}
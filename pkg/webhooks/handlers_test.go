@@ -5,12 +5,15 @@ package webhooks
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/ory/hydra/v2/oauth2"
@@ -25,6 +28,7 @@ import (
 func TestAPI_TokenHook(t *testing.T) {
 	tests := []struct {
 		name           string
+		strictJSON     bool
 		requestBody    interface{}
 		setupMocks     func(*MockServiceInterface, *MockLoggerInterface)
 		expectedStatus int
@@ -68,6 +72,27 @@ func TestAPI_TokenHook(t *testing.T) {
 			setupMocks:     func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:        "unknown field ignored",
+			requestBody: `{"session":{},"tennant_id":"tenant-1"}`,
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().HandleTokenHook(gomock.Any(), gomock.Any()).Return(&TokenHookResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown field rejected in strict mode",
+			strictJSON:     true,
+			requestBody:    `{"session":{},"tennant_id":"tenant-1"}`,
+			setupMocks:     func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, resp *http.Response) {
+				body, _ := io.ReadAll(resp.Body)
+				if !bytes.Contains(body, []byte(`unknown field "tennant_id"`)) {
+					t.Errorf("expected the unknown field to be named, got %s", body)
+				}
+			},
+		},
 		{
 			name: "service error",
 			requestBody: &oauth2.TokenHookRequest{
@@ -78,6 +103,16 @@ func TestAPI_TokenHook(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "tenant not allowed",
+			requestBody: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession("user-123"),
+			},
+			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
+				mockSvc.EXPECT().HandleTokenHook(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: not a member", ErrTenantNotAllowed))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,7 +124,7 @@ func TestAPI_TokenHook(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			setupLoggerMock(ctrl, mockLogger)
 
-			api := NewAPI(mockService, mockLogger)
+			api := NewAPI(mockService, tt.strictJSON, mockLogger)
 
 			var body []byte
 			var err error
@@ -140,7 +175,7 @@ func TestAPI_Registration(t *testing.T) {
 				Email: "user@example.com",
 			},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().HandleRegistration(gomock.Any(), "identity-123", "user@example.com").Return(nil)
+				mockSvc.EXPECT().HandleRegistration(gomock.Any(), &KratosIdentity{ID: "identity-123", Email: "user@example.com", Extra: map[string]interface{}{"user_id": "identity-123", "email": "user@example.com"}}).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -157,7 +192,7 @@ func TestAPI_Registration(t *testing.T) {
 				Email: "error@example.com",
 			},
 			setupMocks: func(mockSvc *MockServiceInterface, mockLogger *MockLoggerInterface) {
-				mockSvc.EXPECT().HandleRegistration(gomock.Any(), "identity-456", "error@example.com").Return(errors.New("service error"))
+				mockSvc.EXPECT().HandleRegistration(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -172,7 +207,7 @@ func TestAPI_Registration(t *testing.T) {
 			mockLogger := NewMockLoggerInterface(ctrl)
 			setupLoggerMock(ctrl, mockLogger)
 
-			api := NewAPI(mockService, mockLogger)
+			api := NewAPI(mockService, false, mockLogger)
 
 			var body []byte
 			var err error
@@ -204,3 +239,370 @@ func TestAPI_Registration(t *testing.T) {
 		})
 	}
 }
+
+func TestAPI_Login(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*MockServiceInterface)
+		expectedStatus int
+		expectedID     int
+	}{
+		{
+			name: "allowed",
+			body: `{"user_id":"identity-123"}`,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleLogin(gomock.Any(), "identity-123").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "tenants disabled",
+			body: `{"user_id":"identity-123"}`,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleLogin(gomock.Any(), "identity-123").Return(ErrTenantsDisabled)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedID:     LoginBlockedMessageID,
+		},
+		{
+			name: "service error",
+			body: `{"user_id":"identity-123"}`,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleLogin(gomock.Any(), "identity-123").Return(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid body",
+			body:           `{`,
+			setupMocks:     func(mockSvc *MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := NewMockServiceInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			tt.setupMocks(mockService)
+
+			api := NewAPI(mockService, false, mockLogger)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v0/webhooks/login", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			mux := chi.NewMux()
+			api.RegisterEndpoints(mux)
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedID == 0 {
+				return
+			}
+
+			var resp KratosError
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Messages) != 1 || resp.Messages[0].InstancePtr != "#/" || len(resp.Messages[0].Messages) != 1 {
+				t.Fatalf("expected a single flow message, got %+v", resp)
+			}
+			if m := resp.Messages[0].Messages[0]; m.ID != tt.expectedID || m.Type != "error" {
+				t.Errorf("expected error message %d, got %+v", tt.expectedID, m)
+			}
+		})
+	}
+}
+
+func TestAPI_IdentityDeleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		secrets        []string
+		secret         string
+		setupMocks     func(*MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name:    "success",
+			secrets: []string{"secret"},
+			secret:  "secret",
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleIdentityDeleted(gomock.Any(), "identity-123").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "service error",
+			secrets: []string{"secret"},
+			secret:  "secret",
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleIdentityDeleted(gomock.Any(), "identity-123").Return(errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "not served without secrets",
+			setupMocks:     func(mockSvc *MockServiceInterface) {},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := NewMockServiceInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			tt.setupMocks(mockService)
+
+			api := NewAPI(mockService, false, mockLogger)
+			if len(tt.secrets) > 0 {
+				api.SetVerifiers(nil, nil, nil, NewSecretVerifier("identity_deleted", tt.secrets, 5*time.Minute, mockLogger), nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v0/webhooks/identity-deleted", bytes.NewBufferString(`{"user_id":"identity-123"}`))
+			req.Header.Set(SecretHeader, tt.secret)
+			w := httptest.NewRecorder()
+
+			mux := chi.NewMux()
+			api.RegisterEndpoints(mux)
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAPI_Consent(t *testing.T) {
+	body := `{"challenge":"challenge-1","subject":"user-123","client":{"client_id":"client-1"},"requested_scope":["openid","billing"],"tenant_id":"tenant-1"}`
+
+	tests := []struct {
+		name           string
+		secrets        []string
+		body           string
+		setupMocks     func(*MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name:    "success",
+			secrets: []string{"secret"},
+			body:    body,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleConsent(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *ConsentRequest) (*ConsentResponse, error) {
+						if req.Subject != "user-123" || req.Client.ClientID != "client-1" || req.TenantID != "tenant-1" || len(req.RequestedScope) != 2 {
+							t.Errorf("unexpected consent request %+v", req)
+						}
+						return &ConsentResponse{GrantScope: []string{"openid"}}, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "tenant not allowed",
+			secrets: []string{"secret"},
+			body:    body,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleConsent(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: not a member", ErrTenantNotAllowed))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:    "service error",
+			secrets: []string{"secret"},
+			body:    body,
+			setupMocks: func(mockSvc *MockServiceInterface) {
+				mockSvc.EXPECT().HandleConsent(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "no subject",
+			secrets:        []string{"secret"},
+			body:           `{"requested_scope":["openid"]}`,
+			setupMocks:     func(mockSvc *MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not served without secrets",
+			body:           body,
+			setupMocks:     func(mockSvc *MockServiceInterface) {},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := NewMockServiceInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			tt.setupMocks(mockService)
+
+			// unknown fields of the Hydra consent request are ignored in strict mode
+			api := NewAPI(mockService, true, mockLogger)
+			if len(tt.secrets) > 0 {
+				api.SetVerifiers(nil, nil, nil, nil, NewSecretVerifier("consent", tt.secrets, 5*time.Minute, mockLogger))
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v0/webhooks/consent", bytes.NewBufferString(tt.body))
+			req.Header.Set(SecretHeader, "secret")
+			w := httptest.NewRecorder()
+
+			mux := chi.NewMux()
+			api.RegisterEndpoints(mux)
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAPI_Versions(t *testing.T) {
+	const identityID = "9f8d2c4e-1b7a-4c3e-8f6d-2a5b9c0e1d3f"
+
+	tests := []struct {
+		name            string
+		path            string
+		version         string
+		defaultVersion  Version
+		strictJSON      bool
+		body            string
+		setupMocks      func(*MockServiceInterface)
+		expectedStatus  int
+		expectedVersion string
+	}{
+		{
+			name:            "v0 by default",
+			path:            "/api/v0/webhooks/registration",
+			body:            `{"user_id": "identity-123"}`,
+			setupMocks:      func(s *MockServiceInterface) { s.EXPECT().HandleRegistration(gomock.Any(), gomock.Any()).Return(nil) },
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v0",
+		},
+		{
+			name:    "v1 registration",
+			path:    "/api/v0/webhooks/registration",
+			version: "v1",
+			body:    `{"user_id": "` + identityID + `", "email": "user@example.com", "transient_payload": {"invited": true}}`,
+			setupMocks: func(s *MockServiceInterface) {
+				s.EXPECT().HandleRegistration(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, identity *KratosIdentity) error {
+						if identity.ID != identityID || identity.Email != "user@example.com" || identity.Extra["transient_payload"] == nil {
+							return errors.New("unexpected identity")
+						}
+						return nil
+					},
+				)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v1 by configuration",
+			path:            "/api/v0/webhooks/login",
+			defaultVersion:  V1,
+			body:            `{"id": "` + identityID + `"}`,
+			setupMocks:      func(*MockServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v1 invalid email",
+			path:            "/api/v0/webhooks/registration",
+			version:         "V1",
+			body:            `{"user_id": "` + identityID + `", "email": "not-an-email"}`,
+			setupMocks:      func(*MockServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v1 unknown field in strict mode",
+			path:            "/api/v0/webhooks/registration",
+			version:         "v1",
+			strictJSON:      true,
+			body:            `{"user_id": "` + identityID + `", "identity": {}}`,
+			setupMocks:      func(*MockServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedVersion: "v1",
+		},
+		{
+			name:    "v1 token hook",
+			path:    "/api/v0/webhooks/token",
+			version: "v1",
+			body:    `{"session": {"id_token": {"subject": "user-123"}}, "request": {"client_id": "client-1"}}`,
+			setupMocks: func(s *MockServiceInterface) {
+				s.EXPECT().HandleTokenHook(gomock.Any(), gomock.Any()).Return(&TokenHookResponse{}, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v1 token hook without request",
+			path:            "/api/v0/webhooks/token",
+			version:         "v1",
+			body:            `{"session": {"id_token": {"subject": "user-123"}}}`,
+			setupMocks:      func(*MockServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedVersion: "v1",
+		},
+		{
+			name:           "unsupported version",
+			path:           "/api/v0/webhooks/registration",
+			version:        "v2",
+			body:           `{"user_id": "` + identityID + `"}`,
+			setupMocks:     func(*MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := NewMockServiceInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			tt.setupMocks(mockService)
+
+			api := NewAPI(mockService, tt.strictJSON, mockLogger)
+			if tt.defaultVersion != "" {
+				api.SetVersion(tt.defaultVersion)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			if tt.version != "" {
+				req.Header.Set(VersionHeader, tt.version)
+			}
+			w := httptest.NewRecorder()
+
+			mux := chi.NewMux()
+			api.RegisterEndpoints(mux)
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if v := w.Header().Get(VersionHeader); v != tt.expectedVersion {
+				t.Errorf("expected version %q, got %q", tt.expectedVersion, v)
+			}
+		})
+	}
+}
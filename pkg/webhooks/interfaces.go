@@ -6,6 +6,8 @@ package webhooks
 import (
 	"context"
 
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/openfga"
 	"github.com/canonical/tenant-service/internal/types"
 	"github.com/ory/hydra/v2/oauth2"
 )
@@ -14,18 +16,54 @@ import (
 // It is a subset of the internal/storage interface.
 type StorageInterface interface {
 	CreateTenant(ctx context.Context, t *types.Tenant) (*types.Tenant, error)
-	AddMember(ctx context.Context, tenantID, userID, role string) (string, error)
+	GetTenantByOriginIdentityID(ctx context.Context, identityID string) (*types.Tenant, error)
+	GetMember(ctx context.Context, tenantID, userID string) (*types.Membership, error)
+	AddMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) (string, error)
 	ListActiveTenantsByUserID(ctx context.Context, userID string) ([]*types.Tenant, error)
+	ListMembershipsByUserID(ctx context.Context, userID string) ([]*types.UserMembership, error)
+	ListMembersByTenantID(ctx context.Context, tenantID string) ([]*types.Membership, error)
+	UpdateMember(ctx context.Context, tenantID, userID string, role types.MembershipRole) error
+	DeleteMember(ctx context.Context, tenantID, userID string) error
+	UpdateTenant(ctx context.Context, tenant *types.Tenant, paths []string) error
+	ListRolesByTenantID(ctx context.Context, tenantID string) ([]*types.Role, error)
+	CreateWebhookDeadLetter(ctx context.Context, kind string, payload []byte, lastError string) (*types.WebhookDeadLetter, error)
+	GetWebhookDeadLetter(ctx context.Context, id string) (*types.WebhookDeadLetter, error)
+	ListWebhookDeadLetters(ctx context.Context, kind string, limit uint64) ([]*types.WebhookDeadLetter, error)
+	RecordWebhookDeadLetterReplay(ctx context.Context, id, lastError string) error
+	DeleteWebhookDeadLetter(ctx context.Context, id string) error
+	AcceptInvites(ctx context.Context, userID string) (int64, error)
 }
 
 // AuthorizerInterface defines the authorization operations required by the webhooks package.
 // It is a subset of the internal/authorization interface.
 type AuthorizerInterface interface {
 	AssignTenantOwner(ctx context.Context, tenantID, userID string) error
+	AssignTenantAdmin(ctx context.Context, tenantID, userID string) error
+	AssignTenantMember(ctx context.Context, tenantID, userID string) error
+	RemoveTenantOwner(ctx context.Context, tenantID, userID string) error
+	RemoveTenantAdmin(ctx context.Context, tenantID, userID string) error
+	RemoveTenantMember(ctx context.Context, tenantID, userID string) error
+	UnassignRole(ctx context.Context, roleID, userID string) error
+	CheckTenantAccess(ctx context.Context, tenantID, userID, relation string, tuples ...openfga.Tuple) (bool, error)
+}
+
+// QueueInterface defines the job queue operations required by the webhooks package.
+type QueueInterface interface {
+	Handle(kind string, h jobs.Handler)
+	HandleFailure(kind string, h jobs.FailureHandler)
+	Enqueue(ctx context.Context, kind string, payload any) error
+}
+
+// EventsInterface publishes the tenant lifecycle events, see events.Publisher.
+type EventsInterface interface {
+	Publish(ctx context.Context, eventType, subject string, data any) error
 }
 
 // ServiceInterface defines the webhook service operations.
 type ServiceInterface interface {
-	HandleRegistration(ctx context.Context, identityID, email string) error
+	HandleRegistration(ctx context.Context, identity *KratosIdentity) error
 	HandleTokenHook(ctx context.Context, req *oauth2.TokenHookRequest) (*TokenHookResponse, error)
+	HandleConsent(ctx context.Context, req *ConsentRequest) (*ConsentResponse, error)
+	HandleIdentityDeleted(ctx context.Context, identityID string) error
+	HandleLogin(ctx context.Context, identityID string) error
 }
@@ -0,0 +1,182 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package webhooks
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/canonical/tenant-service/internal/clientip"
+	"github.com/canonical/tenant-service/internal/logging"
+	"github.com/canonical/tenant-service/internal/monitoring"
+)
+
+// RateLimitConfig tunes a RateLimiter, a zero Limit disables its bucket.
+type RateLimitConfig struct {
+	// Limit and Burst are the rate, per second, and the burst of the calls
+	// of all the sources.
+	Limit float64
+	Burst int
+	// SourceLimit and SourceBurst are the rate, per second, and the burst
+	// of the calls of each client IP.
+	SourceLimit float64
+	SourceBurst int
+	// Sources bounds the client IPs tracked at once.
+	Sources int
+}
+
+// RateLimiter limits the calls of the webhooks with token buckets, one
+// shared by all the sources and one per client IP. The webhooks are public
+// routes, the buckets bound what unauthenticated callers can make the
+// service do.
+//
+// At most Sources client IPs are tracked, once full the sources with a full
+// bucket are dropped and new sources only count against the global bucket
+// until some are.
+type RateLimiter struct {
+	global *rate.Limiter
+
+	limit rate.Limit
+	burst int
+	size  int
+	now   func() time.Time
+
+	mu      sync.Mutex
+	sources map[string]*rate.Limiter
+
+	monitor monitoring.MonitorInterface
+	logger  logging.LoggerInterface
+}
+
+// Middleware rejects the calls over the limits with 429 and the wait before
+// a retry in Retry-After, before they are authenticated.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source, ok := clientip.FromContext(r.Context())
+		if !ok {
+			source = remoteIP(r.RemoteAddr)
+		}
+
+		if retryAfter, scope, limited := l.limited(source); limited {
+			l.logger.Debugw("webhook: rate limited", "path", r.URL.Path, "source", source, "scope", scope)
+			l.countLimited(scope)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limited takes a token of the bucket of source and of the global bucket,
+// it reports whether one of them is empty, which one and how long until it
+// holds a token. No token is taken from a bucket when the call is limited.
+func (l *RateLimiter) limited(source string) (time.Duration, string, bool) {
+	now := l.now()
+
+	var reserved *rate.Reservation
+	if s := l.source(source, now); s != nil {
+		reserved = s.ReserveN(now, 1)
+		if delay := reserved.DelayFrom(now); delay > 0 {
+			reserved.CancelAt(now)
+			return delay, "source", true
+		}
+	}
+
+	if l.global != nil {
+		r := l.global.ReserveN(now, 1)
+		if delay := r.DelayFrom(now); delay > 0 {
+			r.CancelAt(now)
+			if reserved != nil {
+				reserved.CancelAt(now)
+			}
+			return delay, "global", true
+		}
+	}
+
+	return 0, "", false
+}
+
+// source returns the bucket of source, nil when the sources are not limited
+// or too many are tracked.
+func (l *RateLimiter) source(source string, now time.Time) *rate.Limiter {
+	if l.limit == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.sources[source]
+	if ok {
+		return s
+	}
+	if len(l.sources) >= l.size {
+		l.sweep(now)
+		if len(l.sources) >= l.size {
+			return nil
+		}
+	}
+
+	s = rate.NewLimiter(l.limit, l.burst)
+	l.sources[source] = s
+	return s
+}
+
+// sweep drops the sources with a full bucket, a new bucket is full as well.
+func (l *RateLimiter) sweep(now time.Time) {
+	for source, s := range l.sources {
+		if s.TokensAt(now) >= float64(l.burst) {
+			delete(l.sources, source)
+		}
+	}
+}
+
+func (l *RateLimiter) countLimited(scope string) {
+	operation := "webhook_rate_limited_" + scope
+	if err := l.monitor.IncrementCounter(map[string]string{"operation": operation, "role": ""}); err != nil {
+		l.logger.Warnf("failed to increment counter %s: %v", operation, err)
+	}
+}
+
+// NewRateLimiter returns a limiter of the webhook calls with the buckets of
+// config, nil when both are disabled.
+func NewRateLimiter(config RateLimitConfig, monitor monitoring.MonitorInterface, logger logging.LoggerInterface) *RateLimiter {
+	if config.Limit <= 0 && config.SourceLimit <= 0 {
+		return nil
+	}
+
+	l := new(RateLimiter)
+	if config.Limit > 0 {
+		l.global = rate.NewLimiter(rate.Limit(config.Limit), max(config.Burst, 1))
+	}
+	if config.SourceLimit > 0 {
+		l.limit = rate.Limit(config.SourceLimit)
+		l.burst = max(config.SourceBurst, 1)
+	}
+	l.size = config.Sources
+	l.now = time.Now
+	l.sources = make(map[string]*rate.Limiter)
+	l.monitor = monitor
+	l.logger = logger
+
+	return l
+}
+
+// remoteIP returns the IP of a host:port address, the address itself if it
+// has no port.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Canonical Ltd.
+// SPDX-License-Identifier: AGPL-3.0
+
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/tenant-service/internal/clientip"
+)
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	type call struct {
+		source         string
+		after          time.Duration
+		expectedStatus int
+	}
+
+	tests := []struct {
+		name     string
+		config   RateLimitConfig
+		calls    []call
+		expected map[string]int
+	}{
+		{
+			name:   "source over its burst",
+			config: RateLimitConfig{SourceLimit: 1, SourceBurst: 2, Sources: 10},
+			calls: []call{
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.1", expectedStatus: http.StatusTooManyRequests},
+				{source: "10.0.0.2", expectedStatus: http.StatusOK},
+				{source: "10.0.0.1", after: time.Second, expectedStatus: http.StatusOK},
+			},
+			expected: map[string]int{"webhook_rate_limited_source": 1},
+		},
+		{
+			name:   "sources over the global burst",
+			config: RateLimitConfig{Limit: 1, Burst: 2, SourceLimit: 1, SourceBurst: 2, Sources: 10},
+			calls: []call{
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.2", expectedStatus: http.StatusOK},
+				{source: "10.0.0.3", expectedStatus: http.StatusTooManyRequests},
+				{source: "10.0.0.3", after: time.Second, expectedStatus: http.StatusOK},
+			},
+			expected: map[string]int{"webhook_rate_limited_global": 1},
+		},
+		{
+			name:   "a limited source takes no global token",
+			config: RateLimitConfig{Limit: 1, Burst: 2, SourceLimit: 1, SourceBurst: 1, Sources: 10},
+			calls: []call{
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.1", expectedStatus: http.StatusTooManyRequests},
+				{source: "10.0.0.2", expectedStatus: http.StatusOK},
+			},
+			expected: map[string]int{"webhook_rate_limited_source": 1},
+		},
+		{
+			name:   "untracked sources only count globally",
+			config: RateLimitConfig{SourceLimit: 1, SourceBurst: 1, Sources: 1},
+			calls: []call{
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.2", expectedStatus: http.StatusOK},
+				{source: "10.0.0.2", expectedStatus: http.StatusOK},
+				{source: "10.0.0.1", expectedStatus: http.StatusTooManyRequests},
+			},
+			expected: map[string]int{"webhook_rate_limited_source": 1},
+		},
+		{
+			name:   "full buckets are swept",
+			config: RateLimitConfig{SourceLimit: 1, SourceBurst: 1, Sources: 1},
+			calls: []call{
+				{source: "10.0.0.1", expectedStatus: http.StatusOK},
+				{source: "10.0.0.2", after: time.Second, expectedStatus: http.StatusOK},
+				{source: "10.0.0.2", expectedStatus: http.StatusTooManyRequests},
+			},
+			expected: map[string]int{"webhook_rate_limited_source": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLogger := NewMockLoggerInterface(ctrl)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			counted := make(map[string]int)
+			mockMonitor.EXPECT().IncrementCounter(gomock.Any()).DoAndReturn(
+				func(tags map[string]string) error {
+					counted[tags["operation"]]++
+					return nil
+				},
+			).AnyTimes()
+
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			l := NewRateLimiter(tt.config, mockMonitor, mockLogger)
+			l.now = func() time.Time { return now }
+			handler := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+			for i, c := range tt.calls {
+				now = now.Add(c.after)
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v0/webhooks/registration", nil)
+				req = req.WithContext(clientip.WithClientIP(req.Context(), c.source))
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+
+				if rr.Code != c.expectedStatus {
+					t.Fatalf("call %d: expected status %d, got %d", i, c.expectedStatus, rr.Code)
+				}
+				if c.expectedStatus == http.StatusTooManyRequests && rr.Header().Get("Retry-After") != "1" {
+					t.Errorf("call %d: expected Retry-After 1, got %q", i, rr.Header().Get("Retry-After"))
+				}
+			}
+
+			for operation, n := range tt.expected {
+				if counted[operation] != n {
+					t.Errorf("expected %d %s, got %d", n, operation, counted[operation])
+				}
+			}
+		})
+	}
+}
+
+func TestNewRateLimiterDisabled(t *testing.T) {
+	if l := NewRateLimiter(RateLimitConfig{Burst: 10, SourceBurst: 10}, nil, nil); l != nil {
+		t.Error("expected no limiter without a rate")
+	}
+}
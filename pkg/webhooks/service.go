@@ -5,19 +5,88 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/canonical/tenant-service/internal/authorization"
+	"github.com/canonical/tenant-service/internal/db"
 	"github.com/canonical/tenant-service/internal/logging"
 	"github.com/canonical/tenant-service/internal/monitoring"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/tracing"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/events"
 	"github.com/ory/hydra/v2/oauth2"
 )
 
+const (
+	// DeadLetterRegistration and DeadLetterTokenHook are the kinds of the
+	// dead letters of the registration webhook and of the token hook.
+	DeadLetterRegistration = "registration"
+	DeadLetterTokenHook    = "token_hook"
+
+	// MaxDeadLetters caps the dead letters listed at once.
+	MaxDeadLetters = 100
+)
+
+var (
+	// ErrDeadLetterNotFound is returned when there is no dead letter with
+	// the given ID.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterNotReplayable is returned when replaying the dead letter
+	// of a token hook, the token cannot be issued again.
+	ErrDeadLetterNotReplayable = errors.New("dead letter cannot be replayed")
+)
+
+// ErrTenantNotAllowed is returned by the token hook when the token is
+// requested for a tenant the user cannot get a token for.
+var ErrTenantNotAllowed = errors.New("tenant not allowed")
+
+// ErrTenantsDisabled is returned by the login hook when every tenant of the
+// identity is disabled.
+var ErrTenantsDisabled = errors.New("all tenants of the identity are disabled")
+
+const (
+	// TenantIDClaim and TenantRoleClaim hold the tenant a token is requested
+	// for and the role of the user in it.
+	TenantIDClaim   = "tenant_id"
+	TenantRoleClaim = "tenant_role"
+)
+
+// RegistrationJob is the kind of the jobs provisioning the tenant of a
+// registered identity.
+const RegistrationJob = "registration"
+
+type registrationPayload struct {
+	IdentityID string `json:"identity_id"`
+	Email      string `json:"email"`
+}
+
+// tokenHookDeadLetter identifies a failed token hook, the request is not
+// kept since it holds the claims of the session.
+type tokenHookDeadLetter struct {
+	UserID   string `json:"user_id"`
+	ClientID string `json:"client_id"`
+}
+
 type Service struct {
+	targets      TokenTargets
+	claim        TokenClaim
+	registration RegistrationTenant
+	consent      ConsentPolicy
+	// the tenants the tokens of a client are issued for, by client ID
+	clientTenants map[string]string
+	// the registrations are provisioned right away without a queue
+	queue QueueInterface
+	// no event is published without a publisher
+	events EventsInterface
+
 	storage StorageInterface
 	authz   AuthorizerInterface
 	tracer  tracing.TracingInterface
@@ -26,6 +95,7 @@ type Service struct {
 }
 
 func NewService(
+	targets TokenTargets,
 	storage StorageInterface,
 	authz AuthorizerInterface,
 	tracer tracing.TracingInterface,
@@ -33,14 +103,65 @@ func NewService(
 	logger logging.LoggerInterface,
 ) *Service {
 	return &Service{
-		storage: storage,
-		authz:   authz,
-		tracer:  tracer,
-		monitor: monitor,
-		logger:  logger,
+		targets:      targets,
+		claim:        TokenClaim{Name: DefaultClaimName, Format: ClaimFormatIDs},
+		registration: defaultRegistrationTenant(),
+		storage:      storage,
+		authz:        authz,
+		tracer:       tracer,
+		monitor:      monitor,
+		logger:       logger,
 	}
 }
 
+// SetQueue provisions the registrations from the jobs of queue, so that the
+// webhook returns once the registration is persisted and the failures are
+// retried. The jobs out of attempts are kept as dead letters.
+func (s *Service) SetQueue(queue QueueInterface) {
+	s.queue = queue
+	queue.Handle(RegistrationJob, s.provisionJob)
+	queue.HandleFailure(RegistrationJob, s.provisionFailed)
+}
+
+// SetTokenClaim shapes the tenant claim added by the token hook, a list of
+// the tenant IDs under DefaultClaimName by default.
+func (s *Service) SetTokenClaim(claim TokenClaim) {
+	s.claim = claim
+}
+
+// SetRegistrationTenant shapes the tenants provisioned for the
+// registrations, disabled tenants named after the email of their owner by
+// default.
+func (s *Service) SetRegistrationTenant(r RegistrationTenant) {
+	s.registration = r
+}
+
+// SetConsentPolicy restricts the scopes and audiences granted at consent to
+// the members holding a role, none is restricted by default.
+func (s *Service) SetConsentPolicy(p ConsentPolicy) {
+	s.consent = p
+}
+
+// SetClientTenants binds clients to a tenant, keyed by client ID, their
+// tokens only hold that tenant.
+func (s *Service) SetClientTenants(clientTenants map[string]string) {
+	s.clientTenants = clientTenants
+}
+
+// SetEvents publishes the creation of the tenants of the registrations with
+// p, in the transaction of the provisioning.
+func (s *Service) SetEvents(p EventsInterface) {
+	s.events = p
+}
+
+// publish publishes an event about subject when events are enabled.
+func (s *Service) publish(ctx context.Context, eventType, subject string, data any) error {
+	if s.events == nil {
+		return nil
+	}
+	return s.events.Publish(ctx, eventType, subject, data)
+}
+
 // recordError records an error on the span and emits a structured error log.
 // The "error" key is always appended to keysAndValues automatically.
 func (s *Service) recordError(span trace.Span, msg string, err error, keysAndValues ...interface{}) {
@@ -49,10 +170,15 @@ func (s *Service) recordError(span trace.Span, msg string, err error, keysAndVal
 	s.logger.Errorw(msg, append(keysAndValues, "error", err)...)
 }
 
-func (s *Service) HandleRegistration(ctx context.Context, identityID, email string) error {
+// HandleRegistration provisions the tenant of a registered identity, unless
+// the identity holds the skip field of the registration tenant, as the
+// identities of an invitation do. A tenant that cannot be provisioned is kept
+// as a dead letter and the registration still succeeds.
+func (s *Service) HandleRegistration(ctx context.Context, identity *KratosIdentity) error {
 	ctx, span := s.tracer.Start(ctx, "webhooks.Service.HandleRegistration")
 	defer span.End()
 
+	identityID, email := identity.ID, identity.Email
 	s.logger.Debugw("handling registration webhook", "identity_id", identityID, "email", email)
 
 	if identityID == "" {
@@ -62,18 +188,82 @@ func (s *Service) HandleRegistration(ctx context.Context, identityID, email stri
 		return err
 	}
 
-	// 1. Create a tenant named '{Email}'s Org'
-	tenantName := fmt.Sprintf("%s's Org", email)
-	if email == "" {
-		tenantName = ""
+	if s.registration.skips(identity) {
+		s.logger.Infow("tenant skipped on registration",
+			"identity_id", identityID,
+			"email", email,
+			"field", s.registration.SkipField,
+		)
+		return nil
+	}
+
+	if s.queue != nil {
+		if err := s.queue.Enqueue(ctx, RegistrationJob, registrationPayload{IdentityID: identityID, Email: email}); err != nil {
+			s.recordError(span, "failed to queue registration", err,
+				"identity_id", identityID,
+				"email", email,
+			)
+			return fmt.Errorf("failed to queue registration: %w", err)
+		}
+		s.logger.Infow("registration queued", "identity_id", identityID, "email", email)
+		return nil
+	}
+
+	if err := s.provision(ctx, span, identityID, email); err != nil {
+		// Kratos does not call the webhook again, the registration is kept
+		// for the operators to replay and the identity is not refused. It
+		// only fails when the dead letter cannot be kept either.
+		if err := s.deadLetter(ctx, DeadLetterRegistration, registrationPayload{IdentityID: identityID, Email: email}, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provisionJob provisions the tenant of a registration taken from the queue.
+func (s *Service) provisionJob(ctx context.Context, payload []byte) error {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.provisionJob")
+	defer span.End()
+
+	var p registrationPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid registration job: %w", err)
+	}
+
+	return s.provision(ctx, span, p.IdentityID, p.Email)
+}
+
+// provisionFailed keeps the registration of a job out of attempts as a
+// dead letter.
+func (s *Service) provisionFailed(ctx context.Context, payload []byte, err error) {
+	s.deadLetter(ctx, DeadLetterRegistration, json.RawMessage(payload), err)
+}
+
+// provision creates the tenant of a registered identity, which holds the
+// role of the registration tenant in it, owner by default.
+func (s *Service) provision(ctx context.Context, span trace.Span, identityID, email string) error {
+	// 1. Create the tenant, named by the registration template
+	tenantName, err := s.registration.name(identityID, email)
+	if err != nil {
+		s.recordError(span, "failed to name tenant on registration", err,
+			"identity_id", identityID,
+			"email", email,
+		)
+		return fmt.Errorf("failed to name tenant: %w", err)
 	}
 
 	tenant := &types.Tenant{
-		Name:    tenantName,
-		Enabled: false,
+		Name:             tenantName,
+		Enabled:          s.registration.enabled(email),
+		OriginIdentityID: identityID,
 	}
 
+	// a replayed dead letter or a retried job finds the tenant created for
+	// the identity, it is provisioned once the identity holds the role in it
 	newTenant, err := s.storage.CreateTenant(ctx, tenant)
+	if errors.Is(err, storage.ErrDuplicateKey) {
+		return s.reprovision(ctx, span, identityID, email)
+	}
 	if err != nil {
 		s.recordError(span, "failed to create tenant on registration", err,
 			"identity_id", identityID,
@@ -82,35 +272,297 @@ func (s *Service) HandleRegistration(ctx context.Context, identityID, email stri
 		return fmt.Errorf("failed to create tenant: %w", err)
 	}
 
-	// 2. Add the user as 'owner'
-	_, err = s.storage.AddMember(ctx, newTenant.ID, identityID, "owner")
+	return s.provisionMember(ctx, span, newTenant, identityID, email)
+}
+
+// reprovision completes the provisioning of the tenant already created for
+// the identity, which is only provisioned when the identity holds the role
+// of the registration tenant in it.
+func (s *Service) reprovision(ctx context.Context, span trace.Span, identityID, email string) error {
+	role := s.registration.Role
+
+	tenant, err := s.storage.GetTenantByOriginIdentityID(ctx, identityID)
+	if err != nil {
+		s.recordError(span, "failed to get tenant provisioned on registration", err, "identity_id", identityID)
+		return fmt.Errorf("failed to get provisioned tenant: %w", err)
+	}
+
+	member, err := s.storage.GetMember(ctx, tenant.ID, identityID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// the previous attempt failed before adding the member
+		s.logger.Infow("resuming the provisioning of the tenant on registration",
+			"tenant_id", tenant.ID,
+			"identity_id", identityID,
+		)
+		return s.provisionMember(ctx, span, tenant, identityID, email)
+	}
+	if err != nil {
+		s.recordError(span, "failed to get member provisioned on registration", err,
+			"tenant_id", tenant.ID,
+			"identity_id", identityID,
+		)
+		return fmt.Errorf("failed to get provisioned member: %w", err)
+	}
+	if member.Role != role {
+		err := fmt.Errorf("tenant %s of the identity is held as %s instead of %s", tenant.ID, member.Role, role)
+		s.recordError(span, "tenant provisioned on registration held with another role", err,
+			"tenant_id", tenant.ID,
+			"identity_id", identityID,
+		)
+		return err
+	}
+
+	// the previous attempt may have failed writing the tuple, which is
+	// written again
+	if err := s.assignRole(ctx, tenant.ID, identityID, role); err != nil {
+		s.recordError(span, "failed to assign tenant role in authz on registration", err,
+			"tenant_id", tenant.ID,
+			"identity_id", identityID,
+			"role", role,
+		)
+		return fmt.Errorf("failed to assign tenant %s in authz: %w", role, err)
+	}
+
+	s.logger.Infow("tenant already provisioned on registration",
+		"tenant_id", tenant.ID,
+		"identity_id", identityID,
+		"email", email,
+	)
+	return nil
+}
+
+// provisionMember adds the registered identity to its tenant, with the role
+// of the registration tenant, and announces them.
+func (s *Service) provisionMember(ctx context.Context, span trace.Span, newTenant *types.Tenant, identityID, email string) error {
+	role := s.registration.Role
+
+	// 2. Add the user with the registration role
+	_, err := s.storage.AddMember(ctx, newTenant.ID, identityID, role)
 	if err != nil {
-		s.recordError(span, "failed to add owner member on registration", err,
+		s.recordError(span, "failed to add member on registration", err,
 			"tenant_id", newTenant.ID,
 			"identity_id", identityID,
+			"role", role,
 		)
 		return fmt.Errorf("failed to add member: %w", err)
 	}
 
 	// 3. Call OpenFGA to write the tuple
-	err = s.authz.AssignTenantOwner(ctx, newTenant.ID, identityID)
+	err = s.assignRole(ctx, newTenant.ID, identityID, role)
 	if err != nil {
-		s.recordError(span, "failed to assign tenant owner in authz on registration", err,
+		s.recordError(span, "failed to assign tenant role in authz on registration", err,
 			"tenant_id", newTenant.ID,
 			"identity_id", identityID,
+			"role", role,
 		)
-		return fmt.Errorf("failed to assign tenant owner in authz: %w", err)
+		return fmt.Errorf("failed to assign tenant %s in authz: %w", role, err)
+	}
+
+	// 4. Announce the tenant and its member
+	if err := s.publish(ctx, events.TenantCreated, newTenant.ID, events.TenantData{TenantID: newTenant.ID, Name: newTenant.Name, Region: newTenant.Region, Enabled: newTenant.Enabled}); err != nil {
+		s.recordError(span, "failed to publish tenant event on registration", err, "tenant_id", newTenant.ID)
+		return fmt.Errorf("failed to publish tenant event: %w", err)
+	}
+	if err := s.publish(ctx, events.MemberAdded, newTenant.ID, events.MemberData{TenantID: newTenant.ID, UserID: identityID, Role: role.String()}); err != nil {
+		s.recordError(span, "failed to publish member event on registration", err, "tenant_id", newTenant.ID)
+		return fmt.Errorf("failed to publish member event: %w", err)
 	}
 
 	s.logger.Infow("tenant provisioned on registration",
 		"tenant_id", newTenant.ID,
 		"identity_id", identityID,
 		"email", email,
+		"role", role,
+		"enabled", newTenant.Enabled,
 	)
 	s.logger.Security().AdminAction(identityID, "self_registration", "webhooks.Service.HandleRegistration", newTenant.ID)
 	return nil
 }
 
+// HandleLogin rejects with ErrTenantsDisabled the login of an identity
+// whose tenants are all disabled. An identity without tenants may log in, its
+// tenant may still be provisioned. An accepted login accepts the pending
+// invitations of the identity.
+func (s *Service) HandleLogin(ctx context.Context, identityID string) error {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.HandleLogin")
+	defer span.End()
+
+	s.logger.Debugw("handling login webhook", "identity_id", identityID)
+
+	if identityID == "" {
+		err := fmt.Errorf("identity ID is empty")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, identityID)
+	if err != nil {
+		s.recordError(span, "failed to list memberships of logging in identity", err, "identity_id", identityID)
+		return fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	if len(memberships) == 0 || slices.ContainsFunc(memberships, func(m *types.UserMembership) bool { return m.Tenant.Enabled }) {
+		s.acceptInvites(ctx, identityID)
+		return nil
+	}
+
+	s.logger.Warnw("login rejected, all tenants of the identity are disabled", "identity_id", identityID, "tenants", len(memberships))
+	s.logger.Security().AuthzFailure(identityID, "login_disabled_tenants")
+	return ErrTenantsDisabled
+}
+
+// acceptInvites marks the pending invitations of an identity accepted, a
+// failure is logged rather than failing the login.
+func (s *Service) acceptInvites(ctx context.Context, identityID string) {
+	accepted, err := s.storage.AcceptInvites(ctx, identityID)
+	if err != nil {
+		s.logger.Warnw("failed to accept the invitations of logging in identity", "identity_id", identityID, "error", err)
+		return
+	}
+	if accepted > 0 {
+		s.logger.Infow("invitations accepted on login", "identity_id", identityID, "invitations", accepted)
+	}
+}
+
+// HandleIdentityDeleted removes a deleted identity from its tenants, with
+// its relations and role assignments. Its owner role in a tenant left without
+// owner goes to the oldest admin, the tenant is disabled without any, as the
+// diagnostics do for the ownerless tenants.
+func (s *Service) HandleIdentityDeleted(ctx context.Context, identityID string) error {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.HandleIdentityDeleted")
+	defer span.End()
+
+	s.logger.Debugw("handling identity deleted webhook", "identity_id", identityID)
+
+	if identityID == "" {
+		err := fmt.Errorf("identity ID is empty")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, identityID)
+	if err != nil {
+		s.recordError(span, "failed to list memberships of deleted identity", err, "identity_id", identityID)
+		return fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	for _, m := range memberships {
+		if m.Role == types.RoleOwner {
+			if err := s.handOver(ctx, m.Tenant, identityID); err != nil {
+				s.recordError(span, "failed to hand over tenant of deleted identity", err, "tenant_id", m.Tenant.ID, "identity_id", identityID)
+				return fmt.Errorf("failed to hand over tenant %s: %w", m.Tenant.ID, err)
+			}
+		}
+
+		if err := s.removeMember(ctx, m.Tenant.ID, identityID); err != nil {
+			s.recordError(span, "failed to remove deleted identity from tenant", err, "tenant_id", m.Tenant.ID, "identity_id", identityID)
+			return fmt.Errorf("failed to remove identity from tenant %s: %w", m.Tenant.ID, err)
+		}
+	}
+
+	s.logger.Infow("deleted identity removed from its tenants", "identity_id", identityID, "tenants", len(memberships))
+	s.logger.Security().AdminAction(identityID, "identity_deleted", "webhooks.Service.HandleIdentityDeleted", identityID)
+	return nil
+}
+
+// handOver makes the oldest admin the owner of a tenant the deleted identity
+// is the last owner of, or disables the tenant without any admin.
+func (s *Service) handOver(ctx context.Context, tenant *types.Tenant, identityID string) error {
+	members, err := s.storage.ListMembersByTenantID(ctx, tenant.ID)
+	if err != nil {
+		return err
+	}
+
+	var successor *types.Membership
+	for _, m := range members {
+		if m.KratosIdentityID == identityID {
+			continue
+		}
+		if m.Role == types.RoleOwner {
+			return nil
+		}
+		// the members are listed oldest first
+		if successor == nil && m.Role == types.RoleAdmin {
+			successor = m
+		}
+	}
+
+	if successor == nil {
+		if !tenant.Enabled {
+			return nil
+		}
+		if err := s.storage.UpdateTenant(ctx, &types.Tenant{ID: tenant.ID, Enabled: false}, []string{"enabled"}); err != nil {
+			return err
+		}
+		s.logger.Warnw("tenant disabled, its last owner was deleted", "tenant_id", tenant.ID, "identity_id", identityID)
+		s.logger.Security().AdminAction(identityID, "disable_ownerless_tenant", "webhooks.Service.HandleIdentityDeleted", tenant.ID)
+		return nil
+	}
+
+	if err := s.storage.UpdateMember(ctx, tenant.ID, successor.KratosIdentityID, types.RoleOwner); err != nil {
+		return err
+	}
+	if err := s.authz.AssignTenantOwner(ctx, tenant.ID, successor.KratosIdentityID); err != nil {
+		return err
+	}
+	if err := s.authz.RemoveTenantAdmin(ctx, tenant.ID, successor.KratosIdentityID); err != nil {
+		return err
+	}
+
+	s.logger.Infow("tenant ownership transferred from deleted identity",
+		"tenant_id", tenant.ID,
+		"identity_id", identityID,
+		"owner_id", successor.KratosIdentityID,
+	)
+	s.logger.Security().AdminAction(identityID, "transfer_tenant_ownership", "webhooks.Service.HandleIdentityDeleted", tenant.ID+":"+successor.KratosIdentityID)
+	return nil
+}
+
+// removeMember drops the membership of the identity with every relation and
+// role assignment it holds on the tenant, the missing ones are skipped so
+// that a retried webhook completes.
+// assignRole writes the tuple of role in the tenant for the identity.
+func (s *Service) assignRole(ctx context.Context, tenantID, identityID string, role types.MembershipRole) error {
+	switch role {
+	case types.RoleOwner:
+		return s.authz.AssignTenantOwner(ctx, tenantID, identityID)
+	case types.RoleAdmin:
+		return s.authz.AssignTenantAdmin(ctx, tenantID, identityID)
+	case types.RoleMember:
+		return s.authz.AssignTenantMember(ctx, tenantID, identityID)
+	}
+	return fmt.Errorf("unknown role %q", role)
+}
+
+func (s *Service) removeMember(ctx context.Context, tenantID, identityID string) error {
+	if err := s.storage.DeleteMember(ctx, tenantID, identityID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	for _, remove := range []func(context.Context, string, string) error{
+		s.authz.RemoveTenantOwner,
+		s.authz.RemoveTenantAdmin,
+		s.authz.RemoveTenantMember,
+	} {
+		if err := remove(ctx, tenantID, identityID); err != nil {
+			return err
+		}
+	}
+
+	roles, err := s.storage.ListRolesByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if err := s.authz.UnassignRole(ctx, r.ID, identityID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Service) HandleTokenHook(ctx context.Context, req *oauth2.TokenHookRequest) (*TokenHookResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "webhooks.Service.HandleTokenHook")
 	defer span.End()
@@ -129,35 +581,317 @@ func (s *Service) HandleTokenHook(ctx context.Context, req *oauth2.TokenHookRequ
 		return nil, err
 	}
 
+	resp, err := s.tokenClaims(ctx, span, req, userID)
+	if err != nil && !errors.Is(err, ErrTenantNotAllowed) {
+		// Hydra refuses the token, the failure is kept for the operators
+		s.deadLetter(ctx, DeadLetterTokenHook, tokenHookDeadLetter{UserID: userID, ClientID: req.Request.ClientID}, err)
+	}
+	return resp, err
+}
+
+// tokenClaims returns the tenant claims of the tokens of the user.
+func (s *Service) tokenClaims(ctx context.Context, span trace.Span, req *oauth2.TokenHookRequest, userID string) (*TokenHookResponse, error) {
+	tenantID, err := s.requestedTenant(req)
+	if err != nil {
+		s.recordError(span, "token hook tenant hint rejected", err, "user_id", userID, "client_id", req.Request.ClientID)
+		return nil, err
+	}
+	if tenantID != "" {
+		return s.tenantToken(ctx, span, userID, tenantID)
+	}
+
 	// Fetch Tenants
-	tenants, err := s.storage.ListActiveTenantsByUserID(ctx, userID)
+	memberships, err := s.activeMemberships(ctx, userID)
 	if err != nil {
 		s.recordError(span, "failed to list tenants for token hook", err, "user_id", userID)
 		return nil, fmt.Errorf("failed to list tenants: %w", err)
 	}
 
 	// Format Response
-	tenantList := make([]string, 0, len(tenants))
-	for _, t := range tenants {
+	tenantList := make([]string, 0, len(memberships))
+	tenantRoles := make(map[string]string, len(memberships))
+	for _, m := range memberships {
+		t := m.Tenant
+		if s.claim.MaxTenants > 0 && len(tenantList) == s.claim.MaxTenants {
+			s.logger.Warnw("token hook tenants truncated", "user_id", userID, "max_tenants", s.claim.MaxTenants)
+			break
+		}
+
+		// the membership was just read from the database, pass it as a contextual
+		// tuple so a tenant joined before its tuple is written stays in the token
+		membership := openfga.NewTuple(authorization.UserTuple(userID), authorization.MEMBER_RELATION, authorization.TenantTuple(t.ID))
+		allowed, err := s.authz.CheckTenantAccess(ctx, t.ID, userID, authorization.CAN_VIEW_PERMISSION, *membership)
+		if err != nil {
+			s.recordError(span, "failed to check tenant access for token hook", err, "user_id", userID, "tenant_id", t.ID)
+			return nil, fmt.Errorf("failed to check tenant access: %w", err)
+		}
+		if !allowed {
+			s.logger.Warnw("token hook tenant dropped, access denied", "user_id", userID, "tenant_id", t.ID)
+			continue
+		}
 		tenantList = append(tenantList, t.ID)
+		tenantRoles[t.ID] = string(m.Role)
 	}
 
 	s.logger.Debugw("token hook tenants resolved", "user_id", userID, "tenant_count", len(tenantList))
 
-	resp := TokenHookResponse{
-		Session: struct {
-			IDToken     map[string]interface{} `json:"id_token,omitempty"`
-			AccessToken map[string]interface{} `json:"access_token,omitempty"`
-		}{
-			IDToken:     map[string]interface{}{},
-			AccessToken: map[string]interface{}{},
-		},
+	resp := s.newTokenHookResponse()
+	if len(tenantList) == 0 {
+		return resp, nil
 	}
 
-	if len(tenantList) > 0 {
-		resp.Session.IDToken["tenants"] = tenantList
-		resp.Session.AccessToken["tenants"] = tenantList
+	var claim interface{} = tenantList
+	if s.claim.Format == ClaimFormatRoles {
+		claim = tenantRoles
 	}
+	s.addClaim(resp, s.claim.Name, claim)
+
+	return resp, nil
+}
 
-	return &resp, nil
+// HandleConsent returns the requested scopes and audiences the memberships
+// of the user allow, overall and for each of the active tenants of the user.
+// With a tenant, picked by the user or bound to the client, only the role of
+// the user in that tenant counts.
+func (s *Service) HandleConsent(ctx context.Context, req *ConsentRequest) (*ConsentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.HandleConsent")
+	defer span.End()
+
+	userID := req.Subject
+	s.logger.Debugw("handling consent", "user_id", userID, "client_id", req.Client.ClientID)
+
+	if userID == "" {
+		err := fmt.Errorf("could not identify user from request")
+		s.recordError(span, "consent request missing user subject", err)
+		return nil, err
+	}
+
+	tenantID, err := s.clientTenant(req.Client.ClientID, req.TenantID)
+	if err != nil {
+		s.recordError(span, "consent tenant rejected", err, "user_id", userID, "client_id", req.Client.ClientID)
+		return nil, err
+	}
+
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		s.recordError(span, "failed to list memberships for consent", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	resp := &ConsentResponse{Tenants: []*ConsentTenant{}}
+	var best types.MembershipRole
+	for _, m := range memberships {
+		t := m.Tenant
+		if !t.Enabled || (tenantID != "" && t.ID != tenantID) {
+			continue
+		}
+
+		membership := openfga.NewTuple(authorization.UserTuple(userID), authorization.MEMBER_RELATION, authorization.TenantTuple(t.ID))
+		allowed, err := s.authz.CheckTenantAccess(ctx, t.ID, userID, authorization.CAN_VIEW_PERMISSION, *membership)
+		if err != nil {
+			s.recordError(span, "failed to check tenant access for consent", err, "user_id", userID, "tenant_id", t.ID)
+			return nil, fmt.Errorf("failed to check tenant access: %w", err)
+		}
+		if !allowed {
+			s.logger.Warnw("consent tenant dropped, access denied", "user_id", userID, "tenant_id", t.ID)
+			continue
+		}
+
+		scopes, audiences := s.consent.grant(req.RequestedScope, req.RequestedAudience, m.Role)
+		resp.Tenants = append(resp.Tenants, &ConsentTenant{
+			ID:            t.ID,
+			Name:          t.Name,
+			Role:          string(m.Role),
+			GrantScope:    scopes,
+			GrantAudience: audiences,
+		})
+		if roleLevels[m.Role] > roleLevels[best] {
+			best = m.Role
+		}
+	}
+
+	if tenantID != "" && len(resp.Tenants) == 0 {
+		s.logger.Security().AuthzFailure(userID, "consent_tenant:"+tenantID)
+		return nil, fmt.Errorf("%w: user %s is not an active member of tenant %s", ErrTenantNotAllowed, userID, tenantID)
+	}
+
+	// a role grants what the lower ones do, the best one grants them all
+	resp.GrantScope, resp.GrantAudience = s.consent.grant(req.RequestedScope, req.RequestedAudience, best)
+
+	s.logger.Debugw("consent resolved", "user_id", userID, "tenant_count", len(resp.Tenants), "scope_count", len(resp.GrantScope))
+	return resp, nil
+}
+
+// ListDeadLetters returns the dead letters of the given kind, all of them
+// when kind is empty, oldest first and at most MaxDeadLetters of them.
+func (s *Service) ListDeadLetters(ctx context.Context, kind string, limit int) ([]*types.WebhookDeadLetter, error) {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.ListDeadLetters")
+	defer span.End()
+
+	if limit <= 0 || limit > MaxDeadLetters {
+		limit = MaxDeadLetters
+	}
+
+	letters, err := s.storage.ListWebhookDeadLetters(ctx, kind, uint64(limit))
+	if err != nil {
+		s.recordError(span, "failed to list dead letters", err, "kind", kind)
+		return nil, err
+	}
+	return letters, nil
+}
+
+// ReplayDeadLetter processes a dead letter again and deletes it once it
+// succeeds, a failed replay is counted on the dead letter. Only the
+// registrations can be replayed.
+func (s *Service) ReplayDeadLetter(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "webhooks.Service.ReplayDeadLetter")
+	defer span.End()
+
+	letter, err := s.storage.GetWebhookDeadLetter(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrDeadLetterNotFound
+	}
+	if err != nil {
+		s.recordError(span, "failed to get dead letter", err, "dead_letter_id", id)
+		return err
+	}
+	if letter.Kind != DeadLetterRegistration {
+		return fmt.Errorf("%w: %s dead letters are only kept for inspection", ErrDeadLetterNotReplayable, letter.Kind)
+	}
+
+	var p registrationPayload
+	if err := json.Unmarshal(letter.Payload, &p); err != nil {
+		return fmt.Errorf("invalid registration dead letter: %w", err)
+	}
+
+	if err := s.provision(ctx, span, p.IdentityID, p.Email); err != nil {
+		// the transaction of ctx is rolled back with the failed replay
+		if err := s.storage.RecordWebhookDeadLetterReplay(db.WithoutTx(ctx), id, err.Error()); err != nil {
+			s.logger.Errorw("failed to record dead letter replay", "dead_letter_id", id, "error", err)
+		}
+		return err
+	}
+
+	if err := s.storage.DeleteWebhookDeadLetter(ctx, id); err != nil {
+		s.recordError(span, "failed to delete replayed dead letter", err, "dead_letter_id", id)
+		return err
+	}
+
+	s.logger.Infow("dead letter replayed", "dead_letter_id", id, "kind", letter.Kind, "identity_id", p.IdentityID)
+	return nil
+}
+
+// deadLetter keeps the payload of a webhook call whose processing failed
+// for good, outside of the transaction of ctx which is rolled back. It
+// returns cause when the dead letter cannot be kept.
+func (s *Service) deadLetter(ctx context.Context, kind string, payload any, cause error) error {
+	data, err := json.Marshal(payload)
+	if err == nil {
+		_, err = s.storage.CreateWebhookDeadLetter(db.WithoutTx(ctx), kind, data, cause.Error())
+	}
+	if err != nil {
+		s.logger.Errorw("failed to keep dead letter", "kind", kind, "cause", cause, "error", err)
+		return fmt.Errorf("%w, failed to keep dead letter: %v", cause, err)
+	}
+	s.logger.Warnw("webhook call kept as a dead letter", "kind", kind, "error", cause)
+	return nil
+}
+
+// requestedTenant returns the tenant the token is requested for, the tenant
+// the client is bound to or else the tenant_id picked at consent, empty
+// without either.
+func (s *Service) requestedTenant(req *oauth2.TokenHookRequest) (string, error) {
+	var requested string
+	if req.Session != nil {
+		requested, _ = req.Session.Extra[TenantIDClaim].(string)
+	}
+
+	return s.clientTenant(req.Request.ClientID, requested)
+}
+
+// clientTenant returns the tenant the client is bound to or else the
+// requested one, a client bound to another tenant is not allowed.
+func (s *Service) clientTenant(clientID, requested string) (string, error) {
+	bound := s.clientTenants[clientID]
+	if bound == "" {
+		return requested, nil
+	}
+	if requested != "" && requested != bound {
+		return "", fmt.Errorf("%w: tenant %s requested with client %s bound to tenant %s", ErrTenantNotAllowed, requested, clientID, bound)
+	}
+	return bound, nil
+}
+
+// tenantToken returns the claims of a token requested for a single tenant,
+// the user must be an active member of it.
+func (s *Service) tenantToken(ctx context.Context, span trace.Span, userID, tenantID string) (*TokenHookResponse, error) {
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		s.recordError(span, "failed to list memberships for token hook", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	i := slices.IndexFunc(memberships, func(m *types.UserMembership) bool { return m.Tenant.ID == tenantID && m.Tenant.Enabled })
+	if i < 0 {
+		s.logger.Security().AuthzFailure(userID, "token_hook_tenant:"+tenantID)
+		return nil, fmt.Errorf("%w: user %s is not an active member of tenant %s", ErrTenantNotAllowed, userID, tenantID)
+	}
+
+	membership := openfga.NewTuple(authorization.UserTuple(userID), authorization.MEMBER_RELATION, authorization.TenantTuple(tenantID))
+	allowed, err := s.authz.CheckTenantAccess(ctx, tenantID, userID, authorization.CAN_VIEW_PERMISSION, *membership)
+	if err != nil {
+		s.recordError(span, "failed to check tenant access for token hook", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, fmt.Errorf("failed to check tenant access: %w", err)
+	}
+	if !allowed {
+		s.logger.Security().AuthzFailure(userID, "token_hook_tenant:"+tenantID)
+		return nil, fmt.Errorf("%w: user %s cannot view tenant %s", ErrTenantNotAllowed, userID, tenantID)
+	}
+
+	s.logger.Debugw("token hook tenant selected", "user_id", userID, "tenant_id", tenantID)
+
+	resp := s.newTokenHookResponse()
+	s.addClaim(resp, TenantIDClaim, tenantID)
+	s.addClaim(resp, TenantRoleClaim, string(memberships[i].Role))
+	return resp, nil
+}
+
+func (s *Service) newTokenHookResponse() *TokenHookResponse {
+	resp := new(TokenHookResponse)
+	resp.Session.IDToken = map[string]interface{}{}
+	resp.Session.AccessToken = map[string]interface{}{}
+	return resp
+}
+
+// addClaim sets a claim of the tokens targeted by the hook.
+func (s *Service) addClaim(resp *TokenHookResponse, name string, value interface{}) {
+	if s.targets.IDToken {
+		resp.Session.IDToken[name] = value
+	}
+	if s.targets.AccessToken {
+		resp.Session.AccessToken[name] = value
+	}
+}
+
+// activeMemberships returns the memberships of the user in the enabled
+// tenants, their role is only read for the roles claim.
+func (s *Service) activeMemberships(ctx context.Context, userID string) ([]*types.UserMembership, error) {
+	if s.claim.Format != ClaimFormatRoles {
+		tenants, err := s.storage.ListActiveTenantsByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		memberships := make([]*types.UserMembership, 0, len(tenants))
+		for _, t := range tenants {
+			memberships = append(memberships, &types.UserMembership{Tenant: t})
+		}
+		return memberships, nil
+	}
+
+	memberships, err := s.storage.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(memberships, func(m *types.UserMembership) bool { return !m.Tenant.Enabled }), nil
 }
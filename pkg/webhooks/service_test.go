@@ -5,10 +5,18 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/canonical/tenant-service/internal/jobs"
+	"github.com/canonical/tenant-service/internal/openfga"
+	"github.com/canonical/tenant-service/internal/storage"
 	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/pkg/events"
 	"github.com/ory/hydra/v2/oauth2"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
@@ -29,6 +37,7 @@ func setupLoggerMock(ctrl *gomock.Controller, mockLogger *MockLoggerInterface) *
 	mockLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Security().Return(mockSecurityLogger).AnyTimes()
 	mockSecurityLogger.EXPECT().AdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockSecurityLogger.EXPECT().AuthzFailure(gomock.Any(), gomock.Any()).AnyTimes()
 	return mockSecurityLogger
 }
 
@@ -57,9 +66,12 @@ func TestService_HandleRegistration(t *testing.T) {
 						if t.Enabled {
 							return nil, errors.New("tenant should start disabled")
 						}
+						if t.OriginIdentityID != identityID {
+							return nil, errors.New("tenant should record the registered identity")
+						}
 						return tenant, nil
 					})
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, "owner").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, types.RoleOwner).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, identityID).Return(nil)
 			},
 			expectedErr: false,
@@ -76,7 +88,19 @@ func TestService_HandleRegistration(t *testing.T) {
 						}
 						return tenant, nil
 					})
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, "owner").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, types.RoleOwner).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, identityID).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:       "success - retry of a provisioned identity",
+			identityID: identityID,
+			email:      email,
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, storage.ErrDuplicateKey)
+				mockStorage.EXPECT().GetTenantByOriginIdentityID(gomock.Any(), identityID).Return(tenant, nil)
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenant.ID, identityID).Return(&types.Membership{TenantID: tenant.ID, KratosIdentityID: identityID, Role: types.RoleOwner}, nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, identityID).Return(nil)
 			},
 			expectedErr: false,
@@ -90,23 +114,25 @@ func TestService_HandleRegistration(t *testing.T) {
 			expectedErr: true,
 		},
 		{
-			name:       "error - failed to create tenant",
+			name:       "dead letter - failed to create tenant",
 			identityID: identityID,
 			email:      email,
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
+				mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterRegistration, []byte(`{"identity_id":"identity-123","email":"user@example.com"}`), gomock.Any()).Return(&types.WebhookDeadLetter{}, nil)
 			},
-			expectedErr: true,
+			expectedErr: false,
 		},
 		{
-			name:       "error - failed to add member",
+			name:       "dead letter - failed to add member",
 			identityID: identityID,
 			email:      email,
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, "owner").Return("", errors.New("storage error"))
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, types.RoleOwner).Return("", errors.New("storage error"))
+				mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterRegistration, gomock.Any(), gomock.Any()).Return(&types.WebhookDeadLetter{}, nil)
 			},
-			expectedErr: true,
+			expectedErr: false,
 		},
 		{
 			name:       "error - failed to assign authz",
@@ -114,8 +140,10 @@ func TestService_HandleRegistration(t *testing.T) {
 			email:      email,
 			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
-				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, "owner").Return("member-id", nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, identityID, types.RoleOwner).Return("member-id", nil)
 				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, identityID).Return(errors.New("authz error"))
+				// failing to keep the dead letter still fails the registration
+				mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterRegistration, gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
 			},
 			expectedErr: true,
 		},
@@ -133,13 +161,13 @@ func TestService_HandleRegistration(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s := NewService(TokenTargets{IDToken: true, AccessToken: true}, mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "webhooks.Service.HandleRegistration").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
 			tc.setupMocks(mockStorage, mockAuthz, mockLogger)
 
-			err := s.HandleRegistration(context.Background(), tc.identityID, tc.email)
+			err := s.HandleRegistration(context.Background(), &KratosIdentity{ID: tc.identityID, Email: tc.email})
 
 			if tc.expectedErr {
 				if err == nil {
@@ -152,6 +180,557 @@ func TestService_HandleRegistration(t *testing.T) {
 	}
 }
 
+func TestService_HandleRegistrationEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockAuthz := NewMockAuthorizerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+	mockEvents := NewMockEventsInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+	s.SetEvents(mockEvents)
+
+	tenant := &types.Tenant{ID: "tenant-123", Name: "user@example.com's Org"}
+	mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
+	mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, "identity-123", types.RoleOwner).Return("member-id", nil)
+	mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "identity-123").Return(nil)
+	gomock.InOrder(
+		mockEvents.EXPECT().Publish(gomock.Any(), events.TenantCreated, tenant.ID, events.TenantData{TenantID: tenant.ID, Name: tenant.Name}).Return(nil),
+		mockEvents.EXPECT().Publish(gomock.Any(), events.MemberAdded, tenant.ID, events.MemberData{TenantID: tenant.ID, UserID: "identity-123", Role: "owner"}).Return(nil),
+	)
+
+	if err := s.HandleRegistration(context.Background(), &KratosIdentity{ID: "identity-123", Email: "user@example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestService_HandleRegistrationTenant(t *testing.T) {
+	registration, err := ParseRegistrationTenant("{{.EmailLocalPart}}'s workspace", false, []string{"Example.com"}, "member", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		email           string
+		expectedName    string
+		expectedEnabled bool
+	}{
+		{name: "allowlisted domain", email: "user@EXAMPLE.com", expectedName: "user's workspace", expectedEnabled: true},
+		{name: "other domain", email: "user@other.com", expectedName: "user's workspace"},
+		{name: "no email", email: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+				Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			s.SetRegistrationTenant(registration)
+
+			mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, t *types.Tenant) (*types.Tenant, error) {
+					if t.Name != tc.expectedName || t.Enabled != tc.expectedEnabled {
+						return nil, errors.New("unexpected tenant")
+					}
+					return &types.Tenant{ID: "tenant-123", Name: t.Name, Enabled: t.Enabled}, nil
+				},
+			)
+			mockStorage.EXPECT().AddMember(gomock.Any(), "tenant-123", "identity-123", types.RoleMember).Return("member-id", nil)
+			mockAuthz.EXPECT().AssignTenantMember(gomock.Any(), "tenant-123", "identity-123").Return(nil)
+
+			if err := s.HandleRegistration(context.Background(), &KratosIdentity{ID: "identity-123", Email: tc.email}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestService_HandleRegistrationSkipped(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		provided bool
+	}{
+		{name: "invited", body: `{"user_id": "identity-123", "transient_payload": {"invited": true}}`},
+		{name: "invite code", body: `{"user_id": "identity-123", "transient_payload": {"invited": "code-1"}}`},
+		{name: "not invited", body: `{"user_id": "identity-123", "transient_payload": {"invited": false}}`, provided: true},
+		{name: "no transient payload", body: `{"user_id": "identity-123", "transient_payload": null}`, provided: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+				Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+			registration, err := ParseRegistrationTenant(DefaultRegistrationTenantName, false, nil, "owner", "transient_payload.invited")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			s.SetRegistrationTenant(registration)
+
+			if tc.provided {
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(&types.Tenant{ID: "tenant-123"}, nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), "tenant-123", "identity-123", types.RoleOwner).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), "tenant-123", "identity-123").Return(nil)
+			}
+
+			var identity KratosIdentity
+			if err := json.Unmarshal([]byte(tc.body), &identity); err != nil {
+				t.Fatalf("failed to decode identity: %v", err)
+			}
+			if err := s.HandleRegistration(context.Background(), &identity); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestService_HandleRegistrationQueued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockAuthz := NewMockAuthorizerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+	mockQueue := NewMockQueueInterface(ctrl)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	var job jobs.Handler
+	var failed jobs.FailureHandler
+	mockQueue.EXPECT().Handle(RegistrationJob, gomock.Any()).Do(func(_ string, h jobs.Handler) { job = h })
+	mockQueue.EXPECT().HandleFailure(RegistrationJob, gomock.Any()).Do(func(_ string, h jobs.FailureHandler) { failed = h })
+
+	s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+	s.SetQueue(mockQueue)
+
+	// the webhook only persists the job
+	var payload []byte
+	mockQueue.EXPECT().Enqueue(gomock.Any(), RegistrationJob, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, p any) error {
+			payload, _ = json.Marshal(p)
+			return nil
+		})
+
+	if err := s.HandleRegistration(context.Background(), &KratosIdentity{ID: "identity-123", Email: "user@example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the job provisions the tenant
+	tenant := &types.Tenant{ID: "tenant-123"}
+	mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, t *types.Tenant) (*types.Tenant, error) {
+			if t.Name != "user@example.com's Org" || t.OriginIdentityID != "identity-123" {
+				return nil, errors.New("wrong tenant")
+			}
+			return tenant, nil
+		})
+	mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, "identity-123", types.RoleOwner).Return("member-id", nil)
+	mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "identity-123").Return(errors.New("authz error"))
+
+	// a failure is returned to the queue to be retried
+	if err := job(context.Background(), payload); err == nil {
+		t.Fatal("expected the job to fail")
+	}
+
+	// the job out of attempts is kept as a dead letter
+	mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterRegistration, payload, "authz error").Return(&types.WebhookDeadLetter{}, nil)
+	failed(context.Background(), payload, errors.New("authz error"))
+}
+
+func TestService_HandleRegistrationReplayed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := NewMockStorageInterface(ctrl)
+	mockAuthz := NewMockAuthorizerInterface(ctrl)
+	mockTracer := NewMockTracingInterface(ctrl)
+	mockLogger := NewMockLoggerInterface(ctrl)
+	setupLoggerMock(ctrl, mockLogger)
+
+	mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+		Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+
+	s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+	// the failed registration is kept as a dead letter and succeeds
+	var letter *types.WebhookDeadLetter
+	mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
+	mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterRegistration, gomock.Any(), "failed to create tenant: storage error").DoAndReturn(
+		func(_ context.Context, kind string, payload []byte, lastError string) (*types.WebhookDeadLetter, error) {
+			letter = &types.WebhookDeadLetter{ID: "letter-1", Kind: kind, Payload: payload, LastError: lastError}
+			return letter, nil
+		})
+
+	if err := s.HandleRegistration(context.Background(), &KratosIdentity{ID: "identity-123", Email: "user@example.com"}); err != nil {
+		t.Fatalf("expected the registration to succeed, got %v", err)
+	}
+	if letter == nil {
+		t.Fatal("expected a dead letter")
+	}
+
+	// replaying the dead letter provisions the tenant once
+	tenant := &types.Tenant{ID: "tenant-123"}
+	mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(letter, nil)
+	mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, t *types.Tenant) (*types.Tenant, error) {
+			if t.OriginIdentityID != "identity-123" {
+				return nil, errors.New("wrong tenant")
+			}
+			return tenant, nil
+		})
+	mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, "identity-123", types.RoleOwner).Return("member-id", nil)
+	mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "identity-123").Return(nil)
+	mockStorage.EXPECT().DeleteWebhookDeadLetter(gomock.Any(), "letter-1").Return(nil)
+
+	if err := s.ReplayDeadLetter(context.Background(), "letter-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestService_ReplayDeadLetter(t *testing.T) {
+	registration := &types.WebhookDeadLetter{ID: "letter-1", Kind: DeadLetterRegistration, Payload: []byte(`{"identity_id":"identity-123","email":"user@example.com"}`)}
+	tenant := &types.Tenant{ID: "tenant-123"}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthorizerInterface)
+		expectedErr error
+	}{
+		{
+			name: "replayed and deleted",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(registration, nil)
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant, nil)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, "identity-123", types.RoleOwner).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "identity-123").Return(nil)
+				mockStorage.EXPECT().DeleteWebhookDeadLetter(gomock.Any(), "letter-1").Return(nil)
+			},
+		},
+		{
+			name: "failed replay is counted",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(registration, nil)
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
+				mockStorage.EXPECT().RecordWebhookDeadLetterReplay(gomock.Any(), "letter-1", "failed to create tenant: storage error").Return(nil)
+			},
+			expectedErr: errors.New("failed to create tenant: storage error"),
+		},
+		{
+			name: "tenant created without its member",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(registration, nil)
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, storage.ErrDuplicateKey)
+				mockStorage.EXPECT().GetTenantByOriginIdentityID(gomock.Any(), "identity-123").Return(tenant, nil)
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenant.ID, "identity-123").Return(nil, storage.ErrNotFound)
+				mockStorage.EXPECT().AddMember(gomock.Any(), tenant.ID, "identity-123", types.RoleOwner).Return("member-id", nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "identity-123").Return(nil)
+				mockStorage.EXPECT().DeleteWebhookDeadLetter(gomock.Any(), "letter-1").Return(nil)
+			},
+		},
+		{
+			name: "tenant held with another role",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(registration, nil)
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, storage.ErrDuplicateKey)
+				mockStorage.EXPECT().GetTenantByOriginIdentityID(gomock.Any(), "identity-123").Return(tenant, nil)
+				mockStorage.EXPECT().GetMember(gomock.Any(), tenant.ID, "identity-123").Return(&types.Membership{TenantID: tenant.ID, KratosIdentityID: "identity-123", Role: types.RoleMember}, nil)
+				mockStorage.EXPECT().RecordWebhookDeadLetterReplay(gomock.Any(), "letter-1", gomock.Any()).Return(nil)
+			},
+			expectedErr: errors.New("tenant tenant-123 of the identity is held as member instead of owner"),
+		},
+		{
+			name: "provisioned tenant not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(registration, nil)
+				mockStorage.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(nil, storage.ErrDuplicateKey)
+				mockStorage.EXPECT().GetTenantByOriginIdentityID(gomock.Any(), "identity-123").Return(nil, storage.ErrNotFound)
+				mockStorage.EXPECT().RecordWebhookDeadLetterReplay(gomock.Any(), "letter-1", gomock.Any()).Return(nil)
+			},
+			expectedErr: storage.ErrNotFound,
+		},
+		{
+			name: "token hook",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(&types.WebhookDeadLetter{ID: "letter-1", Kind: DeadLetterTokenHook}, nil)
+			},
+			expectedErr: ErrDeadLetterNotReplayable,
+		},
+		{
+			name: "not found",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().GetWebhookDeadLetter(gomock.Any(), "letter-1").Return(nil, storage.ErrNotFound)
+			},
+			expectedErr: ErrDeadLetterNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), gomock.Any()).
+				Return(context.Background(), trace.SpanFromContext(context.Background())).AnyTimes()
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+			err := s.ReplayDeadLetter(context.Background(), "letter-1")
+
+			switch {
+			case tc.expectedErr == nil && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.expectedErr != nil && (err == nil || !errors.Is(err, tc.expectedErr) && err.Error() != tc.expectedErr.Error()):
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_HandleLogin(t *testing.T) {
+	identityID := "identity-123"
+	enabled := &types.Tenant{ID: "tenant-1", Enabled: true}
+	disabled := &types.Tenant{ID: "tenant-2", Enabled: false}
+
+	testCases := []struct {
+		name        string
+		memberships []*types.UserMembership
+		listErr     error
+		accepts     bool
+		acceptErr   error
+		expectedErr error
+	}{
+		{
+			name:        "enabled tenant",
+			memberships: []*types.UserMembership{{Tenant: disabled, Role: types.RoleOwner}, {Tenant: enabled, Role: types.RoleMember}},
+			accepts:     true,
+		},
+		{
+			name:    "no tenants",
+			accepts: true,
+		},
+		{
+			name:        "invitation acceptance error does not reject the login",
+			memberships: []*types.UserMembership{{Tenant: enabled, Role: types.RoleMember}},
+			accepts:     true,
+			acceptErr:   errors.New("db error"),
+		},
+		{
+			name:        "all tenants disabled",
+			memberships: []*types.UserMembership{{Tenant: disabled, Role: types.RoleOwner}},
+			expectedErr: ErrTenantsDisabled,
+		},
+		{
+			name:        "storage error",
+			listErr:     errors.New("db error"),
+			expectedErr: errors.New("failed to list memberships: db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "webhooks.Service.HandleLogin").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return(tc.memberships, tc.listErr)
+			if tc.accepts {
+				mockStorage.EXPECT().AcceptInvites(gomock.Any(), identityID).Return(int64(1), tc.acceptErr)
+			}
+
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, NewMockAuthorizerInterface(ctrl), mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			err := s.HandleLogin(context.Background(), identityID)
+			switch {
+			case tc.expectedErr == nil && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.expectedErr != nil && (err == nil || err.Error() != tc.expectedErr.Error()):
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_HandleIdentityDeleted(t *testing.T) {
+	identityID := "identity-123"
+	tenant := &types.Tenant{ID: "tenant-123", Enabled: true}
+	roles := []*types.Role{{ID: "role-1", TenantID: tenant.ID}}
+
+	// expectRemoval expects the identity to be dropped from tenant with its
+	// relations and role assignments.
+	expectRemoval := func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+		mockStorage.EXPECT().DeleteMember(gomock.Any(), tenant.ID, identityID).Return(nil)
+		mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenant.ID, identityID).Return(nil)
+		mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenant.ID, identityID).Return(nil)
+		mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenant.ID, identityID).Return(nil)
+		mockStorage.EXPECT().ListRolesByTenantID(gomock.Any(), tenant.ID).Return(roles, nil)
+		mockAuthz.EXPECT().UnassignRole(gomock.Any(), "role-1", identityID).Return(nil)
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(*MockStorageInterface, *MockAuthorizerInterface)
+		expectedErr bool
+	}{
+		{
+			name: "member is removed",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleMember}}, nil)
+				expectRemoval(mockStorage, mockAuthz)
+			},
+		},
+		{
+			name: "co-owned tenant keeps its other owner",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleOwner}}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenant.ID).Return([]*types.Membership{
+					{KratosIdentityID: identityID, Role: types.RoleOwner},
+					{KratosIdentityID: "admin-1", Role: types.RoleAdmin},
+					{KratosIdentityID: "owner-2", Role: types.RoleOwner},
+				}, nil)
+				expectRemoval(mockStorage, mockAuthz)
+			},
+		},
+		{
+			name: "oldest admin becomes the owner",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleOwner}}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenant.ID).Return([]*types.Membership{
+					{KratosIdentityID: identityID, Role: types.RoleOwner},
+					{KratosIdentityID: "member-1", Role: types.RoleMember},
+					{KratosIdentityID: "admin-1", Role: types.RoleAdmin},
+					{KratosIdentityID: "admin-2", Role: types.RoleAdmin},
+				}, nil)
+				mockStorage.EXPECT().UpdateMember(gomock.Any(), tenant.ID, "admin-1", types.RoleOwner).Return(nil)
+				mockAuthz.EXPECT().AssignTenantOwner(gomock.Any(), tenant.ID, "admin-1").Return(nil)
+				mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenant.ID, "admin-1").Return(nil)
+				expectRemoval(mockStorage, mockAuthz)
+			},
+		},
+		{
+			name: "sole-owned tenant is disabled",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleOwner}}, nil)
+				mockStorage.EXPECT().ListMembersByTenantID(gomock.Any(), tenant.ID).Return([]*types.Membership{
+					{KratosIdentityID: identityID, Role: types.RoleOwner},
+					{KratosIdentityID: "member-1", Role: types.RoleMember},
+				}, nil)
+				mockStorage.EXPECT().UpdateTenant(gomock.Any(), &types.Tenant{ID: tenant.ID, Enabled: false}, []string{"enabled"}).Return(nil)
+				expectRemoval(mockStorage, mockAuthz)
+			},
+		},
+		{
+			name: "membership already removed",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleMember}}, nil)
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenant.ID, identityID).Return(storage.ErrNotFound)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenant.ID, identityID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantAdmin(gomock.Any(), tenant.ID, identityID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantMember(gomock.Any(), tenant.ID, identityID).Return(nil)
+				mockStorage.EXPECT().ListRolesByTenantID(gomock.Any(), tenant.ID).Return(nil, nil)
+			},
+		},
+		{
+			name: "authz error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return([]*types.UserMembership{{Tenant: tenant, Role: types.RoleMember}}, nil)
+				mockStorage.EXPECT().DeleteMember(gomock.Any(), tenant.ID, identityID).Return(nil)
+				mockAuthz.EXPECT().RemoveTenantOwner(gomock.Any(), tenant.ID, identityID).Return(errors.New("authz error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name: "list memberships error",
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), identityID).Return(nil, errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "webhooks.Service.HandleIdentityDeleted").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, NewMockMonitorInterface(ctrl), mockLogger)
+
+			err := s.HandleIdentityDeleted(context.Background(), identityID)
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+// tenantRequest returns a token hook request of userID through clientID,
+// for the tenant picked at consent.
+func tenantRequest(userID, tenantID, clientID string) *oauth2.TokenHookRequest {
+	req := &oauth2.TokenHookRequest{Session: oauth2.NewSession(userID)}
+	req.Request.ClientID = clientID
+	if tenantID != "" {
+		req.Session.Extra = map[string]interface{}{"tenant_id": tenantID}
+	}
+	return req
+}
+
+func membership(userID, tenantID string) openfga.Tuple {
+	return *openfga.NewTuple("user:"+userID, "member", "tenant:"+tenantID)
+}
+
 func TestService_HandleTokenHook(t *testing.T) {
 	userID := "user-123"
 	tenants := []*types.Tenant{
@@ -162,7 +741,10 @@ func TestService_HandleTokenHook(t *testing.T) {
 	testCases := []struct {
 		name         string
 		request      *oauth2.TokenHookRequest
-		setupMocks   func(*MockStorageInterface, *MockLoggerInterface)
+		targets      *TokenTargets
+		claim        *TokenClaim
+		clients      map[string]string
+		setupMocks   func(*MockStorageInterface, *MockAuthorizerInterface, *MockLoggerInterface)
 		expectedErr  bool
 		validateResp func(*testing.T, *TokenHookResponse)
 	}{
@@ -171,8 +753,11 @@ func TestService_HandleTokenHook(t *testing.T) {
 			request: &oauth2.TokenHookRequest{
 				Session: oauth2.NewSession(userID),
 			},
-			setupMocks: func(mockStorage *MockStorageInterface, mockLogger *MockLoggerInterface) {
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				for _, tenant := range tenants {
+					mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenant.ID, userID, "can_view", membership(userID, tenant.ID)).Return(true, nil)
+				}
 			},
 			expectedErr: false,
 			validateResp: func(t *testing.T, resp *TokenHookResponse) {
@@ -191,12 +776,182 @@ func TestService_HandleTokenHook(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "success - roles claim renamed",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			claim: &TokenClaim{Name: "org_roles", Format: ClaimFormatRoles},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return([]*types.UserMembership{
+					{Tenant: tenants[0], Role: types.RoleOwner},
+					{Tenant: &types.Tenant{ID: "tenant-disabled"}, Role: types.RoleOwner},
+					{Tenant: tenants[1], Role: types.RoleMember},
+				}, nil)
+				for _, tenant := range tenants {
+					mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenant.ID, userID, "can_view", membership(userID, tenant.ID)).Return(true, nil)
+				}
+			},
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				if _, ok := resp.Session.IDToken["tenants"]; ok {
+					t.Error("expected no tenants claim")
+				}
+				roles, ok := resp.Session.IDToken["org_roles"].(map[string]string)
+				if !ok || len(roles) != 2 || roles["tenant-1"] != "owner" || roles["tenant-2"] != "member" {
+					t.Errorf("expected the roles of the enabled tenants, got %v", resp.Session.IDToken["org_roles"])
+				}
+			},
+		},
+		{
+			name: "success - tenants capped",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			claim: &TokenClaim{Name: DefaultClaimName, Format: ClaimFormatIDs, MaxTenants: 1},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", membership(userID, "tenant-1")).Return(true, nil)
+			},
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				tenantList, ok := resp.Session.AccessToken["tenants"].([]string)
+				if !ok || len(tenantList) != 1 || tenantList[0] != "tenant-1" {
+					t.Errorf("expected only tenant-1 in access token, got %v", resp.Session.AccessToken["tenants"])
+				}
+			},
+		},
+		{
+			name:    "success - tenant requested at consent",
+			request: tenantRequest(userID, "tenant-2", ""),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return([]*types.UserMembership{
+					{Tenant: tenants[0], Role: types.RoleOwner},
+					{Tenant: tenants[1], Role: types.RoleMember},
+				}, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", membership(userID, "tenant-2")).Return(true, nil)
+			},
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				for _, claims := range []map[string]interface{}{resp.Session.IDToken, resp.Session.AccessToken} {
+					if claims["tenant_id"] != "tenant-2" || claims["tenant_role"] != "member" {
+						t.Errorf("expected tenant-2 with the member role, got %v", claims)
+					}
+					if _, ok := claims["tenants"]; ok {
+						t.Error("expected no tenants list")
+					}
+				}
+			},
+		},
+		{
+			name:    "success - tenant bound to the client",
+			request: tenantRequest(userID, "", "client-1"),
+			clients: map[string]string{"client-1": "tenant-1"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return([]*types.UserMembership{
+					{Tenant: tenants[0], Role: types.RoleOwner},
+				}, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", membership(userID, "tenant-1")).Return(true, nil)
+			},
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				if resp.Session.AccessToken["tenant_id"] != "tenant-1" || resp.Session.AccessToken["tenant_role"] != "owner" {
+					t.Errorf("expected tenant-1 with the owner role, got %v", resp.Session.AccessToken)
+				}
+			},
+		},
+		{
+			name:    "error - tenant requested by a non member",
+			request: tenantRequest(userID, "tenant-3", ""),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return([]*types.UserMembership{
+					{Tenant: tenants[0], Role: types.RoleOwner},
+				}, nil)
+			},
+			expectedErr: true,
+		},
+		{
+			name:    "error - tenant requested in a disabled tenant",
+			request: tenantRequest(userID, "tenant-disabled", ""),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return([]*types.UserMembership{
+					{Tenant: &types.Tenant{ID: "tenant-disabled"}, Role: types.RoleOwner},
+				}, nil)
+			},
+			expectedErr: true,
+		},
+		{
+			name:    "error - tenant requested outside the tenant of the client",
+			request: tenantRequest(userID, "tenant-2", "client-1"),
+			clients: map[string]string{"client-1": "tenant-1"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+			},
+			expectedErr: true,
+		},
+		{
+			name: "success - tenant denied by authz is dropped",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", membership(userID, "tenant-1")).Return(false, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", membership(userID, "tenant-2")).Return(true, nil)
+			},
+			expectedErr: false,
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				tenantList, ok := resp.Session.AccessToken["tenants"].([]string)
+				if !ok || len(tenantList) != 1 || tenantList[0] != "tenant-2" {
+					t.Errorf("expected only tenant-2 in access token, got %v", resp.Session.AccessToken["tenants"])
+				}
+			},
+		},
+		{
+			name: "success - access token only",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			targets: &TokenTargets{AccessToken: true},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				for _, tenant := range tenants {
+					mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenant.ID, userID, "can_view", membership(userID, tenant.ID)).Return(true, nil)
+				}
+			},
+			expectedErr: false,
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				if resp.Session.IDToken["tenants"] != nil {
+					t.Error("expected no tenants in ID token")
+				}
+				if resp.Session.AccessToken["tenants"] == nil {
+					t.Error("expected tenants in access token")
+				}
+			},
+		},
+		{
+			name: "success - ID token only",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			targets: &TokenTargets{IDToken: true},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				for _, tenant := range tenants {
+					mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), tenant.ID, userID, "can_view", membership(userID, tenant.ID)).Return(true, nil)
+				}
+			},
+			expectedErr: false,
+			validateResp: func(t *testing.T, resp *TokenHookResponse) {
+				if resp.Session.IDToken["tenants"] == nil {
+					t.Error("expected tenants in ID token")
+				}
+				if resp.Session.AccessToken["tenants"] != nil {
+					t.Error("expected no tenants in access token")
+				}
+			},
+		},
 		{
 			name: "success - user with no tenants",
 			request: &oauth2.TokenHookRequest{
 				Session: oauth2.NewSession(userID),
 			},
-			setupMocks: func(mockStorage *MockStorageInterface, mockLogger *MockLoggerInterface) {
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return([]*types.Tenant{}, nil)
 			},
 			expectedErr: false,
@@ -215,14 +970,14 @@ func TestService_HandleTokenHook(t *testing.T) {
 			request: &oauth2.TokenHookRequest{
 				Session: oauth2.NewSession(""),
 			},
-			setupMocks: func(mockStorage *MockStorageInterface, mockLogger *MockLoggerInterface) {
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 			},
 			expectedErr: true,
 		},
 		{
 			name:    "error - nil session",
 			request: &oauth2.TokenHookRequest{},
-			setupMocks: func(mockStorage *MockStorageInterface, mockLogger *MockLoggerInterface) {
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 			},
 			expectedErr: true,
 		},
@@ -231,8 +986,21 @@ func TestService_HandleTokenHook(t *testing.T) {
 			request: &oauth2.TokenHookRequest{
 				Session: oauth2.NewSession(userID),
 			},
-			setupMocks: func(mockStorage *MockStorageInterface, mockLogger *MockLoggerInterface) {
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
 				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(nil, errors.New("storage error"))
+				mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterTokenHook, []byte(`{"user_id":"`+userID+`","client_id":""}`), gomock.Any()).Return(&types.WebhookDeadLetter{}, nil)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "error - authz error",
+			request: &oauth2.TokenHookRequest{
+				Session: oauth2.NewSession(userID),
+			},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface, mockLogger *MockLoggerInterface) {
+				mockStorage.EXPECT().ListActiveTenantsByUserID(gomock.Any(), userID).Return(tenants, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", gomock.Any()).Return(false, errors.New("fga down"))
+				mockStorage.EXPECT().CreateWebhookDeadLetter(gomock.Any(), DeadLetterTokenHook, gomock.Any(), "failed to check tenant access: fga down").Return(&types.WebhookDeadLetter{}, nil)
 			},
 			expectedErr: true,
 		},
@@ -250,11 +1018,19 @@ func TestService_HandleTokenHook(t *testing.T) {
 			setupLoggerMock(ctrl, mockLogger)
 			mockMonitor := NewMockMonitorInterface(ctrl)
 
-			s := NewService(mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			targets := TokenTargets{IDToken: true, AccessToken: true}
+			if tc.targets != nil {
+				targets = *tc.targets
+			}
+			s := NewService(targets, mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			if tc.claim != nil {
+				s.SetTokenClaim(*tc.claim)
+			}
+			s.SetClientTenants(tc.clients)
 
 			mockTracer.EXPECT().Start(gomock.Any(), "webhooks.Service.HandleTokenHook").
 				Return(context.Background(), trace.SpanFromContext(context.Background()))
-			tc.setupMocks(mockStorage, mockLogger)
+			tc.setupMocks(mockStorage, mockAuthz, mockLogger)
 
 			resp, err := s.HandleTokenHook(context.Background(), tc.request)
 
@@ -273,3 +1049,343 @@ func TestService_HandleTokenHook(t *testing.T) {
 		})
 	}
 }
+
+func TestService_HandleConsent(t *testing.T) {
+	userID := "user-123"
+	owned := &types.Tenant{ID: "tenant-1", Name: "Acme", Enabled: true}
+	joined := &types.Tenant{ID: "tenant-2", Name: "Globex", Enabled: true}
+	memberships := []*types.UserMembership{
+		{Tenant: owned, Role: types.RoleOwner},
+		{Tenant: &types.Tenant{ID: "tenant-disabled"}, Role: types.RoleOwner},
+		{Tenant: joined, Role: types.RoleMember},
+	}
+	policy := ConsentPolicy{
+		Scopes:    map[string]types.MembershipRole{"billing": types.RoleOwner, "members.write": types.RoleAdmin},
+		Audiences: map[string]types.MembershipRole{"https://billing.example.com": types.RoleOwner},
+	}
+	requested := func(tenantID, clientID string) *ConsentRequest {
+		req := &ConsentRequest{
+			Subject:           userID,
+			RequestedScope:    []string{"openid", "billing", "members.write"},
+			RequestedAudience: []string{"https://api.example.com", "https://billing.example.com"},
+			TenantID:          tenantID,
+		}
+		req.Client.ClientID = clientID
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *ConsentRequest
+		clients            map[string]string
+		setupMocks         func(*MockStorageInterface, *MockAuthorizerInterface)
+		expectedScope      []string
+		expectedAudience   []string
+		expectedTenants    []string
+		expectedNotAllowed bool
+		expectedErr        bool
+	}{
+		{
+			name:    "best role of the tenants",
+			request: requested("", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", membership(userID, "tenant-1")).Return(true, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", membership(userID, "tenant-2")).Return(true, nil)
+			},
+			expectedScope:    []string{"openid", "billing", "members.write"},
+			expectedAudience: []string{"https://api.example.com", "https://billing.example.com"},
+			expectedTenants:  []string{"tenant-1", "tenant-2"},
+		},
+		{
+			name:    "picked tenant",
+			request: requested("tenant-2", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", membership(userID, "tenant-2")).Return(true, nil)
+			},
+			expectedScope:    []string{"openid"},
+			expectedAudience: []string{"https://api.example.com"},
+			expectedTenants:  []string{"tenant-2"},
+		},
+		{
+			name:    "client bound to a tenant",
+			request: requested("", "client-1"),
+			clients: map[string]string{"client-1": "tenant-2"},
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", membership(userID, "tenant-2")).Return(true, nil)
+			},
+			expectedScope:    []string{"openid"},
+			expectedAudience: []string{"https://api.example.com"},
+			expectedTenants:  []string{"tenant-2"},
+		},
+		{
+			name:    "access denied drops the tenant",
+			request: requested("", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", gomock.Any()).Return(false, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-2", userID, "can_view", gomock.Any()).Return(true, nil)
+			},
+			expectedScope:    []string{"openid"},
+			expectedAudience: []string{"https://api.example.com"},
+			expectedTenants:  []string{"tenant-2"},
+		},
+		{
+			name:    "no tenant",
+			request: requested("", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(nil, nil)
+			},
+			expectedScope:    []string{"openid"},
+			expectedAudience: []string{"https://api.example.com"},
+			expectedTenants:  []string{},
+		},
+		{
+			name:    "disabled tenant picked",
+			request: requested("tenant-disabled", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+			},
+			expectedNotAllowed: true,
+		},
+		{
+			name:               "tenant picked with a client bound to another",
+			request:            requested("tenant-1", "client-1"),
+			clients:            map[string]string{"client-1": "tenant-2"},
+			setupMocks:         func(*MockStorageInterface, *MockAuthorizerInterface) {},
+			expectedNotAllowed: true,
+		},
+		{
+			name:        "no subject",
+			request:     &ConsentRequest{},
+			setupMocks:  func(*MockStorageInterface, *MockAuthorizerInterface) {},
+			expectedErr: true,
+		},
+		{
+			name:    "authz error",
+			request: requested("", "client-1"),
+			setupMocks: func(mockStorage *MockStorageInterface, mockAuthz *MockAuthorizerInterface) {
+				mockStorage.EXPECT().ListMembershipsByUserID(gomock.Any(), userID).Return(memberships, nil)
+				mockAuthz.EXPECT().CheckTenantAccess(gomock.Any(), "tenant-1", userID, "can_view", gomock.Any()).Return(false, errors.New("fga down"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStorage := NewMockStorageInterface(ctrl)
+			mockAuthz := NewMockAuthorizerInterface(ctrl)
+			mockTracer := NewMockTracingInterface(ctrl)
+			mockLogger := NewMockLoggerInterface(ctrl)
+			setupLoggerMock(ctrl, mockLogger)
+			mockMonitor := NewMockMonitorInterface(ctrl)
+
+			s := NewService(TokenTargets{IDToken: true}, mockStorage, mockAuthz, mockTracer, mockMonitor, mockLogger)
+			s.SetConsentPolicy(policy)
+			s.SetClientTenants(tc.clients)
+
+			mockTracer.EXPECT().Start(gomock.Any(), "webhooks.Service.HandleConsent").
+				Return(context.Background(), trace.SpanFromContext(context.Background()))
+			tc.setupMocks(mockStorage, mockAuthz)
+
+			resp, err := s.HandleConsent(context.Background(), tc.request)
+
+			if tc.expectedNotAllowed {
+				if !errors.Is(err, ErrTenantNotAllowed) {
+					t.Errorf("expected ErrTenantNotAllowed, got %v", err)
+				}
+				return
+			}
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(resp.GrantScope, tc.expectedScope) {
+				t.Errorf("expected scopes %v, got %v", tc.expectedScope, resp.GrantScope)
+			}
+			if !slices.Equal(resp.GrantAudience, tc.expectedAudience) {
+				t.Errorf("expected audiences %v, got %v", tc.expectedAudience, resp.GrantAudience)
+			}
+			tenants := make([]string, 0, len(resp.Tenants))
+			for _, tenant := range resp.Tenants {
+				tenants = append(tenants, tenant.ID)
+			}
+			if !slices.Equal(tenants, tc.expectedTenants) {
+				t.Errorf("expected tenants %v, got %v", tc.expectedTenants, tenants)
+			}
+		})
+	}
+}
+
+func TestParseTokenTargets(t *testing.T) {
+	testCases := []struct {
+		value       string
+		expected    TokenTargets
+		expectedErr bool
+	}{
+		{value: "id_token,access_token", expected: TokenTargets{IDToken: true, AccessToken: true}},
+		{value: "access_token", expected: TokenTargets{AccessToken: true}},
+		{value: " id_token ,", expected: TokenTargets{IDToken: true}},
+		{value: "", expectedErr: true},
+		{value: "id_token,refresh_token", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			targets, err := ParseTokenTargets(tc.value)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if targets != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, targets)
+			}
+		})
+	}
+}
+
+func TestParseTokenClaim(t *testing.T) {
+	testCases := []struct {
+		name        string
+		claim       string
+		format      string
+		maxTenants  int
+		expected    TokenClaim
+		expectedErr bool
+	}{
+		{name: "ids", claim: "tenants", format: "ids", expected: TokenClaim{Name: "tenants", Format: ClaimFormatIDs}},
+		{name: "roles capped", claim: " org_roles ", format: "roles", maxTenants: 10, expected: TokenClaim{Name: "org_roles", Format: ClaimFormatRoles, MaxTenants: 10}},
+		{name: "empty name", claim: "", format: "ids", expectedErr: true},
+		{name: "registered claim", claim: "sub", format: "ids", expectedErr: true},
+		{name: "slugs", claim: "tenants", format: "slugs", expectedErr: true},
+		{name: "negative cap", claim: "tenants", format: "ids", maxTenants: -1, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claim, err := ParseTokenClaim(tc.claim, tc.format, tc.maxTenants)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if claim != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, claim)
+			}
+		})
+	}
+}
+
+func TestParseRegistrationTenant(t *testing.T) {
+	testCases := []struct {
+		name            string
+		template        string
+		domains         []string
+		role            string
+		skipField       string
+		email           string
+		expectedName    string
+		expectedDomains []string
+		expectedErr     bool
+	}{
+		{name: "default", template: DefaultRegistrationTenantName, role: "owner", email: "user@example.com", expectedName: "user@example.com's Org"},
+		{name: "domains", template: "{{.EmailDomain}}", domains: []string{" @Example.com", ""}, role: "admin", email: "user@example.com", expectedName: "example.com", expectedDomains: []string{"example.com"}},
+		{name: "long name cut", template: strings.Repeat("a", 200), role: "owner", email: "user@example.com", expectedName: strings.Repeat("a", 128)},
+		{name: "bad syntax", template: "{{.Email", role: "owner", expectedErr: true},
+		{name: "unknown field", template: "{{.Name}}", role: "owner", expectedErr: true},
+		{name: "bad domain", template: DefaultRegistrationTenantName, domains: []string{"user@example.com"}, role: "owner", expectedErr: true},
+		{name: "bad role", template: DefaultRegistrationTenantName, role: "guest", expectedErr: true},
+		{name: "bad skip field", template: DefaultRegistrationTenantName, role: "owner", skipField: "transient_payload..invited", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ParseRegistrationTenant(tc.template, false, tc.domains, tc.role, tc.skipField)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.Role.String() != tc.role {
+				t.Errorf("expected role %s, got %s", tc.role, r.Role)
+			}
+			if !slices.Equal(r.EnabledDomains, tc.expectedDomains) {
+				t.Errorf("expected domains %v, got %v", tc.expectedDomains, r.EnabledDomains)
+			}
+			if name, err := r.name("identity-123", tc.email); err != nil || name != tc.expectedName {
+				t.Errorf("expected name %q, got %q (%v)", tc.expectedName, name, err)
+			}
+		})
+	}
+}
+
+func TestParseConsentPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		scopes      []string
+		audiences   []string
+		expected    ConsentPolicy
+		expectedErr bool
+	}{
+		{
+			name:      "roles",
+			scopes:    []string{"billing=owner", " members.write = admin ", ""},
+			audiences: []string{"https://billing.example.com=owner"},
+			expected: ConsentPolicy{
+				Scopes:    map[string]types.MembershipRole{"billing": types.RoleOwner, "members.write": types.RoleAdmin},
+				Audiences: map[string]types.MembershipRole{"https://billing.example.com": types.RoleOwner},
+			},
+		},
+		{name: "none", expected: ConsentPolicy{Scopes: map[string]types.MembershipRole{}, Audiences: map[string]types.MembershipRole{}}},
+		{name: "no role", scopes: []string{"billing"}, expectedErr: true},
+		{name: "no scope", scopes: []string{"=owner"}, expectedErr: true},
+		{name: "bad role", audiences: []string{"https://billing.example.com=guest"}, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParseConsentPolicy(tc.scopes, tc.audiences)
+
+			if tc.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !maps.Equal(p.Scopes, tc.expected.Scopes) || !maps.Equal(p.Audiences, tc.expected.Audiences) {
+				t.Errorf("expected %+v, got %+v", tc.expected, p)
+			}
+		})
+	}
+}
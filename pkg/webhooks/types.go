@@ -5,8 +5,16 @@ package webhooks
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode/utf8"
 
 	"github.com/ory/hydra/v2/oauth2"
+
+	"github.com/canonical/tenant-service/internal/types"
+	"github.com/canonical/tenant-service/internal/validation"
 )
 
 type KratosIdentity struct {
@@ -28,6 +36,112 @@ func (k *KratosIdentity) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Version is the schema version of the webhook bodies, negotiated with
+// VersionHeader.
+type Version string
+
+const (
+	// VersionHeader names the schema version of the body of a webhook call,
+	// the response carries the version the body was read with.
+	VersionHeader = "X-Webhook-Version"
+
+	// V0 reads the bodies loosely, a missing field is read as its zero value.
+	V0 Version = "v0"
+	// V1 validates the bodies against their schema, see IdentityRequestV1
+	// and TokenHookRequestV1.
+	V1 Version = "v1"
+)
+
+// Versions lists the supported schema versions.
+var Versions = []Version{V0, V1}
+
+// ParseVersion returns the schema version named s.
+func ParseVersion(s string) (Version, error) {
+	v := Version(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Versions, v) {
+		return "", fmt.Errorf("unsupported webhook version %q, expected one of %v", s, Versions)
+	}
+	return v, nil
+}
+
+// IdentityRequestV1 is the v1 body of the registration, login and
+// identity-deleted hooks.
+type IdentityRequestV1 struct {
+	// UserID is the ID of the Kratos identity.
+	UserID string `json:"user_id"`
+	Email  string `json:"email,omitempty"`
+	// Traits and TransientPayload may hold the skip field of the
+	// registration tenant.
+	Traits           map[string]interface{} `json:"traits,omitempty"`
+	TransientPayload map[string]interface{} `json:"transient_payload,omitempty"`
+}
+
+// Validate checks the request against its schema, the email is optional.
+func (r *IdentityRequestV1) Validate() error {
+	v := validation.New().UUID("user_id", r.UserID)
+	if r.Email != "" {
+		v.Email("email", r.Email)
+	}
+	return v.Err()
+}
+
+// TokenHookRequestV1 is the v1 body of the token hook, the body Hydra sends
+// with the subject of its session and the client of its request required.
+type TokenHookRequestV1 struct {
+	Session *oauth2.Session `json:"session"`
+	Request *oauth2.Request `json:"request"`
+}
+
+// Validate checks the request against its schema.
+func (r *TokenHookRequestV1) Validate() error {
+	var subject, clientID string
+	if r.Session != nil && r.Session.DefaultSession != nil {
+		subject = r.Session.Subject
+	}
+	if r.Request != nil {
+		clientID = r.Request.ClientID
+	}
+	return validation.New().
+		Required("session.id_token.subject", subject).
+		Required("request.client_id", clientID).
+		Err()
+}
+
+// LoginBlockedMessageID is the ID of the message Kratos shows a user whose
+// login is rejected by the login hook.
+const LoginBlockedMessageID = 4000100
+
+// KratosError is the body of a webhook response interrupting a Kratos flow,
+// Kratos shows its messages to the user when it parses the response.
+type KratosError struct {
+	Messages []KratosMessages `json:"messages"`
+}
+
+// KratosMessages are the messages about a field of the flow, or the whole
+// flow with the #/ pointer.
+type KratosMessages struct {
+	InstancePtr string          `json:"instance_ptr"`
+	Messages    []KratosMessage `json:"messages"`
+}
+
+type KratosMessage struct {
+	ID      int            `json:"id"`
+	Text    string         `json:"text"`
+	Type    string         `json:"type"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+// NewKratosError returns the response interrupting a flow with a single
+// error message about the whole flow.
+func NewKratosError(id int, text string, context map[string]any) *KratosError {
+	return &KratosError{
+		Messages: []KratosMessages{{
+			InstancePtr: "#/",
+			Messages:    []KratosMessage{{ID: id, Text: text, Type: "error", Context: context}},
+		}},
+	}
+}
+
 type TokenHookRequest = oauth2.TokenHookRequest
 
 type TokenHookResponse struct {
@@ -36,3 +150,326 @@ type TokenHookResponse struct {
 		AccessToken map[string]interface{} `json:"access_token,omitempty"`
 	} `json:"session"`
 }
+
+const (
+	IDTokenTarget     = "id_token"
+	AccessTokenTarget = "access_token"
+)
+
+// TokenTargets selects the tokens the token hook adds the tenant claims to.
+type TokenTargets struct {
+	IDToken     bool
+	AccessToken bool
+}
+
+// ParseTokenTargets parses a comma separated list of id_token and access_token.
+func ParseTokenTargets(value string) (TokenTargets, error) {
+	var t TokenTargets
+
+	for _, target := range strings.Split(value, ",") {
+		switch strings.TrimSpace(target) {
+		case "":
+		case IDTokenTarget:
+			t.IDToken = true
+		case AccessTokenTarget:
+			t.AccessToken = true
+		default:
+			return TokenTargets{}, fmt.Errorf("invalid token hook target %q, expected %s or %s", target, IDTokenTarget, AccessTokenTarget)
+		}
+	}
+
+	if !t.IDToken && !t.AccessToken {
+		return TokenTargets{}, fmt.Errorf("at least one token hook target is required")
+	}
+
+	return t, nil
+}
+
+// ClaimFormat is the shape of the tenant claim the token hook adds.
+type ClaimFormat string
+
+const (
+	// ClaimFormatIDs lists the IDs of the tenants, ["tenant-1"].
+	ClaimFormatIDs ClaimFormat = "ids"
+	// ClaimFormatRoles maps the IDs of the tenants to the role of the user,
+	// {"tenant-1": "owner"}.
+	ClaimFormatRoles ClaimFormat = "roles"
+)
+
+// DefaultClaimName is the claim holding the tenants of the user.
+const DefaultClaimName = "tenants"
+
+// registeredClaims are set by Hydra, a tenant claim must not override them.
+var registeredClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "auth_time", "nonce", "acr", "amr", "azp", "at_hash", "c_hash", "sid", "scp", "client_id", "ext"}
+
+// TokenClaim shapes the tenant claim the token hook adds to the tokens.
+type TokenClaim struct {
+	Name   string
+	Format ClaimFormat
+	// MaxTenants caps the number of tenants in the claim, 0 does not.
+	MaxTenants int
+}
+
+// ParseTokenClaim returns the claim named name, of the ids or roles format,
+// holding at most maxTenants tenants.
+func ParseTokenClaim(name, format string, maxTenants int) (TokenClaim, error) {
+	c := TokenClaim{Name: strings.TrimSpace(name), Format: ClaimFormat(format), MaxTenants: maxTenants}
+
+	if c.Name == "" {
+		return TokenClaim{}, fmt.Errorf("the token hook claim name is empty")
+	}
+	if slices.Contains(registeredClaims, c.Name) {
+		return TokenClaim{}, fmt.Errorf("the token hook claim %q is set by Hydra", c.Name)
+	}
+	if c.Format != ClaimFormatIDs && c.Format != ClaimFormatRoles {
+		return TokenClaim{}, fmt.Errorf("invalid token hook claim format %q, expected %s or %s", format, ClaimFormatIDs, ClaimFormatRoles)
+	}
+	if c.MaxTenants < 0 {
+		return TokenClaim{}, fmt.Errorf("the token hook maximum number of tenants is negative")
+	}
+
+	return c, nil
+}
+
+// ConsentRequest is the Hydra consent request a consent app gets from
+// Hydra, along with the tenant the user picked, if any.
+type ConsentRequest struct {
+	Subject string `json:"subject"`
+	Client  struct {
+		ClientID string `json:"client_id"`
+	} `json:"client"`
+	RequestedScope    []string `json:"requested_scope"`
+	RequestedAudience []string `json:"requested_access_token_audience"`
+	TenantID          string   `json:"tenant_id,omitempty"`
+}
+
+// ConsentResponse holds the scopes and audiences the memberships of the
+// user allow, named as in the Hydra consent acceptance, and the tenants
+// they were granted for.
+type ConsentResponse struct {
+	GrantScope    []string         `json:"grant_scope"`
+	GrantAudience []string         `json:"grant_access_token_audience"`
+	Tenants       []*ConsentTenant `json:"tenants"`
+}
+
+// ConsentTenant is a tenant the user can consent for, with what its role
+// allows.
+type ConsentTenant struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Role          string   `json:"role"`
+	GrantScope    []string `json:"grant_scope"`
+	GrantAudience []string `json:"grant_access_token_audience"`
+}
+
+// roleLevels orders the membership roles, a role grants what the lower
+// ones do.
+var roleLevels = map[types.MembershipRole]int{
+	types.RoleMember: 1,
+	types.RoleAdmin:  2,
+	types.RoleOwner:  3,
+}
+
+// ConsentPolicy maps the restricted scopes and audiences to the least
+// membership role granting them. The others are granted to every user.
+type ConsentPolicy struct {
+	Scopes    map[string]types.MembershipRole
+	Audiences map[string]types.MembershipRole
+}
+
+// ParseConsentPolicy parses the scopes and audiences given as name=role.
+func ParseConsentPolicy(scopes, audiences []string) (ConsentPolicy, error) {
+	var p ConsentPolicy
+	var err error
+
+	if p.Scopes, err = parseConsentRoles("scope", scopes); err != nil {
+		return ConsentPolicy{}, err
+	}
+	if p.Audiences, err = parseConsentRoles("audience", audiences); err != nil {
+		return ConsentPolicy{}, err
+	}
+
+	return p, nil
+}
+
+func parseConsentRoles(kind string, values []string) (map[string]types.MembershipRole, error) {
+	roles := make(map[string]types.MembershipRole, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		// audiences are URLs, the role follows the last =
+		i := strings.LastIndex(v, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid consent %s %q, expected %s=role", kind, v, kind)
+		}
+		name := strings.TrimSpace(v[:i])
+		if name == "" {
+			return nil, fmt.Errorf("invalid consent %s %q, the %s is empty", kind, v, kind)
+		}
+		role, err := types.ParseMembershipRole(strings.TrimSpace(v[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid consent %s %q: %w", kind, v, err)
+		}
+		roles[name] = role
+	}
+	return roles, nil
+}
+
+// grant returns the requested scopes and audiences a member holding role
+// is allowed, an empty role allows the unrestricted ones only.
+func (p ConsentPolicy) grant(scopes, audiences []string, role types.MembershipRole) ([]string, []string) {
+	return grantable(scopes, p.Scopes, role), grantable(audiences, p.Audiences, role)
+}
+
+func grantable(requested []string, restricted map[string]types.MembershipRole, role types.MembershipRole) []string {
+	granted := make([]string, 0, len(requested))
+	for _, r := range requested {
+		least, ok := restricted[r]
+		if !ok || (role != "" && roleLevels[role] >= roleLevels[least]) {
+			granted = append(granted, r)
+		}
+	}
+	return granted
+}
+
+// DefaultRegistrationTenantName is the name template of the tenants of the
+// registrations.
+const DefaultRegistrationTenantName = "{{.Email}}'s Org"
+
+// RegistrationNameData is what the name template of the tenants of the
+// registrations is executed with.
+type RegistrationNameData struct {
+	IdentityID     string
+	Email          string
+	EmailLocalPart string
+	EmailDomain    string
+}
+
+// RegistrationTenant shapes the tenant provisioned for a registration.
+type RegistrationTenant struct {
+	// NameTemplate names the tenant, it is executed with RegistrationNameData.
+	NameTemplate *template.Template
+	// Enabled is the initial state of the tenants, those of the emails of
+	// EnabledDomains are enabled whatever it is.
+	Enabled        bool
+	EnabledDomains []string
+	// Role is the role of the registered identity in its tenant.
+	Role types.MembershipRole
+	// SkipField is the dot-separated path of the field of the registration
+	// body, such as transient_payload.invited, skipping the tenant when set.
+	SkipField string
+}
+
+// ParseRegistrationTenant returns the shape of the tenants of the
+// registrations, named by the text/template nameTemplate. The registrations
+// whose skipField is set get no tenant, an empty skipField never skips.
+func ParseRegistrationTenant(nameTemplate string, enabled bool, enabledDomains []string, role, skipField string) (RegistrationTenant, error) {
+	tmpl, err := template.New("tenant_name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return RegistrationTenant{}, fmt.Errorf("invalid registration tenant name template: %w", err)
+	}
+	// an unknown field only fails once executed
+	if err := tmpl.Execute(new(strings.Builder), RegistrationNameData{}); err != nil {
+		return RegistrationTenant{}, fmt.Errorf("invalid registration tenant name template: %w", err)
+	}
+
+	r := RegistrationTenant{NameTemplate: tmpl, Enabled: enabled}
+	if r.Role, err = types.ParseMembershipRole(role); err != nil {
+		return RegistrationTenant{}, fmt.Errorf("invalid registration tenant role: %w", err)
+	}
+	for _, d := range enabledDomains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" {
+			continue
+		}
+		if strings.Contains(d, "@") {
+			return RegistrationTenant{}, fmt.Errorf("invalid registration tenant domain %q", d)
+		}
+		r.EnabledDomains = append(r.EnabledDomains, d)
+	}
+
+	if r.SkipField = strings.TrimSpace(skipField); r.SkipField != "" && slices.Contains(strings.Split(r.SkipField, "."), "") {
+		return RegistrationTenant{}, fmt.Errorf("invalid registration tenant skip field %q", skipField)
+	}
+
+	return r, nil
+}
+
+// name returns the name of the tenant of the registration of email, the
+// tenants of the registrations without email are unnamed. The name is cut
+// to the longest tenant name.
+func (r RegistrationTenant) name(identityID, email string) (string, error) {
+	if email == "" {
+		return "", nil
+	}
+
+	local, domain, _ := strings.Cut(email, "@")
+	var b strings.Builder
+	if err := r.NameTemplate.Execute(&b, RegistrationNameData{
+		IdentityID:     identityID,
+		Email:          email,
+		EmailLocalPart: local,
+		EmailDomain:    domain,
+	}); err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(b.String())
+	if utf8.RuneCountInString(name) > validation.MaxTenantNameLength {
+		name = strings.TrimSpace(string([]rune(name)[:validation.MaxTenantNameLength]))
+	}
+	return name, nil
+}
+
+// enabled tells whether the tenant of the registration of email starts
+// enabled.
+func (r RegistrationTenant) enabled(email string) bool {
+	if r.Enabled {
+		return true
+	}
+	_, domain, ok := strings.Cut(email, "@")
+	return ok && slices.Contains(r.EnabledDomains, strings.ToLower(domain))
+}
+
+// skips tells whether the registration of identity gets no tenant, its skip
+// field holds any value but null, false, "", 0 or an empty array or object.
+func (r RegistrationTenant) skips(identity *KratosIdentity) bool {
+	if r.SkipField == "" {
+		return false
+	}
+
+	var v any = identity.Extra
+	for _, key := range strings.Split(r.SkipField, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		v = m[key]
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// defaultRegistrationTenant provisions disabled tenants named after the
+// email of their owner.
+func defaultRegistrationTenant() RegistrationTenant {
+	return RegistrationTenant{
+		NameTemplate: template.Must(template.New("tenant_name").Parse(DefaultRegistrationTenantName)),
+		Role:         types.RoleOwner,
+	}
+}
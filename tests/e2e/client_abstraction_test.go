@@ -6,6 +6,7 @@ package e2e
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,11 +17,17 @@ import (
 	httpclient "github.com/canonical/tenant-service/client/http"
 	v0 "github.com/canonical/tenant-service/v0"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// errNotFound is wrapped by the errors of the calls rejected as not found,
+// whatever the protocol.
+var errNotFound = errors.New("not found")
+
 var (
 	cachedToken string
 	tokenExpiry time.Time
@@ -106,9 +113,10 @@ type TenantClient interface {
 	// UpdateTenant modifies the tenant with the given ID.
 	UpdateTenant(ctx context.Context, id, name string) error
 
-	// DeleteTenant removes the tenant with the given ID.
-	// Implementations should be idempotent per project conventions.
-	DeleteTenant(ctx context.Context, id string) error
+	// DeleteTenant removes the tenant with the given ID and reports whether it
+	// existed. Deleting a missing tenant succeeds unless strict is set, in
+	// which case the error wraps errNotFound.
+	DeleteTenant(ctx context.Context, id string, strict bool) (bool, error)
 
 	// Close releases any resources held by the client.
 	Close() error
@@ -187,7 +195,7 @@ func (c *HTTPTenantClient) ListTenants(ctx context.Context) ([]Tenant, error) {
 		return nil, err
 	}
 
-	resp, err := c.client.TenantServiceListTenants(ctx, authEditor)
+	resp, err := c.client.TenantServiceListTenants(ctx, nil, authEditor)
 	if err != nil {
 		return nil, err
 	}
@@ -231,6 +239,7 @@ func (c *HTTPTenantClient) UpdateTenant(ctx context.Context, id, name string) er
 			CreatedAt *string `json:"createdAt,omitempty"`
 			Enabled   *bool   `json:"enabled,omitempty"`
 			Name      *string `json:"name,omitempty"`
+			Region    *string `json:"region,omitempty"`
 		}{
 			Name: &name,
 		},
@@ -254,27 +263,42 @@ func (c *HTTPTenantClient) UpdateTenant(ctx context.Context, id, name string) er
 	return nil
 }
 
-func (c *HTTPTenantClient) DeleteTenant(ctx context.Context, id string) error {
+func (c *HTTPTenantClient) DeleteTenant(ctx context.Context, id string, strict bool) (bool, error) {
 	authEditor, err := c.authEditor(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	resp, err := c.client.TenantServiceDeleteTenant(ctx, id, authEditor)
+	params := &httpclient.TenantServiceDeleteTenantParams{}
+	if strict {
+		params.Strict = &strict
+	}
+	resp, err := c.client.TenantServiceDeleteTenant(ctx, id, params, authEditor)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return fmt.Errorf("unexpected status %d (failed to read body: %w)", resp.StatusCode, readErr)
-		}
-		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return nil
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, fmt.Errorf("%w: %s", errNotFound, string(body))
+	default:
+		return false, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var out struct {
+		Existed bool `json:"existed"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return out.Existed, nil
 }
 
 func (c *HTTPTenantClient) Close() error {
@@ -374,16 +398,23 @@ func (c *GRPCTenantClient) UpdateTenant(ctx context.Context, id, name string) er
 	return err
 }
 
-func (c *GRPCTenantClient) DeleteTenant(ctx context.Context, id string) error {
+func (c *GRPCTenantClient) DeleteTenant(ctx context.Context, id string, strict bool) (bool, error) {
 	authCtx, err := c.authContext(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	_, err = c.client.DeleteTenant(authCtx, &v0.DeleteTenantRequest{
+	resp, err := c.client.DeleteTenant(authCtx, &v0.DeleteTenantRequest{
 		TenantId: id,
+		Strict:   strict,
 	})
-	return err
+	if status.Code(err) == codes.NotFound {
+		return false, fmt.Errorf("%w: %v", errNotFound, err)
+	}
+	if err != nil {
+		return false, err
+	}
+	return resp.Existed, nil
 }
 
 func (c *GRPCTenantClient) Close() error {
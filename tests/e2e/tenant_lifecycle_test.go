@@ -5,12 +5,16 @@ package e2e
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 	"time"
 )
 
+// missingTenantID is a valid tenant ID that no test creates.
+const missingTenantID = "0b6e8a52-4c1f-4d7e-9a3b-5f2c8d1e6a40"
+
 // testTenantLifecycle is a common test function that works with any TenantClient
 func testTenantLifecycle(t *testing.T, client TenantClient) {
 	// Add timeout to prevent hanging tests
@@ -39,7 +43,7 @@ func testTenantLifecycle(t *testing.T, client TenantClient) {
 			cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
-			if err := client.DeleteTenant(cleanupCtx, tenantID); err != nil {
+			if _, err := client.DeleteTenant(cleanupCtx, tenantID, false); err != nil {
 				t.Logf("warning: failed to delete tenant %s: %v", tenantID, err)
 			}
 		}
@@ -97,9 +101,13 @@ func testTenantLifecycle(t *testing.T, client TenantClient) {
 
 	// 4. Delete Tenant
 	t.Run("Delete Tenant", func(t *testing.T) {
-		if err := client.DeleteTenant(ctx, tenantID); err != nil {
+		existed, err := client.DeleteTenant(ctx, tenantID, false)
+		if err != nil {
 			t.Fatalf("failed to delete tenant: %v", err)
 		}
+		if !existed {
+			t.Error("expected existed to be true for the created tenant")
+		}
 
 		// Verify deletion by listing
 		tenants, err := client.ListTenants(ctx)
@@ -222,14 +230,20 @@ func TestTenantValidation(t *testing.T) {
 				}
 			})
 
-			t.Run("Delete non-existent tenant should be idempotent", func(t *testing.T) {
-				// Per project conventions, deletes should be idempotent
-				// This should not return an error
-				err := client.DeleteTenant(ctx, "non-existent-id-67890")
-				// Note: If the API returns an error for non-existent deletes,
-				// this test documents that behavior
+			t.Run("Delete non-existent tenant is idempotent", func(t *testing.T) {
+				existed, err := client.DeleteTenant(ctx, missingTenantID, false)
 				if err != nil {
-					t.Logf("Delete of non-existent tenant returned error: %v (documenting current behavior)", err)
+					t.Fatalf("expected delete of non-existent tenant to succeed, got %v", err)
+				}
+				if existed {
+					t.Error("expected existed to be false for a non-existent tenant")
+				}
+			})
+
+			t.Run("Strict delete of non-existent tenant", func(t *testing.T) {
+				_, err := client.DeleteTenant(ctx, missingTenantID, true)
+				if !errors.Is(err, errNotFound) {
+					t.Errorf("expected not found for a strict delete of a non-existent tenant, got %v", err)
 				}
 			})
 		})